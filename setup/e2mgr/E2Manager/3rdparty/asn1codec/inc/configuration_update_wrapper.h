@@ -35,8 +35,10 @@ extern "C"
 #endif
 bool  build_pack_x2enb_configuration_update_ack(size_t* packed_buf_size, unsigned char* packed_buf, size_t err_buf_size, char* err_buf);
 bool  build_pack_x2enb_configuration_update_failure(size_t* packed_buf_size, unsigned char* packed_buf, size_t err_buf_size, char* err_buf);
+bool  build_pack_x2enb_configuration_update_failure_with_cause(enum Cause_PR cause_group, int cause_value, size_t* packed_buf_size, unsigned char* packed_buf, size_t err_buf_size, char* err_buf);
 bool  build_pack_endc_configuration_update_ack(size_t* packed_buf_size, unsigned char* packed_buf, size_t err_buf_size, char* err_buf);
 bool  build_pack_endc_configuration_update_failure(size_t* packed_buf_size, unsigned char* packed_buf, size_t err_buf_size, char* err_buf);
+bool  build_pack_endc_configuration_update_failure_with_cause(enum Cause_PR cause_group, int cause_value, size_t* packed_buf_size, unsigned char* packed_buf, size_t err_buf_size, char* err_buf);
 #ifdef __cplusplus
 }
 #endif
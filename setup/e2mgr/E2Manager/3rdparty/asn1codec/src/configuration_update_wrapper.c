@@ -87,6 +87,23 @@ build_pack_x2enb_configuration_update_failure(
         unsigned char* packed_buf,
         size_t err_buf_size,
 		char* err_buf)
+{
+	return build_pack_x2enb_configuration_update_failure_with_cause(Cause_PR_protocol, CauseProtocol_abstract_syntax_error_reject, packed_buf_size, packed_buf, err_buf_size, err_buf);
+}
+
+/*
+ * Build and pack ENB Configuration Update Failure (unsuccessful outcome message) with the specified cause.
+ * Abort the process on allocation failure.
+ *  packed_buf_size - in: size of packed_buf; out: number of chars used.
+ */
+bool
+build_pack_x2enb_configuration_update_failure_with_cause(
+		enum Cause_PR cause_group,
+		int cause_value,
+		size_t* packed_buf_size,
+        unsigned char* packed_buf,
+        size_t err_buf_size,
+		char* err_buf)
 {
 	bool rc = true;
 	E2AP_PDU_t *pdu = calloc(1, sizeof(E2AP_PDU_t));
@@ -110,8 +127,26 @@ build_pack_x2enb_configuration_update_failure(
     enbConfigurationUpdateFailure_IEs->id = ProtocolIE_ID_id_Cause;
 	enbConfigurationUpdateFailure_IEs->criticality = Criticality_ignore;
 	enbConfigurationUpdateFailure_IEs->value.present = ENBConfigurationUpdateFailure_IEs__value_PR_Cause;
-	enbConfigurationUpdateFailure_IEs->value.choice.Cause.present = Cause_PR_protocol;
-	enbConfigurationUpdateFailure_IEs->value.choice.Cause.choice.protocol= CauseProtocol_abstract_syntax_error_reject;
+	Cause_t *cause = &enbConfigurationUpdateFailure_IEs->value.choice.Cause;
+	cause->present = cause_group;
+	switch (cause->present) {
+	case Cause_PR_radioNetwork:
+		cause->choice.radioNetwork = cause_value;
+		break;
+	case Cause_PR_transport:
+		cause->choice.transport = cause_value;
+		break;
+	case Cause_PR_protocol:
+		cause->choice.protocol = cause_value;
+		break;
+	case Cause_PR_misc:
+		cause->choice.misc = cause_value;
+		break;
+	default:
+		cause->present = Cause_PR_misc;
+		cause->choice.misc = CauseMisc_unspecified;
+		break;
+	}
     ASN_SEQUENCE_ADD(&enbConfigurationUpdateFailure->protocolIEs, enbConfigurationUpdateFailure_IEs);
 
 
@@ -180,6 +215,23 @@ build_pack_endc_configuration_update_failure(
         unsigned char* packed_buf,
         size_t err_buf_size,
 		char* err_buf)
+{
+	return build_pack_endc_configuration_update_failure_with_cause(Cause_PR_protocol, CauseProtocol_abstract_syntax_error_reject, packed_buf_size, packed_buf, err_buf_size, err_buf);
+}
+
+/*
+ * Build and pack ENDC Configuration Update Failure (unsuccessful outcome message) with the specified cause.
+ * Abort the process on allocation failure.
+ *  packed_buf_size - in: size of packed_buf; out: number of chars used.
+ */
+bool
+build_pack_endc_configuration_update_failure_with_cause(
+		enum Cause_PR cause_group,
+		int cause_value,
+		size_t* packed_buf_size,
+        unsigned char* packed_buf,
+        size_t err_buf_size,
+		char* err_buf)
 {
 	bool rc = true;
 	E2AP_PDU_t *pdu = calloc(1, sizeof(E2AP_PDU_t));
@@ -203,8 +255,26 @@ build_pack_endc_configuration_update_failure(
     endcConfigurationUpdateFailure_IEs->id = ProtocolIE_ID_id_Cause;
     endcConfigurationUpdateFailure_IEs->criticality = Criticality_ignore;
     endcConfigurationUpdateFailure_IEs->value.present = ENDCConfigurationUpdateFailure_IEs__value_PR_Cause;
-    endcConfigurationUpdateFailure_IEs->value.choice.Cause.present = Cause_PR_protocol;
-    endcConfigurationUpdateFailure_IEs->value.choice.Cause.choice.protocol= CauseProtocol_abstract_syntax_error_reject;
+	Cause_t *cause = &endcConfigurationUpdateFailure_IEs->value.choice.Cause;
+	cause->present = cause_group;
+	switch (cause->present) {
+	case Cause_PR_radioNetwork:
+		cause->choice.radioNetwork = cause_value;
+		break;
+	case Cause_PR_transport:
+		cause->choice.transport = cause_value;
+		break;
+	case Cause_PR_protocol:
+		cause->choice.protocol = cause_value;
+		break;
+	case Cause_PR_misc:
+		cause->choice.misc = cause_value;
+		break;
+	default:
+		cause->present = Cause_PR_misc;
+		cause->choice.misc = CauseMisc_unspecified;
+		break;
+	}
     ASN_SEQUENCE_ADD(&endcConfigurationUpdateFailure->protocolIEs, endcConfigurationUpdateFailure_IEs);
 
     rc = per_pack_pdu(pdu, packed_buf_size, packed_buf,err_buf_size, err_buf);
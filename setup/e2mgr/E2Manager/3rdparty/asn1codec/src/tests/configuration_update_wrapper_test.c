@@ -28,15 +28,19 @@
 
 void test_build_pack_x2enb_configuration_update_ack();
 void test_build_pack_x2enb_configuration_update_failure();
+void test_build_pack_x2enb_configuration_update_failure_with_cause();
 void test_build_pack_endc_configuration_update_ack();
 void test_build_pack_endc_configuration_update_failure();
+void test_build_pack_endc_configuration_update_failure_with_cause();
 int
 main(int argc, char* argv[])
 {
     test_build_pack_x2enb_configuration_update_ack();
     test_build_pack_x2enb_configuration_update_failure();
+    test_build_pack_x2enb_configuration_update_failure_with_cause();
     test_build_pack_endc_configuration_update_ack();
     test_build_pack_endc_configuration_update_failure();
+    test_build_pack_endc_configuration_update_failure_with_cause();
     exit(0);
 }
 
@@ -72,6 +76,22 @@ void test_build_pack_x2enb_configuration_update_failure(){
     printf("\n");
 }
 
+void test_build_pack_x2enb_configuration_update_failure_with_cause(){
+	size_t error_buf_size = 8192;
+	size_t packed_buf_size = 4096;
+	unsigned char responseDataBuf[packed_buf_size];
+	char responseErrorBuf[error_buf_size];
+	bool result = build_pack_x2enb_configuration_update_failure_with_cause(Cause_PR_misc, CauseMisc_unspecified, &packed_buf_size, responseDataBuf, error_buf_size, responseErrorBuf);
+    if (!result) {
+        printf("#test_build_pack_x2enb_configuration_update_failure_with_cause. Packing error %s\n", responseErrorBuf);
+        return;
+    }
+    printf("x2enb configuration update failure (misc:unspecified) packed size:%lu\nPayload:\n", packed_buf_size);
+    for (size_t i = 0; i < packed_buf_size; ++i)
+        printf("%02x",responseDataBuf[i]);
+    printf("\n");
+}
+
 void test_build_pack_endc_configuration_update_ack(){
     size_t error_buf_size = 8192;
     size_t packed_buf_size = 4096;
@@ -112,3 +132,19 @@ void test_build_pack_endc_configuration_update_failure(){
         printf("%02x",responseDataBuf[i]);
     printf("\n");
 }
+
+void test_build_pack_endc_configuration_update_failure_with_cause(){
+	size_t error_buf_size = 8192;
+	size_t packed_buf_size = 4096;
+	unsigned char responseDataBuf[packed_buf_size];
+	char responseErrorBuf[error_buf_size];
+	bool result = build_pack_endc_configuration_update_failure_with_cause(Cause_PR_misc, CauseMisc_unspecified, &packed_buf_size, responseDataBuf, error_buf_size, responseErrorBuf);
+    if (!result) {
+        printf("#test_build_pack_endc_configuration_update_failure_with_cause. Packing error %s\n", responseErrorBuf);
+        return;
+    }
+    printf("endc configuration update failure (misc:unspecified) packed size:%lu\nPayload:\n", packed_buf_size);
+    for (size_t i = 0; i < packed_buf_size; ++i)
+        printf("%02x",responseDataBuf[i]);
+    printf("\n");
+}
@@ -20,66 +20,94 @@
 package main
 
 import (
-	"e2mgr/clients"
+	"context"
+	"e2mgr/auth"
+	"e2mgr/bootstrap"
 	"e2mgr/configuration"
-	"e2mgr/controllers"
 	"e2mgr/httpserver"
 	"e2mgr/logger"
-	"e2mgr/managers"
-	"e2mgr/managers/notificationmanager"
-	"e2mgr/providers/httpmsghandlerprovider"
-	"e2mgr/providers/rmrmsghandlerprovider"
-	"e2mgr/rNibWriter"
 	"e2mgr/rmrCgo"
-	"e2mgr/services"
-	"e2mgr/services/rmrreceiver"
-	"e2mgr/services/rmrsender"
+	"e2mgr/tracing"
+	"flag"
 	"fmt"
-	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/reader"
 	"gerrit.o-ran-sc.org/r/ric-plt/sdlgo"
 	"os"
 	"strconv"
 )
 
 func main() {
-	config := configuration.ParseConfiguration()
+	checkConfig := flag.Bool("check-config", false, "validate the configuration, including environment overrides, and exit")
+	flag.Parse()
+
+	config, err := configuration.LoadConfiguration()
+	if *checkConfig {
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println("configuration OK")
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Printf("#app.main - %s\n", err)
+		os.Exit(1)
+	}
 	logLevel, _ := logger.LogLevelTokenToLevel(config.Logging.LogLevel)
-	logger, err := logger.InitLogger(logLevel)
+	componentLogLevels := make(map[string]logger.LogLevel)
+	for component, level := range config.Logging.ComponentLogLevels {
+		componentLevel, ok := logger.LogLevelTokenToLevel(level)
+		if !ok {
+			fmt.Printf("#app.main - invalid log level %s of component %s\n", level, component)
+			os.Exit(1)
+		}
+		componentLogLevels[component] = componentLevel
+	}
+	log, err := logger.InitLogger(logLevel)
 	if err != nil {
 		fmt.Printf("#app.main - failed to initialize logger, error: %s", err)
 		os.Exit(1)
 	}
-	logger.Infof("#app.main - Configuration %s", config)
+	for component, level := range componentLogLevels {
+		_ = log.SetComponentLevel(component, level)
+	}
+	log.Infof("#app.main - Configuration %s", config)
+	shutdownTracing, err := tracing.InitTracerProvider(log, config)
+	if err != nil {
+		log.Errorf("#app.main - failed to initialize tracing, error: %s", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
 	db := sdlgo.NewDatabase()
 	sdl := sdlgo.NewSdlInstance("e2Manager", db)
 	defer sdl.Close()
-	rnibDataService := services.NewRnibDataService(logger, config, reader.GetRNibReader(sdl), rNibWriter.GetRNibWriter(sdl))
 	var msgImpl *rmrCgo.Context
-	rmrMessenger := msgImpl.Init("tcp:"+strconv.Itoa(config.Rmr.Port), config.Rmr.MaxMsgSize, 0, logger)
-	rmrSender := rmrsender.NewRmrSender(logger, rmrMessenger)
-	kubernetes := managers.NewKubernetesManager(logger, config)
-	ranSetupManager := managers.NewRanSetupManager(logger, rmrSender, rnibDataService)
-	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, logger)
-	routingManagerClient := clients.NewRoutingManagerClient(logger, config, clients.NewHttpClient())
-	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManager, routingManagerClient)
-	e2tShutdownManager := managers.NewE2TShutdownManager(logger, config, rnibDataService, e2tInstancesManager, e2tAssociationManager, kubernetes)
-	e2tKeepAliveWorker := managers.NewE2TKeepAliveWorker(logger, rmrSender, e2tInstancesManager, e2tShutdownManager, config)
-	rmrNotificationHandlerProvider := rmrmsghandlerprovider.NewNotificationHandlerProvider()
-	rmrNotificationHandlerProvider.Init(logger, config, rnibDataService, rmrSender, ranSetupManager, e2tInstancesManager, routingManagerClient, e2tAssociationManager)
-
-	notificationManager := notificationmanager.NewNotificationManager(logger, rmrNotificationHandlerProvider)
-	rmrReceiver := rmrreceiver.NewRmrReceiver(logger, rmrMessenger, notificationManager)
-
-	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+	rmrMessenger := msgImpl.Init("tcp:"+strconv.Itoa(config.Rmr.Port), config.Rmr.MaxMsgSize, 0, log)
+	e2Manager, err := bootstrap.New(log, config, sdl, rmrMessenger)
+	if err != nil {
+		log.Errorf("#app.main - %s", err)
+		os.Exit(1)
+	}
+	defer e2Manager.Close()
 
-	defer rmrMessenger.Close()
+	go e2Manager.RmrReceiver.ListenAndHandle()
+	go e2Manager.KeepAliveWorker.Execute()
 
-	go rmrReceiver.ListenAndHandle()
-	go e2tKeepAliveWorker.Execute()
+	configWatcher := configuration.NewWatcher(log, config)
+	configWatcher.OnChange(e2Manager.Reload)
+	err = configWatcher.Watch()
+	if err != nil {
+		log.Errorf("#app.main - failed to watch configuration file, error: %s", err)
+	}
 
-	httpMsgHandlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(logger, rmrSender, config, rnibDataService, ranSetupManager, e2tInstancesManager, e2tAssociationManager, routingManagerClient)
-	rootController := controllers.NewRootController(rnibDataService)
-	nodebController := controllers.NewNodebController(logger, httpMsgHandlerProvider)
-	e2tController := controllers.NewE2TController(logger, httpMsgHandlerProvider)
-	_ = httpserver.Run(logger, config.Http.Port, rootController, nodebController, e2tController)
+	tlsConfig, err := httpserver.NewTlsConfig(log, config)
+	if err != nil {
+		log.Errorf("#app.main - failed to initialize tls, error: %s", err)
+		os.Exit(1)
+	}
+	authorizer, err := auth.NewAuthorizerFromConfig(log, config)
+	if err != nil {
+		log.Errorf("#app.main - failed to initialize authorization, error: %s", err)
+		os.Exit(1)
+	}
+	_ = httpserver.Run(log, config.Http.Port, tlsConfig, e2Manager.RootController, e2Manager.NodebController, e2Manager.E2TController, e2Manager.LoggingController, e2Manager.FaultInjectionController, authorizer)
 }
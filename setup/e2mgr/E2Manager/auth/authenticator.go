@@ -0,0 +1,144 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package auth
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// ErrNoCredentials is returned by an Authenticator when the request carries no credentials it handles, so that
+// the next authenticator is tried
+var ErrNoCredentials = fmt.Errorf("no credentials")
+
+type Authenticator interface {
+	Authenticate(request *http.Request) (*Identity, error)
+}
+
+func bearerToken(request *http.Request) (string, bool) {
+	header := request.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return "", false
+	}
+	token := strings.TrimSpace(header[7:])
+	return token, token != ""
+}
+
+type StaticToken struct {
+	Subject string `yaml:"subject"`
+	Role    string `yaml:"role"`
+	Token   string `yaml:"token"`
+}
+
+// StaticTokenAuthenticator accepts a fixed set of bearer tokens. Tokens are kept and looked up by their sha256
+// digest, so the lookup time does not depend on how much of a guessed token matches
+type StaticTokenAuthenticator struct {
+	identities map[[sha256.Size]byte]*Identity
+}
+
+func NewStaticTokenAuthenticator(tokens []StaticToken) (*StaticTokenAuthenticator, error) {
+	identities := make(map[[sha256.Size]byte]*Identity)
+
+	for _, token := range tokens {
+		if token.Token == "" || token.Subject == "" {
+			return nil, fmt.Errorf("static token of subject %q has no token or subject", token.Subject)
+		}
+		role, ok := ParseRole(token.Role)
+		if !ok {
+			return nil, fmt.Errorf("static token of subject %s has unknown role %q", token.Subject, token.Role)
+		}
+		identities[sha256.Sum256([]byte(token.Token))] = &Identity{Subject: token.Subject, Role: role, Method: "token"}
+	}
+
+	return &StaticTokenAuthenticator{identities: identities}, nil
+}
+
+// LoadStaticTokens reads a yaml file of the form
+//   tokens:
+//     - subject: dashboard
+//       role: viewer
+//       token: <secret>
+func LoadStaticTokens(path string) ([]StaticToken, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file %s: %s", path, err)
+	}
+
+	var file struct {
+		Tokens []StaticToken `yaml:"tokens"`
+	}
+	err = yaml.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tokens file %s: %s", path, err)
+	}
+
+	return file.Tokens, nil
+}
+
+func (a *StaticTokenAuthenticator) Authenticate(request *http.Request) (*Identity, error) {
+	token, ok := bearerToken(request)
+	if !ok || strings.Count(token, ".") == 2 {
+		return nil, ErrNoCredentials
+	}
+
+	identity, ok := a.identities[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, fmt.Errorf("unknown bearer token")
+	}
+
+	return identity, nil
+}
+
+// JwtAuthenticator accepts bearer JWTs signed by a key of the JWKS. The caller's role is the highest known role
+// listed in the roles claim
+type JwtAuthenticator struct {
+	verifier *JwtVerifier
+}
+
+func NewJwtAuthenticator(verifier *JwtVerifier) *JwtAuthenticator {
+	return &JwtAuthenticator{verifier: verifier}
+}
+
+func (a *JwtAuthenticator) Authenticate(request *http.Request) (*Identity, error) {
+	token, ok := bearerToken(request)
+	if !ok || strings.Count(token, ".") != 2 {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := a.verifier.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := claims.Subject
+	if subject == "" {
+		subject = "unknown"
+	}
+
+	// A token without a known role still identifies the caller, who is then denied every route
+	role, _ := highestRole(claims.Roles)
+
+	return &Identity{Subject: subject, Role: role, Method: "jwt"}, nil
+}
@@ -0,0 +1,142 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package auth
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type IAuthorizer interface {
+	// Authorize wraps handler so that it only runs for callers holding role
+	Authorize(role Role, handler http.HandlerFunc) http.HandlerFunc
+}
+
+// Authorizer authenticates the bearer credentials of a request with the first authenticator that handles them
+// and enforces the role required by the route. Denied requests are audit logged with the caller identity
+type Authorizer struct {
+	logger         *logger.Logger
+	authenticators []Authenticator
+}
+
+func NewAuthorizer(logger *logger.Logger, authenticators ...Authenticator) *Authorizer {
+	return &Authorizer{
+		logger:         logger,
+		authenticators: authenticators,
+	}
+}
+
+// NewAuthorizerFromConfig builds the authorizer configured in the auth section. When auth is disabled every
+// request is allowed
+func NewAuthorizerFromConfig(logger *logger.Logger, config *configuration.Configuration) (IAuthorizer, error) {
+	if !config.Auth.Enabled {
+		logger.Warnf("#auth.NewAuthorizerFromConfig - authorization is disabled, the northbound API is open to any caller")
+		return AllowAll{}, nil
+	}
+
+	var authenticators []Authenticator
+
+	if config.Auth.TokensFile != "" {
+		tokens, err := LoadStaticTokens(config.Auth.TokensFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticator, err := NewStaticTokenAuthenticator(tokens)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, authenticator)
+	}
+
+	if config.Auth.JwksFile != "" {
+		keys, err := LoadJwks(config.Auth.JwksFile)
+		if err != nil {
+			return nil, err
+		}
+		leeway := time.Duration(config.Auth.JwtLeewaySec) * time.Second
+		verifier := NewJwtVerifier(keys, config.Auth.JwtIssuer, config.Auth.JwtAudience, config.Auth.JwtRolesClaim, leeway)
+		authenticators = append(authenticators, NewJwtAuthenticator(verifier))
+	}
+
+	if len(authenticators) == 0 {
+		return nil, fmt.Errorf("auth is enabled but neither auth.tokensFile nor auth.jwksFile is set")
+	}
+
+	logger.Infof("#auth.NewAuthorizerFromConfig - authorization enabled, static tokens: %t, jwt: %t", config.Auth.TokensFile != "", config.Auth.JwksFile != "")
+	return NewAuthorizer(logger, authenticators...), nil
+}
+
+func (a *Authorizer) Authorize(role Role, handler http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		identity, err := a.authenticate(request)
+
+		if err != nil {
+			a.deny(writer, request, nil, role, err.Error(), e2managererrors.NewUnauthorizedError().BaseError, http.StatusUnauthorized)
+			return
+		}
+
+		if !identity.Role.Includes(role) {
+			a.deny(writer, request, identity, role, "insufficient role", e2managererrors.NewForbiddenError().BaseError, http.StatusForbidden)
+			return
+		}
+
+		a.logger.Debugf("#Authorizer.Authorize - %s %s allowed for %s", request.Method, request.URL.Path, identity)
+		handler(writer, request.WithContext(WithIdentity(request.Context(), identity)))
+	}
+}
+
+func (a *Authorizer) authenticate(request *http.Request) (*Identity, error) {
+	for _, authenticator := range a.authenticators {
+		identity, err := authenticator.Authenticate(request)
+		if err == ErrNoCredentials {
+			continue
+		}
+		return identity, err
+	}
+	return nil, ErrNoCredentials
+}
+
+func (a *Authorizer) deny(writer http.ResponseWriter, request *http.Request, identity *Identity, required Role, reason string, e2Error *e2managererrors.BaseError, httpStatus int) {
+	a.logger.Warnf("#Authorizer.Authorize - AUDIT denied %s %s from %s, caller: %s, required role: %s, reason: %s",
+		request.Method, request.URL.Path, request.RemoteAddr, identity, required, reason)
+
+	errorResponse, _ := json.Marshal(models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message})
+
+	writer.Header().Set("Content-Type", "application/json")
+	if httpStatus == http.StatusUnauthorized {
+		writer.Header().Set("WWW-Authenticate", `Bearer realm="e2mgr"`)
+	}
+	writer.WriteHeader(httpStatus)
+	_, _ = writer.Write(errorResponse)
+}
+
+// AllowAll is the authorizer used when auth is disabled
+type AllowAll struct{}
+
+func (AllowAll) Authorize(_ Role, handler http.HandlerFunc) http.HandlerFunc {
+	return handler
+}
@@ -0,0 +1,184 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package auth
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func initLog(t *testing.T) *logger.Logger {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#initLog test - failed to initialize logger, error: %s", err)
+	}
+	return log
+}
+
+func setupAuthorizer(t *testing.T, keys *testKeys) *Authorizer {
+	staticTokens, err := NewStaticTokenAuthenticator([]StaticToken{
+		{Subject: "dashboard", Role: "viewer", Token: "viewer-token"},
+		{Subject: "ops", Role: "Admin", Token: "admin-token"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create static token authenticator: %s", err)
+	}
+	return NewAuthorizer(initLog(t), staticTokens, NewJwtAuthenticator(initVerifier(t, keys)))
+}
+
+func authorize(authorizer IAuthorizer, role Role, authorization string) (*httptest.ResponseRecorder, *Identity, bool) {
+	var identity *Identity
+	called := false
+	handler := authorizer.Authorize(role, func(writer http.ResponseWriter, request *http.Request) {
+		called = true
+		identity = IdentityFromContext(request.Context())
+	})
+
+	req, _ := http.NewRequest(http.MethodPut, "/v1/nodeb/shutdown", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+	return rr, identity, called
+}
+
+func TestRoleIncludes(t *testing.T) {
+	assert.True(t, RoleAdmin.Includes(RoleOperator))
+	assert.True(t, RoleOperator.Includes(RoleOperator))
+	assert.False(t, RoleViewer.Includes(RoleOperator))
+	assert.False(t, Role("").Includes(RoleViewer))
+}
+
+func TestAuthorizeStaticToken(t *testing.T) {
+	authorizer := setupAuthorizer(t, generateTestKeys(t))
+
+	rr, identity, called := authorize(authorizer, RoleAdmin, "Bearer admin-token")
+	assert.True(t, called)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, &Identity{Subject: "ops", Role: RoleAdmin, Method: "token"}, identity)
+}
+
+func TestAuthorizeJwt(t *testing.T) {
+	keys := generateTestKeys(t)
+	authorizer := setupAuthorizer(t, keys)
+
+	rr, identity, called := authorize(authorizer, RoleOperator, "Bearer "+keys.sign(t, "RS256", "rsa-1", validClaims()))
+	assert.True(t, called)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, &Identity{Subject: "xapp-onboarder", Role: RoleOperator, Method: "jwt"}, identity)
+}
+
+func TestAuthorizeUnauthenticated(t *testing.T) {
+	keys := generateTestKeys(t)
+	authorizer := setupAuthorizer(t, keys)
+	claims := validClaims()
+	claims["iss"] = "https://other"
+
+	for _, authorization := range []string{"", "Basic b3BzOnNlY3JldA==", "Bearer unknown-token", "Bearer " + keys.sign(t, "RS256", "rsa-1", claims)} {
+		rr, _, called := authorize(authorizer, RoleViewer, authorization)
+		assert.False(t, called, authorization)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, authorization)
+		assert.Equal(t, `Bearer realm="e2mgr"`, rr.Header().Get("WWW-Authenticate"))
+
+		var body map[string]interface{}
+		_ = json.Unmarshal(rr.Body.Bytes(), &body)
+		assert.Equal(t, float64(406), body["errorCode"])
+	}
+}
+
+func TestAuthorizeForbidden(t *testing.T) {
+	keys := generateTestKeys(t)
+	authorizer := setupAuthorizer(t, keys)
+	claims := validClaims()
+	claims["roles"] = []string{"xapp"}
+
+	for _, authorization := range []string{"Bearer viewer-token", "Bearer " + keys.sign(t, "ES256", "ec-1", claims)} {
+		rr, _, called := authorize(authorizer, RoleOperator, authorization)
+		assert.False(t, called, authorization)
+		assert.Equal(t, http.StatusForbidden, rr.Code, authorization)
+		assert.Equal(t, `{"errorCode":407,"errorMessage":"Operation not permitted"}`, rr.Body.String())
+	}
+}
+
+func TestAllowAll(t *testing.T) {
+	rr, identity, called := authorize(AllowAll{}, RoleAdmin, "")
+	assert.True(t, called)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, identity)
+}
+
+func TestNewStaticTokenAuthenticatorFailure(t *testing.T) {
+	_, err := NewStaticTokenAuthenticator([]StaticToken{{Subject: "ops", Role: "root", Token: "token"}})
+	assert.NotNil(t, err)
+	_, err = NewStaticTokenAuthenticator([]StaticToken{{Subject: "ops", Role: "admin"}})
+	assert.NotNil(t, err)
+}
+
+func TestNewAuthorizerFromConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "e2mgr-auth")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys := generateTestKeys(t)
+	tokensFile := filepath.Join(dir, "tokens.yaml")
+	jwksFile := filepath.Join(dir, "jwks.json")
+	_ = ioutil.WriteFile(tokensFile, []byte("tokens:\n  - subject: ops\n    role: operator\n    token: operator-token\n"), 0600)
+	_ = ioutil.WriteFile(jwksFile, keys.jwks, 0600)
+
+	config := &configuration.Configuration{}
+	authorizer, err := NewAuthorizerFromConfig(initLog(t), config)
+	assert.Nil(t, err)
+	assert.Equal(t, AllowAll{}, authorizer)
+
+	config.Auth.Enabled = true
+	_, err = NewAuthorizerFromConfig(initLog(t), config)
+	assert.NotNil(t, err)
+
+	config.Auth.TokensFile = filepath.Join(dir, "missing.yaml")
+	_, err = NewAuthorizerFromConfig(initLog(t), config)
+	assert.NotNil(t, err)
+
+	config.Auth.TokensFile = tokensFile
+	config.Auth.JwksFile = jwksFile
+	config.Auth.JwtAudience = "e2mgr"
+	config.Auth.JwtRolesClaim = "roles"
+	authorizer, err = NewAuthorizerFromConfig(initLog(t), config)
+	assert.Nil(t, err)
+
+	_, identity, called := authorize(authorizer, RoleOperator, "Bearer operator-token")
+	assert.True(t, called)
+	assert.Equal(t, "ops", identity.Subject)
+
+	_, identity, called = authorize(authorizer, RoleOperator, "Bearer "+keys.sign(t, "ES256", "ec-1", validClaims()))
+	assert.True(t, called)
+	assert.Equal(t, "xapp-onboarder", identity.Subject)
+}
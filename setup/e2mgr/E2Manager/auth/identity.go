@@ -0,0 +1,94 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+type Role string
+
+// Roles are hierarchical: an operator may do anything a viewer may, and an admin anything an operator may
+const (
+	RoleViewer   Role = "viewer"
+	RoleOperator Role = "operator"
+	RoleAdmin    Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+func ParseRole(name string) (Role, bool) {
+	role := Role(strings.ToLower(name))
+	_, ok := roleRanks[role]
+	return role, ok
+}
+
+// Includes reports whether role grants the permissions of required
+func (r Role) Includes(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// highestRole returns the most privileged known role among names
+func highestRole(names []string) (Role, bool) {
+	var highest Role
+	found := false
+
+	for _, name := range names {
+		role, ok := ParseRole(name)
+		if ok && (!found || role.Includes(highest)) {
+			highest = role
+			found = true
+		}
+	}
+
+	return highest, found
+}
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+	Subject string
+	Role    Role
+	Method  string
+}
+
+func (i *Identity) String() string {
+	if i == nil {
+		return "anonymous"
+	}
+	return i.Subject + " (" + i.Method + ", " + string(i.Role) + ")"
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller of the request, or nil when authorization is disabled
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
@@ -0,0 +1,354 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+)
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// LoadJwks reads the RSA and EC public keys of a JWKS file, by key id
+func LoadJwks(path string) (map[string]crypto.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file %s: %s", path, err)
+	}
+	return ParseJwks(data)
+}
+
+func ParseJwks(data []byte) (map[string]crypto.PublicKey, error) {
+	var set jsonWebKeySet
+	err := json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwks: %s", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwks key %q: %s", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks contains no signing key")
+	}
+
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid base64url value %q", value)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+var jwtHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// JwtClaims are the claims of a verified token. Audience is normalized to a list and Roles holds the values of
+// the configured roles claim
+type JwtClaims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	Roles     []string
+}
+
+// JwtVerifier verifies the signature and the registered claims of RS* and ES* signed tokens
+type JwtVerifier struct {
+	keys       map[string]crypto.PublicKey
+	issuer     string
+	audience   string
+	rolesClaim string
+	leeway     time.Duration
+	now        func() time.Time
+}
+
+func NewJwtVerifier(keys map[string]crypto.PublicKey, issuer string, audience string, rolesClaim string, leeway time.Duration) *JwtVerifier {
+	return &JwtVerifier{
+		keys:       keys,
+		issuer:     issuer,
+		audience:   audience,
+		rolesClaim: rolesClaim,
+		leeway:     leeway,
+		now:        time.Now,
+	}
+}
+
+func (v *JwtVerifier) Verify(token string) (*JwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	var header jwtHeader
+	err := decodeSegment(parts[0], &header)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token header: %s", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature")
+	}
+
+	err = v.verifySignature(header, parts[0]+"."+parts[1], signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	err = decodeSegment(parts[1], &raw)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token claims: %s", err)
+	}
+
+	claims := v.parseClaims(raw)
+	err = v.validateClaims(claims, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *JwtVerifier) verifySignature(header jwtHeader, signed string, signature []byte) error {
+	hash, ok := jwtHashes[header.Alg]
+	if !ok {
+		return fmt.Errorf("unsupported token algorithm %q", header.Alg)
+	}
+
+	key, ok := v.keys[header.Kid]
+	if !ok && header.Kid == "" && len(v.keys) == 1 {
+		for _, onlyKey := range v.keys {
+			key, ok = onlyKey, true
+		}
+	}
+	if !ok {
+		return fmt.Errorf("unknown token key id %q", header.Kid)
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	switch publicKey := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(header.Alg, "RS") {
+			return fmt.Errorf("token algorithm %s does not match the RSA key %q", header.Alg, header.Kid)
+		}
+		if rsa.VerifyPKCS1v15(publicKey, hash, digest, signature) != nil {
+			return fmt.Errorf("invalid token signature")
+		}
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(header.Alg, "ES") {
+			return fmt.Errorf("token algorithm %s does not match the EC key %q", header.Alg, header.Kid)
+		}
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(publicKey, digest, r, s) {
+			return fmt.Errorf("invalid token signature")
+		}
+	default:
+		return fmt.Errorf("unsupported key type for key id %q", header.Kid)
+	}
+
+	return nil
+}
+
+func (v *JwtVerifier) parseClaims(raw map[string]interface{}) *JwtClaims {
+	claims := &JwtClaims{
+		Subject:   stringClaim(raw["sub"]),
+		Issuer:    stringClaim(raw["iss"]),
+		Audience:  stringListClaim(raw["aud"]),
+		ExpiresAt: timeClaim(raw["exp"]),
+		NotBefore: timeClaim(raw["nbf"]),
+	}
+
+	if v.rolesClaim != "" {
+		claims.Roles = stringListClaim(raw[v.rolesClaim])
+	}
+
+	return claims
+}
+
+func (v *JwtVerifier) validateClaims(claims *JwtClaims, raw map[string]interface{}) error {
+	now := v.now()
+
+	if _, ok := raw["exp"]; !ok {
+		return fmt.Errorf("token has no expiration")
+	}
+	if now.After(claims.ExpiresAt.Add(v.leeway)) {
+		return fmt.Errorf("token expired at %s", claims.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if _, ok := raw["nbf"]; ok && now.Add(v.leeway).Before(claims.NotBefore) {
+		return fmt.Errorf("token not valid before %s", claims.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return fmt.Errorf("unexpected token issuer %q", claims.Issuer)
+	}
+	if v.audience != "" && !contains(claims.Audience, v.audience) {
+		return fmt.Errorf("token audience %v does not include %q", claims.Audience, v.audience)
+	}
+
+	return nil
+}
+
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func stringClaim(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// stringListClaim accepts a list of strings or a single, space separated, string such as the OAuth2 scope claim
+func stringListClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	default:
+		return nil
+	}
+}
+
+func timeClaim(value interface{}) time.Time {
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(seconds), 0)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testKeys struct {
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+	jwks   []byte
+}
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func generateTestKeys(t *testing.T) *testKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %s", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ec key: %s", err)
+	}
+
+	jwks, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": encodeBigInt(rsaKey.N), "e": encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encodeBigInt(ecKey.X), "y": encodeBigInt(ecKey.Y)},
+		},
+	})
+
+	return &testKeys{rsaKey: rsaKey, ecKey: ecKey, jwks: jwks}
+}
+
+func (k *testKeys) sign(t *testing.T, alg string, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+	hashed := digest.Sum(nil)
+
+	var signature []byte
+	switch alg {
+	case "RS256":
+		signature, _ = rsa.SignPKCS1v15(rand.Reader, k.rsaKey, crypto.SHA256, hashed)
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, k.ecKey, hashed)
+		if err != nil {
+			t.Fatalf("failed to sign: %s", err)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	default:
+		signature = []byte("signature")
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":   "xapp-onboarder",
+		"iss":   "https://keycloak/realms/ric",
+		"aud":   []string{"e2mgr", "a1"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"viewer", "operator"},
+	}
+}
+
+func initVerifier(t *testing.T, keys *testKeys) *JwtVerifier {
+	jwks, err := ParseJwks(keys.jwks)
+	if err != nil {
+		t.Fatalf("failed to parse jwks: %s", err)
+	}
+	return NewJwtVerifier(jwks, "https://keycloak/realms/ric", "e2mgr", "roles", 30*time.Second)
+}
+
+func TestParseJwksInvalid(t *testing.T) {
+	for _, jwks := range []string{
+		`not json`,
+		`{"keys":[]}`,
+		`{"keys":[{"kty":"oct","kid":"hmac","k":"c2VjcmV0"}]}`,
+		`{"keys":[{"kty":"EC","kid":"ec","crv":"P-256","x":"AQ","y":"AQ"}]}`,
+		`{"keys":[{"kty":"RSA","kid":"rsa","n":"","e":"AQAB"}]}`,
+	} {
+		_, err := ParseJwks([]byte(jwks))
+		assert.NotNil(t, err, jwks)
+	}
+}
+
+func TestParseJwksSkipsEncryptionKeys(t *testing.T) {
+	keys := generateTestKeys(t)
+	jwks := strings.Replace(string(keys.jwks), `"use":"sig"`, `"use":"enc"`, 1)
+
+	parsed, err := ParseJwks([]byte(jwks))
+	assert.Nil(t, err)
+	assert.Len(t, parsed, 1)
+	assert.Contains(t, parsed, "ec-1")
+}
+
+func TestVerifyRs256Success(t *testing.T) {
+	keys := generateTestKeys(t)
+	verifier := initVerifier(t, keys)
+
+	claims, err := verifier.Verify(keys.sign(t, "RS256", "rsa-1", validClaims()))
+	assert.Nil(t, err)
+	assert.Equal(t, "xapp-onboarder", claims.Subject)
+	assert.Equal(t, []string{"e2mgr", "a1"}, claims.Audience)
+	assert.Equal(t, []string{"viewer", "operator"}, claims.Roles)
+}
+
+func TestVerifyEs256Success(t *testing.T) {
+	keys := generateTestKeys(t)
+	verifier := initVerifier(t, keys)
+	claims := validClaims()
+	claims["aud"] = "e2mgr"
+	claims["roles"] = "admin viewer"
+
+	verified, err := verifier.Verify(keys.sign(t, "ES256", "ec-1", claims))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"admin", "viewer"}, verified.Roles)
+}
+
+func TestVerifyFailure(t *testing.T) {
+	keys := generateTestKeys(t)
+	verifier := initVerifier(t, keys)
+
+	claimsWith := func(key string, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tamperedParts := strings.Split(keys.sign(t, "RS256", "rsa-1", validClaims()), ".")
+	payload, _ := json.Marshal(claimsWith("roles", []string{"admin"}))
+	tampered := tamperedParts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + tamperedParts[2]
+
+	for name, token := range map[string]string{
+		"malformed":          "a.b",
+		"none algorithm":     keys.sign(t, "none", "rsa-1", validClaims()),
+		"hmac algorithm":     keys.sign(t, "HS256", "rsa-1", validClaims()),
+		"unknown key":        keys.sign(t, "RS256", "rsa-2", validClaims()),
+		"algorithm mismatch": keys.sign(t, "RS256", "ec-1", validClaims()),
+		"tampered claims":    tampered,
+		"expired":            keys.sign(t, "RS256", "rsa-1", claimsWith("exp", time.Now().Add(-time.Minute).Unix())),
+		"no expiration":      keys.sign(t, "RS256", "rsa-1", claimsWith("exp", nil)),
+		"not yet valid":      keys.sign(t, "RS256", "rsa-1", claimsWith("nbf", time.Now().Add(time.Minute).Unix())),
+		"wrong issuer":       keys.sign(t, "RS256", "rsa-1", claimsWith("iss", "https://other")),
+		"wrong audience":     keys.sign(t, "RS256", "rsa-1", claimsWith("aud", "a1")),
+	} {
+		claims, err := verifier.Verify(token)
+		assert.NotNil(t, err, name)
+		assert.Nil(t, claims, name)
+	}
+}
+
+func TestVerifyLeeway(t *testing.T) {
+	keys := generateTestKeys(t)
+	verifier := initVerifier(t, keys)
+	claims := validClaims()
+	claims["exp"] = time.Now().Add(-10 * time.Second).Unix()
+
+	_, err := verifier.Verify(keys.sign(t, "RS256", "rsa-1", claims))
+	assert.Nil(t, err)
+
+	verifier.now = func() time.Time { return time.Now().Add(time.Minute) }
+	_, err = verifier.Verify(keys.sign(t, "RS256", "rsa-1", claims))
+	assert.Equal(t, fmt.Sprintf("token expired at %s", time.Unix(claims["exp"].(int64), 0).UTC().Format(time.RFC3339)), err.Error())
+}
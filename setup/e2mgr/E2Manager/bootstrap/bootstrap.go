@@ -0,0 +1,153 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+// Package bootstrap composes the E2 Manager components. app/main.go runs the composition on SDL and the RMR library,
+// the integration harness on an in-memory SDL and RMR bus, so both exercise the same component graph
+package bootstrap
+
+import (
+	"context"
+	"e2mgr/clients"
+	"e2mgr/configuration"
+	"e2mgr/controllers"
+	"e2mgr/healthcheck"
+	"e2mgr/logger"
+	"e2mgr/managers"
+	"e2mgr/managers/notificationmanager"
+	"e2mgr/providers/httpmsghandlerprovider"
+	"e2mgr/providers/rmrmsghandlerprovider"
+	"e2mgr/rNibWriter"
+	"e2mgr/rmrcapture"
+	"e2mgr/rmrtypes"
+	"e2mgr/rnibfaults"
+	"e2mgr/services"
+	"e2mgr/services/rmrreceiver"
+	"e2mgr/services/rmrsender"
+	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/reader"
+	"time"
+)
+
+type retryPolicy interface {
+	SetRetryPolicy(maxAttempts int, retryInterval time.Duration)
+}
+
+// E2Manager is the composed component graph. The caller starts RmrReceiver and KeepAliveWorker and serves the
+// controllers
+type E2Manager struct {
+	RnibDataService          services.RNibDataService
+	RmrMessenger             rmrtypes.RmrMessenger
+	Capture                  *rmrcapture.Writer
+	Faults                   *rnibfaults.Injector
+	KeepAliveWorker          managers.E2TKeepAliveWorker
+	NotificationManager      *notificationmanager.NotificationManager
+	RmrReceiver              *rmrreceiver.RmrReceiver
+	RootController           controllers.IRootController
+	NodebController          controllers.INodebController
+	E2TController            controllers.IE2TController
+	LoggingController        controllers.ILoggingController
+	FaultInjectionController controllers.IFaultInjectionController
+	logger                   *logger.Logger
+	retryPolicy              retryPolicy
+}
+
+// New composes the E2 Manager on sdl and rmrMessenger, wrapping them for fault injection and RMR capture when config
+// enables those
+func New(logger *logger.Logger, config *configuration.Configuration, sdl common.ISdlInstance, rmrMessenger rmrtypes.RmrMessenger) (*E2Manager, error) {
+	var err error
+	e := &E2Manager{logger: logger}
+
+	if config.FaultInjection.Enabled || config.FaultInjection.DebugEndpoint {
+		e.Faults, err = rnibfaults.NewInjector(logger, config)
+		if err != nil {
+			return nil, fmt.Errorf("#bootstrap.New - failed to initialize fault injection, error: %s", err)
+		}
+		logger.Warnf("#bootstrap.New - rNib fault injection is available, enabled: %t", config.FaultInjection.Enabled)
+		sdl = rnibfaults.NewSdl(e.Faults, sdl)
+	}
+	rnibService := services.NewRnibDataService(logger, config, reader.GetRNibReader(sdl), rNibWriter.GetRNibWriter(sdl))
+	e.retryPolicy = rnibService
+	e.RnibDataService = rnibService
+	if e.Faults != nil {
+		e.RnibDataService = rnibfaults.NewDataService(e.Faults, rnibService)
+	}
+
+	routingManagerHttpClient, err := clients.NewRoutingManagerHttpClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("#bootstrap.New - failed to initialize routing manager client, error: %s", err)
+	}
+
+	if config.Rmr.Capture.Enabled {
+		e.Capture, err = rmrcapture.CreateFile(config.Rmr.Capture.File)
+		if err != nil {
+			return nil, fmt.Errorf("#bootstrap.New - failed to start RMR capture, error: %s", err)
+		}
+		logger.Infof("#bootstrap.New - capturing RMR messages to %s", config.Rmr.Capture.File)
+		rmrMessenger = rmrcapture.NewMessenger(logger, rmrMessenger, e.Capture)
+	}
+	e.RmrMessenger = rmrMessenger
+
+	rmrSender := rmrsender.NewRmrSender(logger, rmrMessenger)
+	kubernetes := managers.NewKubernetesManager(logger, config)
+	ranSetupManager := managers.NewRanSetupManager(logger, rmrSender, e.RnibDataService)
+	e2tInstancesManager := managers.NewE2TInstancesManager(e.RnibDataService, logger)
+	routingManagerClient := clients.NewRoutingManagerClient(logger, config, routingManagerHttpClient)
+	e2tAssociationManager := managers.NewE2TAssociationManager(logger, e.RnibDataService, e2tInstancesManager, routingManagerClient)
+	e2tShutdownManager := managers.NewE2TShutdownManager(logger, config, e.RnibDataService, e2tInstancesManager, e2tAssociationManager, kubernetes)
+	e.KeepAliveWorker = managers.NewE2TKeepAliveWorker(logger, rmrSender, e2tInstancesManager, e2tShutdownManager, config)
+	rmrNotificationHandlerProvider := rmrmsghandlerprovider.NewNotificationHandlerProvider()
+	rmrNotificationHandlerProvider.Init(logger, config, e.RnibDataService, rmrSender, ranSetupManager, e2tInstancesManager, routingManagerClient, e2tAssociationManager)
+
+	e.NotificationManager = notificationmanager.NewNotificationManager(logger, rmrNotificationHandlerProvider)
+	e.RmrReceiver = rmrreceiver.NewRmrReceiver(logger, rmrMessenger, e.NotificationManager)
+
+	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances(context.Background())
+
+	httpMsgHandlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(logger, rmrSender, config, e.RnibDataService, ranSetupManager, e2tInstancesManager, e2tAssociationManager, routingManagerClient)
+	healthChecker := healthcheck.NewE2ManagerHealthChecker(logger, config, e.RnibDataService, rmrMessenger, e.KeepAliveWorker, e.NotificationManager)
+	e.RootController = controllers.NewRootController(e.RnibDataService, healthChecker)
+	e.NodebController = controllers.NewNodebController(logger, httpMsgHandlerProvider)
+	e.E2TController = controllers.NewE2TController(logger, httpMsgHandlerProvider)
+	e.LoggingController = controllers.NewLoggingController(logger, config)
+	if config.FaultInjection.DebugEndpoint {
+		e.FaultInjectionController = controllers.NewFaultInjectionController(logger, e.Faults)
+	}
+
+	return e, nil
+}
+
+// Reload applies a reloaded configuration to the components that support it
+func (e *E2Manager) Reload(config *configuration.Configuration, changes []configuration.Change) {
+	if configuration.HasChanged(changes, configuration.LogLevelKey) {
+		level, _ := logger.LogLevelTokenToLevel(config.Logging.LogLevel)
+		_ = e.logger.SetLevel(level)
+	}
+	e.retryPolicy.SetRetryPolicy(config.MaxRnibConnectionAttempts, time.Duration(config.RnibRetryIntervalMs)*time.Millisecond)
+	e.KeepAliveWorker.SetTimings(config.KeepAliveDelayMs, config.KeepAliveResponseTimeoutMs)
+}
+
+// Close closes the RMR messenger and the capture file
+func (e *E2Manager) Close() {
+	e.RmrMessenger.Close()
+	if e.Capture != nil {
+		_ = e.Capture.Close()
+	}
+}
@@ -0,0 +1,78 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package bootstrap_test
+
+import (
+	"e2mgr/bootstrap"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/rmrmemory"
+	"e2mgr/tests/integration"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func initE2Manager(t *testing.T, config *configuration.Configuration) (*bootstrap.E2Manager, *logger.Logger, error) {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Fatalf("#bootstrap_test.initE2Manager - failed to initialize logger, error: %s", err)
+	}
+	messenger, err := rmrmemory.NewMessenger(rmrmemory.NewBus(), integration.E2ManagerEndpoint, config.Rmr.MaxMsgSize, log)
+	if err != nil {
+		t.Fatalf("#bootstrap_test.initE2Manager - failed to attach messenger, error: %s", err)
+	}
+	e2Manager, err := bootstrap.New(log, config, integration.NewMemorySdl(), messenger.Init("", config.Rmr.MaxMsgSize, 0, log))
+	return e2Manager, log, err
+}
+
+func TestNewSuccess(t *testing.T) {
+	e2Manager, _, err := initE2Manager(t, integration.DefaultConfiguration())
+	assert.Nil(t, err)
+	defer e2Manager.Close()
+	assert.NotNil(t, e2Manager.RmrReceiver)
+	assert.NotNil(t, e2Manager.RootController)
+	assert.Nil(t, e2Manager.Capture)
+	assert.Nil(t, e2Manager.Faults)
+	assert.Nil(t, e2Manager.FaultInjectionController)
+}
+
+func TestNewCaptureFailure(t *testing.T) {
+	config := integration.DefaultConfiguration()
+	config.Rmr.Capture.Enabled = true
+	config.Rmr.Capture.File = filepath.Join(t.TempDir(), "missing", "rmr.capture")
+
+	e2Manager, _, err := initE2Manager(t, config)
+	assert.Nil(t, e2Manager)
+	assert.Contains(t, err.Error(), "failed to start RMR capture")
+}
+
+func TestReloadSetsLogLevel(t *testing.T) {
+	config := integration.DefaultConfiguration()
+	e2Manager, log, err := initE2Manager(t, config)
+	assert.Nil(t, err)
+	defer e2Manager.Close()
+	assert.False(t, log.DebugEnabled())
+
+	config.Logging.LogLevel = "debug"
+	e2Manager.Reload(config, []configuration.Change{{Key: configuration.LogLevelKey, Previous: "info", Current: "debug"}})
+	assert.True(t, log.DebugEnabled())
+}
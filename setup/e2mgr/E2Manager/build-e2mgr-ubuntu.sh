@@ -21,7 +21,7 @@
 # Prerequisites:
 #   Debian distro; e.g., Ubuntu
 #   NNG shared library
-#   golang (go); tested with version 1.12
+#   golang (go); tested with version 1.22
 #   current working directory is E2Manager
 #   running with sudo privs, which is default in Docker
 
@@ -50,7 +50,7 @@ export LD_LIBRARY_PATH=/usr/local/lib
 export PATH=$PATH:/usr/local/go/bin:$HOME/go/bin
 
 # install the go coverage tool helper
-go get -v github.com/ory/go-acc
+go install github.com/ory/go-acc@v0.2.8
 
 cd 3rdparty/asn1codec \
     && make clobber \
@@ -60,7 +60,7 @@ cd 3rdparty/asn1codec \
 go build -v app/main.go
 
 # Execute UT and measure coverage
-# cgocheck=2 enables expensive checks that should not miss any errors,
+# GOEXPERIMENT=cgocheck2 enables expensive checks that should not miss any errors,
 # but will cause your program to run slower.
 # clobberfree=1 causes the garbage collector to clobber the memory content
 # of an object with bad content when it frees the object.
@@ -70,7 +70,8 @@ go build -v app/main.go
 # garbage collection finishes.
 # Setting allocfreetrace=1 causes every allocation to be profiled and a
 # stack trace printed on each object's allocation and free.
-export GODEBUG=cgocheck=2,clobberfree=1,gcstoptheworld=2,allocfreetrace=0
+export GOEXPERIMENT=cgocheck2
+export GODEBUG=clobberfree=1,gcstoptheworld=2,allocfreetrace=0
 # Static route table is provided in git repo
 export RMR_SEED_RT=$(pwd)/router_test.txt
 # writes to coverage.txt by default
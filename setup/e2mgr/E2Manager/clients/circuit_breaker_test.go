@@ -0,0 +1,72 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package clients
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	breaker := NewCircuitBreaker(0, time.Second)
+	for i := 0; i < 10; i++ {
+		assert.False(t, breaker.Failure())
+	}
+	assert.Nil(t, breaker.Allow())
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	breaker := NewCircuitBreaker(3, time.Second)
+
+	assert.False(t, breaker.Failure())
+	assert.False(t, breaker.Failure())
+	breaker.Success()
+	assert.False(t, breaker.Failure())
+	assert.False(t, breaker.Failure())
+	assert.True(t, breaker.Failure())
+
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.Equal(t, ErrCircuitOpen, breaker.Allow())
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(1, time.Second)
+	breaker.now = func() time.Time { return now }
+	breaker.Failure()
+
+	now = now.Add(time.Second)
+	assert.Nil(t, breaker.Allow())
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	assert.Equal(t, ErrCircuitOpen, breaker.Allow(), "only one probe at a time")
+
+	assert.True(t, breaker.Failure())
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.Equal(t, ErrCircuitOpen, breaker.Allow())
+
+	now = now.Add(time.Second)
+	assert.Nil(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, CircuitClosed, breaker.State())
+	assert.Equal(t, "closed", breaker.State().String())
+}
@@ -20,13 +20,21 @@
 package clients
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"e2mgr/configuration"
+	"e2mgr/tracing"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 type IHttpClient interface {
-	Post(url, contentType string, body io.Reader) (resp *http.Response, err error)
-	Delete(url, contentType string, body io.Reader) (resp *http.Response, err error)
+	Post(ctx context.Context, url, contentType string, body io.Reader) (resp *http.Response, err error)
+	Delete(ctx context.Context, url, contentType string, body io.Reader) (resp *http.Response, err error)
 }
 
 type HttpClient struct {
@@ -39,9 +47,86 @@ func NewHttpClient() *HttpClient {
 	}
 }
 
-func (c *HttpClient) Delete(url, contentType string, body io.Reader) (resp *http.Response, err error) {
-	req, _ := http.NewRequest(http.MethodDelete, url, body)
+// NewRoutingManagerHttpClient returns a client configured by the routingManager tls and authTokenFile settings.
+// Timeouts are applied per call by the RoutingManagerClient
+func NewRoutingManagerHttpClient(config *configuration.Configuration) (*HttpClient, error) {
+	tlsSettings := config.RoutingManager.Tls
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if tlsSettings.CaFile != "" || tlsSettings.CertFile != "" || tlsSettings.InsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: tlsSettings.InsecureSkipVerify,
+		}
+
+		if tlsSettings.CaFile != "" {
+			pem, err := ioutil.ReadFile(tlsSettings.CaFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read routing manager CA bundle %s: %s", tlsSettings.CaFile, err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in routing manager CA bundle %s", tlsSettings.CaFile)
+			}
+		}
+
+		if tlsSettings.CertFile != "" {
+			certificate, err := tls.LoadX509KeyPair(tlsSettings.CertFile, tlsSettings.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load routing manager client certificate %s: %s", tlsSettings.CertFile, err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	var roundTripper http.RoundTripper = transport
+
+	if config.RoutingManager.AuthTokenFile != "" {
+		roundTripper = &bearerTokenTransport{tokenFile: config.RoutingManager.AuthTokenFile, next: transport}
+	}
+
+	return &HttpClient{
+		&http.Client{Transport: roundTripper},
+	}, nil
+}
+
+// bearerTokenTransport sets the Authorization header from a token file, read on every request so that a rotated
+// token, such as a projected service account token, is picked up
+type bearerTokenTransport struct {
+	tokenFile string
+	next      http.RoundTripper
+}
+
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := ioutil.ReadFile(t.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auth token file %s: %s", t.tokenFile, err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	return t.next.RoundTrip(req)
+}
+
+func (c *HttpClient) Post(ctx context.Context, url, contentType string, body io.Reader) (resp *http.Response, err error) {
+	return c.send(ctx, http.MethodPost, url, contentType, body)
+}
+
+func (c *HttpClient) Delete(ctx context.Context, url, contentType string, body io.Reader) (resp *http.Response, err error) {
+	return c.send(ctx, http.MethodDelete, url, contentType, body)
+}
+
+func (c *HttpClient) send(ctx context.Context, method, url, contentType string, body io.Reader) (resp *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+
+	if err != nil {
+		return nil, err
+	}
+
 	req.Header.Set("Content-Type", contentType)
+	tracing.InjectHeaders(ctx, req.Header)
 	return c.Do(req)
 }
 
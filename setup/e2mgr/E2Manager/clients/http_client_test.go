@@ -0,0 +1,137 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/tracing"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHttpClientPropagatesTraceContext(t *testing.T) {
+	tp, err := tracing.NewStdoutTracerProvider(&bytes.Buffer{})
+	assert.Nil(t, err)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		received = request.Header
+		writer.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	ctx, span := tracing.StartSpan(context.Background(), "RoutingManagerClient POST", trace.SpanKindClient)
+	defer span.End()
+
+	resp, err := NewHttpClient().Post(ctx, server.URL, "application/json", bytes.NewBufferString("{}"))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "application/json", received.Get("Content-Type"))
+	assert.Contains(t, received.Get("traceparent"), span.SpanContext().TraceID().String())
+}
+
+func TestHttpClientDelete(t *testing.T) {
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		method = request.Method
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := NewHttpClient().Delete(context.Background(), server.URL, "application/json", bytes.NewBufferString("{}"))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Empty(t, resp.Header.Get("traceparent"))
+}
+
+func TestRoutingManagerHttpClientTlsAndAuthToken(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		authorization = request.Header.Get("Authorization")
+		writer.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "e2mgr-rm-client")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "ca.crt")
+	tokenFile := filepath.Join(dir, "token")
+	_ = ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)
+	_ = ioutil.WriteFile(tokenFile, []byte("first-token\n"), 0600)
+
+	config := &configuration.Configuration{}
+	config.RoutingManager.Tls.CaFile = caFile
+	config.RoutingManager.AuthTokenFile = tokenFile
+	httpClient, err := NewRoutingManagerHttpClient(config)
+	assert.Nil(t, err)
+
+	resp, err := httpClient.Post(context.Background(), server.URL, "application/json", bytes.NewBufferString("{}"))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "Bearer first-token", authorization)
+
+	_ = ioutil.WriteFile(tokenFile, []byte("rotated-token"), 0600)
+	resp, err = httpClient.Delete(context.Background(), server.URL, "application/json", bytes.NewBufferString("{}"))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "Bearer rotated-token", authorization)
+}
+
+func TestRoutingManagerHttpClientUntrustedServer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	httpClient, err := NewRoutingManagerHttpClient(&configuration.Configuration{})
+	assert.Nil(t, err)
+
+	_, err = httpClient.Post(context.Background(), server.URL, "application/json", bytes.NewBufferString("{}"))
+	assert.NotNil(t, err)
+}
+
+func TestRoutingManagerHttpClientInvalidCaFile(t *testing.T) {
+	config := &configuration.Configuration{}
+	config.RoutingManager.Tls.CaFile = "/nonexistent/ca.crt"
+
+	_, err := NewRoutingManagerHttpClient(config)
+	assert.NotNil(t, err)
+}
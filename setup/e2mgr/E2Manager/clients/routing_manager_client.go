@@ -21,12 +21,22 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/models"
+	"e2mgr/tracing"
 	"encoding/json"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"io/ioutil"
+	"math/rand"
 	"net/http"
+	"time"
 )
 
 const (
@@ -34,20 +44,24 @@ const (
 	AssociateRanToE2TInstanceApiSuffix = "associate-ran-to-e2t"
 	DissociateRanE2TInstanceApiSuffix  = "dissociate-ran"
 	DeleteE2TInstanceApiSuffix         = "e2t"
+	maxErrorResponseBodySize           = 4096
 )
 
 type RoutingManagerClient struct {
 	logger     *logger.Logger
 	config     *configuration.Configuration
 	httpClient IHttpClient
+	breaker    *CircuitBreaker
+	sleep      func(time.Duration)
+	jitter     func(n int64) int64
 }
 
 type IRoutingManagerClient interface {
-	AddE2TInstance(e2tAddress string) error
-	AssociateRanToE2TInstance(e2tAddress string, ranName string) error
-	DissociateRanE2TInstance(e2tAddress string, ranName string) error
-	DissociateAllRans(e2tAddresses []string) error
-	DeleteE2TInstance(e2tAddress string, ransToBeDissociated []string) error
+	AddE2TInstance(ctx context.Context, e2tAddress string) error
+	AssociateRanToE2TInstance(ctx context.Context, e2tAddress string, ranName string) error
+	DissociateRanE2TInstance(ctx context.Context, e2tAddress string, ranName string) error
+	DissociateAllRans(ctx context.Context, e2tAddresses []string) error
+	DeleteE2TInstance(ctx context.Context, e2tAddress string, ransToBeDissociated []string) error
 }
 
 func NewRoutingManagerClient(logger *logger.Logger, config *configuration.Configuration, httpClient IHttpClient) *RoutingManagerClient {
@@ -55,87 +69,186 @@ func NewRoutingManagerClient(logger *logger.Logger, config *configuration.Config
 		logger:     logger,
 		config:     config,
 		httpClient: httpClient,
+		breaker: NewCircuitBreaker(config.RoutingManager.CircuitBreaker.FailureThreshold,
+			time.Duration(config.RoutingManager.CircuitBreaker.OpenDurationMs)*time.Millisecond),
+		sleep:  time.Sleep,
+		jitter: rand.Int63n,
 	}
 }
 
-func (c *RoutingManagerClient) AddE2TInstance(e2tAddress string) error {
+func (c *RoutingManagerClient) CircuitState() CircuitState {
+	return c.breaker.State()
+}
+
+func (c *RoutingManagerClient) AddE2TInstance(ctx context.Context, e2tAddress string) error {
 
 	data := models.NewRoutingManagerE2TData(e2tAddress)
 	url := c.config.RoutingManager.BaseUrl + AddE2TInstanceApiSuffix
 
-	return c.PostMessage(url, data)
+	return c.PostMessage(ctx, url, data)
 }
 
-func (c *RoutingManagerClient) AssociateRanToE2TInstance(e2tAddress string, ranName string) error {
+func (c *RoutingManagerClient) AssociateRanToE2TInstance(ctx context.Context, e2tAddress string, ranName string) error {
 
 	data := models.RoutingManagerE2TDataList{models.NewRoutingManagerE2TData(e2tAddress, ranName)}
 	url := c.config.RoutingManager.BaseUrl + AssociateRanToE2TInstanceApiSuffix
 
-	return c.PostMessage(url, data)
+	return c.sendMessage(ctx, http.MethodPost, url, data, true)
 }
 
-func (c *RoutingManagerClient) DissociateRanE2TInstance(e2tAddress string, ranName string) error {
+func (c *RoutingManagerClient) DissociateRanE2TInstance(ctx context.Context, e2tAddress string, ranName string) error {
 
 	data := models.RoutingManagerE2TDataList{models.NewRoutingManagerE2TData(e2tAddress, ranName)}
 	url := c.config.RoutingManager.BaseUrl + DissociateRanE2TInstanceApiSuffix
 
-	return c.PostMessage(url, data)
+	return c.sendMessage(ctx, http.MethodPost, url, data, true)
 }
 
-func (c *RoutingManagerClient) DissociateAllRans(e2tAddresses []string) error {
+func (c *RoutingManagerClient) DissociateAllRans(ctx context.Context, e2tAddresses []string) error {
 
 	data := mapE2TAddressesToE2DataList(e2tAddresses)
 	url := c.config.RoutingManager.BaseUrl + DissociateRanE2TInstanceApiSuffix
 
-	return c.PostMessage(url, data)
+	return c.sendMessage(ctx, http.MethodPost, url, data, true)
 }
 
-func (c *RoutingManagerClient) DeleteE2TInstance(e2tAddress string, ransTobeDissociated []string) error {
+func (c *RoutingManagerClient) DeleteE2TInstance(ctx context.Context, e2tAddress string, ransTobeDissociated []string) error {
 	data := models.NewRoutingManagerDeleteRequestModel(e2tAddress, ransTobeDissociated, nil)
 	url := c.config.RoutingManager.BaseUrl + DeleteE2TInstanceApiSuffix
-	return c.DeleteMessage(url, data)
+	return c.DeleteMessage(ctx, url, data)
 }
 
-func (c *RoutingManagerClient) sendMessage(method string, url string, data interface{}) error {
+// sendMessage calls the Routing Manager, retrying idempotent calls on transport errors and transient (5xx, 429)
+// responses. Every attempt is bounded by routingManager.timeoutMs and no attempt is made while the circuit is open
+func (c *RoutingManagerClient) sendMessage(ctx context.Context, method string, url string, data interface{}, idempotent bool) error {
 	marshaled, err := json.Marshal(data)
 
 	if err != nil {
-		return e2managererrors.NewRoutingManagerError()
+		return e2managererrors.NewRoutingManagerRequestError(err)
+	}
+
+	c.logger.Infof("[E2 Manager -> Routing Manager] #RoutingManagerClient.sendMessage - %s url: %s, request body: %s", method, url, marshaled)
+
+	ctx, span := tracing.StartSpan(ctx, "RoutingManagerClient "+method, trace.SpanKindClient,
+		semconv.HTTPRequestMethodKey.String(method), semconv.URLFull(url))
+	defer span.End()
+
+	err = c.breaker.Allow()
+
+	if err != nil {
+		c.logger.Errorf("#RoutingManagerClient.sendMessage - %s url: %s not sent, routing manager circuit breaker is open", method, url)
+		span.SetStatus(codes.Error, err.Error())
+		return e2managererrors.NewRoutingManagerRequestError(err)
+	}
+
+	attempts := 1
+	if idempotent {
+		attempts += c.config.RoutingManager.MaxRetries
+	}
+
+	for attempt := 1; ; attempt++ {
+		rmError := c.attempt(ctx, method, url, marshaled)
+		span.SetAttributes(attribute.Int("e2mgr.rm.attempts", attempt))
+
+		if rmError == nil {
+			c.breaker.Success()
+			return nil
+		}
+
+		span.RecordError(rmError)
+
+		if !isTransient(rmError) {
+			// The Routing Manager answered, so it is alive
+			c.breaker.Success()
+			span.SetStatus(codes.Error, rmError.Error())
+			return rmError
+		}
+
+		if attempt >= attempts {
+			if c.breaker.Failure() {
+				c.logger.Errorf("#RoutingManagerClient.sendMessage - routing manager circuit breaker opened")
+			}
+			span.SetStatus(codes.Error, rmError.Error())
+			return rmError
+		}
+
+		backoff := c.backoff(attempt)
+		c.logger.Warnf("#RoutingManagerClient.sendMessage - attempt %d of %d failed, retrying in %s. error: %s", attempt, attempts, backoff, rmError)
+		c.sleep(backoff)
+	}
+}
+
+func (c *RoutingManagerClient) attempt(ctx context.Context, method string, url string, marshaled []byte) *e2managererrors.RoutingManagerError {
+	if c.config.RoutingManager.TimeoutMs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(c.config.RoutingManager.TimeoutMs)*time.Millisecond)
+		defer cancel()
 	}
 
 	body := bytes.NewBuffer(marshaled)
-	c.logger.Infof("[E2 Manager -> Routing Manager] #RoutingManagerClient.sendMessage - %s url: %s, request body: %+v", method, url, body)
 
 	var resp *http.Response
+	var err error
 
 	if method == http.MethodPost {
-		resp, err = c.httpClient.Post(url, "application/json", body)
+		resp, err = c.httpClient.Post(ctx, url, "application/json", body)
 	} else if method == http.MethodDelete {
-		resp, err = c.httpClient.Delete(url, "application/json", body)
+		resp, err = c.httpClient.Delete(ctx, url, "application/json", body)
 	}
 
 	if err != nil {
 		c.logger.Errorf("#RoutingManagerClient.sendMessage - failed sending request. error: %s", err)
-		return e2managererrors.NewRoutingManagerError()
+		return e2managererrors.NewRoutingManagerRequestError(err)
 	}
 
 	defer resp.Body.Close()
+	trace.SpanFromContext(ctx).SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 
 	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
 		c.logger.Infof("[Routing Manager -> E2 Manager] #RoutingManagerClient.sendMessage - success. http status code: %d", resp.StatusCode)
 		return nil
 	}
 
-	c.logger.Errorf("[Routing Manager -> E2 Manager] #RoutingManagerClient.sendMessage - failure. http status code: %d", resp.StatusCode)
-	return e2managererrors.NewRoutingManagerError()
+	responseBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorResponseBodySize))
+	c.logger.Errorf("[Routing Manager -> E2 Manager] #RoutingManagerClient.sendMessage - failure. http status code: %d, response body: %s", resp.StatusCode, responseBody)
+	return e2managererrors.NewRoutingManagerResponseError(resp.StatusCode, string(responseBody))
+}
+
+// isTransient reports whether the failure may succeed on retry: no response was received, or the Routing Manager
+// is overloaded or temporarily unavailable
+func isTransient(err *e2managererrors.RoutingManagerError) bool {
+	if err.StatusCode == 0 {
+		return err.Cause != nil
+	}
+	return err.StatusCode == http.StatusTooManyRequests || (err.StatusCode >= http.StatusInternalServerError && err.StatusCode != http.StatusNotImplemented)
+}
+
+// backoff doubles retryBackoffMs on every attempt, up to retryMaxBackoffMs, and picks a random delay between half
+// and all of it so that retries of concurrent calls spread out
+func (c *RoutingManagerClient) backoff(attempt int) time.Duration {
+	backoff := int64(c.config.RoutingManager.RetryBackoffMs) << uint(attempt-1)
+	maxBackoff := int64(c.config.RoutingManager.RetryMaxBackoffMs)
+
+	if maxBackoff > 0 && backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return time.Duration(half+c.jitter(backoff-half+1)) * time.Millisecond
 }
 
-func (c *RoutingManagerClient) DeleteMessage(url string, data interface{}) error {
-	return c.sendMessage(http.MethodDelete, url, data)
+// DeleteMessage sends an idempotent DELETE, retried on transient failures
+func (c *RoutingManagerClient) DeleteMessage(ctx context.Context, url string, data interface{}) error {
+	return c.sendMessage(ctx, http.MethodDelete, url, data, true)
 }
 
-func (c *RoutingManagerClient) PostMessage(url string, data interface{}) error {
-	return c.sendMessage(http.MethodPost, url, data)
+// PostMessage sends a POST that is not retried
+func (c *RoutingManagerClient) PostMessage(ctx context.Context, url string, data interface{}) error {
+	return c.sendMessage(ctx, http.MethodPost, url, data, false)
 }
 
 func mapE2TAddressesToE2DataList(e2tAddresses []string) models.RoutingManagerE2TDataList {
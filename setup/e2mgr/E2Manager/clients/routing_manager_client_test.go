@@ -21,6 +21,7 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
@@ -29,9 +30,12 @@ import (
 	"encoding/json"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 const E2TAddress = "10.0.2.15:38000"
@@ -56,7 +60,7 @@ func TestDeleteE2TInstanceSuccess(t *testing.T) {
 	url := config.RoutingManager.BaseUrl + "e2t"
 	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
 	httpClientMock.On("Delete", url, "application/json", body).Return(&http.Response{StatusCode: http.StatusOK, Body: respBody}, nil)
-	err := rmClient.DeleteE2TInstance(E2TAddress, []string{"test1"})
+	err := rmClient.DeleteE2TInstance(context.Background(), E2TAddress, []string{"test1"})
 	assert.Nil(t, err)
 }
 
@@ -69,7 +73,7 @@ func TestDeleteE2TInstanceFailure(t *testing.T) {
 	url := config.RoutingManager.BaseUrl + "e2t"
 	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
 	httpClientMock.On("Delete", url, "application/json", body).Return(&http.Response{StatusCode: http.StatusBadRequest, Body: respBody}, nil)
-	err := rmClient.DeleteE2TInstance(E2TAddress, []string{"test1"})
+	err := rmClient.DeleteE2TInstance(context.Background(), E2TAddress, []string{"test1"})
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
 }
 
@@ -81,7 +85,7 @@ func TestDeleteE2TInstanceDeleteFailure(t *testing.T) {
 	body := bytes.NewBuffer(marshaled)
 	url := config.RoutingManager.BaseUrl + "e2t"
 	httpClientMock.On("Delete", url, "application/json", body).Return(&http.Response{}, errors.New("error"))
-	err := rmClient.DeleteE2TInstance(E2TAddress, []string{"test1"})
+	err := rmClient.DeleteE2TInstance(context.Background(), E2TAddress, []string{"test1"})
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
 }
 
@@ -94,7 +98,7 @@ func TestAddE2TInstanceSuccess(t *testing.T) {
 	url := config.RoutingManager.BaseUrl + "e2t"
 	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{StatusCode: http.StatusCreated, Body: respBody}, nil)
-	err := rmClient.AddE2TInstance(E2TAddress)
+	err := rmClient.AddE2TInstance(context.Background(), E2TAddress)
 	assert.Nil(t, err)
 }
 
@@ -106,7 +110,7 @@ func TestAddE2TInstanceHttpPostFailure(t *testing.T) {
 	body := bytes.NewBuffer(marshaled)
 	url := config.RoutingManager.BaseUrl + "e2t"
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{}, errors.New("error"))
-	err := rmClient.AddE2TInstance(E2TAddress)
+	err := rmClient.AddE2TInstance(context.Background(), E2TAddress)
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
 }
 
@@ -119,7 +123,7 @@ func TestAddE2TInstanceFailure(t *testing.T) {
 	url := config.RoutingManager.BaseUrl + "e2t"
 	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{StatusCode: http.StatusBadRequest, Body: respBody}, nil)
-	err := rmClient.AddE2TInstance(E2TAddress)
+	err := rmClient.AddE2TInstance(context.Background(), E2TAddress)
 	assert.NotNil(t, err)
 }
 
@@ -132,7 +136,7 @@ func TestAssociateRanToE2TInstance_Success(t *testing.T) {
 	url := config.RoutingManager.BaseUrl + AssociateRanToE2TInstanceApiSuffix
 	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{StatusCode: http.StatusCreated, Body: respBody}, nil)
-	err := rmClient.AssociateRanToE2TInstance(E2TAddress, RanName)
+	err := rmClient.AssociateRanToE2TInstance(context.Background(), E2TAddress, RanName)
 	assert.Nil(t, err)
 }
 
@@ -144,7 +148,7 @@ func TestAssociateRanToE2TInstance_RoutingManagerError(t *testing.T) {
 	body := bytes.NewBuffer(marshaled)
 	url := config.RoutingManager.BaseUrl + AssociateRanToE2TInstanceApiSuffix
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{}, errors.New("error"))
-	err := rmClient.AssociateRanToE2TInstance(E2TAddress, RanName)
+	err := rmClient.AssociateRanToE2TInstance(context.Background(), E2TAddress, RanName)
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
 }
 
@@ -157,7 +161,7 @@ func TestAssociateRanToE2TInstance_RoutingManager_400(t *testing.T) {
 	url := config.RoutingManager.BaseUrl + AssociateRanToE2TInstanceApiSuffix
 	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{StatusCode: http.StatusBadRequest, Body: respBody}, nil)
-	err := rmClient.AssociateRanToE2TInstance(E2TAddress, RanName)
+	err := rmClient.AssociateRanToE2TInstance(context.Background(), E2TAddress, RanName)
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
 }
 
@@ -170,7 +174,7 @@ func TestDissociateRanE2TInstance_Success(t *testing.T) {
 	url := config.RoutingManager.BaseUrl + DissociateRanE2TInstanceApiSuffix
 	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{StatusCode: http.StatusCreated, Body: respBody}, nil)
-	err := rmClient.DissociateRanE2TInstance(E2TAddress, RanName)
+	err := rmClient.DissociateRanE2TInstance(context.Background(), E2TAddress, RanName)
 	assert.Nil(t, err)
 }
 
@@ -182,7 +186,7 @@ func TestDissociateRanE2TInstance_RoutingManagerError(t *testing.T) {
 	body := bytes.NewBuffer(marshaled)
 	url := config.RoutingManager.BaseUrl + DissociateRanE2TInstanceApiSuffix
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{}, errors.New("error"))
-	err := rmClient.DissociateRanE2TInstance(E2TAddress, RanName)
+	err := rmClient.DissociateRanE2TInstance(context.Background(), E2TAddress, RanName)
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
 }
 
@@ -195,10 +199,136 @@ func TestDissociateRanE2TInstance_RoutingManager_400(t *testing.T) {
 	url := config.RoutingManager.BaseUrl + DissociateRanE2TInstanceApiSuffix
 	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
 	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{StatusCode: http.StatusBadRequest, Body: respBody}, nil)
-	err := rmClient.DissociateRanE2TInstance(E2TAddress, RanName)
+	err := rmClient.DissociateRanE2TInstance(context.Background(), E2TAddress, RanName)
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
 }
 
+func initResilientRoutingManagerClientTest(t *testing.T) (*RoutingManagerClient, *mocks.HttpClientMock, *[]time.Duration) {
+	rmClient, httpClientMock, config := initRoutingManagerClientTest(t)
+	config.RoutingManager.MaxRetries = 2
+	config.RoutingManager.RetryBackoffMs = 100
+	config.RoutingManager.RetryMaxBackoffMs = 150
+	config.RoutingManager.CircuitBreaker.FailureThreshold = 2
+	config.RoutingManager.CircuitBreaker.OpenDurationMs = 1000
+	rmClient = NewRoutingManagerClient(rmClient.logger, config, httpClientMock)
+	var sleeps []time.Duration
+	rmClient.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	rmClient.jitter = func(n int64) int64 { return n - 1 }
+	return rmClient, httpClientMock, &sleeps
+}
+
+func response(statusCode int, body string) *http.Response {
+	return &http.Response{StatusCode: statusCode, Body: ioutil.NopCloser(bytes.NewBufferString(body))}
+}
+
+func TestAssociateRanToE2TInstance_RetriesTransientFailures(t *testing.T) {
+	rmClient, httpClientMock, sleeps := initResilientRoutingManagerClientTest(t)
+
+	url := rmClient.config.RoutingManager.BaseUrl + AssociateRanToE2TInstanceApiSuffix
+	httpClientMock.On("Post", url, "application/json", mock.Anything).Return(&http.Response{}, errors.New("connection refused")).Once()
+	httpClientMock.On("Post", url, "application/json", mock.Anything).Return(response(http.StatusServiceUnavailable, "busy"), nil).Once()
+	httpClientMock.On("Post", url, "application/json", mock.Anything).Return(response(http.StatusCreated, ""), nil).Once()
+
+	err := rmClient.AssociateRanToE2TInstance(context.Background(), E2TAddress, RanName)
+	assert.Nil(t, err)
+	httpClientMock.AssertNumberOfCalls(t, "Post", 3)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 150 * time.Millisecond}, *sleeps)
+}
+
+func TestAssociateRanToE2TInstance_RetriesExhausted(t *testing.T) {
+	rmClient, httpClientMock, _ := initResilientRoutingManagerClientTest(t)
+
+	url := rmClient.config.RoutingManager.BaseUrl + AssociateRanToE2TInstanceApiSuffix
+	for i := 0; i < 3; i++ {
+		httpClientMock.On("Post", url, "application/json", mock.Anything).Return(response(http.StatusBadGateway, "no upstream"), nil).Once()
+	}
+
+	err := rmClient.AssociateRanToE2TInstance(context.Background(), E2TAddress, RanName)
+	httpClientMock.AssertNumberOfCalls(t, "Post", 3)
+	rmError, ok := err.(*e2managererrors.RoutingManagerError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadGateway, rmError.StatusCode)
+	assert.Equal(t, "no upstream", rmError.ResponseBody)
+	assert.Equal(t, "No Routing Manager Available", rmError.Message)
+	assert.Equal(t, CircuitClosed, rmClient.CircuitState())
+}
+
+func TestAddE2TInstance_NotRetried(t *testing.T) {
+	rmClient, httpClientMock, _ := initResilientRoutingManagerClientTest(t)
+
+	url := rmClient.config.RoutingManager.BaseUrl + AddE2TInstanceApiSuffix
+	httpClientMock.On("Post", url, "application/json", mock.Anything).Return(response(http.StatusServiceUnavailable, ""), nil)
+
+	err := rmClient.AddE2TInstance(context.Background(), E2TAddress)
+	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
+	httpClientMock.AssertNumberOfCalls(t, "Post", 1)
+}
+
+func TestDeleteE2TInstance_ClientErrorNotRetried(t *testing.T) {
+	rmClient, httpClientMock, _ := initResilientRoutingManagerClientTest(t)
+
+	url := rmClient.config.RoutingManager.BaseUrl + DeleteE2TInstanceApiSuffix
+	httpClientMock.On("Delete", url, "application/json", mock.Anything).Return(response(http.StatusBadRequest, `{"error":"bad e2t address"}`), nil)
+
+	err := rmClient.DeleteE2TInstance(context.Background(), E2TAddress, []string{RanName})
+	httpClientMock.AssertNumberOfCalls(t, "Delete", 1)
+	assert.Equal(t, `No Routing Manager Available - http status code: 400, response body: {"error":"bad e2t address"}`, err.Error())
+}
+
+func TestCircuitBreakerFailsFast(t *testing.T) {
+	rmClient, httpClientMock, _ := initResilientRoutingManagerClientTest(t)
+	now := time.Now()
+	rmClient.breaker.now = func() time.Time { return now }
+
+	url := rmClient.config.RoutingManager.BaseUrl + AddE2TInstanceApiSuffix
+	httpClientMock.On("Post", url, "application/json", mock.Anything).Return(&http.Response{}, errors.New("i/o timeout")).Times(2)
+
+	_ = rmClient.AddE2TInstance(context.Background(), E2TAddress)
+	_ = rmClient.AddE2TInstance(context.Background(), E2TAddress)
+	assert.Equal(t, CircuitOpen, rmClient.CircuitState())
+
+	err := rmClient.AddE2TInstance(context.Background(), E2TAddress)
+	rmError, ok := err.(*e2managererrors.RoutingManagerError)
+	assert.True(t, ok)
+	assert.Equal(t, ErrCircuitOpen, rmError.Cause)
+	httpClientMock.AssertNumberOfCalls(t, "Post", 2)
+
+	now = now.Add(time.Second)
+	httpClientMock.On("Post", url, "application/json", mock.Anything).Return(response(http.StatusCreated, ""), nil).Once()
+	err = rmClient.AddE2TInstance(context.Background(), E2TAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, CircuitClosed, rmClient.CircuitState())
+}
+
+func TestSendMessageTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		writer.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	config := &configuration.Configuration{}
+	config.RoutingManager.BaseUrl = server.URL + "/"
+	config.RoutingManager.TimeoutMs = 20
+	rmClient := NewRoutingManagerClient(initLog(t), config, NewHttpClient())
+
+	start := time.Now()
+	err := rmClient.AddE2TInstance(context.Background(), E2TAddress)
+	assert.Less(t, int64(time.Since(start)), int64(150*time.Millisecond))
+	rmError, ok := err.(*e2managererrors.RoutingManagerError)
+	assert.True(t, ok)
+	assert.NotNil(t, rmError.Cause)
+}
+
+func TestBackoffIsCappedAndJittered(t *testing.T) {
+	rmClient, _, _ := initResilientRoutingManagerClientTest(t)
+	rmClient.jitter = func(n int64) int64 { return 0 }
+
+	assert.Equal(t, 50*time.Millisecond, rmClient.backoff(1))
+	assert.Equal(t, 75*time.Millisecond, rmClient.backoff(2))
+	assert.Equal(t, 75*time.Millisecond, rmClient.backoff(10))
+}
+
 // TODO: extract to test_utils
 func initLog(t *testing.T) *logger.Logger {
 	log, err := logger.InitLogger(logger.InfoLevel)
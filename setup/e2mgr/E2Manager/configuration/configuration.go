@@ -22,26 +22,61 @@ package configuration
 import (
 	"fmt"
 	"github.com/spf13/viper"
+	"os"
+	"sort"
+	"strings"
 )
 
 type Configuration struct {
 	Logging struct {
-		LogLevel string
+		LogLevel                   string
+		ComponentLogLevels         map[string]string
+		RanTraceDefaultDurationSec int
+		RanTraceMaxDurationSec     int
 	}
 	Http struct {
 		Port int
+		Tls  struct {
+			Enabled      bool
+			CertFile     string
+			KeyFile      string
+			MinVersion   string
+			CipherSuites []string
+			ClientCaFile string
+			ClientAuth   string
+		}
 	}
 	Rmr struct {
 		Port       int
 		MaxMsgSize int
+		Capture    struct {
+			Enabled bool
+			File    string
+		}
 	}
 	RoutingManager struct {
-		BaseUrl string
+		BaseUrl           string
+		TimeoutMs         int
+		MaxRetries        int
+		RetryBackoffMs    int
+		RetryMaxBackoffMs int
+		CircuitBreaker    struct {
+			FailureThreshold int
+			OpenDurationMs   int
+		}
+		Tls struct {
+			CaFile             string
+			CertFile           string
+			KeyFile            string
+			InsecureSkipVerify bool
+		}
+		AuthTokenFile string
+	}
+	Kubernetes struct {
+		Enabled       bool
+		ConfigPath    string
+		KubeNamespace string
 	}
-/*	Kubernetes struct {
-		ConfigPath string
-		KubeNamespace  string
-	}*/
 	NotificationResponseBuffer   int
 	BigRedButtonTimeoutSec       int
 	MaxRnibConnectionAttempts    int
@@ -49,96 +84,330 @@ type Configuration struct {
 	KeepAliveResponseTimeoutMs   int
 	KeepAliveDelayMs             int
 	E2TInstanceDeletionTimeoutMs int
+	RanStatusHistorySize         int
 	GlobalRicId                  struct {
 		PlmnId      string
 		RicNearRtId string
 	}
+	Tracing struct {
+		Enabled      bool
+		Exporter     string
+		OtlpEndpoint string
+		OtlpInsecure bool
+		ServiceName  string
+		SampleRatio  float64
+	}
+	Health struct {
+		CheckTimeoutMs               int
+		KeepAliveMaxAgeMs            int
+		NotificationQueueMaxInFlight int
+		Critical                     map[string]bool
+	}
+	Auth struct {
+		Enabled       bool
+		TokensFile    string
+		JwksFile      string
+		JwtIssuer     string
+		JwtAudience   string
+		JwtRolesClaim string
+		JwtLeewaySec  int
+	}
+	FaultInjection struct {
+		Enabled       bool
+		DebugEndpoint bool
+		Rules         []FaultRule
+	}
+	LoadInformation struct {
+		Enabled bool
+	}
 }
 
+// FaultRule injects a fault into the rNib operations whose name matches Operation and whose key matches Key, both
+// path.Match patterns where an empty pattern matches everything. Layer sdl injects below the retries of the data
+// service, layer rnib injects into the data service itself
+type FaultRule struct {
+	Layer       string  `json:"layer"`
+	Operation   string  `json:"operation,omitempty"`
+	Key         string  `json:"key,omitempty"`
+	Fault       string  `json:"fault"`
+	LatencyMs   int     `json:"latencyMs,omitempty"`
+	Probability float64 `json:"probability,omitempty"`
+	Count       int     `json:"count,omitempty"`
+}
+
+const EnvPrefix = "E2MGR"
+
+// ParseConfiguration reads configuration.yaml, overridden by environment variables, and panics on failure
 func ParseConfiguration() *Configuration {
-	viper.SetConfigType("yaml")
-	viper.SetConfigName("configuration")
-	viper.AddConfigPath("E2Manager/resources/")
-	viper.AddConfigPath("./resources/")     //For production
-	viper.AddConfigPath("../resources/")    //For test under Docker
-	viper.AddConfigPath("../../resources/") //For test under Docker
-	err := viper.ReadInConfig()
+	config, _, err := readConfiguration()
 	if err != nil {
-		panic(fmt.Sprintf("#configuration.ParseConfiguration - failed to read configuration file: %s\n", err))
+		panic(err.Error())
 	}
+	return config
+}
 
+// LoadConfiguration reads and validates the configuration, returning errors instead of panicking
+func LoadConfiguration() (*Configuration, error) {
+	config, _, err := readConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// newViper returns a viper instance where every key can be overridden by an environment variable named after its
+// upper cased path, e.g. E2MGR_ROUTINGMANAGER_BASEURL for routingManager.baseUrl
+func newViper() *viper.Viper {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetConfigName("configuration")
+	v.AddConfigPath("E2Manager/resources/")
+	v.AddConfigPath("./resources/")     //For production
+	v.AddConfigPath("../resources/")    //For test under Docker
+	v.AddConfigPath("../../resources/") //For test under Docker
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	return v
+}
+
+func readConfiguration() (*Configuration, *viper.Viper, error) {
+	v := newViper()
+	err := v.ReadInConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("#configuration.ParseConfiguration - failed to read configuration file: %s\n", err)
+	}
+	config, err := populateConfiguration(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return config, v, nil
+}
+
+func populateConfiguration(v *viper.Viper) (*Configuration, error) {
 	config := Configuration{}
-	config.populateRmrConfig(viper.Sub("rmr"))
-	config.populateHttpConfig(viper.Sub("http"))
-	config.populateLoggingConfig(viper.Sub("logging"))
-	config.populateRoutingManagerConfig(viper.Sub("routingManager"))
-	//config.populateKubernetesConfig(viper.Sub("kubernetes"))
-	config.NotificationResponseBuffer = viper.GetInt("notificationResponseBuffer")
-	config.BigRedButtonTimeoutSec = viper.GetInt("bigRedButtonTimeoutSec")
-	config.MaxRnibConnectionAttempts = viper.GetInt("maxRnibConnectionAttempts")
-	config.RnibRetryIntervalMs = viper.GetInt("rnibRetryIntervalMs")
-	config.KeepAliveResponseTimeoutMs = viper.GetInt("keepAliveResponseTimeoutMs")
-	config.KeepAliveDelayMs = viper.GetInt("KeepAliveDelayMs")
-	config.E2TInstanceDeletionTimeoutMs = viper.GetInt("e2tInstanceDeletionTimeoutMs")
-	config.populateGlobalRicIdConfig(viper.Sub("globalRicId"))
-	return &config
+	for _, populate := range []func(*viper.Viper) error{
+		config.populateRmrConfig,
+		config.populateHttpConfig,
+		config.populateLoggingConfig,
+		config.populateRoutingManagerConfig,
+		config.populateGlobalRicIdConfig,
+	} {
+		if err := populate(v); err != nil {
+			return nil, err
+		}
+	}
+	config.NotificationResponseBuffer = v.GetInt("notificationResponseBuffer")
+	config.BigRedButtonTimeoutSec = v.GetInt("bigRedButtonTimeoutSec")
+	config.MaxRnibConnectionAttempts = v.GetInt("maxRnibConnectionAttempts")
+	config.RnibRetryIntervalMs = v.GetInt("rnibRetryIntervalMs")
+	config.KeepAliveResponseTimeoutMs = v.GetInt("keepAliveResponseTimeoutMs")
+	config.KeepAliveDelayMs = v.GetInt("KeepAliveDelayMs")
+	config.E2TInstanceDeletionTimeoutMs = v.GetInt("e2tInstanceDeletionTimeoutMs")
+	config.RanStatusHistorySize = v.GetInt("ranStatusHistorySize")
+	config.populateTracingConfig(v)
+	config.populateHealthConfig(v)
+	config.populateAuthConfig(v)
+	config.populateKubernetesConfig(v)
+	config.populateLoadInformationConfig(v)
+	if err := config.populateFaultInjectionConfig(v); err != nil {
+		return nil, err
+	}
+	return &config, nil
 }
 
-func (c *Configuration) populateLoggingConfig(logConfig *viper.Viper) {
-	if logConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateLoggingConfig - failed to populate logging configuration: The entry 'logging' not found\n"))
+func (c *Configuration) populateLoggingConfig(v *viper.Viper) error {
+	if v.Sub("logging") == nil {
+		return fmt.Errorf("#configuration.populateLoggingConfig - failed to populate logging configuration: The entry 'logging' not found\n")
 	}
-	c.Logging.LogLevel = logConfig.GetString("logLevel")
+	c.Logging.LogLevel = v.GetString("logging.logLevel")
+	c.Logging.ComponentLogLevels = make(map[string]string)
+	for _, component := range mapEntryNames(v, "logging.components") {
+		c.Logging.ComponentLogLevels[component] = v.GetString("logging.components." + component)
+	}
+	c.Logging.RanTraceDefaultDurationSec = v.GetInt("logging.ranTraceDefaultDurationSec")
+	c.Logging.RanTraceMaxDurationSec = v.GetInt("logging.ranTraceMaxDurationSec")
+	return nil
 }
 
-func (c *Configuration) populateHttpConfig(httpConfig *viper.Viper) {
-	if httpConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateHttpConfig - failed to populate HTTP configuration: The entry 'http' not found\n"))
+func (c *Configuration) populateHttpConfig(v *viper.Viper) error {
+	if v.Sub("http") == nil {
+		return fmt.Errorf("#configuration.populateHttpConfig - failed to populate HTTP configuration: The entry 'http' not found\n")
 	}
-	c.Http.Port = httpConfig.GetInt("port")
+	c.Http.Port = v.GetInt("http.port")
+	c.Http.Tls.Enabled = v.GetBool("http.tls.enabled")
+	c.Http.Tls.CertFile = v.GetString("http.tls.certFile")
+	c.Http.Tls.KeyFile = v.GetString("http.tls.keyFile")
+	c.Http.Tls.MinVersion = v.GetString("http.tls.minVersion")
+	c.Http.Tls.CipherSuites = v.GetStringSlice("http.tls.cipherSuites")
+	c.Http.Tls.ClientCaFile = v.GetString("http.tls.clientCaFile")
+	c.Http.Tls.ClientAuth = v.GetString("http.tls.clientAuth")
+	return nil
+}
+
+func (c *Configuration) populateRmrConfig(v *viper.Viper) error {
+	if v.Sub("rmr") == nil {
+		return fmt.Errorf("#configuration.populateRmrConfig - failed to populate RMR configuration: The entry 'rmr' not found\n")
+	}
+	c.Rmr.Port = v.GetInt("rmr.port")
+	c.Rmr.MaxMsgSize = v.GetInt("rmr.maxMsgSize")
+	c.Rmr.Capture.Enabled = v.GetBool("rmr.capture.enabled")
+	c.Rmr.Capture.File = v.GetString("rmr.capture.file")
+	return nil
+}
+
+func (c *Configuration) populateRoutingManagerConfig(v *viper.Viper) error {
+	if v.Sub("routingManager") == nil {
+		return fmt.Errorf("#configuration.populateRoutingManagerConfig - failed to populate Routing Manager configuration: The entry 'routingManager' not found\n")
+	}
+	c.RoutingManager.BaseUrl = v.GetString("routingManager.baseUrl")
+	c.RoutingManager.TimeoutMs = v.GetInt("routingManager.timeoutMs")
+	c.RoutingManager.MaxRetries = v.GetInt("routingManager.maxRetries")
+	c.RoutingManager.RetryBackoffMs = v.GetInt("routingManager.retryBackoffMs")
+	c.RoutingManager.RetryMaxBackoffMs = v.GetInt("routingManager.retryMaxBackoffMs")
+	c.RoutingManager.CircuitBreaker.FailureThreshold = v.GetInt("routingManager.circuitBreaker.failureThreshold")
+	c.RoutingManager.CircuitBreaker.OpenDurationMs = v.GetInt("routingManager.circuitBreaker.openDurationMs")
+	c.RoutingManager.Tls.CaFile = v.GetString("routingManager.tls.caFile")
+	c.RoutingManager.Tls.CertFile = v.GetString("routingManager.tls.certFile")
+	c.RoutingManager.Tls.KeyFile = v.GetString("routingManager.tls.keyFile")
+	c.RoutingManager.Tls.InsecureSkipVerify = v.GetBool("routingManager.tls.insecureSkipVerify")
+	c.RoutingManager.AuthTokenFile = v.GetString("routingManager.authTokenFile")
+	return nil
+}
+
+// populateKubernetesConfig reads the optional kubernetes entry. Without it the pods of dead E2T instances are not
+// deleted. An empty configPath means E2 Manager runs in the cluster and uses its service account
+func (c *Configuration) populateKubernetesConfig(v *viper.Viper) {
+	c.Kubernetes.Enabled = v.GetBool("kubernetes.enabled")
+	c.Kubernetes.ConfigPath = v.GetString("kubernetes.configPath")
+	c.Kubernetes.KubeNamespace = v.GetString("kubernetes.kubeNamespace")
 }
 
-func (c *Configuration) populateRmrConfig(rmrConfig *viper.Viper) {
-	if rmrConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateRmrConfig - failed to populate RMR configuration: The entry 'rmr' not found\n"))
+func (c *Configuration) populateGlobalRicIdConfig(v *viper.Viper) error {
+	if v.Sub("globalRicId") == nil {
+		return fmt.Errorf("#configuration.populateGlobalRicIdConfig - failed to populate Global RicId configuration: The entry 'globalRicId' not found\n")
 	}
-	c.Rmr.Port = rmrConfig.GetInt("port")
-	c.Rmr.MaxMsgSize = rmrConfig.GetInt("maxMsgSize")
+	c.GlobalRicId.PlmnId = v.GetString("globalRicId.plmnId")
+	c.GlobalRicId.RicNearRtId = v.GetString("globalRicId.ricNearRtId")
+	return nil
+}
+
+// Tracing is optional - a missing entry leaves it disabled
+func (c *Configuration) populateTracingConfig(v *viper.Viper) {
+	c.Tracing.Enabled = v.GetBool("tracing.enabled")
+	c.Tracing.Exporter = v.GetString("tracing.exporter")
+	c.Tracing.OtlpEndpoint = v.GetString("tracing.otlpEndpoint")
+	c.Tracing.OtlpInsecure = v.GetBool("tracing.otlpInsecure")
+	c.Tracing.ServiceName = v.GetString("tracing.serviceName")
+	c.Tracing.SampleRatio = v.GetFloat64("tracing.sampleRatio")
 }
 
-func (c *Configuration) populateRoutingManagerConfig(rmConfig *viper.Viper) {
-	if rmConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateRoutingManagerConfig - failed to populate Routing Manager configuration: The entry 'routingManager' not found\n"))
+// Health checks fall back to their defaults when the entry is missing
+func (c *Configuration) populateHealthConfig(v *viper.Viper) {
+	c.Health.CheckTimeoutMs = v.GetInt("health.checkTimeoutMs")
+	c.Health.KeepAliveMaxAgeMs = v.GetInt("health.keepAliveMaxAgeMs")
+	c.Health.NotificationQueueMaxInFlight = v.GetInt("health.notificationQueueMaxInFlight")
+	critical := mapEntryNames(v, "health.critical")
+	if len(critical) == 0 {
+		return
+	}
+	c.Health.Critical = make(map[string]bool)
+	for _, name := range critical {
+		c.Health.Critical[name] = v.GetBool("health.critical." + name)
 	}
-	c.RoutingManager.BaseUrl = rmConfig.GetString("baseUrl")
 }
 
-/*func (c *Configuration) populateKubernetesConfig(rmConfig *viper.Viper) {
-	if rmConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateKubernetesConfig - failed to populate Kubernetes configuration: The entry 'kubernetes' not found\n"))
+// mapEntryNames returns the lower cased entry names of a map valued key, e.g. logging.components. AutomaticEnv only
+// overrides keys viper already knows, so the entries are collected from both the configuration file and the environment:
+// E2MGR_HEALTH_CRITICAL_RMR overrides health.critical.rmr and E2MGR_LOGGING_COMPONENTS_RMRRECEIVER adds
+// logging.components.rmrreceiver even though the file has no such entry
+func mapEntryNames(v *viper.Viper, key string) []string {
+	names := make(map[string]bool)
+	// viper keys are case insensitive and always returned in lower case
+	for name := range v.GetStringMap(key) {
+		names[name] = true
+	}
+	envPrefix := EnvPrefix + "_" + strings.ToUpper(strings.Replace(key, ".", "_", -1)) + "_"
+	for _, env := range os.Environ() {
+		if name := strings.SplitN(env, "=", 2)[0]; strings.HasPrefix(name, envPrefix) && len(name) > len(envPrefix) {
+			names[strings.ToLower(strings.TrimPrefix(name, envPrefix))] = true
+		}
+	}
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
 	}
-	c.Kubernetes.ConfigPath = rmConfig.GetString("configPath")
-	c.Kubernetes.KubeNamespace = rmConfig.GetString("kubeNamespace")
-}*/
+	sort.Strings(result)
+	return result
+}
+
+// Auth is optional - a missing entry leaves the northbound API open
+func (c *Configuration) populateAuthConfig(v *viper.Viper) {
+	c.Auth.Enabled = v.GetBool("auth.enabled")
+	c.Auth.TokensFile = v.GetString("auth.tokensFile")
+	c.Auth.JwksFile = v.GetString("auth.jwksFile")
+	c.Auth.JwtIssuer = v.GetString("auth.jwtIssuer")
+	c.Auth.JwtAudience = v.GetString("auth.jwtAudience")
+	c.Auth.JwtRolesClaim = v.GetString("auth.jwtRolesClaim")
+	c.Auth.JwtLeewaySec = v.GetInt("auth.jwtLeewaySec")
+}
 
-func (c *Configuration) populateGlobalRicIdConfig(globalRicIdConfig *viper.Viper) {
-	if globalRicIdConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateGlobalRicIdConfig - failed to populate Global RicId configuration: The entry 'globalRicId' not found\n"))
+// Fault injection is optional and meant for chaos testing only - a missing entry leaves the rNib untouched
+func (c *Configuration) populateFaultInjectionConfig(v *viper.Viper) error {
+	c.FaultInjection.Enabled = v.GetBool("faultInjection.enabled")
+	c.FaultInjection.DebugEndpoint = v.GetBool("faultInjection.debugEndpoint")
+	if err := v.UnmarshalKey("faultInjection.rules", &c.FaultInjection.Rules); err != nil {
+		return fmt.Errorf("#configuration.populateFaultInjectionConfig - failed to populate fault injection rules: %s", err)
 	}
-	c.GlobalRicId.PlmnId = globalRicIdConfig.GetString("plmnId")
-	c.GlobalRicId.RicNearRtId = globalRicIdConfig.GetString("ricNearRtId")
+	return nil
+}
+
+// eNB load information is optional - a missing entry leaves RIC_ENB_LOAD_INFORMATION messages unhandled
+func (c *Configuration) populateLoadInformationConfig(v *viper.Viper) {
+	c.LoadInformation.Enabled = v.GetBool("loadInformation.enabled")
 }
 
 func (c *Configuration) String() string {
-	return fmt.Sprintf("{logging.logLevel: %s, http.port: %d, rmr: { port: %d, maxMsgSize: %d}, routingManager.baseUrl: %s, "+
+	return fmt.Sprintf("{logging: { logLevel: %s, components: %v, ranTraceDefaultDurationSec: %d, ranTraceMaxDurationSec: %d}, http: { port: %d, tls: { enabled: %t, certFile: %s, keyFile: %s, minVersion: %s, cipherSuites: %v, clientCaFile: %s, clientAuth: %s}}, rmr: { port: %d, maxMsgSize: %d, capture: { enabled: %t, file: %s}}, routingManager: { baseUrl: %s, timeoutMs: %d, maxRetries: %d, retryBackoffMs: %d, retryMaxBackoffMs: %d, "+
+		"circuitBreaker: { failureThreshold: %d, openDurationMs: %d}, tls: { caFile: %s, certFile: %s, insecureSkipVerify: %t}, authTokenFile: %s}, "+
 		"notificationResponseBuffer: %d, bigRedButtonTimeoutSec: %d, maxRnibConnectionAttempts: %d, "+
-		"rnibRetryIntervalMs: %d, keepAliveResponseTimeoutMs: %d, keepAliveDelayMs: %d, e2tInstanceDeletionTimeoutMs: %d, "+
-		"globalRicId: { plmnId: %s, ricNearRtId: %s}",//, kubernetes: {configPath: %s, kubeNamespace: %s}}",
+		"rnibRetryIntervalMs: %d, keepAliveResponseTimeoutMs: %d, keepAliveDelayMs: %d, e2tInstanceDeletionTimeoutMs: %d, ranStatusHistorySize: %d, "+
+		"globalRicId: { plmnId: %s, ricNearRtId: %s}, tracing: { enabled: %t, exporter: %s, otlpEndpoint: %s, serviceName: %s, sampleRatio: %.2f}, "+
+		"health: { checkTimeoutMs: %d, keepAliveMaxAgeMs: %d, notificationQueueMaxInFlight: %d, critical: %v}, "+
+		"auth: { enabled: %t, tokensFile: %s, jwksFile: %s, jwtIssuer: %s, jwtAudience: %s, jwtRolesClaim: %s, jwtLeewaySec: %d}, "+
+		"kubernetes: { enabled: %t, configPath: %s, kubeNamespace: %s}, faultInjection: { enabled: %t, debugEndpoint: %t, rules: %v}, "+
+		"loadInformation: { enabled: %t}}",
 		c.Logging.LogLevel,
+		c.Logging.ComponentLogLevels,
+		c.Logging.RanTraceDefaultDurationSec,
+		c.Logging.RanTraceMaxDurationSec,
 		c.Http.Port,
+		c.Http.Tls.Enabled,
+		c.Http.Tls.CertFile,
+		c.Http.Tls.KeyFile,
+		c.Http.Tls.MinVersion,
+		c.Http.Tls.CipherSuites,
+		c.Http.Tls.ClientCaFile,
+		c.Http.Tls.ClientAuth,
 		c.Rmr.Port,
 		c.Rmr.MaxMsgSize,
+		c.Rmr.Capture.Enabled,
+		c.Rmr.Capture.File,
 		c.RoutingManager.BaseUrl,
+		c.RoutingManager.TimeoutMs,
+		c.RoutingManager.MaxRetries,
+		c.RoutingManager.RetryBackoffMs,
+		c.RoutingManager.RetryMaxBackoffMs,
+		c.RoutingManager.CircuitBreaker.FailureThreshold,
+		c.RoutingManager.CircuitBreaker.OpenDurationMs,
+		c.RoutingManager.Tls.CaFile,
+		c.RoutingManager.Tls.CertFile,
+		c.RoutingManager.Tls.InsecureSkipVerify,
+		c.RoutingManager.AuthTokenFile,
 		c.NotificationResponseBuffer,
 		c.BigRedButtonTimeoutSec,
 		c.MaxRnibConnectionAttempts,
@@ -146,9 +415,31 @@ func (c *Configuration) String() string {
 		c.KeepAliveResponseTimeoutMs,
 		c.KeepAliveDelayMs,
 		c.E2TInstanceDeletionTimeoutMs,
+		c.RanStatusHistorySize,
 		c.GlobalRicId.PlmnId,
 		c.GlobalRicId.RicNearRtId,
-/*		c.Kubernetes.ConfigPath,
-		c.Kubernetes.KubeNamespace,*/
+		c.Tracing.Enabled,
+		c.Tracing.Exporter,
+		c.Tracing.OtlpEndpoint,
+		c.Tracing.ServiceName,
+		c.Tracing.SampleRatio,
+		c.Health.CheckTimeoutMs,
+		c.Health.KeepAliveMaxAgeMs,
+		c.Health.NotificationQueueMaxInFlight,
+		c.Health.Critical,
+		c.Auth.Enabled,
+		c.Auth.TokensFile,
+		c.Auth.JwksFile,
+		c.Auth.JwtIssuer,
+		c.Auth.JwtAudience,
+		c.Auth.JwtRolesClaim,
+		c.Auth.JwtLeewaySec,
+		c.Kubernetes.Enabled,
+		c.Kubernetes.ConfigPath,
+		c.Kubernetes.KubeNamespace,
+		c.FaultInjection.Enabled,
+		c.FaultInjection.DebugEndpoint,
+		c.FaultInjection.Rules,
+		c.LoadInformation.Enabled,
 	)
 }
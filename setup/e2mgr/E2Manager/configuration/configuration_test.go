@@ -31,19 +31,55 @@ import (
 func TestParseConfigurationSuccess(t *testing.T) {
 	config := ParseConfiguration()
 	assert.Equal(t, 3800, config.Http.Port)
+	assert.False(t, config.Http.Tls.Enabled)
+	assert.Equal(t, "1.2", config.Http.Tls.MinVersion)
+	assert.Equal(t, "none", config.Http.Tls.ClientAuth)
+	assert.Empty(t, config.Http.Tls.CipherSuites)
 	assert.Equal(t, 3801, config.Rmr.Port)
 	assert.Equal(t, 65536, config.Rmr.MaxMsgSize)
+	assert.False(t, config.Rmr.Capture.Enabled)
+	assert.Equal(t, "/opt/E2Manager/capture/rmr.capture", config.Rmr.Capture.File)
 	assert.Equal(t, "info", config.Logging.LogLevel)
 	assert.Equal(t, 100, config.NotificationResponseBuffer)
 	assert.Equal(t, 5, config.BigRedButtonTimeoutSec)
 	assert.Equal(t, 4500, config.KeepAliveResponseTimeoutMs)
 	assert.Equal(t, 1500, config.KeepAliveDelayMs)
 	assert.Equal(t, 15000, config.E2TInstanceDeletionTimeoutMs)
+	assert.Equal(t, 50, config.RanStatusHistorySize)
+	assert.Equal(t, "info", config.Logging.ComponentLogLevels["e2tkeepaliveworker"])
+	assert.Equal(t, 600, config.Logging.RanTraceDefaultDurationSec)
+	assert.Equal(t, 3600, config.Logging.RanTraceMaxDurationSec)
 	assert.NotNil(t, config.GlobalRicId)
 	assert.NotEmpty(t, config.GlobalRicId.PlmnId)
 	assert.NotEmpty(t, config.GlobalRicId.RicNearRtId)
-/*	assert.NotEmpty(t, config.Kubernetes.KubeNamespace)
-	assert.NotEmpty(t, config.Kubernetes.ConfigPath)*/
+	assert.False(t, config.Tracing.Enabled)
+	assert.Equal(t, "otlp", config.Tracing.Exporter)
+	assert.Equal(t, "localhost:4318", config.Tracing.OtlpEndpoint)
+	assert.Equal(t, "e2mgr", config.Tracing.ServiceName)
+	assert.Equal(t, 1.0, config.Tracing.SampleRatio)
+	assert.Equal(t, 1000, config.Health.CheckTimeoutMs)
+	assert.Equal(t, 4500, config.Health.KeepAliveMaxAgeMs)
+	assert.Equal(t, 1000, config.Health.NotificationQueueMaxInFlight)
+	assert.True(t, config.Health.Critical["rnib"])
+	assert.False(t, config.Health.Critical["routingmanager"])
+	assert.Equal(t, 2000, config.RoutingManager.TimeoutMs)
+	assert.Equal(t, 2, config.RoutingManager.MaxRetries)
+	assert.Equal(t, 200, config.RoutingManager.RetryBackoffMs)
+	assert.Equal(t, 2000, config.RoutingManager.RetryMaxBackoffMs)
+	assert.Equal(t, 5, config.RoutingManager.CircuitBreaker.FailureThreshold)
+	assert.Equal(t, 30000, config.RoutingManager.CircuitBreaker.OpenDurationMs)
+	assert.Empty(t, config.RoutingManager.Tls.CaFile)
+	assert.False(t, config.Auth.Enabled)
+	assert.Equal(t, "e2mgr", config.Auth.JwtAudience)
+	assert.Equal(t, "roles", config.Auth.JwtRolesClaim)
+	assert.Equal(t, 30, config.Auth.JwtLeewaySec)
+	assert.False(t, config.Kubernetes.Enabled)
+	assert.Empty(t, config.Kubernetes.ConfigPath)
+	assert.Equal(t, "ricplt", config.Kubernetes.KubeNamespace)
+	assert.False(t, config.FaultInjection.Enabled)
+	assert.False(t, config.FaultInjection.DebugEndpoint)
+	assert.Empty(t, config.FaultInjection.Rules)
+	assert.False(t, config.LoadInformation.Enabled)
 }
 
 func TestStringer(t *testing.T) {
@@ -222,17 +258,51 @@ func TestGlobalRicIdConfigNotFoundFailure(t *testing.T) {
 		func() { ParseConfiguration() })
 }
 
-/*func TestKubernetesConfigNotFoundFailure(t *testing.T) {
+func TestTracingConfigNotFoundDisablesTracing(t *testing.T) {
 	configPath := "../resources/configuration.yaml"
 	configPathTmp := "../resources/configuration.yaml_tmp"
 	err := os.Rename(configPath, configPathTmp)
 	if err != nil {
-		t.Errorf("#TestKubernetesConfigNotFoundFailure - failed to rename configuration file: %s\n", configPath)
+		t.Errorf("#TestTracingConfigNotFoundDisablesTracing - failed to rename configuration file: %s\n", configPath)
 	}
 	defer func() {
 		err = os.Rename(configPathTmp, configPath)
 		if err != nil {
-			t.Errorf("#TestKubernetesConfigNotFoundFailure - failed to rename configuration file: %s\n", configPath)
+			t.Errorf("#TestTracingConfigNotFoundDisablesTracing - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":            map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":        map[string]interface{}{"logLevel": "info"},
+		"http":           map[string]interface{}{"port": 3800},
+		"routingManager": map[string]interface{}{"baseUrl": "http://iltlv740.intl.att.com:8080/ric/v1/handles/"},
+		"globalRicId":    map[string]interface{}{"plmnId": "131014", "ricNearRtId": "556670"},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestTracingConfigNotFoundDisablesTracing - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestTracingConfigNotFoundDisablesTracing - failed to write configuration file: %s\n", configPath)
+	}
+	config := ParseConfiguration()
+	assert.False(t, config.Tracing.Enabled)
+	assert.Empty(t, config.Tracing.Exporter)
+	assert.Nil(t, config.Health.Critical)
+}
+
+func TestKubernetesConfigNotFoundDisablesPodDeletion(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestKubernetesConfigNotFoundDisablesPodDeletion - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestKubernetesConfigNotFoundDisablesPodDeletion - failed to rename configuration file: %s\n", configPath)
 		}
 	}()
 	yamlMap := map[string]interface{}{
@@ -244,12 +314,57 @@ func TestGlobalRicIdConfigNotFoundFailure(t *testing.T) {
 	}
 	buf, err := yaml.Marshal(yamlMap)
 	if err != nil {
-		t.Errorf("#TestKubernetesConfigNotFoundFailure - failed to marshal configuration map\n")
+		t.Errorf("#TestKubernetesConfigNotFoundDisablesPodDeletion - failed to marshal configuration map\n")
 	}
 	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
 	if err != nil {
-		t.Errorf("#TestKubernetesConfigNotFoundFailure - failed to write configuration file: %s\n", configPath)
+		t.Errorf("#TestKubernetesConfigNotFoundDisablesPodDeletion - failed to write configuration file: %s\n", configPath)
 	}
-	assert.PanicsWithValue(t, "#configuration.populateKubernetesConfig - failed to populate Kubernetes configuration: The entry 'kubernetes' not found\n",
-		func() { ParseConfiguration() })
-}*/
+	config := ParseConfiguration()
+	assert.False(t, config.Kubernetes.Enabled)
+	assert.Empty(t, config.Kubernetes.KubeNamespace)
+}
+
+func TestFaultInjectionConfig(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestFaultInjectionConfig - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestFaultInjectionConfig - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":            map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":        map[string]interface{}{"logLevel": "info"},
+		"http":           map[string]interface{}{"port": 3800},
+		"routingManager": map[string]interface{}{"baseUrl": "http://iltlv740.intl.att.com:8080/ric/v1/handles/"},
+		"globalRicId":    map[string]interface{}{"plmnId": "131014", "ricNearRtId": "556670"},
+		"faultInjection": map[string]interface{}{
+			"enabled": true,
+			"rules": []interface{}{
+				map[string]interface{}{"layer": "sdl", "operation": "Get", "key": "RAN:*", "fault": "connectionError", "count": 2},
+				map[string]interface{}{"layer": "rnib", "operation": "SaveNodeb", "fault": "latency", "latencyMs": 100, "probability": 0.5},
+			},
+		},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestFaultInjectionConfig - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestFaultInjectionConfig - failed to write configuration file: %s\n", configPath)
+	}
+	config := ParseConfiguration()
+	assert.True(t, config.FaultInjection.Enabled)
+	assert.False(t, config.FaultInjection.DebugEndpoint)
+	assert.Equal(t, []FaultRule{
+		{Layer: "sdl", Operation: "Get", Key: "RAN:*", Fault: "connectionError", Count: 2},
+		{Layer: "rnib", Operation: "SaveNodeb", Fault: "latency", LatencyMs: 100, Probability: 0.5},
+	}, config.FaultInjection.Rules)
+}
@@ -0,0 +1,64 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package configuration
+
+import "crypto/tls"
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// none - no client certificate; request - verify the client certificate if one is sent; require - a client
+// certificate signed by the client CA bundle is mandatory
+var tlsClientAuthTypes = map[string]tls.ClientAuthType{
+	"":        tls.NoClientCert,
+	"none":    tls.NoClientCert,
+	"request": tls.VerifyClientCertIfGiven,
+	"require": tls.RequireAndVerifyClientCert,
+}
+
+// TlsVersion maps a http.tls.minVersion value, e.g. "1.2", to its crypto/tls constant. An empty value means TLS 1.2
+func TlsVersion(name string) (uint16, bool) {
+	if name == "" {
+		return tls.VersionTLS12, true
+	}
+	version, ok := tlsVersions[name]
+	return version, ok
+}
+
+func TlsClientAuth(name string) (tls.ClientAuthType, bool) {
+	clientAuth, ok := tlsClientAuthTypes[name]
+	return clientAuth, ok
+}
+
+// TlsCipherSuite maps an IANA cipher suite name, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, to its id.
+// Suites known to be insecure are rejected
+func TlsCipherSuite(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+	return 0, false
+}
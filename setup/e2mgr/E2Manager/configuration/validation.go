@@ -0,0 +1,179 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package configuration
+
+import (
+	"crypto/tls"
+	"e2mgr/logger"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var hexIdPattern = regexp.MustCompile("^[0-9a-fA-F]{6}$")
+
+// ValidationError lists every problem found in the configuration, not only the first one
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(e.Problems, "; "))
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, format string, a ...interface{}) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf(format, a...))
+	}
+}
+
+// Validate checks the values of the configuration and returns a *ValidationError describing every invalid key
+func (c *Configuration) Validate() error {
+	v := &validator{}
+
+	_, ok := logger.LogLevelTokenToLevel(c.Logging.LogLevel)
+	v.check(ok, "logging.logLevel: unknown level %q", c.Logging.LogLevel)
+	for component, level := range c.Logging.ComponentLogLevels {
+		_, ok := logger.LogLevelTokenToLevel(level)
+		v.check(ok, "logging.components.%s: unknown level %q", component, level)
+	}
+	v.check(c.Logging.RanTraceDefaultDurationSec >= 0, "logging.ranTraceDefaultDurationSec: must not be negative, got %d", c.Logging.RanTraceDefaultDurationSec)
+	v.check(c.Logging.RanTraceMaxDurationSec >= 0, "logging.ranTraceMaxDurationSec: must not be negative, got %d", c.Logging.RanTraceMaxDurationSec)
+	v.check(c.Logging.RanTraceMaxDurationSec == 0 || c.Logging.RanTraceDefaultDurationSec <= c.Logging.RanTraceMaxDurationSec,
+		"logging.ranTraceDefaultDurationSec: %d exceeds logging.ranTraceMaxDurationSec %d", c.Logging.RanTraceDefaultDurationSec, c.Logging.RanTraceMaxDurationSec)
+
+	v.check(isPort(c.Http.Port), "http.port: must be between 1 and 65535, got %d", c.Http.Port)
+	v.check(isPort(c.Rmr.Port), "rmr.port: must be between 1 and 65535, got %d", c.Rmr.Port)
+	v.check(c.Http.Port != c.Rmr.Port, "rmr.port: must differ from http.port %d", c.Http.Port)
+	if c.Http.Tls.Enabled {
+		v.check(c.Http.Tls.CertFile != "", "http.tls.certFile: required when tls is enabled")
+		v.check(c.Http.Tls.KeyFile != "", "http.tls.keyFile: required when tls is enabled")
+		_, ok := TlsVersion(c.Http.Tls.MinVersion)
+		v.check(ok, "http.tls.minVersion: must be one of 1.0, 1.1, 1.2 or 1.3, got %q", c.Http.Tls.MinVersion)
+		for _, name := range c.Http.Tls.CipherSuites {
+			_, ok := TlsCipherSuite(name)
+			v.check(ok, "http.tls.cipherSuites: unknown or insecure cipher suite %q", name)
+		}
+		clientAuth, ok := TlsClientAuth(c.Http.Tls.ClientAuth)
+		v.check(ok, "http.tls.clientAuth: must be none, request or require, got %q", c.Http.Tls.ClientAuth)
+		v.check(clientAuth == tls.NoClientCert || c.Http.Tls.ClientCaFile != "", "http.tls.clientCaFile: required when http.tls.clientAuth is %s", c.Http.Tls.ClientAuth)
+	}
+	v.check(c.Rmr.MaxMsgSize > 0, "rmr.maxMsgSize: must be positive, got %d", c.Rmr.MaxMsgSize)
+	v.check(!c.Rmr.Capture.Enabled || c.Rmr.Capture.File != "", "rmr.capture.file: required when capture is enabled")
+
+	u, err := url.Parse(c.RoutingManager.BaseUrl)
+	v.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+		"routingManager.baseUrl: must be an absolute http(s) url, got %q", c.RoutingManager.BaseUrl)
+	v.check(strings.HasSuffix(c.RoutingManager.BaseUrl, "/"), "routingManager.baseUrl: must end with '/', got %q", c.RoutingManager.BaseUrl)
+
+	v.check(c.RoutingManager.TimeoutMs >= 0, "routingManager.timeoutMs: must not be negative, got %d", c.RoutingManager.TimeoutMs)
+	v.check(c.RoutingManager.MaxRetries >= 0, "routingManager.maxRetries: must not be negative, got %d", c.RoutingManager.MaxRetries)
+	v.check(c.RoutingManager.RetryBackoffMs >= 0, "routingManager.retryBackoffMs: must not be negative, got %d", c.RoutingManager.RetryBackoffMs)
+	v.check(c.RoutingManager.RetryMaxBackoffMs >= c.RoutingManager.RetryBackoffMs,
+		"routingManager.retryMaxBackoffMs: %d must not be below routingManager.retryBackoffMs %d", c.RoutingManager.RetryMaxBackoffMs, c.RoutingManager.RetryBackoffMs)
+	v.check(c.RoutingManager.CircuitBreaker.FailureThreshold >= 0, "routingManager.circuitBreaker.failureThreshold: must not be negative, got %d", c.RoutingManager.CircuitBreaker.FailureThreshold)
+	v.check(c.RoutingManager.CircuitBreaker.FailureThreshold == 0 || c.RoutingManager.CircuitBreaker.OpenDurationMs > 0,
+		"routingManager.circuitBreaker.openDurationMs: must be positive when the circuit breaker is enabled, got %d", c.RoutingManager.CircuitBreaker.OpenDurationMs)
+	v.check((c.RoutingManager.Tls.CertFile == "") == (c.RoutingManager.Tls.KeyFile == ""), "routingManager.tls: certFile and keyFile must be set together")
+
+	v.check(c.NotificationResponseBuffer >= 0, "notificationResponseBuffer: must not be negative, got %d", c.NotificationResponseBuffer)
+	v.check(c.BigRedButtonTimeoutSec > 0, "bigRedButtonTimeoutSec: must be positive, got %d", c.BigRedButtonTimeoutSec)
+	v.check(c.MaxRnibConnectionAttempts > 0, "maxRnibConnectionAttempts: must be positive, got %d", c.MaxRnibConnectionAttempts)
+	v.check(c.RnibRetryIntervalMs >= 0, "rnibRetryIntervalMs: must not be negative, got %d", c.RnibRetryIntervalMs)
+	v.check(c.KeepAliveDelayMs > 0, "keepAliveDelayMs: must be positive, got %d", c.KeepAliveDelayMs)
+	v.check(c.KeepAliveResponseTimeoutMs > c.KeepAliveDelayMs, "keepAliveResponseTimeoutMs: %d must exceed keepAliveDelayMs %d", c.KeepAliveResponseTimeoutMs, c.KeepAliveDelayMs)
+	v.check(c.E2TInstanceDeletionTimeoutMs > 0, "e2tInstanceDeletionTimeoutMs: must be positive, got %d", c.E2TInstanceDeletionTimeoutMs)
+	v.check(c.RanStatusHistorySize >= 0, "ranStatusHistorySize: must not be negative, got %d", c.RanStatusHistorySize)
+
+	v.check(hexIdPattern.MatchString(c.GlobalRicId.PlmnId), "globalRicId.plmnId: must be 6 hex digits, got %q", c.GlobalRicId.PlmnId)
+	v.check(hexIdPattern.MatchString(c.GlobalRicId.RicNearRtId), "globalRicId.ricNearRtId: must be 6 hex digits, got %q", c.GlobalRicId.RicNearRtId)
+
+	if c.Tracing.Enabled {
+		v.check(c.Tracing.Exporter == "otlp" || c.Tracing.Exporter == "stdout", "tracing.exporter: must be otlp or stdout, got %q", c.Tracing.Exporter)
+		v.check(c.Tracing.Exporter != "otlp" || c.Tracing.OtlpEndpoint != "", "tracing.otlpEndpoint: required by the otlp exporter")
+		v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio: must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+	}
+
+	v.check(c.Health.CheckTimeoutMs >= 0, "health.checkTimeoutMs: must not be negative, got %d", c.Health.CheckTimeoutMs)
+	v.check(c.Health.KeepAliveMaxAgeMs >= 0, "health.keepAliveMaxAgeMs: must not be negative, got %d", c.Health.KeepAliveMaxAgeMs)
+	v.check(c.Health.KeepAliveMaxAgeMs <= 0 || c.Health.KeepAliveMaxAgeMs > c.KeepAliveDelayMs,
+		"health.keepAliveMaxAgeMs: %d must exceed keepAliveDelayMs %d", c.Health.KeepAliveMaxAgeMs, c.KeepAliveDelayMs)
+	v.check(c.Health.NotificationQueueMaxInFlight >= 0, "health.notificationQueueMaxInFlight: must not be negative, got %d", c.Health.NotificationQueueMaxInFlight)
+
+	if c.Auth.Enabled {
+		v.check(c.Auth.TokensFile != "" || c.Auth.JwksFile != "", "auth: tokensFile or jwksFile is required when auth is enabled")
+		v.check(c.Auth.JwksFile == "" || c.Auth.JwtRolesClaim != "", "auth.jwtRolesClaim: required when auth.jwksFile is set")
+	}
+	v.check(c.Auth.JwtLeewaySec >= 0, "auth.jwtLeewaySec: must not be negative, got %d", c.Auth.JwtLeewaySec)
+
+	for i, rule := range c.FaultInjection.Rules {
+		for _, problem := range rule.problems() {
+			v.check(false, "faultInjection.rules[%d].%s", i, problem)
+		}
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+const (
+	FaultLayerSdl  = "sdl"
+	FaultLayerRnib = "rnib"
+
+	FaultLatency         = "latency"
+	FaultConnectionError = "connectionError"
+	FaultNotFound        = "notFound"
+)
+
+// Validate checks a single fault rule, as the rules are also set at runtime through the debug endpoint
+func (r FaultRule) Validate() error {
+	if problems := r.problems(); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (r FaultRule) problems() []string {
+	v := &validator{}
+	v.check(r.Layer == FaultLayerSdl || r.Layer == FaultLayerRnib, "layer: must be sdl or rnib, got %q", r.Layer)
+	v.check(r.Fault == FaultLatency || r.Fault == FaultConnectionError || r.Fault == FaultNotFound,
+		"fault: must be latency, connectionError or notFound, got %q", r.Fault)
+	_, err := path.Match(r.Operation, "")
+	v.check(err == nil, "operation: malformed pattern %q", r.Operation)
+	_, err = path.Match(r.Key, "")
+	v.check(err == nil, "key: malformed pattern %q", r.Key)
+	v.check(r.LatencyMs >= 0, "latencyMs: must not be negative, got %d", r.LatencyMs)
+	v.check(r.Fault != FaultLatency || r.LatencyMs > 0, "latencyMs: must be positive for a latency fault, got %d", r.LatencyMs)
+	v.check(r.Probability >= 0 && r.Probability <= 1, "probability: must be between 0 and 1, got %v", r.Probability)
+	v.check(r.Count >= 0, "count: must not be negative, got %d", r.Count)
+	return v.problems
+}
+
+func isPort(port int) bool {
+	return port > 0 && port <= 65535
+}
@@ -0,0 +1,204 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package configuration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func TestValidateDefaultConfigurationSuccess(t *testing.T) {
+	config := ParseConfiguration()
+	assert.Nil(t, config.Validate())
+}
+
+func TestValidateKeepAliveResponseTimeoutFailure(t *testing.T) {
+	config := ParseConfiguration()
+	config.KeepAliveResponseTimeoutMs = config.KeepAliveDelayMs
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Len(t, err.(*ValidationError).Problems, 1)
+	assert.Contains(t, err.Error(), "keepAliveResponseTimeoutMs")
+}
+
+func TestValidateKeepAliveMaxAgeFailure(t *testing.T) {
+	config := ParseConfiguration()
+	config.Health.KeepAliveMaxAgeMs = config.KeepAliveDelayMs
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Len(t, err.(*ValidationError).Problems, 1)
+	assert.Contains(t, err.Error(), "health.keepAliveMaxAgeMs")
+}
+
+func TestValidateNotificationQueueMaxInFlightFailure(t *testing.T) {
+	config := ParseConfiguration()
+	config.Health.NotificationQueueMaxInFlight = -1
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Len(t, err.(*ValidationError).Problems, 1)
+	assert.Contains(t, err.Error(), "health.notificationQueueMaxInFlight")
+}
+
+func TestValidateListsEveryProblem(t *testing.T) {
+	config := ParseConfiguration()
+	config.Logging.LogLevel = "verbose"
+	config.Rmr.Port = config.Http.Port
+	config.RoutingManager.BaseUrl = "10.0.2.15:12020/ric/v1/handles/"
+	config.GlobalRicId.PlmnId = "xyz"
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	problems := err.(*ValidationError).Problems
+	assert.Len(t, problems, 4)
+	assert.Contains(t, problems[0], "logging.logLevel")
+	assert.Contains(t, problems[1], "rmr.port")
+	assert.Contains(t, problems[2], "routingManager.baseUrl")
+	assert.Contains(t, problems[3], "globalRicId.plmnId")
+}
+
+func TestValidateTlsFailure(t *testing.T) {
+	config := ParseConfiguration()
+	config.Http.Tls.Enabled = true
+	config.Http.Tls.CertFile = ""
+	config.Http.Tls.MinVersion = "1.4"
+	config.Http.Tls.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_RSA_WITH_RC4_128_SHA"}
+	config.Http.Tls.ClientAuth = "require"
+	config.Http.Tls.ClientCaFile = ""
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	problems := err.(*ValidationError).Problems
+	assert.Len(t, problems, 4)
+	assert.Contains(t, problems[0], "http.tls.certFile")
+	assert.Contains(t, problems[1], "http.tls.minVersion")
+	assert.Contains(t, problems[2], "TLS_RSA_WITH_RC4_128_SHA")
+	assert.Contains(t, problems[3], "http.tls.clientCaFile")
+}
+
+func TestValidateTlsSuccess(t *testing.T) {
+	config := ParseConfiguration()
+	config.Http.Tls.Enabled = true
+	config.Http.Tls.ClientAuth = "request"
+	config.Http.Tls.ClientCaFile = "/opt/E2Manager/tls/ca.crt"
+
+	assert.Nil(t, config.Validate())
+}
+
+func TestValidateRmrCaptureFailure(t *testing.T) {
+	config := ParseConfiguration()
+	config.Rmr.Capture.Enabled = true
+	config.Rmr.Capture.File = ""
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Len(t, err.(*ValidationError).Problems, 1)
+	assert.Contains(t, err.Error(), "rmr.capture.file")
+}
+
+func TestValidateFaultInjectionFailure(t *testing.T) {
+	config := ParseConfiguration()
+	config.FaultInjection.Rules = []FaultRule{
+		{Layer: "sdl", Operation: "Get", Fault: "connectionError"},
+		{Layer: "redis", Key: "[", Fault: "latency", Probability: 2},
+	}
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	problems := err.(*ValidationError).Problems
+	assert.Len(t, problems, 4)
+	assert.Contains(t, problems[0], "faultInjection.rules[1].layer")
+	assert.Contains(t, problems[1], "faultInjection.rules[1].key")
+	assert.Contains(t, problems[2], "faultInjection.rules[1].latencyMs")
+	assert.Contains(t, problems[3], "faultInjection.rules[1].probability")
+}
+
+func TestValidateAuthFailure(t *testing.T) {
+	config := ParseConfiguration()
+	config.Auth.Enabled = true
+	config.Auth.JwtLeewaySec = -1
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	problems := err.(*ValidationError).Problems
+	assert.Len(t, problems, 2)
+	assert.Contains(t, problems[0], "tokensFile or jwksFile")
+	assert.Contains(t, problems[1], "auth.jwtLeewaySec")
+}
+
+func TestValidateRoutingManagerFailure(t *testing.T) {
+	config := ParseConfiguration()
+	config.RoutingManager.RetryMaxBackoffMs = 100
+	config.RoutingManager.CircuitBreaker.OpenDurationMs = 0
+	config.RoutingManager.Tls.CertFile = "/opt/E2Manager/tls/rm.crt"
+
+	err := config.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	problems := err.(*ValidationError).Problems
+	assert.Len(t, problems, 3)
+	assert.Contains(t, problems[0], "routingManager.retryMaxBackoffMs")
+	assert.Contains(t, problems[1], "routingManager.circuitBreaker.openDurationMs")
+	assert.Contains(t, problems[2], "routingManager.tls")
+}
+
+func TestEnvironmentOverride(t *testing.T) {
+	os.Setenv("E2MGR_ROUTINGMANAGER_BASEURL", "http://rtmgr:12020/ric/v1/handles/")
+	os.Setenv("E2MGR_KEEPALIVEDELAYMS", "1000")
+	defer os.Unsetenv("E2MGR_ROUTINGMANAGER_BASEURL")
+	defer os.Unsetenv("E2MGR_KEEPALIVEDELAYMS")
+
+	config := ParseConfiguration()
+	assert.Equal(t, "http://rtmgr:12020/ric/v1/handles/", config.RoutingManager.BaseUrl)
+	assert.Equal(t, 1000, config.KeepAliveDelayMs)
+	assert.Equal(t, 3800, config.Http.Port)
+}
+
+func TestEnvironmentOverrideOfMapEntries(t *testing.T) {
+	os.Setenv("E2MGR_LOGGING_COMPONENTS_E2TKEEPALIVEWORKER", "debug")
+	os.Setenv("E2MGR_LOGGING_COMPONENTS_RMRRECEIVER", "warn")
+	os.Setenv("E2MGR_HEALTH_CRITICAL_ROUTINGMANAGER", "true")
+	defer os.Unsetenv("E2MGR_LOGGING_COMPONENTS_E2TKEEPALIVEWORKER")
+	defer os.Unsetenv("E2MGR_LOGGING_COMPONENTS_RMRRECEIVER")
+	defer os.Unsetenv("E2MGR_HEALTH_CRITICAL_ROUTINGMANAGER")
+
+	config := ParseConfiguration()
+	assert.Equal(t, map[string]string{"e2tkeepaliveworker": "debug", "rmrreceiver": "warn"}, config.Logging.ComponentLogLevels)
+	assert.True(t, config.Health.Critical["routingmanager"])
+	assert.True(t, config.Health.Critical["rnib"])
+}
+
+func TestLoadConfigurationValidationFailure(t *testing.T) {
+	os.Setenv("E2MGR_KEEPALIVERESPONSETIMEOUTMS", "100")
+	defer os.Unsetenv("E2MGR_KEEPALIVERESPONSETIMEOUTMS")
+
+	config, err := LoadConfiguration()
+	assert.Nil(t, config)
+	assert.IsType(t, &ValidationError{}, err)
+}
+
+func TestLoadConfigurationSuccess(t *testing.T) {
+	config, err := LoadConfiguration()
+	assert.Nil(t, err)
+	assert.Equal(t, 3801, config.Rmr.Port)
+}
@@ -0,0 +1,173 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package configuration
+
+import (
+	"e2mgr/logger"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"reflect"
+	"sync"
+)
+
+// Keys of the settings that are applied without a restart
+const (
+	LogLevelKey                   = "logging.logLevel"
+	KeepAliveDelayMsKey           = "keepAliveDelayMs"
+	KeepAliveResponseTimeoutMsKey = "keepAliveResponseTimeoutMs"
+	MaxRnibConnectionAttemptsKey  = "maxRnibConnectionAttempts"
+	RnibRetryIntervalMsKey        = "rnibRetryIntervalMs"
+)
+
+type Change struct {
+	Key      string
+	Previous string
+	Current  string
+}
+
+type ChangeListener func(config *Configuration, changes []Change)
+
+// Watcher reloads the configuration file when it changes. Only the hot reloadable settings are applied, and only
+// if the new configuration is valid; listeners are notified of the applied changes
+type Watcher struct {
+	logger    *logger.Logger
+	mu        sync.Mutex
+	current   *Configuration
+	listeners []ChangeListener
+	read      func() (*Configuration, *viper.Viper, error)
+}
+
+func NewWatcher(logger *logger.Logger, config *Configuration) *Watcher {
+	return &Watcher{
+		logger:  logger,
+		current: config,
+		read:    readConfiguration,
+	}
+}
+
+func (w *Watcher) OnChange(listener ChangeListener) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, listener)
+}
+
+// Current returns the configuration with the hot reloadable settings applied so far
+func (w *Watcher) Current() *Configuration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Watch starts watching the configuration file in the background
+func (w *Watcher) Watch() error {
+	_, v, err := w.read()
+
+	if err != nil {
+		return err
+	}
+
+	v.OnConfigChange(func(event fsnotify.Event) {
+		w.logger.Infof("#configuration.Watcher.Watch - configuration file %s changed (%s)", event.Name, event.Op)
+		_ = w.Reload()
+	})
+	v.WatchConfig()
+	w.logger.Infof("#configuration.Watcher.Watch - watching configuration file %s", v.ConfigFileUsed())
+	return nil
+}
+
+func (w *Watcher) Reload() error {
+	config, _, err := w.read()
+
+	if err == nil {
+		err = config.Validate()
+	}
+
+	if err != nil {
+		w.logger.Errorf("#configuration.Watcher.Reload - keeping the current configuration: %s", err)
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	next := *w.current
+	changes := applyHotReloadable(&next, config)
+
+	if !reflect.DeepEqual(&next, config) {
+		w.logger.Warnf("#configuration.Watcher.Reload - settings other than %s, %s, %s, %s and %s changed and require a restart",
+			LogLevelKey, KeepAliveDelayMsKey, KeepAliveResponseTimeoutMsKey, MaxRnibConnectionAttemptsKey, RnibRetryIntervalMsKey)
+	}
+
+	if len(changes) == 0 {
+		w.logger.Infof("#configuration.Watcher.Reload - no hot reloadable setting changed")
+		return nil
+	}
+
+	for _, change := range changes {
+		w.logger.Infof("#configuration.Watcher.Reload - %s changed from %s to %s", change.Key, change.Previous, change.Current)
+	}
+
+	w.current = &next
+
+	for _, listener := range w.listeners {
+		listener(w.current, changes)
+	}
+
+	return nil
+}
+
+// applyHotReloadable copies the hot reloadable settings of source into target and returns what changed
+func applyHotReloadable(target *Configuration, source *Configuration) []Change {
+	var changes []Change
+
+	if target.Logging.LogLevel != source.Logging.LogLevel {
+		changes = append(changes, Change{LogLevelKey, target.Logging.LogLevel, source.Logging.LogLevel})
+		target.Logging.LogLevel = source.Logging.LogLevel
+	}
+
+	for _, setting := range []struct {
+		key    string
+		target *int
+		source int
+	}{
+		{KeepAliveDelayMsKey, &target.KeepAliveDelayMs, source.KeepAliveDelayMs},
+		{KeepAliveResponseTimeoutMsKey, &target.KeepAliveResponseTimeoutMs, source.KeepAliveResponseTimeoutMs},
+		{MaxRnibConnectionAttemptsKey, &target.MaxRnibConnectionAttempts, source.MaxRnibConnectionAttempts},
+		{RnibRetryIntervalMsKey, &target.RnibRetryIntervalMs, source.RnibRetryIntervalMs},
+	} {
+		if *setting.target != setting.source {
+			changes = append(changes, Change{setting.key, fmt.Sprint(*setting.target), fmt.Sprint(setting.source)})
+			*setting.target = setting.source
+		}
+	}
+
+	return changes
+}
+
+// HasChanged reports whether key is one of the changes
+func HasChanged(changes []Change, key string) bool {
+	for _, change := range changes {
+		if change.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package configuration
+
+import (
+	"e2mgr/logger"
+	"errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func initWatcherTest(t *testing.T) (*Watcher, *Configuration) {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#... - failed to initialize logger, error: %s", err)
+	}
+	config := ParseConfiguration()
+	return NewWatcher(log, config), config
+}
+
+func readReturning(config *Configuration, err error) func() (*Configuration, *viper.Viper, error) {
+	return func() (*Configuration, *viper.Viper, error) {
+		return config, nil, err
+	}
+}
+
+func TestReloadAppliesHotReloadableChanges(t *testing.T) {
+	watcher, config := initWatcherTest(t)
+	next := ParseConfiguration()
+	next.Logging.LogLevel = "debug"
+	next.KeepAliveDelayMs = 1000
+	watcher.read = readReturning(next, nil)
+
+	var received []Change
+	watcher.OnChange(func(config *Configuration, changes []Change) {
+		received = changes
+	})
+
+	err := watcher.Reload()
+	assert.Nil(t, err)
+	assert.Equal(t, []Change{{LogLevelKey, "info", "debug"}, {KeepAliveDelayMsKey, "1500", "1000"}}, received)
+	assert.Equal(t, "debug", watcher.Current().Logging.LogLevel)
+	assert.Equal(t, 1000, watcher.Current().KeepAliveDelayMs)
+	assert.Equal(t, "info", config.Logging.LogLevel)
+	assert.Equal(t, 1500, config.KeepAliveDelayMs)
+}
+
+func TestReloadIgnoresSettingsRequiringRestart(t *testing.T) {
+	watcher, config := initWatcherTest(t)
+	next := ParseConfiguration()
+	next.Http.Port = 3900
+	watcher.read = readReturning(next, nil)
+
+	notified := false
+	watcher.OnChange(func(config *Configuration, changes []Change) {
+		notified = true
+	})
+
+	err := watcher.Reload()
+	assert.Nil(t, err)
+	assert.False(t, notified)
+	assert.Equal(t, config, watcher.Current())
+	assert.Equal(t, 3800, watcher.Current().Http.Port)
+}
+
+func TestReloadKeepsCurrentOnInvalidConfiguration(t *testing.T) {
+	watcher, config := initWatcherTest(t)
+	next := ParseConfiguration()
+	next.KeepAliveDelayMs = 1000
+	next.KeepAliveResponseTimeoutMs = 500
+	watcher.read = readReturning(next, nil)
+
+	notified := false
+	watcher.OnChange(func(config *Configuration, changes []Change) {
+		notified = true
+	})
+
+	err := watcher.Reload()
+	assert.IsType(t, &ValidationError{}, err)
+	assert.False(t, notified)
+	assert.Equal(t, config, watcher.Current())
+}
+
+func TestReloadKeepsCurrentOnReadFailure(t *testing.T) {
+	watcher, config := initWatcherTest(t)
+	watcher.read = readReturning(nil, errors.New("#configuration.readConfiguration - failed to read configuration file"))
+
+	err := watcher.Reload()
+	assert.NotNil(t, err)
+	assert.Equal(t, config, watcher.Current())
+}
+
+func TestHasChanged(t *testing.T) {
+	changes := []Change{{RnibRetryIntervalMsKey, "10", "20"}}
+	assert.True(t, HasChanged(changes, RnibRetryIntervalMsKey))
+	assert.False(t, HasChanged(changes, LogLevelKey))
+}
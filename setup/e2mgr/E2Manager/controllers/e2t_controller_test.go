@@ -18,6 +18,7 @@
 package controllers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/managers"
 	"e2mgr/mocks"
@@ -128,7 +129,7 @@ func TestInvalidRequestName(t *testing.T) {
 
 	header := &http.Header{}
 
-	controller.handleRequest(writer, header, "", nil, true)
+	controller.handleRequest(context.Background(), writer, header, "", nil, true)
 
 	var errorResponse = parseJsonRequest(t, writer.Body)
 
@@ -0,0 +1,134 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package controllers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/rnibfaults"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+type IFaultInjectionController interface {
+	GetFaults(writer http.ResponseWriter, r *http.Request)
+	SetFaults(writer http.ResponseWriter, r *http.Request)
+	ClearFaults(writer http.ResponseWriter, r *http.Request)
+}
+
+// FaultInjectionController is the debug endpoint of the rNib fault injector. It is only routed when
+// faultInjection.debugEndpoint is set
+type FaultInjectionController struct {
+	logger   *logger.Logger
+	injector *rnibfaults.Injector
+}
+
+func NewFaultInjectionController(logger *logger.Logger, injector *rnibfaults.Injector) *FaultInjectionController {
+	return &FaultInjectionController{
+		logger:   logger,
+		injector: injector,
+	}
+}
+
+func (c *FaultInjectionController) GetFaults(writer http.ResponseWriter, r *http.Request) {
+	c.writeStatus(writer)
+}
+
+// SetFaults replaces the rules of the injector and enables or disables it. The injected counters of the request are
+// ignored
+func (c *FaultInjectionController) SetFaults(writer http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	request := rnibfaults.Status{}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, LimitRequest))
+
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+
+	if err != nil {
+		c.logger.Errorf("[Client -> E2 Manager] #FaultInjectionController.SetFaults - unable to extract json body - error: %s", err)
+		c.handleErrorResponse(e2managererrors.NewInvalidJsonError(), writer)
+		return
+	}
+
+	rules := make([]configuration.FaultRule, 0, len(request.Rules))
+	for _, rule := range request.Rules {
+		rules = append(rules, rule.FaultRule)
+	}
+
+	if err := c.injector.SetRules(rules); err != nil {
+		c.logger.Errorf("#FaultInjectionController.SetFaults - %s", err)
+		c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+		return
+	}
+
+	c.injector.SetEnabled(request.Enabled)
+	c.logger.Warnf("[Client -> E2 Manager] #FaultInjectionController.SetFaults - enabled: %t, rules: %v", request.Enabled, rules)
+	c.writeStatus(writer)
+}
+
+func (c *FaultInjectionController) ClearFaults(writer http.ResponseWriter, r *http.Request) {
+	c.injector.Clear()
+	c.logger.Infof("[Client -> E2 Manager] #FaultInjectionController.ClearFaults - fault injection disabled")
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (c *FaultInjectionController) writeStatus(writer http.ResponseWriter) {
+	result, err := json.Marshal(c.injector.Status())
+
+	if err != nil {
+		c.handleErrorResponse(err, writer)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(result)
+}
+
+func (c *FaultInjectionController) handleErrorResponse(err error, writer http.ResponseWriter) {
+
+	var errorResponseDetails models.ErrorResponse
+	var httpError int
+
+	switch e2Error := err.(type) {
+	case *e2managererrors.RequestValidationError:
+		errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+		httpError = http.StatusBadRequest
+	case *e2managererrors.InvalidJsonError:
+		errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+		httpError = http.StatusBadRequest
+	default:
+		internalError := e2managererrors.NewInternalError()
+		errorResponseDetails = models.ErrorResponse{Code: internalError.Code, Message: internalError.Message}
+		httpError = http.StatusInternalServerError
+	}
+
+	errorResponse, _ := json.Marshal(errorResponseDetails)
+
+	c.logger.Errorf("[E2 Manager -> Client] #FaultInjectionController.handleErrorResponse - http status: %d, error response: %+v", httpError, errorResponseDetails)
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(httpError)
+	_, _ = writer.Write(errorResponse)
+}
@@ -0,0 +1,116 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package controllers
+
+import (
+	"bytes"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/rnibfaults"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setupFaultInjectionControllerTest(t *testing.T) (*FaultInjectionController, *rnibfaults.Injector) {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#... - failed to initialize logger, error: %s", err)
+	}
+	config := &configuration.Configuration{}
+	config.FaultInjection.Rules = []configuration.FaultRule{{Layer: "sdl", Operation: "Get", Fault: "notFound"}}
+	injector, err := rnibfaults.NewInjector(log, config)
+	if err != nil {
+		t.Errorf("#... - failed to initialize fault injector, error: %s", err)
+	}
+	return NewFaultInjectionController(log, injector), injector
+}
+
+func newFaultsRequest(method string, body string) *http.Request {
+	req, _ := http.NewRequest(method, "/v1/debug/faults", bytes.NewBufferString(body))
+	return req
+}
+
+func readFaultsResponse(t *testing.T, writer *httptest.ResponseRecorder) rnibfaults.Status {
+	response := rnibfaults.Status{}
+	err := json.Unmarshal(writer.Body.Bytes(), &response)
+	if err != nil {
+		t.Errorf("#readFaultsResponse - failed to unmarshal response body: %s", err)
+	}
+	return response
+}
+
+func TestGetFaults(t *testing.T) {
+	c, _ := setupFaultInjectionControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.GetFaults(writer, newFaultsRequest(http.MethodGet, ""))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.JSONEq(t, `{"enabled":false,"rules":[{"layer":"sdl","operation":"Get","fault":"notFound","injected":0}]}`, writer.Body.String())
+}
+
+func TestSetFaultsSuccess(t *testing.T) {
+	c, injector := setupFaultInjectionControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.SetFaults(writer, newFaultsRequest(http.MethodPut, `{"enabled":true,"rules":[{"layer":"rnib","operation":"SaveNodeb","key":"ran*","fault":"connectionError","count":1,"injected":5}]}`))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	expected := rnibfaults.Status{
+		Enabled: true,
+		Rules:   []rnibfaults.RuleStatus{{FaultRule: configuration.FaultRule{Layer: "rnib", Operation: "SaveNodeb", Key: "ran*", Fault: "connectionError", Count: 1}}},
+	}
+	assert.Equal(t, expected, readFaultsResponse(t, writer))
+	assert.Equal(t, expected, injector.Status())
+}
+
+func TestSetFaultsInvalidRule(t *testing.T) {
+	c, injector := setupFaultInjectionControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.SetFaults(writer, newFaultsRequest(http.MethodPut, `{"enabled":true,"rules":[{"layer":"rnib","fault":"latency"}]}`))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.False(t, injector.Status().Enabled)
+	assert.Equal(t, "notFound", injector.Status().Rules[0].Fault)
+}
+
+func TestSetFaultsInvalidJson(t *testing.T) {
+	c, _ := setupFaultInjectionControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.SetFaults(writer, newFaultsRequest(http.MethodPut, `{"enabled":`))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestClearFaults(t *testing.T) {
+	c, injector := setupFaultInjectionControllerTest(t)
+	injector.SetEnabled(true)
+
+	writer := httptest.NewRecorder()
+	c.ClearFaults(writer, newFaultsRequest(http.MethodDelete, ""))
+
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+	assert.Equal(t, rnibfaults.Status{Rules: []rnibfaults.RuleStatus{}}, injector.Status())
+}
@@ -0,0 +1,254 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package controllers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	ParamComponent = "component"
+
+	defaultRanTraceDurationSec = 600
+	maxRanTraceDurationSec     = 3600
+)
+
+type ILoggingController interface {
+	GetLoggingConfig(writer http.ResponseWriter, r *http.Request)
+	SetLogLevel(writer http.ResponseWriter, r *http.Request)
+	SetComponentLogLevel(writer http.ResponseWriter, r *http.Request)
+	ResetComponentLogLevel(writer http.ResponseWriter, r *http.Request)
+	TraceRan(writer http.ResponseWriter, r *http.Request)
+	StopRanTrace(writer http.ResponseWriter, r *http.Request)
+}
+
+type LoggingController struct {
+	logger *logger.Logger
+	config *configuration.Configuration
+}
+
+func NewLoggingController(logger *logger.Logger, config *configuration.Configuration) *LoggingController {
+	return &LoggingController{
+		logger: logger,
+		config: config,
+	}
+}
+
+func (c *LoggingController) GetLoggingConfig(writer http.ResponseWriter, r *http.Request) {
+	c.writeLoggingConfig(writer)
+}
+
+func (c *LoggingController) SetLogLevel(writer http.ResponseWriter, r *http.Request) {
+	level, ok := c.extractLogLevel(r, writer)
+
+	if !ok {
+		return
+	}
+
+	_ = c.logger.SetLevel(level)
+	c.logger.Infof("[Client -> E2 Manager] #LoggingController.SetLogLevel - log level set to %s", level)
+	c.writeLoggingConfig(writer)
+}
+
+func (c *LoggingController) SetComponentLogLevel(writer http.ResponseWriter, r *http.Request) {
+	component := mux.Vars(r)[ParamComponent]
+	level, ok := c.extractLogLevel(r, writer)
+
+	if !ok {
+		return
+	}
+
+	if err := c.logger.SetComponentLevel(component, level); err != nil {
+		c.logger.Errorf("#LoggingController.SetComponentLogLevel - %s", err)
+		c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+		return
+	}
+
+	c.logger.Infof("[Client -> E2 Manager] #LoggingController.SetComponentLogLevel - log level of component %s set to %s", component, level)
+	c.writeLoggingConfig(writer)
+}
+
+func (c *LoggingController) ResetComponentLogLevel(writer http.ResponseWriter, r *http.Request) {
+	component := mux.Vars(r)[ParamComponent]
+
+	if !c.logger.ResetComponentLevel(component) {
+		c.handleErrorResponse(e2managererrors.NewResourceNotFoundError(), writer)
+		return
+	}
+
+	c.logger.Infof("[Client -> E2 Manager] #LoggingController.ResetComponentLogLevel - component %s uses the base log level", component)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (c *LoggingController) TraceRan(writer http.ResponseWriter, r *http.Request) {
+	ranName := mux.Vars(r)[ParamRanName]
+	request := models.TraceRanRequest{}
+
+	if r.ContentLength > 0 && !c.extractJsonBody(r, &request, writer) {
+		return
+	}
+
+	duration := c.ranTraceDuration(request.DurationSec)
+
+	if duration <= 0 {
+		c.logger.Errorf("#LoggingController.TraceRan - invalid duration: %d", request.DurationSec)
+		c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+		return
+	}
+
+	expiresAt := c.logger.TraceRan(ranName, duration)
+	c.logger.Infof("[Client -> E2 Manager] #LoggingController.TraceRan - RAN name: %s - debug logging until %s", ranName, expiresAt.Format(time.RFC3339))
+	c.writeLoggingConfig(writer)
+}
+
+func (c *LoggingController) StopRanTrace(writer http.ResponseWriter, r *http.Request) {
+	ranName := mux.Vars(r)[ParamRanName]
+
+	if !c.logger.StopRanTrace(ranName) {
+		c.handleErrorResponse(e2managererrors.NewResourceNotFoundError(), writer)
+		return
+	}
+
+	c.logger.Infof("[Client -> E2 Manager] #LoggingController.StopRanTrace - RAN name: %s - debug logging stopped", ranName)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// ranTraceDuration applies the configured default to a missing duration and caps it to the configured maximum
+func (c *LoggingController) ranTraceDuration(durationSec int) time.Duration {
+	if durationSec < 0 {
+		return 0
+	}
+
+	if durationSec == 0 {
+		durationSec = c.config.Logging.RanTraceDefaultDurationSec
+
+		if durationSec <= 0 {
+			durationSec = defaultRanTraceDurationSec
+		}
+	}
+
+	maxDurationSec := c.config.Logging.RanTraceMaxDurationSec
+
+	if maxDurationSec <= 0 {
+		maxDurationSec = maxRanTraceDurationSec
+	}
+
+	if durationSec > maxDurationSec {
+		durationSec = maxDurationSec
+	}
+
+	return time.Duration(durationSec) * time.Second
+}
+
+func (c *LoggingController) extractLogLevel(r *http.Request, writer http.ResponseWriter) (logger.LogLevel, bool) {
+	request := models.SetLogLevelRequest{}
+
+	if !c.extractJsonBody(r, &request, writer) {
+		return 0, false
+	}
+
+	level, ok := logger.LogLevelTokenToLevel(request.Level)
+
+	if !ok {
+		c.logger.Errorf("#LoggingController.extractLogLevel - invalid log level: %s", request.Level)
+		c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+		return 0, false
+	}
+
+	return level, true
+}
+
+func (c *LoggingController) extractJsonBody(r *http.Request, request interface{}, writer http.ResponseWriter) bool {
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, LimitRequest))
+
+	if err == nil {
+		err = json.Unmarshal(body, request)
+	}
+
+	if err != nil {
+		c.logger.Errorf("[Client -> E2 Manager] #LoggingController.extractJsonBody - unable to extract json body - error: %s", err)
+		c.handleErrorResponse(e2managererrors.NewInvalidJsonError(), writer)
+		return false
+	}
+
+	return true
+}
+
+func (c *LoggingController) writeLoggingConfig(writer http.ResponseWriter) {
+	response := &models.LoggingConfigResponse{
+		Level:      c.logger.Level().String(),
+		Components: make(map[string]string),
+		TracedRans: c.logger.TracedRans(),
+	}
+
+	for component, level := range c.logger.ComponentLevels() {
+		response.Components[component] = level.String()
+	}
+
+	result, err := response.Marshal()
+
+	if err != nil {
+		c.handleErrorResponse(err, writer)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(result)
+}
+
+func (c *LoggingController) handleErrorResponse(err error, writer http.ResponseWriter) {
+
+	var errorResponseDetails models.ErrorResponse
+	var httpError int
+
+	switch e2Error := err.(type) {
+	case *e2managererrors.RequestValidationError:
+		errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+		httpError = http.StatusBadRequest
+	case *e2managererrors.InvalidJsonError:
+		errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+		httpError = http.StatusBadRequest
+	case *e2managererrors.ResourceNotFoundError:
+		errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+		httpError = http.StatusNotFound
+	default:
+		internalError := e2managererrors.NewInternalError()
+		errorResponseDetails = models.ErrorResponse{Code: internalError.Code, Message: internalError.Message}
+		httpError = http.StatusInternalServerError
+	}
+
+	errorResponse, _ := json.Marshal(errorResponseDetails)
+
+	c.logger.Errorf("[E2 Manager -> Client] #LoggingController.handleErrorResponse - http status: %d, error response: %+v", httpError, errorResponseDetails)
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(httpError)
+	_, _ = writer.Write(errorResponse)
+}
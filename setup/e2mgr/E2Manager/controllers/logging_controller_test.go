@@ -0,0 +1,175 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package controllers
+
+import (
+	"bytes"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func setupLoggingControllerTest(t *testing.T) (*LoggingController, *logger.Logger) {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#... - failed to initialize logger, error: %s", err)
+	}
+	config := &configuration.Configuration{}
+	config.Logging.RanTraceDefaultDurationSec = 60
+	config.Logging.RanTraceMaxDurationSec = 120
+	return NewLoggingController(log, config), log
+}
+
+func newLoggingRequest(method string, body string, vars map[string]string) *http.Request {
+	req, _ := http.NewRequest(method, "/v1/logging", bytes.NewBufferString(body))
+	req.ContentLength = int64(len(body))
+	return mux.SetURLVars(req, vars)
+}
+
+func readLoggingConfigResponse(t *testing.T, writer *httptest.ResponseRecorder) *models.LoggingConfigResponse {
+	response := &models.LoggingConfigResponse{}
+	err := json.Unmarshal(writer.Body.Bytes(), response)
+	if err != nil {
+		t.Errorf("#readLoggingConfigResponse - failed to unmarshal response body: %s", err)
+	}
+	return response
+}
+
+func TestGetLoggingConfig(t *testing.T) {
+	c, log := setupLoggingControllerTest(t)
+	_ = log.SetComponentLevel("E2TKeepAliveWorker", logger.WarnLevel)
+
+	writer := httptest.NewRecorder()
+	c.GetLoggingConfig(writer, newLoggingRequest(http.MethodGet, "", nil))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	response := readLoggingConfigResponse(t, writer)
+	assert.Equal(t, "info", response.Level)
+	assert.Equal(t, map[string]string{"e2tkeepaliveworker": "warn"}, response.Components)
+	assert.Empty(t, response.TracedRans)
+}
+
+func TestSetLogLevelSuccess(t *testing.T) {
+	c, log := setupLoggingControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.SetLogLevel(writer, newLoggingRequest(http.MethodPut, `{"level":"debug"}`, nil))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "debug", readLoggingConfigResponse(t, writer).Level)
+	assert.Equal(t, logger.DebugLevel, log.Level())
+}
+
+func TestSetLogLevelInvalidLevel(t *testing.T) {
+	c, log := setupLoggingControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.SetLogLevel(writer, newLoggingRequest(http.MethodPut, `{"level":"verbose"}`, nil))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, logger.InfoLevel, log.Level())
+}
+
+func TestSetLogLevelInvalidJson(t *testing.T) {
+	c, _ := setupLoggingControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.SetLogLevel(writer, newLoggingRequest(http.MethodPut, `{"level":`, nil))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestSetAndResetComponentLogLevel(t *testing.T) {
+	c, log := setupLoggingControllerTest(t)
+	vars := map[string]string{ParamComponent: "RanSetupManager"}
+
+	writer := httptest.NewRecorder()
+	c.SetComponentLogLevel(writer, newLoggingRequest(http.MethodPut, `{"level":"debug"}`, vars))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "debug", readLoggingConfigResponse(t, writer).Components["ransetupmanager"])
+
+	writer = httptest.NewRecorder()
+	c.ResetComponentLogLevel(writer, newLoggingRequest(http.MethodDelete, "", vars))
+
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+	assert.Empty(t, log.ComponentLevels())
+}
+
+func TestResetComponentLogLevelNotFound(t *testing.T) {
+	c, _ := setupLoggingControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.ResetComponentLogLevel(writer, newLoggingRequest(http.MethodDelete, "", map[string]string{ParamComponent: "RanSetupManager"}))
+
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+}
+
+func TestTraceRanDefaultDuration(t *testing.T) {
+	c, log := setupLoggingControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.TraceRan(writer, newLoggingRequest(http.MethodPut, "", map[string]string{ParamRanName: "test"}))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), log.TracedRans()["test"], time.Second)
+	assert.Contains(t, readLoggingConfigResponse(t, writer).TracedRans, "test")
+}
+
+func TestTraceRanDurationIsCapped(t *testing.T) {
+	c, log := setupLoggingControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.TraceRan(writer, newLoggingRequest(http.MethodPut, `{"durationSec":86400}`, map[string]string{ParamRanName: "test"}))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), log.TracedRans()["test"], time.Second)
+}
+
+func TestTraceRanNegativeDuration(t *testing.T) {
+	c, log := setupLoggingControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	c.TraceRan(writer, newLoggingRequest(http.MethodPut, `{"durationSec":-1}`, map[string]string{ParamRanName: "test"}))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Empty(t, log.TracedRans())
+}
+
+func TestStopRanTrace(t *testing.T) {
+	c, log := setupLoggingControllerTest(t)
+	log.TraceRan("test", time.Minute)
+	vars := map[string]string{ParamRanName: "test"}
+
+	writer := httptest.NewRecorder()
+	c.StopRanTrace(writer, newLoggingRequest(http.MethodDelete, "", vars))
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+
+	writer = httptest.NewRecorder()
+	c.StopRanTrace(writer, newLoggingRequest(http.MethodDelete, "", vars))
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+}
@@ -20,6 +20,7 @@
 package controllers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/models"
@@ -33,11 +34,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"strconv"
 	"strings"
 )
 
 const (
 	ParamRanName = "ranName"
+	ParamDryRun  = "dryRun"
 	LimitRequest = 2000
 )
 
@@ -48,7 +51,11 @@ type INodebController interface {
 	EndcSetup(writer http.ResponseWriter, r *http.Request)
 	GetNodeb(writer http.ResponseWriter, r *http.Request)
 	UpdateGnb(writer http.ResponseWriter, r *http.Request)
+	UpdateEnb(writer http.ResponseWriter, r *http.Request)
+	UpdateGnbCells(writer http.ResponseWriter, r *http.Request)
 	GetNodebIdList(writer http.ResponseWriter, r *http.Request)
+	GetRanStatusHistory(writer http.ResponseWriter, r *http.Request)
+	GetRanLoadInformation(writer http.ResponseWriter, r *http.Request)
 }
 
 type NodebController struct {
@@ -66,7 +73,7 @@ func NewNodebController(logger *logger.Logger, handlerProvider *httpmsghandlerpr
 func (c *NodebController) GetNodebIdList(writer http.ResponseWriter, r *http.Request) {
 	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetNodebIdList - request: %v", c.prettifyRequest(r))
 
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.GetNodebIdListRequest, nil, false)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetNodebIdListRequest, nil, false)
 }
 
 func (c *NodebController) GetNodeb(writer http.ResponseWriter, r *http.Request) {
@@ -74,7 +81,23 @@ func (c *NodebController) GetNodeb(writer http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	ranName := vars["ranName"]
 	request := models.GetNodebRequest{RanName: ranName}
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.GetNodebRequest, request, false)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetNodebRequest, request, false)
+}
+
+func (c *NodebController) GetRanStatusHistory(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetRanStatusHistory - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	ranName := vars[ParamRanName]
+	request := models.GetRanStatusHistoryRequest{RanName: ranName}
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetRanStatusHistoryRequest, request, false)
+}
+
+func (c *NodebController) GetRanLoadInformation(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetRanLoadInformation - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	ranName := vars[ParamRanName]
+	request := models.GetRanLoadInformationRequest{RanName: ranName}
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetRanLoadInformationRequest, request, false)
 }
 
 func (c *NodebController) UpdateGnb(writer http.ResponseWriter, r *http.Request) {
@@ -92,12 +115,49 @@ func (c *NodebController) UpdateGnb(writer http.ResponseWriter, r *http.Request)
 
 	request.Gnb = &gnb;
 	request.RanName = ranName
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.UpdateGnbRequest, request, true)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.UpdateGnbRequest, request, true)
+}
+
+func (c *NodebController) UpdateGnbCells(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.UpdateGnbCells - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	ranName := vars[ParamRanName]
+
+	request := models.UpdateGnbCellsRequest{}
+
+	if !c.extractDryRun(r, &request.DryRun, writer) {
+		return
+	}
+
+	if !c.extractRequestBodyToCells(r, &request, writer) {
+		return
+	}
+
+	request.RanName = ranName
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.UpdateGnbCellsRequest, request, true)
+}
+
+func (c *NodebController) UpdateEnb(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.UpdateEnb - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	ranName := vars[ParamRanName]
+
+	request := models.UpdateEnbRequest{}
+
+	enb := entities.Enb{}
+
+	if !c.extractRequestBodyToProto(r, &enb, writer) {
+		return
+	}
+
+	request.Enb = &enb
+	request.RanName = ranName
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.UpdateEnbRequest, request, true)
 }
 
 func (c *NodebController) Shutdown(writer http.ResponseWriter, r *http.Request) {
 	c.logger.Infof("[Client -> E2 Manager] #NodebController.Shutdown - request: %v", c.prettifyRequest(r))
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.ShutdownRequest, nil, false)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.ShutdownRequest, nil, false)
 }
 
 func (c *NodebController) X2Reset(writer http.ResponseWriter, r *http.Request) {
@@ -110,7 +170,7 @@ func (c *NodebController) X2Reset(writer http.ResponseWriter, r *http.Request) {
 		return
 	}
 	request.RanName = ranName
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.ResetRequest, request, false)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.ResetRequest, request, false)
 }
 
 func (c *NodebController) X2Setup(writer http.ResponseWriter, r *http.Request) {
@@ -122,7 +182,7 @@ func (c *NodebController) X2Setup(writer http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.X2SetupRequest, request, true)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.X2SetupRequest, request, true)
 }
 
 func (c *NodebController) EndcSetup(writer http.ResponseWriter, r *http.Request) {
@@ -134,7 +194,41 @@ func (c *NodebController) EndcSetup(writer http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.EndcSetupRequest, request, true)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.EndcSetupRequest, request, true)
+}
+
+func (c *NodebController) extractDryRun(r *http.Request, dryRun *bool, writer http.ResponseWriter) bool {
+	value := r.URL.Query().Get(ParamDryRun)
+
+	if value == "" {
+		return true
+	}
+
+	var err error
+	*dryRun, err = strconv.ParseBool(value)
+
+	if err != nil {
+		c.logger.Errorf("[Client -> E2 Manager] #NodebController.extractDryRun - invalid %s query parameter: %s", ParamDryRun, value)
+		c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+		return false
+	}
+
+	return true
+}
+
+// extractRequestBodyToCells parses a body of cells, which like a nodeb entity is not limited to LimitRequest
+func (c *NodebController) extractRequestBodyToCells(r *http.Request, request json.Unmarshaler, writer http.ResponseWriter) bool {
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(request)
+
+	if err != nil {
+		c.logger.Errorf("[Client -> E2 Manager] #NodebController.extractRequestBodyToCells - unable to extract json body - error: %s", err)
+		c.handleErrorResponse(e2managererrors.NewInvalidJsonError(), writer)
+		return false
+	}
+
+	return true
 }
 
 func (c *NodebController) extractRequestBodyToProto(r *http.Request, pb proto.Message , writer http.ResponseWriter) bool {
@@ -171,7 +265,7 @@ func (c *NodebController) extractJsonBody(r *http.Request, request models.Reques
 	return true
 }
 
-func (c *NodebController) handleRequest(writer http.ResponseWriter, header *http.Header, requestName httpmsghandlerprovider.IncomingRequest, request models.Request, validateRequestHeaders bool) {
+func (c *NodebController) handleRequest(ctx context.Context, writer http.ResponseWriter, header *http.Header, requestName httpmsghandlerprovider.IncomingRequest, request models.Request, validateRequestHeaders bool) {
 
 	if validateRequestHeaders {
 
@@ -189,7 +283,8 @@ func (c *NodebController) handleRequest(writer http.ResponseWriter, header *http
 		return
 	}
 
-	response, err := handler.Handle(request)
+	// The request is carried out even if the client goes away, only its trace context is passed on
+	response, err := handler.Handle(context.WithoutCancel(ctx), request)
 
 	if err != nil {
 		c.handleErrorResponse(err, writer)
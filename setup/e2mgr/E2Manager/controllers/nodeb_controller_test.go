@@ -21,6 +21,7 @@ package controllers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
@@ -30,7 +31,7 @@ import (
 	"e2mgr/mocks"
 	"e2mgr/models"
 	"e2mgr/providers/httpmsghandlerprovider"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
@@ -49,6 +50,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 	"unsafe"
 )
 
@@ -64,6 +66,7 @@ const (
 var (
 	ServedNrCellInformationRequiredFields = []string{"cellId", "choiceNrMode", "nrMode", "nrPci", "servedPlmns"}
 	NrNeighbourInformationRequiredFields  = []string{"nrCgi", "choiceNrMode", "nrMode", "nrPci"}
+	ServedCellRequiredFields              = []string{"cellId", "choiceEutraMode", "eutraMode", "tac", "broadcastPlmns"}
 )
 
 type controllerGetNodebTestContext struct {
@@ -104,6 +107,19 @@ type controllerUpdateGnbTestContext struct {
 	expectedJsonResponse      string
 }
 
+type patchEnbCellsParams struct {
+	servedCellsToRemove []*entities.ServedCellInfo
+	err                 error
+}
+
+type controllerUpdateEnbTestContext struct {
+	getNodebInfoResult   *getNodebInfoResult
+	patchEnbCellsParams  *patchEnbCellsParams
+	requestBody          map[string]interface{}
+	expectedStatusCode   int
+	expectedJsonResponse string
+}
+
 func generateServedNrCells(cellIds ...string) []*entities.ServedNRCell {
 
 	servedNrCells := []*entities.ServedNRCell{}
@@ -162,6 +178,61 @@ func buildServedNrCellInformation(propToOmit string) map[string]interface{} {
 	return ret
 }
 
+func generateServedCells(cellIds ...string) []*entities.ServedCellInfo {
+
+	servedCells := []*entities.ServedCellInfo{}
+
+	for i, v := range cellIds {
+		servedCells = append(servedCells, &entities.ServedCellInfo{
+			CellId: v,
+			ChoiceEutraMode: &entities.ChoiceEUTRAMode{
+				Fdd: &entities.FddInfo{},
+			},
+			EutraMode:      entities.Eutra_FDD,
+			Pci:            uint32(i + 1),
+			Tac:            "0001",
+			BroadcastPlmns: []string{"whatever"},
+		})
+	}
+
+	return servedCells
+}
+
+func buildNeighbourInformation(propToOmit string) map[string]interface{} {
+	ret := map[string]interface{}{
+		"ecgi": "whatever",
+		"pci":  1,
+		"tac":  "0001",
+	}
+
+	if len(propToOmit) != 0 {
+		delete(ret, propToOmit)
+	}
+
+	return ret
+}
+
+func buildServedCell(propToOmit string) map[string]interface{} {
+	ret := map[string]interface{}{
+		"cellId": "whatever",
+		"choiceEutraMode": map[string]interface{}{
+			"fdd": map[string]interface{}{},
+		},
+		"eutraMode": 1,
+		"pci":       1,
+		"tac":       "0001",
+		"broadcastPlmns": []interface{}{
+			"whatever",
+		},
+	}
+
+	if len(propToOmit) != 0 {
+		delete(ret, propToOmit)
+	}
+
+	return ret
+}
+
 func setupControllerTest(t *testing.T) (*NodebController, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.RmrMessengerMock, *mocks.E2TInstancesManagerMock) {
 	log := initLog(t)
 	config := configuration.ParseConfiguration()
@@ -213,11 +284,12 @@ func TestX2SetupSuccess(t *testing.T) {
 
 	var nbUpdated2 = &entities.NodebInfo{RanName: ranName, ConnectionStatus: entities.ConnectionStatus_CONNECTING, E2ApplicationProtocol: entities.E2ApplicationProtocol_X2_SETUP_REQUEST, AssociatedE2TInstanceAddress: "10.0.2.15:8989"}
 	writerMock.On("UpdateNodebInfo", nbUpdated2).Return(nil)
+	writerMock.On("AddRanStatusChange", ranName, mock.Anything, 50).Return(nil)
 
 	payload := e2pdus.PackedX2setupRequest
 	var xAction []byte
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
 
 	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, nil)
 
@@ -244,11 +316,12 @@ func TestEndcSetupSuccess(t *testing.T) {
 
 	var nbUpdated2 = &entities.NodebInfo{RanName: ranName, ConnectionStatus: entities.ConnectionStatus_CONNECTING, E2ApplicationProtocol: entities.E2ApplicationProtocol_ENDC_X2_SETUP_REQUEST, AssociatedE2TInstanceAddress: "10.0.2.15:8989"}
 	writerMock.On("UpdateNodebInfo", nbUpdated2).Return(nil)
+	writerMock.On("AddRanStatusChange", ranName, mock.Anything, 50).Return(nil)
 
 	payload := e2pdus.PackedEndcX2setupRequest
 	var xAction []byte
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_ENDC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_ENDC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
 
 	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, nil)
 
@@ -644,6 +717,329 @@ func TestControllerUpdateGnbSuccess(t *testing.T) {
 	controllerUpdateGnbTestExecuter(t, &context)
 }
 
+func activateControllerUpdateEnbMocks(context *controllerUpdateEnbTestContext, readerMock *mocks.RnibReaderMock, writerMock *mocks.RnibWriterMock) {
+	if context.getNodebInfoResult != nil {
+		readerMock.On("GetNodeb", RanName).Return(context.getNodebInfoResult.nodebInfo, context.getNodebInfoResult.rnibError)
+	}
+
+	if context.patchEnbCellsParams != nil {
+		updatedNodebInfo := *context.getNodebInfoResult.nodebInfo
+		enb := entities.Enb{}
+		_ = jsonpb.Unmarshal(getJsonRequestAsBuffer(context.requestBody), &enb)
+		writerMock.On("PatchEnbCells", &updatedNodebInfo, enb.ServedCells, context.patchEnbCellsParams.servedCellsToRemove).Return(context.patchEnbCellsParams.err)
+
+		if context.patchEnbCellsParams.err == nil {
+			writerMock.On("RemoveCellLoadInformation", RanName, enb.ServedCells, context.patchEnbCellsParams.servedCellsToRemove).Return(nil)
+		}
+	}
+}
+
+func assertControllerUpdateEnb(t *testing.T, context *controllerUpdateEnbTestContext, writer *httptest.ResponseRecorder, readerMock *mocks.RnibReaderMock, writerMock *mocks.RnibWriterMock) {
+	assert.Equal(t, context.expectedStatusCode, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, context.expectedJsonResponse, string(bodyBytes))
+	readerMock.AssertExpectations(t)
+	writerMock.AssertExpectations(t)
+
+	if context.getNodebInfoResult == nil {
+		readerMock.AssertNotCalled(t, "GetNodeb")
+	}
+
+	if context.patchEnbCellsParams == nil {
+		writerMock.AssertNotCalled(t, "PatchEnbCells")
+	}
+}
+
+func buildUpdateEnbRequest(context *controllerUpdateEnbTestContext) *http.Request {
+	updateEnbUrl := fmt.Sprintf("/nodeb/%s/enb", RanName)
+	requestBody := getJsonRequestAsBuffer(context.requestBody)
+	req, _ := http.NewRequest(http.MethodPut, updateEnbUrl, requestBody)
+	req.Header.Set("Content-Type", "application/json")
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	return req
+}
+
+func controllerUpdateEnbTestExecuter(t *testing.T, context *controllerUpdateEnbTestContext) {
+	controller, readerMock, writerMock, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+
+	activateControllerUpdateEnbMocks(context, readerMock, writerMock)
+	req := buildUpdateEnbRequest(context)
+	controller.UpdateEnb(writer, req)
+	assertControllerUpdateEnb(t, context, writer, readerMock, writerMock)
+}
+
+func TestControllerUpdateEnbEmptyServedCells(t *testing.T) {
+	context := controllerUpdateEnbTestContext{
+		requestBody: map[string]interface{}{
+			"servedCells": []interface{}{
+			},
+		},
+		expectedStatusCode:   http.StatusBadRequest,
+		expectedJsonResponse: ValidationFailureJson,
+	}
+
+	controllerUpdateEnbTestExecuter(t, &context)
+}
+
+func TestControllerUpdateEnbMissingServedCellRequiredProp(t *testing.T) {
+
+	for _, v := range ServedCellRequiredFields {
+		context := controllerUpdateEnbTestContext{
+			requestBody: map[string]interface{}{
+				"servedCells": []interface{}{
+					buildServedCell(v),
+				},
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedJsonResponse: ValidationFailureJson,
+		}
+
+		controllerUpdateEnbTestExecuter(t, &context)
+	}
+}
+
+func TestControllerUpdateEnbMissingServedCellFddOrTdd(t *testing.T) {
+
+	servedCell := buildServedCell("")
+	servedCell["choiceEutraMode"] = map[string]interface{}{}
+
+	context := controllerUpdateEnbTestContext{
+		requestBody: map[string]interface{}{
+			"servedCells": []interface{}{
+				servedCell,
+			},
+		},
+		expectedStatusCode:   http.StatusBadRequest,
+		expectedJsonResponse: ValidationFailureJson,
+	}
+
+	controllerUpdateEnbTestExecuter(t, &context)
+}
+
+func TestControllerUpdateEnbMissingNeighbourInformationEcgi(t *testing.T) {
+
+	servedCell := buildServedCell("")
+	servedCell["neighbourInfos"] = []interface{}{
+		buildNeighbourInformation("ecgi"),
+	}
+
+	context := controllerUpdateEnbTestContext{
+		requestBody: map[string]interface{}{
+			"servedCells": []interface{}{
+				servedCell,
+			},
+		},
+		expectedStatusCode:   http.StatusBadRequest,
+		expectedJsonResponse: ValidationFailureJson,
+	}
+
+	controllerUpdateEnbTestExecuter(t, &context)
+}
+
+func TestControllerUpdateEnbValidServedCellGetNodebNotFound(t *testing.T) {
+	context := controllerUpdateEnbTestContext{
+		getNodebInfoResult: &getNodebInfoResult{
+			nodebInfo: nil,
+			rnibError: common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"),
+		},
+		requestBody: map[string]interface{}{
+			"servedCells": []interface{}{
+				buildServedCell(""),
+			},
+		},
+		expectedStatusCode:   http.StatusNotFound,
+		expectedJsonResponse: ResourceNotFoundJson,
+	}
+
+	controllerUpdateEnbTestExecuter(t, &context)
+}
+
+func TestControllerUpdateEnbValidServedCellGetNodebInternalError(t *testing.T) {
+	context := controllerUpdateEnbTestContext{
+		getNodebInfoResult: &getNodebInfoResult{
+			nodebInfo: nil,
+			rnibError: common.NewInternalError(errors.New("#reader.GetNodeb - Internal Error")),
+		},
+		requestBody: map[string]interface{}{
+			"servedCells": []interface{}{
+				buildServedCell(""),
+			},
+		},
+		expectedStatusCode:   http.StatusInternalServerError,
+		expectedJsonResponse: RnibErrorJson,
+	}
+
+	controllerUpdateEnbTestExecuter(t, &context)
+}
+
+func TestControllerUpdateEnbGetNodebSuccessInvalidEnbConfiguration(t *testing.T) {
+	context := controllerUpdateEnbTestContext{
+		getNodebInfoResult: &getNodebInfoResult{
+			nodebInfo: &entities.NodebInfo{
+				RanName:                      RanName,
+				ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
+				AssociatedE2TInstanceAddress: AssociatedE2TInstanceAddress,
+				Configuration:                &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{}},
+			},
+			rnibError: nil,
+		},
+		requestBody: map[string]interface{}{
+			"servedCells": []interface{}{
+				buildServedCell(""),
+			},
+		},
+		expectedStatusCode:   http.StatusInternalServerError,
+		expectedJsonResponse: InternalErrorJson,
+	}
+
+	controllerUpdateEnbTestExecuter(t, &context)
+}
+
+func TestControllerUpdateEnbGetNodebSuccessPatchEnbCellsFailure(t *testing.T) {
+	oldServedCells := generateServedCells("whatever1", "whatever2")
+	context := controllerUpdateEnbTestContext{
+		patchEnbCellsParams: &patchEnbCellsParams{
+			servedCellsToRemove: oldServedCells,
+			err:                 common.NewInternalError(errors.New("#writer.PatchEnbCells - Internal Error")),
+		},
+		getNodebInfoResult: &getNodebInfoResult{
+			nodebInfo: &entities.NodebInfo{
+				RanName:                      RanName,
+				ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
+				AssociatedE2TInstanceAddress: AssociatedE2TInstanceAddress,
+				Configuration:                &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: oldServedCells}},
+			},
+			rnibError: nil,
+		},
+		requestBody: map[string]interface{}{
+			"servedCells": []interface{}{
+				buildServedCell(""),
+			},
+		},
+		expectedStatusCode:   http.StatusInternalServerError,
+		expectedJsonResponse: RnibErrorJson,
+	}
+
+	controllerUpdateEnbTestExecuter(t, &context)
+}
+
+func TestControllerUpdateEnbSuccess(t *testing.T) {
+	oldServedCells := generateServedCells("whatever1", "whatever2")
+	servedCell := buildServedCell("")
+	servedCell["neighbourInfos"] = []interface{}{
+		buildNeighbourInformation(""),
+	}
+
+	context := controllerUpdateEnbTestContext{
+		patchEnbCellsParams: &patchEnbCellsParams{
+			servedCellsToRemove: oldServedCells,
+			err:                 nil,
+		},
+		getNodebInfoResult: &getNodebInfoResult{
+			nodebInfo: &entities.NodebInfo{
+				RanName:                      RanName,
+				ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
+				AssociatedE2TInstanceAddress: AssociatedE2TInstanceAddress,
+				Configuration:                &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: oldServedCells}},
+			},
+			rnibError: nil,
+		},
+		requestBody: map[string]interface{}{
+			"servedCells": []interface{}{
+				servedCell,
+			},
+		},
+		expectedStatusCode:   http.StatusOK,
+		expectedJsonResponse: "{\"ranName\":\"test\",\"connectionStatus\":\"CONNECTED\",\"enb\":{\"servedCells\":[{\"pci\":1,\"cellId\":\"whatever\",\"tac\":\"0001\",\"broadcastPlmns\":[\"whatever\"],\"choiceEutraMode\":{\"fdd\":{}},\"eutraMode\":\"FDD\",\"neighbourInfos\":[{\"ecgi\":\"whatever\",\"pci\":1,\"tac\":\"0001\"}]}]},\"associatedE2tInstanceAddress\":\"10.0.2.15:38000\"}",
+	}
+
+	controllerUpdateEnbTestExecuter(t, &context)
+}
+
+func buildUpdateGnbCellsRequest(query string, requestBody io.Reader) *http.Request {
+	updateGnbCellsUrl := fmt.Sprintf("/nodeb/%s/update%s", RanName, query)
+	req, _ := http.NewRequest(http.MethodPatch, updateGnbCellsUrl, requestBody)
+	req.Header.Set("Content-Type", "application/json")
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	return req
+}
+
+func generateUpdateGnbCellsNodebInfo(cellIds ...string) *entities.NodebInfo {
+	servedNrCells := []*entities.ServedNRCell{}
+
+	for i, v := range cellIds {
+		servedNrCells = append(servedNrCells, &entities.ServedNRCell{ServedNrCellInformation: &entities.ServedNRCellInformation{CellId: v, NrPci: uint32(i + 1)}})
+	}
+
+	return &entities.NodebInfo{
+		RanName:                      RanName,
+		ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
+		AssociatedE2TInstanceAddress: AssociatedE2TInstanceAddress,
+		Configuration:                &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{ServedNrCells: servedNrCells}},
+	}
+}
+
+func TestControllerUpdateGnbCellsDryRunSuccess(t *testing.T) {
+	controller, readerMock, writerMock, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	readerMock.On("GetNodeb", RanName).Return(generateUpdateGnbCellsNodebInfo("whatever1", "whatever2"), nil)
+	requestBody := getJsonRequestAsBuffer(map[string]interface{}{"servedNrCellsToDelete": []interface{}{"whatever1"}})
+
+	controller.UpdateGnbCells(writer, buildUpdateGnbCellsRequest("?dryRun=true", requestBody))
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, "{\"ranName\":\"test\",\"dryRun\":true,\"added\":[],\"modified\":[],\"deleted\":[{\"servedNrCellInformation\":{\"nrPci\":1,\"cellId\":\"whatever1\"}}]}", string(bodyBytes))
+	writerMock.AssertNotCalled(t, "PatchGnbCells", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestControllerUpdateGnbCellsSuccess(t *testing.T) {
+	controller, readerMock, writerMock, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	nodebInfo := generateUpdateGnbCellsNodebInfo("whatever1", "whatever2")
+	servedNrCells := nodebInfo.GetGnb().ServedNrCells
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	updatedNodebInfo := generateUpdateGnbCellsNodebInfo()
+	updatedNodebInfo.GetGnb().ServedNrCells = servedNrCells[1:]
+	writerMock.On("PatchGnbCells", updatedNodebInfo, []*entities.ServedNRCell{}, servedNrCells[:1]).Return(nil)
+	requestBody := getJsonRequestAsBuffer(map[string]interface{}{"servedNrCellsToDelete": []interface{}{"whatever1"}})
+
+	controller.UpdateGnbCells(writer, buildUpdateGnbCellsRequest("", requestBody))
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, "{\"ranName\":\"test\",\"dryRun\":false,\"added\":[],\"modified\":[],\"deleted\":[{\"servedNrCellInformation\":{\"nrPci\":1,\"cellId\":\"whatever1\"}}]}", string(bodyBytes))
+	writerMock.AssertExpectations(t)
+}
+
+func TestControllerUpdateGnbCellsInvalidDryRun(t *testing.T) {
+	controller, readerMock, _, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	requestBody := getJsonRequestAsBuffer(map[string]interface{}{"servedNrCellsToDelete": []interface{}{"whatever1"}})
+
+	controller.UpdateGnbCells(writer, buildUpdateGnbCellsRequest("?dryRun=maybe", requestBody))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, ValidationFailureJson, string(bodyBytes))
+	readerMock.AssertNotCalled(t, "GetNodeb", RanName)
+}
+
+func TestControllerUpdateGnbCellsInvalidServedNrCell(t *testing.T) {
+	controller, readerMock, _, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	requestBody := strings.NewReader("{\"servedNrCellsToAdd\":[{\"servedNrCellInformation\":{\"nrPci\":\"one\"}}]}")
+
+	controller.UpdateGnbCells(writer, buildUpdateGnbCellsRequest("", requestBody))
+
+	var errorResponse = parseJsonRequest(t, writer.Body)
+
+	assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+	assert.Equal(t, e2managererrors.NewInvalidJsonError().Code, errorResponse.Code)
+	readerMock.AssertNotCalled(t, "GetNodeb", RanName)
+}
+
 func getJsonRequestAsBuffer(requestJson map[string]interface{}) *bytes.Buffer {
 	b := new(bytes.Buffer)
 	_ = json.NewEncoder(b).Encode(requestJson)
@@ -693,6 +1089,78 @@ func TestControllerGetNodebInternal(t *testing.T) {
 	controllerGetNodebTestExecuter(t, &context)
 }
 
+func TestControllerGetRanStatusHistorySuccess(t *testing.T) {
+	controller, readerMock, writerMock, _, _ := setupControllerTest(t)
+	timestamp := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	history := []*models.RanStatusChange{
+		{Timestamp: timestamp, PreviousStatus: "CONNECTED", NewStatus: "DISCONNECTED", Cause: "RAN connection lost", Component: "RanDisconnectionManager"},
+	}
+	readerMock.On("GetNodeb", RanName).Return(&entities.NodebInfo{RanName: RanName}, nil)
+	writerMock.On("GetRanStatusHistory", RanName).Return(history, nil)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/test/history", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.GetRanStatusHistory(writer, req)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	expected := "{\"ranName\":\"test\",\"history\":[{\"timestamp\":\"2020-05-01T10:00:00Z\",\"previousStatus\":\"CONNECTED\",\"newStatus\":\"DISCONNECTED\",\"cause\":\"RAN connection lost\",\"component\":\"RanDisconnectionManager\"}]}"
+	assert.Equal(t, expected, string(bodyBytes))
+}
+
+func TestControllerGetRanStatusHistoryNotFound(t *testing.T) {
+	controller, readerMock, writerMock, _, _ := setupControllerTest(t)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/test/history", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.GetRanStatusHistory(writer, req)
+
+	assert.Equal(t, http.StatusNotFound, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, ResourceNotFoundJson, string(bodyBytes))
+	writerMock.AssertNotCalled(t, "GetRanStatusHistory", RanName)
+}
+
+func TestControllerGetRanLoadInformationSuccess(t *testing.T) {
+	controller, readerMock, writerMock, _, _ := setupControllerTest(t)
+	timestamp := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	cells := []*models.CellLoadInformation{
+		{Timestamp: timestamp, LoadInformation: &entities.CellLoadInformation{CellId: "02f829:0007ab50", RelativeNarrowbandTxPower: &entities.RelativeNarrowbandTxPower{RntpPerPrb: "cc", PB: 2, PdcchInterferenceImpact: 1}}},
+	}
+	readerMock.On("GetNodeb", RanName).Return(&entities.NodebInfo{RanName: RanName}, nil)
+	writerMock.On("GetCellLoadInformation", RanName).Return(cells, nil)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/test/load", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.GetRanLoadInformation(writer, req)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	expected := "{\"ranName\":\"test\",\"cells\":[{\"cellId\":\"02f829:0007ab50\",\"timestamp\":\"2020-05-01T10:00:00Z\",\"loadInformation\":{\"cellId\":\"02f829:0007ab50\",\"relativeNarrowbandTxPower\":{\"rntpPerPrb\":\"cc\",\"pB\":2,\"pdcchInterferenceImpact\":1}}}]}"
+	assert.Equal(t, expected, string(bodyBytes))
+}
+
+func TestControllerGetRanLoadInformationNotFound(t *testing.T) {
+	controller, readerMock, writerMock, _, _ := setupControllerTest(t)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/test/load", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.GetRanLoadInformation(writer, req)
+
+	assert.Equal(t, http.StatusNotFound, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, ResourceNotFoundJson, string(bodyBytes))
+	writerMock.AssertNotCalled(t, "GetCellLoadInformation", RanName)
+}
+
 func TestControllerGetNodebIdListSuccess(t *testing.T) {
 	var rnibError error
 	nodebIdList := []*entities.NbIdentity{
@@ -743,7 +1211,7 @@ func TestHeaderValidationFailed(t *testing.T) {
 
 	header := &http.Header{}
 
-	controller.handleRequest(writer, header, httpmsghandlerprovider.ShutdownRequest, nil, true)
+	controller.handleRequest(context.Background(), writer, header, httpmsghandlerprovider.ShutdownRequest, nil, true)
 
 	var errorResponse = parseJsonRequest(t, writer.Body)
 	err := e2managererrors.NewHeaderValidationError()
@@ -855,7 +1323,7 @@ func TestX2ResetHandleSuccessfulRequestedCause(t *testing.T) {
 	payload := []byte{0x00, 0x07, 0x00, 0x08, 0x00, 0x00, 0x01, 0x00, 0x05, 0x40, 0x01, 0x40}
 	var xAction []byte
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", msg, mock.Anything).Return(msg, nil)
 
 	writer := httptest.NewRecorder()
@@ -882,7 +1350,7 @@ func TestX2ResetHandleSuccessfulRequestedDefault(t *testing.T) {
 	payload := []byte{0x00, 0x07, 0x00, 0x08, 0x00, 0x00, 0x01, 0x00, 0x05, 0x40, 0x01, 0x64}
 	var xAction []byte
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil)
 
 	writer := httptest.NewRecorder()
@@ -954,7 +1422,7 @@ func TestHandleErrorResponse(t *testing.T) {
 }
 
 func getRmrSender(rmrMessengerMock *mocks.RmrMessengerMock, log *logger.Logger) *rmrsender.RmrSender {
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrMessengerMock.On("Init", tests.GetPort(), tests.MaxMsgSize, tests.Flags, log).Return(&rmrMessenger)
 	return rmrsender.NewRmrSender(log, rmrMessenger)
 }
@@ -21,21 +21,27 @@
 package controllers
 
 import (
+	"e2mgr/healthcheck"
+	"e2mgr/models"
 	"e2mgr/services"
 	"net/http"
 )
 
 type IRootController interface {
 	HandleHealthCheckRequest(writer http.ResponseWriter, request *http.Request)
+	HandleLivenessRequest(writer http.ResponseWriter, request *http.Request)
+	HandleReadinessRequest(writer http.ResponseWriter, request *http.Request)
 }
 
 type RootController struct {
 	rnibDataService services.RNibDataService
+	healthChecker   healthcheck.IHealthChecker
 }
 
-func NewRootController(rnibDataService services.RNibDataService) *RootController {
+func NewRootController(rnibDataService services.RNibDataService, healthChecker healthcheck.IHealthChecker) *RootController {
 	return &RootController{
 		rnibDataService: rnibDataService,
+		healthChecker:   healthChecker,
 	}
 }
 
@@ -48,3 +54,30 @@ func (rc *RootController) HandleHealthCheckRequest(writer http.ResponseWriter, r
 
 	writer.WriteHeader(httpStatus)
 }
+
+func (rc *RootController) HandleLivenessRequest(writer http.ResponseWriter, request *http.Request) {
+	rc.writeHealthCheckResponse(writer, rc.healthChecker.Live())
+}
+
+func (rc *RootController) HandleReadinessRequest(writer http.ResponseWriter, request *http.Request) {
+	rc.writeHealthCheckResponse(writer, rc.healthChecker.Ready())
+}
+
+func (rc *RootController) writeHealthCheckResponse(writer http.ResponseWriter, response *models.HealthCheckResponse) {
+	httpStatus := http.StatusOK
+
+	if response.Status == models.HealthStatusDown {
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	result, err := response.Marshal()
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(httpStatus)
+	_, _ = writer.Write(result)
+}
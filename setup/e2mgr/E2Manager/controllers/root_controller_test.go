@@ -22,9 +22,13 @@ package controllers
 
 import (
 	"e2mgr/configuration"
+	"e2mgr/healthcheck"
 	"e2mgr/logger"
 	"e2mgr/mocks"
+	"e2mgr/models"
 	"e2mgr/services"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -51,7 +55,7 @@ func setupNodebControllerTest(t *testing.T) (services.RNibDataService, *mocks.Rn
 
 func TestNewRequestController(t *testing.T) {
 	rnibDataService, _ := setupNodebControllerTest(t)
-	assert.NotNil(t, NewRootController(rnibDataService))
+	assert.NotNil(t, NewRootController(rnibDataService, nil))
 }
 
 func TestHandleHealthCheckRequestGood(t *testing.T) {
@@ -60,7 +64,7 @@ func TestHandleHealthCheckRequestGood(t *testing.T) {
 	var nbList []*entities.NbIdentity
 	rnibReaderMock.On("GetListNodebIds").Return(nbList, nil)
 
-	rc := NewRootController(rnibDataService)
+	rc := NewRootController(rnibDataService, nil)
 	writer := httptest.NewRecorder()
 	rc.HandleHealthCheckRequest(writer, nil)
 	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
@@ -73,7 +77,7 @@ func TestHandleHealthCheckRequestOtherError(t *testing.T) {
 	var nbList []*entities.NbIdentity
 	rnibReaderMock.On("GetListNodebIds").Return(nbList, mockOtherErr)
 
-	rc := NewRootController(rnibDataService)
+	rc := NewRootController(rnibDataService, nil)
 	writer := httptest.NewRecorder()
 	rc.HandleHealthCheckRequest(writer, nil)
 	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
@@ -87,8 +91,84 @@ func TestHandleHealthCheckRequestConnError(t *testing.T) {
 	rnibReaderMock.On("GetListNodebIds").Return(nbList, mockConnErr)
 
 
-	rc := NewRootController(rnibDataService)
+	rc := NewRootController(rnibDataService, nil)
 	writer := httptest.NewRecorder()
 	rc.HandleHealthCheckRequest(writer, nil)
 	assert.Equal(t, http.StatusInternalServerError, writer.Result().StatusCode)
-}
\ No newline at end of file
+}
+
+func setupHealthCheckTest(t *testing.T, critical map[string]bool) (*RootController, *healthcheck.HealthChecker) {
+	rnibDataService, _ := setupNodebControllerTest(t)
+	log, err := logger.InitLogger(logger.DebugLevel)
+	if err != nil {
+		t.Errorf("#... - failed to initialize logger, error: %s", err)
+	}
+	config := &configuration.Configuration{}
+	config.Health.Critical = critical
+	healthChecker := healthcheck.NewHealthChecker(log, config)
+	return NewRootController(rnibDataService, healthChecker), healthChecker
+}
+
+func readHealthCheckResponse(t *testing.T, writer *httptest.ResponseRecorder) *models.HealthCheckResponse {
+	response := &models.HealthCheckResponse{}
+	err := json.Unmarshal(writer.Body.Bytes(), response)
+	if err != nil {
+		t.Errorf("#readHealthCheckResponse - failed to unmarshal response body: %s", err)
+	}
+	return response
+}
+
+func TestHandleReadinessRequestUp(t *testing.T) {
+	rc, healthChecker := setupHealthCheckTest(t, nil)
+	healthChecker.Register("rnib", true, healthcheck.CheckerFunc(func() error { return nil }))
+
+	writer := httptest.NewRecorder()
+	rc.HandleReadinessRequest(writer, nil)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	assert.Equal(t, "application/json", writer.Header().Get("Content-Type"))
+	response := readHealthCheckResponse(t, writer)
+	assert.Equal(t, models.HealthStatusUp, response.Status)
+	assert.Len(t, response.Checks, 1)
+}
+
+func TestHandleReadinessRequestCriticalCheckDown(t *testing.T) {
+	rc, healthChecker := setupHealthCheckTest(t, nil)
+	healthChecker.Register("rnib", true, healthcheck.CheckerFunc(func() error { return errors.New("rNib is unreachable") }))
+
+	writer := httptest.NewRecorder()
+	rc.HandleReadinessRequest(writer, nil)
+
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Result().StatusCode)
+	response := readHealthCheckResponse(t, writer)
+	assert.Equal(t, models.HealthStatusDown, response.Status)
+	assert.Equal(t, "rNib is unreachable", response.Checks[0].Error)
+}
+
+func TestHandleReadinessRequestConfiguredNonCriticalCheckDown(t *testing.T) {
+	rc, healthChecker := setupHealthCheckTest(t, map[string]bool{"rnib": false})
+	healthChecker.Register("rnib", true, healthcheck.CheckerFunc(func() error { return errors.New("rNib is unreachable") }))
+
+	writer := httptest.NewRecorder()
+	rc.HandleReadinessRequest(writer, nil)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	response := readHealthCheckResponse(t, writer)
+	assert.Equal(t, models.HealthStatusDegraded, response.Status)
+	assert.False(t, response.Checks[0].Critical)
+}
+
+func TestHandleLivenessRequestRunsLivenessChecksOnly(t *testing.T) {
+	rc, healthChecker := setupHealthCheckTest(t, nil)
+	healthChecker.Register("rnib", true, healthcheck.CheckerFunc(func() error { return errors.New("rNib is unreachable") }))
+	healthChecker.RegisterLiveness("keepAlive", true, healthcheck.CheckerFunc(func() error { return nil }))
+
+	writer := httptest.NewRecorder()
+	rc.HandleLivenessRequest(writer, nil)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	response := readHealthCheckResponse(t, writer)
+	assert.Equal(t, models.HealthStatusUp, response.Status)
+	assert.Len(t, response.Checks, 1)
+	assert.Equal(t, "keepAlive", response.Checks[0].Name)
+}
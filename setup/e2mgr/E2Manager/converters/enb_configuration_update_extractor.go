@@ -0,0 +1,141 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package converters
+
+// #cgo CFLAGS: -I../3rdparty/asn1codec/inc/ -I../3rdparty/asn1codec/e2ap_engine/
+// #cgo LDFLAGS: -L ../3rdparty/asn1codec/lib/ -L../3rdparty/asn1codec/e2ap_engine/ -le2ap_codec -lasncodec
+// #include <asn1codec_utils.h>
+// #include <InitiatingMessage.h>
+// #include <ServedCellsToModify-Item.h>
+import "C"
+import (
+	"e2mgr/e2pdus"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"fmt"
+	"unsafe"
+)
+
+type EnbConfigurationUpdateExtractor struct {
+	logger *logger.Logger
+}
+
+type IEnbConfigurationUpdateExtractor interface {
+	ExtractEnbConfigurationUpdate(packedBuffer []byte) (*models.EnbConfigurationUpdate, error)
+}
+
+func NewEnbConfigurationUpdateExtractor(logger *logger.Logger) *EnbConfigurationUpdateExtractor {
+	return &EnbConfigurationUpdateExtractor{
+		logger: logger,
+	}
+}
+
+func getServedCellsToModify(servedCellsToModify *C.ServedCellsToModify_t) ([]*models.ServedCellToModify, error) {
+	var cells []*models.ServedCellToModify
+
+	if servedCellsToModify != nil && servedCellsToModify.list.count > 0 && servedCellsToModify.list.count <= maxCellineNB {
+		count := int(servedCellsToModify.list.count)
+		servedCellsToModify_Item_slice := (*[1 << 30]*C.ServedCellsToModify_Item_t)(unsafe.Pointer(servedCellsToModify.list.array))[:count:count]
+		for _, item := range servedCellsToModify_Item_slice {
+			servedCellInfo, err := getServedCellInfo(&item.servedCellInfo, item.neighbour_Info)
+			if err != nil {
+				return nil, err
+			}
+
+			cells = append(cells, &models.ServedCellToModify{OldCellId: getECGI(&item.old_ecgi), ServedCellInfo: servedCellInfo})
+		}
+	}
+
+	return cells, nil
+}
+
+func getOldECGIs(oldECGIs *C.Old_ECGIs_t) []string {
+	var cellIds []string
+
+	if oldECGIs != nil && oldECGIs.list.count > 0 && oldECGIs.list.count <= maxCellineNB {
+		count := int(oldECGIs.list.count)
+		ecgi_slice := (*[1 << 30]*C.ECGI_t)(unsafe.Pointer(oldECGIs.list.array))[:count:count]
+		for _, ecgi := range ecgi_slice {
+			cellIds = append(cellIds, getECGI(ecgi))
+		}
+	}
+
+	return cellIds
+}
+
+func enbConfigurationUpdateToProtobuf(pdu *C.E2AP_PDU_t) (*models.EnbConfigurationUpdate, error) {
+
+	if pdu.present != C.E2AP_PDU_PR_initiatingMessage {
+		return nil, fmt.Errorf("#enb_configuration_update_extractor.enbConfigurationUpdateToProtobuf - Invalid E2AP_PDU value")
+	}
+
+	//dereference a union of pointers (C union is represented as a byte array with the size of the largest member)
+	initiatingMessage := *(**C.InitiatingMessage_t)(unsafe.Pointer(&pdu.choice[0]))
+
+	if initiatingMessage == nil || initiatingMessage.value.present != C.InitiatingMessage__value_PR_ENBConfigurationUpdate {
+		return nil, fmt.Errorf("#enb_configuration_update_extractor.enbConfigurationUpdateToProtobuf - Unexpected InitiatingMessage value")
+	}
+
+	enbConfigurationUpdate := (*C.ENBConfigurationUpdate_t)(unsafe.Pointer(&initiatingMessage.value.choice[0]))
+	update := &models.EnbConfigurationUpdate{}
+
+	if enbConfigurationUpdate.protocolIEs.list.count == 0 {
+		return update, nil
+	}
+
+	count := int(enbConfigurationUpdate.protocolIEs.list.count)
+	enbConfigurationUpdate_IEs_slice := (*[1 << 30]*C.ENBConfigurationUpdate_IEs_t)(unsafe.Pointer(enbConfigurationUpdate.protocolIEs.list.array))[:count:count]
+
+	for _, enbConfigurationUpdate_IE := range enbConfigurationUpdate_IEs_slice {
+		switch enbConfigurationUpdate_IE.value.present {
+		case C.ENBConfigurationUpdate_IEs__value_PR_ServedCells:
+			servedCells, err := getServedCells((*C.ServedCells_t)(unsafe.Pointer(&enbConfigurationUpdate_IE.value.choice[0])))
+			if err != nil {
+				return nil, err
+			}
+			update.ServedCellsToAdd = servedCells
+		case C.ENBConfigurationUpdate_IEs__value_PR_ServedCellsToModify:
+			servedCellsToModify, err := getServedCellsToModify((*C.ServedCellsToModify_t)(unsafe.Pointer(&enbConfigurationUpdate_IE.value.choice[0])))
+			if err != nil {
+				return nil, err
+			}
+			update.ServedCellsToModify = servedCellsToModify
+		case C.ENBConfigurationUpdate_IEs__value_PR_Old_ECGIs:
+			update.ServedCellsToDelete = getOldECGIs((*C.Old_ECGIs_t)(unsafe.Pointer(&enbConfigurationUpdate_IE.value.choice[0])))
+		case C.ENBConfigurationUpdate_IEs__value_PR_GUGroupIDList:
+			/*ignored*/
+		case C.ENBConfigurationUpdate_IEs__value_PR_CoverageModificationList:
+			/*ignored*/
+		}
+	}
+
+	return update, nil
+}
+
+// ExtractEnbConfigurationUpdate decodes the served cells an ENB CONFIGURATION UPDATE adds, modifies and deletes
+func (e *EnbConfigurationUpdateExtractor) ExtractEnbConfigurationUpdate(packedBuffer []byte) (*models.EnbConfigurationUpdate, error) {
+	pdu, err := UnpackX2apPdu(e.logger, e2pdus.MaxAsn1CodecAllocationBufferSize, len(packedBuffer), packedBuffer, e2pdus.MaxAsn1CodecMessageBufferSize)
+	if err != nil {
+		return nil, err
+	}
+
+	defer C.delete_pdu(pdu)
+	return enbConfigurationUpdateToProtobuf(pdu)
+}
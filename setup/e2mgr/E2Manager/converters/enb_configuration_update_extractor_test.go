@@ -0,0 +1,133 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package converters
+
+import (
+	"e2mgr/logger"
+	"e2mgr/models"
+	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// An ENB CONFIGURATION UPDATE which adds cell 0007ad50 with cell 0007ab50 as its neighbour, moves cell 0007ab50 to
+// PCI 98 and deletes cell 0007ac50
+const (
+	EnbConfigurationUpdateAperPdu      = "0008005b00000300190025004000650002f8290007ad50103002f82900000300033300010002f8290007ab5000620002001a001e000002f8290007ab5000620002f8290007ab50102002f829000002000233001b0009000002f8290007ac50"
+	EnbConfigurationUpdateEmptyAperPdu = "00080003000000"
+)
+
+func initEnbConfigurationUpdateExtractorTest(t *testing.T) *EnbConfigurationUpdateExtractor {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Fatalf("#initEnbConfigurationUpdateExtractorTest - failed to initialize logger, error: %s", err)
+	}
+	return NewEnbConfigurationUpdateExtractor(log)
+}
+
+func decodePackedPdu(t testing.TB, packedPdu string) []byte {
+	var payload []byte
+	_, err := fmt.Sscanf(packedPdu, "%x", &payload)
+	if err != nil {
+		t.Fatalf("convert packed pdu to bytes. Error: %v\n", err)
+	}
+	return payload
+}
+
+func fddServedCellInfo(pci uint32, cellId string, tac string, earFcn uint32) *entities.ServedCellInfo {
+	return &entities.ServedCellInfo{
+		Pci:            pci,
+		CellId:         cellId,
+		Tac:            tac,
+		BroadcastPlmns: []string{"02f829"},
+		EutraMode:      entities.Eutra_FDD,
+		ChoiceEutraMode: &entities.ChoiceEUTRAMode{Fdd: &entities.FddInfo{
+			UlearFcn:                earFcn,
+			DlearFcn:                earFcn,
+			UlTransmissionBandwidth: entities.TransmissionBandwidth_BW50,
+			DlTransmissionBandwidth: entities.TransmissionBandwidth_BW50,
+		}},
+	}
+}
+
+func TestExtractEnbConfigurationUpdate(t *testing.T) {
+	extractor := initEnbConfigurationUpdateExtractorTest(t)
+
+	addedCell := fddServedCellInfo(101, "02f829:0007ad50", "0103", 3)
+	addedCell.NeighbourInfos = []*entities.NeighbourInformation{{Ecgi: "02f829:0007ab50", Pci: 98, EarFcn: 2}}
+
+	expected := &models.EnbConfigurationUpdate{
+		ServedCellsToAdd:    []*entities.ServedCellInfo{addedCell},
+		ServedCellsToModify: []*models.ServedCellToModify{{OldCellId: "02f829:0007ab50", ServedCellInfo: fddServedCellInfo(98, "02f829:0007ab50", "0102", 2)}},
+		ServedCellsToDelete: []string{"02f829:0007ac50"},
+	}
+
+	update, err := extractor.ExtractEnbConfigurationUpdate(decodePackedPdu(t, EnbConfigurationUpdateAperPdu))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, update)
+}
+
+func TestExtractEnbConfigurationUpdateNoChanges(t *testing.T) {
+	extractor := initEnbConfigurationUpdateExtractorTest(t)
+
+	update, err := extractor.ExtractEnbConfigurationUpdate(decodePackedPdu(t, EnbConfigurationUpdateEmptyAperPdu))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &models.EnbConfigurationUpdate{}, update)
+}
+
+func TestExtractEnbConfigurationUpdateFailure(t *testing.T) {
+	extractor := initEnbConfigurationUpdateExtractorTest(t)
+
+	var testCases = []struct {
+		name      string
+		packedPdu string
+		expected  string
+	}{
+		{name: "garbage", packedPdu: "12312312", expected: "unpacking error"},
+		{name: "x2 setup response", packedPdu: "2006002a000002001500080002f82900007a8000140017000000630002f8290007ab50102002f829000001000133", expected: "Invalid E2AP_PDU value"},
+		{name: "x2 reset request", packedPdu: "000700080000010005400164", expected: "Unexpected InitiatingMessage value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			update, err := extractor.ExtractEnbConfigurationUpdate(decodePackedPdu(t, tc.packedPdu))
+
+			assert.Nil(t, update)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func FuzzExtractEnbConfigurationUpdate(f *testing.F) {
+	for _, packedPdu := range []string{EnbConfigurationUpdateAperPdu, EnbConfigurationUpdateEmptyAperPdu} {
+		f.Add(decodePackedPdu(f, packedPdu))
+	}
+
+	log, _ := logger.InitLogger(logger.InfoLevel)
+	extractor := NewEnbConfigurationUpdateExtractor(log)
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		_, _ = extractor.ExtractEnbConfigurationUpdate(payload)
+	})
+}
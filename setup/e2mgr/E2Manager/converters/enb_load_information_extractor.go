@@ -19,13 +19,14 @@
 
 
 package converters
-/*
+
 // #cgo CFLAGS: -I../3rdparty/asn1codec/inc/  -I../3rdparty/asn1codec/e2ap_engine/
 // #cgo LDFLAGS: -L ../3rdparty/asn1codec/lib/ -L../3rdparty/asn1codec/e2ap_engine/ -le2ap_codec -lasncodec
 // #include <asn1codec_utils.h>
 // #include <load_information_wrapper.h>
 import "C"
 import (
+	"e2mgr/e2pdus"
 	"e2mgr/logger"
 	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -45,7 +46,7 @@ type EnbLoadInformationExtractor struct {
 }
 
 type IEnbLoadInformationExtractor interface {
-	ExtractAndBuildRanLoadInformation(pdu *C.E2AP_PDU_t, ranLoadInformation *entities.RanLoadInformation) error
+	ExtractAndBuildRanLoadInformation(packedBuffer []byte, ranLoadInformation *entities.RanLoadInformation) error
 }
 
 func NewEnbLoadInformationExtractor(logger *logger.Logger) *EnbLoadInformationExtractor {
@@ -667,7 +668,18 @@ func populateCellLoadInformationExtensionIEs(cellId string, cellLoadInformation
 	return nil
 }
 
-func (*EnbLoadInformationExtractor) ExtractAndBuildRanLoadInformation(pdu *C.E2AP_PDU_t, ranLoadInformation *entities.RanLoadInformation) error {
+func (e *EnbLoadInformationExtractor) ExtractAndBuildRanLoadInformation(packedBuffer []byte, ranLoadInformation *entities.RanLoadInformation) error {
+	pdu, err := UnpackX2apPdu(e.logger, e2pdus.MaxAsn1CodecAllocationBufferSize, len(packedBuffer), packedBuffer, e2pdus.MaxAsn1CodecMessageBufferSize)
+
+	if err != nil {
+		return err
+	}
+
+	return extractAndBuildRanLoadInformation(pdu, ranLoadInformation)
+}
+
+// extractAndBuildRanLoadInformation takes ownership of the pdu and releases it
+func extractAndBuildRanLoadInformation(pdu *C.E2AP_PDU_t, ranLoadInformation *entities.RanLoadInformation) error {
 
 	defer C.delete_pdu(pdu)
 
@@ -687,4 +699,3 @@ func (*EnbLoadInformationExtractor) ExtractAndBuildRanLoadInformation(pdu *C.E2A
 
 	return nil
 }
-*/
\ No newline at end of file
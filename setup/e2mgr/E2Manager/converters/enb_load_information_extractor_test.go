@@ -17,86 +17,183 @@
 //  This source code is part of the near-RT RIC (RAN Intelligent Controller)
 //  platform project (RICP).
 
-
 package converters
 
-//import (
-//	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
-//)
-//
-///*
-//Test permutations of eNB Load Information to protobuf
-//*/
-//
-//type LoadInformationTestCaseName string
-//
-//const LoadTimestamp = 1257894000000000000
-//
-//const (
-//	SingleCellWithCellIdOnly     LoadInformationTestCaseName = "SINGLE CELL WITH CELL ID ONLY"   //base
-//	SingleCellPartiallyPopulated LoadInformationTestCaseName = "SINGLE CELL PARTIALLY POPULATED" //8
-//	TwoCellsFullInfo             LoadInformationTestCaseName = "TWO CELLS FULLY POPULATED"       //13
-//)
-//
-//type LoadInformationTestCase struct {
-//	loadInformationTestCaseName LoadInformationTestCaseName
-//	packedUperPdu               string
-//	packedAperPdu               string
-//	expectedLoadInformation     *entities.RanLoadInformation
-//}
-//
-//var testCases = []LoadInformationTestCase{
-//	{
-//		loadInformationTestCaseName: SingleCellWithCellIdOnly,
-//		packedAperPdu:               "000240140000010006400d00000740080002f8290007ab50",
-//		packedUperPdu:               "004898000400190d0000074200017c148003d5a80000",
-//		expectedLoadInformation:     GenerateSingleCellWithCellIdOnlyRanLoadInformation(),
-//	},
-//	{
-//		loadInformationTestCaseName: SingleCellPartiallyPopulated,
-//		packedAperPdu:               "", //TODO: populate and USE
-//		packedUperPdu:               "004b380004001961000007571e017c148003d5a8205000017c180003d5a875555003331420008007a85801f07c1f07c41f07c1e07801f2020000c680b0003220664102800d8908020000be0c4001ead4016e007ab50100002f8320067ab5005b8c1ead5070190c000000",
-//		expectedLoadInformation:     GenerateSingleCellPartiallyPopulatedLoadInformation(),
-//	},
-//	{
-//		loadInformationTestCaseName: TwoCellsFullInfo,
-//		packedAperPdu:               "", //TODO: populate and USE
-//		packedUperPdu:               "004c07080004001980da0100075bde017c148003d5a8205000017c180003d5a875555403331420000012883a0003547400cd20002801ea16007c1f07c1f107c1f0781e007c80800031a02c000c88199040a00352083669190000d8908020000be0c4001ead4016e007ab50100002f8320067ab5005b8c1ead5070190c00001d637805f220000f56a081400005f020000f56a1d555400ccc508002801ea16007c1f07c1f107c1f0781e007c80800031a02c000c88199040a00352083669190000d8908020000be044001ead4016e007ab50100002f8120067ab5005b8c1ead5070190c00000",
-//		expectedLoadInformation:     GenerateTwoCellsFullyPopulatedRanLoadInformation(),
-//	},
-//}
-
-//func TestExtractAndBuildRanLoadInformation(t *testing.T) {
-//	logger, _ := logger.InitLogger(logger.InfoLevel)
-//
-//	for _, tc := range testCases {
-//		t.Run(string(tc.loadInformationTestCaseName), func(t *testing.T) {
-//
-//			var payload []byte
-//			_, err := fmt.Sscanf(tc.packedUperPdu, "%x", &payload)
-//
-//			if err != nil {
-//				t.Errorf("convert inputPayloadAsStr to payloadAsByte. Error: %v\n", err)
-//			}
-//
-//			pdu, err := unpackX2apPduUPer(logger, MaxAsn1CodecAllocationBufferSize, len(payload), payload, MaxAsn1CodecMessageBufferSize)
-//
-//			actualRanLoadInformation := &entities.RanLoadInformation{LoadTimestamp: LoadTimestamp}
-//
-//			err = ExtractAndBuildRanLoadInformation(pdu, actualRanLoadInformation)
-//
-//			if err != nil {
-//				t.Errorf("want: success, got: error: %v\n", err)
-//			}
-//
-//			if !assert.Equal(t, tc.expectedLoadInformation, actualRanLoadInformation) {
-//				t.Errorf("want: %v, got: %v", tc.expectedLoadInformation, actualRanLoadInformation)
-//			}
-//		})
-//	}
-//}
-
-/*func GenerateSingleCellWithCellIdOnlyRanLoadInformation() *entities.RanLoadInformation {
+import (
+	"e2mgr/e2pdus"
+	"e2mgr/logger"
+	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+/*
+Test permutations of eNB Load Information to protobuf
+*/
+
+type LoadInformationTestCaseName string
+
+const LoadTimestamp = 1257894000000000000
+
+const (
+	SingleCellWithCellIdOnly             LoadInformationTestCaseName = "SINGLE CELL WITH CELL ID ONLY"                 //base
+	SingleCellWithOverloadIndications    LoadInformationTestCaseName = "SINGLE CELL WITH UL INTERFERENCE OVERLOAD"     //1
+	SingleCellWithRelativeNarrowbandTxPw LoadInformationTestCaseName = "SINGLE CELL WITH RELATIVE NARROWBAND TX POWER" //3
+	SingleCellPartiallyPopulated         LoadInformationTestCaseName = "SINGLE CELL PARTIALLY POPULATED"               //8
+	TwoCellsFullInfo                     LoadInformationTestCaseName = "TWO CELLS FULLY POPULATED"                     //13
+)
+
+// The numbers above refer to the XER files of 3rdparty/asn1codec/src/tests/load_information_xer_files. Cases with
+// extension IEs or UL high interference information carry no aligned PER pdu, as the bundled codec does not decode
+// its own aligned PER encoding of those back
+type LoadInformationTestCase struct {
+	loadInformationTestCaseName LoadInformationTestCaseName
+	packedUperPdu               string
+	packedAperPdu               string
+	expectedLoadInformation     *entities.RanLoadInformation
+}
+
+var testCases = []LoadInformationTestCase{
+	{
+		loadInformationTestCaseName: SingleCellWithCellIdOnly,
+		packedAperPdu:               "000240140000010006400d00000740080002f8290007ab50",
+		packedUperPdu:               "004898000400190d0000074200017c148003d5a80000",
+		expectedLoadInformation:     GenerateSingleCellWithCellIdOnlyRanLoadInformation(),
+	},
+	{
+		loadInformationTestCaseName: SingleCellWithOverloadIndications,
+		packedAperPdu:               "000240160000010006400f000007400a4002f8290007ab502080",
+		expectedLoadInformation:     GenerateSingleCellWithOverloadIndicationsRanLoadInformation(),
+	},
+	{
+		loadInformationTestCaseName: SingleCellWithRelativeNarrowbandTxPw,
+		packedAperPdu:               "0002401900000100064012000007400d5002f8290007ab500802cc3142",
+		expectedLoadInformation:     GenerateSingleCellWithRelativeNarrowbandTxPowerRanLoadInformation(),
+	},
+	{
+		loadInformationTestCaseName: SingleCellPartiallyPopulated,
+		packedUperPdu:               "004b380004001961000007571e017c148003d5a8205000017c180003d5a875555003331420008007a85801f07c1f07c41f07c1e07801f2020000c680b0003220664102800d8908020000be0c4001ead4016e007ab50100002f8320067ab5005b8c1ead5070190c000000",
+		expectedLoadInformation:     GenerateSingleCellPartiallyPopulatedLoadInformation(),
+	},
+	{
+		loadInformationTestCaseName: TwoCellsFullInfo,
+		packedUperPdu:               "004c07080004001980da0100075bde017c148003d5a8205000017c180003d5a875555403331420000012883a0003547400cd20002801ea16007c1f07c1f107c1f0781e007c80800031a02c000c88199040a00352083669190000d8908020000be0c4001ead4016e007ab50100002f8320067ab5005b8c1ead5070190c00001d637805f220000f56a081400005f020000f56a1d555400ccc508002801ea16007c1f07c1f107c1f0781e007c80800031a02c000c88199040a00352083669190000d8908020000be044001ead4016e007ab50100002f8120067ab5005b8c1ead5070190c00000",
+		expectedLoadInformation:     GenerateTwoCellsFullyPopulatedRanLoadInformation(),
+	},
+}
+
+func TestExtractAndBuildRanLoadInformation(t *testing.T) {
+	logger, _ := logger.InitLogger(logger.InfoLevel)
+	extractor := NewEnbLoadInformationExtractor(logger)
+
+	for _, tc := range testCases {
+		if tc.packedAperPdu == "" {
+			continue
+		}
+
+		t.Run(string(tc.loadInformationTestCaseName), func(t *testing.T) {
+
+			var payload []byte
+			_, err := fmt.Sscanf(tc.packedAperPdu, "%x", &payload)
+
+			if err != nil {
+				t.Errorf("convert inputPayloadAsStr to payloadAsByte. Error: %v\n", err)
+			}
+
+			actualRanLoadInformation := &entities.RanLoadInformation{LoadTimestamp: LoadTimestamp}
+
+			err = extractor.ExtractAndBuildRanLoadInformation(payload, actualRanLoadInformation)
+
+			if err != nil {
+				t.Errorf("want: success, got: error: %v\n", err)
+			}
+
+			if !assert.Equal(t, tc.expectedLoadInformation, actualRanLoadInformation) {
+				t.Errorf("want: %v, got: %v", tc.expectedLoadInformation, actualRanLoadInformation)
+			}
+		})
+	}
+}
+
+func TestExtractAndBuildRanLoadInformationUper(t *testing.T) {
+	logger, _ := logger.InitLogger(logger.InfoLevel)
+
+	for _, tc := range testCases {
+		if tc.packedUperPdu == "" {
+			continue
+		}
+
+		t.Run(string(tc.loadInformationTestCaseName), func(t *testing.T) {
+
+			var payload []byte
+			_, err := fmt.Sscanf(tc.packedUperPdu, "%x", &payload)
+
+			if err != nil {
+				t.Errorf("convert inputPayloadAsStr to payloadAsByte. Error: %v\n", err)
+			}
+
+			pdu, err := unpackX2apPduUPer(logger, e2pdus.MaxAsn1CodecAllocationBufferSize, len(payload), payload, e2pdus.MaxAsn1CodecMessageBufferSize)
+
+			if err != nil {
+				t.Fatalf("want: success, got: error: %v\n", err)
+			}
+
+			actualRanLoadInformation := &entities.RanLoadInformation{LoadTimestamp: LoadTimestamp}
+
+			err = extractAndBuildRanLoadInformation(pdu, actualRanLoadInformation)
+
+			if err != nil {
+				t.Errorf("want: success, got: error: %v\n", err)
+			}
+
+			if !assert.Equal(t, tc.expectedLoadInformation, actualRanLoadInformation) {
+				t.Errorf("want: %v, got: %v", tc.expectedLoadInformation, actualRanLoadInformation)
+			}
+		})
+	}
+}
+
+func TestExtractAndBuildRanLoadInformationFailure(t *testing.T) {
+	logger, _ := logger.InitLogger(logger.InfoLevel)
+	extractor := NewEnbLoadInformationExtractor(logger)
+
+	// garbage, an empty buffer and an X2 setup response, which is not a load information message
+	for _, packedPdu := range []string{"12312312", "", "2006002a000002001500080002f82900007a8000140017000000630002f8290007ab50102002f829000001000133"} {
+		var payload []byte
+		_, _ = fmt.Sscanf(packedPdu, "%x", &payload)
+
+		ranLoadInformation := &entities.RanLoadInformation{LoadTimestamp: LoadTimestamp}
+		err := extractor.ExtractAndBuildRanLoadInformation(payload, ranLoadInformation)
+
+		assert.NotNil(t, err, "packed pdu: %s", packedPdu)
+		assert.Empty(t, ranLoadInformation.CellLoadInfos)
+	}
+}
+
+// FuzzExtractAndBuildRanLoadInformation feeds malformed load information messages to the extractor, which may fail
+// but must not panic
+func FuzzExtractAndBuildRanLoadInformation(f *testing.F) {
+	for _, tc := range testCases {
+		if tc.packedAperPdu == "" {
+			continue
+		}
+		var payload []byte
+		if _, err := fmt.Sscanf(tc.packedAperPdu, "%x", &payload); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(payload)
+	}
+
+	logger, _ := logger.InitLogger(logger.ErrorLevel)
+	extractor := NewEnbLoadInformationExtractor(logger)
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		_ = extractor.ExtractAndBuildRanLoadInformation(payload, &entities.RanLoadInformation{})
+	})
+}
+
+func GenerateSingleCellWithCellIdOnlyRanLoadInformation() *entities.RanLoadInformation {
 	return &entities.RanLoadInformation{
 		LoadTimestamp: LoadTimestamp,
 		CellLoadInfos: []*entities.CellLoadInformation{
@@ -105,6 +202,40 @@ package converters
 	}
 }
 
+func GenerateSingleCellWithOverloadIndicationsRanLoadInformation() *entities.RanLoadInformation {
+	return &entities.RanLoadInformation{
+		LoadTimestamp: LoadTimestamp,
+		CellLoadInfos: []*entities.CellLoadInformation{
+			{
+				CellId: "02f829:0007ab50",
+				UlInterferenceOverloadIndications: []entities.UlInterferenceOverloadIndication{
+					entities.UlInterferenceOverloadIndication_HIGH_INTERFERENCE,
+					entities.UlInterferenceOverloadIndication_MEDIUM_INTERFERENCE,
+				},
+			},
+		},
+	}
+}
+
+func GenerateSingleCellWithRelativeNarrowbandTxPowerRanLoadInformation() *entities.RanLoadInformation {
+	return &entities.RanLoadInformation{
+		LoadTimestamp: LoadTimestamp,
+		CellLoadInfos: []*entities.CellLoadInformation{
+			{
+				CellId:                            "02f829:0007ab50",
+				UlInterferenceOverloadIndications: []entities.UlInterferenceOverloadIndication{entities.UlInterferenceOverloadIndication_LOW_INTERFERENCE},
+				RelativeNarrowbandTxPower: &entities.RelativeNarrowbandTxPower{
+					RntpPerPrb:                       "cc",
+					RntpThreshold:                    entities.RntpThreshold_NEG_6,
+					NumberOfCellSpecificAntennaPorts: entities.NumberOfCellSpecificAntennaPorts_V2_ANT_PRT,
+					PB:                               2,
+					PdcchInterferenceImpact:          1,
+				},
+			},
+		},
+	}
+}
+
 func GenerateSingleCellPartiallyPopulatedLoadInformation() *entities.RanLoadInformation {
 
 	ulInterferenceOverloadIndications := []entities.UlInterferenceOverloadIndication{
@@ -271,4 +402,4 @@ func GenerateTwoCellsFullyPopulatedRanLoadInformation() *entities.RanLoadInforma
 			},
 		},
 	}
-}*/
+}
@@ -0,0 +1,164 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package converters
+
+// #cgo CFLAGS: -I../3rdparty/asn1codec/inc/ -I../3rdparty/asn1codec/e2ap_engine/
+// #cgo LDFLAGS: -L ../3rdparty/asn1codec/lib/ -L../3rdparty/asn1codec/e2ap_engine/ -le2ap_codec -lasncodec
+// #include <asn1codec_utils.h>
+// #include <InitiatingMessage.h>
+// #include <ServedNRCellsToModify-Item.h>
+import "C"
+import (
+	"e2mgr/e2pdus"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"fmt"
+	"unsafe"
+)
+
+type EndcConfigurationUpdateExtractor struct {
+	logger *logger.Logger
+}
+
+type IEndcConfigurationUpdateExtractor interface {
+	ExtractEndcConfigurationUpdate(packedBuffer []byte) (*models.EndcConfigurationUpdate, error)
+}
+
+func NewEndcConfigurationUpdateExtractor(logger *logger.Logger) *EndcConfigurationUpdateExtractor {
+	return &EndcConfigurationUpdateExtractor{
+		logger: logger,
+	}
+}
+
+func getServedNRCellsToModify(servedNRcellsToModify *C.ServedNRcellsToModifyENDCConfUpdList_t) ([]*models.ServedNrCellToModify, error) {
+	var cells []*models.ServedNrCellToModify
+
+	if servedNRcellsToModify != nil && servedNRcellsToModify.list.count > 0 && servedNRcellsToModify.list.count <= maxCellinengNB {
+		count := int(servedNRcellsToModify.list.count)
+		servedNRCellsToModify_Item_slice := (*[1 << 30]*C.ServedNRCellsToModify_Item_t)(unsafe.Pointer(servedNRcellsToModify.list.array))[:count:count]
+		for _, item := range servedNRCellsToModify_Item_slice {
+			servedNRCell, err := getServedNRCell(&item.servedNRCellInformation, item.nrNeighbourInformation)
+			if err != nil {
+				return nil, err
+			}
+
+			cells = append(cells, &models.ServedNrCellToModify{OldCellId: getNRCGI(&item.old_nrcgi), ServedNrCell: servedNRCell})
+		}
+	}
+
+	return cells, nil
+}
+
+func getServedNRCellsToDelete(servedNRcellsToDelete *C.ServedNRcellsToDeleteENDCConfUpdList_t) []string {
+	var cellIds []string
+
+	if servedNRcellsToDelete != nil && servedNRcellsToDelete.list.count > 0 && servedNRcellsToDelete.list.count <= maxCellinengNB {
+		count := int(servedNRcellsToDelete.list.count)
+		nrcgi_slice := (*[1 << 30]*C.NRCGI_t)(unsafe.Pointer(servedNRcellsToDelete.list.array))[:count:count]
+		for _, nrcgi := range nrcgi_slice {
+			cellIds = append(cellIds, getNRCGI(nrcgi))
+		}
+	}
+
+	return cellIds
+}
+
+func endcConfigurationUpdateToProtobuf(pdu *C.E2AP_PDU_t) (*models.EndcConfigurationUpdate, error) {
+
+	if pdu.present != C.E2AP_PDU_PR_initiatingMessage {
+		return nil, fmt.Errorf("#endc_configuration_update_extractor.endcConfigurationUpdateToProtobuf - Invalid E2AP_PDU value")
+	}
+
+	//dereference a union of pointers (C union is represented as a byte array with the size of the largest member)
+	initiatingMessage := *(**C.InitiatingMessage_t)(unsafe.Pointer(&pdu.choice[0]))
+
+	if initiatingMessage == nil || initiatingMessage.value.present != C.InitiatingMessage__value_PR_ENDCConfigurationUpdate {
+		return nil, fmt.Errorf("#endc_configuration_update_extractor.endcConfigurationUpdateToProtobuf - Unexpected InitiatingMessage value")
+	}
+
+	endcConfigurationUpdate := (*C.ENDCConfigurationUpdate_t)(unsafe.Pointer(&initiatingMessage.value.choice[0]))
+	update := &models.EndcConfigurationUpdate{}
+
+	if endcConfigurationUpdate.protocolIEs.list.count == 0 {
+		return update, nil
+	}
+
+	count := int(endcConfigurationUpdate.protocolIEs.list.count)
+	endcConfigurationUpdate_IEs_slice := (*[1 << 30]*C.ENDCConfigurationUpdate_IEs_t)(unsafe.Pointer(endcConfigurationUpdate.protocolIEs.list.array))[:count:count]
+
+	for _, endcConfigurationUpdate_IE := range endcConfigurationUpdate_IEs_slice {
+		if endcConfigurationUpdate_IE.value.present != C.ENDCConfigurationUpdate_IEs__value_PR_InitiatingNodeType_EndcConfigUpdate {
+			continue
+		}
+
+		initiatingNodeType := (*C.InitiatingNodeType_EndcConfigUpdate_t)(unsafe.Pointer(&endcConfigurationUpdate_IE.value.choice[0]))
+
+		if initiatingNodeType.present == C.InitiatingNodeType_EndcConfigUpdate_PR_init_eNB {
+			update.InitiatedByEnb = true
+			continue
+		}
+
+		if initiatingNodeType.present != C.InitiatingNodeType_EndcConfigUpdate_PR_init_en_gNB {
+			return nil, fmt.Errorf("#endc_configuration_update_extractor.endcConfigurationUpdateToProtobuf - Unexpected InitiatingNodeType value")
+		}
+
+		en_gNB_ENDCConfigUpdateIEs_Container := *(**C.ProtocolIE_Container_119P93_t)(unsafe.Pointer(&initiatingNodeType.choice[0]))
+
+		if en_gNB_ENDCConfigUpdateIEs_Container == nil || en_gNB_ENDCConfigUpdateIEs_Container.list.count == 0 {
+			continue
+		}
+
+		count := int(en_gNB_ENDCConfigUpdateIEs_Container.list.count)
+		en_gNB_ENDCConfigUpdateIEs_slice := (*[1 << 30]*C.En_gNB_ENDCConfigUpdateIEs_t)(unsafe.Pointer(en_gNB_ENDCConfigUpdateIEs_Container.list.array))[:count:count]
+
+		for _, en_gNB_ENDCConfigUpdateIE := range en_gNB_ENDCConfigUpdateIEs_slice {
+			switch en_gNB_ENDCConfigUpdateIE.value.present {
+			case C.En_gNB_ENDCConfigUpdateIEs__value_PR_ServedNRcellsENDCX2ManagementList:
+				servedNrCells, err := getServedNRCells((*C.ServedNRcellsENDCX2ManagementList_t)(unsafe.Pointer(&en_gNB_ENDCConfigUpdateIE.value.choice[0])))
+				if err != nil {
+					return nil, err
+				}
+				update.ServedNrCellsToAdd = servedNrCells
+			case C.En_gNB_ENDCConfigUpdateIEs__value_PR_ServedNRcellsToModifyENDCConfUpdList:
+				servedNrCellsToModify, err := getServedNRCellsToModify((*C.ServedNRcellsToModifyENDCConfUpdList_t)(unsafe.Pointer(&en_gNB_ENDCConfigUpdateIE.value.choice[0])))
+				if err != nil {
+					return nil, err
+				}
+				update.ServedNrCellsToModify = servedNrCellsToModify
+			case C.En_gNB_ENDCConfigUpdateIEs__value_PR_ServedNRcellsToDeleteENDCConfUpdList:
+				update.ServedNrCellsToDelete = getServedNRCellsToDelete((*C.ServedNRcellsToDeleteENDCConfUpdList_t)(unsafe.Pointer(&en_gNB_ENDCConfigUpdateIE.value.choice[0])))
+			}
+		}
+	}
+
+	return update, nil
+}
+
+// ExtractEndcConfigurationUpdate decodes the served NR cells an EN-DC CONFIGURATION UPDATE from an en-gNB adds, modifies
+// and deletes. The E-UTRA cells of an update from an eNB are not decoded, it is only marked as such
+func (e *EndcConfigurationUpdateExtractor) ExtractEndcConfigurationUpdate(packedBuffer []byte) (*models.EndcConfigurationUpdate, error) {
+	pdu, err := UnpackX2apPdu(e.logger, e2pdus.MaxAsn1CodecAllocationBufferSize, len(packedBuffer), packedBuffer, e2pdus.MaxAsn1CodecMessageBufferSize)
+	if err != nil {
+		return nil, err
+	}
+
+	defer C.delete_pdu(pdu)
+	return endcConfigurationUpdateToProtobuf(pdu)
+}
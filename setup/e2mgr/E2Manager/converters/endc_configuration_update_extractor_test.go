@@ -0,0 +1,142 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package converters
+
+import (
+	"e2mgr/logger"
+	"e2mgr/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// An EN-DC CONFIGURATION UPDATE from an en-gNB which adds NR cell 0000000030 with NR cell 0000000010 as its neighbour,
+// moves NR cell 0000000010 to PCI 10 and deletes NR cell 0000000020
+const (
+	EndcConfigurationUpdateAperPdu      = "002500808200000100f5007b40000300fd003700004000030002f829000000003002f8294100099cf000004d002700010100000000010002f829000000001001014100099cf000004d000105002a00000002f8290000000014000a0002f82900000000100000010002f8294100099cf000004d00270001010106000b00000002f8290000000020"
+	EndcConfigurationUpdateEmptyAperPdu = "0025000a00000100f50003400000"
+	EndcConfigurationUpdateFromEnbPdu   = "0025000a00000100f50003000000"
+)
+
+func initEndcConfigurationUpdateExtractorTest(t *testing.T) *EndcConfigurationUpdateExtractor {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Fatalf("#initEndcConfigurationUpdateExtractorTest - failed to initialize logger, error: %s", err)
+	}
+	return NewEndcConfigurationUpdateExtractor(log)
+}
+
+func nrFrequencyInfo() *entities.NrFrequencyInfo {
+	return &entities.NrFrequencyInfo{NrArFcn: 630000, FrequencyBands: []*entities.FrequencyBandItem{{NrFrequencyBand: 78}}}
+}
+
+func tddServedNrCell(pci uint32, cellId string) *entities.ServedNRCell {
+	return &entities.ServedNRCell{ServedNrCellInformation: &entities.ServedNRCellInformation{
+		NrPci:       pci,
+		CellId:      cellId,
+		ServedPlmns: []string{"02f829"},
+		NrMode:      entities.Nr_TDD,
+		ChoiceNrMode: &entities.ServedNRCellInformation_ChoiceNRMode{Tdd: &entities.ServedNRCellInformation_ChoiceNRMode_TddInfo{
+			NrFreqInfo:            nrFrequencyInfo(),
+			TransmissionBandwidth: &entities.NrTransmissionBandwidth{Nrscs: entities.Nrscs_SCS30, Ncnrb: entities.Ncnrb_NRB106},
+		}},
+	}}
+}
+
+func TestExtractEndcConfigurationUpdate(t *testing.T) {
+	extractor := initEndcConfigurationUpdateExtractorTest(t)
+
+	addedCell := tddServedNrCell(3, "02f829:0000000030")
+	addedCell.NrNeighbourInfos = []*entities.NrNeighbourInformation{{
+		NrPci:        1,
+		NrCgi:        "02f829:0000000010",
+		NrMode:       entities.Nr_TDD,
+		ChoiceNrMode: &entities.NrNeighbourInformation_ChoiceNRMode{Tdd: &entities.NrNeighbourInformation_ChoiceNRMode_TddInfo{ArFcnNrFreqInfo: nrFrequencyInfo()}},
+	}}
+
+	modifiedCell := tddServedNrCell(10, "02f829:0000000010")
+	modifiedCell.ServedNrCellInformation.Stac5G = "000001"
+
+	expected := &models.EndcConfigurationUpdate{
+		ServedNrCellsToAdd:    []*entities.ServedNRCell{addedCell},
+		ServedNrCellsToModify: []*models.ServedNrCellToModify{{OldCellId: "02f829:0000000010", ServedNrCell: modifiedCell}},
+		ServedNrCellsToDelete: []string{"02f829:0000000020"},
+	}
+
+	update, err := extractor.ExtractEndcConfigurationUpdate(decodePackedPdu(t, EndcConfigurationUpdateAperPdu))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, update)
+}
+
+func TestExtractEndcConfigurationUpdateNoChanges(t *testing.T) {
+	extractor := initEndcConfigurationUpdateExtractorTest(t)
+
+	update, err := extractor.ExtractEndcConfigurationUpdate(decodePackedPdu(t, EndcConfigurationUpdateEmptyAperPdu))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &models.EndcConfigurationUpdate{}, update)
+}
+
+func TestExtractEndcConfigurationUpdateFromEnb(t *testing.T) {
+	extractor := initEndcConfigurationUpdateExtractorTest(t)
+
+	update, err := extractor.ExtractEndcConfigurationUpdate(decodePackedPdu(t, EndcConfigurationUpdateFromEnbPdu))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &models.EndcConfigurationUpdate{InitiatedByEnb: true}, update)
+}
+
+func TestExtractEndcConfigurationUpdateFailure(t *testing.T) {
+	extractor := initEndcConfigurationUpdateExtractorTest(t)
+
+	var testCases = []struct {
+		name      string
+		packedPdu string
+		expected  string
+	}{
+		{name: "garbage", packedPdu: "12312312", expected: "unpacking error"},
+		{name: "endc configuration update ack", packedPdu: "2025000a00000100f70003000000", expected: "Invalid E2AP_PDU value"},
+		{name: "enb configuration update", packedPdu: EnbConfigurationUpdateEmptyAperPdu, expected: "Unexpected InitiatingMessage value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			update, err := extractor.ExtractEndcConfigurationUpdate(decodePackedPdu(t, tc.packedPdu))
+
+			assert.Nil(t, update)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func FuzzExtractEndcConfigurationUpdate(f *testing.F) {
+	for _, packedPdu := range []string{EndcConfigurationUpdateAperPdu, EndcConfigurationUpdateEmptyAperPdu, EndcConfigurationUpdateFromEnbPdu} {
+		f.Add(decodePackedPdu(f, packedPdu))
+	}
+
+	log, _ := logger.InitLogger(logger.InfoLevel)
+	extractor := NewEndcConfigurationUpdateExtractor(log)
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		_, _ = extractor.ExtractEndcConfigurationUpdate(payload)
+	})
+}
@@ -216,6 +216,57 @@ func getnRNeighbourInfo(neighbour_Information *C.NRNeighbour_Information_t) ([]*
 	return neighbours, nil
 }
 
+func getNRCGI(nrcgi *C.NRCGI_t) string {
+	//pLMN_Identity:nRcellIdentifier
+	plmnId := C.GoBytes(unsafe.Pointer(nrcgi.pLMN_Identity.buf), C.int(nrcgi.pLMN_Identity.size))
+	nRcellIdentifier := C.GoBytes(unsafe.Pointer(nrcgi.nRcellIdentifier.buf), C.int(nrcgi.nRcellIdentifier.size))
+	return fmt.Sprintf("%02x:%02x", plmnId, nRcellIdentifier)
+}
+
+func getServedNRCell(servedNRCellInfo *C.ServedNRCell_Information_t, nRNeighbourInfo *C.NRNeighbour_Information_t) (*entities.ServedNRCell, error) {
+	servedNRCell := &entities.ServedNRCell{ServedNrCellInformation: &entities.ServedNRCellInformation{NrPci: uint32(servedNRCellInfo.nrpCI)}}
+
+	servedNRCell.ServedNrCellInformation.CellId = getNRCGI(&servedNRCellInfo.nrCellID)
+
+	if servedNRCellInfo.fiveGS_TAC != nil {
+		servedNRCell.ServedNrCellInformation.Stac5G = fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(servedNRCellInfo.fiveGS_TAC.buf), C.int(servedNRCellInfo.fiveGS_TAC.size)))
+	}
+
+	if servedNRCellInfo.configured_TAC != nil {
+		servedNRCell.ServedNrCellInformation.ConfiguredStac = fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(servedNRCellInfo.configured_TAC.buf), C.int(servedNRCellInfo.configured_TAC.size)))
+	}
+
+	if servedNRCellInfo.broadcastPLMNs.list.count > 0 && servedNRCellInfo.broadcastPLMNs.list.count <= maxnoofBPLMNs {
+		count := int(servedNRCellInfo.broadcastPLMNs.list.count)
+		pLMN_Identity_slice := (*[1 << 30]*C.PLMN_Identity_t)(unsafe.Pointer(servedNRCellInfo.broadcastPLMNs.list.array))[:count:count]
+		for _, pLMN_Identity := range pLMN_Identity_slice {
+			servedNRCell.ServedNrCellInformation.ServedPlmns = append(servedNRCell.ServedNrCellInformation.ServedPlmns, fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(pLMN_Identity.buf), C.int(pLMN_Identity.size))))
+		}
+	}
+	switch servedNRCellInfo.nrModeInfo.present {
+	case C.ServedNRCell_Information__nrModeInfo_PR_fdd:
+		if fdd, err := getnrModeInfoFDDInfo(*(**C.FDD_InfoServedNRCell_Information_t)(unsafe.Pointer(&servedNRCellInfo.nrModeInfo.choice[0]))); fdd != nil && err == nil {
+			servedNRCell.ServedNrCellInformation.ChoiceNrMode, servedNRCell.ServedNrCellInformation.NrMode = &entities.ServedNRCellInformation_ChoiceNRMode{Fdd: fdd}, entities.Nr_FDD
+		} else {
+			return nil, err
+		}
+	case C.ServedNRCell_Information__nrModeInfo_PR_tdd:
+		if tdd, err := getnrModeInfoTDDInfo(*(**C.TDD_InfoServedNRCell_Information_t)(unsafe.Pointer(&servedNRCellInfo.nrModeInfo.choice[0]))); tdd != nil && err == nil {
+			servedNRCell.ServedNrCellInformation.ChoiceNrMode, servedNRCell.ServedNrCellInformation.NrMode = &entities.ServedNRCellInformation_ChoiceNRMode{Tdd: tdd}, entities.Nr_TDD
+		} else {
+			return nil, err
+		}
+	}
+
+	neighbours, err := getnRNeighbourInfo(nRNeighbourInfo)
+	if err != nil {
+		return nil, err
+	}
+	servedNRCell.NrNeighbourInfos = neighbours
+
+	return servedNRCell, nil
+}
+
 func getServedNRCells(servedNRcellsManagementList *C.ServedNRcellsENDCX2ManagementList_t) ([]*entities.ServedNRCell, error) {
 	var servedNRCells []*entities.ServedNRCell
 
@@ -223,49 +274,10 @@ func getServedNRCells(servedNRcellsManagementList *C.ServedNRcellsENDCX2Manageme
 		count := int(servedNRcellsManagementList.list.count)
 		servedNRcellsENDCX2ManagementList__Member_slice := (*[1 << 30]*C.ServedNRcellsENDCX2ManagementList__Member)(unsafe.Pointer(servedNRcellsManagementList.list.array))[:count:count]
 		for _, servedNRcellsENDCX2ManagementList__Member := range servedNRcellsENDCX2ManagementList__Member_slice {
-			servedNRCellInfo := servedNRcellsENDCX2ManagementList__Member.servedNRCellInfo
-			servedNRCell := &entities.ServedNRCell{ServedNrCellInformation: &entities.ServedNRCellInformation{NrPci: uint32(servedNRCellInfo.nrpCI)}}
-
-			//pLMN_Identity:nRcellIdentifier
-			plmnId := C.GoBytes(unsafe.Pointer(servedNRCellInfo.nrCellID.pLMN_Identity.buf), C.int(servedNRCellInfo.nrCellID.pLMN_Identity.size))
-			nRcellIdentifier := C.GoBytes(unsafe.Pointer(servedNRCellInfo.nrCellID.nRcellIdentifier.buf), C.int(servedNRCellInfo.nrCellID.nRcellIdentifier.size))
-			servedNRCell.ServedNrCellInformation.CellId = fmt.Sprintf("%02x:%02x", plmnId, nRcellIdentifier)
-
-			if servedNRCellInfo.fiveGS_TAC != nil {
-				servedNRCell.ServedNrCellInformation.Stac5G = fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(servedNRCellInfo.fiveGS_TAC.buf), C.int(servedNRCellInfo.fiveGS_TAC.size)))
-			}
-
-			if servedNRCellInfo.configured_TAC != nil {
-				servedNRCell.ServedNrCellInformation.ConfiguredStac = fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(servedNRCellInfo.configured_TAC.buf), C.int(servedNRCellInfo.configured_TAC.size)))
-			}
-
-			if servedNRCellInfo.broadcastPLMNs.list.count > 0 && servedNRCellInfo.broadcastPLMNs.list.count <= maxnoofBPLMNs {
-				count := int(servedNRCellInfo.broadcastPLMNs.list.count)
-				pLMN_Identity_slice := (*[1 << 30]*C.PLMN_Identity_t)(unsafe.Pointer(servedNRCellInfo.broadcastPLMNs.list.array))[:count:count]
-				for _, pLMN_Identity := range pLMN_Identity_slice {
-					servedNRCell.ServedNrCellInformation.ServedPlmns = append(servedNRCell.ServedNrCellInformation.ServedPlmns, fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(pLMN_Identity.buf), C.int(pLMN_Identity.size))))
-				}
-			}
-			switch servedNRCellInfo.nrModeInfo.present {
-			case C.ServedNRCell_Information__nrModeInfo_PR_fdd:
-				if fdd, err := getnrModeInfoFDDInfo(*(**C.FDD_InfoServedNRCell_Information_t)(unsafe.Pointer(&servedNRCellInfo.nrModeInfo.choice[0]))); fdd != nil && err == nil {
-					servedNRCell.ServedNrCellInformation.ChoiceNrMode, servedNRCell.ServedNrCellInformation.NrMode = &entities.ServedNRCellInformation_ChoiceNRMode{Fdd: fdd}, entities.Nr_FDD
-				} else {
-					return nil, err
-				}
-			case C.ServedNRCell_Information__nrModeInfo_PR_tdd:
-				if tdd, err := getnrModeInfoTDDInfo(*(**C.TDD_InfoServedNRCell_Information_t)(unsafe.Pointer(&servedNRCellInfo.nrModeInfo.choice[0]))); tdd != nil && err == nil {
-					servedNRCell.ServedNrCellInformation.ChoiceNrMode, servedNRCell.ServedNrCellInformation.NrMode = &entities.ServedNRCellInformation_ChoiceNRMode{Tdd: tdd}, entities.Nr_TDD
-				} else {
-					return nil, err
-				}
-			}
-
-			neighbours, err := getnRNeighbourInfo(servedNRcellsENDCX2ManagementList__Member.nRNeighbourInfo)
+			servedNRCell, err := getServedNRCell(&servedNRcellsENDCX2ManagementList__Member.servedNRCellInfo, servedNRcellsENDCX2ManagementList__Member.nRNeighbourInfo)
 			if err != nil {
 				return nil, err
 			}
-			servedNRCell.NrNeighbourInfos = neighbours
 
 			servedNRCells = append(servedNRCells, servedNRCell)
 		}
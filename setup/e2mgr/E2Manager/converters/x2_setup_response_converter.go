@@ -322,6 +322,56 @@ func getServedCellsNeighbour_Info(neighbour_Information *C.Neighbour_Information
 	return neighbours, nil
 }
 
+func getServedCellInfo(servedCellInformation *C.ServedCell_Information_t, neighbour_Information *C.Neighbour_Information_t) (*entities.ServedCellInfo, error) {
+	servedCellInfo := &entities.ServedCellInfo{Pci: uint32(servedCellInformation.pCI)}
+
+	servedCellInfo.CellId = getECGI(&servedCellInformation.cellId)
+
+	servedCellInfo.Tac = fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(servedCellInformation.tAC.buf), C.int(servedCellInformation.tAC.size)))
+
+	if servedCellInformation.broadcastPLMNs.list.count > 0 && servedCellInformation.broadcastPLMNs.list.count <= maxnoofBPLMNs {
+		count := int(servedCellInformation.broadcastPLMNs.list.count)
+		pLMN_Identity_slice := (*[1 << 30]*C.PLMN_Identity_t)(unsafe.Pointer(servedCellInformation.broadcastPLMNs.list.array))[:count:count]
+		for _, pLMN_Identity := range pLMN_Identity_slice {
+			servedCellInfo.BroadcastPlmns = append(servedCellInfo.BroadcastPlmns, fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(pLMN_Identity.buf), C.int(pLMN_Identity.size))))
+		}
+	}
+
+	switch servedCellInformation.eUTRA_Mode_Info.present {
+	case C.EUTRA_Mode_Info_PR_fDD:
+		if fdd, err := getFDDInfo(*(**C.FDD_Info_t)(unsafe.Pointer(&servedCellInformation.eUTRA_Mode_Info.choice[0]))); fdd != nil && err == nil {
+			servedCellInfo.ChoiceEutraMode, servedCellInfo.EutraMode = &entities.ChoiceEUTRAMode{Fdd: fdd}, entities.Eutra_FDD
+		} else {
+			return nil, err
+		}
+	case C.EUTRA_Mode_Info_PR_tDD:
+		if tdd, err := getTDDInfo(*(**C.TDD_Info_t)(unsafe.Pointer(&servedCellInformation.eUTRA_Mode_Info.choice[0]))); tdd != nil && err == nil {
+			servedCellInfo.ChoiceEutraMode, servedCellInfo.EutraMode = &entities.ChoiceEUTRAMode{Tdd: tdd}, entities.Eutra_TDD
+		} else {
+			return nil, err
+		}
+	}
+
+	neighbours, err := getServedCellsNeighbour_Info(neighbour_Information)
+	if err != nil {
+		return nil, err
+	}
+	servedCellInfo.NeighbourInfos = neighbours
+
+	if err := getServedCellsInfoExt((*C.ProtocolExtensionContainer_170P192_t)(unsafe.Pointer(servedCellInformation.iE_Extensions)), servedCellInfo); err != nil {
+		return nil, err
+	}
+
+	return servedCellInfo, nil
+}
+
+//pLMN_Identity:eUTRANcellIdentifier
+func getECGI(ecgi *C.ECGI_t) string {
+	plmnId := C.GoBytes(unsafe.Pointer(ecgi.pLMN_Identity.buf), C.int(ecgi.pLMN_Identity.size))
+	eUTRANcellIdentifier := C.GoBytes(unsafe.Pointer(ecgi.eUTRANcellIdentifier.buf), C.int(ecgi.eUTRANcellIdentifier.size))
+	return fmt.Sprintf("%02x:%02x", plmnId, eUTRANcellIdentifier)
+}
+
 func getServedCells(servedCellsIE *C.ServedCells_t) ([]*entities.ServedCellInfo, error) {
 	var servedCells []*entities.ServedCellInfo
 
@@ -329,47 +379,10 @@ func getServedCells(servedCellsIE *C.ServedCells_t) ([]*entities.ServedCellInfo,
 		count := int(servedCellsIE.list.count)
 		servedCells__Member_slice := (*[1 << 30]*C.ServedCells__Member)(unsafe.Pointer(servedCellsIE.list.array))[:count:count]
 		for _, member := range servedCells__Member_slice {
-			servedCellInfo := &entities.ServedCellInfo{Pci: uint32(member.servedCellInfo.pCI)}
-
-			//pLMN_Identity:eUTRANcellIdentifier
-			plmnId := C.GoBytes(unsafe.Pointer(member.servedCellInfo.cellId.pLMN_Identity.buf), C.int(member.servedCellInfo.cellId.pLMN_Identity.size))
-			eUTRANcellIdentifier := C.GoBytes(unsafe.Pointer(member.servedCellInfo.cellId.eUTRANcellIdentifier.buf), C.int(member.servedCellInfo.cellId.eUTRANcellIdentifier.size))
-			servedCellInfo.CellId = fmt.Sprintf("%02x:%02x", plmnId, eUTRANcellIdentifier)
-
-			servedCellInfo.Tac = fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(member.servedCellInfo.tAC.buf), C.int(member.servedCellInfo.tAC.size)))
-
-			if member.servedCellInfo.broadcastPLMNs.list.count > 0 && member.servedCellInfo.broadcastPLMNs.list.count <= maxnoofBPLMNs {
-				count := int(member.servedCellInfo.broadcastPLMNs.list.count)
-				pLMN_Identity_slice := (*[1 << 30]*C.PLMN_Identity_t)(unsafe.Pointer(member.servedCellInfo.broadcastPLMNs.list.array))[:count:count]
-				for _, pLMN_Identity := range pLMN_Identity_slice {
-					servedCellInfo.BroadcastPlmns = append(servedCellInfo.BroadcastPlmns, fmt.Sprintf("%02x", C.GoBytes(unsafe.Pointer(pLMN_Identity.buf), C.int(pLMN_Identity.size))))
-				}
-			}
-
-			switch member.servedCellInfo.eUTRA_Mode_Info.present {
-			case C.EUTRA_Mode_Info_PR_fDD:
-				if fdd, err := getFDDInfo(*(**C.FDD_Info_t)(unsafe.Pointer(&member.servedCellInfo.eUTRA_Mode_Info.choice[0]))); fdd != nil && err == nil {
-					servedCellInfo.ChoiceEutraMode, servedCellInfo.EutraMode = &entities.ChoiceEUTRAMode{Fdd: fdd}, entities.Eutra_FDD
-				} else {
-					return nil, err
-				}
-			case C.EUTRA_Mode_Info_PR_tDD:
-				if tdd, err := getTDDInfo(*(**C.TDD_Info_t)(unsafe.Pointer(&member.servedCellInfo.eUTRA_Mode_Info.choice[0]))); tdd != nil && err == nil {
-					servedCellInfo.ChoiceEutraMode, servedCellInfo.EutraMode = &entities.ChoiceEUTRAMode{Tdd: tdd}, entities.Eutra_TDD
-				} else {
-					return nil, err
-				}
-			}
-
-			neighbours, err := getServedCellsNeighbour_Info(member.neighbour_Info)
+			servedCellInfo, err := getServedCellInfo(&member.servedCellInfo, member.neighbour_Info)
 			if err != nil {
 				return nil, err
 			}
-			servedCellInfo.NeighbourInfos = neighbours
-
-			if err := getServedCellsInfoExt((*C.ProtocolExtensionContainer_170P192_t)(unsafe.Pointer(member.servedCellInfo.iE_Extensions)), servedCellInfo); err != nil {
-				return nil, err
-			}
 
 			servedCells = append(servedCells, servedCellInfo)
 
@@ -129,3 +129,44 @@ func TestUnpackX2apSetupResponseUnpackError(t *testing.T) {
 
 	}
 }
+
+func TestUnpackX2apPduInvalidBufferSize(t *testing.T) {
+	logger, _ := logger.InitLogger(logger.InfoLevel)
+
+	_, err := UnpackX2apPduAndRefine(logger, e2pdus.MaxAsn1CodecAllocationBufferSize, 0, []byte{}, e2pdus.MaxAsn1CodecMessageBufferSize)
+	if err == nil {
+		t.Errorf("want: unpacking error for an empty buffer, got: success")
+	}
+
+	_, err = UnpackX2apPduAndRefine(logger, e2pdus.MaxAsn1CodecAllocationBufferSize, 46, []byte{0x20, 0x06}, e2pdus.MaxAsn1CodecMessageBufferSize)
+	if err == nil {
+		t.Errorf("want: unpacking error for a size beyond the buffer, got: success")
+	}
+}
+
+// FuzzUnpackX2apPduAndRefine is seeded with the X2 setup, EN-DC setup and failure vectors of the converter tests
+func FuzzUnpackX2apPduAndRefine(f *testing.F) {
+	for _, packedPdu := range []string{
+		"2006002a000002001500080002f82900007a8000140017000000630002f8290007ab50102002f829000001000133",
+		"20060043000002001500080002f82900007a8000140030010000630002f8290007ab50102002f8290000010001330000640002f9290007ac50203202f82902f929000002000344",
+		"2006002a000002001500080002f82900007a8000140017000000630002f8290007ab50102002f829400001320820",
+		"20060056000002001500090002f8298003007a4000140042010800630002f8290007ab50102002f829000001000133000000294001000800640002f9290007ac50203202f82902f92900000200034400000037400500000f79e0",
+		"2024006500000100f6005e40000200fc00090002f829504a952a0a00fd004a00004c0005001e3f271f2e3d4ff03d44d34e4f003e4e5e4400010000150400000a000209e040033e4e5e000000002c001e3f271f2e3d4ff0031e3f274400050000150400000a00061820",
+		"2024001700000100f6001040000100fc00090002f829504a952aaa",
+		"4006001a0000030005400200000016400100001140087821a00000008040",
+		"400600120000020005400168001140061a0000008040",
+	} {
+		var payload []byte
+		if _, err := fmt.Sscanf(packedPdu, "%x", &payload); err != nil {
+			f.Fatal(err)
+		}
+		f.Add(payload)
+	}
+
+	// Debug level also runs the PDU printer on every decoded input
+	logger, _ := logger.InitLogger(logger.DebugLevel)
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		_, _ = UnpackX2apPduAndRefine(logger, e2pdus.MaxAsn1CodecAllocationBufferSize, len(payload), payload, e2pdus.MaxAsn1CodecMessageBufferSize)
+	})
+}
@@ -20,19 +20,49 @@
 
 package e2managererrors
 
+import "fmt"
+
+// RoutingManagerError keeps what is known of a failed Routing Manager call: the status code and body of an
+// unsuccessful response, or the cause when no response was received. The Message returned to clients is unchanged
 type RoutingManagerError struct {
 	*BaseError
+	StatusCode   int
+	ResponseBody string
+	Cause        error
 }
 
 func NewRoutingManagerError() *RoutingManagerError {
 	return &RoutingManagerError{
-		&BaseError{
+		BaseError: &BaseError{
 			Code:    511,
 			Message: "No Routing Manager Available",
 		},
 	}
 }
 
+func NewRoutingManagerResponseError(statusCode int, responseBody string) *RoutingManagerError {
+	e := NewRoutingManagerError()
+	e.StatusCode = statusCode
+	e.ResponseBody = responseBody
+	return e
+}
+
+func NewRoutingManagerRequestError(cause error) *RoutingManagerError {
+	e := NewRoutingManagerError()
+	e.Cause = cause
+	return e
+}
+
 func (e *RoutingManagerError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%s - http status code: %d, response body: %s", e.Message, e.StatusCode, e.ResponseBody)
+	}
+	if e.Cause != nil {
+		return fmt.Sprintf("%s - %s", e.Message, e.Cause)
+	}
 	return e.Message
 }
+
+func (e *RoutingManagerError) Unwrap() error {
+	return e.Cause
+}
@@ -29,6 +29,7 @@ package e2pdus
 import "C"
 import (
 	"fmt"
+	"strings"
 	"unsafe"
 )
 
@@ -37,6 +38,9 @@ var PackedEndcConfigurationUpdateAck []byte
 var PackedX2EnbConfigurationUpdateFailure []byte
 var PackedX2EnbConfigurationUpdateAck []byte
 
+var knownCausesToX2EnbConfigurationUpdateFailurePDUs = map[string][]byte{}
+var knownCausesToEndcConfigurationUpdateFailurePDUs = map[string][]byte{}
+
 func prepareEndcConfigurationUpdateFailurePDU(maxAsn1PackedBufferSize int, maxAsn1CodecMessageBufferSize int) error {
 
 	packedBuffer := make([]C.uchar, maxAsn1PackedBufferSize)
@@ -52,6 +56,27 @@ func prepareEndcConfigurationUpdateFailurePDU(maxAsn1PackedBufferSize int, maxAs
 	return nil
 }
 
+func prepareEndcConfigurationUpdateFailurePDUs(maxAsn1PackedBufferSize int, maxAsn1CodecMessageBufferSize int) error {
+	packedBuffer := make([]C.uchar, maxAsn1PackedBufferSize)
+	errorBuffer := make([]C.char, maxAsn1CodecMessageBufferSize)
+
+	for k, cause := range knownCauses {
+		var payloadSize = C.ulong(maxAsn1PackedBufferSize)
+		if status := C.build_pack_endc_configuration_update_failure_with_cause(cause.causeGroup, C.int(cause.cause), &payloadSize, &packedBuffer[0], C.ulong(maxAsn1CodecMessageBufferSize), &errorBuffer[0]); !status {
+			return fmt.Errorf("#configuration_update.prepareEndcConfigurationUpdateFailurePDUs - failed to build and pack the endc configuration update failure message %s ", C.GoString(&errorBuffer[0]))
+		}
+		knownCausesToEndcConfigurationUpdateFailurePDUs[strings.ToLower(k)] = C.GoBytes(unsafe.Pointer(&packedBuffer[0]), C.int(payloadSize))
+	}
+
+	return nil
+}
+
+// KnownCausesToEndcConfigurationUpdateFailurePDU returns a packed endc configuration update failure pdu with the specified cause (case insensitive match).
+func KnownCausesToEndcConfigurationUpdateFailurePDU(cause string) ([]byte, bool) {
+	v, ok := knownCausesToEndcConfigurationUpdateFailurePDUs[strings.ToLower(cause)]
+	return v, ok
+}
+
 func prepareX2EnbConfigurationUpdateFailurePDU(maxAsn1PackedBufferSize int, maxAsn1CodecMessageBufferSize int) error {
 
 	packedBuffer := make([]C.uchar, maxAsn1PackedBufferSize)
@@ -67,6 +92,27 @@ func prepareX2EnbConfigurationUpdateFailurePDU(maxAsn1PackedBufferSize int, maxA
 	return nil
 }
 
+func prepareX2EnbConfigurationUpdateFailurePDUs(maxAsn1PackedBufferSize int, maxAsn1CodecMessageBufferSize int) error {
+	packedBuffer := make([]C.uchar, maxAsn1PackedBufferSize)
+	errorBuffer := make([]C.char, maxAsn1CodecMessageBufferSize)
+
+	for k, cause := range knownCauses {
+		var payloadSize = C.ulong(maxAsn1PackedBufferSize)
+		if status := C.build_pack_x2enb_configuration_update_failure_with_cause(cause.causeGroup, C.int(cause.cause), &payloadSize, &packedBuffer[0], C.ulong(maxAsn1CodecMessageBufferSize), &errorBuffer[0]); !status {
+			return fmt.Errorf("#configuration_update.prepareX2EnbConfigurationUpdateFailurePDUs - failed to build and pack the x2 configuration update failure message %s ", C.GoString(&errorBuffer[0]))
+		}
+		knownCausesToX2EnbConfigurationUpdateFailurePDUs[strings.ToLower(k)] = C.GoBytes(unsafe.Pointer(&packedBuffer[0]), C.int(payloadSize))
+	}
+
+	return nil
+}
+
+// KnownCausesToX2EnbConfigurationUpdateFailurePDU returns a packed x2 configuration update failure pdu with the specified cause (case insensitive match).
+func KnownCausesToX2EnbConfigurationUpdateFailurePDU(cause string) ([]byte, bool) {
+	v, ok := knownCausesToX2EnbConfigurationUpdateFailurePDUs[strings.ToLower(cause)]
+	return v, ok
+}
+
 func prepareEndcConfigurationUpdateAckPDU(maxAsn1PackedBufferSize int, maxAsn1CodecMessageBufferSize int) error {
 
 	packedBuffer := make([]C.uchar, maxAsn1PackedBufferSize)
@@ -110,4 +156,10 @@ func init() {
 	if err := prepareX2EnbConfigurationUpdateAckPDU(MaxAsn1PackedBufferSize, MaxAsn1CodecMessageBufferSize); err != nil {
 		panic(err)
 	}
+	if err := prepareX2EnbConfigurationUpdateFailurePDUs(MaxAsn1PackedBufferSize, MaxAsn1CodecMessageBufferSize); err != nil {
+		panic(err)
+	}
+	if err := prepareEndcConfigurationUpdateFailurePDUs(MaxAsn1PackedBufferSize, MaxAsn1CodecMessageBufferSize); err != nil {
+		panic(err)
+	}
 }
@@ -168,4 +168,140 @@ func TestPrepareX2EnbConfigurationUpdateAckPDUFailure(t *testing.T) {
 	if !strings.Contains(err.Error(), expected) {
 		t.Errorf("want :[%s], got: [%s]\n", expected, err)
 	}
-}
\ No newline at end of file
+}
+
+func TestKnownCausesToX2EnbConfigurationUpdateFailurePDU(t *testing.T) {
+	_, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("failed to initialize logger, error: %s", err)
+	}
+	var testCases = []struct {
+		cause     string
+		packedPdu string
+	}{
+		{
+			cause:     "protocol:abstract-syntax-error-reject",
+			packedPdu: "400800080000010005400142",
+		},
+		{
+			cause:     "MISC:unspecified",
+			packedPdu: "400800080000010005400168",
+		},
+		{
+			cause:     "protocol:message-not-compatible-with-receiver-state",
+			packedPdu: "400800080000010005400146",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.packedPdu, func(t *testing.T) {
+
+			payload, ok := KnownCausesToX2EnbConfigurationUpdateFailurePDU(tc.cause)
+			if !ok {
+				t.Errorf("want: success, got: not found.\n")
+			} else {
+				tmp := fmt.Sprintf("%x", payload)
+				if strings.Compare(tmp, tc.packedPdu) != 0 {
+					t.Errorf("\nwant :\t[%s]\n got: \t\t[%s]\n", tc.packedPdu, tmp)
+				}
+			}
+		})
+	}
+}
+
+func TestKnownCausesToX2EnbConfigurationUpdateFailurePDUFailure(t *testing.T) {
+	_, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("failed to initialize logger, error: %s", err)
+	}
+
+	_, ok := KnownCausesToX2EnbConfigurationUpdateFailurePDU("xxxx")
+	if ok {
+		t.Errorf("want: not found, got: success.\n")
+	}
+}
+
+func TestPrepareX2EnbConfigurationUpdateFailurePDUsFailure(t *testing.T) {
+	_, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("failed to initialize logger, error: %s", err)
+	}
+
+	err = prepareX2EnbConfigurationUpdateFailurePDUs(1, 4096)
+	if err == nil {
+		t.Errorf("want: error, got: success.\n")
+	}
+
+	expected := "#configuration_update.prepareX2EnbConfigurationUpdateFailurePDUs - failed to build and pack the x2 configuration update failure message #src/asn1codec_utils.c.pack_pdu_aux - Encoded output of E2AP-PDU, is too big"
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("want :[%s], got: [%s]\n", expected, err)
+	}
+}
+
+func TestKnownCausesToEndcConfigurationUpdateFailurePDU(t *testing.T) {
+	_, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("failed to initialize logger, error: %s", err)
+	}
+	var testCases = []struct {
+		cause     string
+		packedPdu string
+	}{
+		{
+			cause:     "protocol:abstract-syntax-error-reject",
+			packedPdu: "402500080000010005400142",
+		},
+		{
+			cause:     "MISC:unspecified",
+			packedPdu: "402500080000010005400168",
+		},
+		{
+			cause:     "protocol:message-not-compatible-with-receiver-state",
+			packedPdu: "402500080000010005400146",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.packedPdu, func(t *testing.T) {
+
+			payload, ok := KnownCausesToEndcConfigurationUpdateFailurePDU(tc.cause)
+			if !ok {
+				t.Errorf("want: success, got: not found.\n")
+			} else {
+				tmp := fmt.Sprintf("%x", payload)
+				if strings.Compare(tmp, tc.packedPdu) != 0 {
+					t.Errorf("\nwant :\t[%s]\n got: \t\t[%s]\n", tc.packedPdu, tmp)
+				}
+			}
+		})
+	}
+}
+
+func TestKnownCausesToEndcConfigurationUpdateFailurePDUFailure(t *testing.T) {
+	_, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("failed to initialize logger, error: %s", err)
+	}
+
+	_, ok := KnownCausesToEndcConfigurationUpdateFailurePDU("xxxx")
+	if ok {
+		t.Errorf("want: not found, got: success.\n")
+	}
+}
+
+func TestPrepareEndcConfigurationUpdateFailurePDUsFailure(t *testing.T) {
+	_, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("failed to initialize logger, error: %s", err)
+	}
+
+	err = prepareEndcConfigurationUpdateFailurePDUs(1, 4096)
+	if err == nil {
+		t.Errorf("want: error, got: success.\n")
+	}
+
+	expected := "#configuration_update.prepareEndcConfigurationUpdateFailurePDUs - failed to build and pack the endc configuration update failure message #src/asn1codec_utils.c.pack_pdu_aux - Encoded output of E2AP-PDU, is too big"
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("want :[%s], got: [%s]\n", expected, err)
+	}
+}
@@ -21,13 +21,14 @@ package httpmsghandlers
 
 import "C"
 import (
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
@@ -57,41 +58,41 @@ func NewDeleteAllRequestHandler(logger *logger.Logger, rmrSender *rmrsender.RmrS
 	}
 }
 
-func (h *DeleteAllRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *DeleteAllRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 	h.logger.Infof("#DeleteAllRequestHandler.Handle - handling shutdown request")
 
-	e2tAddresses, err := h.e2tInstancesManager.GetE2TAddresses()
+	e2tAddresses, err := h.e2tInstancesManager.GetE2TAddresses(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
 	if len(e2tAddresses) == 0 {
-		err, _ = h.updateNodebs(h.updateNodebInfoForceShutdown)
+		err, _ = h.updateNodebs(ctx, h.updateNodebInfoForceShutdown)
 		return nil, err
 	}
 
-	dissocErr := h.rmClient.DissociateAllRans(e2tAddresses)
+	dissocErr := h.rmClient.DissociateAllRans(ctx, e2tAddresses)
 
 	if dissocErr != nil {
 		h.logger.Warnf("#DeleteAllRequestHandler.Handle - routing manager failure. continue flow.")
 	}
 
-	err, updatedAtLeastOnce := h.updateNodebs(h.updateNodebInfoShuttingDown)
+	err, updatedAtLeastOnce := h.updateNodebs(ctx, h.updateNodebInfoShuttingDown)
 
 	if err != nil {
 		return nil, err
 	}
 
-	err = h.e2tInstancesManager.ClearRansOfAllE2TInstances()
+	err = h.e2tInstancesManager.ClearRansOfAllE2TInstances(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
-	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
+	rmrMessage := models.RmrMessage{MsgType: rmrtypes.RIC_SCTP_CLEAR_ALL}
 
-	err = h.rmrSender.Send(&rmrMessage)
+	err = h.rmrSender.Send(ctx, &rmrMessage)
 
 	if err != nil {
 		h.logger.Errorf("#DeleteAllRequestHandler.Handle - failed to send sctp clear all message to RMR: %s", err)
@@ -111,7 +112,7 @@ func (h *DeleteAllRequestHandler) Handle(request models.Request) (models.IRespon
 	time.Sleep(time.Duration(h.config.BigRedButtonTimeoutSec) * time.Second)
 	h.logger.Infof("#DeleteAllRequestHandler.Handle - timer expired")
 
-	err, _ = h.updateNodebs(h.updateNodebInfoShutDown)
+	err, _ = h.updateNodebs(ctx, h.updateNodebInfoShutDown)
 
 	if err != nil {
 		return nil, err
@@ -124,8 +125,8 @@ func (h *DeleteAllRequestHandler) Handle(request models.Request) (models.IRespon
 	return nil, nil
 }
 
-func (h *DeleteAllRequestHandler) updateNodebs(updateCb func(node *entities.NodebInfo) (error, bool)) (error, bool) {
-	nbIdentityList, err := h.rnibDataService.GetListNodebIds()
+func (h *DeleteAllRequestHandler) updateNodebs(ctx context.Context, updateCb func(ctx context.Context, node *entities.NodebInfo) (error, bool)) (error, bool) {
+	nbIdentityList, err := h.rnibDataService.GetListNodebIds(ctx)
 
 	if err != nil {
 		h.logger.Errorf("#DeleteAllRequestHandler.updateNodebs - failed to get nodes list from rNib. Error: %s", err)
@@ -135,7 +136,7 @@ func (h *DeleteAllRequestHandler) updateNodebs(updateCb func(node *entities.Node
 	updatedAtLeastOnce := false
 
 	for _, nbIdentity := range nbIdentityList {
-		node, err := h.rnibDataService.GetNodeb(nbIdentity.InventoryName)
+		node, err := h.rnibDataService.GetNodeb(ctx, nbIdentity.InventoryName)
 
 		if err != nil {
 			_, ok := err.(*common.ResourceNotFoundError)
@@ -147,7 +148,7 @@ func (h *DeleteAllRequestHandler) updateNodebs(updateCb func(node *entities.Node
 			continue
 		}
 
-		err, updated := updateCb(node)
+		err, updated := updateCb(ctx, node)
 
 		if err != nil {
 			return err, false
@@ -161,8 +162,8 @@ func (h *DeleteAllRequestHandler) updateNodebs(updateCb func(node *entities.Node
 	return nil, updatedAtLeastOnce
 }
 
-func (h *DeleteAllRequestHandler) updateNodebInfoForceShutdown(node *entities.NodebInfo) (error, bool) {
-	err := h.updateNodebInfo(node, entities.ConnectionStatus_SHUT_DOWN, true)
+func (h *DeleteAllRequestHandler) updateNodebInfoForceShutdown(ctx context.Context, node *entities.NodebInfo) (error, bool) {
+	err := h.updateNodebInfo(ctx, node, entities.ConnectionStatus_SHUT_DOWN, true, "shutdown request, no E2T instances")
 
 	if err != nil {
 		return err, false
@@ -171,12 +172,12 @@ func (h *DeleteAllRequestHandler) updateNodebInfoForceShutdown(node *entities.No
 	return nil, true
 }
 
-func (h *DeleteAllRequestHandler) updateNodebInfoShuttingDown(node *entities.NodebInfo) (error, bool) {
+func (h *DeleteAllRequestHandler) updateNodebInfoShuttingDown(ctx context.Context, node *entities.NodebInfo) (error, bool) {
 	if node.ConnectionStatus == entities.ConnectionStatus_SHUT_DOWN {
 		return nil, false
 	}
 
-	err := h.updateNodebInfo(node, entities.ConnectionStatus_SHUTTING_DOWN, true)
+	err := h.updateNodebInfo(ctx, node, entities.ConnectionStatus_SHUTTING_DOWN, true, "shutdown request")
 
 	if err != nil {
 		return err, false
@@ -185,7 +186,7 @@ func (h *DeleteAllRequestHandler) updateNodebInfoShuttingDown(node *entities.Nod
 	return nil, true
 }
 
-func (h *DeleteAllRequestHandler) updateNodebInfoShutDown(node *entities.NodebInfo) (error, bool) {
+func (h *DeleteAllRequestHandler) updateNodebInfoShutDown(ctx context.Context, node *entities.NodebInfo) (error, bool) {
 	if node.ConnectionStatus == entities.ConnectionStatus_SHUT_DOWN {
 		return nil, false
 	}
@@ -195,7 +196,7 @@ func (h *DeleteAllRequestHandler) updateNodebInfoShutDown(node *entities.NodebIn
 		return nil, false
 	}
 
-	err :=  h.updateNodebInfo(node, entities.ConnectionStatus_SHUT_DOWN, false)
+	err :=  h.updateNodebInfo(ctx, node, entities.ConnectionStatus_SHUT_DOWN, false, "shutdown timer expired")
 
 	if err != nil {
 		return err, false
@@ -204,14 +205,15 @@ func (h *DeleteAllRequestHandler) updateNodebInfoShutDown(node *entities.NodebIn
 	return nil, true
 }
 
-func (h *DeleteAllRequestHandler) updateNodebInfo(node *entities.NodebInfo, connectionStatus entities.ConnectionStatus, resetAssociatedE2TAddress bool) error {
+func (h *DeleteAllRequestHandler) updateNodebInfo(ctx context.Context, node *entities.NodebInfo, connectionStatus entities.ConnectionStatus, resetAssociatedE2TAddress bool, cause string) error {
+	previousStatus := node.ConnectionStatus
 	node.ConnectionStatus = connectionStatus
 
 	if resetAssociatedE2TAddress {
 		node.AssociatedE2TInstanceAddress = ""
 	}
 
-	err := h.rnibDataService.UpdateNodebInfo(node)
+	err := h.rnibDataService.UpdateNodebInfo(ctx, node)
 
 	if err != nil {
 		h.logger.Errorf("#DeleteAllRequestHandler.updateNodebInfo - RAN name: %s - failed updating nodeB entity in rNib. error: %s", node.RanName, err)
@@ -219,6 +221,14 @@ func (h *DeleteAllRequestHandler) updateNodebInfo(node *entities.NodebInfo, conn
 	}
 
 	h.logger.Infof("#DeleteAllRequestHandler.updateNodebInfo - RAN name: %s, connection status: %s", node.RanName, connectionStatus)
+
+	ranStatusChange := models.NewRanStatusChange(previousStatus, connectionStatus, cause, "DeleteAllRequestHandler")
+	err = h.rnibDataService.AddRanStatusChange(ctx, node.RanName, ranStatusChange)
+
+	if err != nil {
+		h.logger.Warnf("#DeleteAllRequestHandler.updateNodebInfo - RAN name: %s - failed adding status change to RAN's status history. error: %s", node.RanName, err)
+	}
+
 	return nil
 
 }
@@ -21,6 +21,7 @@ package httpmsghandlers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
@@ -28,7 +29,7 @@ import (
 	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
@@ -37,6 +38,7 @@ import (
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"io/ioutil"
 	"net/http"
 	"testing"
@@ -62,6 +64,12 @@ func setupDeleteAllRequestHandlerTest(t *testing.T) (*DeleteAllRequestHandler, *
 	return handler, readerMock, writerMock, rmrMessengerMock, httpClientMock
 }
 
+func mockAddRanStatusChange(writerMock *mocks.RnibWriterMock, ranName string, previousStatus entities.ConnectionStatus, newStatus entities.ConnectionStatus) {
+	writerMock.On("AddRanStatusChange", ranName, mock.MatchedBy(func(ranStatusChange *models.RanStatusChange) bool {
+		return ranStatusChange.PreviousStatus == previousStatus.String() && ranStatusChange.NewStatus == newStatus.String() && ranStatusChange.Component == "DeleteAllRequestHandler"
+	}), 50).Return(nil)
+}
+
 func mapE2TAddressesToE2DataList(e2tAddresses []string) models.RoutingManagerE2TDataList {
 	e2tDataList := make(models.RoutingManagerE2TDataList, len(e2tAddresses))
 
@@ -91,7 +99,7 @@ func mockHttpClientDissociateAllRans(httpClientMock *mocks.HttpClientMock, e2tAd
 func TestGetE2TAddressesFailure(t *testing.T) {
 	h, readerMock, _, _, _ := setupDeleteAllRequestHandlerTest(t)
 	readerMock.On("GetE2TAddresses").Return([]string{}, common.NewInternalError(errors.New("error")))
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
 }
@@ -105,7 +113,8 @@ func TestOneRanGetE2TAddressesEmptyList(t *testing.T) {
 	readerMock.On("GetNodeb", "RanName_1").Return(nb1, nil)
 	updatedNb1 := &entities.NodebInfo{RanName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN,}
 	writerMock.On("UpdateNodebInfo", updatedNb1).Return(nil)
-	_, err := h.Handle(nil)
+	mockAddRanStatusChange(writerMock, "RanName_1", entities.ConnectionStatus_DISCONNECTED, entities.ConnectionStatus_SHUT_DOWN)
+	_, err := h.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -120,9 +129,10 @@ func TestTwoRansGetE2TAddressesEmptyListOneGetNodebFailure(t *testing.T) {
 	readerMock.On("GetNodeb", "RanName_1").Return(nb1, nil)
 	updatedNb1 := &entities.NodebInfo{RanName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN,}
 	writerMock.On("UpdateNodebInfo", updatedNb1).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_1", entities.ConnectionStatus_DISCONNECTED, entities.ConnectionStatus_SHUT_DOWN)
 	var nb2 *entities.NodebInfo
 	readerMock.On("GetNodeb", "RanName_2").Return(nb2, common.NewInternalError(errors.New("error")))
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 	assert.IsType(t,&e2managererrors.RnibDbError{}, err)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 1)
 	readerMock.AssertExpectations(t)
@@ -138,12 +148,13 @@ func TestTwoRansGetE2TAddressesEmptyListOneUpdateNodebInfoFailure(t *testing.T)
 	readerMock.On("GetNodeb", "RanName_1").Return(nb1, nil)
 	updatedNb1 := &entities.NodebInfo{RanName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN,}
 	writerMock.On("UpdateNodebInfo", updatedNb1).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_1", entities.ConnectionStatus_DISCONNECTED, entities.ConnectionStatus_SHUT_DOWN)
 
 	nb2 := &entities.NodebInfo{RanName: "RanName_2", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED,}
 	readerMock.On("GetNodeb", "RanName_2").Return(nb2, nil)
 	updatedNb2 := &entities.NodebInfo{RanName: "RanName_2", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN,}
 	writerMock.On("UpdateNodebInfo", updatedNb2).Return(common.NewInternalError(errors.New("error")))
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 	assert.IsType(t,&e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -156,7 +167,7 @@ func TestOneRanDissociateSucceedsTryShuttingDownFailure(t *testing.T) {
 	mockHttpClientDissociateAllRans(httpClientMock, e2tAddresses, true)
 	nbIdentityList := []*entities.NbIdentity{}
 	readerMock.On("GetListNodebIds").Return(nbIdentityList, common.NewInternalError(errors.New("error")))
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -170,7 +181,7 @@ func TestOneRanDissociateFailsTryShuttingDownFailure(t *testing.T) {
 	mockHttpClientDissociateAllRans(httpClientMock, e2tAddresses, false)
 	nbIdentityList := []*entities.NbIdentity{}
 	readerMock.On("GetListNodebIds").Return(nbIdentityList, common.NewInternalError(errors.New("error")))
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -187,9 +198,10 @@ func TestOneRanTryShuttingDownSucceedsClearFails(t *testing.T) {
 	readerMock.On("GetNodeb", "RanName_1").Return(nb1, nil)
 	updatedNb1 := &entities.NodebInfo{RanName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUTTING_DOWN,}
 	writerMock.On("UpdateNodebInfo", updatedNb1).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_1", entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_SHUTTING_DOWN)
 	readerMock.On("GetE2TAddresses").Return([]string{E2TAddress}, nil)
 	readerMock.On("GetE2TInstances", []string{E2TAddress}).Return([]*entities.E2TInstance{}, common.NewInternalError(errors.New("error")))
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -206,6 +218,7 @@ func TestOneRanTryShuttingDownSucceedsClearSucceedsRmrSendFails(t *testing.T) {
 	readerMock.On("GetNodeb", "RanName_1").Return(nb1, nil)
 	updatedNb1 := &entities.NodebInfo{RanName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUTTING_DOWN,}
 	writerMock.On("UpdateNodebInfo", updatedNb1).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_1", entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_SHUTTING_DOWN)
 	readerMock.On("GetE2TAddresses").Return([]string{E2TAddress}, nil)
 	e2tInstance := entities.E2TInstance{Address: E2TAddress, AssociatedRanList: []string{"RanName_1"}}
 	readerMock.On("GetE2TInstances", []string{E2TAddress}).Return([]*entities.E2TInstance{&e2tInstance}, nil)
@@ -213,10 +226,10 @@ func TestOneRanTryShuttingDownSucceedsClearSucceedsRmrSendFails(t *testing.T) {
 	updatedE2tInstance.AssociatedRanList = []string{}
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 
-	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
-	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
+	rmrMessage := models.RmrMessage{MsgType: rmrtypes.RIC_SCTP_CLEAR_ALL}
+	mbuf := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, e2managererrors.NewRmrError())
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RmrError{}, err)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
 	readerMock.AssertExpectations(t)
@@ -241,10 +254,10 @@ func testTwoRansTryShuttingDownSucceedsClearSucceedsRmrSucceedsAllRansAreShutdow
 	updatedE2tInstance.AssociatedRanList = []string{}
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 
-	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
-	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
+	rmrMessage := models.RmrMessage{MsgType: rmrtypes.RIC_SCTP_CLEAR_ALL}
+	mbuf := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil)
-	resp, err := h.Handle(nil)
+	resp, err := h.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 
 	if partial {
@@ -285,8 +298,8 @@ func TestTwoRansTryShuttingDownSucceedsClearSucceedsRmrSucceedsAllRansAreShutdow
 //	updatedE2tInstance.AssociatedRanList = []string{}
 //	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 //
-//	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
-//	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction)
+//	rmrMessage := models.RmrMessage{MsgType: rmrtypes.RIC_SCTP_CLEAR_ALL}
+//	mbuf := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction)
 //	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil)
 //
 //	readerMock.On("GetListNodebIds").Return(nbIdentityList, nil)
@@ -318,8 +331,8 @@ func TestTwoRansTryShuttingDownSucceedsClearSucceedsRmrSucceedsAllRansAreShutdow
 //	updatedE2tInstance.AssociatedRanList = []string{}
 //	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 //
-//	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
-//	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction)
+//	rmrMessage := models.RmrMessage{MsgType: rmrtypes.RIC_SCTP_CLEAR_ALL}
+//	mbuf := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction)
 //	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil)
 //
 //	readerMock.On("GetListNodebIds").Return(nbIdentityList, nil)
@@ -352,15 +365,16 @@ func testOneRanTryShuttingDownSucceedsClearSucceedsRmrSucceedsRanStatusIsShuttin
 	updatedE2tInstance.AssociatedRanList = []string{}
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 
-	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
-	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
+	rmrMessage := models.RmrMessage{MsgType: rmrtypes.RIC_SCTP_CLEAR_ALL}
+	mbuf := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil)
 
 	readerMock.On("GetListNodebIds").Return(nbIdentityList, nil)
 	readerMock.On("GetNodeb", "RanName_1").Return(updatedNb1, nil)
 	updatedNb2 := &entities.NodebInfo{RanName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN,}
 	writerMock.On("UpdateNodebInfo", updatedNb2).Return(nil)
-	_, err := h.Handle(nil)
+	mockAddRanStatusChange(writerMock, "RanName_1", entities.ConnectionStatus_SHUTTING_DOWN, entities.ConnectionStatus_SHUT_DOWN)
+	_, err := h.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
 	readerMock.AssertExpectations(t)
@@ -420,8 +434,8 @@ func TestSuccessTwoE2TInstancesSixRans(t *testing.T) {
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance2).Return(nil)
 
-	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
-	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
+	rmrMessage := models.RmrMessage{MsgType: rmrtypes.RIC_SCTP_CLEAR_ALL}
+	mbuf := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil)
 
 	readerMock.On("GetListNodebIds").Return(nbIdentityList, nil)
@@ -435,22 +449,28 @@ func TestSuccessTwoE2TInstancesSixRans(t *testing.T) {
 	updatedNb1AfterTimer := *updatedNb1
 	updatedNb1AfterTimer.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", &updatedNb1AfterTimer).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_1", entities.ConnectionStatus_SHUTTING_DOWN, entities.ConnectionStatus_SHUT_DOWN)
 	updatedNb2AfterTimer := *updatedNb2
 	updatedNb2AfterTimer.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", &updatedNb2AfterTimer).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_2", entities.ConnectionStatus_SHUTTING_DOWN, entities.ConnectionStatus_SHUT_DOWN)
 	updatedNb3AfterTimer := *updatedNb3
 	updatedNb3AfterTimer.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", &updatedNb3AfterTimer).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_3", entities.ConnectionStatus_SHUTTING_DOWN, entities.ConnectionStatus_SHUT_DOWN)
 	updatedNb4AfterTimer := *updatedNb4
 	updatedNb4AfterTimer.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", &updatedNb4AfterTimer).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_4", entities.ConnectionStatus_SHUTTING_DOWN, entities.ConnectionStatus_SHUT_DOWN)
 	updatedNb5AfterTimer := *updatedNb5
 	updatedNb5AfterTimer.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", &updatedNb5AfterTimer).Return(nil)
+	mockAddRanStatusChange(writerMock, "RanName_5", entities.ConnectionStatus_SHUTTING_DOWN, entities.ConnectionStatus_SHUT_DOWN)
 	updatedNb6AfterTimer := *updatedNb6
 	updatedNb6AfterTimer.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", &updatedNb6AfterTimer).Return(nil)
-	_, err := h.Handle(nil)
+	mockAddRanStatusChange(writerMock, "RanName_6", entities.ConnectionStatus_SHUTTING_DOWN, entities.ConnectionStatus_SHUT_DOWN)
+	_, err := h.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
 	readerMock.AssertExpectations(t)
@@ -467,7 +487,7 @@ func initLog(t *testing.T) *logger.Logger {
 }
 
 func getRmrSender(rmrMessengerMock *mocks.RmrMessengerMock, log *logger.Logger) *rmrsender.RmrSender {
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrMessengerMock.On("Init", tests.GetPort(), tests.MaxMsgSize, tests.Flags, log).Return(&rmrMessenger)
 	return rmrsender.NewRmrSender(log, rmrMessenger)
 }
@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
@@ -38,9 +39,9 @@ func NewGetE2TInstancesRequestHandler(logger *logger.Logger, e2tInstancesManager
 	}
 }
 
-func (h *GetE2TInstancesRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *GetE2TInstancesRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 
-	e2tInstances, err := h.e2tInstancesManager.GetE2TInstances()
+	e2tInstances, err := h.e2tInstancesManager.GetE2TInstances(ctx)
 
 	if err != nil {
 		h.logger.Errorf("#GetE2TInstancesRequestHandler.Handle - Error fetching E2T instances from rNib: %s", err)
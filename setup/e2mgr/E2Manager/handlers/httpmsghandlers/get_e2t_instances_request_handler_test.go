@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/managers"
 	"e2mgr/mocks"
@@ -49,14 +50,14 @@ func setupGetE2TInstancesListRequestHandlerTest(t *testing.T) (*GetE2TInstancesR
 func TestGetE2TInstancesFailure(t *testing.T) {
 	handler, rnibReaderMock := setupGetE2TInstancesListRequestHandlerTest(t)
 	rnibReaderMock.On("GetE2TAddresses").Return([]string{}, common.NewInternalError(errors.New("error")))
-	_, err := handler.Handle(nil)
+	_, err := handler.Handle(context.Background(), nil)
 	assert.NotNil(t, err)
 }
 
 func TestGetE2TInstancesNoInstances(t *testing.T) {
 	handler, rnibReaderMock := setupGetE2TInstancesListRequestHandlerTest(t)
 	rnibReaderMock.On("GetE2TAddresses").Return([]string{}, nil)
-	resp, err := handler.Handle(nil)
+	resp, err := handler.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	assert.IsType(t, models.E2TInstancesResponse{}, resp)
 	assert.Len(t, resp, 0)
@@ -71,7 +72,7 @@ func TestGetE2TInstancesSuccess(t *testing.T) {
 	e2tInstance2 := entities.E2TInstance{Address: E2TAddress2, AssociatedRanList: []string{"test3", "test4", "test5"}}
 
 	rnibReaderMock.On("GetE2TInstances", e2tAddresses).Return([]*entities.E2TInstance{&e2tInstance, &e2tInstance2}, nil)
-	resp, err := handler.Handle(nil)
+	resp, err := handler.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	assert.IsType(t, models.E2TInstancesResponse{}, resp)
 	assert.Len(t, resp, 2)
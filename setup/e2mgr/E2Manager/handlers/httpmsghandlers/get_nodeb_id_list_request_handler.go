@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/models"
@@ -39,9 +40,9 @@ func NewGetNodebIdListRequestHandler(logger *logger.Logger, rNibDataService serv
 	}
 }
 
-func (handler *GetNodebIdListRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (handler *GetNodebIdListRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 
-	nodebIdList, err := handler.rNibDataService.GetListNodebIds()
+	nodebIdList, err := handler.rNibDataService.GetListNodebIds(ctx)
 
 	if err != nil {
 		handler.logger.Errorf("#GetNodebIdListRequestHandler.Handle - Error fetching Nodeb Identity list from rNib: %v", err)
@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/mocks"
 	"e2mgr/models"
@@ -46,7 +47,7 @@ func TestHandleGetNodebIdListSuccess(t *testing.T) {
 	handler, readerMock := setupGetNodebIdListRequestHandlerTest(t)
 	var rnibError error
 	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{}, rnibError)
-	response, err := handler.Handle(nil)
+	response, err := handler.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
 	assert.IsType(t, &models.GetNodebIdListResponse{}, response)
@@ -56,7 +57,7 @@ func TestHandleGetNodebIdListFailure(t *testing.T) {
 	handler, readerMock := setupGetNodebIdListRequestHandlerTest(t)
 	var nodebIdList []*entities.NbIdentity
 	readerMock.On("GetListNodebIds").Return(nodebIdList, common.NewInternalError(errors.New("#reader.GetListNodebIds - Internal Error")))
-	response, err := handler.Handle(nil)
+	response, err := handler.Handle(context.Background(), nil)
 	assert.NotNil(t, err)
 	assert.Nil(t, response)
 }
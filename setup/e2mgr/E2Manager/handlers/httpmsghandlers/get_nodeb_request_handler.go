@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/models"
@@ -40,10 +41,10 @@ func NewGetNodebRequestHandler(logger *logger.Logger, rNibDataService services.R
 	}
 }
 
-func (handler *GetNodebRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (handler *GetNodebRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 	getNodebRequest := request.(models.GetNodebRequest)
 	ranName:= getNodebRequest.RanName
-	nodeb, err := handler.rNibDataService.GetNodeb(ranName)
+	nodeb, err := handler.rNibDataService.GetNodeb(ctx, ranName)
 
 	if err != nil {
 		handler.logger.Errorf("#GetNodebRequestHandler.Handle - RAN name: %s - Error fetching RAN from rNib: %v",  ranName, err)
@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/mocks"
 	"e2mgr/models"
@@ -47,7 +48,7 @@ func TestHandleGetNodebSuccess(t *testing.T) {
 	ranName := "test1"
 	var rnibError error
 	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName:ranName}, rnibError)
-	response, err := handler.Handle(models.GetNodebRequest{RanName: ranName})
+	response, err := handler.Handle(context.Background(), models.GetNodebRequest{RanName: ranName})
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
 	assert.IsType(t, &models.GetNodebResponse{}, response)
@@ -58,7 +59,7 @@ func TestHandleGetNodebFailure(t *testing.T) {
 	ranName := "test1"
 	var nodebInfo *entities.NodebInfo
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, common.NewInternalError(errors.New("#reader.GetNodeb - Internal Error")))
-	response, err := handler.Handle(models.GetNodebRequest{RanName: ranName})
+	response, err := handler.Handle(context.Background(), models.GetNodebRequest{RanName: ranName})
 	assert.NotNil(t, err)
 	assert.Nil(t, response)
 }
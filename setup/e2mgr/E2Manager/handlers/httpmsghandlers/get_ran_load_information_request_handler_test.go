@@ -0,0 +1,103 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func setupGetRanLoadInformationRequestHandlerTest(t *testing.T) (*GetRanLoadInformationRequestHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
+	log := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	handler := NewGetRanLoadInformationRequestHandler(log, rnibDataService)
+	return handler, readerMock, writerMock
+}
+
+func TestHandleGetRanLoadInformationSuccess(t *testing.T) {
+	handler, readerMock, writerMock := setupGetRanLoadInformationRequestHandlerTest(t)
+	ranName := "test1"
+	cells := []*models.CellLoadInformation{
+		models.NewCellLoadInformation(1588327200000000000, &entities.CellLoadInformation{
+			CellId:                    "02f829:0007ab50",
+			RelativeNarrowbandTxPower: &entities.RelativeNarrowbandTxPower{RntpPerPrb: "cc", PB: 2, PdcchInterferenceImpact: 1},
+		}),
+	}
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, nil)
+	writerMock.On("GetCellLoadInformation", ranName).Return(cells, nil)
+
+	response, err := handler.Handle(context.Background(), models.GetRanLoadInformationRequest{RanName: ranName})
+
+	assert.Nil(t, err)
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"ranName\":\"test1\",\"cells\":[{\"cellId\":\"02f829:0007ab50\",\"timestamp\":\"2020-05-01T10:00:00Z\",\"loadInformation\":{\"cellId\":\"02f829:0007ab50\",\"relativeNarrowbandTxPower\":{\"rntpPerPrb\":\"cc\",\"pB\":2,\"pdcchInterferenceImpact\":1}}}]}", string(data))
+}
+
+func TestHandleGetRanLoadInformationEmpty(t *testing.T) {
+	handler, readerMock, writerMock := setupGetRanLoadInformationRequestHandlerTest(t)
+	ranName := "test1"
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, nil)
+	writerMock.On("GetCellLoadInformation", ranName).Return([]*models.CellLoadInformation{}, nil)
+
+	response, err := handler.Handle(context.Background(), models.GetRanLoadInformationRequest{RanName: ranName})
+
+	assert.Nil(t, err)
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"ranName\":\"test1\",\"cells\":[]}", string(data))
+}
+
+func TestHandleGetRanLoadInformationRanNotFound(t *testing.T) {
+	handler, readerMock, writerMock := setupGetRanLoadInformationRequestHandlerTest(t)
+	ranName := "test1"
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", ranName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+
+	response, err := handler.Handle(context.Background(), models.GetRanLoadInformationRequest{RanName: ranName})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+	writerMock.AssertNotCalled(t, "GetCellLoadInformation", ranName)
+}
+
+func TestHandleGetRanLoadInformationFailure(t *testing.T) {
+	handler, readerMock, writerMock := setupGetRanLoadInformationRequestHandlerTest(t)
+	ranName := "test1"
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, nil)
+	writerMock.On("GetCellLoadInformation", ranName).Return(nil, common.NewInternalError(errors.New("#writer.GetCellLoadInformation - Internal Error")))
+
+	response, err := handler.Handle(context.Background(), models.GetRanLoadInformationRequest{RanName: ranName})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
+}
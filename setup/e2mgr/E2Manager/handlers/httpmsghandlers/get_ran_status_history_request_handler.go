@@ -0,0 +1,61 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/services"
+)
+
+type GetRanStatusHistoryRequestHandler struct {
+	rNibDataService services.RNibDataService
+	logger          *logger.Logger
+}
+
+func NewGetRanStatusHistoryRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService) *GetRanStatusHistoryRequestHandler {
+	return &GetRanStatusHistoryRequestHandler{
+		logger:          logger,
+		rNibDataService: rNibDataService,
+	}
+}
+
+func (handler *GetRanStatusHistoryRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	getRanStatusHistoryRequest := request.(models.GetRanStatusHistoryRequest)
+	ranName := getRanStatusHistoryRequest.RanName
+
+	_, err := handler.rNibDataService.GetNodeb(ctx, ranName)
+
+	if err != nil {
+		handler.logger.Errorf("#GetRanStatusHistoryRequestHandler.Handle - RAN name: %s - Error fetching RAN from rNib: %v", ranName, err)
+		return nil, rnibErrorToE2ManagerError(err)
+	}
+
+	history, err := handler.rNibDataService.GetRanStatusHistory(ctx, ranName)
+
+	if err != nil {
+		handler.logger.Errorf("#GetRanStatusHistoryRequestHandler.Handle - RAN name: %s - Error fetching RAN's status history from rNib: %v", ranName, err)
+		return nil, e2managererrors.NewRnibDbError()
+	}
+
+	return models.NewGetRanStatusHistoryResponse(ranName, history), nil
+}
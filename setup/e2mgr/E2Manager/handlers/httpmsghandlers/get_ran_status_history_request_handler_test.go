@@ -0,0 +1,97 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func setupGetRanStatusHistoryRequestHandlerTest(t *testing.T) (*GetRanStatusHistoryRequestHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
+	log := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	handler := NewGetRanStatusHistoryRequestHandler(log, rnibDataService)
+	return handler, readerMock, writerMock
+}
+
+func TestHandleGetRanStatusHistorySuccess(t *testing.T) {
+	handler, readerMock, writerMock := setupGetRanStatusHistoryRequestHandlerTest(t)
+	ranName := "test1"
+	history := []*models.RanStatusChange{models.NewRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, "RAN connection lost", "RanDisconnectionManager")}
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, nil)
+	writerMock.On("GetRanStatusHistory", ranName).Return(history, nil)
+
+	response, err := handler.Handle(context.Background(), models.GetRanStatusHistoryRequest{RanName: ranName})
+
+	assert.Nil(t, err)
+	assert.Equal(t, models.NewGetRanStatusHistoryResponse(ranName, history), response)
+}
+
+func TestHandleGetRanStatusHistoryEmpty(t *testing.T) {
+	handler, readerMock, writerMock := setupGetRanStatusHistoryRequestHandlerTest(t)
+	ranName := "test1"
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, nil)
+	writerMock.On("GetRanStatusHistory", ranName).Return([]*models.RanStatusChange{}, nil)
+
+	response, err := handler.Handle(context.Background(), models.GetRanStatusHistoryRequest{RanName: ranName})
+
+	assert.Nil(t, err)
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"ranName\":\"test1\",\"history\":[]}", string(data))
+}
+
+func TestHandleGetRanStatusHistoryRanNotFound(t *testing.T) {
+	handler, readerMock, writerMock := setupGetRanStatusHistoryRequestHandlerTest(t)
+	ranName := "test1"
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", ranName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+
+	response, err := handler.Handle(context.Background(), models.GetRanStatusHistoryRequest{RanName: ranName})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+	writerMock.AssertNotCalled(t, "GetRanStatusHistory", ranName)
+}
+
+func TestHandleGetRanStatusHistoryFailure(t *testing.T) {
+	handler, readerMock, writerMock := setupGetRanStatusHistoryRequestHandlerTest(t)
+	ranName := "test1"
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, nil)
+	writerMock.On("GetRanStatusHistory", ranName).Return(nil, common.NewInternalError(errors.New("#writer.GetRanStatusHistory - Internal Error")))
+
+	response, err := handler.Handle(context.Background(), models.GetRanStatusHistoryRequest{RanName: ranName})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
+}
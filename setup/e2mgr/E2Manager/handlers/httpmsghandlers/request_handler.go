@@ -20,9 +20,10 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/models"
 )
 
 type RequestHandler interface {
-	Handle(request models.Request) (models.IResponse, error)
+	Handle(ctx context.Context, request models.Request) (models.IResponse, error)
 }
@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
@@ -57,7 +58,7 @@ func NewSetupRequestHandler(logger *logger.Logger, rNibDataService services.RNib
 	}
 }
 
-func (h *SetupRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *SetupRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 
 	setupRequest := request.(models.SetupRequest)
 
@@ -66,7 +67,7 @@ func (h *SetupRequestHandler) Handle(request models.Request) (models.IResponse,
 		return nil, err
 	}
 
-	nodebInfo, err := h.rNibDataService.GetNodeb(setupRequest.RanName)
+	nodebInfo, err := h.rNibDataService.GetNodeb(ctx, setupRequest.RanName)
 
 	if err != nil {
 		_, ok := err.(*common.ResourceNotFoundError)
@@ -75,7 +76,7 @@ func (h *SetupRequestHandler) Handle(request models.Request) (models.IResponse,
 			return nil, e2managererrors.NewRnibDbError()
 		}
 
-		result := h.connectNewRan(&setupRequest, h.protocol)
+		result := h.connectNewRan(ctx, &setupRequest, h.protocol)
 		return nil, result
 	}
 
@@ -86,11 +87,11 @@ func (h *SetupRequestHandler) Handle(request models.Request) (models.IResponse,
 	}
 
 	if len(nodebInfo.AssociatedE2TInstanceAddress) != 0 {
-		result := h.connectExistingRanWithAssociatedE2TAddress(nodebInfo)
+		result := h.connectExistingRanWithAssociatedE2TAddress(ctx, nodebInfo)
 		return nil, result
 	}
 
-	result := h.connectExistingRanWithoutAssociatedE2TAddress(nodebInfo)
+	result := h.connectExistingRanWithoutAssociatedE2TAddress(ctx, nodebInfo)
 	return nil, result
 }
 
@@ -111,8 +112,8 @@ func createInitialNodeInfo(requestDetails *models.SetupRequest, protocol entitie
 	return nodebInfo, nbIdentity
 }
 
-func (h *SetupRequestHandler) connectExistingRanWithoutAssociatedE2TAddress(nodebInfo *entities.NodebInfo) error {
-	e2tAddress, err := h.e2tInstancesManager.SelectE2TInstance()
+func (h *SetupRequestHandler) connectExistingRanWithoutAssociatedE2TAddress(ctx context.Context, nodebInfo *entities.NodebInfo) error {
+	e2tAddress, err := h.e2tInstancesManager.SelectE2TInstance(ctx)
 
 	if err != nil {
 		h.logger.Errorf("#SetupRequestHandler.connectExistingRanWithoutAssociatedE2TAddress - RAN name: %s - failed selecting E2T instance", nodebInfo.RanName)
@@ -122,7 +123,7 @@ func (h *SetupRequestHandler) connectExistingRanWithoutAssociatedE2TAddress(node
 		}
 
 		nodebInfo.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
-		updateError := h.rNibDataService.UpdateNodebInfo(nodebInfo)
+		updateError := h.rNibDataService.UpdateNodebInfo(ctx, nodebInfo)
 
 		if updateError != nil {
 			h.logger.Errorf("#SetupRequestHandler.connectExistingRanWithoutAssociatedE2TAddress - RAN name: %s - failed updating nodeb. error: %s", nodebInfo.RanName, updateError)
@@ -131,7 +132,7 @@ func (h *SetupRequestHandler) connectExistingRanWithoutAssociatedE2TAddress(node
 		return err
 	}
 
-	err = h.e2tAssociationManager.AssociateRan(e2tAddress, nodebInfo)
+	err = h.e2tAssociationManager.AssociateRan(ctx, e2tAddress, nodebInfo)
 
 	if err != nil {
 		h.logger.Errorf("#SetupRequestHandler.connectExistingRanWithoutAssociatedE2TAddress - RAN name: %s - failed associating ran to e2t address %s. error: %s", nodebInfo.RanName, e2tAddress, err)
@@ -140,16 +141,16 @@ func (h *SetupRequestHandler) connectExistingRanWithoutAssociatedE2TAddress(node
 
 	h.logger.Infof("#SetupRequestHandler.connectExistingRanWithoutAssociatedE2TAddress - RAN name: %s - successfully updated nodeb in rNib", nodebInfo.RanName)
 
-	result := h.ranSetupManager.ExecuteSetup(nodebInfo, entities.ConnectionStatus_CONNECTING)
+	result := h.ranSetupManager.ExecuteSetup(ctx, nodebInfo, entities.ConnectionStatus_CONNECTING)
 	return result
 }
 
-func (h *SetupRequestHandler) connectExistingRanWithAssociatedE2TAddress(nodebInfo *entities.NodebInfo) error {
+func (h *SetupRequestHandler) connectExistingRanWithAssociatedE2TAddress(ctx context.Context, nodebInfo *entities.NodebInfo) error {
 	status := entities.ConnectionStatus_CONNECTING
 	if nodebInfo.ConnectionStatus == entities.ConnectionStatus_CONNECTED {
 		status = nodebInfo.ConnectionStatus
 	}
-	err := h.rNibDataService.UpdateNodebInfo(nodebInfo)
+	err := h.rNibDataService.UpdateNodebInfo(ctx, nodebInfo)
 
 	if err != nil {
 		h.logger.Errorf("#SetupRequestHandler.connectExistingRanWithAssociatedE2TAddress - RAN name: %s - failed resetting connection attempts of RAN. error: %s", nodebInfo.RanName, err)
@@ -158,13 +159,13 @@ func (h *SetupRequestHandler) connectExistingRanWithAssociatedE2TAddress(nodebIn
 
 	h.logger.Infof("#SetupRequestHandler.connectExistingRanWithAssociatedE2TAddress - RAN name: %s - successfully reset connection attempts of RAN", nodebInfo.RanName)
 
-	result := h.ranSetupManager.ExecuteSetup(nodebInfo, status)
+	result := h.ranSetupManager.ExecuteSetup(ctx, nodebInfo, status)
 	return result
 }
 
-func (h *SetupRequestHandler) connectNewRan(request *models.SetupRequest, protocol entities.E2ApplicationProtocol) error {
+func (h *SetupRequestHandler) connectNewRan(ctx context.Context, request *models.SetupRequest, protocol entities.E2ApplicationProtocol) error {
 
-	e2tAddress, err := h.e2tInstancesManager.SelectE2TInstance()
+	e2tAddress, err := h.e2tInstancesManager.SelectE2TInstance(ctx)
 
 	if err != nil {
 		h.logger.Errorf("#SetupRequestHandler.connectNewRan - RAN name: %s - failed selecting E2T instance", request.RanName)
@@ -173,7 +174,7 @@ func (h *SetupRequestHandler) connectNewRan(request *models.SetupRequest, protoc
 
 	nodebInfo, nodebIdentity := createInitialNodeInfo(request, protocol)
 
-	err = h.rNibDataService.SaveNodeb(nodebIdentity, nodebInfo)
+	err = h.rNibDataService.SaveNodeb(ctx, nodebIdentity, nodebInfo)
 
 	if err != nil {
 		h.logger.Errorf("#SetupRequestHandler.connectNewRan - RAN name: %s - failed to save initial nodeb entity in RNIB. error: %s", request.RanName, err)
@@ -182,14 +183,14 @@ func (h *SetupRequestHandler) connectNewRan(request *models.SetupRequest, protoc
 
 	h.logger.Infof("#SetupRequestHandler.connectNewRan - RAN name: %s - initial nodeb entity was saved to rNib", request.RanName)
 
-	err = h.e2tAssociationManager.AssociateRan(e2tAddress, nodebInfo)
+	err = h.e2tAssociationManager.AssociateRan(ctx, e2tAddress, nodebInfo)
 
 	if err != nil {
 		h.logger.Errorf("#SetupRequestHandler.connectNewRan - RAN name: %s - failed associating ran to e2t address %s. error: %s", request.RanName, e2tAddress, err)
 		return err
 	}
 
-	result := h.ranSetupManager.ExecuteSetup(nodebInfo, entities.ConnectionStatus_CONNECTING)
+	result := h.ranSetupManager.ExecuteSetup(ctx, nodebInfo, entities.ConnectionStatus_CONNECTING)
 
 	return result
 }
@@ -21,6 +21,7 @@ package httpmsghandlers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
@@ -28,7 +29,7 @@ import (
 	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"encoding/json"
 	"fmt"
@@ -100,7 +101,7 @@ func TestX2SetupHandleNoPortError(t *testing.T) {
 	readerMock, _, handler, _, _, _ := initSetupRequestTest(t, entities.E2ApplicationProtocol_X2_SETUP_REQUEST)
 
 	sr := models.SetupRequest{"127.0.0.1", 0, RanName,}
-	_, err := handler.Handle(sr)
+	_, err := handler.Handle(context.Background(), sr)
 	assert.IsType(t, &e2managererrors.RequestValidationError{}, err)
 	readerMock.AssertNotCalled(t, "GetNodeb")
 }
@@ -109,7 +110,7 @@ func TestX2SetupHandleNoRanNameError(t *testing.T) {
 	readerMock, _, handler, _, _, _ := initSetupRequestTest(t, entities.E2ApplicationProtocol_X2_SETUP_REQUEST)
 
 	sr := models.SetupRequest{RanPort: 8080, RanIp: "127.0.0.1"}
-	_, err := handler.Handle(sr)
+	_, err := handler.Handle(context.Background(), sr)
 	assert.IsType(t, &e2managererrors.RequestValidationError{}, err)
 	readerMock.AssertNotCalled(t, "GetNodeb")
 }
@@ -118,7 +119,7 @@ func TestX2SetupHandleNoIpError(t *testing.T) {
 	readerMock, _, handler, _, _, _ := initSetupRequestTest(t, entities.E2ApplicationProtocol_X2_SETUP_REQUEST)
 
 	sr := models.SetupRequest{RanPort: 8080, RanName: RanName}
-	_, err := handler.Handle(sr)
+	_, err := handler.Handle(context.Background(), sr)
 	assert.IsType(t, &e2managererrors.RequestValidationError{}, err)
 	readerMock.AssertNotCalled(t, "GetNodeb")
 }
@@ -127,7 +128,7 @@ func TestX2SetupHandleInvalidIpError(t *testing.T) {
 	readerMock, _, handler, _, _, _:= initSetupRequestTest(t, entities.E2ApplicationProtocol_X2_SETUP_REQUEST)
 
 	sr := models.SetupRequest{RanPort: 8080, RanName: RanName, RanIp: "invalid ip"}
-	_, err := handler.Handle(sr)
+	_, err := handler.Handle(context.Background(), sr)
 	assert.IsType(t, &e2managererrors.RequestValidationError{}, err)
 	readerMock.AssertNotCalled(t, "GetNodeb")
 }
@@ -140,7 +141,7 @@ func TestSetupGetNodebFailure(t *testing.T) {
 	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
 
 	sr := models.SetupRequest{"127.0.0.1", 8080, RanName,}
-	_, err := handler.Handle(sr)
+	_, err := handler.Handle(context.Background(), sr)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 }
 
@@ -148,7 +149,7 @@ func TestSetupNewRanSelectE2TInstancesDbError(t *testing.T) {
 	readerMock, writerMock, handler, e2tInstancesManagerMock, ranSetupManagerMock, _ := initSetupRequestTest(t, entities.E2ApplicationProtocol_X2_SETUP_REQUEST)
 	readerMock.On("GetNodeb", RanName).Return(&entities.NodebInfo{}, common.NewResourceNotFoundError(""))
 	e2tInstancesManagerMock.On("SelectE2TInstance").Return("", e2managererrors.NewRnibDbError())
-	_, err := handler.Handle(models.SetupRequest{"127.0.0.1", 8080, RanName,})
+	_, err := handler.Handle(context.Background(), models.SetupRequest{"127.0.0.1", 8080, RanName,})
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	e2tInstancesManagerMock.AssertNotCalled(t, "AddRansToInstance")
 	writerMock.AssertNotCalled(t, "SaveNodeb")
@@ -168,7 +169,7 @@ func TestSetupNewRanSelectE2TInstancesNoInstances(t *testing.T) {
 	updatedNb.ConnectionStatus = entities.ConnectionStatus_CONNECTED
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(nil)
 	ranSetupManagerMock.On("ExecuteSetup", &updatedNb, entities.ConnectionStatus_CONNECTING).Return(nil)
-	_, err := handler.Handle(models.SetupRequest{"127.0.0.1", 8080, RanName,})
+	_, err := handler.Handle(context.Background(), models.SetupRequest{"127.0.0.1", 8080, RanName,})
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -191,7 +192,7 @@ func TestSetupNewRanAssociateRanFailure(t *testing.T) {
 	updatedNb := *nb
 	updatedNb.AssociatedE2TInstanceAddress = E2TAddress
 
-	_, err := handler.Handle(*setupRequest)
+	_, err := handler.Handle(context.Background(), *setupRequest)
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
@@ -209,7 +210,7 @@ func TestSetupNewRanSaveNodebFailure(t *testing.T) {
 	setupRequest := models.SetupRequest{"127.0.0.1", 8080, RanName,}
 	nodebInfo, nbIdentity := createInitialNodeInfo(&setupRequest, entities.E2ApplicationProtocol_X2_SETUP_REQUEST)
 	writerMock.On("SaveNodeb", nbIdentity, nodebInfo).Return(common.NewInternalError(fmt.Errorf("")))
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	ranSetupManagerMock.AssertNotCalled(t, "ExecuteSetup")
 }
@@ -226,7 +227,7 @@ func TestSetupNewRanSetupDbError(t *testing.T) {
 	updatedNb.AssociatedE2TInstanceAddress = E2TAddress
 	updatedNb.ConnectionStatus = entities.ConnectionStatus_CONNECTED
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(nil)
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
@@ -247,7 +248,7 @@ func TestSetupNewRanSetupRmrError(t *testing.T) {
 	updatedNb.ConnectionStatus = entities.ConnectionStatus_CONNECTED
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(nil)
 	ranSetupManagerMock.On("ExecuteSetup", &updatedNb, entities.ConnectionStatus_CONNECTING).Return(e2managererrors.NewRmrError())
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.IsType(t, &e2managererrors.RmrError{}, err)
 }
 
@@ -264,7 +265,7 @@ func TestSetupNewRanSetupSuccess(t *testing.T) {
 	updatedNb.ConnectionStatus = entities.ConnectionStatus_CONNECTED
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(nil)
 	ranSetupManagerMock.On("ExecuteSetup", &updatedNb, entities.ConnectionStatus_CONNECTING).Return(nil)
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.Nil(t, err)
 }
 
@@ -272,7 +273,7 @@ func TestX2SetupExistingRanShuttingDown(t *testing.T) {
 	readerMock, _, handler, e2tInstancesManagerMock, ranSetupManagerMock , _:= initSetupRequestTest(t, entities.E2ApplicationProtocol_X2_SETUP_REQUEST)
 	readerMock.On("GetNodeb", RanName).Return(&entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_SHUTTING_DOWN}, nil)
 	setupRequest := models.SetupRequest{"127.0.0.1", 8080, RanName,}
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.IsType(t, &e2managererrors.WrongStateError{}, err)
 	e2tInstancesManagerMock.AssertNotCalled(t, "SelectE2TInstance")
 	ranSetupManagerMock.AssertNotCalled(t, "ExecuteSetup")
@@ -282,7 +283,7 @@ func TestEndcSetupExistingRanShuttingDown(t *testing.T) {
 	readerMock, _, handler, e2tInstancesManagerMock, ranSetupManagerMock, _ := initSetupRequestTest(t, entities.E2ApplicationProtocol_ENDC_X2_SETUP_REQUEST)
 	readerMock.On("GetNodeb", RanName).Return(&entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_SHUTTING_DOWN}, nil)
 	setupRequest := models.SetupRequest{"127.0.0.1", 8080, RanName,}
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.IsType(t, &e2managererrors.WrongStateError{}, err)
 	e2tInstancesManagerMock.AssertNotCalled(t, "SelectE2TInstance")
 	ranSetupManagerMock.AssertNotCalled(t, "ExecuteSetup")
@@ -297,7 +298,7 @@ func TestSetupExistingRanWithoutAssocE2TInstanceSelectDbError(t *testing.T) {
 	updatedNb.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(nil)
 	setupRequest := models.SetupRequest{"127.0.0.1", 8080, RanName,}
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	ranSetupManagerMock.AssertNotCalled(t, "ExecuteSetup")
 }
@@ -312,7 +313,7 @@ func TestSetupExistingRanWithoutAssocE2TInstanceSelectNoInstanceError(t *testing
 	e2tInstancesManagerMock.On("SelectE2TInstance").Return(E2TAddress, nil)
 	e2tInstancesManagerMock.On("AddRansToInstance", "10.0.2.15:8989", []string{"test"}).Return(nil)
 	mockHttpClientAssociateRan(httpClientMock)
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.IsType(t, &e2managererrors.InternalError{}, err)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 	writerMock.AssertExpectations(t)
@@ -327,7 +328,7 @@ func TestSetupExistingRanWithoutAssocE2TInstanceSelectNoInstanceErrorUpdateFailu
 	updatedNb.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(common.NewInternalError(fmt.Errorf("")))
 	setupRequest := models.SetupRequest{"127.0.0.1", 8080, RanName,}
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.IsType(t, &e2managererrors.E2TInstanceAbsenceError{}, err)
 	ranSetupManagerMock.AssertNotCalled(t, "ExecuteSetup")
 }
@@ -338,7 +339,7 @@ func TestSetupExistingRanWithoutAssocE2TInstanceSelectErrorAlreadyDisconnected(t
 	readerMock.On("GetNodeb", RanName).Return(nb, nil)
 	e2tInstancesManagerMock.On("SelectE2TInstance").Return(E2TAddress, e2managererrors.NewE2TInstanceAbsenceError())
 	setupRequest := models.SetupRequest{"127.0.0.1", 8080, RanName,}
-	_, err := handler.Handle(setupRequest)
+	_, err := handler.Handle(context.Background(), setupRequest)
 	assert.IsType(t, &e2managererrors.E2TInstanceAbsenceError{}, err)
 	writerMock.AssertNotCalled(t, "UpdateNodebInfo")
 	ranSetupManagerMock.AssertNotCalled(t, "ExecuteSetup")
@@ -405,7 +406,7 @@ func TestSetupExistingRanWithAssocE2TInstanceUpdateNodebFailure(t *testing.T) {
 	readerMock.On("GetNodeb", RanName).Return(nb, nil)
 	updatedNb := *nb
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(common.NewInternalError(fmt.Errorf("")))
-	_, err := handler.Handle(models.SetupRequest{"127.0.0.1", 8080, RanName,})
+	_, err := handler.Handle(context.Background(), models.SetupRequest{"127.0.0.1", 8080, RanName,})
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	e2tInstancesManagerMock.AssertNotCalled(t, "SelectE2TInstance")
 	e2tInstancesManagerMock.AssertNotCalled(t, "AddRansToInstance")
@@ -422,10 +423,10 @@ func TestSetupExistingRanWithAssocE2TInstanceExecuteSetupRmrError(t *testing.T)
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(nil)
 	payload := e2pdus.PackedX2setupRequest
 	xaction := []byte(RanName)
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_SETUP_REQ, len(payload), RanName, &payload, &xaction, nil)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), RanName, &payload, &xaction, nil)
 	rmrMessengerMock.On("SendMsg",mock.Anything, true).Return(msg, e2managererrors.NewRmrError())
 	writerMock.On("UpdateNodebInfo", &updatedNb3).Return(nil)
-	_, err := handler.Handle(models.SetupRequest{"127.0.0.1", 8080, RanName,})
+	_, err := handler.Handle(context.Background(), models.SetupRequest{"127.0.0.1", 8080, RanName,})
 	assert.IsType(t, &e2managererrors.RmrError{}, err)
 	writerMock.AssertExpectations(t)
 }
@@ -437,7 +438,7 @@ func TestSetupExistingRanWithAssocE2TInstanceConnectedSuccess(t *testing.T) {
 	updatedNb := *nb
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(nil)
 	ranSetupManagerMock.On("ExecuteSetup", &updatedNb, entities.ConnectionStatus_CONNECTED).Return(nil)
-	_, err := handler.Handle(models.SetupRequest{"127.0.0.1", 8080, RanName,})
+	_, err := handler.Handle(context.Background(), models.SetupRequest{"127.0.0.1", 8080, RanName,})
 	assert.Nil(t, err)
 	e2tInstancesManagerMock.AssertNotCalled(t, "SelectE2TInstance")
 	e2tInstancesManagerMock.AssertNotCalled(t, "AddRansToInstance")
@@ -451,10 +452,10 @@ func TestSetupExistingRanWithoutAssocE2TInstanceExecuteRoutingManagerError(t *te
 	e2tInstancesManagerMock.On("SelectE2TInstance").Return(E2TAddress, nil)
 	mockHttpClientAssociateRan(httpClientMock)
 	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
-	msg := &rmrCgo.MBuf{}
+	msg := &rmrtypes.MBuf{}
 	var errNIl error
 	rmrMessengerMock.On("SendMsg",mock.Anything, true).Return(msg, errNIl)
-	_, err := handler.Handle(models.SetupRequest{"127.0.0.1", 8080, RanName,})
+	_, err := handler.Handle(context.Background(), models.SetupRequest{"127.0.0.1", 8080, RanName,})
 	assert.Nil(t, err)
 	writerMock.AssertExpectations(t)
 	readerMock.AssertExpectations(t)
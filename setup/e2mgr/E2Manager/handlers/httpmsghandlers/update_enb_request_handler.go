@@ -0,0 +1,199 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/pkg/errors"
+)
+
+const UPDATE_ENB_VALIDATION_FAILURE_MESSAGE = "#UpdateEnbRequestHandler.Handle - validation failure: %s is a mandatory field"
+
+type UpdateEnbRequestHandler struct {
+	logger          *logger.Logger
+	rNibDataService services.RNibDataService
+}
+
+func NewUpdateEnbRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService) *UpdateEnbRequestHandler {
+	return &UpdateEnbRequestHandler{
+		logger:          logger,
+		rNibDataService: rNibDataService,
+	}
+}
+
+func (h *UpdateEnbRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+
+	updateEnbRequest := request.(models.UpdateEnbRequest)
+
+	h.logger.Infof("#UpdateEnbRequestHandler.Handle - Ran name: %s", updateEnbRequest.RanName)
+
+	err := h.validateRequestBody(updateEnbRequest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nodebInfo, err := h.rNibDataService.GetNodeb(ctx, updateEnbRequest.RanName)
+
+	if err != nil {
+		_, ok := err.(*common.ResourceNotFoundError)
+		if !ok {
+			h.logger.Errorf("#UpdateEnbRequestHandler.Handle - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", updateEnbRequest.RanName, err)
+			return nil, e2managererrors.NewRnibDbError()
+		}
+
+		h.logger.Errorf("#UpdateEnbRequestHandler.Handle - RAN name: %s - RAN not found on RNIB. Error: %s", updateEnbRequest.RanName, err)
+		return nil, e2managererrors.NewResourceNotFoundError()
+	}
+
+	err = h.updateEnbCells(ctx, nodebInfo, updateEnbRequest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewUpdateEnbResponse(nodebInfo), nil
+}
+
+func (h *UpdateEnbRequestHandler) updateEnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, updateEnbRequest models.UpdateEnbRequest) error {
+
+	ranName := nodebInfo.RanName
+	enb := nodebInfo.GetEnb()
+
+	if enb == nil {
+		h.logger.Errorf("#UpdateEnbRequestHandler.updateEnbCells - RAN name: %s - nodeb missing enb configuration", ranName)
+		return e2managererrors.NewInternalError()
+	}
+
+	servedCellsToRemove := enb.ServedCells
+	enb.ServedCells = updateEnbRequest.ServedCells
+
+	// The new served cells are written before the keys of the previous ones which were not rewritten are removed, so a
+	// failure in between leaves stale keys rather than served cells rNib cannot be queried by
+	err := h.rNibDataService.PatchEnbCells(ctx, nodebInfo, updateEnbRequest.ServedCells, servedCellsToRemove)
+
+	if err != nil {
+		h.logger.Errorf("#UpdateEnbRequestHandler.updateEnbCells - RAN name: %s - Failed updating ENB cells. Error: %s", ranName, err)
+		return e2managererrors.NewRnibDbError()
+	}
+
+	// The served cells are updated, leftover load information of the removed ones does not fail the update
+	err = h.rNibDataService.RemoveCellLoadInformation(ctx, ranName, updateEnbRequest.ServedCells, servedCellsToRemove)
+
+	if err != nil {
+		h.logger.Errorf("#UpdateEnbRequestHandler.updateEnbCells - RAN name: %s - Failed removing load information of removed cells. Error: %s", ranName, err)
+	}
+
+	h.logger.Infof("#UpdateEnbRequestHandler.updateEnbCells - RAN name: %s - Successfully updated ENB cells", ranName)
+	return nil
+}
+
+func (h *UpdateEnbRequestHandler) validateRequestBody(updateEnbRequest models.UpdateEnbRequest) error {
+
+	if updateEnbRequest.Enb == nil || len(updateEnbRequest.ServedCells) == 0 {
+		h.logger.Errorf(UPDATE_ENB_VALIDATION_FAILURE_MESSAGE+" and cannot be empty", "servedCells")
+		return e2managererrors.NewRequestValidationError()
+	}
+
+	for _, servedCell := range updateEnbRequest.ServedCells {
+		err := isServedCellInfoValid(servedCell)
+
+		if err != nil {
+			h.logger.Errorf(UPDATE_ENB_VALIDATION_FAILURE_MESSAGE, err)
+			return e2managererrors.NewRequestValidationError()
+		}
+
+		for _, neighbourInformation := range servedCell.NeighbourInfos {
+
+			err := isNeighbourInformationValid(neighbourInformation)
+
+			if err != nil {
+				h.logger.Errorf(UPDATE_ENB_VALIDATION_FAILURE_MESSAGE, err)
+				return e2managererrors.NewRequestValidationError()
+			}
+		}
+	}
+
+	return nil
+}
+
+// Unlike the NR PCI of a served NR cell, a PCI of 0 is a legal value (and indistinguishable from a missing one), hence
+// the PCI is not validated
+func isServedCellInfoValid(servedCell *entities.ServedCellInfo) error {
+	if servedCell == nil {
+		return errors.New("servedCell")
+	}
+
+	if servedCell.CellId == "" {
+		return errors.New("cellId")
+	}
+
+	if servedCell.ChoiceEutraMode == nil {
+		return errors.New("choiceEutraMode")
+	}
+
+	if servedCell.EutraMode == entities.Eutra_UNKNOWN {
+		return errors.New("eutraMode")
+	}
+
+	if servedCell.Tac == "" {
+		return errors.New("tac")
+	}
+
+	if len(servedCell.BroadcastPlmns) == 0 {
+		return errors.New("broadcastPlmns")
+	}
+
+	return isServedCellInfoChoiceEutraModeValid(servedCell.ChoiceEutraMode)
+}
+
+func isServedCellInfoChoiceEutraModeValid(choiceEutraMode *entities.ChoiceEUTRAMode) error {
+	if choiceEutraMode.Fdd != nil {
+		return isServedCellInfoFddValid(choiceEutraMode.Fdd)
+	}
+
+	if choiceEutraMode.Tdd != nil {
+		return isServedCellInfoTddValid(choiceEutraMode.Tdd)
+	}
+
+	return errors.New("served cell fdd / tdd")
+}
+
+func isServedCellInfoTddValid(tdd *entities.TddInfo) error {
+	return nil
+}
+
+func isServedCellInfoFddValid(fdd *entities.FddInfo) error {
+	return nil
+}
+
+func isNeighbourInformationValid(neighbourInformation *entities.NeighbourInformation) error {
+	if neighbourInformation == nil || neighbourInformation.Ecgi == "" {
+		return errors.New("ecgi")
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+const updateEnbRanName = "test"
+
+func setupUpdateEnbRequestHandlerTest(t *testing.T) (*UpdateEnbRequestHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
+	log := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	handler := NewUpdateEnbRequestHandler(log, rnibDataService)
+	return handler, readerMock, writerMock
+}
+
+func updateEnbServedCell(cellId string, pci uint32) *entities.ServedCellInfo {
+	return &entities.ServedCellInfo{
+		CellId:          cellId,
+		Pci:             pci,
+		Tac:             "0001",
+		BroadcastPlmns:  []string{"whatever"},
+		ChoiceEutraMode: &entities.ChoiceEUTRAMode{Fdd: &entities.FddInfo{}},
+		EutraMode:       entities.Eutra_FDD,
+	}
+}
+
+func updateEnbNodebInfo(servedCells ...*entities.ServedCellInfo) *entities.NodebInfo {
+	return &entities.NodebInfo{
+		RanName:          updateEnbRanName,
+		NodeType:         entities.Node_ENB,
+		ConnectionStatus: entities.ConnectionStatus_CONNECTED,
+		GlobalNbId:       &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0a"},
+		Configuration:    &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: servedCells}},
+	}
+}
+
+func updateEnbRequest(servedCells ...*entities.ServedCellInfo) models.UpdateEnbRequest {
+	return models.UpdateEnbRequest{RanName: updateEnbRanName, Enb: &entities.Enb{ServedCells: servedCells}}
+}
+
+func TestHandleUpdateEnbSuccess(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateEnbRequestHandlerTest(t)
+	oldServedCells := []*entities.ServedCellInfo{updateEnbServedCell("cell1", 1), updateEnbServedCell("cell2", 2)}
+	readerMock.On("GetNodeb", updateEnbRanName).Return(updateEnbNodebInfo(oldServedCells...), nil)
+	servedCells := []*entities.ServedCellInfo{updateEnbServedCell("cell1", 5), updateEnbServedCell("cell3", 3)}
+	writerMock.On("PatchEnbCells", updateEnbNodebInfo(servedCells...), servedCells, oldServedCells).Return(nil)
+	writerMock.On("RemoveCellLoadInformation", updateEnbRanName, servedCells, oldServedCells).Return(nil)
+
+	response, err := handler.Handle(context.Background(), updateEnbRequest(servedCells...))
+
+	assert.Nil(t, err)
+	assert.Equal(t, models.NewUpdateEnbResponse(updateEnbNodebInfo(servedCells...)), response)
+	writerMock.AssertExpectations(t)
+}
+
+func TestHandleUpdateEnbNoServedCellsSuccess(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateEnbRequestHandlerTest(t)
+	readerMock.On("GetNodeb", updateEnbRanName).Return(updateEnbNodebInfo(), nil)
+	servedCells := []*entities.ServedCellInfo{updateEnbServedCell("cell1", 1)}
+	writerMock.On("PatchEnbCells", updateEnbNodebInfo(servedCells...), servedCells, []*entities.ServedCellInfo(nil)).Return(nil)
+	writerMock.On("RemoveCellLoadInformation", updateEnbRanName, servedCells, []*entities.ServedCellInfo(nil)).Return(nil)
+
+	_, err := handler.Handle(context.Background(), updateEnbRequest(servedCells...))
+
+	assert.Nil(t, err)
+	writerMock.AssertExpectations(t)
+}
+
+func TestHandleUpdateEnbInvalidRequest(t *testing.T) {
+	noCellId := updateEnbServedCell("", 1)
+	noChoiceEutraMode := updateEnbServedCell("cell1", 1)
+	noChoiceEutraMode.ChoiceEutraMode = nil
+	noFddOrTdd := updateEnbServedCell("cell1", 1)
+	noFddOrTdd.ChoiceEutraMode = &entities.ChoiceEUTRAMode{}
+	unknownEutraMode := updateEnbServedCell("cell1", 1)
+	unknownEutraMode.EutraMode = entities.Eutra_UNKNOWN
+	noTac := updateEnbServedCell("cell1", 1)
+	noTac.Tac = ""
+	noBroadcastPlmns := updateEnbServedCell("cell1", 1)
+	noBroadcastPlmns.BroadcastPlmns = nil
+	invalidNeighbour := updateEnbServedCell("cell1", 1)
+	invalidNeighbour.NeighbourInfos = []*entities.NeighbourInformation{{Pci: 1}}
+
+	requests := map[string]models.UpdateEnbRequest{
+		"no enb":                 {RanName: updateEnbRanName},
+		"empty":                  updateEnbRequest(),
+		"missing cell":           updateEnbRequest(nil),
+		"no cell id":             updateEnbRequest(noCellId),
+		"no choiceEutraMode":     updateEnbRequest(noChoiceEutraMode),
+		"no fdd or tdd":          updateEnbRequest(noFddOrTdd),
+		"unknown eutra mode":     updateEnbRequest(unknownEutraMode),
+		"no tac":                 updateEnbRequest(noTac),
+		"no broadcast plmns":     updateEnbRequest(noBroadcastPlmns),
+		"neighbour with no ecgi": updateEnbRequest(invalidNeighbour),
+	}
+
+	for name, request := range requests {
+		t.Run(name, func(t *testing.T) {
+			handler, readerMock, _ := setupUpdateEnbRequestHandlerTest(t)
+
+			response, err := handler.Handle(context.Background(), request)
+
+			assert.Nil(t, response)
+			assert.IsType(t, &e2managererrors.RequestValidationError{}, err)
+			readerMock.AssertNotCalled(t, "GetNodeb", updateEnbRanName)
+		})
+	}
+}
+
+func TestHandleUpdateEnbRanNotFound(t *testing.T) {
+	handler, readerMock, _ := setupUpdateEnbRequestHandlerTest(t)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", updateEnbRanName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+
+	response, err := handler.Handle(context.Background(), updateEnbRequest(updateEnbServedCell("cell1", 1)))
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
+
+func TestHandleUpdateEnbGetNodebFailure(t *testing.T) {
+	handler, readerMock, _ := setupUpdateEnbRequestHandlerTest(t)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", updateEnbRanName).Return(nodebInfo, common.NewInternalError(errors.New("#reader.GetNodeb - Internal Error")))
+
+	response, err := handler.Handle(context.Background(), updateEnbRequest(updateEnbServedCell("cell1", 1)))
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
+}
+
+func TestHandleUpdateEnbNotEnb(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateEnbRequestHandlerTest(t)
+	readerMock.On("GetNodeb", updateEnbRanName).Return(&entities.NodebInfo{RanName: updateEnbRanName, Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{}}}, nil)
+
+	response, err := handler.Handle(context.Background(), updateEnbRequest(updateEnbServedCell("cell1", 1)))
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.InternalError{}, err)
+	writerMock.AssertNotCalled(t, "PatchEnbCells", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleUpdateEnbPatchFailure(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateEnbRequestHandlerTest(t)
+	oldServedCells := []*entities.ServedCellInfo{updateEnbServedCell("cell1", 1)}
+	readerMock.On("GetNodeb", updateEnbRanName).Return(updateEnbNodebInfo(oldServedCells...), nil)
+	servedCells := []*entities.ServedCellInfo{updateEnbServedCell("cell2", 2)}
+	writerMock.On("PatchEnbCells", updateEnbNodebInfo(servedCells...), servedCells, oldServedCells).Return(common.NewInternalError(errors.New("#writer.PatchEnbCells - Internal Error")))
+
+	response, err := handler.Handle(context.Background(), updateEnbRequest(servedCells...))
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
+	writerMock.AssertExpectations(t)
+	writerMock.AssertNotCalled(t, "RemoveCellLoadInformation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleUpdateEnbRemoveCellLoadInformationFailure(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateEnbRequestHandlerTest(t)
+	oldServedCells := []*entities.ServedCellInfo{updateEnbServedCell("cell1", 1)}
+	readerMock.On("GetNodeb", updateEnbRanName).Return(updateEnbNodebInfo(oldServedCells...), nil)
+	servedCells := []*entities.ServedCellInfo{updateEnbServedCell("cell2", 2)}
+	writerMock.On("PatchEnbCells", updateEnbNodebInfo(servedCells...), servedCells, oldServedCells).Return(nil)
+	writerMock.On("RemoveCellLoadInformation", updateEnbRanName, servedCells, oldServedCells).Return(common.NewInternalError(errors.New("#writer.RemoveCellLoadInformation - Internal Error")))
+
+	response, err := handler.Handle(context.Background(), updateEnbRequest(servedCells...))
+
+	assert.Nil(t, err)
+	assert.Equal(t, models.NewUpdateEnbResponse(updateEnbNodebInfo(servedCells...)), response)
+	writerMock.AssertExpectations(t)
+}
@@ -0,0 +1,281 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/golang/protobuf/proto"
+)
+
+const UPDATE_GNB_CELLS_VALIDATION_FAILURE_MESSAGE = "#UpdateGnbCellsRequestHandler.Handle - validation failure: %s is a mandatory field"
+
+type UpdateGnbCellsRequestHandler struct {
+	logger          *logger.Logger
+	rNibDataService services.RNibDataService
+}
+
+func NewUpdateGnbCellsRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService) *UpdateGnbCellsRequestHandler {
+	return &UpdateGnbCellsRequestHandler{
+		logger:          logger,
+		rNibDataService: rNibDataService,
+	}
+}
+
+func (h *UpdateGnbCellsRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+
+	updateGnbCellsRequest := request.(models.UpdateGnbCellsRequest)
+
+	h.logger.Infof("#UpdateGnbCellsRequestHandler.Handle - Ran name: %s, dry run: %t", updateGnbCellsRequest.RanName, updateGnbCellsRequest.DryRun)
+
+	err := h.validateRequestBody(updateGnbCellsRequest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nodebInfo, err := h.rNibDataService.GetNodeb(ctx, updateGnbCellsRequest.RanName)
+
+	if err != nil {
+		_, ok := err.(*common.ResourceNotFoundError)
+		if !ok {
+			h.logger.Errorf("#UpdateGnbCellsRequestHandler.Handle - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", updateGnbCellsRequest.RanName, err)
+			return nil, e2managererrors.NewRnibDbError()
+		}
+
+		h.logger.Errorf("#UpdateGnbCellsRequestHandler.Handle - RAN name: %s - RAN not found on RNIB. Error: %s", updateGnbCellsRequest.RanName, err)
+		return nil, e2managererrors.NewResourceNotFoundError()
+	}
+
+	gnb := nodebInfo.GetGnb()
+
+	if gnb == nil {
+		h.logger.Errorf("#UpdateGnbCellsRequestHandler.Handle - RAN name: %s - nodeb missing gnb configuration", nodebInfo.RanName)
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	servedNrCells, diff, err := h.buildGnbCellsDiff(nodebInfo.RanName, gnb.ServedNrCells, updateGnbCellsRequest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.NewUpdateGnbCellsResponse(nodebInfo.RanName, updateGnbCellsRequest.DryRun, diff)
+
+	if updateGnbCellsRequest.DryRun || diff.IsEmpty() {
+		h.logger.Infof("#UpdateGnbCellsRequestHandler.Handle - RAN name: %s - %d cells to add, %d to modify, %d to delete - not updating RNIB", nodebInfo.RanName, len(diff.Added), len(diff.Modified), len(diff.Deleted))
+		return response, nil
+	}
+
+	gnb.ServedNrCells = servedNrCells
+
+	err = h.rNibDataService.PatchGnbCells(ctx, nodebInfo, diff.ServedNrCellsToSet(), diff.ServedNrCellsToRemove())
+
+	if err != nil {
+		h.logger.Errorf("#UpdateGnbCellsRequestHandler.Handle - RAN name: %s - Failed updating GNB cells. Error: %s", nodebInfo.RanName, err)
+		return nil, e2managererrors.NewRnibDbError()
+	}
+
+	h.logger.Infof("#UpdateGnbCellsRequestHandler.Handle - RAN name: %s - Successfully added %d, modified %d and deleted %d GNB cells", nodebInfo.RanName, len(diff.Added), len(diff.Modified), len(diff.Deleted))
+	return response, nil
+}
+
+// buildGnbCellsDiff applies the request to the gNB's served NR cells, keeping their order, and returns the resulting cells
+// along with the diff. Modifying a cell into its current value is not part of the diff.
+func (h *UpdateGnbCellsRequestHandler) buildGnbCellsDiff(ranName string, servedNrCells []*entities.ServedNRCell, updateGnbCellsRequest models.UpdateGnbCellsRequest) ([]*entities.ServedNRCell, *models.GnbCellsDiff, error) {
+
+	diff := &models.GnbCellsDiff{}
+	cellIndexes := make(map[string]int, len(servedNrCells))
+	result := make([]*entities.ServedNRCell, len(servedNrCells))
+
+	for i, servedNrCell := range servedNrCells {
+		cellIndexes[servedNrCellId(servedNrCell)] = i
+		result[i] = servedNrCell
+	}
+
+	for _, cellId := range updateGnbCellsRequest.ServedNrCellsToDelete {
+		i, ok := cellIndexes[cellId]
+
+		if !ok {
+			h.logger.Errorf("#UpdateGnbCellsRequestHandler.buildGnbCellsDiff - RAN name: %s - cell %s to delete is not served by the RAN", ranName, cellId)
+			return nil, nil, e2managererrors.NewRequestValidationError()
+		}
+
+		diff.Deleted = append(diff.Deleted, servedNrCells[i])
+		result[i] = nil
+	}
+
+	for _, servedNrCell := range updateGnbCellsRequest.ServedNrCellsToModify {
+		cellId := servedNrCellId(servedNrCell)
+		i, ok := cellIndexes[cellId]
+
+		if !ok {
+			h.logger.Errorf("#UpdateGnbCellsRequestHandler.buildGnbCellsDiff - RAN name: %s - cell %s to modify is not served by the RAN", ranName, cellId)
+			return nil, nil, e2managererrors.NewRequestValidationError()
+		}
+
+		if proto.Equal(servedNrCells[i], servedNrCell) {
+			continue
+		}
+
+		diff.Modified = append(diff.Modified, &models.ServedNrCellChange{Old: servedNrCells[i], New: servedNrCell})
+		result[i] = servedNrCell
+	}
+
+	for _, servedNrCell := range updateGnbCellsRequest.ServedNrCellsToAdd {
+		cellId := servedNrCellId(servedNrCell)
+
+		if _, ok := cellIndexes[cellId]; ok {
+			h.logger.Errorf("#UpdateGnbCellsRequestHandler.buildGnbCellsDiff - RAN name: %s - cell %s to add is already served by the RAN", ranName, cellId)
+			return nil, nil, e2managererrors.NewRequestValidationError()
+		}
+
+		diff.Added = append(diff.Added, servedNrCell)
+		result = append(result, servedNrCell)
+	}
+
+	servedNrCells = make([]*entities.ServedNRCell, 0, len(result))
+
+	for _, servedNrCell := range result {
+		if servedNrCell != nil {
+			servedNrCells = append(servedNrCells, servedNrCell)
+		}
+	}
+
+	err := h.validateNrPcis(ranName, servedNrCells, diff.ServedNrCellsToSet())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return servedNrCells, diff, nil
+}
+
+// validateNrPcis makes sure a written cell doesn't take the NR PCI of another cell, as the PCI key of a RAN refers to a single cell
+func (h *UpdateGnbCellsRequestHandler) validateNrPcis(ranName string, servedNrCells []*entities.ServedNRCell, servedNrCellsToSet []*entities.ServedNRCell) error {
+
+	cellIdsToSet := make(map[string]bool, len(servedNrCellsToSet))
+
+	for _, servedNrCell := range servedNrCellsToSet {
+		cellIdsToSet[servedNrCellId(servedNrCell)] = true
+	}
+
+	cellIdsByPci := make(map[uint32]string, len(servedNrCells))
+
+	for _, servedNrCell := range servedNrCells {
+		if !cellIdsToSet[servedNrCellId(servedNrCell)] {
+			cellIdsByPci[servedNrCell.GetServedNrCellInformation().GetNrPci()] = servedNrCellId(servedNrCell)
+		}
+	}
+
+	for _, servedNrCell := range servedNrCellsToSet {
+		pci := servedNrCell.GetServedNrCellInformation().GetNrPci()
+		cellId := servedNrCellId(servedNrCell)
+
+		if otherCellId, ok := cellIdsByPci[pci]; ok {
+			h.logger.Errorf("#UpdateGnbCellsRequestHandler.validateNrPcis - RAN name: %s - cell %s has the NR PCI %d of cell %s", ranName, cellId, pci, otherCellId)
+			return e2managererrors.NewRequestValidationError()
+		}
+
+		cellIdsByPci[pci] = cellId
+	}
+
+	return nil
+}
+
+func (h *UpdateGnbCellsRequestHandler) validateRequestBody(updateGnbCellsRequest models.UpdateGnbCellsRequest) error {
+
+	if len(updateGnbCellsRequest.ServedNrCellsToAdd) == 0 && len(updateGnbCellsRequest.ServedNrCellsToModify) == 0 && len(updateGnbCellsRequest.ServedNrCellsToDelete) == 0 {
+		h.logger.Errorf(UPDATE_GNB_CELLS_VALIDATION_FAILURE_MESSAGE+" and cannot be empty", "servedNrCellsToAdd / servedNrCellsToModify / servedNrCellsToDelete")
+		return e2managererrors.NewRequestValidationError()
+	}
+
+	cellIds := make(map[string]bool)
+	servedNrCells := append([]*entities.ServedNRCell{}, updateGnbCellsRequest.ServedNrCellsToAdd...)
+	servedNrCells = append(servedNrCells, updateGnbCellsRequest.ServedNrCellsToModify...)
+
+	for _, servedNrCell := range servedNrCells {
+		err := h.validateServedNrCell(servedNrCell)
+
+		if err != nil {
+			return err
+		}
+
+		cellId := servedNrCellId(servedNrCell)
+
+		if cellIds[cellId] {
+			h.logger.Errorf("#UpdateGnbCellsRequestHandler.Handle - validation failure: cell %s appears more than once", cellId)
+			return e2managererrors.NewRequestValidationError()
+		}
+
+		cellIds[cellId] = true
+	}
+
+	for _, cellId := range updateGnbCellsRequest.ServedNrCellsToDelete {
+		if cellId == "" {
+			h.logger.Errorf(UPDATE_GNB_CELLS_VALIDATION_FAILURE_MESSAGE+" and cannot be empty", "servedNrCellsToDelete cell id")
+			return e2managererrors.NewRequestValidationError()
+		}
+
+		if cellIds[cellId] {
+			h.logger.Errorf("#UpdateGnbCellsRequestHandler.Handle - validation failure: cell %s appears more than once", cellId)
+			return e2managererrors.NewRequestValidationError()
+		}
+
+		cellIds[cellId] = true
+	}
+
+	return nil
+}
+
+func (h *UpdateGnbCellsRequestHandler) validateServedNrCell(servedNrCell *entities.ServedNRCell) error {
+
+	if servedNrCell.GetServedNrCellInformation() == nil {
+		h.logger.Errorf(UPDATE_GNB_CELLS_VALIDATION_FAILURE_MESSAGE+" and cannot be empty", "servedNrCellInformation")
+		return e2managererrors.NewRequestValidationError()
+	}
+
+	err := isServedNrCellInformationValid(servedNrCell.ServedNrCellInformation)
+
+	if err != nil {
+		h.logger.Errorf(UPDATE_GNB_CELLS_VALIDATION_FAILURE_MESSAGE, err)
+		return e2managererrors.NewRequestValidationError()
+	}
+
+	for _, nrNeighbourInformation := range servedNrCell.NrNeighbourInfos {
+		err := isNrNeighbourInformationValid(nrNeighbourInformation)
+
+		if err != nil {
+			h.logger.Errorf(UPDATE_GNB_CELLS_VALIDATION_FAILURE_MESSAGE, err)
+			return e2managererrors.NewRequestValidationError()
+		}
+	}
+
+	return nil
+}
+
+func servedNrCellId(servedNrCell *entities.ServedNRCell) string {
+	return servedNrCell.GetServedNrCellInformation().GetCellId()
+}
@@ -0,0 +1,284 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/services"
+	"encoding/json"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+const updateGnbCellsRanName = "test"
+
+type updateGnbCellsDiffCellIds struct {
+	DryRun   bool
+	Added    []string
+	Modified []string
+	Deleted  []string
+}
+
+func setupUpdateGnbCellsRequestHandlerTest(t *testing.T) (*UpdateGnbCellsRequestHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
+	log := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	handler := NewUpdateGnbCellsRequestHandler(log, rnibDataService)
+	return handler, readerMock, writerMock
+}
+
+func updateGnbCellsServedNrCell(cellId string, pci uint32) *entities.ServedNRCell {
+	return &entities.ServedNRCell{
+		ServedNrCellInformation: &entities.ServedNRCellInformation{
+			CellId: cellId,
+			ChoiceNrMode: &entities.ServedNRCellInformation_ChoiceNRMode{
+				Fdd: &entities.ServedNRCellInformation_ChoiceNRMode_FddInfo{},
+			},
+			NrMode:      entities.Nr_FDD,
+			NrPci:       pci,
+			ServedPlmns: []string{"whatever"},
+		},
+	}
+}
+
+func updateGnbCellsNodebInfo(servedNrCells ...*entities.ServedNRCell) *entities.NodebInfo {
+	return &entities.NodebInfo{
+		RanName:          updateGnbCellsRanName,
+		NodeType:         entities.Node_GNB,
+		ConnectionStatus: entities.ConnectionStatus_CONNECTED,
+		GlobalNbId:       &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0a"},
+		Configuration:    &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{ServedNrCells: servedNrCells}},
+	}
+}
+
+// getDiffCellIds reads the cell ids of the diff from the response, leaving the rendering of the cells to jsonpb
+func getDiffCellIds(t *testing.T, response models.IResponse) *updateGnbCellsDiffCellIds {
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+
+	type servedNrCell struct {
+		ServedNrCellInformation struct {
+			CellId string `json:"cellId"`
+		} `json:"servedNrCellInformation"`
+	}
+
+	result := struct {
+		RanName  string          `json:"ranName"`
+		DryRun   bool            `json:"dryRun"`
+		Added    []*servedNrCell `json:"added"`
+		Modified []*struct {
+			CellId string        `json:"cellId"`
+			Old    *servedNrCell `json:"old"`
+			New    *servedNrCell `json:"new"`
+		} `json:"modified"`
+		Deleted []*servedNrCell `json:"deleted"`
+	}{}
+
+	err = json.Unmarshal(data, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, updateGnbCellsRanName, result.RanName)
+
+	cellIds := &updateGnbCellsDiffCellIds{DryRun: result.DryRun, Added: []string{}, Modified: []string{}, Deleted: []string{}}
+
+	for _, cell := range result.Added {
+		cellIds.Added = append(cellIds.Added, cell.ServedNrCellInformation.CellId)
+	}
+
+	for _, change := range result.Modified {
+		assert.Equal(t, change.CellId, change.Old.ServedNrCellInformation.CellId)
+		assert.Equal(t, change.CellId, change.New.ServedNrCellInformation.CellId)
+		cellIds.Modified = append(cellIds.Modified, change.CellId)
+	}
+
+	for _, cell := range result.Deleted {
+		cellIds.Deleted = append(cellIds.Deleted, cell.ServedNrCellInformation.CellId)
+	}
+
+	return cellIds
+}
+
+func TestHandleUpdateGnbCellsSuccess(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateGnbCellsRequestHandlerTest(t)
+	cell1, cell2, cell3 := updateGnbCellsServedNrCell("cell1", 1), updateGnbCellsServedNrCell("cell2", 2), updateGnbCellsServedNrCell("cell3", 3)
+	readerMock.On("GetNodeb", updateGnbCellsRanName).Return(updateGnbCellsNodebInfo(cell1, cell2, cell3), nil)
+	modifiedCell1 := updateGnbCellsServedNrCell("cell1", 5)
+	addedCell := updateGnbCellsServedNrCell("cell4", 3)
+	expectedNodebInfo := updateGnbCellsNodebInfo(modifiedCell1, cell2, addedCell)
+	writerMock.On("PatchGnbCells", expectedNodebInfo, []*entities.ServedNRCell{addedCell, modifiedCell1}, []*entities.ServedNRCell{cell3, cell1}).Return(nil)
+
+	response, err := handler.Handle(context.Background(), models.UpdateGnbCellsRequest{
+		RanName:               updateGnbCellsRanName,
+		ServedNrCellsToAdd:    []*entities.ServedNRCell{addedCell},
+		ServedNrCellsToModify: []*entities.ServedNRCell{modifiedCell1, proto.Clone(cell2).(*entities.ServedNRCell)},
+		ServedNrCellsToDelete: []string{"cell3"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &updateGnbCellsDiffCellIds{Added: []string{"cell4"}, Modified: []string{"cell1"}, Deleted: []string{"cell3"}}, getDiffCellIds(t, response))
+	writerMock.AssertExpectations(t)
+}
+
+func TestHandleUpdateGnbCellsDryRun(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateGnbCellsRequestHandlerTest(t)
+	cell1, cell2 := updateGnbCellsServedNrCell("cell1", 1), updateGnbCellsServedNrCell("cell2", 2)
+	readerMock.On("GetNodeb", updateGnbCellsRanName).Return(updateGnbCellsNodebInfo(cell1, cell2), nil)
+
+	response, err := handler.Handle(context.Background(), models.UpdateGnbCellsRequest{
+		RanName:               updateGnbCellsRanName,
+		DryRun:                true,
+		ServedNrCellsToAdd:    []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell3", 3)},
+		ServedNrCellsToModify: []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell2", 4)},
+		ServedNrCellsToDelete: []string{"cell1"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &updateGnbCellsDiffCellIds{DryRun: true, Added: []string{"cell3"}, Modified: []string{"cell2"}, Deleted: []string{"cell1"}}, getDiffCellIds(t, response))
+	writerMock.AssertNotCalled(t, "PatchGnbCells", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleUpdateGnbCellsNoChange(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateGnbCellsRequestHandlerTest(t)
+	cell1 := updateGnbCellsServedNrCell("cell1", 1)
+	readerMock.On("GetNodeb", updateGnbCellsRanName).Return(updateGnbCellsNodebInfo(cell1), nil)
+
+	response, err := handler.Handle(context.Background(), models.UpdateGnbCellsRequest{
+		RanName:               updateGnbCellsRanName,
+		ServedNrCellsToModify: []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell1", 1)},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &updateGnbCellsDiffCellIds{Added: []string{}, Modified: []string{}, Deleted: []string{}}, getDiffCellIds(t, response))
+	writerMock.AssertNotCalled(t, "PatchGnbCells", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleUpdateGnbCellsInvalidCells(t *testing.T) {
+	cell1, cell2 := updateGnbCellsServedNrCell("cell1", 1), updateGnbCellsServedNrCell("cell2", 2)
+
+	requests := map[string]models.UpdateGnbCellsRequest{
+		"delete unknown cell": {ServedNrCellsToDelete: []string{"cell3"}},
+		"modify unknown cell": {ServedNrCellsToModify: []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell3", 3)}},
+		"add existing cell":   {ServedNrCellsToAdd: []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell1", 3)}},
+		"add existing pci":    {ServedNrCellsToAdd: []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell3", 2)}},
+		"modify existing pci": {ServedNrCellsToModify: []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell1", 2)}},
+	}
+
+	for name, request := range requests {
+		t.Run(name, func(t *testing.T) {
+			handler, readerMock, writerMock := setupUpdateGnbCellsRequestHandlerTest(t)
+			readerMock.On("GetNodeb", updateGnbCellsRanName).Return(updateGnbCellsNodebInfo(cell1, cell2), nil)
+			request.RanName = updateGnbCellsRanName
+
+			response, err := handler.Handle(context.Background(), request)
+
+			assert.Nil(t, response)
+			assert.IsType(t, &e2managererrors.RequestValidationError{}, err)
+			writerMock.AssertNotCalled(t, "PatchGnbCells", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandleUpdateGnbCellsInvalidRequest(t *testing.T) {
+	invalidCell := updateGnbCellsServedNrCell("cell3", 3)
+	invalidCell.ServedNrCellInformation.ServedPlmns = nil
+	invalidNeighbourCell := updateGnbCellsServedNrCell("cell3", 3)
+	invalidNeighbourCell.NrNeighbourInfos = []*entities.NrNeighbourInformation{{NrPci: 1}}
+
+	requests := map[string]models.UpdateGnbCellsRequest{
+		"empty":                {},
+		"missing information":  {ServedNrCellsToAdd: []*entities.ServedNRCell{{}}},
+		"invalid cell":         {ServedNrCellsToModify: []*entities.ServedNRCell{invalidCell}},
+		"invalid neighbour":    {ServedNrCellsToAdd: []*entities.ServedNRCell{invalidNeighbourCell}},
+		"empty cell to delete": {ServedNrCellsToDelete: []string{""}},
+		"cell added twice":     {ServedNrCellsToAdd: []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell3", 3), updateGnbCellsServedNrCell("cell3", 4)}},
+		"cell modified and deleted": {
+			ServedNrCellsToModify: []*entities.ServedNRCell{updateGnbCellsServedNrCell("cell1", 3)},
+			ServedNrCellsToDelete: []string{"cell1"},
+		},
+	}
+
+	for name, request := range requests {
+		t.Run(name, func(t *testing.T) {
+			handler, readerMock, _ := setupUpdateGnbCellsRequestHandlerTest(t)
+			request.RanName = updateGnbCellsRanName
+
+			response, err := handler.Handle(context.Background(), request)
+
+			assert.Nil(t, response)
+			assert.IsType(t, &e2managererrors.RequestValidationError{}, err)
+			readerMock.AssertNotCalled(t, "GetNodeb", updateGnbCellsRanName)
+		})
+	}
+}
+
+func TestHandleUpdateGnbCellsRanNotFound(t *testing.T) {
+	handler, readerMock, _ := setupUpdateGnbCellsRequestHandlerTest(t)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", updateGnbCellsRanName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+
+	response, err := handler.Handle(context.Background(), models.UpdateGnbCellsRequest{RanName: updateGnbCellsRanName, ServedNrCellsToDelete: []string{"cell1"}})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
+
+func TestHandleUpdateGnbCellsGetNodebFailure(t *testing.T) {
+	handler, readerMock, _ := setupUpdateGnbCellsRequestHandlerTest(t)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", updateGnbCellsRanName).Return(nodebInfo, common.NewInternalError(errors.New("#reader.GetNodeb - Internal Error")))
+
+	response, err := handler.Handle(context.Background(), models.UpdateGnbCellsRequest{RanName: updateGnbCellsRanName, ServedNrCellsToDelete: []string{"cell1"}})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
+}
+
+func TestHandleUpdateGnbCellsNotGnb(t *testing.T) {
+	handler, readerMock, _ := setupUpdateGnbCellsRequestHandlerTest(t)
+	readerMock.On("GetNodeb", updateGnbCellsRanName).Return(&entities.NodebInfo{RanName: updateGnbCellsRanName, Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{}}}, nil)
+
+	response, err := handler.Handle(context.Background(), models.UpdateGnbCellsRequest{RanName: updateGnbCellsRanName, ServedNrCellsToDelete: []string{"cell1"}})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.InternalError{}, err)
+}
+
+func TestHandleUpdateGnbCellsPatchFailure(t *testing.T) {
+	handler, readerMock, writerMock := setupUpdateGnbCellsRequestHandlerTest(t)
+	cell1, cell2 := updateGnbCellsServedNrCell("cell1", 1), updateGnbCellsServedNrCell("cell2", 2)
+	readerMock.On("GetNodeb", updateGnbCellsRanName).Return(updateGnbCellsNodebInfo(cell1, cell2), nil)
+	writerMock.On("PatchGnbCells", updateGnbCellsNodebInfo(cell2), []*entities.ServedNRCell{}, []*entities.ServedNRCell{cell1}).Return(common.NewInternalError(errors.New("#writer.PatchGnbCells - Internal Error")))
+
+	response, err := handler.Handle(context.Background(), models.UpdateGnbCellsRequest{RanName: updateGnbCellsRanName, ServedNrCellsToDelete: []string{"cell1"}})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
+	writerMock.AssertExpectations(t)
+}
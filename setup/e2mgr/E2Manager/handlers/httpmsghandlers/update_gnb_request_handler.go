@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/models"
@@ -43,7 +44,7 @@ func NewUpdateGnbRequestHandler(logger *logger.Logger, rNibDataService services.
 	}
 }
 
-func (h *UpdateGnbRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *UpdateGnbRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 
 	updateGnbRequest := request.(models.UpdateGnbRequest)
 
@@ -55,7 +56,7 @@ func (h *UpdateGnbRequestHandler) Handle(request models.Request) (models.IRespon
 		return nil, err
 	}
 
-	nodebInfo, err := h.rNibDataService.GetNodeb(updateGnbRequest.RanName)
+	nodebInfo, err := h.rNibDataService.GetNodeb(ctx, updateGnbRequest.RanName)
 
 	if err != nil {
 		_, ok := err.(*common.ResourceNotFoundError)
@@ -68,7 +69,7 @@ func (h *UpdateGnbRequestHandler) Handle(request models.Request) (models.IRespon
 		return nil, e2managererrors.NewResourceNotFoundError()
 	}
 
-	err = h.updateGnbCells(nodebInfo, updateGnbRequest)
+	err = h.updateGnbCells(ctx, nodebInfo, updateGnbRequest)
 
 	if err != nil {
 		return nil, err
@@ -77,7 +78,7 @@ func (h *UpdateGnbRequestHandler) Handle(request models.Request) (models.IRespon
 	return models.NewUpdateGnbResponse(nodebInfo), nil
 }
 
-func (h *UpdateGnbRequestHandler) updateGnbCells(nodebInfo *entities.NodebInfo, updateGnbRequest models.UpdateGnbRequest) error {
+func (h *UpdateGnbRequestHandler) updateGnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, updateGnbRequest models.UpdateGnbRequest) error {
 
 	ranName := nodebInfo.RanName
 	gnb := nodebInfo.GetGnb()
@@ -88,7 +89,7 @@ func (h *UpdateGnbRequestHandler) updateGnbCells(nodebInfo *entities.NodebInfo,
 	}
 
 	if len(gnb.ServedNrCells) != 0 {
-		err := h.rNibDataService.RemoveServedNrCells(ranName, gnb.ServedNrCells)
+		err := h.rNibDataService.RemoveServedNrCells(ctx, ranName, gnb.ServedNrCells)
 
 		if err != nil {
 			h.logger.Errorf("#UpdateGnbRequestHandler.updateGnbCells - RAN name: %s - Failed removing served nr cells", ranName)
@@ -98,7 +99,7 @@ func (h *UpdateGnbRequestHandler) updateGnbCells(nodebInfo *entities.NodebInfo,
 
 	gnb.ServedNrCells = updateGnbRequest.ServedNrCells
 
-	err := h.rNibDataService.UpdateGnbCells(nodebInfo, updateGnbRequest.ServedNrCells)
+	err := h.rNibDataService.UpdateGnbCells(ctx, nodebInfo, updateGnbRequest.ServedNrCells)
 
 	if err != nil {
 		h.logger.Errorf("#UpdateGnbRequestHandler.updateGnbCells - RAN name: %s - Failed updating GNB cells. Error: %s", ranName, err)
@@ -21,11 +21,12 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/e2pdus"
 	"e2mgr/logger"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
@@ -51,7 +52,7 @@ func NewX2ResetRequestHandler(logger *logger.Logger, rmrSender *rmrsender.RmrSen
 	}
 }
 
-func (handler *X2ResetRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (handler *X2ResetRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 
 	resetRequest := request.(models.ResetRequest)
 	handler.logger.Infof("#X2ResetRequestHandler.Handle - Ran name: %s", resetRequest.RanName)
@@ -67,7 +68,7 @@ func (handler *X2ResetRequestHandler) Handle(request models.Request) (models.IRe
 		return nil, e2managererrors.NewRequestValidationError()
 	}
 
-	nodeb, err := handler.rNibDataService.GetNodeb(resetRequest.RanName)
+	nodeb, err := handler.rNibDataService.GetNodeb(ctx, resetRequest.RanName)
 	if err != nil {
 		handler.logger.Errorf("#X2ResetRequestHandler.Handle - failed to get status of RAN: %s from RNIB. Error: %s", resetRequest.RanName, err.Error())
 		_, ok := err.(*common.ResourceNotFoundError)
@@ -84,9 +85,9 @@ func (handler *X2ResetRequestHandler) Handle(request models.Request) (models.IRe
 
 	var xAction []byte
 	var msgSrc unsafe.Pointer
-	msg := models.NewRmrMessage(rmrCgo.RIC_X2_RESET, resetRequest.RanName, payload, xAction, msgSrc)
+	msg := models.NewRmrMessage(rmrtypes.RIC_X2_RESET, resetRequest.RanName, payload, xAction, msgSrc)
 
-	err = handler.rmrSender.Send(msg)
+	err = handler.rmrSender.Send(ctx, msg)
 
 	if err != nil {
 		handler.logger.Errorf("#X2ResetRequestHandler.Handle - failed to send reset message to RMR: %s", err)
@@ -21,11 +21,12 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
@@ -55,14 +56,14 @@ func TestHandleSuccessfulDefaultCause(t *testing.T) {
 	payload := []byte{0x00, 0x07, 0x00, 0x08, 0x00, 0x00, 0x01, 0x00, 0x05, 0x40, 0x01, 0x64}
 	var xAction[]byte
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
 
 	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil)
 
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.Nil(t, actual)
 }
@@ -74,13 +75,13 @@ func TestHandleSuccessfulRequestedCause(t *testing.T) {
 	payload := []byte{0x00, 0x07, 0x00, 0x08, 0x00, 0x00, 0x01, 0x00, 0x05, 0x40, 0x01, 0x40}
 	var xAction[]byte
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil)
 
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName, Cause: "protocol:transfer-syntax-error"})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName, Cause: "protocol:transfer-syntax-error"})
 
 	assert.Nil(t, actual)
 }
@@ -92,7 +93,7 @@ func TestHandleFailureUnknownCause(t *testing.T) {
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName, Cause: "XXX"})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName, Cause: "XXX"})
 
 	assert.IsType(t, e2managererrors.NewRequestValidationError(), actual)
 
@@ -105,7 +106,7 @@ func TestHandleFailureWrongState(t *testing.T) {
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.IsType(t, e2managererrors.NewWrongStateError(X2_RESET_ACTIVITY_NAME, entities.ConnectionStatus_name[int32(nodeb.ConnectionStatus)]), actual)
 }
@@ -117,7 +118,7 @@ func TestHandleFailureRanNotFound(t *testing.T) {
 
 	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{}, common.NewResourceNotFoundError("nodeb not found"))
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.IsType(t, e2managererrors.NewResourceNotFoundError(), actual)
 }
@@ -129,7 +130,7 @@ func TestHandleFailureRnibError(t *testing.T) {
 
 	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{}, common.NewInternalError(fmt.Errorf("internal error")))
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.IsType(t, e2managererrors.NewRnibDbError(), actual)
 }
@@ -142,13 +143,13 @@ func TestHandleFailureRmrError(t *testing.T) {
 	payload := []byte{0x00, 0x07, 0x00, 0x08, 0x00, 0x00, 0x01, 0x00, 0x05, 0x40, 0x01, 0x64}
 	var xAction[]byte
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
-	rmrMessengerMock.On("SendMsg", msg, true).Return(&rmrCgo.MBuf{}, fmt.Errorf("rmr error"))
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(&rmrtypes.MBuf{}, fmt.Errorf("rmr error"))
 
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.IsType(t, e2managererrors.NewRmrError(), actual)
 }
@@ -0,0 +1,54 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package rmrmsghandlers
+
+import "sync"
+
+// DecodeErrors counts, by message type, the notifications that could not be decoded: the ones whose decoder returned
+// an error and the ones whose handler panicked
+type DecodeErrors struct {
+	mu      sync.Mutex
+	byMType map[int]int
+}
+
+func NewDecodeErrors() *DecodeErrors {
+	return &DecodeErrors{byMType: map[int]int{}}
+}
+
+// Add counts a decode error of mType and returns the number of decode errors of mType so far
+func (d *DecodeErrors) Add(mType int) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.byMType[mType]++
+	return d.byMType[mType]
+}
+
+// Counts returns the number of decode errors by message type
+func (d *DecodeErrors) Counts() map[int]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := make(map[int]int, len(d.byMType))
+	for mType, count := range d.byMType {
+		result[mType] = count
+	}
+	return result
+}
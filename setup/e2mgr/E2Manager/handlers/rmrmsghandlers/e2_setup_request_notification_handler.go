@@ -21,12 +21,13 @@ package rmrmsghandlers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"encoding/xml"
@@ -45,9 +46,10 @@ type E2SetupRequestNotificationHandler struct {
 	rmrSender             *rmrsender.RmrSender
 	rNibDataService       services.RNibDataService
 	e2tAssociationManager *managers.E2TAssociationManager
+	decodeErrors          *DecodeErrors
 }
 
-func NewE2SetupRequestNotificationHandler(logger *logger.Logger, config *configuration.Configuration, e2tInstancesManager managers.IE2TInstancesManager, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, e2tAssociationManager *managers.E2TAssociationManager) E2SetupRequestNotificationHandler {
+func NewE2SetupRequestNotificationHandler(logger *logger.Logger, config *configuration.Configuration, e2tInstancesManager managers.IE2TInstancesManager, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, e2tAssociationManager *managers.E2TAssociationManager, decodeErrors *DecodeErrors) E2SetupRequestNotificationHandler {
 	return E2SetupRequestNotificationHandler{
 		logger:                logger,
 		config:                config,
@@ -55,30 +57,33 @@ func NewE2SetupRequestNotificationHandler(logger *logger.Logger, config *configu
 		rmrSender:             rmrSender,
 		rNibDataService:       rNibDataService,
 		e2tAssociationManager: e2tAssociationManager,
+		decodeErrors:          decodeErrors,
 	}
 }
 
-func (h E2SetupRequestNotificationHandler) Handle(request *models.NotificationRequest) {
+func (h E2SetupRequestNotificationHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
 	ranName := request.RanName
 	h.logger.Infof("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - received E2_SETUP_REQUEST. Payload: %x", ranName, request.Payload)
 
 	setupRequest, e2tIpAddress, err := h.parseSetupRequest(request.Payload)
 	if err != nil {
 		h.logger.Errorf(err.Error())
+		h.decodeErrors.Add(rmrtypes.RIC_E2_SETUP_REQ)
 		return
 	}
 
 	h.logger.Infof("#E2SetupRequestNotificationHandler.Handle - E2T Address: %s - handling E2_SETUP_REQUEST", e2tIpAddress)
 	h.logger.Debugf("#E2SetupRequestNotificationHandler.Handle - E2_SETUP_REQUEST has been parsed successfully %+v", setupRequest)
 
-	_, err = h.e2tInstancesManager.GetE2TInstance(e2tIpAddress)
+	_, err = h.e2tInstancesManager.GetE2TInstance(ctx, e2tIpAddress)
 
 	if err != nil {
 		h.logger.Errorf("#E2TermInitNotificationHandler.Handle - Failed retrieving E2TInstance. error: %s", err)
 		return
 	}
 
-	nodebInfo, err := h.rNibDataService.GetNodeb(ranName)
+	nodebInfo, err := h.rNibDataService.GetNodeb(ctx, ranName)
+	previousStatus := entities.ConnectionStatus_UNKNOWN_CONNECTION_STATUS
 
 	if err != nil {
 
@@ -88,31 +93,36 @@ func (h E2SetupRequestNotificationHandler) Handle(request *models.NotificationRe
 
 		}
 
-		if nodebInfo, err = h.handleNewRan(ranName, e2tIpAddress, setupRequest); err != nil {
+		if nodebInfo, err = h.handleNewRan(ctx, ranName, e2tIpAddress, setupRequest); err != nil {
 			return
 		}
 
 	} else {
+		previousStatus = nodebInfo.GetConnectionStatus()
+
 		if err = h.handleExistingRan(ranName, nodebInfo, setupRequest); err != nil {
 			return
 		}
 	}
 
-	err = h.e2tAssociationManager.AssociateRan(e2tIpAddress, nodebInfo)
+	err = h.e2tAssociationManager.AssociateRan(ctx, e2tIpAddress, nodebInfo)
 
 	if err != nil {
 
 		h.logger.Errorf("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - failed to associate E2T to nodeB entity. Error: %s", ranName, err)
 		if _, ok := err.(*e2managererrors.RoutingManagerError); ok {
-			h.handleUnsuccessfulResponse(nodebInfo, request)
+			h.addRanStatusChange(ctx, nodebInfo, previousStatus, "E2 setup request, routing manager failure")
+			h.handleUnsuccessfulResponse(ctx, nodebInfo, request)
 		}
 		return
 	}
 
-	h.handleSuccessfulResponse(ranName, request, setupRequest)
+	h.addRanStatusChange(ctx, nodebInfo, previousStatus, "E2 setup request")
+
+	h.handleSuccessfulResponse(ctx, ranName, request, setupRequest)
 }
 
-func (h E2SetupRequestNotificationHandler) handleNewRan(ranName string, e2tIpAddress string, setupRequest *models.E2SetupRequestMessage) (*entities.NodebInfo, error) {
+func (h E2SetupRequestNotificationHandler) handleNewRan(ctx context.Context, ranName string, e2tIpAddress string, setupRequest *models.E2SetupRequestMessage) (*entities.NodebInfo, error) {
 
 	nodebInfo, err := h.buildNodebInfo(ranName, e2tIpAddress, setupRequest)
 
@@ -122,7 +132,7 @@ func (h E2SetupRequestNotificationHandler) handleNewRan(ranName string, e2tIpAdd
 	}
 
 	nbIdentity := h.buildNbIdentity(ranName, setupRequest)
-	err = h.rNibDataService.SaveNodeb(nbIdentity, nodebInfo)
+	err = h.rNibDataService.SaveNodeb(ctx, nbIdentity, nodebInfo)
 
 	if err != nil {
 		h.logger.Errorf("#E2SetupRequestNotificationHandler.handleNewRan - RAN name: %s - failed to save nodebInfo entity. Error: %s", ranName, err)
@@ -157,7 +167,16 @@ func (h E2SetupRequestNotificationHandler) handleExistingRan(ranName string, nod
 	return err
 }
 
-func (h E2SetupRequestNotificationHandler) handleUnsuccessfulResponse(nodebInfo *entities.NodebInfo, req *models.NotificationRequest) {
+func (h E2SetupRequestNotificationHandler) addRanStatusChange(ctx context.Context, nodebInfo *entities.NodebInfo, previousStatus entities.ConnectionStatus, cause string) {
+	ranStatusChange := models.NewRanStatusChange(previousStatus, nodebInfo.GetConnectionStatus(), cause, "E2SetupRequestNotificationHandler")
+	err := h.rNibDataService.AddRanStatusChange(ctx, nodebInfo.RanName, ranStatusChange)
+
+	if err != nil {
+		h.logger.Warnf("#E2SetupRequestNotificationHandler.addRanStatusChange - RAN name: %s - failed adding status change to RAN's status history. Error: %s", nodebInfo.RanName, err)
+	}
+}
+
+func (h E2SetupRequestNotificationHandler) handleUnsuccessfulResponse(ctx context.Context, nodebInfo *entities.NodebInfo, req *models.NotificationRequest) {
 	failureResponse := models.NewE2SetupFailureResponseMessage(models.TimeToWaitEnum.V60s)
 	h.logger.Debugf("#E2SetupRequestNotificationHandler.handleUnsuccessfulResponse - E2_SETUP_RESPONSE has been built successfully %+v", failureResponse)
 
@@ -168,13 +187,13 @@ func (h E2SetupRequestNotificationHandler) handleUnsuccessfulResponse(nodebInfo
 
 	responsePayload = replaceEmptyTagsWithSelfClosing(responsePayload)
 
-	msg := models.NewRmrMessage(rmrCgo.RIC_E2_SETUP_FAILURE, nodebInfo.RanName, responsePayload, req.TransactionId, req.GetMsgSrc())
+	msg := models.NewRmrMessage(rmrtypes.RIC_E2_SETUP_FAILURE, nodebInfo.RanName, responsePayload, req.TransactionId, req.GetMsgSrc())
 	h.logger.Infof("#E2SetupRequestNotificationHandler.handleUnsuccessfulResponse - RAN name: %s - RIC_E2_SETUP_RESP message has been built successfully. Message: %x", nodebInfo.RanName, msg)
-	_ = h.rmrSender.WhSend(msg)
+	_ = h.rmrSender.WhSend(ctx, msg)
 
 }
 
-func (h E2SetupRequestNotificationHandler) handleSuccessfulResponse(ranName string, req *models.NotificationRequest, setupRequest *models.E2SetupRequestMessage) {
+func (h E2SetupRequestNotificationHandler) handleSuccessfulResponse(ctx context.Context, ranName string, req *models.NotificationRequest, setupRequest *models.E2SetupRequestMessage) {
 
 	ricNearRtId, err := convertTo20BitString(h.config.GlobalRicId.RicNearRtId)
 	if err != nil {
@@ -191,9 +210,9 @@ func (h E2SetupRequestNotificationHandler) handleSuccessfulResponse(ranName stri
 
 	responsePayload = replaceEmptyTagsWithSelfClosing(responsePayload)
 
-	msg := models.NewRmrMessage(rmrCgo.RIC_E2_SETUP_RESP, ranName, responsePayload, req.TransactionId, req.GetMsgSrc())
+	msg := models.NewRmrMessage(rmrtypes.RIC_E2_SETUP_RESP, ranName, responsePayload, req.TransactionId, req.GetMsgSrc())
 	h.logger.Infof("#E2SetupRequestNotificationHandler.handleSuccessfulResponse - RAN name: %s - RIC_E2_SETUP_RESP message has been built successfully. Message: %x", ranName, msg)
-	_ = h.rmrSender.Send(msg)
+	_ = h.rmrSender.Send(ctx, msg)
 }
 
 func replaceEmptyTagsWithSelfClosing(responsePayload []byte) []byte {
@@ -239,6 +258,10 @@ func (h E2SetupRequestNotificationHandler) parseSetupRequest(payload []byte) (*m
 		return nil, "", errors.New(fmt.Sprintf("#E2SetupRequestNotificationHandler.parseSetupRequest - Error unmarshalling E2 Setup Request payload: %x", payload))
 	}
 
+	if len(setupRequest.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs) == 0 {
+		return nil, "", errors.New("#E2SetupRequestNotificationHandler.parseSetupRequest - E2 Setup Request has no GlobalE2node-ID")
+	}
+
 	return setupRequest, e2tIpAddress, nil
 }
 
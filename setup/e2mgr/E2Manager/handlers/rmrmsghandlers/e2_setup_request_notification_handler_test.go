@@ -20,11 +20,13 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
+	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/tests"
 	"errors"
@@ -120,6 +122,46 @@ func TestParseSetupRequest_UnmarshalFailure(t *testing.T) {
 	assert.EqualError(t, err, "#E2SetupRequestNotificationHandler.parseSetupRequest - Error unmarshalling E2 Setup Request payload: 31302e302e322e31353a393939397c010203")
 }
 
+func TestParseSetupRequest_NoIesFailure(t *testing.T) {
+	handler, _, _, _, _, _ := initMocks(t)
+	request, _, err := handler.parseSetupRequest([]byte(prefix + "<E2AP-PDU></E2AP-PDU>"))
+	assert.Nil(t, request)
+	assert.EqualError(t, err, "#E2SetupRequestNotificationHandler.parseSetupRequest - E2 Setup Request has no GlobalE2node-ID")
+}
+
+// FuzzParseSetupRequest feeds malformed setup requests to the parser and to the accessors the handler uses on the
+// parsed request, none of which may panic
+func FuzzParseSetupRequest(f *testing.F) {
+	for _, xmlPath := range []string{GnbSetupRequestXmlPath, GnbWithoutFunctionsSetupRequestXmlPath, EnGnbSetupRequestXmlPath, NgEnbSetupRequestXmlPath, EnbSetupRequestXmlPath} {
+		xmlAsBytes, err := ioutil.ReadFile(xmlPath)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(append([]byte(prefix), xmlAsBytes...))
+	}
+	f.Add([]byte(prefix))
+	f.Add([]byte("|"))
+
+	log, err := logger.InitLogger(logger.ErrorLevel)
+	if err != nil {
+		f.Fatal(err)
+	}
+	handler := E2SetupRequestNotificationHandler{logger: log}
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		request, e2tAddress, err := handler.parseSetupRequest(payload)
+		if err != nil {
+			return
+		}
+		if e2tAddress == "" {
+			t.Errorf("parsed a setup request without an E2T address")
+		}
+		_ = request.GetPlmnId()
+		_ = request.GetNbId()
+		_, _ = handler.buildNodebInfo(nodebRanName, e2tAddress, request)
+	})
+}
+
 func TestE2SetupRequestNotificationHandler_HandleNewGnbSuccess(t *testing.T) {
 	xmlGnb := readXmlFile(t, GnbSetupRequestXmlPath)
 	handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock := initMocks(t)
@@ -132,11 +174,11 @@ func TestE2SetupRequestNotificationHandler_HandleNewGnbSuccess(t *testing.T) {
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
 	var errEmpty error
-	rmrMessage := &rmrCgo.MBuf{}
+	rmrMessage := &rmrtypes.MBuf{}
 	rmrMessengerMock.On("SendMsg", mock.Anything, mock.Anything).Return(rmrMessage, errEmpty)
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	assertNewNodebSuccessCalls(readerMock, t, e2tInstancesManagerMock, writerMock, routingManagerClientMock, rmrMessengerMock)
 }
 
@@ -152,11 +194,11 @@ func TestE2SetupRequestNotificationHandler_HandleNewGnbWithoutFunctionsSuccess(t
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
 	var errEmpty error
-	rmrMessage := &rmrCgo.MBuf{}
+	rmrMessage := &rmrtypes.MBuf{}
 	rmrMessengerMock.On("SendMsg", mock.Anything, mock.Anything).Return(rmrMessage, errEmpty)
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	assertNewNodebSuccessCalls(readerMock, t, e2tInstancesManagerMock, writerMock, routingManagerClientMock, rmrMessengerMock)
 }
 
@@ -172,11 +214,11 @@ func TestE2SetupRequestNotificationHandler_HandleNewEnGnbSuccess(t *testing.T) {
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
 	var errEmpty error
-	rmrMessage := &rmrCgo.MBuf{}
+	rmrMessage := &rmrtypes.MBuf{}
 	rmrMessengerMock.On("SendMsg", mock.Anything, mock.Anything).Return(rmrMessage, errEmpty)
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlEnGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	assertNewNodebSuccessCalls(readerMock, t, e2tInstancesManagerMock, writerMock, routingManagerClientMock, rmrMessengerMock)
 }
 
@@ -192,14 +234,38 @@ func TestE2SetupRequestNotificationHandler_HandleNewNgEnbSuccess(t *testing.T) {
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
 	var errEmpty error
-	rmrMessage := &rmrCgo.MBuf{}
+	rmrMessage := &rmrtypes.MBuf{}
 	rmrMessengerMock.On("SendMsg", mock.Anything, mock.Anything).Return(rmrMessage, errEmpty)
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlNgEnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	assertNewNodebSuccessCalls(readerMock, t, e2tInstancesManagerMock, writerMock, routingManagerClientMock, rmrMessengerMock)
 }
 
+func TestE2SetupRequestNotificationHandler_HandleNewGnbRecordsRanStatusChange(t *testing.T) {
+	xmlGnb := readXmlFile(t, GnbSetupRequestXmlPath)
+	handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock := initMocksWithRanStatusHistory(t, 10)
+	var e2tInstance = &entities.E2TInstance{}
+	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(e2tInstance, nil)
+	var gnb *entities.NodebInfo
+	readerMock.On("GetNodeb", mock.Anything).Return(gnb, common.NewResourceNotFoundError("Not found"))
+	writerMock.On("SaveNodeb", mock.Anything, mock.Anything).Return(nil)
+	routingManagerClientMock.On("AssociateRanToE2TInstance", e2tInstanceFullAddress, mock.Anything).Return(nil)
+	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
+	writerMock.On("AddRanStatusChange", nodebRanName, mock.MatchedBy(func(change *models.RanStatusChange) bool {
+		return change.PreviousStatus == "UNKNOWN_CONNECTION_STATUS" && change.NewStatus == "CONNECTED" && change.Component == "E2SetupRequestNotificationHandler"
+	}), 10).Return(nil)
+	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
+	var errEmpty error
+	rmrMessage := &rmrtypes.MBuf{}
+	rmrMessengerMock.On("SendMsg", mock.Anything, mock.Anything).Return(rmrMessage, errEmpty)
+	prefBytes := []byte(prefix)
+	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
+	handler.Handle(context.Background(), notificationRequest)
+	assertNewNodebSuccessCalls(readerMock, t, e2tInstancesManagerMock, writerMock, routingManagerClientMock, rmrMessengerMock)
+	writerMock.AssertNumberOfCalls(t, "AddRanStatusChange", 1)
+}
+
 func TestE2SetupRequestNotificationHandler_HandleExistingGnbSuccess(t *testing.T) {
 	xmlGnb := readXmlFile(t, GnbSetupRequestXmlPath)
 
@@ -218,11 +284,11 @@ func TestE2SetupRequestNotificationHandler_HandleExistingGnbSuccess(t *testing.T
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
 	var errEmpty error
-	rmrMessage := &rmrCgo.MBuf{}
+	rmrMessage := &rmrtypes.MBuf{}
 	rmrMessengerMock.On("SendMsg", mock.Anything, mock.Anything).Return(rmrMessage, errEmpty)
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	assertExistingNodebSuccessCalls(readerMock, t, e2tInstancesManagerMock, writerMock, routingManagerClientMock, rmrMessengerMock)
 }
 
@@ -232,7 +298,7 @@ func TestE2SetupRequestNotificationHandler_HandleParseError(t *testing.T) {
 	handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock := initMocks(t)
 	prefBytes := []byte("invalid_prefix")
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
 	writerMock.AssertNotCalled(t, "SaveNodeb", mock.Anything, mock.Anything)
 	routingManagerClientMock.AssertNotCalled(t, "AssociateRanToE2TInstance", e2tInstanceFullAddress, mock.Anything)
@@ -245,7 +311,7 @@ func TestE2SetupRequestNotificationHandler_HandleUnmarshalError(t *testing.T) {
 	handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock := initMocks(t)
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, "xmlGnb"...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
 	writerMock.AssertNotCalled(t, "SaveNodeb", mock.Anything, mock.Anything)
 	routingManagerClientMock.AssertNotCalled(t, "AssociateRanToE2TInstance", e2tInstanceFullAddress, mock.Anything)
@@ -261,7 +327,7 @@ func TestE2SetupRequestNotificationHandler_HandleGetE2TInstanceError(t *testing.
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(e2tInstance, common.NewResourceNotFoundError("Not found"))
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	e2tInstancesManagerMock.AssertCalled(t, "GetE2TInstance", e2tInstanceFullAddress)
 	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
 	writerMock.AssertNotCalled(t, "SaveNodeb", mock.Anything, mock.Anything)
@@ -281,7 +347,7 @@ func TestE2SetupRequestNotificationHandler_HandleGetNodebError(t *testing.T) {
 	readerMock.On("GetNodeb", mock.Anything).Return(gnb, common.NewInternalError(errors.New("some error")))
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	e2tInstancesManagerMock.AssertCalled(t, "GetE2TInstance", e2tInstanceFullAddress)
 	readerMock.AssertCalled(t, "GetNodeb", mock.Anything)
 	writerMock.AssertNotCalled(t, "SaveNodeb", mock.Anything, mock.Anything)
@@ -304,12 +370,12 @@ func TestE2SetupRequestNotificationHandler_HandleAssociationError(t *testing.T)
 	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
 	routingManagerClientMock.On("AssociateRanToE2TInstance", e2tInstanceFullAddress, mock.Anything).Return(errors.New("association error"))
 	var errEmpty error
-	rmrMessage := &rmrCgo.MBuf{}
+	rmrMessage := &rmrtypes.MBuf{}
 	rmrMessengerMock.On("WhSendMsg", mock.Anything, mock.Anything).Return(rmrMessage, errEmpty)
 
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	readerMock.AssertCalled(t, "GetNodeb", mock.Anything)
 	e2tInstancesManagerMock.AssertCalled(t, "GetE2TInstance", e2tInstanceFullAddress)
 	writerMock.AssertCalled(t, "SaveNodeb", mock.Anything, mock.Anything)
@@ -334,7 +400,7 @@ func TestE2SetupRequestNotificationHandler_ConvertTo20BitStringError(t *testing.
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	e2tInstancesManagerMock := &mocks.E2TInstancesManagerMock{}
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManagerMock, routingManagerClientMock)
-	handler := NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManagerMock, rmrSender, rnibDataService, e2tAssociationManager)
+	handler := NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManagerMock, rmrSender, rnibDataService, e2tAssociationManager, NewDecodeErrors())
 
 	var e2tInstance = &entities.E2TInstance{}
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(e2tInstance, nil)
@@ -345,11 +411,11 @@ func TestE2SetupRequestNotificationHandler_ConvertTo20BitStringError(t *testing.
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	e2tInstancesManagerMock.On("AddRansToInstance", mock.Anything, mock.Anything).Return(nil)
 	var errEmpty error
-	rmrMessage := &rmrCgo.MBuf{}
+	rmrMessage := &rmrtypes.MBuf{}
 	rmrMessengerMock.On("SendMsg", mock.Anything, mock.Anything).Return(rmrMessage, errEmpty)
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlEnGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	readerMock.AssertCalled(t, "GetNodeb", mock.Anything)
 	e2tInstancesManagerMock.AssertCalled(t, "GetE2TInstance", e2tInstanceFullAddress)
 	writerMock.AssertCalled(t, "SaveNodeb", mock.Anything, mock.Anything)
@@ -368,7 +434,7 @@ func TestE2SetupRequestNotificationHandler_HandleExistingGnbInvalidStatusError(t
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(e2tInstance, nil)
 	prefBytes := []byte(prefix)
 	notificationRequest := &models.NotificationRequest{RanName: nodebRanName, Payload: append(prefBytes, xmlGnb...)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	readerMock.AssertCalled(t, "GetNodeb", mock.Anything)
 	e2tInstancesManagerMock.AssertCalled(t, "GetE2TInstance", e2tInstanceFullAddress)
 	writerMock.AssertNotCalled(t, "SaveNodeb", mock.Anything, mock.Anything)
@@ -379,8 +445,12 @@ func TestE2SetupRequestNotificationHandler_HandleExistingGnbInvalidStatusError(t
 }
 
 func initMocks(t *testing.T) (E2SetupRequestNotificationHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.RmrMessengerMock, *mocks.E2TInstancesManagerMock, *mocks.RoutingManagerClientMock) {
+	return initMocksWithRanStatusHistory(t, 0)
+}
+
+func initMocksWithRanStatusHistory(t *testing.T, ranStatusHistorySize int) (E2SetupRequestNotificationHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.RmrMessengerMock, *mocks.E2TInstancesManagerMock, *mocks.RoutingManagerClientMock) {
 	logger := tests.InitLog(t)
-	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, GlobalRicId: struct {
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, RanStatusHistorySize: ranStatusHistorySize, GlobalRicId: struct {
 		PlmnId      string
 		RicNearRtId string
 	}{PlmnId: "131014", RicNearRtId: "556670"}}
@@ -392,7 +462,7 @@ func initMocks(t *testing.T) (E2SetupRequestNotificationHandler, *mocks.RnibRead
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	e2tInstancesManagerMock := &mocks.E2TInstancesManagerMock{}
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManagerMock, routingManagerClientMock)
-	handler := NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManagerMock, rmrSender, rnibDataService, e2tAssociationManager)
+	handler := NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManagerMock, rmrSender, rnibDataService, e2tAssociationManager, NewDecodeErrors())
 	return handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock
 }
 
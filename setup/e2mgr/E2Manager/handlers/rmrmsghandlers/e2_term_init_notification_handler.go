@@ -21,11 +21,15 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/clients"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
+	"e2mgr/rmrtypes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 )
@@ -35,36 +39,33 @@ type E2TermInitNotificationHandler struct {
 	ranDisconnectionManager *managers.RanDisconnectionManager
 	e2tInstancesManager     managers.IE2TInstancesManager
 	routingManagerClient    clients.IRoutingManagerClient
+	decodeErrors            *DecodeErrors
 }
 
-func NewE2TermInitNotificationHandler(logger *logger.Logger, ranDisconnectionManager *managers.RanDisconnectionManager, e2tInstancesManager managers.IE2TInstancesManager, routingManagerClient clients.IRoutingManagerClient) E2TermInitNotificationHandler {
+func NewE2TermInitNotificationHandler(logger *logger.Logger, ranDisconnectionManager *managers.RanDisconnectionManager, e2tInstancesManager managers.IE2TInstancesManager, routingManagerClient clients.IRoutingManagerClient, decodeErrors *DecodeErrors) E2TermInitNotificationHandler {
 	return E2TermInitNotificationHandler{
 		logger:                  logger,
 		ranDisconnectionManager: ranDisconnectionManager,
 		e2tInstancesManager:     e2tInstancesManager,
 		routingManagerClient:    routingManagerClient,
+		decodeErrors:            decodeErrors,
 	}
 }
 
-func (h E2TermInitNotificationHandler) Handle(request *models.NotificationRequest) {
-	unmarshalledPayload := models.E2TermInitPayload{}
-	err := json.Unmarshal(request.Payload, &unmarshalledPayload)
+func (h E2TermInitNotificationHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
+	unmarshalledPayload, err := parseE2TermInitPayload(request.Payload)
 
 	if err != nil {
-		h.logger.Errorf("#E2TermInitNotificationHandler.Handle - Error unmarshaling E2 Term Init payload: %s", err)
+		h.logger.Errorf("#E2TermInitNotificationHandler.Handle - %s", err)
+		h.decodeErrors.Add(rmrtypes.RIC_E2_TERM_INIT)
 		return
 	}
 
 	e2tAddress := unmarshalledPayload.Address
 
-	if len(e2tAddress) == 0 {
-		h.logger.Errorf("#E2TermInitNotificationHandler.Handle - Empty E2T address received")
-		return
-	}
-
-	h.logger.Infof("#E2TermInitNotificationHandler.Handle - E2T payload: %s - handling E2_TERM_INIT", unmarshalledPayload)
+	h.logger.Infof("#E2TermInitNotificationHandler.Handle - E2T payload: %s - handling E2_TERM_INIT", *unmarshalledPayload)
 
-	e2tInstance, err := h.e2tInstancesManager.GetE2TInstance(e2tAddress)
+	e2tInstance, err := h.e2tInstancesManager.GetE2TInstance(ctx, e2tAddress)
 
 	if err != nil {
 		_, ok := err.(*common.ResourceNotFoundError)
@@ -74,7 +75,7 @@ func (h E2TermInitNotificationHandler) Handle(request *models.NotificationReques
 			return
 		}
 
-		h.HandleNewE2TInstance(e2tAddress, unmarshalledPayload.PodName)
+		h.HandleNewE2TInstance(ctx, e2tAddress, unmarshalledPayload.PodName)
 		return
 	}
 
@@ -88,16 +89,16 @@ func (h E2TermInitNotificationHandler) Handle(request *models.NotificationReques
 		return
 	}
 
-	h.HandleExistingE2TInstance(e2tInstance)
+	h.HandleExistingE2TInstance(ctx, e2tInstance)
 
 	h.logger.Infof("#E2TermInitNotificationHandler.Handle - Completed handling of E2_TERM_INIT")
 }
 
-func (h E2TermInitNotificationHandler) HandleExistingE2TInstance(e2tInstance *entities.E2TInstance) {
+func (h E2TermInitNotificationHandler) HandleExistingE2TInstance(ctx context.Context, e2tInstance *entities.E2TInstance) {
 
 	for _, ranName := range e2tInstance.AssociatedRanList {
 
-		if err := h.ranDisconnectionManager.DisconnectRan(ranName); err != nil {
+		if err := h.ranDisconnectionManager.DisconnectRan(ctx, ranName); err != nil {
 			if _, ok := err.(*common.ResourceNotFoundError); !ok{
 				break
 			}
@@ -105,14 +106,29 @@ func (h E2TermInitNotificationHandler) HandleExistingE2TInstance(e2tInstance *en
 	}
 }
 
-func (h E2TermInitNotificationHandler) HandleNewE2TInstance(e2tAddress string, podName string) {
+func (h E2TermInitNotificationHandler) HandleNewE2TInstance(ctx context.Context, e2tAddress string, podName string) {
 
-	err := h.routingManagerClient.AddE2TInstance(e2tAddress)
+	err := h.routingManagerClient.AddE2TInstance(ctx, e2tAddress)
 
 	if err != nil{
 		h.logger.Errorf("#E2TermInitNotificationHandler.HandleNewE2TInstance - e2t address: %s - routing manager failure", e2tAddress)
 		return
 	}
 
-	_ = h.e2tInstancesManager.AddE2TInstance(e2tAddress, podName)
-}
\ No newline at end of file
+	_ = h.e2tInstancesManager.AddE2TInstance(ctx, e2tAddress, podName)
+}
+
+func parseE2TermInitPayload(payload []byte) (*models.E2TermInitPayload, error) {
+	unmarshalledPayload := &models.E2TermInitPayload{}
+	err := json.Unmarshal(payload, unmarshalledPayload)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error unmarshaling E2 Term Init payload: %s", err)
+	}
+
+	if len(unmarshalledPayload.Address) == 0 {
+		return nil, errors.New("Empty E2T address received")
+	}
+
+	return unmarshalledPayload, nil
+}
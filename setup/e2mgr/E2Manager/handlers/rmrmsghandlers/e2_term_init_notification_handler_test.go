@@ -22,13 +22,14 @@ package rmrmsghandlers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
@@ -36,6 +37,7 @@ import (
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io/ioutil"
 	"net/http"
@@ -65,7 +67,7 @@ func initRanLostConnectionTest(t *testing.T) (*logger.Logger, E2TermInitNotifica
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManagerMock, routingManagerClientMock)
 
 	ranDisconnectionManager := managers.NewRanDisconnectionManager(logger, configuration.ParseConfiguration(), rnibDataService, e2tAssociationManager)
-	handler := NewE2TermInitNotificationHandler(logger, ranDisconnectionManager, e2tInstancesManagerMock, routingManagerClientMock)
+	handler := NewE2TermInitNotificationHandler(logger, ranDisconnectionManager, e2tInstancesManagerMock, routingManagerClientMock, NewDecodeErrors())
 
 	return logger, handler, readerMock, writerMock, e2tInstancesManagerMock, routingManagerClientMock
 }
@@ -78,6 +80,7 @@ func initRanLostConnectionTestWithRealE2tInstanceManager(t *testing.T) (*logger.
 	readerMock := &mocks.RnibReaderMock{}
 
 	writerMock := &mocks.RnibWriterMock{}
+	writerMock.On("AddRanStatusChange", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	httpClientMock := &mocks.HttpClientMock{}
 
 	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClientMock)
@@ -86,14 +89,14 @@ func initRanLostConnectionTestWithRealE2tInstanceManager(t *testing.T) (*logger.
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, logger)
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManager, routingManagerClient)
 	ranDisconnectionManager := managers.NewRanDisconnectionManager(logger, configuration.ParseConfiguration(), rnibDataService, e2tAssociationManager)
-	handler := NewE2TermInitNotificationHandler(logger, ranDisconnectionManager, e2tInstancesManager, routingManagerClient)
+	handler := NewE2TermInitNotificationHandler(logger, ranDisconnectionManager, e2tInstancesManager, routingManagerClient, NewDecodeErrors())
 	return logger, config, handler, readerMock, writerMock, httpClientMock
 }
 
 func TestE2TermInitUnmarshalPayloadFailure(t *testing.T) {
 	_, handler, _, _, e2tInstancesManagerMock, _ := initRanLostConnectionTest(t)
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte("asd")}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	e2tInstancesManagerMock.AssertNotCalled(t, "GetE2TInstance")
 	e2tInstancesManagerMock.AssertNotCalled(t, "AddE2TInstance")
 }
@@ -101,7 +104,7 @@ func TestE2TermInitUnmarshalPayloadFailure(t *testing.T) {
 func TestE2TermInitEmptyE2TAddress(t *testing.T) {
 	_, handler, _, _, e2tInstancesManagerMock, _ := initRanLostConnectionTest(t)
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte("{\"address\":\"\"}")}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	e2tInstancesManagerMock.AssertNotCalled(t, "GetE2TInstance")
 	e2tInstancesManagerMock.AssertNotCalled(t, "AddE2TInstance")
 }
@@ -111,7 +114,7 @@ func TestE2TermInitGetE2TInstanceFailure(t *testing.T) {
 	var e2tInstance *entities.E2TInstance
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceAddress).Return(e2tInstance, common.NewInternalError(fmt.Errorf("internal error")))
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	e2tInstancesManagerMock.AssertNotCalled(t, "AddE2TInstance")
 }
 
@@ -120,7 +123,7 @@ func TestE2TermInitGetE2TInstanceDbFailure(t *testing.T) {
 	var e2tInstance *entities.E2TInstance
 	readerMock.On("GetE2TInstance", e2tInstanceAddress).Return(e2tInstance, common.NewInternalError(fmt.Errorf("internal error")))
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	writerMock.AssertNotCalled(t, "UpdateNodebInfo")
 }
 
@@ -142,7 +145,7 @@ func TestE2TermInitNewE2TInstance(t *testing.T) {
 	writerMock.On("SaveE2TAddresses", e2tAddresses).Return(nil)
 
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	httpClientMock.AssertCalled(t, "Post", url, mock.Anything, mock.Anything)
 	writerMock.AssertCalled(t, "SaveE2TInstance", mock.Anything)
@@ -160,7 +163,7 @@ func TestE2TermInitNewE2TInstance__RoutingManagerError(t *testing.T) {
 	httpClientMock.On("Post", url, mock.Anything, mock.Anything).Return(&http.Response{}, errors.New("error"))
 
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNumberOfCalls(t, "SaveE2TInstance", 0)
 }
@@ -170,7 +173,7 @@ func TestE2TermInitExistingE2TInstanceNoAssociatedRans(t *testing.T) {
 	e2tInstance := entities.NewE2TInstance(e2tInstanceAddress, podName)
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceAddress).Return(e2tInstance, nil)
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	e2tInstancesManagerMock.AssertCalled(t, "GetE2TInstance", e2tInstanceAddress)
 }
 
@@ -201,10 +204,11 @@ func TestE2TermInitHandlerSuccessOneRan(t *testing.T) {
 
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 2)
 	writerMock.AssertNumberOfCalls(t, "SaveE2TInstance", 1)
+	writerMock.AssertNumberOfCalls(t, "AddRanStatusChange", 1)
 	httpClientMock.AssertNumberOfCalls(t, "Post", 1)
 }
 
@@ -234,11 +238,12 @@ func TestE2TermInitHandlerSuccessOneRan_RoutingManagerError(t *testing.T) {
 
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 2)
 	writerMock.AssertNumberOfCalls(t, "SaveE2TInstance", 1)
-	httpClientMock.AssertNumberOfCalls(t, "Post", 1)
+	// Dissociation is idempotent and retried on transport errors
+	httpClientMock.AssertNumberOfCalls(t, "Post", 1+config.RoutingManager.MaxRetries)
 }
 
 func TestE2TermInitHandlerSuccessOneRanShuttingdown(t *testing.T) {
@@ -256,7 +261,7 @@ func TestE2TermInitHandlerSuccessOneRanShuttingdown(t *testing.T) {
 	readerMock.On("GetE2TInstance", e2tInstanceAddress).Return(e2tInstance, nil)
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 1)
 }
@@ -278,7 +283,7 @@ func TestE2TermInitHandlerSuccessOneRan_ToBeDeleted(t *testing.T) {
 	readerMock.On("GetE2TInstance", e2tInstanceAddress).Return(e2tInstance, nil)
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	httpClientMock.AssertNotCalled(t, "Post", mock.Anything, mock.Anything, mock.Anything)
 	writerMock.AssertNotCalled(t, "UpdateNodebInfo")
@@ -325,7 +330,7 @@ func TestE2TermInitHandlerSuccessTwoRans(t *testing.T) {
 
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 4)
 	writerMock.AssertNumberOfCalls(t, "SaveE2TInstance", 2)
@@ -364,7 +369,7 @@ func TestE2TermInitHandlerSuccessTwoRansSecondRanShutdown(t *testing.T) {
 
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 2)
 	writerMock.AssertNumberOfCalls(t, "SaveE2TInstance", 1)
@@ -404,7 +409,7 @@ func TestE2TermInitHandlerSuccessTwoRansFirstNotFoundFailure(t *testing.T) {
 
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 2)
 	writerMock.AssertNumberOfCalls(t, "SaveE2TInstance", 1)
@@ -427,7 +432,7 @@ func TestE2TermInitHandlerSuccessTwoRansFirstRnibInternalErrorFailure(t *testing
 
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 0)
 	writerMock.AssertNumberOfCalls(t, "SaveE2TInstance", 0)
@@ -441,7 +446,7 @@ func TestE2TermInitHandlerSuccessZeroRans(t *testing.T) {
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceAddress).Return(e2tInstance, nil)
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
 
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNotCalled(t, "UpdateNodebInfo")
 }
@@ -456,14 +461,14 @@ func TestE2TermInitHandlerFailureGetNodebInternalError(t *testing.T) {
 	e2tInstance.AssociatedRanList = append(e2tInstance.AssociatedRanList, "test1")
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceAddress).Return(e2tInstance, nil)
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(e2tInitPayload)}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 
 	writerMock.AssertNotCalled(t, "UpdateNodebInfo")
 }
 
 // TODO: extract to test_utils
 func initRmrSender(rmrMessengerMock *mocks.RmrMessengerMock, log *logger.Logger) *rmrsender.RmrSender {
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrMessengerMock.On("Init", tests.GetPort(), tests.MaxMsgSize, tests.Flags, log).Return(&rmrMessenger)
 	return rmrsender.NewRmrSender(log, rmrMessenger)
 }
@@ -476,3 +481,29 @@ func initLog(t *testing.T) *logger.Logger {
 	}
 	return log
 }
+
+func TestParseE2TermInitPayload(t *testing.T) {
+	payload, err := parseE2TermInitPayload([]byte(`{"address":"10.0.2.15:38000","fqdn":"e2term","pod_name":"e2term-pod"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, models.E2TermInitPayload{Address: "10.0.2.15:38000", Fqdn: "e2term", PodName: "e2term-pod"}, *payload)
+
+	_, err = parseE2TermInitPayload([]byte(`{"address":""}`))
+	assert.EqualError(t, err, "Empty E2T address received")
+
+	_, err = parseE2TermInitPayload([]byte("asd"))
+	assert.NotNil(t, err)
+}
+
+func FuzzParseE2TermInitPayload(f *testing.F) {
+	f.Add([]byte(e2tInitPayload))
+	f.Add([]byte(`{"address":"10.0.2.15:38000","fqdn":"e2term","pod_name":"e2term-pod"}`))
+	f.Add([]byte(`{"address":""}`))
+	f.Add([]byte("asd"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		payload, err := parseE2TermInitPayload(data)
+		if err == nil && payload.Address == "" {
+			t.Errorf("parsed an E2 Term Init payload without an address")
+		}
+	})
+}
@@ -21,9 +21,11 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"encoding/json"
 )
@@ -32,22 +34,25 @@ type E2TKeepAliveResponseHandler struct {
 	logger              *logger.Logger
 	rnibDataService     services.RNibDataService
 	e2TInstancesManager managers.IE2TInstancesManager
+	decodeErrors        *DecodeErrors
 }
 
-func NewE2TKeepAliveResponseHandler(logger *logger.Logger, rnibDataService services.RNibDataService, e2TInstancesManager managers.IE2TInstancesManager) E2TKeepAliveResponseHandler {
+func NewE2TKeepAliveResponseHandler(logger *logger.Logger, rnibDataService services.RNibDataService, e2TInstancesManager managers.IE2TInstancesManager, decodeErrors *DecodeErrors) E2TKeepAliveResponseHandler {
 	return E2TKeepAliveResponseHandler{
 		logger:              logger,
 		rnibDataService:     rnibDataService,
 		e2TInstancesManager: e2TInstancesManager,
+		decodeErrors:        decodeErrors,
 	}
 }
 
-func (h E2TKeepAliveResponseHandler) Handle(request *models.NotificationRequest) {
+func (h E2TKeepAliveResponseHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
 	unmarshalledPayload := models.E2TKeepAlivePayload{}
 	err := json.Unmarshal(request.Payload, &unmarshalledPayload)
 
 	if err != nil {
 		h.logger.Errorf("#E2TKeepAliveResponseHandler.Handle - Error unmarshaling RMR request payload: %v", err)
+		h.decodeErrors.Add(rmrtypes.E2_TERM_KEEP_ALIVE_RESP)
 		return
 	}
 
@@ -21,11 +21,14 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
 	"e2mgr/models"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -40,15 +43,16 @@ func initE2TKeepAliveTest(t *testing.T) (*logger.Logger, E2TKeepAliveResponseHan
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 
 	e2tInstancesManagerMock := &mocks.E2TInstancesManagerMock{}
-	handler := NewE2TKeepAliveResponseHandler(logger, rnibDataService, e2tInstancesManagerMock)
+	handler := NewE2TKeepAliveResponseHandler(logger, rnibDataService, e2tInstancesManagerMock, NewDecodeErrors())
 	return logger, handler, readerMock, writerMock, e2tInstancesManagerMock
 }
 
 func TestE2TKeepAliveUnmarshalPayloadFailure(t *testing.T) {
 	_, handler, _, _, e2tInstancesManagerMock := initE2TKeepAliveTest(t)
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte("asd")}
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	e2tInstancesManagerMock.AssertNotCalled(t, "ResetKeepAliveTimestamp")
+	assert.Equal(t, map[int]int{rmrtypes.E2_TERM_KEEP_ALIVE_RESP: 1}, handler.decodeErrors.Counts())
 }
 
 func TestE2TKeepAliveUnmarshalPayloadSuccess(t *testing.T) {
@@ -58,6 +62,6 @@ func TestE2TKeepAliveUnmarshalPayloadSuccess(t *testing.T) {
 	notificationRequest := &models.NotificationRequest{RanName: RanName, Payload: []byte(jsonRequest)}
 
 	e2tInstancesManagerMock.On("ResetKeepAliveTimestamp", "10.10.2.15:9800").Return(nil)
-	handler.Handle(notificationRequest)
+	handler.Handle(context.Background(), notificationRequest)
 	e2tInstancesManagerMock.AssertCalled(t, "ResetKeepAliveTimestamp", "10.10.2.15:9800")
 }
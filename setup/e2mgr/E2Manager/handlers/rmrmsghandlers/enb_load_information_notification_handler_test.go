@@ -20,125 +20,96 @@
 
 package rmrmsghandlers
 
-/*import (
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/converters"
+	"e2mgr/logger"
+	"e2mgr/mocks"
 	"e2mgr/models"
+	"e2mgr/services"
 	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/mock"
+	"testing"
 	"time"
 )
 
+// An X2AP LOAD INFORMATION pdu of a single cell reporting its UL interference overload and relative narrowband tx power
 const (
-	FullUperPdu  string = "004c07080004001980da0100075bde017c148003d5a8205000017c180003d5a875555403331420000012883a0003547400cd20002801ea16007c1f07c1f107c1f0781e007c80800031a02c000c88199040a00352083669190000d8908020000be0c4001ead4016e007ab50100002f8320067ab5005b8c1ead5070190c00001d637805f220000f56a081400005f020000f56a1d555400ccc508002801ea16007c1f07c1f107c1f0781e007c80800031a02c000c88199040a00352083669190000d8908020000be044001ead4016e007ab50100002f8120067ab5005b8c1ead5070190c00000"
-	FullAperPdu  string = "" // TODO: populate and use it
-	BasicUperPdu string = "004898000400190d0000074200017c148003d5a80000"
-	BasicAperPdu string = "" // TODO: populate and use it
-	GarbagePdu   string = "12312312"
+	LoadInformationAperPdu = "0002401900000100064012000007400d5002f8290007ab500802cc3142"
+	GarbagePdu             = "12312312"
 )
 
-func createNotificationRequest(ranName string, transactionId []byte, packedPdu string) (*models.NotificationRequest, error) {
-	var packedByteSlice []byte
+func initEnbLoadInformationNotificationHandlerTest(t *testing.T) (EnbLoadInformationNotificationHandler, *mocks.RnibWriterMock) {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#initEnbLoadInformationNotificationHandlerTest - failed to initialize logger, error: %s", err)
+	}
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, &mocks.RnibReaderMock{}, writerMock)
 
-	_, err := fmt.Sscanf(packedPdu, "%x", &packedByteSlice)
+	h := NewEnbLoadInformationNotificationHandler(log, rnibDataService, converters.NewEnbLoadInformationExtractor(log), NewDecodeErrors())
+	return h, writerMock
+}
 
+func createLoadInformationNotificationRequest(t *testing.T, packedPdu string, startTime time.Time) *models.NotificationRequest {
+	var payload []byte
+	_, err := fmt.Sscanf(packedPdu, "%x", &payload)
 	if err != nil {
-		return nil, err
+		t.Fatalf("Failed converting packed pdu. Error: %v\n", err)
 	}
 
-	return models.NewNotificationRequest(ranName, packedByteSlice, time.Now(), transactionId, nil), nil
+	return models.NewNotificationRequest(RanName, payload, startTime, []byte{}, nil)
 }
 
-func createNotificationRequestAndHandle(ranName string, transactionId []byte, loadInformationHandler EnbLoadInformationNotificationHandler, pdu string) error {
-	notificationRequest, err := createNotificationRequest(ranName, transactionId, pdu)
+func TestLoadInformationHandlerSuccess(t *testing.T) {
+	h, writerMock := initEnbLoadInformationNotificationHandlerTest(t)
+	startTime := time.Now()
 
-	if err != nil {
-		return err
+	expected := &entities.RanLoadInformation{
+		LoadTimestamp: uint64(startTime.UnixNano()),
+		CellLoadInfos: []*entities.CellLoadInformation{
+			{
+				CellId:                            "02f829:0007ab50",
+				UlInterferenceOverloadIndications: []entities.UlInterferenceOverloadIndication{entities.UlInterferenceOverloadIndication_LOW_INTERFERENCE},
+				RelativeNarrowbandTxPower: &entities.RelativeNarrowbandTxPower{
+					RntpPerPrb:                       "cc",
+					RntpThreshold:                    entities.RntpThreshold_NEG_6,
+					NumberOfCellSpecificAntennaPorts: entities.NumberOfCellSpecificAntennaPorts_V2_ANT_PRT,
+					PB:                               2,
+					PdcchInterferenceImpact:          1,
+				},
+			},
+		},
 	}
+	writerMock.On("SaveCellLoadInformation", RanName, expected).Return(nil)
 
-	loadInformationHandler.Handle(notificationRequest)
-	return nil
-}*/
+	h.Handle(context.Background(), createLoadInformationNotificationRequest(t, LoadInformationAperPdu, startTime))
 
-//func TestLoadInformationHandlerSuccess(t *testing.T) {
-//	log, err := logger.InitLogger(logger.InfoLevel)
-//	if err != nil {
-//		t.Errorf("#setup_request_handler_test.TestLoadInformationHandlerSuccess - failed to initialize logger, error: %v", err)
-//	}
-//
-//	inventoryName := "testRan"
-//
-//	writerMock := &mocks.RnibWriterMock{}
-//	rnibWriterProvider := func() rNibWriter.RNibWriter {
-//		return writerMock
-//	}
-//
-//	var rnibErr error
-//	writerMock.On("SaveRanLoadInformation",inventoryName, mock.Anything).Return(rnibErr)
-//
-//	loadInformationHandler := NewEnbLoadInformationNotificationHandler(rnibWriterProvider)
-//
-//	var packedExampleByteSlice []byte
-//	_, err = fmt.Sscanf(FullUperPdu, "%x", &packedExampleByteSlice)
-//	notificationRequest := models.NewNotificationRequest(inventoryName, packedExampleByteSlice, time.Now(), " 881828026419")
-//	loadInformationHandler.Handle(log, notificationRequest)
-//
-//	writerMock.AssertNumberOfCalls(t, "SaveRanLoadInformation", 1)
-//}
-//
-//func TestLoadInformationHandlerPayloadFailure(t *testing.T) {
-//	log, err := logger.InitLogger(logger.InfoLevel)
-//	if err != nil {
-//		t.Errorf("#setup_request_handler_test.TestLoadInformationHandlerPayloadFailure - failed to initialize logger, error: %v", err)
-//	}
-//
-//	inventoryName := "testRan"
-//
-//	writerMock := &mocks.RnibWriterMock{}
-//	rnibWriterProvider := func() rNibWriter.RNibWriter {
-//		return writerMock
-//	}
-//
-//	var rnibErr error
-//	writerMock.On("SaveRanLoadInformation",inventoryName, mock.Anything).Return(rnibErr)
-//
-//	loadInformationHandler := NewEnbLoadInformationNotificationHandler(rnibWriterProvider)
-//
-//	var packedExampleByteSlice []byte
-//	_, err = fmt.Sscanf(GarbagePdu, "%x", &packedExampleByteSlice)
-//	notificationRequest := models.NewNotificationRequest(inventoryName, packedExampleByteSlice, time.Now(), " 881828026419")
-//	loadInformationHandler.Handle(log, notificationRequest)
-//
-//	writerMock.AssertNumberOfCalls(t, "SaveRanLoadInformation", 0)
-//}
-
-// Integration test
-//func TestLoadInformationHandlerOverrideSuccess(t *testing.T) {
-//	log, err := logger.InitLogger(logger.InfoLevel)
-//	if err != nil {
-//		t.Errorf("#setup_request_handler_test.TestLoadInformationHandlerOverrideSuccess - failed to initialize logger, error: %s", err)
-//	}
-//
-//	rNibWriter.Init("e2Manager", 1)
-//	defer rNibWriter.Close()
-//	reader.Init("e2Manager", 1)
-//	defer reader.Close()
-//	loadInformationHandler := NewEnbLoadInformationNotificationHandler(rNibWriter.GetRNibWriter)
-//
-//	err = createNotificationRequestAndHandle("ranName", " 881828026419", loadInformationHandler, FullUperPdu)
-//
-//	if err != nil {
-//		t.Errorf("#setup_request_handler_test.TestLoadInformationHandlerOverrideSuccess - failed creating NotificationRequest, error: %v", err)
-//	}
-//
-//	err = createNotificationRequestAndHandle("ranName", " 881828026419", loadInformationHandler, BasicUperPdu)
-//
-//	if err != nil {
-//		t.Errorf("#setup_request_handler_test.TestLoadInformationHandlerOverrideSuccess - failed creating NotificationRequest, error: %v", err)
-//	}
-//
-//	ranLoadInformation, rnibErr := reader.GetRNibReader().GetRanLoadInformation("ranName")
-//
-//	if (rnibErr != nil) {
-//		t.Errorf("#setup_request_handler_test.TestLoadInformationHandlerOverrideSuccess - RNIB error: %v", err)
-//	}
-//
-//	assert.Len(t, ranLoadInformation.CellLoadInfos, 1)
-//}
+	writerMock.AssertExpectations(t)
+	writerMock.AssertNotCalled(t, "SaveRanLoadInformation", mock.Anything, mock.Anything)
+}
+
+func TestLoadInformationHandlerPayloadFailure(t *testing.T) {
+	h, writerMock := initEnbLoadInformationNotificationHandlerTest(t)
+
+	h.Handle(context.Background(), createLoadInformationNotificationRequest(t, GarbagePdu, time.Now()))
+
+	writerMock.AssertNotCalled(t, "SaveCellLoadInformation", mock.Anything, mock.Anything)
+}
+
+func TestLoadInformationHandlerSaveCellLoadInformationFailure(t *testing.T) {
+	h, writerMock := initEnbLoadInformationNotificationHandlerTest(t)
+
+	writerMock.On("SaveCellLoadInformation", RanName, mock.MatchedBy(func(ranLoadInformation *entities.RanLoadInformation) bool {
+		return len(ranLoadInformation.CellLoadInfos) == 1
+	})).Return(common.NewInternalError(fmt.Errorf("internal error")))
+
+	h.Handle(context.Background(), createLoadInformationNotificationRequest(t, LoadInformationAperPdu, time.Now()))
+
+	writerMock.AssertNumberOfCalls(t, "SaveCellLoadInformation", 1)
+	writerMock.AssertNotCalled(t, "SaveRanLoadInformation", mock.Anything, mock.Anything)
+}
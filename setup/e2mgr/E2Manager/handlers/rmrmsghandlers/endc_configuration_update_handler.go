@@ -17,7 +17,6 @@
 //  This source code is part of the near-RT RIC (RAN Intelligent Controller)
 //  platform project (RICP).
 
-
 package rmrmsghandlers
 
 // #cgo CFLAGS: -I../../3rdparty/asn1codec/inc/ -I../../3rdparty/asn1codec/e2ap_engine/
@@ -26,45 +25,196 @@ package rmrmsghandlers
 // #include <configuration_update_wrapper.h>
 import "C"
 import (
+	"context"
 	"e2mgr/converters"
 	"e2mgr/e2pdus"
+	"e2mgr/enums"
 	"e2mgr/logger"
+	"e2mgr/managers"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
+	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/utils"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+const (
+	// Answers an update which cannot be persisted to rNib
+	EndcConfigurationUpdateRnibFailureCause = "misc:unspecified"
+	// Answers an update from a RAN which rNib does not hold as a gNB
+	EndcConfigurationUpdateUnknownGnbCause = "protocol:message-not-compatible-with-receiver-state"
 )
 
 type EndcConfigurationUpdateHandler struct {
-	logger *logger.Logger
-	rmrSender *rmrsender.RmrSender
+	logger                 *logger.Logger
+	rmrSender              *rmrsender.RmrSender
+	rNibDataService        services.RNibDataService
+	ranStatusChangeManager managers.IRanStatusChangeManager
+	extractor              converters.IEndcConfigurationUpdateExtractor
+	decodeErrors           *DecodeErrors
 }
 
-func NewEndcConfigurationUpdateHandler(logger *logger.Logger, rmrSender *rmrsender.RmrSender) EndcConfigurationUpdateHandler {
+func NewEndcConfigurationUpdateHandler(logger *logger.Logger, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, ranStatusChangeManager managers.IRanStatusChangeManager, extractor converters.IEndcConfigurationUpdateExtractor, decodeErrors *DecodeErrors) EndcConfigurationUpdateHandler {
 	return EndcConfigurationUpdateHandler{
-		logger: logger,
-		rmrSender: rmrSender,
+		logger:                 logger,
+		rmrSender:              rmrSender,
+		rNibDataService:        rNibDataService,
+		ranStatusChangeManager: ranStatusChangeManager,
+		extractor:              extractor,
+		decodeErrors:           decodeErrors,
 	}
 }
 
-func (h EndcConfigurationUpdateHandler) Handle(request *models.NotificationRequest) {
+func (h EndcConfigurationUpdateHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
 
-	refinedMessage, err := converters.UnpackX2apPduAndRefine(h.logger, e2pdus.MaxAsn1CodecAllocationBufferSize /*allocation buffer*/, request.Len, request.Payload, e2pdus.MaxAsn1CodecMessageBufferSize /*message buffer*/)
+	update, err := h.extractor.ExtractEndcConfigurationUpdate(request.Payload)
 
 	if err != nil {
-		h.logger.Errorf("#endc_configuration_update_handler.Handle - unpack failed. Error: %v", err)
+		h.logger.Errorf("#endc_configuration_update_handler.Handle - RAN name: %s - unpack failed. Error: %v", request.RanName, err)
+		h.decodeErrors.Add(rmrtypes.RIC_ENDC_CONF_UPDATE)
+		h.sendFailure(ctx, request, e2pdus.PackedEndcConfigurationUpdateFailure)
+		return
+	}
+
+	if update.InitiatedByEnb {
+		// rNib holds no EN-DC information of eNBs, hence the update is acknowledged as is
+		h.logger.Infof("#endc_configuration_update_handler.Handle - RAN name: %s - Endc configuration update initiating message received from an eNB - not updating RNIB", request.RanName)
+		h.sendAck(ctx, request)
+		return
+	}
 
-		msg := models.NewRmrMessage(rmrCgo.RIC_ENDC_CONF_UPDATE_FAILURE, request.RanName, e2pdus.PackedEndcConfigurationUpdateFailure, request.TransactionId, request.GetMsgSrc())
-		_ = h.rmrSender.Send(msg)
+	h.logger.Infof("#endc_configuration_update_handler.Handle - RAN name: %s - Endc configuration update initiating message received - served nr cells to add: %d, to modify: %d, to delete: %d", request.RanName, len(update.ServedNrCellsToAdd), len(update.ServedNrCellsToModify), len(update.ServedNrCellsToDelete))
 
-		h.logger.Infof("#EndcConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling endc configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
+	nodebInfo, cause, ok := h.updateGnbCells(ctx, request.RanName, update)
+
+	if !ok {
+		packedFailure, ok := e2pdus.KnownCausesToEndcConfigurationUpdateFailurePDU(cause)
+
+		if !ok {
+			packedFailure = e2pdus.PackedEndcConfigurationUpdateFailure
+		}
+
+		h.sendFailure(ctx, request, packedFailure)
 		return
 	}
 
-	h.logger.Infof("#endc_configuration_update_handler.Handle - Endc configuration update initiating message received")
-	h.logger.Debugf("#endc_configuration_update_handler.Handle - Endc configuration update initiating message payload: %s", refinedMessage.PduPrint)
-	msg := models.NewRmrMessage(rmrCgo.RIC_ENDC_CONF_UPDATE_ACK, request.RanName, e2pdus.PackedEndcConfigurationUpdateAck, request.TransactionId, request.GetMsgSrc())
-	_ = h.rmrSender.Send(msg)
+	h.sendAck(ctx, request)
+
+	if len(update.ServedNrCellsToAdd) != 0 || len(update.ServedNrCellsToModify) != 0 || len(update.ServedNrCellsToDelete) != 0 {
+		_ = h.ranStatusChangeManager.Execute(ctx, rmrtypes.RAN_RECONFIGURED, enums.RAN_TO_RIC, nodebInfo)
+	}
+}
+
+func (h EndcConfigurationUpdateHandler) sendAck(ctx context.Context, request *models.NotificationRequest) {
+	msg := models.NewRmrMessage(rmrtypes.RIC_ENDC_CONF_UPDATE_ACK, request.RanName, e2pdus.PackedEndcConfigurationUpdateAck, request.TransactionId, request.GetMsgSrc())
+	_ = h.rmrSender.Send(ctx, msg)
 
 	h.logger.Infof("#EndcConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling endc configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
 }
+
+func (h EndcConfigurationUpdateHandler) sendFailure(ctx context.Context, request *models.NotificationRequest, packedFailure []byte) {
+	msg := models.NewRmrMessage(rmrtypes.RIC_ENDC_CONF_UPDATE_FAILURE, request.RanName, packedFailure, request.TransactionId, request.GetMsgSrc())
+	_ = h.rmrSender.Send(ctx, msg)
+
+	h.logger.Infof("#EndcConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling endc configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
+}
+
+// updateGnbCells applies the update to the served NR cells rNib holds for the gNB and returns the updated nodeb. On
+// failure it returns the cause to answer the gNB with
+func (h EndcConfigurationUpdateHandler) updateGnbCells(ctx context.Context, ranName string, update *models.EndcConfigurationUpdate) (*entities.NodebInfo, string, bool) {
+
+	nodebInfo, err := h.rNibDataService.GetNodeb(ctx, ranName)
+
+	if err != nil {
+		h.logger.Errorf("#EndcConfigurationUpdateHandler.updateGnbCells - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", ranName, err)
+
+		if _, ok := err.(*common.ResourceNotFoundError); ok {
+			return nil, EndcConfigurationUpdateUnknownGnbCause, false
+		}
+
+		return nil, EndcConfigurationUpdateRnibFailureCause, false
+	}
+
+	gnb := nodebInfo.GetGnb()
+
+	if gnb == nil {
+		h.logger.Errorf("#EndcConfigurationUpdateHandler.updateGnbCells - RAN name: %s - nodeb missing gnb configuration", ranName)
+		return nil, EndcConfigurationUpdateUnknownGnbCause, false
+	}
+
+	servedNrCells, removedNrCells := applyEndcConfigurationUpdate(gnb.ServedNrCells, update)
+	gnb.ServedNrCells = servedNrCells
+
+	// The keys of the removed cells go first, as a re-added or modified cell may reuse them
+	if len(removedNrCells) != 0 {
+		err = h.rNibDataService.RemoveServedNrCells(ctx, ranName, removedNrCells)
+
+		if err != nil {
+			h.logger.Errorf("#EndcConfigurationUpdateHandler.updateGnbCells - RAN name: %s - Failed removing served NR cells. Error: %s", ranName, err)
+			return nil, EndcConfigurationUpdateRnibFailureCause, false
+		}
+	}
+
+	err = h.rNibDataService.UpdateGnbCells(ctx, nodebInfo, servedNrCells)
+
+	if err != nil {
+		h.logger.Errorf("#EndcConfigurationUpdateHandler.updateGnbCells - RAN name: %s - Failed updating GNB cells. Error: %s", ranName, err)
+		return nil, EndcConfigurationUpdateRnibFailureCause, false
+	}
+
+	h.logger.Infof("#EndcConfigurationUpdateHandler.updateGnbCells - RAN name: %s - Successfully updated GNB cells, served nr cells: %d", ranName, len(servedNrCells))
+	return nodebInfo, "", true
+}
+
+// applyEndcConfigurationUpdate applies the update to the gNB's served NR cells, see servedCellsUpdate.apply. A modified
+// cell reported without NR neighbour information keeps the neighbours of the cell it replaces
+func applyEndcConfigurationUpdate(servedNrCells []*entities.ServedNRCell, update *models.EndcConfigurationUpdate) ([]*entities.ServedNRCell, []*entities.ServedNRCell) {
+	cellsUpdate := servedCellsUpdate{
+		cellsToAdd:    toServedNrCellInterfaces(update.ServedNrCellsToAdd),
+		cellsToDelete: update.ServedNrCellsToDelete,
+		cellId:        func(cell interface{}) string { return servedNrCellId(cell.(*entities.ServedNRCell)) },
+		pci:           func(cell interface{}) uint32 { return servedNrCellPci(cell.(*entities.ServedNRCell)) },
+	}
+
+	servedNrCellsById := make(map[string]*entities.ServedNRCell, len(servedNrCells))
+	for _, cell := range servedNrCells {
+		servedNrCellsById[servedNrCellId(cell)] = cell
+	}
+
+	for _, cell := range update.ServedNrCellsToModify {
+		if oldCell, ok := servedNrCellsById[cell.OldCellId]; ok && len(cell.ServedNrCell.GetNrNeighbourInfos()) == 0 {
+			cell.ServedNrCell.NrNeighbourInfos = oldCell.GetNrNeighbourInfos()
+		}
+
+		cellsUpdate.cellsToModify = append(cellsUpdate.cellsToModify, servedCellToModify{oldCellId: cell.OldCellId, cell: cell.ServedNrCell})
+	}
+
+	updatedCells, removedCells := cellsUpdate.apply(toServedNrCellInterfaces(servedNrCells))
+	return fromServedNrCellInterfaces(updatedCells), fromServedNrCellInterfaces(removedCells)
+}
+
+func toServedNrCellInterfaces(cells []*entities.ServedNRCell) []interface{} {
+	result := make([]interface{}, len(cells))
+	for i, cell := range cells {
+		result[i] = cell
+	}
+	return result
+}
+
+func fromServedNrCellInterfaces(cells []interface{}) []*entities.ServedNRCell {
+	var result []*entities.ServedNRCell
+	for _, cell := range cells {
+		result = append(result, cell.(*entities.ServedNRCell))
+	}
+	return result
+}
+
+func servedNrCellId(cell *entities.ServedNRCell) string {
+	return cell.GetServedNrCellInformation().GetCellId()
+}
+
+func servedNrCellPci(cell *entities.ServedNRCell) uint32 {
+	return cell.GetServedNrCellInformation().GetNrPci()
+}
@@ -20,10 +20,21 @@
 package rmrmsghandlers
 
 import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/converters"
+	"e2mgr/enums"
+	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
+	"e2mgr/services"
+	"encoding/json"
 	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 	"unsafe"
@@ -31,48 +42,263 @@ import (
 
 const PackedEndcConfigurationUpdateAck = "2025000a00000100f70003000000"
 const PackedEndcConfigurationUpdateFailure = "402500080000010005400142"
+const PackedEndcConfigurationUpdateRnibFailure = "402500080000010005400168"
+const PackedEndcConfigurationUpdateUnknownGnbFailure = "402500080000010005400146"
 
-func initEndcConfigurationUpdateHandlerTest(t *testing.T) (EndcConfigurationUpdateHandler, *mocks.RmrMessengerMock) {
+// Adds NR cell 0000000030 (PCI 3), moves NR cell 0000000010 from PCI 1 to PCI 10 and deletes NR cell 0000000020 (PCI 2)
+const PackedEndcConfigurationUpdate = "002500808200000100f5007b40000300fd003700004000030002f829000000003002f8294100099cf000004d002700010100000000010002f829000000001001014100099cf000004d000105002a00000002f8290000000014000a0002f82900000000100000010002f8294100099cf000004d00270001010106000b00000002f8290000000020"
+const PackedEndcConfigurationUpdateNoChanges = "0025000a00000100f50003400000"
+const PackedEndcConfigurationUpdateFromEnb = "0025000a00000100f50003000000"
+
+func initEndcConfigurationUpdateHandlerTest(t *testing.T) (EndcConfigurationUpdateHandler, *mocks.RmrMessengerMock, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
 	log := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
 	rmrSender := initRmrSender(rmrMessengerMock, log)
-	h := NewEndcConfigurationUpdateHandler(log, rmrSender)
-	return h, rmrMessengerMock
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	ranStatusChangeManager := managers.NewRanStatusChangeManager(log, rmrSender)
+	h := NewEndcConfigurationUpdateHandler(log, rmrSender, rnibDataService, ranStatusChangeManager, converters.NewEndcConfigurationUpdateExtractor(log), NewDecodeErrors())
+	return h, rmrMessengerMock, readerMock, writerMock
 }
 
-func TestHandleEndcConfigUpdateSuccess(t *testing.T) {
-	h, rmrMessengerMock := initEndcConfigurationUpdateHandlerTest(t)
-
-	ranName := "test"
+func createEndcConfigurationUpdateRequest(packedPdu string) (*models.NotificationRequest, []byte) {
 	xAction := []byte("123456aa")
+	var payload []byte
+	_, _ = fmt.Sscanf(packedPdu, "%x", &payload)
+	return &models.NotificationRequest{RanName: RanName, Len: len(payload), Payload: payload, StartTime: time.Now(), TransactionId: xAction}, xAction
+}
 
+func setupEndcConfigurationUpdateResponse(rmrMessengerMock *mocks.RmrMessengerMock, msgType int, packedPdu string, xAction []byte) *rmrtypes.MBuf {
 	var payload []byte
-	_, _ = fmt.Sscanf(PackedEndcConfigurationUpdateAck, "%x", &payload)
+	_, _ = fmt.Sscanf(packedPdu, "%x", &payload)
 	var msgSrc unsafe.Pointer
+	mBuf := rmrtypes.NewMBuf(msgType, len(payload), RanName, &payload, &xAction, msgSrc)
+	rmrMessengerMock.On("SendMsg", mBuf, true).Return(&rmrtypes.MBuf{}, nil)
+	return mBuf
+}
+
+func getRanReconfiguredMbuf(nodeType entities.Node_Type) *rmrtypes.MBuf {
+	var xAction []byte
+	resourceStatusPayload := models.NewResourceStatusPayload(nodeType, enums.RAN_TO_RIC)
+	resourceStatusJson, _ := json.Marshal(resourceStatusPayload)
+	var msgSrc unsafe.Pointer
+	return rmrtypes.NewMBuf(rmrtypes.RAN_RECONFIGURED, len(resourceStatusJson), RanName, &resourceStatusJson, &xAction, msgSrc)
+}
 
-	mBuf := rmrCgo.NewMBuf(rmrCgo.RIC_ENDC_CONF_UPDATE_ACK, len(payload), ranName, &payload, &xAction, msgSrc)
-	notificationRequest := models.NotificationRequest{RanName: mBuf.Meid, Len: mBuf.Len, Payload: *mBuf.Payload, StartTime: time.Now(),
-		TransactionId: *mBuf.XAction}
-	var err error
-	rmrMessengerMock.On("SendMsg", mBuf, true).Return(&rmrCgo.MBuf{}, err)
-	h.Handle(&notificationRequest)
+func servedNrCell(cellId string, pci uint32) *entities.ServedNRCell {
+	return &entities.ServedNRCell{ServedNrCellInformation: &entities.ServedNRCellInformation{CellId: cellId, NrPci: pci}}
+}
+
+func servedNrCellIdsAndPcis(cells []*entities.ServedNRCell) []string {
+	ids := []string{}
+	for _, cell := range cells {
+		ids = append(ids, fmt.Sprintf("%s/%d", servedNrCellId(cell), servedNrCellPci(cell)))
+	}
+	return ids
+}
+
+func nrCellsMatcher(expected ...string) interface{} {
+	return mock.MatchedBy(func(cells []*entities.ServedNRCell) bool {
+		return assert.ObjectsAreEqual(expected, servedNrCellIdsAndPcis(cells))
+	})
+}
+
+func generateGnbNodebInfo() *entities.NodebInfo {
+	return &entities.NodebInfo{
+		RanName:          RanName,
+		NodeType:         entities.Node_GNB,
+		ConnectionStatus: entities.ConnectionStatus_CONNECTED,
+		Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{ServedNrCells: []*entities.ServedNRCell{
+			servedNrCell("02f829:0000000010", 1),
+			servedNrCell("02f829:0000000020", 2),
+		}}},
+	}
+}
+
+func TestHandleEndcConfigUpdateSuccess(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest(PackedEndcConfigurationUpdate)
+	nodebInfo := generateGnbNodebInfo()
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	writerMock.On("RemoveServedNrCells", RanName, nrCellsMatcher("02f829:0000000010/1", "02f829:0000000020/2")).Return(nil)
+	writerMock.On("UpdateGnbCells", nodebInfo, nrCellsMatcher("02f829:0000000010/10", "02f829:0000000030/3")).Return(nil)
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_ACK, PackedEndcConfigurationUpdateAck, xAction)
+	ranReconfiguredMbuf := getRanReconfiguredMbuf(entities.Node_GNB)
+	rmrMessengerMock.On("SendMsg", ranReconfiguredMbuf, true).Return(&rmrtypes.MBuf{}, nil)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertExpectations(t)
+	assert.Equal(t, []string{"02f829:0000000010/10", "02f829:0000000030/3"}, servedNrCellIdsAndPcis(nodebInfo.GetGnb().ServedNrCells))
+	assert.Equal(t, "02f829:0000000010", nodebInfo.GetGnb().ServedNrCells[1].NrNeighbourInfos[0].NrCgi)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", ranReconfiguredMbuf, true)
+}
+
+func TestHandleEndcConfigUpdateNoChanges(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest(PackedEndcConfigurationUpdateNoChanges)
+	nodebInfo := generateGnbNodebInfo()
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateGnbCells", nodebInfo, nrCellsMatcher("02f829:0000000010/1", "02f829:0000000020/2")).Return(nil)
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_ACK, PackedEndcConfigurationUpdateAck, xAction)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertExpectations(t)
+	writerMock.AssertNotCalled(t, "RemoveServedNrCells", mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestHandleEndcConfigUpdateFromEnb(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest(PackedEndcConfigurationUpdateFromEnb)
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_ACK, PackedEndcConfigurationUpdateAck, xAction)
+
+	h.Handle(context.Background(), request)
+
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+	writerMock.AssertNotCalled(t, "UpdateGnbCells", mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
 }
 
 func TestHandleEndcConfigUpdateFailure(t *testing.T) {
-	h, rmrMessengerMock := initEndcConfigurationUpdateHandlerTest(t)
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest("00")
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_FAILURE, PackedEndcConfigurationUpdateFailure, xAction)
 
-	ranName := "test"
-	xAction := []byte("123456aa")
+	h.Handle(context.Background(), request)
 
-	var payload []byte
-	_, _ = fmt.Sscanf(PackedEndcConfigurationUpdateFailure, "%x", &payload)
-	var msgSrc unsafe.Pointer
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+	writerMock.AssertNotCalled(t, "UpdateGnbCells", mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+}
 
-	mBuf := rmrCgo.NewMBuf(rmrCgo.RIC_ENDC_CONF_UPDATE_FAILURE, len(payload), ranName, &payload, &xAction, msgSrc)
-	notificationRequest := models.NotificationRequest{RanName: mBuf.Meid, Len: 0, Payload: []byte{0}, StartTime: time.Now(),
-		TransactionId: *mBuf.XAction}
-	rmrMessengerMock.On("SendMsg", mBuf, true).Return(&rmrCgo.MBuf{}, fmt.Errorf("send failure"))
-	h.Handle(&notificationRequest)
+func TestHandleEndcConfigUpdateRanNotFound(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest(PackedEndcConfigurationUpdate)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_FAILURE, PackedEndcConfigurationUpdateUnknownGnbFailure, xAction)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertNotCalled(t, "UpdateGnbCells", mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestHandleEndcConfigUpdateNotAGnb(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest(PackedEndcConfigurationUpdate)
+	readerMock.On("GetNodeb", RanName).Return(generateEnbNodebInfo(), nil)
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_FAILURE, PackedEndcConfigurationUpdateUnknownGnbFailure, xAction)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertNotCalled(t, "UpdateGnbCells", mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestHandleEndcConfigUpdateGetNodebFailure(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest(PackedEndcConfigurationUpdate)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, common.NewInternalError(fmt.Errorf("internal error")))
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_FAILURE, PackedEndcConfigurationUpdateRnibFailure, xAction)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertNotCalled(t, "UpdateGnbCells", mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestHandleEndcConfigUpdateRemoveServedNrCellsFailure(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest(PackedEndcConfigurationUpdate)
+	readerMock.On("GetNodeb", RanName).Return(generateGnbNodebInfo(), nil)
+	writerMock.On("RemoveServedNrCells", RanName, mock.Anything).Return(common.NewInternalError(fmt.Errorf("internal error")))
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_FAILURE, PackedEndcConfigurationUpdateRnibFailure, xAction)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertNotCalled(t, "UpdateGnbCells", mock.Anything, mock.Anything)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestHandleEndcConfigUpdateUpdateGnbCellsFailure(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initEndcConfigurationUpdateHandlerTest(t)
+	request, xAction := createEndcConfigurationUpdateRequest(PackedEndcConfigurationUpdate)
+	readerMock.On("GetNodeb", RanName).Return(generateGnbNodebInfo(), nil)
+	writerMock.On("RemoveServedNrCells", RanName, mock.Anything).Return(nil)
+	writerMock.On("UpdateGnbCells", mock.Anything, mock.Anything).Return(common.NewInternalError(fmt.Errorf("internal error")))
+	mBuf := setupEndcConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENDC_CONF_UPDATE_FAILURE, PackedEndcConfigurationUpdateRnibFailure, xAction)
+
+	h.Handle(context.Background(), request)
+
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestApplyEndcConfigurationUpdate(t *testing.T) {
+	servedNrCells := []*entities.ServedNRCell{servedNrCell("cell1", 1), servedNrCell("cell2", 2), servedNrCell("cell3", 3)}
+	modifiedCell1 := servedNrCell("cell1", 1)
+	modifiedCell1.NrNeighbourInfos = []*entities.NrNeighbourInformation{{NrCgi: "cell3", NrPci: 30}}
+	update := &models.EndcConfigurationUpdate{
+		// cell3 is re-added on a new PCI, cell4 is new
+		ServedNrCellsToAdd: []*entities.ServedNRCell{servedNrCell("cell3", 30), servedNrCell("cell4", 4)},
+		// cell1 keeps its keys but gets a new neighbour, cell2 moves to a new cell id, cell5 is unknown and hence added
+		ServedNrCellsToModify: []*models.ServedNrCellToModify{
+			{OldCellId: "cell1", ServedNrCell: modifiedCell1},
+			{OldCellId: "cell2", ServedNrCell: servedNrCell("cell20", 20)},
+			{OldCellId: "cell5", ServedNrCell: servedNrCell("cell5", 5)},
+		},
+		ServedNrCellsToDelete: []string{"cell6"},
+	}
+
+	updatedCells, removedCells := applyEndcConfigurationUpdate(servedNrCells, update)
+
+	assert.Equal(t, []string{"cell1/1", "cell20/20", "cell3/30", "cell4/4", "cell5/5"}, servedNrCellIdsAndPcis(updatedCells))
+	assert.Equal(t, "cell3", updatedCells[0].NrNeighbourInfos[0].NrCgi)
+	assert.Equal(t, []string{"cell2/2", "cell3/3"}, servedNrCellIdsAndPcis(removedCells))
+	assert.Len(t, update.ServedNrCellsToAdd, 2)
+}
+
+func TestApplyEndcConfigurationUpdateNoChanges(t *testing.T) {
+	servedNrCells := []*entities.ServedNRCell{servedNrCell("cell1", 1)}
+
+	updatedCells, removedCells := applyEndcConfigurationUpdate(servedNrCells, &models.EndcConfigurationUpdate{})
+
+	assert.Equal(t, servedNrCells, updatedCells)
+	assert.Empty(t, removedCells)
+}
+
+func TestApplyEndcConfigurationUpdateKeepsNrNeighbours(t *testing.T) {
+	cell1 := servedNrCell("cell1", 1)
+	cell1.NrNeighbourInfos = []*entities.NrNeighbourInformation{{NrCgi: "cell2", NrPci: 2}}
+	cell2 := servedNrCell("cell2", 2)
+	cell2.NrNeighbourInfos = []*entities.NrNeighbourInformation{{NrCgi: "cell1", NrPci: 1}}
+	modifiedCell2 := servedNrCell("cell2", 2)
+	modifiedCell2.NrNeighbourInfos = []*entities.NrNeighbourInformation{{NrCgi: "cell3", NrPci: 3}}
+	update := &models.EndcConfigurationUpdate{
+		// cell1 moves to a new PCI and reports no neighbours, only the neighbours of cell2 change
+		ServedNrCellsToModify: []*models.ServedNrCellToModify{
+			{OldCellId: "cell1", ServedNrCell: servedNrCell("cell1", 10)},
+			{OldCellId: "cell2", ServedNrCell: modifiedCell2},
+		},
+	}
+
+	updatedCells, removedCells := applyEndcConfigurationUpdate([]*entities.ServedNRCell{cell1, cell2}, update)
+
+	assert.Equal(t, []string{"cell1/10", "cell2/2"}, servedNrCellIdsAndPcis(updatedCells))
+	assert.Equal(t, "cell2", updatedCells[0].NrNeighbourInfos[0].NrCgi)
+	assert.Equal(t, "cell3", updatedCells[1].NrNeighbourInfos[0].NrCgi)
+	assert.Equal(t, []string{"cell1/1"}, servedNrCellIdsAndPcis(removedCells))
 }
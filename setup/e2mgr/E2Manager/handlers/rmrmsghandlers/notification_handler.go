@@ -21,9 +21,10 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/models"
 )
 
 type NotificationHandler interface {
-	Handle(*models.NotificationRequest)
+	Handle(context.Context, *models.NotificationRequest)
 }
@@ -21,6 +21,7 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
@@ -37,11 +38,11 @@ func NewRanLostConnectionHandler(logger *logger.Logger, ranDisconnectionManager
 		ranDisconnectionManager: ranDisconnectionManager,
 	}
 }
-func (h RanLostConnectionHandler) Handle(request *models.NotificationRequest) {
+func (h RanLostConnectionHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
 
 	ranName := request.RanName
 
 	h.logger.Warnf("#RanLostConnectionHandler.Handle - RAN name: %s - Received lost connection notification", ranName)
 
-	_ = h.ranDisconnectionManager.DisconnectRan(ranName)
+	_ = h.ranDisconnectionManager.DisconnectRan(ctx, ranName)
 }
@@ -20,6 +20,7 @@ package rmrmsghandlers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/logger"
@@ -45,7 +46,7 @@ func TestLostConnectionHandlerSuccess(t *testing.T) {
 	ranDisconnectionManagerMock := &mocks.RanDisconnectionManagerMock{}
 	ranDisconnectionManagerMock.On("DisconnectRan", ranName).Return(nil)
 	handler := NewRanLostConnectionHandler(logger, ranDisconnectionManagerMock)
-	handler.Handle(&notificationRequest)
+	handler.Handle(context.Background(), &notificationRequest)
 	ranDisconnectionManagerMock.AssertCalled(t, "DisconnectRan", ranName)
 }
 
@@ -56,7 +57,7 @@ func TestLostConnectionHandlerFailure(t *testing.T) {
 	ranDisconnectionManagerMock := &mocks.RanDisconnectionManagerMock{}
 	ranDisconnectionManagerMock.On("DisconnectRan", ranName).Return(errors.New("error"))
 	handler := NewRanLostConnectionHandler(logger, ranDisconnectionManagerMock)
-	handler.Handle(&notificationRequest)
+	handler.Handle(context.Background(), &notificationRequest)
 	ranDisconnectionManagerMock.AssertCalled(t, "DisconnectRan", ranName)
 }
 
@@ -112,7 +113,7 @@ func TestLostConnectionHandlerFailureWithRealDisconnectionManager(t *testing.T)
 	handler, readerMock, writerMock, httpClientMock := setupLostConnectionHandlerTestWithRealDisconnectionManager(t, false)
 
 	notificationRequest := models.NotificationRequest{RanName: ranName}
-	handler.Handle(&notificationRequest)
+	handler.Handle(context.Background(), &notificationRequest)
 
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -124,7 +125,7 @@ func TestLostConnectionHandlerSuccessWithRealDisconnectionManager(t *testing.T)
 	handler, readerMock, writerMock, httpClientMock := setupLostConnectionHandlerTestWithRealDisconnectionManager(t, true)
 
 	notificationRequest := models.NotificationRequest{RanName: ranName}
-	handler.Handle(&notificationRequest)
+	handler.Handle(context.Background(), &notificationRequest)
 
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -0,0 +1,101 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrmsghandlers
+
+// servedCellsUpdate holds the served cell changes of an X2 eNB or EN-DC configuration update. The cells are
+// *entities.ServedCellInfo or *entities.ServedNRCell, their rNib keys are read through the cellId and pci accessors
+type servedCellsUpdate struct {
+	cellsToAdd    []interface{}
+	cellsToModify []servedCellToModify
+	cellsToDelete []string
+	cellId        func(cell interface{}) string
+	pci           func(cell interface{}) uint32
+}
+
+type servedCellToModify struct {
+	oldCellId string
+	cell      interface{}
+}
+
+// apply returns the served cells after the update, along with the cells whose cell id and PCI keys the update made
+// stale. A modified cell the RAN did not serve before, like an added one it already served, is served with the
+// reported information
+func (u servedCellsUpdate) apply(servedCells []interface{}) ([]interface{}, []interface{}) {
+	var removedCells []interface{}
+
+	cellsToDelete := make(map[string]bool, len(u.cellsToDelete))
+	for _, cellId := range u.cellsToDelete {
+		cellsToDelete[cellId] = true
+	}
+
+	cellsToModify := make(map[string]interface{}, len(u.cellsToModify))
+	for _, cell := range u.cellsToModify {
+		cellsToModify[cell.oldCellId] = cell.cell
+	}
+
+	replace := func(cell interface{}, newCell interface{}) interface{} {
+		if u.cellId(cell) != u.cellId(newCell) || u.pci(cell) != u.pci(newCell) {
+			removedCells = append(removedCells, cell)
+		}
+		return newCell
+	}
+
+	updatedCells := make([]interface{}, 0, len(servedCells)+len(u.cellsToAdd))
+
+	for _, cell := range servedCells {
+		if cellsToDelete[u.cellId(cell)] {
+			removedCells = append(removedCells, cell)
+			continue
+		}
+
+		if newCell, ok := cellsToModify[u.cellId(cell)]; ok {
+			delete(cellsToModify, u.cellId(cell))
+			cell = replace(cell, newCell)
+		}
+
+		updatedCells = append(updatedCells, cell)
+	}
+
+	cellsToAdd := append([]interface{}{}, u.cellsToAdd...)
+
+	for _, cell := range u.cellsToModify {
+		if _, ok := cellsToModify[cell.oldCellId]; ok {
+			cellsToAdd = append(cellsToAdd, cell.cell)
+		}
+	}
+
+	for _, newCell := range cellsToAdd {
+		added := false
+
+		for i, cell := range updatedCells {
+			if u.cellId(cell) == u.cellId(newCell) {
+				updatedCells[i] = replace(cell, newCell)
+				added = true
+				break
+			}
+		}
+
+		if !added {
+			updatedCells = append(updatedCells, newCell)
+		}
+	}
+
+	return updatedCells, removedCells
+}
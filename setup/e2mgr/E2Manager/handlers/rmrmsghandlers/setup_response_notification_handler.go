@@ -20,11 +20,12 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/enums"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/utils"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -36,32 +37,34 @@ type SetupResponseNotificationHandler struct {
 	setupResponseManager   managers.ISetupResponseManager
 	ranStatusChangeManager managers.IRanStatusChangeManager
 	msgType                int
+	decodeErrors           *DecodeErrors
 }
 
 var msgTypeToMsgName = map[int]string{
-	rmrCgo.RIC_X2_SETUP_RESP:         "X2 Setup Response",
-	rmrCgo.RIC_X2_SETUP_FAILURE:      "X2 Setup Failure Response",
-	rmrCgo.RIC_ENDC_X2_SETUP_RESP:    "ENDC Setup Response",
-	rmrCgo.RIC_ENDC_X2_SETUP_FAILURE: "ENDC Setup Failure Response",
+	rmrtypes.RIC_X2_SETUP_RESP:         "X2 Setup Response",
+	rmrtypes.RIC_X2_SETUP_FAILURE:      "X2 Setup Failure Response",
+	rmrtypes.RIC_ENDC_X2_SETUP_RESP:    "ENDC Setup Response",
+	rmrtypes.RIC_ENDC_X2_SETUP_FAILURE: "ENDC Setup Failure Response",
 }
 
-func NewSetupResponseNotificationHandler(logger *logger.Logger, rnibDataService services.RNibDataService, setupResponseManager managers.ISetupResponseManager, ranStatusChangeManager managers.IRanStatusChangeManager, msgType int) SetupResponseNotificationHandler {
+func NewSetupResponseNotificationHandler(logger *logger.Logger, rnibDataService services.RNibDataService, setupResponseManager managers.ISetupResponseManager, ranStatusChangeManager managers.IRanStatusChangeManager, msgType int, decodeErrors *DecodeErrors) SetupResponseNotificationHandler {
 	return SetupResponseNotificationHandler{
 		logger: logger,
 		rnibDataService:        rnibDataService,
 		setupResponseManager:   setupResponseManager,
 		ranStatusChangeManager: ranStatusChangeManager,
 		msgType:                msgType,
+		decodeErrors:           decodeErrors,
 	}
 }
 
-func (h SetupResponseNotificationHandler) Handle(request *models.NotificationRequest) {
+func (h SetupResponseNotificationHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
 	msgName := msgTypeToMsgName[h.msgType]
 	h.logger.Infof("#SetupResponseNotificationHandler - RAN name: %s - Received %s notification", request.RanName, msgName)
 
 	inventoryName := request.RanName
 
-	nodebInfo, rnibErr := h.rnibDataService.GetNodeb(inventoryName)
+	nodebInfo, rnibErr := h.rnibDataService.GetNodeb(ctx, inventoryName)
 
 	if rnibErr != nil {
 		h.logger.Errorf("#SetupResponseNotificationHandler - RAN name: %s - Error fetching RAN from rNib: %v", request.RanName, rnibErr)
@@ -77,10 +80,11 @@ func (h SetupResponseNotificationHandler) Handle(request *models.NotificationReq
 	err := h.setupResponseManager.PopulateNodebByPdu(h.logger, nbIdentity, nodebInfo, request.Payload)
 
 	if err != nil {
+		h.decodeErrors.Add(h.msgType)
 		return
 	}
 
-	rnibErr = h.rnibDataService.SaveNodeb(nbIdentity, nodebInfo)
+	rnibErr = h.rnibDataService.SaveNodeb(ctx, nbIdentity, nodebInfo)
 
 	if rnibErr != nil {
 		h.logger.Errorf("#SetupResponseNotificationHandler - RAN name: %s - Error saving RAN to rNib: %v", request.RanName, rnibErr)
@@ -94,7 +98,7 @@ func (h SetupResponseNotificationHandler) Handle(request *models.NotificationReq
 		return
 	}
 
-	_ = h.ranStatusChangeManager.Execute(rmrCgo.RAN_CONNECTED, enums.RIC_TO_RAN, nodebInfo)
+	_ = h.ranStatusChangeManager.Execute(ctx, rmrtypes.RAN_CONNECTED, enums.RIC_TO_RAN, nodebInfo)
 }
 
 func isConnectionStatusValid(connectionStatus entities.ConnectionStatus) bool {
@@ -102,5 +106,5 @@ func isConnectionStatusValid(connectionStatus entities.ConnectionStatus) bool {
 }
 
 func isSuccessSetupResponseMessage(msgType int) bool {
-	return msgType == rmrCgo.RIC_X2_SETUP_RESP || msgType == rmrCgo.RIC_ENDC_X2_SETUP_RESP
+	return msgType == rmrtypes.RIC_X2_SETUP_RESP || msgType == rmrtypes.RIC_ENDC_X2_SETUP_RESP
 }
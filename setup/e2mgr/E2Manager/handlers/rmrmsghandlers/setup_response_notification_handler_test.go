@@ -21,6 +21,7 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/converters"
 	"e2mgr/e2managererrors"
@@ -29,7 +30,7 @@ import (
 	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"encoding/json"
@@ -57,7 +58,7 @@ type setupSuccessResponseTestCase struct {
 	msgType              int
 	saveNodebMockError   error
 	sendMsgError         error
-	statusChangeMbuf     *rmrCgo.MBuf
+	statusChangeMbuf     *rmrtypes.MBuf
 }
 
 type setupFailureResponseTestCase struct {
@@ -106,9 +107,9 @@ func NewSetupResponseTestContext(manager managers.ISetupResponseManager) *setupR
 func TestSetupResponseGetNodebFailure(t *testing.T) {
 	notificationRequest := models.NotificationRequest{RanName: RanName}
 	testContext := NewSetupResponseTestContext(nil)
-	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, &managers.X2SetupResponseManager{}, testContext.ranStatusChangeManager, rmrCgo.RIC_X2_SETUP_RESP)
+	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, &managers.X2SetupResponseManager{}, testContext.ranStatusChangeManager, rmrtypes.RIC_X2_SETUP_RESP, NewDecodeErrors())
 	testContext.readerMock.On("GetNodeb", RanName).Return(&entities.NodebInfo{}, common.NewInternalError(errors.New("Error")))
-	handler.Handle(&notificationRequest)
+	handler.Handle(context.Background(), &notificationRequest)
 	testContext.readerMock.AssertCalled(t, "GetNodeb", RanName)
 	testContext.writerMock.AssertNotCalled(t, "SaveNodeb")
 	testContext.rmrMessengerMock.AssertNotCalled(t, "SendMsg")
@@ -118,10 +119,10 @@ func TestSetupResponseInvalidConnectionStatus(t *testing.T) {
 	ranName := "test"
 	notificationRequest := models.NotificationRequest{RanName: ranName}
 	testContext := NewSetupResponseTestContext(nil)
-	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, &managers.X2SetupResponseManager{}, testContext.ranStatusChangeManager, rmrCgo.RIC_X2_SETUP_RESP)
+	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, &managers.X2SetupResponseManager{}, testContext.ranStatusChangeManager, rmrtypes.RIC_X2_SETUP_RESP, NewDecodeErrors())
 	var rnibErr error
 	testContext.readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN}, rnibErr)
-	handler.Handle(&notificationRequest)
+	handler.Handle(context.Background(), &notificationRequest)
 	testContext.readerMock.AssertCalled(t, "GetNodeb", ranName)
 	testContext.writerMock.AssertNotCalled(t, "SaveNodeb")
 	testContext.rmrMessengerMock.AssertNotCalled(t, "SendMsg")
@@ -137,7 +138,7 @@ func executeHandleSetupSuccessResponse(t *testing.T, tc setupSuccessResponseTest
 	notificationRequest := models.NotificationRequest{RanName: RanName, Payload: payload}
 	testContext := NewSetupResponseTestContext(tc.setupResponseManager)
 
-	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, testContext.setupResponseManager, testContext.ranStatusChangeManager, tc.msgType)
+	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, testContext.setupResponseManager, testContext.ranStatusChangeManager, tc.msgType, NewDecodeErrors())
 
 	var rnibErr error
 
@@ -150,18 +151,18 @@ func executeHandleSetupSuccessResponse(t *testing.T, tc setupSuccessResponseTest
 
 	testContext.readerMock.On("GetNodeb", RanName).Return(nodebInfo, rnibErr)
 	testContext.writerMock.On("SaveNodeb", mock.Anything, mock.Anything).Return(tc.saveNodebMockError)
-	testContext.rmrMessengerMock.On("SendMsg", tc.statusChangeMbuf, true).Return(&rmrCgo.MBuf{}, tc.sendMsgError)
-	handler.Handle(&notificationRequest)
+	testContext.rmrMessengerMock.On("SendMsg", tc.statusChangeMbuf, true).Return(&rmrtypes.MBuf{}, tc.sendMsgError)
+	handler.Handle(context.Background(), &notificationRequest)
 
 	return testContext, nodebInfo
 }
 
-func getRanConnectedMbuf(nodeType entities.Node_Type) *rmrCgo.MBuf {
+func getRanConnectedMbuf(nodeType entities.Node_Type) *rmrtypes.MBuf {
 	var xAction []byte
 	resourceStatusPayload := models.NewResourceStatusPayload(nodeType, enums.RIC_TO_RAN)
 	resourceStatusJson, _ := json.Marshal(resourceStatusPayload)
 	var msgSrc unsafe.Pointer
-	return rmrCgo.NewMBuf(rmrCgo.RAN_CONNECTED, len(resourceStatusJson), RanName, &resourceStatusJson, &xAction, msgSrc)
+	return rmrtypes.NewMBuf(rmrtypes.RAN_CONNECTED, len(resourceStatusJson), RanName, &resourceStatusJson, &xAction, msgSrc)
 }
 
 func executeHandleSetupFailureResponse(t *testing.T, tc setupFailureResponseTestCase) (*setupResponseTestContext, *entities.NodebInfo) {
@@ -174,7 +175,7 @@ func executeHandleSetupFailureResponse(t *testing.T, tc setupFailureResponseTest
 	notificationRequest := models.NotificationRequest{RanName: RanName, Payload: payload}
 	testContext := NewSetupResponseTestContext(tc.setupResponseManager)
 
-	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, testContext.setupResponseManager, testContext.ranStatusChangeManager, tc.msgType)
+	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, testContext.setupResponseManager, testContext.ranStatusChangeManager, tc.msgType, NewDecodeErrors())
 
 	var rnibErr error
 
@@ -187,7 +188,7 @@ func executeHandleSetupFailureResponse(t *testing.T, tc setupFailureResponseTest
 
 	testContext.readerMock.On("GetNodeb", RanName).Return(nodebInfo, rnibErr)
 	testContext.writerMock.On("SaveNodeb", mock.Anything, mock.Anything).Return(tc.saveNodebMockError)
-	handler.Handle(&notificationRequest)
+	handler.Handle(context.Background(), &notificationRequest)
 
 	return testContext, nodebInfo
 }
@@ -199,7 +200,7 @@ func TestX2SetupResponse(t *testing.T) {
 	tc := setupSuccessResponseTestCase{
 		X2SetupResponsePackedPdu,
 		managers.NewX2SetupResponseManager(converters.NewX2SetupResponseConverter(logger)),
-		rmrCgo.RIC_X2_SETUP_RESP,
+		rmrtypes.RIC_X2_SETUP_RESP,
 		saveNodebMockError,
 		sendMsgError,
 		getRanConnectedMbuf(entities.Node_ENB),
@@ -223,7 +224,7 @@ func TestX2SetupFailureResponse(t *testing.T) {
 	tc := setupFailureResponseTestCase{
 		X2SetupFailureResponsePackedPdu,
 		managers.NewX2SetupFailureResponseManager(converters.NewX2SetupFailureResponseConverter(logger)),
-		rmrCgo.RIC_X2_SETUP_FAILURE,
+		rmrtypes.RIC_X2_SETUP_FAILURE,
 		saveNodebMockError,
 	}
 
@@ -243,7 +244,7 @@ func TestEndcSetupResponse(t *testing.T) {
 	tc := setupSuccessResponseTestCase{
 		EndcSetupResponsePackedPdu,
 		managers.NewEndcSetupResponseManager(converters.NewEndcSetupResponseConverter(logger)),
-		rmrCgo.RIC_ENDC_X2_SETUP_RESP,
+		rmrtypes.RIC_ENDC_X2_SETUP_RESP,
 		saveNodebMockError,
 		sendMsgError,
 		getRanConnectedMbuf(entities.Node_GNB),
@@ -267,7 +268,7 @@ func TestEndcSetupFailureResponse(t *testing.T) {
 	tc := setupFailureResponseTestCase{
 		EndcSetupFailureResponsePackedPdu,
 		managers.NewEndcSetupFailureResponseManager(converters.NewEndcSetupFailureResponseConverter(logger)),
-		rmrCgo.RIC_ENDC_X2_SETUP_FAILURE,
+		rmrtypes.RIC_ENDC_X2_SETUP_FAILURE,
 		saveNodebMockError,
 	}
 
@@ -285,13 +286,14 @@ func TestSetupResponseInvalidPayload(t *testing.T) {
 	ranName := "test"
 	notificationRequest := models.NotificationRequest{RanName: ranName, Payload: []byte("123")}
 	testContext := NewSetupResponseTestContext(nil)
-	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, managers.NewX2SetupResponseManager(converters.NewX2SetupResponseConverter(logger)), testContext.ranStatusChangeManager, rmrCgo.RIC_X2_SETUP_RESP)
+	handler := NewSetupResponseNotificationHandler(testContext.logger, testContext.rnibDataService, managers.NewX2SetupResponseManager(converters.NewX2SetupResponseConverter(logger)), testContext.ranStatusChangeManager, rmrtypes.RIC_X2_SETUP_RESP, NewDecodeErrors())
 	var rnibErr error
 	testContext.readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTING}, rnibErr)
-	handler.Handle(&notificationRequest)
+	handler.Handle(context.Background(), &notificationRequest)
 	testContext.readerMock.AssertCalled(t, "GetNodeb", ranName)
 	testContext.writerMock.AssertNotCalled(t, "SaveNodeb")
 	testContext.rmrMessengerMock.AssertNotCalled(t, "SendMsg")
+	assert.Equal(t, map[int]int{rmrtypes.RIC_X2_SETUP_RESP: 1}, handler.decodeErrors.Counts())
 }
 
 func TestSetupResponseSaveNodebFailure(t *testing.T) {
@@ -301,7 +303,7 @@ func TestSetupResponseSaveNodebFailure(t *testing.T) {
 	tc := setupSuccessResponseTestCase{
 		X2SetupResponsePackedPdu,
 		managers.NewX2SetupResponseManager(converters.NewX2SetupResponseConverter(logger)),
-		rmrCgo.RIC_X2_SETUP_RESP,
+		rmrtypes.RIC_X2_SETUP_RESP,
 		saveNodebMockError,
 		sendMsgError,
 		getRanConnectedMbuf(entities.Node_ENB),
@@ -320,7 +322,7 @@ func TestSetupResponseStatusChangeSendFailure(t *testing.T) {
 	tc := setupSuccessResponseTestCase{
 		X2SetupResponsePackedPdu,
 		managers.NewX2SetupResponseManager(converters.NewX2SetupResponseConverter(logger)),
-		rmrCgo.RIC_X2_SETUP_RESP,
+		rmrtypes.RIC_X2_SETUP_RESP,
 		saveNodebMockError,
 		sendMsgError,
 		getRanConnectedMbuf(entities.Node_ENB),
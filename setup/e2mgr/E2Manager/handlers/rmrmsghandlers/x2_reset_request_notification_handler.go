@@ -26,12 +26,13 @@ package rmrmsghandlers
 // #include <x2reset_response_wrapper.h>
 import "C"
 import (
+	"context"
 	"e2mgr/e2pdus"
 	"e2mgr/enums"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/utils"
@@ -54,11 +55,11 @@ func NewX2ResetRequestNotificationHandler(logger *logger.Logger, rnibDataService
 	}
 }
 
-func (h X2ResetRequestNotificationHandler) Handle(request *models.NotificationRequest) {
+func (h X2ResetRequestNotificationHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
 
 	h.logger.Infof("#X2ResetRequestNotificationHandler.Handle - Ran name: %s", request.RanName)
 
-	nb, rNibErr := h.rnibDataService.GetNodeb(request.RanName)
+	nb, rNibErr := h.rnibDataService.GetNodeb(ctx, request.RanName)
 	if rNibErr != nil {
 		h.logger.Errorf("#X2ResetRequestNotificationHandler.Handle - failed to retrieve nodeB entity. RanName: %s. Error: %s", request.RanName, rNibErr.Error())
 		h.logger.Infof("#X2ResetRequestNotificationHandler.Handle - Summary: elapsed time for receiving and handling reset request message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
@@ -79,9 +80,9 @@ func (h X2ResetRequestNotificationHandler) Handle(request *models.NotificationRe
 		return
 	}
 
-	msg := models.NewRmrMessage(rmrCgo.RIC_X2_RESET_RESP, request.RanName, e2pdus.PackedX2ResetResponse, request.TransactionId, request.GetMsgSrc())
+	msg := models.NewRmrMessage(rmrtypes.RIC_X2_RESET_RESP, request.RanName, e2pdus.PackedX2ResetResponse, request.TransactionId, request.GetMsgSrc())
 
-	_ = h.rmrSender.Send(msg)
+	_ = h.rmrSender.Send(ctx, msg)
 	h.logger.Infof("#X2ResetRequestNotificationHandler.Handle - Summary: elapsed time for receiving and handling reset request message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
-	_ = h.ranStatusChangeManager.Execute(rmrCgo.RAN_RESTARTED, enums.RAN_TO_RIC, nb)
+	_ = h.ranStatusChangeManager.Execute(ctx, rmrtypes.RAN_RESTARTED, enums.RAN_TO_RIC, nb)
 }
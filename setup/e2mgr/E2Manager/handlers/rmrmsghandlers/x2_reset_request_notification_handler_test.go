@@ -21,13 +21,14 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2pdus"
 	"e2mgr/enums"
 	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/tests"
 	"encoding/json"
@@ -52,12 +53,12 @@ func initX2ResetRequestNotificationHandlerTest(t *testing.T) (X2ResetRequestNoti
 	return h, readerMock, rmrMessengerMock
 }
 
-func getRanRestartedMbuf(nodeType entities.Node_Type, messageDirection enums.MessageDirection) *rmrCgo.MBuf {
+func getRanRestartedMbuf(nodeType entities.Node_Type, messageDirection enums.MessageDirection) *rmrtypes.MBuf {
 	var xAction []byte
 	resourceStatusPayload := models.NewResourceStatusPayload(nodeType, messageDirection)
 	resourceStatusJson, _ := json.Marshal(resourceStatusPayload)
 	var msgSrc unsafe.Pointer
-	return rmrCgo.NewMBuf(rmrCgo.RAN_RESTARTED, len(resourceStatusJson), RanName, &resourceStatusJson, &xAction, msgSrc)
+	return rmrtypes.NewMBuf(rmrtypes.RAN_RESTARTED, len(resourceStatusJson), RanName, &resourceStatusJson, &xAction, msgSrc)
 }
 
 func TestHandleX2ResetRequestNotificationSuccess(t *testing.T) {
@@ -70,11 +71,11 @@ func TestHandleX2ResetRequestNotificationSuccess(t *testing.T) {
 	var err error
 	readerMock.On("GetNodeb", ranName).Return(nb, err)
 	var msgSrc unsafe.Pointer
-	resetResponseMbuf := rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET_RESP, len(e2pdus.PackedX2ResetResponse), ranName, &e2pdus.PackedX2ResetResponse, &xAction, msgSrc)
-	rmrMessengerMock.On("SendMsg", resetResponseMbuf, true).Return(&rmrCgo.MBuf{}, err)
+	resetResponseMbuf := rmrtypes.NewMBuf(rmrtypes.RIC_X2_RESET_RESP, len(e2pdus.PackedX2ResetResponse), ranName, &e2pdus.PackedX2ResetResponse, &xAction, msgSrc)
+	rmrMessengerMock.On("SendMsg", resetResponseMbuf, true).Return(&rmrtypes.MBuf{}, err)
 	ranRestartedMbuf := getRanRestartedMbuf(nb.NodeType, enums.RAN_TO_RIC)
-	rmrMessengerMock.On("SendMsg", ranRestartedMbuf, true).Return(&rmrCgo.MBuf{}, err)
-	h.Handle(notificationRequest)
+	rmrMessengerMock.On("SendMsg", ranRestartedMbuf, true).Return(&rmrtypes.MBuf{}, err)
+	h.Handle(context.Background(), notificationRequest)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", resetResponseMbuf, true)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", ranRestartedMbuf, true)
 }
@@ -85,7 +86,7 @@ func TestHandleX2ResetRequestNotificationShuttingDownStatus(t *testing.T) {
 
 	xAction := []byte("123456aa")
 	var msgSrc unsafe.Pointer
-	mBuf := rmrCgo.NewMBuf(tests.MessageType, len(payload), "RanName", &payload, &xAction, msgSrc)
+	mBuf := rmrtypes.NewMBuf(tests.MessageType, len(payload), "RanName", &payload, &xAction, msgSrc)
 	notificationRequest := models.NotificationRequest{RanName: mBuf.Meid, Len: mBuf.Len, Payload: *mBuf.Payload,
 		StartTime: time.Now(), TransactionId: xAction}
 
@@ -94,7 +95,7 @@ func TestHandleX2ResetRequestNotificationShuttingDownStatus(t *testing.T) {
 
 	readerMock.On("GetNodeb", mBuf.Meid).Return(nb, rnibErr)
 
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
 
@@ -103,13 +104,13 @@ func TestHandleX2ResetRequestNotificationDisconnectStatus(t *testing.T) {
 	var payload []byte
 	xAction := []byte("123456aa")
 	var msgSrc unsafe.Pointer
-	mBuf := rmrCgo.NewMBuf(tests.MessageType, len(payload), "RanName", &payload, &xAction, msgSrc)
+	mBuf := rmrtypes.NewMBuf(tests.MessageType, len(payload), "RanName", &payload, &xAction, msgSrc)
 	notificationRequest := models.NotificationRequest{RanName: mBuf.Meid, Len: mBuf.Len, Payload: *mBuf.Payload, StartTime: time.Now(), TransactionId: xAction}
 	nb := &entities.NodebInfo{RanName: mBuf.Meid, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED,}
 	var rnibErr error
 	readerMock.On("GetNodeb", mBuf.Meid).Return(nb, rnibErr)
 
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
 
@@ -119,7 +120,7 @@ func TestHandleX2ResetRequestNotificationGetNodebFailed(t *testing.T) {
 	var payload []byte
 	var xAction []byte
 	var msgSrc unsafe.Pointer
-	mBuf := rmrCgo.NewMBuf(tests.MessageType, len(payload), "RanName", &payload, &xAction, msgSrc)
+	mBuf := rmrtypes.NewMBuf(tests.MessageType, len(payload), "RanName", &payload, &xAction, msgSrc)
 	notificationRequest := models.NotificationRequest{RanName: mBuf.Meid, Len: mBuf.Len, Payload: *mBuf.Payload,
 		StartTime: time.Now(), TransactionId: xAction}
 
@@ -127,6 +128,6 @@ func TestHandleX2ResetRequestNotificationGetNodebFailed(t *testing.T) {
 	rnibErr := &common.ResourceNotFoundError{}
 	readerMock.On("GetNodeb", mBuf.Meid).Return(nb, rnibErr)
 
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
@@ -25,12 +25,13 @@ package rmrmsghandlers
 // #include <asn1codec_utils.h>
 import "C"
 import (
+	"context"
 	"e2mgr/converters"
 	"e2mgr/enums"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/utils"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -41,22 +42,24 @@ type X2ResetResponseHandler struct {
 	rnibDataService        services.RNibDataService
 	ranStatusChangeManager managers.IRanStatusChangeManager
 	extractor              converters.IX2ResetResponseExtractor
+	decodeErrors           *DecodeErrors
 }
 
-func NewX2ResetResponseHandler(logger *logger.Logger, rnibDataService services.RNibDataService, ranStatusChangeManager managers.IRanStatusChangeManager, x2ResetResponseExtractor converters.IX2ResetResponseExtractor) X2ResetResponseHandler {
+func NewX2ResetResponseHandler(logger *logger.Logger, rnibDataService services.RNibDataService, ranStatusChangeManager managers.IRanStatusChangeManager, x2ResetResponseExtractor converters.IX2ResetResponseExtractor, decodeErrors *DecodeErrors) X2ResetResponseHandler {
 	return X2ResetResponseHandler{
 		logger:                 logger,
 		rnibDataService:        rnibDataService,
 		ranStatusChangeManager: ranStatusChangeManager,
 		extractor:              x2ResetResponseExtractor,
+		decodeErrors:           decodeErrors,
 	}
 }
 
-func (h X2ResetResponseHandler) Handle(request *models.NotificationRequest) {
+func (h X2ResetResponseHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
 	ranName := request.RanName
 	h.logger.Infof("#X2ResetResponseHandler.Handle - RAN name: %s - received reset response. Payload: %x", ranName, request.Payload)
 
-	nodebInfo, err := h.rnibDataService.GetNodeb(ranName)
+	nodebInfo, err := h.rnibDataService.GetNodeb(ctx, ranName)
 	if err != nil {
 		h.logger.Errorf("#x2ResetResponseHandler.Handle - RAN name: %s - failed to retrieve nodebInfo entity. Error: %s", ranName, err)
 		return
@@ -82,7 +85,7 @@ func (h X2ResetResponseHandler) Handle(request *models.NotificationRequest) {
 		return
 	}
 
-	_ = h.ranStatusChangeManager.Execute(rmrCgo.RAN_RESTARTED, enums.RIC_TO_RAN, nodebInfo)
+	_ = h.ranStatusChangeManager.Execute(ctx, rmrtypes.RAN_RESTARTED, enums.RIC_TO_RAN, nodebInfo)
 }
 
 func (h X2ResetResponseHandler) isSuccessfulResetResponse(ranName string, packedBuffer []byte) (bool, error) {
@@ -91,6 +94,7 @@ func (h X2ResetResponseHandler) isSuccessfulResetResponse(ranName string, packed
 
 	if err != nil {
 		h.logger.Errorf("#X2ResetResponseHandler.isSuccessfulResetResponse - RAN name: %s - Failed extracting pdu: %s", ranName, err)
+		h.decodeErrors.Add(rmrtypes.RIC_X2_RESET_RESP)
 		return false, err
 	}
 
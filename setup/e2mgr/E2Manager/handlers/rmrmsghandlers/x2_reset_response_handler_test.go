@@ -21,6 +21,7 @@
 package rmrmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/converters"
 	"e2mgr/enums"
@@ -28,7 +29,7 @@ import (
 	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
@@ -57,7 +58,7 @@ func initX2ResetResponseHandlerTest(t *testing.T) (X2ResetResponseHandler, *mock
 	rmrSender := initRmrSender(rmrMessengerMock, log)
 	ranStatusChangeManager := managers.NewRanStatusChangeManager(log, rmrSender)
 
-	h := NewX2ResetResponseHandler(log, rnibDataService, ranStatusChangeManager, converters.NewX2ResetResponseExtractor(log))
+	h := NewX2ResetResponseHandler(log, rnibDataService, ranStatusChangeManager, converters.NewX2ResetResponseExtractor(log), NewDecodeErrors())
 	return h, readerMock, rmrMessengerMock
 }
 
@@ -75,8 +76,8 @@ func TestX2ResetResponseSuccess(t *testing.T) {
 	var rnibErr error
 	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
 	ranRestartedMbuf := getRanRestartedMbuf(nb.NodeType, enums.RIC_TO_RAN)
-	rmrMessengerMock.On("SendMsg", ranRestartedMbuf, true).Return(&rmrCgo.MBuf{}, err)
-	h.Handle(&notificationRequest)
+	rmrMessengerMock.On("SendMsg", ranRestartedMbuf, true).Return(&rmrtypes.MBuf{}, err)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", ranRestartedMbuf, true)
 }
 
@@ -94,8 +95,8 @@ func TestX2ResetResponseSuccessEmptyIEs(t *testing.T) {
 	var rnibErr error
 	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
 	ranRestartedMbuf := getRanRestartedMbuf(nb.NodeType, enums.RIC_TO_RAN)
-	rmrMessengerMock.On("SendMsg", ranRestartedMbuf, true).Return(&rmrCgo.MBuf{}, err)
-	h.Handle(&notificationRequest)
+	rmrMessengerMock.On("SendMsg", ranRestartedMbuf, true).Return(&rmrtypes.MBuf{}, err)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", ranRestartedMbuf, true)
 }
 
@@ -112,7 +113,7 @@ func TestX2ResetResponseShuttingDown(t *testing.T) {
 	nb := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_SHUTTING_DOWN, NodeType: entities.Node_ENB}
 	var rnibErr error
 	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
 
@@ -129,7 +130,7 @@ func TestX2ResetResponseInvalidConnectionStatus(t *testing.T) {
 	nb := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED, NodeType: entities.Node_ENB}
 	var rnibErr error
 	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
 
@@ -146,7 +147,7 @@ func TestX2ResetResponseError(t *testing.T) {
 	nb := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED, NodeType: entities.Node_ENB}
 	var rnibErr error
 	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
 
@@ -166,7 +167,7 @@ func TestX2ResetResponseGetNodebFailure(t *testing.T) {
 	rnibErr := common.NewResourceNotFoundError("nodeb not found")
 	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
 
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
 
@@ -180,6 +181,6 @@ func TestX2ResetResponseUnpackFailure(t *testing.T) {
 	var rnibErr error
 	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
 
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), &notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
@@ -26,46 +26,156 @@ package rmrmsghandlers
 // #include <configuration_update_wrapper.h>
 import "C"
 import (
+	"context"
 	"e2mgr/converters"
 	"e2mgr/e2pdus"
 	"e2mgr/logger"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
+	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/utils"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+const (
+	// Answers an update which cannot be persisted to rNib
+	X2EnbConfigurationUpdateRnibFailureCause = "misc:unspecified"
+	// Answers an update from a RAN which rNib does not hold as an eNB
+	X2EnbConfigurationUpdateUnknownEnbCause = "protocol:message-not-compatible-with-receiver-state"
 )
 
 type X2EnbConfigurationUpdateHandler struct {
-	logger    *logger.Logger
-	rmrSender *rmrsender.RmrSender
+	logger          *logger.Logger
+	rmrSender       *rmrsender.RmrSender
+	rNibDataService services.RNibDataService
+	extractor       converters.IEnbConfigurationUpdateExtractor
+	decodeErrors    *DecodeErrors
 }
 
-func NewX2EnbConfigurationUpdateHandler(logger *logger.Logger, rmrSender *rmrsender.RmrSender) X2EnbConfigurationUpdateHandler {
+func NewX2EnbConfigurationUpdateHandler(logger *logger.Logger, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, extractor converters.IEnbConfigurationUpdateExtractor, decodeErrors *DecodeErrors) X2EnbConfigurationUpdateHandler {
 	return X2EnbConfigurationUpdateHandler{
-		logger:    logger,
-		rmrSender: rmrSender,
+		logger:          logger,
+		rmrSender:       rmrSender,
+		rNibDataService: rNibDataService,
+		extractor:       extractor,
+		decodeErrors:    decodeErrors,
 	}
 }
 
-func (h X2EnbConfigurationUpdateHandler) Handle(request *models.NotificationRequest) {
+func (h X2EnbConfigurationUpdateHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
 
-	refinedMessage, err := converters.UnpackX2apPduAndRefine(h.logger, e2pdus.MaxAsn1CodecAllocationBufferSize, request.Len, request.Payload, e2pdus.MaxAsn1CodecMessageBufferSize)
+	update, err := h.extractor.ExtractEnbConfigurationUpdate(request.Payload)
 
 	if err != nil {
-		h.logger.Errorf("#x2enb_configuration_update_handler.Handle - unpack failed. Error: %v", err)
+		h.logger.Errorf("#x2enb_configuration_update_handler.Handle - RAN name: %s - unpack failed. Error: %v", request.RanName, err)
+		h.decodeErrors.Add(rmrtypes.RIC_ENB_CONF_UPDATE)
+		h.sendFailure(ctx, request, e2pdus.PackedX2EnbConfigurationUpdateFailure)
+		return
+	}
+
+	h.logger.Infof("#x2enb_configuration_update_handler.Handle - RAN name: %s - Enb configuration update initiating message received - served cells to add: %d, to modify: %d, to delete: %d", request.RanName, len(update.ServedCellsToAdd), len(update.ServedCellsToModify), len(update.ServedCellsToDelete))
+
+	if cause, ok := h.updateEnbCells(ctx, request.RanName, update); !ok {
+		packedFailure, ok := e2pdus.KnownCausesToX2EnbConfigurationUpdateFailurePDU(cause)
 
-		msg := models.NewRmrMessage(rmrCgo.RIC_ENB_CONFIGURATION_UPDATE_FAILURE, request.RanName, e2pdus.PackedX2EnbConfigurationUpdateFailure, request.TransactionId, request.GetMsgSrc())
-		_ = h.rmrSender.Send(msg)
+		if !ok {
+			packedFailure = e2pdus.PackedX2EnbConfigurationUpdateFailure
+		}
 
-		h.logger.Infof("#X2EnbConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling enb configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
+		h.sendFailure(ctx, request, packedFailure)
 		return
 	}
 
-	h.logger.Infof("#x2enb_configuration_update_handler.Handle - Enb configuration update initiating message received")
-	h.logger.Debugf("#x2enb_configuration_update_handler.Handle - Enb configuration update initiating message payload: %s", refinedMessage.PduPrint)
+	msg := models.NewRmrMessage(rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_ACK, request.RanName, e2pdus.PackedX2EnbConfigurationUpdateAck, request.TransactionId, request.GetMsgSrc())
+	_ = h.rmrSender.Send(ctx, msg)
 
-	msg := models.NewRmrMessage(rmrCgo.RIC_ENB_CONFIGURATION_UPDATE_ACK, request.RanName, e2pdus.PackedX2EnbConfigurationUpdateAck,request.TransactionId, request.GetMsgSrc())
-	_ = h.rmrSender.Send(msg)
+	h.logger.Infof("#X2EnbConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling enb configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
+}
+
+func (h X2EnbConfigurationUpdateHandler) sendFailure(ctx context.Context, request *models.NotificationRequest, packedFailure []byte) {
+	msg := models.NewRmrMessage(rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_FAILURE, request.RanName, packedFailure, request.TransactionId, request.GetMsgSrc())
+	_ = h.rmrSender.Send(ctx, msg)
 
 	h.logger.Infof("#X2EnbConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling enb configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
 }
+
+// updateEnbCells applies the update to the served cells rNib holds for the eNB. On failure it returns the cause to
+// answer the eNB with
+func (h X2EnbConfigurationUpdateHandler) updateEnbCells(ctx context.Context, ranName string, update *models.EnbConfigurationUpdate) (string, bool) {
+
+	nodebInfo, err := h.rNibDataService.GetNodeb(ctx, ranName)
+
+	if err != nil {
+		h.logger.Errorf("#X2EnbConfigurationUpdateHandler.updateEnbCells - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", ranName, err)
+
+		if _, ok := err.(*common.ResourceNotFoundError); ok {
+			return X2EnbConfigurationUpdateUnknownEnbCause, false
+		}
+
+		return X2EnbConfigurationUpdateRnibFailureCause, false
+	}
+
+	enb := nodebInfo.GetEnb()
+
+	if enb == nil {
+		h.logger.Errorf("#X2EnbConfigurationUpdateHandler.updateEnbCells - RAN name: %s - nodeb missing enb configuration", ranName)
+		return X2EnbConfigurationUpdateUnknownEnbCause, false
+	}
+
+	servedCells, removedCells := applyEnbConfigurationUpdate(enb.ServedCells, update)
+	enb.ServedCells = servedCells
+
+	// The served cells are written before the keys of the removed ones are, so a failure in between leaves stale keys
+	// rather than served cells rNib cannot be queried by
+	err = h.rNibDataService.PatchEnbCells(ctx, nodebInfo, servedCells, removedCells)
+
+	if err != nil {
+		h.logger.Errorf("#X2EnbConfigurationUpdateHandler.updateEnbCells - RAN name: %s - Failed updating ENB cells. Error: %s", ranName, err)
+		return X2EnbConfigurationUpdateRnibFailureCause, false
+	}
+
+	// The served cells are updated, leftover load information of the removed ones does not fail the update
+	err = h.rNibDataService.RemoveCellLoadInformation(ctx, ranName, servedCells, removedCells)
+
+	if err != nil {
+		h.logger.Errorf("#X2EnbConfigurationUpdateHandler.updateEnbCells - RAN name: %s - Failed removing load information of removed cells. Error: %s", ranName, err)
+	}
+
+	h.logger.Infof("#X2EnbConfigurationUpdateHandler.updateEnbCells - RAN name: %s - Successfully updated ENB cells, served cells: %d", ranName, len(servedCells))
+	return "", true
+}
+
+// applyEnbConfigurationUpdate applies the update to the eNB's served cells, see servedCellsUpdate.apply
+func applyEnbConfigurationUpdate(servedCells []*entities.ServedCellInfo, update *models.EnbConfigurationUpdate) ([]*entities.ServedCellInfo, []*entities.ServedCellInfo) {
+	cellsUpdate := servedCellsUpdate{
+		cellsToAdd:    toServedCellInterfaces(update.ServedCellsToAdd),
+		cellsToDelete: update.ServedCellsToDelete,
+		cellId:        func(cell interface{}) string { return cell.(*entities.ServedCellInfo).GetCellId() },
+		pci:           func(cell interface{}) uint32 { return cell.(*entities.ServedCellInfo).GetPci() },
+	}
+
+	for _, cell := range update.ServedCellsToModify {
+		cellsUpdate.cellsToModify = append(cellsUpdate.cellsToModify, servedCellToModify{oldCellId: cell.OldCellId, cell: cell.ServedCellInfo})
+	}
+
+	updatedCells, removedCells := cellsUpdate.apply(toServedCellInterfaces(servedCells))
+	return fromServedCellInterfaces(updatedCells), fromServedCellInterfaces(removedCells)
+}
+
+func toServedCellInterfaces(cells []*entities.ServedCellInfo) []interface{} {
+	result := make([]interface{}, len(cells))
+	for i, cell := range cells {
+		result[i] = cell
+	}
+	return result
+}
+
+func fromServedCellInterfaces(cells []interface{}) []*entities.ServedCellInfo {
+	var result []*entities.ServedCellInfo
+	for _, cell := range cells {
+		result = append(result, cell.(*entities.ServedCellInfo))
+	}
+	return result
+}
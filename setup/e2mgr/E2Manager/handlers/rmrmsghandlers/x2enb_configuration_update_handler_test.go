@@ -20,10 +20,18 @@
 package rmrmsghandlers
 
 import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/converters"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
+	"e2mgr/services"
 	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 	"unsafe"
@@ -31,47 +39,192 @@ import (
 
 const PackedX2EnbConfigurationUpdateAck = "200800080000010011400100"
 const PackedX2EnbConfigurationUpdateFailure = "400800080000010005400142"
+const PackedX2EnbConfigurationUpdateRnibFailure = "400800080000010005400168"
+const PackedX2EnbConfigurationUpdateUnknownEnbFailure = "400800080000010005400146"
 
-func initX2EnbConfigurationUpdateHandlerTest(t *testing.T) (X2EnbConfigurationUpdateHandler, *mocks.RmrMessengerMock) {
+// Adds cell 0007ad50 (PCI 101), moves cell 0007ab50 from PCI 99 to PCI 98 and deletes cell 0007ac50 (PCI 100)
+const PackedX2EnbConfigurationUpdate = "0008005b00000300190025004000650002f8290007ad50103002f82900000300033300010002f8290007ab5000620002001a001e000002f8290007ab5000620002f8290007ab50102002f829000002000233001b0009000002f8290007ac50"
+
+func initX2EnbConfigurationUpdateHandlerTest(t *testing.T) (X2EnbConfigurationUpdateHandler, *mocks.RmrMessengerMock, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
 	log := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
 	rmrSender := initRmrSender(rmrMessengerMock, log)
-	h := NewX2EnbConfigurationUpdateHandler(log, rmrSender)
-	return h, rmrMessengerMock
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	h := NewX2EnbConfigurationUpdateHandler(log, rmrSender, rnibDataService, converters.NewEnbConfigurationUpdateExtractor(log), NewDecodeErrors())
+	return h, rmrMessengerMock, readerMock, writerMock
 }
 
-func TestHandleX2EnbConfigUpdateSuccess(t *testing.T) {
-	h, rmrMessengerMock := initX2EnbConfigurationUpdateHandlerTest(t)
-
-	ranName := "test"
+func createX2EnbConfigurationUpdateRequest(packedPdu string) (*models.NotificationRequest, []byte) {
 	xAction := []byte("123456aa")
 	var payload []byte
-	_, _ = fmt.Sscanf(PackedX2EnbConfigurationUpdateAck, "%x", &payload)
+	_, _ = fmt.Sscanf(packedPdu, "%x", &payload)
+	return &models.NotificationRequest{RanName: RanName, Len: len(payload), Payload: payload, StartTime: time.Now(), TransactionId: xAction}, xAction
+}
+
+func setupX2EnbConfigurationUpdateResponse(rmrMessengerMock *mocks.RmrMessengerMock, msgType int, packedPdu string, xAction []byte) *rmrtypes.MBuf {
+	var payload []byte
+	_, _ = fmt.Sscanf(packedPdu, "%x", &payload)
 	var msgSrc unsafe.Pointer
+	mBuf := rmrtypes.NewMBuf(msgType, len(payload), RanName, &payload, &xAction, msgSrc)
+	rmrMessengerMock.On("SendMsg", mBuf, true).Return(&rmrtypes.MBuf{}, nil)
+	return mBuf
+}
 
-	mBuf := rmrCgo.NewMBuf(rmrCgo.RIC_ENB_CONFIGURATION_UPDATE_ACK, len(payload), ranName, &payload, &xAction, msgSrc)
-	notificationRequest := models.NotificationRequest{RanName: mBuf.Meid, Len: mBuf.Len, Payload: *mBuf.Payload,
-		StartTime: time.Now(), TransactionId:xAction}
-	var err error
-	rmrMessengerMock.On("SendMsg", mBuf, true).Return(&rmrCgo.MBuf{}, err)
-	h.Handle(&notificationRequest)
+func servedCell(cellId string, pci uint32) *entities.ServedCellInfo {
+	return &entities.ServedCellInfo{CellId: cellId, Pci: pci}
+}
+
+func servedCellIdsAndPcis(cells []*entities.ServedCellInfo) []string {
+	ids := []string{}
+	for _, cell := range cells {
+		ids = append(ids, fmt.Sprintf("%s/%d", cell.GetCellId(), cell.GetPci()))
+	}
+	return ids
+}
+
+func cellsMatcher(expected ...string) interface{} {
+	return mock.MatchedBy(func(cells []*entities.ServedCellInfo) bool {
+		return assert.ObjectsAreEqual(expected, servedCellIdsAndPcis(cells))
+	})
+}
+
+func generateEnbNodebInfo() *entities.NodebInfo {
+	return &entities.NodebInfo{
+		RanName:          RanName,
+		NodeType:         entities.Node_ENB,
+		ConnectionStatus: entities.ConnectionStatus_CONNECTED,
+		Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: []*entities.ServedCellInfo{
+			servedCell("02f829:0007ab50", 99),
+			servedCell("02f829:0007ac50", 100),
+		}}},
+	}
+}
+
+func TestHandleX2EnbConfigUpdateSuccess(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initX2EnbConfigurationUpdateHandlerTest(t)
+	request, xAction := createX2EnbConfigurationUpdateRequest(PackedX2EnbConfigurationUpdate)
+	nodebInfo := generateEnbNodebInfo()
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	writerMock.On("PatchEnbCells", nodebInfo, cellsMatcher("02f829:0007ab50/98", "02f829:0007ad50/101"), cellsMatcher("02f829:0007ab50/99", "02f829:0007ac50/100")).Return(nil)
+	writerMock.On("RemoveCellLoadInformation", RanName, cellsMatcher("02f829:0007ab50/98", "02f829:0007ad50/101"), cellsMatcher("02f829:0007ab50/99", "02f829:0007ac50/100")).Return(nil)
+	mBuf := setupX2EnbConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_ACK, PackedX2EnbConfigurationUpdateAck, xAction)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertExpectations(t)
+	assert.Equal(t, []string{"02f829:0007ab50/98", "02f829:0007ad50/101"}, servedCellIdsAndPcis(nodebInfo.GetEnb().ServedCells))
+	assert.Equal(t, "02f829:0007ab50", nodebInfo.GetEnb().ServedCells[1].NeighbourInfos[0].Ecgi)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
 }
 
 func TestHandleX2EnbConfigUpdateFailure(t *testing.T) {
-	h, rmrMessengerMock := initX2EnbConfigurationUpdateHandlerTest(t)
+	h, rmrMessengerMock, readerMock, writerMock := initX2EnbConfigurationUpdateHandlerTest(t)
+	request, xAction := createX2EnbConfigurationUpdateRequest("00")
+	mBuf := setupX2EnbConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_FAILURE, PackedX2EnbConfigurationUpdateFailure, xAction)
 
-	ranName := "test"
-	xAction := []byte("123456aa")
+	h.Handle(context.Background(), request)
 
-	var payload []byte
-	_, _ = fmt.Sscanf(PackedX2EnbConfigurationUpdateFailure, "%x", &payload)
-	var msgSrc unsafe.Pointer
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+	writerMock.AssertNotCalled(t, "PatchEnbCells", mock.Anything, mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+}
+
+func TestHandleX2EnbConfigUpdateRanNotFound(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initX2EnbConfigurationUpdateHandlerTest(t)
+	request, xAction := createX2EnbConfigurationUpdateRequest(PackedX2EnbConfigurationUpdate)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+	mBuf := setupX2EnbConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_FAILURE, PackedX2EnbConfigurationUpdateUnknownEnbFailure, xAction)
 
-	mBuf := rmrCgo.NewMBuf(rmrCgo.RIC_ENB_CONFIGURATION_UPDATE_FAILURE, len(payload), ranName, &payload, &xAction, msgSrc)
-	notificationRequest := models.NotificationRequest{RanName: mBuf.Meid, Len: 0, Payload: []byte{0},
-		StartTime: time.Now(), TransactionId:xAction}
-	rmrMessengerMock.On("SendMsg", mBuf, true).Return(&rmrCgo.MBuf{}, fmt.Errorf("send failure"))
-	h.Handle(&notificationRequest)
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertNotCalled(t, "PatchEnbCells", mock.Anything, mock.Anything, mock.Anything)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
 }
+
+func TestHandleX2EnbConfigUpdateNotAnEnb(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initX2EnbConfigurationUpdateHandlerTest(t)
+	request, xAction := createX2EnbConfigurationUpdateRequest(PackedX2EnbConfigurationUpdate)
+	readerMock.On("GetNodeb", RanName).Return(&entities.NodebInfo{RanName: RanName, NodeType: entities.Node_GNB, Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{}}}, nil)
+	mBuf := setupX2EnbConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_FAILURE, PackedX2EnbConfigurationUpdateUnknownEnbFailure, xAction)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertNotCalled(t, "PatchEnbCells", mock.Anything, mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+}
+
+func TestHandleX2EnbConfigUpdateGetNodebFailure(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initX2EnbConfigurationUpdateHandlerTest(t)
+	request, xAction := createX2EnbConfigurationUpdateRequest(PackedX2EnbConfigurationUpdate)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, common.NewInternalError(fmt.Errorf("internal error")))
+	mBuf := setupX2EnbConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_FAILURE, PackedX2EnbConfigurationUpdateRnibFailure, xAction)
+
+	h.Handle(context.Background(), request)
+
+	writerMock.AssertNotCalled(t, "PatchEnbCells", mock.Anything, mock.Anything, mock.Anything)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+}
+
+func TestHandleX2EnbConfigUpdatePatchEnbCellsFailure(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initX2EnbConfigurationUpdateHandlerTest(t)
+	request, xAction := createX2EnbConfigurationUpdateRequest(PackedX2EnbConfigurationUpdate)
+	readerMock.On("GetNodeb", RanName).Return(generateEnbNodebInfo(), nil)
+	writerMock.On("PatchEnbCells", mock.Anything, mock.Anything, mock.Anything).Return(common.NewInternalError(fmt.Errorf("internal error")))
+	mBuf := setupX2EnbConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_FAILURE, PackedX2EnbConfigurationUpdateRnibFailure, xAction)
+
+	h.Handle(context.Background(), request)
+
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	writerMock.AssertNotCalled(t, "RemoveCellLoadInformation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleX2EnbConfigUpdateRemoveCellLoadInformationFailure(t *testing.T) {
+	h, rmrMessengerMock, readerMock, writerMock := initX2EnbConfigurationUpdateHandlerTest(t)
+	request, xAction := createX2EnbConfigurationUpdateRequest(PackedX2EnbConfigurationUpdate)
+	readerMock.On("GetNodeb", RanName).Return(generateEnbNodebInfo(), nil)
+	writerMock.On("PatchEnbCells", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	writerMock.On("RemoveCellLoadInformation", RanName, mock.Anything, mock.Anything).Return(common.NewInternalError(fmt.Errorf("internal error")))
+	mBuf := setupX2EnbConfigurationUpdateResponse(rmrMessengerMock, rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_ACK, PackedX2EnbConfigurationUpdateAck, xAction)
+
+	h.Handle(context.Background(), request)
+
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mBuf, true)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestApplyEnbConfigurationUpdate(t *testing.T) {
+	servedCells := []*entities.ServedCellInfo{servedCell("cell1", 1), servedCell("cell2", 2), servedCell("cell3", 3)}
+	update := &models.EnbConfigurationUpdate{
+		// cell3 is re-added on a new PCI, cell4 is new
+		ServedCellsToAdd: []*entities.ServedCellInfo{servedCell("cell3", 30), servedCell("cell4", 4)},
+		// cell1 keeps its keys, cell2 moves to a new cell id, cell5 is unknown and hence added
+		ServedCellsToModify: []*models.ServedCellToModify{
+			{OldCellId: "cell1", ServedCellInfo: &entities.ServedCellInfo{CellId: "cell1", Pci: 1, Tac: "0102"}},
+			{OldCellId: "cell2", ServedCellInfo: servedCell("cell20", 20)},
+			{OldCellId: "cell5", ServedCellInfo: servedCell("cell5", 5)},
+		},
+		ServedCellsToDelete: []string{"cell6"},
+	}
+
+	updatedCells, removedCells := applyEnbConfigurationUpdate(servedCells, update)
+
+	assert.Equal(t, []string{"cell1/1", "cell20/20", "cell3/30", "cell4/4", "cell5/5"}, servedCellIdsAndPcis(updatedCells))
+	assert.Equal(t, "0102", updatedCells[0].Tac)
+	assert.Equal(t, []string{"cell2/2", "cell3/3"}, servedCellIdsAndPcis(removedCells))
+	assert.Len(t, update.ServedCellsToAdd, 2)
+}
+
+func TestApplyEnbConfigurationUpdateNoChanges(t *testing.T) {
+	servedCells := []*entities.ServedCellInfo{servedCell("cell1", 1)}
+
+	updatedCells, removedCells := applyEnbConfigurationUpdate(servedCells, &models.EnbConfigurationUpdate{})
+
+	assert.Equal(t, servedCells, updatedCells)
+	assert.Empty(t, removedCells)
+}
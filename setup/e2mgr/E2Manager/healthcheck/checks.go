@@ -0,0 +1,180 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package healthcheck
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/rmrtypes"
+	"e2mgr/services"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+const (
+	RnibCheckName              = "rnib"
+	RmrCheckName               = "rmr"
+	RoutingManagerCheckName    = "routingManager"
+	KeepAliveCheckName         = "keepAlive"
+	NotificationQueueCheckName = "notificationQueue"
+
+	keepAliveMaxAgeFactor = 3
+)
+
+type KeepAliveWorker interface {
+	LastExecution() time.Time
+	Delay() time.Duration
+}
+
+type NotificationQueue interface {
+	InFlight() int
+	DecodeErrors() map[int]int
+}
+
+// NewE2ManagerHealthChecker registers the checks of every E2 Manager dependency.
+// Only the keep alive loop is part of the liveness report - the other dependencies are external and a restart would not fix them
+func NewE2ManagerHealthChecker(logger *logger.Logger, config *configuration.Configuration, rnibDataService services.RNibDataService,
+	rmrMessenger rmrtypes.RmrMessenger, keepAliveWorker KeepAliveWorker, notificationQueue NotificationQueue) *HealthChecker {
+
+	h := NewHealthChecker(logger, config)
+	h.Register(RnibCheckName, true, NewRnibChecker(rnibDataService))
+	h.Register(RmrCheckName, true, NewRmrChecker(rmrMessenger))
+	h.Register(RoutingManagerCheckName, false, NewRoutingManagerChecker(config.RoutingManager.BaseUrl, h.timeout))
+	h.RegisterLiveness(KeepAliveCheckName, true, NewKeepAliveChecker(keepAliveWorker, time.Duration(config.Health.KeepAliveMaxAgeMs)*time.Millisecond))
+	h.Register(NotificationQueueCheckName, false, NewNotificationQueueChecker(notificationQueue, config.Health.NotificationQueueMaxInFlight))
+	return h
+}
+
+func NewRnibChecker(rnibDataService services.RNibDataService) Checker {
+	return CheckerFunc(func() error {
+		if !rnibDataService.PingRnib() {
+			return errors.New("rNib is unreachable")
+		}
+		return nil
+	})
+}
+
+func NewRmrChecker(rmrMessenger rmrtypes.RmrMessenger) Checker {
+	return CheckerFunc(func() error {
+		if !rmrMessenger.IsReady() {
+			return errors.New("RMR routing table is not ready")
+		}
+		return nil
+	})
+}
+
+// NewRoutingManagerChecker verifies that a TCP connection to the Routing Manager can be opened
+func NewRoutingManagerChecker(baseUrl string, timeout time.Duration) Checker {
+	return CheckerFunc(func() error {
+		address, err := hostPort(baseUrl)
+
+		if err != nil {
+			return err
+		}
+
+		conn, err := net.DialTimeout("tcp", address, timeout)
+
+		if err != nil {
+			return fmt.Errorf("Routing Manager is unreachable: %s", err)
+		}
+
+		return conn.Close()
+	})
+}
+
+// NewKeepAliveChecker fails once the keep alive loop has not run for maxAge. The limit is re-evaluated against the worker's
+// current delay on every check, so a reloaded keepAliveDelayMs above maxAge falls back to a multiple of the new delay
+func NewKeepAliveChecker(keepAliveWorker KeepAliveWorker, maxAge time.Duration) Checker {
+	return CheckerFunc(func() error {
+		lastExecution := keepAliveWorker.LastExecution()
+		maxAge := keepAliveMaxAge(maxAge, keepAliveWorker.Delay())
+
+		if lastExecution.IsZero() {
+			return errors.New("keep alive loop has not started")
+		}
+
+		if age := time.Since(lastExecution); age > maxAge {
+			return fmt.Errorf("keep alive loop stalled, last execution %s ago", age.Round(time.Millisecond))
+		}
+
+		return nil
+	})
+}
+
+type notificationQueueChecker struct {
+	notificationQueue NotificationQueue
+	maxInFlight       int
+}
+
+// NewNotificationQueueChecker fails once maxInFlight notifications are being handled at the same time, 0 disables the limit.
+// The decode errors of the notification handlers are reported as details of the check, they do not fail it
+func NewNotificationQueueChecker(notificationQueue NotificationQueue, maxInFlight int) Checker {
+	return &notificationQueueChecker{notificationQueue: notificationQueue, maxInFlight: maxInFlight}
+}
+
+func (c *notificationQueueChecker) Check() error {
+	if c.maxInFlight <= 0 {
+		return nil
+	}
+
+	inFlight := c.notificationQueue.InFlight()
+
+	if inFlight >= c.maxInFlight {
+		return fmt.Errorf("notification queue saturated: %d notifications in flight, limit is %d", inFlight, c.maxInFlight)
+	}
+
+	return nil
+}
+
+func (c *notificationQueueChecker) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"inFlight":     c.notificationQueue.InFlight(),
+		"decodeErrors": c.notificationQueue.DecodeErrors(),
+	}
+}
+
+func keepAliveMaxAge(configuredMaxAge time.Duration, delay time.Duration) time.Duration {
+	if configuredMaxAge > delay {
+		return configuredMaxAge
+	}
+
+	return keepAliveMaxAgeFactor * delay
+}
+
+func hostPort(baseUrl string) (string, error) {
+	u, err := url.Parse(baseUrl)
+
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid Routing Manager url: %q", baseUrl)
+	}
+
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+
+	if u.Scheme == "https" {
+		return net.JoinHostPort(u.Hostname(), "443"), nil
+	}
+
+	return net.JoinHostPort(u.Hostname(), "80"), nil
+}
@@ -0,0 +1,188 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package healthcheck
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/rmrtypes"
+	"e2mgr/services"
+	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type keepAliveWorkerStub struct {
+	lastExecution time.Time
+	delay         time.Duration
+}
+
+func (w keepAliveWorkerStub) LastExecution() time.Time {
+	return w.lastExecution
+}
+
+func (w keepAliveWorkerStub) Delay() time.Duration {
+	return w.delay
+}
+
+type notificationQueueStub struct {
+	inFlight     int
+	decodeErrors map[int]int
+}
+
+func (q notificationQueueStub) InFlight() int {
+	return q.inFlight
+}
+
+func (q notificationQueueStub) DecodeErrors() map[int]int {
+	return q.decodeErrors
+}
+
+func TestRnibChecker(t *testing.T) {
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	readerMock := &mocks.RnibReaderMock{}
+	rnibDataService := services.NewRnibDataService(initLog(t), config, readerMock, &mocks.RnibWriterMock{})
+	var nbList []*entities.NbIdentity
+	readerMock.On("GetListNodebIds").Return(nbList, nil).Once()
+	readerMock.On("GetListNodebIds").Return(nbList, &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}})
+
+	checker := NewRnibChecker(rnibDataService)
+
+	assert.Nil(t, checker.Check())
+	assert.NotNil(t, checker.Check())
+}
+
+func TestRmrChecker(t *testing.T) {
+	rmrMessengerMock := &mocks.RmrMessengerMock{}
+	rmrMessengerMock.On("IsReady").Return(true).Once()
+	rmrMessengerMock.On("IsReady").Return(false)
+
+	checker := NewRmrChecker(rmrMessengerMock)
+
+	assert.Nil(t, checker.Check())
+	assert.NotNil(t, checker.Check())
+}
+
+func TestRoutingManagerCheckerReachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	checker := NewRoutingManagerChecker(server.URL+"/ric/v1/handles/", time.Second)
+
+	assert.Nil(t, checker.Check())
+}
+
+func TestRoutingManagerCheckerUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	baseUrl := server.URL + "/ric/v1/handles/"
+	server.Close()
+
+	checker := NewRoutingManagerChecker(baseUrl, time.Second)
+
+	assert.NotNil(t, checker.Check())
+}
+
+func TestRoutingManagerCheckerInvalidUrl(t *testing.T) {
+	checker := NewRoutingManagerChecker("ric/v1/handles/", time.Second)
+
+	assert.NotNil(t, checker.Check())
+}
+
+func TestHostPort(t *testing.T) {
+	address, err := hostPort("http://10.0.2.15:12020/ric/v1/handles/")
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.2.15:12020", address)
+
+	address, err = hostPort("http://service-ricplt-rtmgr-http/ric/v1/handles/")
+	assert.Nil(t, err)
+	assert.Equal(t, "service-ricplt-rtmgr-http:80", address)
+
+	address, err = hostPort("https://service-ricplt-rtmgr-http/ric/v1/handles/")
+	assert.Nil(t, err)
+	assert.Equal(t, "service-ricplt-rtmgr-http:443", address)
+}
+
+func TestKeepAliveChecker(t *testing.T) {
+	maxAge := 3 * time.Second
+
+	assert.Nil(t, NewKeepAliveChecker(keepAliveWorkerStub{time.Now(), time.Second}, maxAge).Check())
+	assert.NotNil(t, NewKeepAliveChecker(keepAliveWorkerStub{time.Now().Add(-4 * time.Second), time.Second}, maxAge).Check())
+	assert.NotNil(t, NewKeepAliveChecker(keepAliveWorkerStub{}, maxAge).Check())
+}
+
+func TestKeepAliveCheckerFollowsReloadedDelay(t *testing.T) {
+	maxAge := 3 * time.Second
+
+	assert.Nil(t, NewKeepAliveChecker(keepAliveWorkerStub{time.Now().Add(-4 * time.Second), 5 * time.Second}, maxAge).Check())
+	assert.NotNil(t, NewKeepAliveChecker(keepAliveWorkerStub{time.Now().Add(-16 * time.Second), 5 * time.Second}, maxAge).Check())
+}
+
+func TestNotificationQueueChecker(t *testing.T) {
+	assert.Nil(t, NewNotificationQueueChecker(notificationQueueStub{inFlight: 99}, 100).Check())
+	assert.NotNil(t, NewNotificationQueueChecker(notificationQueueStub{inFlight: 100}, 100).Check())
+	assert.Nil(t, NewNotificationQueueChecker(notificationQueueStub{inFlight: 100}, 0).Check())
+}
+
+func TestNotificationQueueCheckerReportsDecodeErrors(t *testing.T) {
+	decodeErrors := map[int]int{rmrtypes.RIC_X2_SETUP_RESP: 2}
+	checker := NewNotificationQueueChecker(notificationQueueStub{inFlight: 3, decodeErrors: decodeErrors}, 100)
+
+	details := checker.(DetailsReporter).Details()
+	assert.Equal(t, 3, details["inFlight"])
+	assert.Equal(t, decodeErrors, details["decodeErrors"])
+}
+
+func TestKeepAliveMaxAge(t *testing.T) {
+	assert.Equal(t, 4500*time.Millisecond, keepAliveMaxAge(0, 1500*time.Millisecond))
+	assert.Equal(t, 10*time.Second, keepAliveMaxAge(10*time.Second, 1500*time.Millisecond))
+	assert.Equal(t, 15*time.Second, keepAliveMaxAge(4500*time.Millisecond, 5*time.Second))
+}
+
+func TestNewE2ManagerHealthChecker(t *testing.T) {
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, KeepAliveDelayMs: 1500, NotificationResponseBuffer: 100}
+	config.RoutingManager.BaseUrl = "http://10.0.2.15:12020/ric/v1/handles/"
+	config.Health.CheckTimeoutMs = 50
+	readerMock := &mocks.RnibReaderMock{}
+	var nbList []*entities.NbIdentity
+	readerMock.On("GetListNodebIds").Return(nbList, nil)
+	rnibDataService := services.NewRnibDataService(initLog(t), config, readerMock, &mocks.RnibWriterMock{})
+	rmrMessengerMock := &mocks.RmrMessengerMock{}
+	rmrMessengerMock.On("IsReady").Return(true)
+
+	h := NewE2ManagerHealthChecker(initLog(t), config, rnibDataService, rmrMessengerMock, keepAliveWorkerStub{time.Now(), time.Second}, notificationQueueStub{})
+
+	live := h.Live()
+	assert.Equal(t, models.HealthStatusUp, live.Status)
+	assert.Len(t, live.Checks, 1)
+
+	ready := h.Ready()
+	assert.Len(t, ready.Checks, 5)
+	for _, check := range ready.Checks {
+		assert.Equal(t, check.Name == RoutingManagerCheckName || check.Name == NotificationQueueCheckName, !check.Critical)
+		assert.Equal(t, check.Name == NotificationQueueCheckName, check.Details != nil)
+	}
+}
@@ -0,0 +1,176 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package healthcheck
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultCheckTimeoutMs = 1000
+
+type Checker interface {
+	Check() error
+}
+
+// DetailsReporter is implemented by checkers that report figures along with their status
+type DetailsReporter interface {
+	Details() map[string]interface{}
+}
+
+type CheckerFunc func() error
+
+func (f CheckerFunc) Check() error {
+	return f()
+}
+
+type IHealthChecker interface {
+	Live() *models.HealthCheckResponse
+	Ready() *models.HealthCheckResponse
+}
+
+type registeredCheck struct {
+	name     string
+	critical bool
+	liveness bool
+	checker  Checker
+}
+
+type HealthChecker struct {
+	logger  *logger.Logger
+	config  *configuration.Configuration
+	timeout time.Duration
+	checks  []*registeredCheck
+}
+
+func NewHealthChecker(logger *logger.Logger, config *configuration.Configuration) *HealthChecker {
+	timeoutMs := config.Health.CheckTimeoutMs
+
+	if timeoutMs <= 0 {
+		timeoutMs = defaultCheckTimeoutMs
+	}
+
+	return &HealthChecker{
+		logger:  logger,
+		config:  config,
+		timeout: time.Duration(timeoutMs) * time.Millisecond,
+	}
+}
+
+// Register adds a readiness check. critical is used unless the configuration sets health.critical.<name>
+func (h *HealthChecker) Register(name string, critical bool, checker Checker) {
+	h.register(name, critical, false, checker)
+}
+
+// RegisterLiveness adds a check that is part of both the liveness and the readiness report
+func (h *HealthChecker) RegisterLiveness(name string, critical bool, checker Checker) {
+	h.register(name, critical, true, checker)
+}
+
+func (h *HealthChecker) register(name string, critical bool, liveness bool, checker Checker) {
+	if configured, ok := h.config.Health.Critical[strings.ToLower(name)]; ok {
+		critical = configured
+	}
+
+	h.checks = append(h.checks, &registeredCheck{name: name, critical: critical, liveness: liveness, checker: checker})
+}
+
+func (h *HealthChecker) Live() *models.HealthCheckResponse {
+	var checks []*registeredCheck
+
+	for _, check := range h.checks {
+		if check.liveness {
+			checks = append(checks, check)
+		}
+	}
+
+	return h.run(checks)
+}
+
+func (h *HealthChecker) Ready() *models.HealthCheckResponse {
+	return h.run(h.checks)
+}
+
+func (h *HealthChecker) run(checks []*registeredCheck) *models.HealthCheckResponse {
+	channels := make([]chan *models.HealthCheckResult, len(checks))
+
+	for i, check := range checks {
+		channels[i] = make(chan *models.HealthCheckResult, 1)
+		go h.runCheck(check, channels[i])
+	}
+
+	results := make([]*models.HealthCheckResult, len(checks))
+	deadline := time.NewTimer(h.timeout)
+	defer deadline.Stop()
+	expired := false
+
+	for i, check := range checks {
+		if !expired {
+			select {
+			case results[i] = <-channels[i]:
+				continue
+			case <-deadline.C:
+				expired = true
+			}
+		}
+
+		// The deadline is shared, once it passes only checks that have already completed are reported as such
+		select {
+		case results[i] = <-channels[i]:
+		default:
+			results[i] = h.newResult(check, fmt.Errorf("timed out after %s", h.timeout), h.timeout)
+		}
+	}
+
+	return models.NewHealthCheckResponse(results)
+}
+
+func (h *HealthChecker) runCheck(check *registeredCheck, result chan<- *models.HealthCheckResult) {
+	start := time.Now()
+	err := check.checker.Check()
+	checkResult := h.newResult(check, err, time.Since(start))
+
+	if reporter, ok := check.checker.(DetailsReporter); ok {
+		checkResult.Details = reporter.Details()
+	}
+
+	result <- checkResult
+}
+
+func (h *HealthChecker) newResult(check *registeredCheck, err error, duration time.Duration) *models.HealthCheckResult {
+	result := &models.HealthCheckResult{
+		Name:       check.name,
+		Status:     models.HealthStatusUp,
+		Critical:   check.critical,
+		DurationMs: duration.Milliseconds(),
+	}
+
+	if err != nil {
+		h.logger.Warnf("#HealthChecker.newResult - check %s failed: %s", check.name, err)
+		result.Status = models.HealthStatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
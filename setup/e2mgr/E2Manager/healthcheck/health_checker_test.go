@@ -0,0 +1,136 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package healthcheck
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func initLog(t *testing.T) *logger.Logger {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#tests.initLog - failed to initialize logger, error: %s", err)
+	}
+	return log
+}
+
+func succeeding() Checker {
+	return CheckerFunc(func() error { return nil })
+}
+
+func failing() Checker {
+	return CheckerFunc(func() error { return errors.New("error") })
+}
+
+func TestReadyAllChecksUp(t *testing.T) {
+	h := NewHealthChecker(initLog(t), &configuration.Configuration{})
+	h.Register(RnibCheckName, true, succeeding())
+	h.Register(RoutingManagerCheckName, false, succeeding())
+
+	response := h.Ready()
+
+	assert.Equal(t, models.HealthStatusUp, response.Status)
+	assert.Len(t, response.Checks, 2)
+	assert.Equal(t, RnibCheckName, response.Checks[0].Name)
+	assert.Equal(t, RoutingManagerCheckName, response.Checks[1].Name)
+}
+
+func TestReadyNonCriticalCheckDown(t *testing.T) {
+	h := NewHealthChecker(initLog(t), &configuration.Configuration{})
+	h.Register(RnibCheckName, true, succeeding())
+	h.Register(RoutingManagerCheckName, false, failing())
+
+	response := h.Ready()
+
+	assert.Equal(t, models.HealthStatusDegraded, response.Status)
+	assert.Equal(t, models.HealthStatusDown, response.Checks[1].Status)
+	assert.Equal(t, "error", response.Checks[1].Error)
+}
+
+func TestReadyCriticalCheckDown(t *testing.T) {
+	h := NewHealthChecker(initLog(t), &configuration.Configuration{})
+	h.Register(RoutingManagerCheckName, false, failing())
+	h.Register(RnibCheckName, true, failing())
+
+	response := h.Ready()
+
+	assert.Equal(t, models.HealthStatusDown, response.Status)
+}
+
+func TestConfiguredCriticality(t *testing.T) {
+	config := &configuration.Configuration{}
+	config.Health.Critical = map[string]bool{"routingmanager": true}
+	h := NewHealthChecker(initLog(t), config)
+	h.Register(RoutingManagerCheckName, false, failing())
+
+	response := h.Ready()
+
+	assert.Equal(t, models.HealthStatusDown, response.Status)
+	assert.True(t, response.Checks[0].Critical)
+}
+
+func TestLiveIncludesLivenessChecksOnly(t *testing.T) {
+	h := NewHealthChecker(initLog(t), &configuration.Configuration{})
+	h.Register(RnibCheckName, true, failing())
+	h.RegisterLiveness(KeepAliveCheckName, true, succeeding())
+
+	live := h.Live()
+	ready := h.Ready()
+
+	assert.Equal(t, models.HealthStatusUp, live.Status)
+	assert.Len(t, live.Checks, 1)
+	assert.Equal(t, KeepAliveCheckName, live.Checks[0].Name)
+	assert.Equal(t, models.HealthStatusDown, ready.Status)
+	assert.Len(t, ready.Checks, 2)
+}
+
+func TestNoChecks(t *testing.T) {
+	h := NewHealthChecker(initLog(t), &configuration.Configuration{})
+
+	response := h.Live()
+
+	assert.Equal(t, models.HealthStatusUp, response.Status)
+	assert.NotNil(t, response.Checks)
+}
+
+func TestCheckTimeout(t *testing.T) {
+	config := &configuration.Configuration{}
+	config.Health.CheckTimeoutMs = 50
+	h := NewHealthChecker(initLog(t), config)
+	h.Register(RoutingManagerCheckName, true, CheckerFunc(func() error {
+		time.Sleep(time.Second)
+		return nil
+	}))
+	h.Register(RnibCheckName, true, succeeding())
+
+	start := time.Now()
+	response := h.Ready()
+
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, models.HealthStatusDown, response.Status)
+	assert.Equal(t, "timed out after 50ms", response.Checks[0].Error)
+	assert.Equal(t, models.HealthStatusUp, response.Checks[1].Status)
+}
@@ -20,35 +20,75 @@
 package httpserver
 
 import (
+	"crypto/tls"
+	"e2mgr/auth"
 	"e2mgr/controllers"
 	"e2mgr/logger"
+	"e2mgr/tracing"
 	"fmt"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
-func Run(log *logger.Logger, port int, rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController) error {
+// Run serves the northbound API, over TLS when tlsConfig is not nil
+func Run(log *logger.Logger, port int, tlsConfig *tls.Config, rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController, loggingController controllers.ILoggingController, faultInjectionController controllers.IFaultInjectionController, authorizer auth.IAuthorizer) error {
 
-	router := mux.NewRouter();
-	initializeRoutes(router, rootController, nodebController, e2tController)
+	router := NewRouter(rootController, nodebController, e2tController, loggingController, faultInjectionController, authorizer)
 
 	addr := fmt.Sprintf(":%d", port)
 
-	err := http.ListenAndServe(addr, router)
+	server := &http.Server{Addr: addr, Handler: router, TLSConfig: tlsConfig}
+
+	var err error
+	if tlsConfig != nil {
+		// The certificate is served by tlsConfig.GetCertificate
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
 
 	log.Errorf("#http_server.Run - Fail initiating HTTP server. Error: %v", err)
 	return err
 }
 
-func initializeRoutes(router *mux.Router, rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController) {
+// NewRouter returns the handler of the northbound API
+func NewRouter(rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController, loggingController controllers.ILoggingController, faultInjectionController controllers.IFaultInjectionController, authorizer auth.IAuthorizer) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(tracing.HttpMiddleware)
+	initializeRoutes(router, rootController, nodebController, e2tController, loggingController, faultInjectionController, authorizer)
+	return router
+}
+
+// The health routes are left open for the orchestrator probes, every other route requires the role it is wrapped with.
+// The fault injection routes are only added when faultInjectionController is not nil
+func initializeRoutes(router *mux.Router, rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController, loggingController controllers.ILoggingController, faultInjectionController controllers.IFaultInjectionController, authorizer auth.IAuthorizer) {
 	r := router.PathPrefix("/v1").Subrouter()
 	r.HandleFunc("/health", rootController.HandleHealthCheckRequest).Methods(http.MethodGet)
+	r.HandleFunc("/health/live", rootController.HandleLivenessRequest).Methods(http.MethodGet)
+	r.HandleFunc("/health/ready", rootController.HandleReadinessRequest).Methods(http.MethodGet)
 
 	rr := r.PathPrefix("/nodeb").Subrouter()
-	rr.HandleFunc("/ids", nodebController.GetNodebIdList).Methods(http.MethodGet)
-	rr.HandleFunc("/{ranName}", nodebController.GetNodeb).Methods(http.MethodGet)
-	rr.HandleFunc("/{ranName}/update", nodebController.UpdateGnb).Methods(http.MethodPut)
-	rr.HandleFunc("/shutdown", nodebController.Shutdown).Methods(http.MethodPut)
+	rr.HandleFunc("/ids", authorizer.Authorize(auth.RoleViewer, nodebController.GetNodebIdList)).Methods(http.MethodGet)
+	rr.HandleFunc("/{ranName}", authorizer.Authorize(auth.RoleViewer, nodebController.GetNodeb)).Methods(http.MethodGet)
+	rr.HandleFunc("/{ranName}/history", authorizer.Authorize(auth.RoleViewer, nodebController.GetRanStatusHistory)).Methods(http.MethodGet)
+	rr.HandleFunc("/{ranName}/load", authorizer.Authorize(auth.RoleViewer, nodebController.GetRanLoadInformation)).Methods(http.MethodGet)
+	rr.HandleFunc("/{ranName}/update", authorizer.Authorize(auth.RoleOperator, nodebController.UpdateGnb)).Methods(http.MethodPut)
+	rr.HandleFunc("/{ranName}/update", authorizer.Authorize(auth.RoleOperator, nodebController.UpdateGnbCells)).Methods(http.MethodPatch)
+	rr.HandleFunc("/{ranName}/enb", authorizer.Authorize(auth.RoleOperator, nodebController.UpdateEnb)).Methods(http.MethodPut)
+	rr.HandleFunc("/shutdown", authorizer.Authorize(auth.RoleAdmin, nodebController.Shutdown)).Methods(http.MethodPut)
 	rrr := r.PathPrefix("/e2t").Subrouter()
-	rrr.HandleFunc("/list", e2tController.GetE2TInstances).Methods(http.MethodGet)
+	rrr.HandleFunc("/list", authorizer.Authorize(auth.RoleViewer, e2tController.GetE2TInstances)).Methods(http.MethodGet)
+	r.HandleFunc("/logging", authorizer.Authorize(auth.RoleViewer, loggingController.GetLoggingConfig)).Methods(http.MethodGet)
+	lr := r.PathPrefix("/logging").Subrouter()
+	lr.HandleFunc("/level", authorizer.Authorize(auth.RoleOperator, loggingController.SetLogLevel)).Methods(http.MethodPut)
+	lr.HandleFunc("/components/{component}", authorizer.Authorize(auth.RoleOperator, loggingController.SetComponentLogLevel)).Methods(http.MethodPut)
+	lr.HandleFunc("/components/{component}", authorizer.Authorize(auth.RoleOperator, loggingController.ResetComponentLogLevel)).Methods(http.MethodDelete)
+	lr.HandleFunc("/rans/{ranName}", authorizer.Authorize(auth.RoleOperator, loggingController.TraceRan)).Methods(http.MethodPut)
+	lr.HandleFunc("/rans/{ranName}", authorizer.Authorize(auth.RoleOperator, loggingController.StopRanTrace)).Methods(http.MethodDelete)
+	if faultInjectionController != nil {
+		dr := r.PathPrefix("/debug").Subrouter()
+		dr.HandleFunc("/faults", authorizer.Authorize(auth.RoleViewer, faultInjectionController.GetFaults)).Methods(http.MethodGet)
+		dr.HandleFunc("/faults", authorizer.Authorize(auth.RoleAdmin, faultInjectionController.SetFaults)).Methods(http.MethodPut)
+		dr.HandleFunc("/faults", authorizer.Authorize(auth.RoleAdmin, faultInjectionController.ClearFaults)).Methods(http.MethodDelete)
+	}
 }
@@ -21,6 +21,7 @@
 package httpserver
 
 import (
+	"e2mgr/auth"
 	"e2mgr/logger"
 	"e2mgr/mocks"
 	"github.com/gorilla/mux"
@@ -34,21 +35,41 @@ import (
 func setupRouterAndMocks() (*mux.Router, *mocks.RootControllerMock, *mocks.NodebControllerMock, *mocks.E2TControllerMock) {
 	rootControllerMock := &mocks.RootControllerMock{}
 	rootControllerMock.On("HandleHealthCheckRequest").Return(nil)
+	rootControllerMock.On("HandleLivenessRequest").Return(nil)
+	rootControllerMock.On("HandleReadinessRequest").Return(nil)
 
 	nodebControllerMock := &mocks.NodebControllerMock{}
 	nodebControllerMock.On("Shutdown").Return(nil)
 	nodebControllerMock.On("GetNodeb").Return(nil)
 	nodebControllerMock.On("GetNodebIdList").Return(nil)
+	nodebControllerMock.On("GetRanStatusHistory").Return(nil)
+	nodebControllerMock.On("GetRanLoadInformation").Return(nil)
+	nodebControllerMock.On("UpdateEnb").Return(nil)
+	nodebControllerMock.On("UpdateGnbCells").Return(nil)
 
 	e2tControllerMock := &mocks.E2TControllerMock{}
 
 	e2tControllerMock.On("GetE2TInstances").Return(nil)
 
 	router := mux.NewRouter()
-	initializeRoutes(router, rootControllerMock, nodebControllerMock, e2tControllerMock)
+	initializeRoutes(router, rootControllerMock, nodebControllerMock, e2tControllerMock, &mocks.LoggingControllerMock{}, nil, auth.AllowAll{})
 	return router, rootControllerMock, nodebControllerMock, e2tControllerMock
 }
 
+func setupLoggingRouterAndMock() (*mux.Router, *mocks.LoggingControllerMock) {
+	loggingControllerMock := &mocks.LoggingControllerMock{}
+	loggingControllerMock.On("GetLoggingConfig").Return(nil)
+	loggingControllerMock.On("SetLogLevel").Return(nil)
+	loggingControllerMock.On("SetComponentLogLevel").Return(nil)
+	loggingControllerMock.On("ResetComponentLogLevel").Return(nil)
+	loggingControllerMock.On("TraceRan").Return(nil)
+	loggingControllerMock.On("StopRanTrace").Return(nil)
+
+	router := mux.NewRouter()
+	initializeRoutes(router, &mocks.RootControllerMock{}, &mocks.NodebControllerMock{}, &mocks.E2TControllerMock{}, loggingControllerMock, nil, auth.AllowAll{})
+	return router, loggingControllerMock
+}
+
 func TestRouteGetNodebIds(t *testing.T) {
 	router, _, nodebControllerMock, _ := setupRouterAndMocks()
 
@@ -77,6 +98,38 @@ func TestRouteGetNodebRanName(t *testing.T) {
 	nodebControllerMock.AssertNumberOfCalls(t, "GetNodeb", 1)
 }
 
+func TestRouteGetNodebStatusHistory(t *testing.T) {
+	router, _, nodebControllerMock, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/nodeb/ran1/history", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	assert.Equal(t, "ran1", rr.Body.String(), "handler returned wrong body")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetRanStatusHistory", 1)
+	nodebControllerMock.AssertNotCalled(t, "GetNodeb")
+}
+
+func TestRouteGetNodebLoadInformation(t *testing.T) {
+	router, _, nodebControllerMock, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/nodeb/ran1/load", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	assert.Equal(t, "ran1", rr.Body.String(), "handler returned wrong body")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetRanLoadInformation", 1)
+	nodebControllerMock.AssertNotCalled(t, "GetNodeb")
+}
+
 func TestRouteGetHealth(t *testing.T) {
 	router, rootControllerMock, _, _ := setupRouterAndMocks()
 
@@ -90,6 +143,33 @@ func TestRouteGetHealth(t *testing.T) {
 	rootControllerMock.AssertNumberOfCalls(t, "HandleHealthCheckRequest", 1)
 }
 
+func TestRouteGetHealthLive(t *testing.T) {
+	router, rootControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/health/live", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	rootControllerMock.AssertNumberOfCalls(t, "HandleLivenessRequest", 1)
+	rootControllerMock.AssertNotCalled(t, "HandleHealthCheckRequest")
+}
+
+func TestRouteGetHealthReady(t *testing.T) {
+	router, rootControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/health/ready", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	rootControllerMock.AssertNumberOfCalls(t, "HandleReadinessRequest", 1)
+}
+
 func TestRoutePutNodebShutdown(t *testing.T) {
 	router, _, nodebControllerMock, _ := setupRouterAndMocks()
 
@@ -103,6 +183,38 @@ func TestRoutePutNodebShutdown(t *testing.T) {
 	nodebControllerMock.AssertNumberOfCalls(t, "Shutdown", 1)
 }
 
+func TestRoutePutNodebEnb(t *testing.T) {
+	router, _, nodebControllerMock, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("PUT", "/v1/nodeb/ran1/enb", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	assert.Equal(t, "ran1", rr.Body.String(), "handler returned wrong body")
+	nodebControllerMock.AssertNumberOfCalls(t, "UpdateEnb", 1)
+	nodebControllerMock.AssertNotCalled(t, "GetNodeb")
+}
+
+func TestRoutePatchNodebUpdate(t *testing.T) {
+	router, _, nodebControllerMock, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("PATCH", "/v1/nodeb/ran1/update?dryRun=true", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	assert.Equal(t, "ran1", rr.Body.String(), "handler returned wrong body")
+	nodebControllerMock.AssertNumberOfCalls(t, "UpdateGnbCells", 1)
+	nodebControllerMock.AssertNotCalled(t, "UpdateGnb")
+}
+
 func TestRouteNotFound(t *testing.T) {
 	router, _, _,_ := setupRouterAndMocks()
 
@@ -116,16 +228,159 @@ func TestRouteNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rr.Code, "handler returned wrong status code")
 }
 
+func TestRouteGetLoggingConfig(t *testing.T) {
+	router, loggingControllerMock := setupLoggingRouterAndMock()
+
+	req, err := http.NewRequest("GET", "/v1/logging", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	loggingControllerMock.AssertNumberOfCalls(t, "GetLoggingConfig", 1)
+}
+
+func TestRoutePutLogLevel(t *testing.T) {
+	router, loggingControllerMock := setupLoggingRouterAndMock()
+
+	req, err := http.NewRequest("PUT", "/v1/logging/level", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	loggingControllerMock.AssertNumberOfCalls(t, "SetLogLevel", 1)
+}
+
+func TestRoutePutAndDeleteComponentLogLevel(t *testing.T) {
+	router, loggingControllerMock := setupLoggingRouterAndMock()
+
+	req, err := http.NewRequest("PUT", "/v1/logging/components/E2TKeepAliveWorker", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, "E2TKeepAliveWorker", rr.Body.String(), "handler returned wrong body")
+
+	req, err = http.NewRequest("DELETE", "/v1/logging/components/E2TKeepAliveWorker", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	loggingControllerMock.AssertNumberOfCalls(t, "SetComponentLogLevel", 1)
+	loggingControllerMock.AssertNumberOfCalls(t, "ResetComponentLogLevel", 1)
+}
+
+func TestRoutePutAndDeleteRanTrace(t *testing.T) {
+	router, loggingControllerMock := setupLoggingRouterAndMock()
+
+	req, err := http.NewRequest("PUT", "/v1/logging/rans/ran1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, "ran1", rr.Body.String(), "handler returned wrong body")
+
+	req, err = http.NewRequest("DELETE", "/v1/logging/rans/ran1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	loggingControllerMock.AssertNumberOfCalls(t, "TraceRan", 1)
+	loggingControllerMock.AssertNumberOfCalls(t, "StopRanTrace", 1)
+}
+
+func TestRouteFaults(t *testing.T) {
+	faultInjectionControllerMock := &mocks.FaultInjectionControllerMock{}
+	faultInjectionControllerMock.On("GetFaults").Return(nil)
+	faultInjectionControllerMock.On("SetFaults").Return(nil)
+	faultInjectionControllerMock.On("ClearFaults").Return(nil)
+	router := mux.NewRouter()
+	initializeRoutes(router, &mocks.RootControllerMock{}, &mocks.NodebControllerMock{}, &mocks.E2TControllerMock{}, &mocks.LoggingControllerMock{}, faultInjectionControllerMock, auth.AllowAll{})
+
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		req, err := http.NewRequest(method, "/v1/debug/faults", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	faultInjectionControllerMock.AssertNumberOfCalls(t, "GetFaults", 1)
+	faultInjectionControllerMock.AssertNumberOfCalls(t, "SetFaults", 1)
+	faultInjectionControllerMock.AssertNumberOfCalls(t, "ClearFaults", 1)
+}
+
+func TestRouteFaultsNotFoundWithoutController(t *testing.T) {
+	router, _, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/debug/faults", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestRouteAuthorization(t *testing.T) {
+	rootControllerMock := &mocks.RootControllerMock{}
+	rootControllerMock.On("HandleHealthCheckRequest").Return(nil)
+	nodebControllerMock := &mocks.NodebControllerMock{}
+	nodebControllerMock.On("Shutdown").Return(nil)
+	nodebControllerMock.On("GetNodebIdList").Return(nil)
+	authenticator, _ := auth.NewStaticTokenAuthenticator([]auth.StaticToken{
+		{Subject: "dashboard", Role: "viewer", Token: "viewer-token"},
+		{Subject: "ops", Role: "admin", Token: "admin-token"},
+	})
+	router := mux.NewRouter()
+	initializeRoutes(router, rootControllerMock, nodebControllerMock, &mocks.E2TControllerMock{}, &mocks.LoggingControllerMock{}, nil, auth.NewAuthorizer(initLog(t), authenticator))
+
+	for _, test := range []struct {
+		method string
+		url    string
+		token  string
+		status int
+	}{
+		{http.MethodGet, "/v1/health", "", http.StatusOK},
+		{http.MethodGet, "/v1/nodeb/ids", "", http.StatusUnauthorized},
+		{http.MethodGet, "/v1/nodeb/ids", "viewer-token", http.StatusOK},
+		{http.MethodPut, "/v1/nodeb/shutdown", "viewer-token", http.StatusForbidden},
+		{http.MethodPut, "/v1/nodeb/shutdown", "wrong-token", http.StatusUnauthorized},
+		{http.MethodPut, "/v1/nodeb/shutdown", "admin-token", http.StatusOK},
+	} {
+		req, _ := http.NewRequest(test.method, test.url, nil)
+		if test.token != "" {
+			req.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, test.status, rr.Code, "%s %s with token %q", test.method, test.url, test.token)
+	}
+
+	nodebControllerMock.AssertNumberOfCalls(t, "Shutdown", 1)
+	nodebControllerMock.AssertNumberOfCalls(t, "GetNodebIdList", 1)
+}
+
 func TestRunError(t *testing.T) {
 	log := initLog(t)
-	err := Run(log, 1234567, &mocks.RootControllerMock{}, &mocks.NodebControllerMock{}, &mocks.E2TControllerMock{})
+	err := Run(log, 1234567, nil, &mocks.RootControllerMock{}, &mocks.NodebControllerMock{}, &mocks.E2TControllerMock{}, &mocks.LoggingControllerMock{}, nil, auth.AllowAll{})
 	assert.NotNil(t, err)
 }
 
 func TestRun(t *testing.T) {
 	log := initLog(t)
 	_, rootControllerMock, nodebControllerMock, e2tControllerMock := setupRouterAndMocks()
-	go Run(log, 11223, rootControllerMock, nodebControllerMock, e2tControllerMock)
+	go Run(log, 11223, nil, rootControllerMock, nodebControllerMock, e2tControllerMock, &mocks.LoggingControllerMock{}, nil, auth.AllowAll{})
 
 	time.Sleep(time.Millisecond * 100)
 	resp, err := http.Get("http://localhost:11223/v1/health")
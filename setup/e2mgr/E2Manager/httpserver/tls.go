@@ -0,0 +1,228 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+const certificateCheckInterval = time.Second
+
+// CertificateReloader serves the server certificate and the client CA bundle, reloading them when their files
+// change so that rotated certificates are picked up without a restart. A file that fails to load is logged and
+// the previously loaded material is kept
+type CertificateReloader struct {
+	logger        *logger.Logger
+	certFile      string
+	keyFile       string
+	clientCaFile  string
+	checkInterval time.Duration
+	mu            sync.Mutex
+	lastCheck     time.Time
+	modTimes      map[string]time.Time
+	certificate   *tls.Certificate
+	clientCAs     *x509.CertPool
+}
+
+func NewCertificateReloader(logger *logger.Logger, certFile string, keyFile string, clientCaFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		logger:        logger,
+		certFile:      certFile,
+		keyFile:       keyFile,
+		clientCaFile:  clientCaFile,
+		checkInterval: certificateCheckInterval,
+	}
+
+	modTimes, err := r.modificationTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.load(modTimes)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.reloadIfChanged()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.certificate, nil
+}
+
+func (r *CertificateReloader) ClientCAs() *x509.CertPool {
+	r.reloadIfChanged()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clientCAs
+}
+
+func (r *CertificateReloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCaFile != "" {
+		files = append(files, r.clientCaFile)
+	}
+	return files
+}
+
+func (r *CertificateReloader) modificationTimes() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+func (r *CertificateReloader) reloadIfChanged() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastCheck) < r.checkInterval {
+		return
+	}
+	r.lastCheck = now
+
+	modTimes, err := r.modificationTimes()
+	if err != nil {
+		r.logger.Errorf("#CertificateReloader.reloadIfChanged - keeping the loaded certificates, error: %s", err)
+		return
+	}
+
+	changed := false
+	for file, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[file]) {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	err = r.load(modTimes)
+	if err != nil {
+		r.logger.Errorf("#CertificateReloader.reloadIfChanged - keeping the loaded certificates, error: %s", err)
+		return
+	}
+
+	r.logger.Infof("#CertificateReloader.reloadIfChanged - reloaded certificate %s", r.certFile)
+}
+
+// load must be called with mu held, or before the reloader is shared
+func (r *CertificateReloader) load(modTimes map[string]time.Time) error {
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate %s, key %s: %s", r.certFile, r.keyFile, err)
+	}
+
+	var clientCAs *x509.CertPool
+
+	if r.clientCaFile != "" {
+		pem, err := ioutil.ReadFile(r.clientCaFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA bundle %s: %s", r.clientCaFile, err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in client CA bundle %s", r.clientCaFile)
+		}
+	}
+
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return nil
+}
+
+// NewTlsConfig builds the TLS configuration of the northbound API, or returns nil when http.tls is disabled
+func NewTlsConfig(logger *logger.Logger, config *configuration.Configuration) (*tls.Config, error) {
+	tlsSettings := config.Http.Tls
+
+	if !tlsSettings.Enabled {
+		return nil, nil
+	}
+
+	minVersion, ok := configuration.TlsVersion(tlsSettings.MinVersion)
+	if !ok {
+		return nil, fmt.Errorf("unknown tls version %s", tlsSettings.MinVersion)
+	}
+
+	clientAuth, ok := configuration.TlsClientAuth(tlsSettings.ClientAuth)
+	if !ok {
+		return nil, fmt.Errorf("unknown tls client auth %s", tlsSettings.ClientAuth)
+	}
+
+	var cipherSuites []uint16
+	for _, name := range tlsSettings.CipherSuites {
+		id, ok := configuration.TlsCipherSuite(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure cipher suite %s", name)
+		}
+		cipherSuites = append(cipherSuites, id)
+	}
+
+	clientCaFile := ""
+	if clientAuth != tls.NoClientCert {
+		clientCaFile = tlsSettings.ClientCaFile
+	}
+
+	reloader, err := NewCertificateReloader(logger, tlsSettings.CertFile, tlsSettings.KeyFile, clientCaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   cipherSuites,
+		ClientAuth:     clientAuth,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if clientAuth != tls.NoClientCert {
+		tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			clientConfig := tlsConfig.Clone()
+			clientConfig.GetConfigForClient = nil
+			clientConfig.ClientCAs = reloader.ClientCAs()
+			return clientConfig, nil
+		}
+	}
+
+	logger.Infof("#httpserver.NewTlsConfig - tls enabled, min version: %s, client auth: %s", tlsSettings.MinVersion, tlsSettings.ClientAuth)
+	return tlsConfig, nil
+}
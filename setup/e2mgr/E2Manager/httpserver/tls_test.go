@@ -0,0 +1,295 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"e2mgr/auth"
+	"e2mgr/configuration"
+	"e2mgr/mocks"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPem     []byte
+	keyPem      []byte
+}
+
+// generateCertificate creates a certificate signed by parent, or a self signed CA when parent is nil
+func generateCertificate(t *testing.T, commonName string, serial int64, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.certificate, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPem:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPem:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	certificate, err := tls.X509KeyPair(c.certPem, c.keyPem)
+	if err != nil {
+		t.Fatalf("failed to load key pair: %s", err)
+	}
+	return certificate
+}
+
+type tlsFiles struct {
+	dir      string
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+func writeTlsFiles(t *testing.T, files *tlsFiles, server *testCertificate, ca *testCertificate, modTime time.Time) {
+	for file, content := range map[string][]byte{files.certFile: server.certPem, files.keyFile: server.keyPem, files.caFile: ca.certPem} {
+		if err := ioutil.WriteFile(file, content, 0600); err != nil {
+			t.Fatalf("failed to write %s: %s", file, err)
+		}
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatalf("failed to touch %s: %s", file, err)
+		}
+	}
+}
+
+func setupTlsFiles(t *testing.T) (*tlsFiles, *testCertificate, *testCertificate) {
+	dir, err := ioutil.TempDir("", "e2mgr-tls")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err)
+	}
+	files := &tlsFiles{
+		dir:      dir,
+		certFile: filepath.Join(dir, "tls.crt"),
+		keyFile:  filepath.Join(dir, "tls.key"),
+		caFile:   filepath.Join(dir, "ca.crt"),
+	}
+	ca := generateCertificate(t, "e2mgr-test-ca", 1, nil)
+	server := generateCertificate(t, "e2mgr", 2, ca)
+	writeTlsFiles(t, files, server, ca, time.Now().Add(-time.Minute))
+	return files, ca, server
+}
+
+func tlsTestConfiguration(files *tlsFiles, clientAuth string) *configuration.Configuration {
+	config := &configuration.Configuration{}
+	config.Http.Tls.Enabled = true
+	config.Http.Tls.CertFile = files.certFile
+	config.Http.Tls.KeyFile = files.keyFile
+	config.Http.Tls.MinVersion = "1.2"
+	config.Http.Tls.ClientCaFile = files.caFile
+	config.Http.Tls.ClientAuth = clientAuth
+	return config
+}
+
+func httpsClient(ca *testCertificate, clientCertificates ...tls.Certificate) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+	return &http.Client{
+		Timeout: time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: clientCertificates},
+		},
+	}
+}
+
+func runTlsServer(t *testing.T, port int, tlsConfig *tls.Config) {
+	log := initLog(t)
+	_, rootControllerMock, nodebControllerMock, e2tControllerMock := setupRouterAndMocks()
+	go Run(log, port, tlsConfig, rootControllerMock, nodebControllerMock, e2tControllerMock, &mocks.LoggingControllerMock{}, nil, auth.AllowAll{})
+	time.Sleep(time.Millisecond * 100)
+}
+
+func TestNewTlsConfigDisabled(t *testing.T) {
+	tlsConfig, err := NewTlsConfig(initLog(t), &configuration.Configuration{})
+	assert.Nil(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
+func TestNewTlsConfigCertificateNotFound(t *testing.T) {
+	files, _, _ := setupTlsFiles(t)
+	defer os.RemoveAll(files.dir)
+	config := tlsTestConfiguration(files, "none")
+	config.Http.Tls.CertFile = filepath.Join(files.dir, "missing.crt")
+
+	tlsConfig, err := NewTlsConfig(initLog(t), config)
+	assert.NotNil(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
+func TestNewTlsConfigUnknownCipherSuite(t *testing.T) {
+	files, _, _ := setupTlsFiles(t)
+	defer os.RemoveAll(files.dir)
+	config := tlsTestConfiguration(files, "none")
+	config.Http.Tls.CipherSuites = []string{"TLS_RSA_WITH_RC4_128_SHA"}
+
+	tlsConfig, err := NewTlsConfig(initLog(t), config)
+	assert.NotNil(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
+func TestNewTlsConfigSettings(t *testing.T) {
+	files, _, _ := setupTlsFiles(t)
+	defer os.RemoveAll(files.dir)
+	config := tlsTestConfiguration(files, "require")
+	config.Http.Tls.MinVersion = "1.3"
+	config.Http.Tls.CipherSuites = []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}
+
+	tlsConfig, err := NewTlsConfig(initLog(t), config)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, tlsConfig.CipherSuites)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+	assert.NotNil(t, tlsConfig.GetConfigForClient)
+}
+
+func TestRunTls(t *testing.T) {
+	files, ca, _ := setupTlsFiles(t)
+	defer os.RemoveAll(files.dir)
+	tlsConfig, err := NewTlsConfig(initLog(t), tlsTestConfiguration(files, "none"))
+	assert.Nil(t, err)
+	runTlsServer(t, 11224, tlsConfig)
+
+	resp, err := httpsClient(ca).Get("https://localhost:11224/v1/health")
+	if err != nil {
+		t.Fatalf("failed to perform GET to https://localhost:11224/v1/health: %s", err)
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+
+	_, err = http.Get("http://localhost:11224/v1/health")
+	assert.Nil(t, err, "plain http is answered with a 400 by the tls server")
+}
+
+func TestRunMutualTls(t *testing.T) {
+	files, ca, _ := setupTlsFiles(t)
+	defer os.RemoveAll(files.dir)
+	tlsConfig, err := NewTlsConfig(initLog(t), tlsTestConfiguration(files, "require"))
+	assert.Nil(t, err)
+	runTlsServer(t, 11225, tlsConfig)
+
+	_, err = httpsClient(ca).Get("https://localhost:11225/v1/health")
+	assert.NotNil(t, err)
+
+	untrusted := generateCertificate(t, "untrusted", 3, generateCertificate(t, "other-ca", 4, nil))
+	_, err = httpsClient(ca, untrusted.tlsCertificate(t)).Get("https://localhost:11225/v1/health")
+	assert.NotNil(t, err)
+
+	client := generateCertificate(t, "xapp", 5, ca)
+	resp, err := httpsClient(ca, client.tlsCertificate(t)).Get("https://localhost:11225/v1/health")
+	if err != nil {
+		t.Fatalf("failed to perform GET to https://localhost:11225/v1/health: %s", err)
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestCertificateReloaderReloadsChangedFiles(t *testing.T) {
+	files, ca, server := setupTlsFiles(t)
+	defer os.RemoveAll(files.dir)
+	reloader, err := NewCertificateReloader(initLog(t), files.certFile, files.keyFile, files.caFile)
+	assert.Nil(t, err)
+	reloader.checkInterval = 0
+
+	certificate, _ := reloader.GetCertificate(nil)
+	assert.Equal(t, server.certPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]}))
+
+	rotated := generateCertificate(t, "e2mgr", 6, ca)
+	writeTlsFiles(t, files, rotated, ca, time.Now())
+
+	certificate, _ = reloader.GetCertificate(nil)
+	assert.Equal(t, rotated.certPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]}))
+	assert.NotNil(t, reloader.ClientCAs())
+}
+
+func TestCertificateReloaderKeepsCertificateOnInvalidFiles(t *testing.T) {
+	files, _, server := setupTlsFiles(t)
+	defer os.RemoveAll(files.dir)
+	reloader, err := NewCertificateReloader(initLog(t), files.certFile, files.keyFile, "")
+	assert.Nil(t, err)
+	reloader.checkInterval = 0
+
+	err = ioutil.WriteFile(files.certFile, []byte("not a certificate"), 0600)
+	assert.Nil(t, err)
+	now := time.Now()
+	_ = os.Chtimes(files.certFile, now, now)
+
+	certificate, err := reloader.GetCertificate(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, server.certPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]}))
+	assert.Nil(t, reloader.ClientCAs())
+}
+
+func TestCertificateReloaderThrottlesChecks(t *testing.T) {
+	files, ca, server := setupTlsFiles(t)
+	defer os.RemoveAll(files.dir)
+	reloader, err := NewCertificateReloader(initLog(t), files.certFile, files.keyFile, "")
+	assert.Nil(t, err)
+	reloader.checkInterval = time.Hour
+	reloader.lastCheck = time.Now()
+
+	writeTlsFiles(t, files, generateCertificate(t, "e2mgr", 7, ca), ca, time.Now())
+
+	certificate, _ := reloader.GetCertificate(nil)
+	assert.Equal(t, server.certPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]}))
+}
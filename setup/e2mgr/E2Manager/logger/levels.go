@@ -0,0 +1,288 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package logger
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"strings"
+	"sync"
+	"time"
+)
+
+// levels holds the log levels that can be changed at runtime: the base level, per component levels keyed by the
+// component of the "#Component.Method" message prefix, and RANs whose messages are temporarily logged at debug level.
+// It is shared by every copy of a Logger.
+type levels struct {
+	mu         sync.RWMutex
+	atomic     zap.AtomicLevel
+	base       LogLevel
+	components map[string]LogLevel
+	rans       map[string]time.Time
+}
+
+func newLevels(base LogLevel) *levels {
+	return &levels{
+		atomic:     zap.NewAtomicLevelAt(zapcore.Level(base)),
+		base:       base,
+		components: make(map[string]LogLevel),
+		rans:       make(map[string]time.Time),
+	}
+}
+
+// updateAtomic lowers the zap core level to the most verbose level currently required. Must be called with mu held
+func (l *levels) updateAtomic() {
+	min := l.base
+
+	for _, level := range l.components {
+		if level < min {
+			min = level
+		}
+	}
+
+	if len(l.rans) > 0 {
+		min = DebugLevel
+	}
+
+	l.atomic.SetLevel(zapcore.Level(min))
+}
+
+// message formats the message and reports whether it should be written at the given level
+func (l *levels) message(level LogLevel, formatMsg string, a []interface{}) (string, bool) {
+	l.mu.RLock()
+	threshold, ok := l.components[componentOf(formatMsg)]
+	if !ok {
+		threshold = l.base
+	}
+	traced := len(l.rans) > 0
+	l.mu.RUnlock()
+
+	if level >= threshold {
+		return fmt.Sprintf(formatMsg, a...), true
+	}
+
+	if !traced {
+		return "", false
+	}
+
+	msg := fmt.Sprintf(formatMsg, a...)
+	return msg, l.isTraced(msg)
+}
+
+func (l *levels) isTraced(msg string) bool {
+	now := time.Now()
+	expired := false
+
+	l.mu.RLock()
+	for ranName, expiresAt := range l.rans {
+		if now.After(expiresAt) {
+			expired = true
+			continue
+		}
+		if containsRanName(msg, ranName) {
+			l.mu.RUnlock()
+			return true
+		}
+	}
+	l.mu.RUnlock()
+
+	if expired {
+		l.removeExpired(now)
+	}
+
+	return false
+}
+
+// containsRanName reports whether the RAN name appears in the message as a whole word, so that tracing "ran1" does
+// not catch the messages of "ran10"
+func containsRanName(msg string, ranName string) bool {
+	if ranName == "" {
+		return false
+	}
+
+	for offset := 0; ; {
+		i := strings.Index(msg[offset:], ranName)
+
+		if i < 0 {
+			return false
+		}
+
+		start := offset + i
+		end := start + len(ranName)
+
+		if (start == 0 || !isRanNameByte(msg[start-1])) && (end == len(msg) || !isRanNameByte(msg[end])) {
+			return true
+		}
+
+		offset = start + 1
+	}
+}
+
+func isRanNameByte(b byte) bool {
+	return b == '_' || b == '-' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+func (l *levels) removeExpired(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ranName, expiresAt := range l.rans {
+		if now.After(expiresAt) {
+			delete(l.rans, ranName)
+		}
+	}
+
+	l.updateAtomic()
+}
+
+func (l *levels) enabled(level LogLevel) bool {
+	return l.atomic.Enabled(zapcore.Level(level))
+}
+
+// componentOf extracts the component of messages following the "#Component.Method - ..." convention.
+// Components are case insensitive, configuration keys are lower cased by viper
+func componentOf(formatMsg string) string {
+	start := strings.IndexByte(formatMsg, '#')
+
+	if start < 0 {
+		return ""
+	}
+
+	component := formatMsg[start+1:]
+
+	if end := strings.IndexAny(component, ". "); end >= 0 {
+		component = component[:end]
+	}
+
+	return strings.ToLower(component)
+}
+
+func (l *Logger) Level() LogLevel {
+	l.levels.mu.RLock()
+	defer l.levels.mu.RUnlock()
+	return l.levels.base
+}
+
+func (l *Logger) SetLevel(level LogLevel) error {
+	if err := validateLevel(level); err != nil {
+		return err
+	}
+
+	l.levels.mu.Lock()
+	defer l.levels.mu.Unlock()
+	l.levels.base = level
+	l.levels.updateAtomic()
+	return nil
+}
+
+// ComponentLevels returns the per component levels, keyed by lower cased component
+func (l *Logger) ComponentLevels() map[string]LogLevel {
+	l.levels.mu.RLock()
+	defer l.levels.mu.RUnlock()
+
+	components := make(map[string]LogLevel, len(l.levels.components))
+
+	for component, level := range l.levels.components {
+		components[component] = level
+	}
+
+	return components
+}
+
+func (l *Logger) SetComponentLevel(component string, level LogLevel) error {
+	if err := validateLevel(level); err != nil {
+		return err
+	}
+
+	if component == "" {
+		return fmt.Errorf("empty component")
+	}
+
+	l.levels.mu.Lock()
+	defer l.levels.mu.Unlock()
+	l.levels.components[strings.ToLower(component)] = level
+	l.levels.updateAtomic()
+	return nil
+}
+
+// ResetComponentLevel makes the component use the base level again. Returns false if the component had no level of its own
+func (l *Logger) ResetComponentLevel(component string) bool {
+	l.levels.mu.Lock()
+	defer l.levels.mu.Unlock()
+
+	key := strings.ToLower(component)
+
+	if _, ok := l.levels.components[key]; !ok {
+		return false
+	}
+
+	delete(l.levels.components, key)
+	l.levels.updateAtomic()
+	return true
+}
+
+// TraceRan logs every message mentioning ranName at debug level until duration passes
+func (l *Logger) TraceRan(ranName string, duration time.Duration) time.Time {
+	expiresAt := time.Now().Add(duration)
+
+	l.levels.mu.Lock()
+	defer l.levels.mu.Unlock()
+	l.levels.rans[ranName] = expiresAt
+	l.levels.updateAtomic()
+	return expiresAt
+}
+
+// StopRanTrace returns false if the RAN was not traced
+func (l *Logger) StopRanTrace(ranName string) bool {
+	l.levels.mu.Lock()
+	defer l.levels.mu.Unlock()
+
+	if _, ok := l.levels.rans[ranName]; !ok {
+		return false
+	}
+
+	delete(l.levels.rans, ranName)
+	l.levels.updateAtomic()
+	return true
+}
+
+// TracedRans returns the traced RANs and the time their tracing expires
+func (l *Logger) TracedRans() map[string]time.Time {
+	l.levels.removeExpired(time.Now())
+
+	l.levels.mu.RLock()
+	defer l.levels.mu.RUnlock()
+
+	rans := make(map[string]time.Time, len(l.levels.rans))
+
+	for ranName, expiresAt := range l.levels.rans {
+		rans[ranName] = expiresAt
+	}
+
+	return rans
+}
+
+func validateLevel(level LogLevel) error {
+	if level < _minLevel || level > _maxLevel {
+		return fmt.Errorf("Invalid logging Level :%d", level)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package logger
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func captureRecords(t *testing.T, log *Logger, write func()) []string {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	// zap resolves stdout when the logger is built, so rebuild it on the pipe
+	zapLogger, err := initLoggerByLevel(log.levels.atomic)
+	if err != nil {
+		t.Errorf("logger_test.captureRecords - failed to initialize logger, error: %s", err)
+	}
+	log.Logger = zapLogger
+	write()
+	_ = w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	if err != nil {
+		t.Errorf("logger_test.captureRecords - failed to copy bytes, error: %s", err)
+	}
+	var records []string
+	for _, record := range strings.Split(buf.String(), "\n") {
+		if record != "" {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func TestComponentOf(t *testing.T) {
+	assert.Equal(t, "nodebcontroller", componentOf("[E2 Manager -> Client] #NodebController.handleRequest - response: %s"))
+	assert.Equal(t, "e2tkeepaliveworker", componentOf("#E2TKeepAliveWorker.Execute - keep alive started"))
+	assert.Equal(t, "app", componentOf("#app.main - Configuration %s"))
+	assert.Equal(t, "", componentOf("%v, %v"))
+}
+
+func TestSetLevel(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+
+	records := captureRecords(t, log, func() {
+		log.Debugf("#RanSetupManager.ExecuteSetup - before")
+		assert.Nil(t, log.SetLevel(DebugLevel))
+		log.Debugf("#RanSetupManager.ExecuteSetup - after")
+	})
+
+	assert.Len(t, records, 1)
+	assert.Contains(t, records[0], "after")
+	assert.Equal(t, DebugLevel, log.Level())
+	assert.True(t, log.DebugEnabled())
+}
+
+func TestSetLevelInvalid(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+
+	assert.NotNil(t, log.SetLevel(99))
+	assert.Equal(t, InfoLevel, log.Level())
+}
+
+func TestSetLevelSharedByCopies(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+	logCopy := *log
+
+	assert.Nil(t, log.SetLevel(ErrorLevel))
+
+	assert.Equal(t, ErrorLevel, logCopy.Level())
+	assert.False(t, logCopy.InfoEnabled())
+}
+
+func TestComponentLevelRaisesAndLowersBaseLevel(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+	assert.Nil(t, log.SetComponentLevel("E2TKeepAliveWorker", WarnLevel))
+	assert.Nil(t, log.SetComponentLevel("RanSetupManager", DebugLevel))
+
+	records := captureRecords(t, log, func() {
+		log.Infof("#E2TKeepAliveWorker.E2TKeepAliveExpired - filtered")
+		log.Warnf("#E2TKeepAliveWorker.E2TKeepAliveExpired - kept warning")
+		log.Debugf("#RanSetupManager.ExecuteSetup - kept debug")
+		log.Debugf("#NodebController.GetNodeb - filtered")
+		log.Infof("#NodebController.GetNodeb - kept info")
+	})
+
+	assert.Len(t, records, 3)
+	assert.Contains(t, records[0], "kept warning")
+	assert.Contains(t, records[1], "kept debug")
+	assert.Contains(t, records[2], "kept info")
+	assert.Equal(t, map[string]LogLevel{"e2tkeepaliveworker": WarnLevel, "ransetupmanager": DebugLevel}, log.ComponentLevels())
+}
+
+func TestResetComponentLevel(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+	assert.Nil(t, log.SetComponentLevel("RanSetupManager", DebugLevel))
+	assert.True(t, log.DebugEnabled())
+
+	assert.True(t, log.ResetComponentLevel("ransetupmanager"))
+	assert.False(t, log.ResetComponentLevel("ransetupmanager"))
+
+	assert.Empty(t, log.ComponentLevels())
+	assert.False(t, log.DebugEnabled())
+}
+
+func TestSetComponentLevelInvalid(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+
+	assert.NotNil(t, log.SetComponentLevel("RanSetupManager", -5))
+	assert.NotNil(t, log.SetComponentLevel("", DebugLevel))
+	assert.Empty(t, log.ComponentLevels())
+}
+
+func TestTraceRan(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+	log.TraceRan("gnb:208-092-303030", time.Minute)
+
+	records := captureRecords(t, log, func() {
+		log.Debugf("#RanSetupManager.ExecuteSetup - Ran name: %s - kept", "gnb:208-092-303030")
+		log.Debugf("#RanSetupManager.ExecuteSetup - Ran name: %s - filtered", "test")
+	})
+
+	assert.Len(t, records, 1)
+	assert.Contains(t, records[0], "kept")
+	assert.Contains(t, log.TracedRans(), "gnb:208-092-303030")
+}
+
+func TestTraceRanMatchesWholeName(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+	log.TraceRan("ran1", time.Minute)
+
+	records := captureRecords(t, log, func() {
+		log.Debugf("#RanSetupManager.ExecuteSetup - Ran name: %s - kept", "ran1")
+		log.Debugf("#E2TAssociationManager.AssociateRan - RAN ran_name:\"%s\" - kept", "ran1")
+		log.Debugf("#RanSetupManager.ExecuteSetup - Ran name: %s - filtered", "ran10")
+		log.Debugf("#RanSetupManager.ExecuteSetup - Ran name: %s - filtered", "gnb_ran1")
+	})
+
+	assert.Len(t, records, 2)
+	for _, record := range records {
+		assert.Contains(t, record, "kept")
+	}
+}
+
+func TestContainsRanName(t *testing.T) {
+	assert.True(t, containsRanName("RAN name: ran1", "ran1"))
+	assert.True(t, containsRanName("ran1, ran10", "ran10"))
+	assert.True(t, containsRanName("RAN name: gnb:208-092-303030.", "gnb:208-092-303030"))
+	assert.False(t, containsRanName("RAN name: ran10", "ran1"))
+	assert.False(t, containsRanName("RAN name: ran1-2", "ran1"))
+	assert.False(t, containsRanName("RAN name: ran1", ""))
+}
+
+func TestTraceRanExpires(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+	log.TraceRan("test", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	records := captureRecords(t, log, func() {
+		log.Debugf("#RanSetupManager.ExecuteSetup - Ran name: %s", "test")
+	})
+
+	assert.Empty(t, records)
+	assert.Empty(t, log.TracedRans())
+	assert.False(t, log.DebugEnabled())
+}
+
+func TestStopRanTrace(t *testing.T) {
+	log, _ := InitLogger(InfoLevel)
+	log.TraceRan("test", time.Minute)
+
+	assert.True(t, log.StopRanTrace("test"))
+	assert.False(t, log.StopRanTrace("test"))
+	assert.False(t, log.DebugEnabled())
+}
+
+func TestLogLevelString(t *testing.T) {
+	assert.Equal(t, "debug", DebugLevel.String())
+	assert.Equal(t, "warn", WarnLevel.String())
+	assert.Equal(t, "LogLevel(99)", LogLevel(99).String())
+}
@@ -30,6 +30,7 @@ import (
 
 type Logger struct {
 	Logger     *zap.Logger
+	levels     *levels
 }
 
 // Copied from zap logger
@@ -78,31 +79,26 @@ func LogLevelTokenToLevel(level string) (LogLevel, bool) {
 	return _maxLevel+1, false
 }
 
+func (l LogLevel) String() string {
+	for token, level := range logLevelTokenToLevel {
+		if level == l {
+			return token
+		}
+	}
+	return fmt.Sprintf("LogLevel(%d)", l)
+}
+
 func InitLogger(requested LogLevel) (*Logger, error) {
-	var logger *zap.Logger
-	var err error
-	switch requested {
-	case DebugLevel:
-		logger, err = initLoggerByLevel(zapcore.DebugLevel)
-	case InfoLevel:
-		logger, err = initLoggerByLevel(zapcore.InfoLevel)
-	case WarnLevel:
-		logger, err = initLoggerByLevel(zapcore.WarnLevel)
-	case ErrorLevel:
-		logger, err = initLoggerByLevel(zapcore.ErrorLevel)
-	case DPanicLevel:
-		logger, err = initLoggerByLevel(zapcore.DPanicLevel)
-	case PanicLevel:
-		logger, err = initLoggerByLevel(zapcore.PanicLevel)
-	case FatalLevel:
-		logger, err = initLoggerByLevel(zapcore.FatalLevel)
-	default:
-		err = fmt.Errorf("Invalid logging Level :%d",requested)
+	err := validateLevel(requested)
+	if err != nil {
+		return nil, err
 	}
+	levels := newLevels(requested)
+	logger, err := initLoggerByLevel(levels.atomic)
 	if err != nil {
 		return nil, err
 	}
-	return &Logger{Logger:logger}, nil
+	return &Logger{Logger:logger, levels:levels}, nil
 
 }
 func(l *Logger)Sync() error {
@@ -111,27 +107,32 @@ func(l *Logger)Sync() error {
 }
 
 func (l *Logger)Infof(formatMsg string, a ...interface{})  {
-	if l.InfoEnabled() {
-		msg := fmt.Sprintf(formatMsg, a...)
-		l.Logger.Info(msg, zap.Any("mdc", l.getTimeStampMdc()))
-	}
+	l.logf(InfoLevel, formatMsg, a)
 }
 
 func (l *Logger)Debugf(formatMsg string, a ...interface{})  {
-	if l.DebugEnabled(){
-		msg := fmt.Sprintf(formatMsg, a...)
-		l.Logger.Debug(msg, zap.Any("mdc", l.getTimeStampMdc()))
-	}
+	l.logf(DebugLevel, formatMsg, a)
 }
 
 func (l *Logger)Errorf(formatMsg string, a ...interface{})  {
-	msg := fmt.Sprintf(formatMsg, a...)
-	l.Logger.Error(msg, zap.Any("mdc", l.getTimeStampMdc()))
+	l.logf(ErrorLevel, formatMsg, a)
 }
 
 func (l *Logger)Warnf(formatMsg string, a ...interface{})  {
-	msg := fmt.Sprintf(formatMsg, a...)
-	l.Logger.Warn(msg, zap.Any("mdc", l.getTimeStampMdc()))
+	l.logf(WarnLevel, formatMsg, a)
+}
+
+func (l *Logger) logf(level LogLevel, formatMsg string, a []interface{}) {
+	if !l.levels.enabled(level) {
+		return
+	}
+	msg, ok := l.levels.message(level, formatMsg, a)
+	if !ok {
+		return
+	}
+	if entry := l.Logger.Check(zapcore.Level(level), msg); entry != nil {
+		entry.Write(zap.Any("mdc", l.getTimeStampMdc()))
+	}
 }
 
 func (l *Logger) getTimeStampMdc() map[string]string {
@@ -140,23 +141,24 @@ func (l *Logger) getTimeStampMdc() map[string]string {
 	return mdc
 }
 
+// InfoEnabled reports whether info messages of at least one component (or traced RAN) are logged
 func (l *Logger)InfoEnabled()bool{
-	return l.Logger.Core().Enabled(zap.InfoLevel)
+	return l.levels.enabled(InfoLevel)
 }
 
+// DebugEnabled reports whether debug messages of at least one component (or traced RAN) are logged
 func (l *Logger)DebugEnabled()bool{
-	return l.Logger.Core().Enabled(zap.DebugLevel)
+	return l.levels.enabled(DebugLevel)
 }
 
 func (l *Logger)DPanicf(formatMsg string, a ...interface{})  {
-	msg := fmt.Sprintf(formatMsg, a...)
-	l.Logger.DPanic(msg, zap.Any("mdc", l.getTimeStampMdc()))
+	l.logf(DPanicLevel, formatMsg, a)
 }
 
-func initLoggerByLevel(l zapcore.Level) (*zap.Logger, error) {
+func initLoggerByLevel(level zap.AtomicLevel) (*zap.Logger, error) {
 	cfg := zap.Config{
 		Encoding:         "json",
-		Level:            zap.NewAtomicLevelAt(l),
+		Level:            level,
 		OutputPaths:      []string{"stdout"},
 		ErrorOutputPaths: []string{"stderr"},
 		EncoderConfig: zapcore.EncoderConfig{
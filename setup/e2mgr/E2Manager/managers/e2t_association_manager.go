@@ -20,6 +20,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/clients"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
@@ -43,16 +44,16 @@ func NewE2TAssociationManager(logger *logger.Logger, rnibDataService services.RN
 	}
 }
 
-func (m *E2TAssociationManager) AssociateRan(e2tAddress string, nodebInfo *entities.NodebInfo) error {
+func (m *E2TAssociationManager) AssociateRan(ctx context.Context, e2tAddress string, nodebInfo *entities.NodebInfo) error {
 	ranName := nodebInfo.RanName
 	m.logger.Infof("#E2TAssociationManager.AssociateRan - Associating RAN %s to E2T Instance address: %s", ranName, e2tAddress)
 
-	err := m.associateRanAndUpdateNodeb(e2tAddress, nodebInfo)
+	err := m.associateRanAndUpdateNodeb(ctx, e2tAddress, nodebInfo)
 	if err != nil {
 		m.logger.Errorf("#E2TAssociationManager.AssociateRan - RoutingManager failure: Failed to associate RAN %s to E2T %s. Error: %s", nodebInfo, e2tAddress, err)
 		return err
 	}
-	err = m.e2tInstanceManager.AddRansToInstance(e2tAddress, []string{ranName})
+	err = m.e2tInstanceManager.AddRansToInstance(ctx, e2tAddress, []string{ranName})
 	if err != nil {
 		m.logger.Errorf("#E2TAssociationManager.AssociateRan - RAN name: %s - Failed to add RAN to E2T instance %s. Error: %s", ranName, e2tAddress, err)
 		return e2managererrors.NewRnibDbError()
@@ -61,16 +62,16 @@ func (m *E2TAssociationManager) AssociateRan(e2tAddress string, nodebInfo *entit
 	return nil
 }
 
-func (m *E2TAssociationManager) associateRanAndUpdateNodeb(e2tAddress string, nodebInfo *entities.NodebInfo) error {
+func (m *E2TAssociationManager) associateRanAndUpdateNodeb(ctx context.Context, e2tAddress string, nodebInfo *entities.NodebInfo) error {
 
-	rmErr := m.rmClient.AssociateRanToE2TInstance(e2tAddress, nodebInfo.RanName)
+	rmErr := m.rmClient.AssociateRanToE2TInstance(ctx, e2tAddress, nodebInfo.RanName)
 	if rmErr != nil {
 		nodebInfo.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	} else {
 		nodebInfo.ConnectionStatus = entities.ConnectionStatus_CONNECTED
 		nodebInfo.AssociatedE2TInstanceAddress = e2tAddress
 	}
-	rNibErr := m.rnibDataService.UpdateNodebInfo(nodebInfo)
+	rNibErr := m.rnibDataService.UpdateNodebInfo(ctx, nodebInfo)
 	if rNibErr != nil {
 		m.logger.Errorf("#E2TAssociationManager.associateRanAndUpdateNodeb - RAN name: %s - Failed to update nodeb entity in rNib. Error: %s", nodebInfo.RanName, rNibErr)
 	}
@@ -83,29 +84,29 @@ func (m *E2TAssociationManager) associateRanAndUpdateNodeb(e2tAddress string, no
 	return err
 }
 
-func (m *E2TAssociationManager) DissociateRan(e2tAddress string, ranName string) error {
+func (m *E2TAssociationManager) DissociateRan(ctx context.Context, e2tAddress string, ranName string) error {
 	m.logger.Infof("#E2TAssociationManager.DissociateRan - Dissociating RAN %s from E2T Instance address: %s", ranName, e2tAddress)
 
-	nodebInfo, rnibErr := m.rnibDataService.GetNodeb(ranName)
+	nodebInfo, rnibErr := m.rnibDataService.GetNodeb(ctx, ranName)
 	if rnibErr != nil {
 		m.logger.Errorf("#E2TAssociationManager.DissociateRan - RAN name: %s - Failed fetching RAN from rNib. Error: %s", ranName, rnibErr)
 		return rnibErr
 	}
 
 	nodebInfo.AssociatedE2TInstanceAddress = ""
-	rnibErr = m.rnibDataService.UpdateNodebInfo(nodebInfo)
+	rnibErr = m.rnibDataService.UpdateNodebInfo(ctx, nodebInfo)
 	if rnibErr != nil {
 		m.logger.Errorf("#E2TAssociationManager.DissociateRan - RAN name: %s - Failed to update RAN.AssociatedE2TInstanceAddress in rNib. Error: %s", ranName, rnibErr)
 		return rnibErr
 	}
 
-	err := m.e2tInstanceManager.RemoveRanFromInstance(ranName, e2tAddress)
+	err := m.e2tInstanceManager.RemoveRanFromInstance(ctx, ranName, e2tAddress)
 	if err != nil {
 		m.logger.Errorf("#E2TAssociationManager.DissociateRan - RAN name: %s - Failed to remove RAN from E2T instance %s. Error: %s", ranName, e2tAddress, err)
 		return err
 	}
 
-	err = m.rmClient.DissociateRanE2TInstance(e2tAddress, ranName)
+	err = m.rmClient.DissociateRanE2TInstance(ctx, e2tAddress, ranName)
 	if err != nil {
 		m.logger.Errorf("#E2TAssociationManager.DissociateRan - RoutingManager failure: Failed to dissociate RAN %s from E2T %s. Error: %s", ranName, e2tAddress, err)
 	} else {
@@ -114,16 +115,16 @@ func (m *E2TAssociationManager) DissociateRan(e2tAddress string, ranName string)
 	return nil
 }
 
-func (m *E2TAssociationManager) RemoveE2tInstance(e2tInstance *entities.E2TInstance) error {
+func (m *E2TAssociationManager) RemoveE2tInstance(ctx context.Context, e2tInstance *entities.E2TInstance) error {
 	m.logger.Infof("#E2TAssociationManager.RemoveE2tInstance -  Removing E2T %s and dessociating its associated RANs.", e2tInstance.Address)
 
-	err := m.rmClient.DeleteE2TInstance(e2tInstance.Address, e2tInstance.AssociatedRanList)
+	err := m.rmClient.DeleteE2TInstance(ctx, e2tInstance.Address, e2tInstance.AssociatedRanList)
 	if err != nil {
 		m.logger.Warnf("#E2TAssociationManager.RemoveE2tInstance - RoutingManager failure: Failed to delete E2T %s. Error: %s", e2tInstance.Address, err)
 		// log and continue
 	}
 
-	err = m.e2tInstanceManager.RemoveE2TInstance(e2tInstance.Address)
+	err = m.e2tInstanceManager.RemoveE2TInstance(ctx, e2tInstance.Address)
 	if err != nil {
 		m.logger.Errorf("#E2TAssociationManager.RemoveE2tInstance - Failed to remove E2T %s. Error: %s", e2tInstance.Address, err)
 		return err
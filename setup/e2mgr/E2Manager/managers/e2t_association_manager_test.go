@@ -21,16 +21,24 @@ package managers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/mocks"
 	"e2mgr/models"
 	"e2mgr/services"
+	"e2mgr/tracing"
 	"encoding/json"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"io/ioutil"
 	"net/http"
 	"testing"
@@ -82,7 +90,7 @@ func TestAssociateRanSuccess(t *testing.T) {
 	updatedE2tInstance.AssociatedRanList = append(updatedE2tInstance.AssociatedRanList, RanName)
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 
-	err := manager.AssociateRan(E2TAddress, nb)
+	err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -90,13 +98,41 @@ func TestAssociateRanSuccess(t *testing.T) {
 	httpClientMock.AssertExpectations(t)
 }
 
+func TestAssociateRanSpansShareParentTrace(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	manager, readerMock, writerMock, httpClientMock := initE2TAssociationManagerTest(t)
+	mockHttpClient(httpClientMock, clients.AssociateRanToE2TInstanceApiSuffix, true)
+	nb := &entities.NodebInfo{RanName: RanName, AssociatedE2TInstanceAddress: ""}
+	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
+	readerMock.On("GetE2TInstance", E2TAddress).Return(&entities.E2TInstance{Address: E2TAddress}, nil)
+	writerMock.On("SaveE2TInstance", mock.Anything).Return(nil)
+
+	ctx, parentSpan := tracing.StartSpan(context.Background(), "RMR receive", trace.SpanKindConsumer)
+	err := manager.AssociateRan(ctx, E2TAddress, nb)
+	parentSpan.End()
+
+	assert.Nil(t, err)
+	spanNames := map[string]bool{}
+	for _, span := range spanRecorder.Ended() {
+		spanNames[span.Name()] = true
+		assert.Equal(t, parentSpan.SpanContext().TraceID(), span.SpanContext().TraceID(), span.Name())
+	}
+	assert.True(t, spanNames["RoutingManagerClient POST"])
+	assert.True(t, spanNames["RnibDataService.UpdateNodebInfo"])
+	assert.True(t, spanNames["RnibDataService.SaveE2TInstance"])
+}
+
 func TestAssociateRanRoutingManagerError(t *testing.T) {
 	manager, _, writerMock, httpClientMock := initE2TAssociationManagerTest(t)
 	mockHttpClient(httpClientMock, clients.AssociateRanToE2TInstanceApiSuffix, false)
 	nb := &entities.NodebInfo{RanName: RanName, AssociatedE2TInstanceAddress: ""}
 	writerMock.On("UpdateNodebInfo", nb).Return(nil)
 
-	err := manager.AssociateRan(E2TAddress, nb)
+	err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
@@ -113,7 +149,7 @@ func TestAssociateRanUpdateNodebError(t *testing.T) {
 	updatedNb.ConnectionStatus = entities.ConnectionStatus_CONNECTED
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(e2managererrors.NewRnibDbError())
 
-	err := manager.AssociateRan(E2TAddress, nb)
+	err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -133,7 +169,7 @@ func TestAssociateRanGetE2tInstanceError(t *testing.T) {
 	var e2tInstance *entities.E2TInstance
 	readerMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance, errors.New("test"))
 
-	err := manager.AssociateRan(E2TAddress, nb)
+	err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -156,7 +192,7 @@ func TestAssociateRanSaveE2tInstanceError(t *testing.T) {
 	updatedE2tInstance.AssociatedRanList = append(updatedE2tInstance.AssociatedRanList, RanName)
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(errors.New("test"))
 
-	err := manager.AssociateRan(E2TAddress, nb)
+	err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -180,7 +216,7 @@ func TestDissociateRanSuccess(t *testing.T) {
 	updatedE2tInstance.AssociatedRanList = []string{}
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 
-	err := manager.DissociateRan(E2TAddress, RanName)
+	err := manager.DissociateRan(context.Background(), E2TAddress, RanName)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -193,7 +229,7 @@ func TestDissociateRanGetNodebError(t *testing.T) {
 	var nb *entities.NodebInfo
 	readerMock.On("GetNodeb", RanName).Return(nb, e2managererrors.NewRnibDbError())
 
-	err := manager.DissociateRan(E2TAddress, RanName)
+	err := manager.DissociateRan(context.Background(), E2TAddress, RanName)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -210,7 +246,7 @@ func TestDissociateRanUpdateNodebError(t *testing.T) {
 	updatedNb.AssociatedE2TInstanceAddress = ""
 	writerMock.On("UpdateNodebInfo", &updatedNb).Return(e2managererrors.NewRnibDbError())
 
-	err := manager.DissociateRan(E2TAddress, RanName)
+	err := manager.DissociateRan(context.Background(), E2TAddress, RanName)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -229,7 +265,7 @@ func TestDissociateRanGetE2tInstanceError(t *testing.T) {
 	var e2tInstance *entities.E2TInstance
 	readerMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance, errors.New("test"))
 
-	err := manager.DissociateRan(E2TAddress, RanName)
+	err := manager.DissociateRan(context.Background(), E2TAddress, RanName)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -252,7 +288,7 @@ func TestDissociateRanSaveE2tInstanceError(t *testing.T) {
 	updatedE2tInstance.AssociatedRanList = []string{}
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(errors.New("test"))
 
-	err := manager.DissociateRan(E2TAddress, RanName)
+	err := manager.DissociateRan(context.Background(), E2TAddress, RanName)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -276,7 +312,7 @@ func TestDissociateRanRoutingManagerError(t *testing.T) {
 	updatedE2tInstance.AssociatedRanList = []string{}
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 
-	err := manager.DissociateRan(E2TAddress, RanName)
+	err := manager.DissociateRan(context.Background(), E2TAddress, RanName)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -298,7 +334,7 @@ func TestRemoveE2tInstanceSuccessWithOrphans(t *testing.T) {
 	writerMock.On("SaveE2TAddresses", e2tAddressesNew).Return(nil)
 
 	e2tInstance1 := &entities.E2TInstance{Address: E2TAddress, AssociatedRanList:ranNamesToBeDissociated}
-	err := manager.RemoveE2tInstance(e2tInstance1)
+	err := manager.RemoveE2tInstance(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -320,7 +356,7 @@ func TestRemoveE2tInstanceFailureRoutingManager(t *testing.T) {
 
 	e2tInstance1 := &entities.E2TInstance{Address: E2TAddress, AssociatedRanList:[]string{"test1"}}
 	//readerMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance1, e2managererrors.NewRnibDbError())
-	err := manager.RemoveE2tInstance(e2tInstance1)
+	err := manager.RemoveE2tInstance(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -339,7 +375,7 @@ func TestRemoveE2tInstanceFailureInE2TInstanceManager(t *testing.T) {
 	readerMock.On("GetE2TAddresses").Return(e2tAddresses, e2managererrors.NewRnibDbError())
 
 	e2tInstance1 := &entities.E2TInstance{Address: E2TAddress, AssociatedRanList:[]string{"test1"}}
-	err := manager.RemoveE2tInstance(e2tInstance1)
+	err := manager.RemoveE2tInstance(context.Background(), e2tInstance1)
 
 	assert.NotNil(t, err)
 	readerMock.AssertExpectations(t)
@@ -360,7 +396,7 @@ func TestRemoveE2tInstanceFailureInE2tInstanceAddRansToInstance(t *testing.T) {
 	writerMock.On("SaveE2TAddresses", e2tAddressesNew).Return(nil)
 
 	e2tInstance1 := &entities.E2TInstance{Address: E2TAddress}
-	err := manager.RemoveE2tInstance(e2tInstance1)
+	err := manager.RemoveE2tInstance(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -21,6 +21,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/services"
@@ -38,18 +39,18 @@ type E2TInstancesManager struct {
 }
 
 type IE2TInstancesManager interface {
-	GetE2TAddresses() ([]string, error)
-	GetE2TInstance(e2tAddress string) (*entities.E2TInstance, error)
-	GetE2TInstances() ([]*entities.E2TInstance, error)
+	GetE2TAddresses(ctx context.Context) ([]string, error)
+	GetE2TInstance(ctx context.Context, e2tAddress string) (*entities.E2TInstance, error)
+	GetE2TInstances(ctx context.Context) ([]*entities.E2TInstance, error)
 	GetE2TInstancesNoLogs() ([]*entities.E2TInstance, error)
-	AddE2TInstance(e2tAddress string, podName string) error
-	RemoveE2TInstance(e2tAddress string) error
-	SelectE2TInstance() (string, error)
-	AddRansToInstance(e2tAddress string, ranNames []string) error
-	RemoveRanFromInstance(ranName string, e2tAddress string) error
+	AddE2TInstance(ctx context.Context, e2tAddress string, podName string) error
+	RemoveE2TInstance(ctx context.Context, e2tAddress string) error
+	SelectE2TInstance(ctx context.Context) (string, error)
+	AddRansToInstance(ctx context.Context, e2tAddress string, ranNames []string) error
+	RemoveRanFromInstance(ctx context.Context, ranName string, e2tAddress string) error
 	ResetKeepAliveTimestamp(e2tAddress string) error
-	ClearRansOfAllE2TInstances() error
-	SetE2tInstanceState(e2tAddress string, currentState entities.E2TInstanceState, newState entities.E2TInstanceState) error
+	ClearRansOfAllE2TInstances(ctx context.Context) error
+	SetE2tInstanceState(ctx context.Context, e2tAddress string, currentState entities.E2TInstanceState, newState entities.E2TInstanceState) error
 }
 
 func NewE2TInstancesManager(rnibDataService services.RNibDataService, logger *logger.Logger) *E2TInstancesManager {
@@ -59,8 +60,8 @@ func NewE2TInstancesManager(rnibDataService services.RNibDataService, logger *lo
 	}
 }
 
-func (m *E2TInstancesManager) GetE2TInstance(e2tAddress string) (*entities.E2TInstance, error) {
-	e2tInstance, err := m.rnibDataService.GetE2TInstance(e2tAddress)
+func (m *E2TInstancesManager) GetE2TInstance(ctx context.Context, e2tAddress string) (*entities.E2TInstance, error) {
+	e2tInstance, err := m.rnibDataService.GetE2TInstance(ctx, e2tAddress)
 
 	if err != nil {
 
@@ -108,8 +109,8 @@ func (m *E2TInstancesManager) GetE2TInstancesNoLogs() ([]*entities.E2TInstance,
 	return e2tInstances, nil
 }
 
-func (m *E2TInstancesManager) GetE2TAddresses() ([]string, error) {
-	e2tAddresses, err := m.rnibDataService.GetE2TAddresses()
+func (m *E2TInstancesManager) GetE2TAddresses(ctx context.Context) ([]string, error) {
+	e2tAddresses, err := m.rnibDataService.GetE2TAddresses(ctx)
 
 	if err != nil {
 
@@ -125,8 +126,8 @@ func (m *E2TInstancesManager) GetE2TAddresses() ([]string, error) {
 	return e2tAddresses, nil
 }
 
-func (m *E2TInstancesManager) GetE2TInstances() ([]*entities.E2TInstance, error) {
-	e2tAddresses, err := m.GetE2TAddresses()
+func (m *E2TInstancesManager) GetE2TInstances(ctx context.Context) ([]*entities.E2TInstance, error) {
+	e2tAddresses, err := m.GetE2TAddresses(ctx)
 
 	if err != nil {
 		return nil, e2managererrors.NewRnibDbError()
@@ -137,7 +138,7 @@ func (m *E2TInstancesManager) GetE2TInstances() ([]*entities.E2TInstance, error)
 		return []*entities.E2TInstance{}, nil
 	}
 
-	e2tInstances, err := m.rnibDataService.GetE2TInstances(e2tAddresses)
+	e2tInstances, err := m.rnibDataService.GetE2TInstances(ctx, e2tAddresses)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.GetE2TInstances - Failed retrieving E2T instances list. error: %s", err)
@@ -152,9 +153,9 @@ func (m *E2TInstancesManager) GetE2TInstances() ([]*entities.E2TInstance, error)
 	return e2tInstances, nil
 }
 
-func (m *E2TInstancesManager) ResetKeepAliveTimestampsForAllE2TInstances() {
+func (m *E2TInstancesManager) ResetKeepAliveTimestampsForAllE2TInstances(ctx context.Context) {
 
-	e2tInstances, err := m.GetE2TInstances()
+	e2tInstances, err := m.GetE2TInstances(ctx)
 
 	if err != nil {
 		m.logger.Errorf("E2TInstancesManager.ResetKeepAliveTimestampForAllE2TInstances - Couldn't reset timestamps due to a DB error")
@@ -174,7 +175,7 @@ func (m *E2TInstancesManager) ResetKeepAliveTimestampsForAllE2TInstances() {
 
 		v.KeepAliveTimestamp = time.Now().UnixNano()
 
-		err := m.rnibDataService.SaveE2TInstance(v)
+		err := m.rnibDataService.SaveE2TInstance(ctx, v)
 
 		if err != nil {
 			m.logger.Errorf("E2TInstancesManager.ResetKeepAliveTimestampForAllE2TInstances - E2T address: %s - failed resetting e2t instance keep alive timestamp. error: %s", v.Address, err)
@@ -198,20 +199,20 @@ func findActiveE2TInstanceWithMinimumAssociatedRans(e2tInstances []*entities.E2T
 	return minInstance
 }
 
-func (m *E2TInstancesManager) AddE2TInstance(e2tAddress string, podName string) error {
+func (m *E2TInstancesManager) AddE2TInstance(ctx context.Context, e2tAddress string, podName string) error {
 
 	m.mux.Lock()
 	defer m.mux.Unlock()
 
 	e2tInstance := entities.NewE2TInstance(e2tAddress, podName)
-	err := m.rnibDataService.SaveE2TInstance(e2tInstance)
+	err := m.rnibDataService.SaveE2TInstance(ctx, e2tInstance)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.AddE2TInstance - E2T Instance address: %s - Failed saving E2T instance. error: %s", e2tInstance.Address, err)
 		return err
 	}
 
-	e2tAddresses, err := m.rnibDataService.GetE2TAddresses()
+	e2tAddresses, err := m.rnibDataService.GetE2TAddresses(ctx)
 
 	if err != nil {
 
@@ -225,7 +226,7 @@ func (m *E2TInstancesManager) AddE2TInstance(e2tAddress string, podName string)
 
 	e2tAddresses = append(e2tAddresses, e2tInstance.Address)
 
-	err = m.rnibDataService.SaveE2TAddresses(e2tAddresses)
+	err = m.rnibDataService.SaveE2TAddresses(ctx, e2tAddresses)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.AddE2TInstance - E2T Instance address: %s - Failed saving E2T addresses list. error: %s", e2tInstance.Address, err)
@@ -236,12 +237,12 @@ func (m *E2TInstancesManager) AddE2TInstance(e2tAddress string, podName string)
 	return nil
 }
 
-func (m *E2TInstancesManager) RemoveRanFromInstance(ranName string, e2tAddress string) error {
+func (m *E2TInstancesManager) RemoveRanFromInstance(ctx context.Context, ranName string, e2tAddress string) error {
 
 	m.mux.Lock()
 	defer m.mux.Unlock()
 
-	e2tInstance, err := m.rnibDataService.GetE2TInstance(e2tAddress)
+	e2tInstance, err := m.rnibDataService.GetE2TInstance(ctx, e2tAddress)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.RemoveRanFromInstance - E2T Instance address: %s - Failed retrieving E2TInstance. error: %s", e2tAddress, err)
@@ -259,7 +260,7 @@ func (m *E2TInstancesManager) RemoveRanFromInstance(ranName string, e2tAddress s
 
 	e2tInstance.AssociatedRanList = e2tInstance.AssociatedRanList[:i]
 
-	err = m.rnibDataService.SaveE2TInstance(e2tInstance)
+	err = m.rnibDataService.SaveE2TInstance(ctx, e2tInstance)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.RemoveRanFromInstance - E2T Instance address: %s - Failed saving E2TInstance. error: %s", e2tAddress, err)
@@ -270,17 +271,17 @@ func (m *E2TInstancesManager) RemoveRanFromInstance(ranName string, e2tAddress s
 	return nil
 }
 
-func (m *E2TInstancesManager) RemoveE2TInstance(e2tAddress string) error {
+func (m *E2TInstancesManager) RemoveE2TInstance(ctx context.Context, e2tAddress string) error {
 	m.mux.Lock()
 	defer m.mux.Unlock()
 
-	err := m.rnibDataService.RemoveE2TInstance(e2tAddress)
+	err := m.rnibDataService.RemoveE2TInstance(ctx, e2tAddress)
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.RemoveE2TInstance - E2T Instance address: %s - Failed removing E2TInstance. error: %s", e2tAddress, err)
 		return e2managererrors.NewRnibDbError()
 	}
 
-	e2tAddresses, err := m.rnibDataService.GetE2TAddresses()
+	e2tAddresses, err := m.rnibDataService.GetE2TAddresses(ctx)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.RemoveE2TInstance - E2T Instance address: %s - Failed retrieving E2T addresses list. error: %s", e2tAddress, err)
@@ -289,7 +290,7 @@ func (m *E2TInstancesManager) RemoveE2TInstance(e2tAddress string) error {
 
 	e2tAddresses = m.removeAddressFromList(e2tAddresses, e2tAddress)
 
-	err = m.rnibDataService.SaveE2TAddresses(e2tAddresses)
+	err = m.rnibDataService.SaveE2TAddresses(ctx, e2tAddresses)
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.RemoveE2TInstance - E2T Instance address: %s - Failed saving E2T addresses list. error: %s", e2tAddress, err)
 		return e2managererrors.NewRnibDbError()
@@ -310,9 +311,9 @@ func (m *E2TInstancesManager) removeAddressFromList(e2tAddresses []string, addre
 	return newAddressList
 }
 
-func (m *E2TInstancesManager) SelectE2TInstance() (string, error) {
+func (m *E2TInstancesManager) SelectE2TInstance(ctx context.Context) (string, error) {
 
-	e2tInstances, err := m.GetE2TInstances()
+	e2tInstances, err := m.GetE2TInstances(ctx)
 
 	if err != nil {
 		return "", err
@@ -334,12 +335,12 @@ func (m *E2TInstancesManager) SelectE2TInstance() (string, error) {
 	return min.Address, nil
 }
 
-func (m *E2TInstancesManager) AddRansToInstance(e2tAddress string, ranNames []string) error {
+func (m *E2TInstancesManager) AddRansToInstance(ctx context.Context, e2tAddress string, ranNames []string) error {
 
 	m.mux.Lock()
 	defer m.mux.Unlock()
 
-	e2tInstance, err := m.rnibDataService.GetE2TInstance(e2tAddress)
+	e2tInstance, err := m.rnibDataService.GetE2TInstance(ctx, e2tAddress)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.AddRansToInstance - E2T Instance address: %s - Failed retrieving E2TInstance. error: %s", e2tAddress, err)
@@ -348,7 +349,7 @@ func (m *E2TInstancesManager) AddRansToInstance(e2tAddress string, ranNames []st
 
 	e2tInstance.AssociatedRanList = append(e2tInstance.AssociatedRanList, ranNames...)
 
-	err = m.rnibDataService.SaveE2TInstance(e2tInstance)
+	err = m.rnibDataService.SaveE2TInstance(ctx, e2tInstance)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.AddRansToInstance - E2T Instance address: %s - Failed saving E2TInstance. error: %s", e2tAddress, err)
@@ -388,11 +389,11 @@ func (m *E2TInstancesManager) ResetKeepAliveTimestamp(e2tAddress string) error {
 	return nil
 }
 
-func (m *E2TInstancesManager) SetE2tInstanceState(e2tAddress string, currentState entities.E2TInstanceState, newState entities.E2TInstanceState) error {
+func (m *E2TInstancesManager) SetE2tInstanceState(ctx context.Context, e2tAddress string, currentState entities.E2TInstanceState, newState entities.E2TInstanceState) error {
 	m.mux.Lock()
 	defer m.mux.Unlock()
 
-	e2tInstance, err := m.rnibDataService.GetE2TInstance(e2tAddress)
+	e2tInstance, err := m.rnibDataService.GetE2TInstance(ctx, e2tAddress)
 
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.SetE2tInstanceState - E2T Instance address: %s - Failed retrieving E2TInstance. error: %s", e2tAddress, err)
@@ -409,7 +410,7 @@ func (m *E2TInstancesManager) SetE2tInstanceState(e2tAddress string, currentStat
 		e2tInstance.KeepAliveTimestamp = time.Now().UnixNano()
 	}
 
-	err = m.rnibDataService.SaveE2TInstance(e2tInstance)
+	err = m.rnibDataService.SaveE2TInstance(ctx, e2tInstance)
 	if err != nil {
 		m.logger.Errorf("#E2TInstancesManager.SetE2tInstanceState - E2T Instance address: %s - Failed saving E2TInstance. error: %s", e2tInstance.Address, err)
 		return err
@@ -420,12 +421,12 @@ func (m *E2TInstancesManager) SetE2tInstanceState(e2tAddress string, currentStat
 	return nil
 }
 
-func (m *E2TInstancesManager) ClearRansOfAllE2TInstances() error {
+func (m *E2TInstancesManager) ClearRansOfAllE2TInstances(ctx context.Context) error {
 	m.logger.Infof("#E2TInstancesManager.ClearRansOfAllE2TInstances - Going to clear associated RANs from E2T instances")
 	m.mux.Lock()
 	defer m.mux.Unlock()
 
-	e2tInstances, err := m.GetE2TInstances()
+	e2tInstances, err := m.GetE2TInstances(ctx)
 
 	if err != nil {
 		return err
@@ -438,7 +439,7 @@ func (m *E2TInstancesManager) ClearRansOfAllE2TInstances() error {
 
 	for _, v := range e2tInstances {
 		v.AssociatedRanList = []string{}
-		err := m.rnibDataService.SaveE2TInstance(v)
+		err := m.rnibDataService.SaveE2TInstance(ctx, v)
 
 		if err != nil {
 			m.logger.Errorf("#E2TInstancesManager.ClearRansOfAllE2TInstances - e2t address: %s - failed saving e2t instance. error: %s", v.Address, err)
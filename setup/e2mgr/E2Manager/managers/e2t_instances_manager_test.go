@@ -20,6 +20,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
@@ -55,7 +56,7 @@ func initE2TInstancesManagerTest(t *testing.T) (*mocks.RnibReaderMock, *mocks.Rn
 func TestAddNewE2TInstanceSaveE2TInstanceFailure(t *testing.T) {
 	rnibReaderMock, rnibWriterMock, e2tInstancesManager := initE2TInstancesManagerTest(t)
 	rnibWriterMock.On("SaveE2TInstance", mock.Anything).Return(common.NewInternalError(errors.New("Error")))
-	err := e2tInstancesManager.AddE2TInstance(E2TAddress, PodName)
+	err := e2tInstancesManager.AddE2TInstance(context.Background(), E2TAddress, PodName)
 	assert.NotNil(t, err)
 	rnibReaderMock.AssertNotCalled(t, "GetE2TAddresses")
 }
@@ -65,7 +66,7 @@ func TestAddNewE2TInstanceGetE2TAddressesInternalFailure(t *testing.T) {
 	rnibWriterMock.On("SaveE2TInstance", mock.Anything).Return(nil)
 	e2tAddresses := []string{}
 	rnibReaderMock.On("GetE2TAddresses").Return(e2tAddresses, common.NewInternalError(errors.New("Error")))
-	err := e2tInstancesManager.AddE2TInstance(E2TAddress, PodName)
+	err := e2tInstancesManager.AddE2TInstance(context.Background(), E2TAddress, PodName)
 	assert.NotNil(t, err)
 	rnibReaderMock.AssertNotCalled(t, "SaveE2TAddresses")
 }
@@ -77,7 +78,7 @@ func TestAddNewE2TInstanceSaveE2TAddressesFailure(t *testing.T) {
 	rnibReaderMock.On("GetE2TAddresses").Return(E2TAddresses, nil)
 	E2TAddresses = append(E2TAddresses, E2TAddress)
 	rnibWriterMock.On("SaveE2TAddresses", E2TAddresses).Return(common.NewResourceNotFoundError(""))
-	err := e2tInstancesManager.AddE2TInstance(E2TAddress, PodName)
+	err := e2tInstancesManager.AddE2TInstance(context.Background(), E2TAddress, PodName)
 	assert.NotNil(t, err)
 }
 
@@ -88,7 +89,7 @@ func TestAddNewE2TInstanceNoE2TAddressesSuccess(t *testing.T) {
 	rnibReaderMock.On("GetE2TAddresses").Return(e2tAddresses, common.NewResourceNotFoundError(""))
 	e2tAddresses = append(e2tAddresses, E2TAddress)
 	rnibWriterMock.On("SaveE2TAddresses", e2tAddresses).Return(nil)
-	err := e2tInstancesManager.AddE2TInstance(E2TAddress, PodName)
+	err := e2tInstancesManager.AddE2TInstance(context.Background(), E2TAddress, PodName)
 	assert.Nil(t, err)
 	rnibWriterMock.AssertCalled(t, "SaveE2TAddresses", e2tAddresses)
 }
@@ -100,7 +101,7 @@ func TestAddNewE2TInstanceEmptyE2TAddressesSuccess(t *testing.T) {
 	rnibReaderMock.On("GetE2TAddresses").Return(e2tAddresses, nil)
 	e2tAddresses = append(e2tAddresses, E2TAddress)
 	rnibWriterMock.On("SaveE2TAddresses", e2tAddresses).Return(nil)
-	err := e2tInstancesManager.AddE2TInstance(E2TAddress, PodName)
+	err := e2tInstancesManager.AddE2TInstance(context.Background(), E2TAddress, PodName)
 	assert.Nil(t, err)
 	rnibWriterMock.AssertCalled(t, "SaveE2TAddresses", e2tAddresses)
 }
@@ -112,7 +113,7 @@ func TestAddNewE2TInstanceExistingE2TAddressesSuccess(t *testing.T) {
 	rnibReaderMock.On("GetE2TAddresses").Return(E2TAddresses, nil)
 	E2TAddresses = append(E2TAddresses, E2TAddress)
 	rnibWriterMock.On("SaveE2TAddresses", E2TAddresses).Return(nil)
-	err := e2tInstancesManager.AddE2TInstance(E2TAddress, PodName)
+	err := e2tInstancesManager.AddE2TInstance(context.Background(), E2TAddress, PodName)
 	assert.Nil(t, err)
 }
 
@@ -120,7 +121,7 @@ func TestGetE2TInstanceFailure(t *testing.T) {
 	rnibReaderMock, _, e2tInstancesManager := initE2TInstancesManagerTest(t)
 	var e2tInstance *entities.E2TInstance
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance, common.NewInternalError(fmt.Errorf("for test")))
-	res, err := e2tInstancesManager.GetE2TInstance(E2TAddress)
+	res, err := e2tInstancesManager.GetE2TInstance(context.Background(), E2TAddress)
 	assert.NotNil(t, err)
 	assert.Nil(t, res)
 }
@@ -130,7 +131,7 @@ func TestGetE2TInstanceSuccess(t *testing.T) {
 	address := "10.10.2.15:9800"
 	e2tInstance := entities.NewE2TInstance(address, PodName)
 	rnibReaderMock.On("GetE2TInstance", address).Return(e2tInstance, nil)
-	res, err := e2tInstancesManager.GetE2TInstance(address)
+	res, err := e2tInstancesManager.GetE2TInstance(context.Background(), address)
 	assert.Nil(t, err)
 	assert.Equal(t, e2tInstance, res)
 }
@@ -141,7 +142,7 @@ func TestAddRanToInstanceGetInstanceFailure(t *testing.T) {
 	var e2tInstance1 *entities.E2TInstance
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance1, common.NewInternalError(fmt.Errorf("for test")))
 
-	err := e2tInstancesManager.AddRansToInstance(E2TAddress, []string{"test1"})
+	err := e2tInstancesManager.AddRansToInstance(context.Background(), E2TAddress, []string{"test1"})
 	assert.NotNil(t, err)
 	rnibWriterMock.AssertNotCalled(t, "SaveE2TInstance")
 }
@@ -152,7 +153,7 @@ func TestAddRanToInstanceSaveInstanceFailure(t *testing.T) {
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance1, nil)
 	rnibWriterMock.On("SaveE2TInstance", mock.Anything).Return(common.NewInternalError(fmt.Errorf("for test")))
 
-	err := e2tInstancesManager.AddRansToInstance(E2TAddress, []string{"test1"})
+	err := e2tInstancesManager.AddRansToInstance(context.Background(), E2TAddress, []string{"test1"})
 	assert.NotNil(t, err)
 	rnibReaderMock.AssertExpectations(t)
 	rnibWriterMock.AssertExpectations(t)
@@ -168,7 +169,7 @@ func TestAddRanToInstanceSuccess(t *testing.T) {
 
 	rnibWriterMock.On("SaveE2TInstance", &updateE2TInstance).Return(nil)
 
-	err := e2tInstancesManager.AddRansToInstance(E2TAddress, []string{"test1"})
+	err := e2tInstancesManager.AddRansToInstance(context.Background(), E2TAddress, []string{"test1"})
 	assert.Nil(t, err)
 	rnibReaderMock.AssertExpectations(t)
 	rnibWriterMock.AssertExpectations(t)
@@ -179,7 +180,7 @@ func TestRemoveRanFromInstanceGetInstanceFailure(t *testing.T) {
 
 	var e2tInstance1 *entities.E2TInstance
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance1, common.NewInternalError(fmt.Errorf("for test")))
-	err := e2tInstancesManager.RemoveRanFromInstance("test1", E2TAddress)
+	err := e2tInstancesManager.RemoveRanFromInstance(context.Background(), "test1", E2TAddress)
 	assert.NotNil(t, err)
 	rnibWriterMock.AssertNotCalled(t, "SaveE2TInstance")
 }
@@ -191,7 +192,7 @@ func TestRemoveRanFromInstanceSaveInstanceFailure(t *testing.T) {
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance1, nil)
 	rnibWriterMock.On("SaveE2TInstance", mock.Anything).Return(common.NewInternalError(fmt.Errorf("for test")))
 
-	err := e2tInstancesManager.RemoveRanFromInstance("test1", E2TAddress)
+	err := e2tInstancesManager.RemoveRanFromInstance(context.Background(), "test1", E2TAddress)
 	assert.NotNil(t, err)
 	rnibReaderMock.AssertExpectations(t)
 	rnibWriterMock.AssertExpectations(t)
@@ -207,7 +208,7 @@ func TestRemoveRanFromInstanceSuccess(t *testing.T) {
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance, nil)
 	rnibWriterMock.On("SaveE2TInstance", &updatedE2TInstance).Return(nil)
 
-	err := e2tInstancesManager.RemoveRanFromInstance("test1", E2TAddress)
+	err := e2tInstancesManager.RemoveRanFromInstance(context.Background(), "test1", E2TAddress)
 	assert.Nil(t, err)
 	rnibReaderMock.AssertExpectations(t)
 	rnibWriterMock.AssertExpectations(t)
@@ -217,7 +218,7 @@ func TestSelectE2TInstancesGetE2TAddressesFailure(t *testing.T) {
 	rnibReaderMock, rnibWriterMock, e2tInstancesManager := initE2TInstancesManagerTest(t)
 
 	rnibReaderMock.On("GetE2TAddresses").Return([]string{}, common.NewInternalError(fmt.Errorf("for test")))
-	address, err := e2tInstancesManager.SelectE2TInstance()
+	address, err := e2tInstancesManager.SelectE2TInstance(context.Background())
 	assert.NotNil(t, err)
 	assert.Empty(t, address)
 	rnibReaderMock.AssertExpectations(t)
@@ -228,7 +229,7 @@ func TestSelectE2TInstancesEmptyE2TAddressList(t *testing.T) {
 	rnibReaderMock, rnibWriterMock, e2tInstancesManager := initE2TInstancesManagerTest(t)
 
 	rnibReaderMock.On("GetE2TAddresses").Return([]string{}, nil)
-	address, err := e2tInstancesManager.SelectE2TInstance()
+	address, err := e2tInstancesManager.SelectE2TInstance(context.Background())
 	assert.NotNil(t, err)
 	assert.Empty(t, address)
 	rnibReaderMock.AssertExpectations(t)
@@ -241,7 +242,7 @@ func TestSelectE2TInstancesGetE2TInstancesFailure(t *testing.T) {
 	addresses := []string{E2TAddress}
 	rnibReaderMock.On("GetE2TAddresses").Return(addresses, nil)
 	rnibReaderMock.On("GetE2TInstances", addresses).Return([]*entities.E2TInstance{}, common.NewInternalError(fmt.Errorf("for test")))
-	address, err := e2tInstancesManager.SelectE2TInstance()
+	address, err := e2tInstancesManager.SelectE2TInstance(context.Background())
 	assert.NotNil(t, err)
 	assert.Empty(t, address)
 	rnibReaderMock.AssertExpectations(t)
@@ -254,7 +255,7 @@ func TestSelectE2TInstancesEmptyE2TInstancesList(t *testing.T) {
 	addresses := []string{E2TAddress}
 	rnibReaderMock.On("GetE2TAddresses").Return(addresses, nil)
 	rnibReaderMock.On("GetE2TInstances", addresses).Return([]*entities.E2TInstance{}, nil)
-	address, err := e2tInstancesManager.SelectE2TInstance()
+	address, err := e2tInstancesManager.SelectE2TInstance(context.Background())
 	assert.NotNil(t, err)
 	assert.Empty(t, address)
 	rnibReaderMock.AssertExpectations(t)
@@ -273,7 +274,7 @@ func TestSelectE2TInstancesNoActiveE2TInstance(t *testing.T) {
 
 	rnibReaderMock.On("GetE2TAddresses").Return(addresses, nil)
 	rnibReaderMock.On("GetE2TInstances", addresses).Return([]*entities.E2TInstance{e2tInstance1, e2tInstance2}, nil)
-	address, err := e2tInstancesManager.SelectE2TInstance()
+	address, err := e2tInstancesManager.SelectE2TInstance(context.Background())
 	assert.NotNil(t, err)
 	assert.Equal(t, "", address)
 	rnibReaderMock.AssertExpectations(t)
@@ -290,7 +291,7 @@ func TestSelectE2TInstancesSuccess(t *testing.T) {
 
 	rnibReaderMock.On("GetE2TAddresses").Return(addresses, nil)
 	rnibReaderMock.On("GetE2TInstances", addresses).Return([]*entities.E2TInstance{e2tInstance1, e2tInstance2}, nil)
-	address, err := e2tInstancesManager.SelectE2TInstance()
+	address, err := e2tInstancesManager.SelectE2TInstance(context.Background())
 	assert.Nil(t, err)
 	assert.Equal(t, E2TAddress, address)
 	rnibReaderMock.AssertExpectations(t)
@@ -306,7 +307,7 @@ func TestActivateE2TInstanceSuccess(t *testing.T) {
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance1, nil)
 	rnibWriterMock.On("SaveE2TInstance", mock.MatchedBy(func(e2tInstance *entities.E2TInstance) bool { return e2tInstance.State == entities.Active })).Return(nil)
 
-	err := e2tInstancesManager.SetE2tInstanceState(E2TAddress, entities.ToBeDeleted, entities.Active)
+	err := e2tInstancesManager.SetE2tInstanceState(context.Background(), E2TAddress, entities.ToBeDeleted, entities.Active)
 	assert.Nil(t, err)
 	assert.Equal(t, entities.Active, e2tInstance1.State)
 	rnibWriterMock.AssertExpectations(t)
@@ -318,7 +319,7 @@ func TestActivateE2TInstance_RnibError(t *testing.T) {
 	var e2tInstance1 *entities.E2TInstance
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance1, common.NewInternalError(errors.New("for test")))
 
-	err := e2tInstancesManager.SetE2tInstanceState(E2TAddress, entities.ToBeDeleted, entities.Active)
+	err := e2tInstancesManager.SetE2tInstanceState(context.Background(), E2TAddress, entities.ToBeDeleted, entities.Active)
 	assert.NotNil(t, err)
 	rnibWriterMock.AssertExpectations(t)
 }
@@ -329,7 +330,7 @@ func TestActivateE2TInstance_NoInstance(t *testing.T) {
 	var e2tInstance1 *entities.E2TInstance
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance1, e2managererrors.NewResourceNotFoundError())
 
-	err := e2tInstancesManager.SetE2tInstanceState(E2TAddress, entities.ToBeDeleted, entities.Active)
+	err := e2tInstancesManager.SetE2tInstanceState(context.Background(), E2TAddress, entities.ToBeDeleted, entities.Active)
 
 	assert.NotNil(t, err)
 	rnibWriterMock.AssertNotCalled(t, "SaveE2TInstance")
@@ -391,14 +392,14 @@ func TestResetKeepAliveTimestampToBeDeleted(t *testing.T) {
 func TestResetKeepAliveTimestampsForAllE2TInstancesGetE2TInstancesFailure(t *testing.T) {
 	rnibReaderMock, rnibWriterMock, e2tInstancesManager := initE2TInstancesManagerTest(t)
 	rnibReaderMock.On("GetE2TAddresses").Return([]string{}, common.NewInternalError(errors.New("Error")))
-	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances(context.Background())
 	rnibWriterMock.AssertNotCalled(t, "SaveE2TInstance")
 }
 
 func TestResetKeepAliveTimestampsForAllE2TInstancesNoInstances(t *testing.T) {
 	rnibReaderMock, rnibWriterMock, e2tInstancesManager := initE2TInstancesManagerTest(t)
 	rnibReaderMock.On("GetE2TAddresses").Return([]string{}, nil)
-	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances(context.Background())
 	rnibWriterMock.AssertNotCalled(t, "SaveE2TInstance")
 }
 
@@ -411,7 +412,7 @@ func TestResetKeepAliveTimestampsForAllE2TInstancesNoActiveInstances(t *testing.
 	e2tInstance2 := entities.NewE2TInstance(E2TAddress2, PodName)
 	e2tInstance2.State = entities.ToBeDeleted
 	rnibReaderMock.On("GetE2TInstances", e2tAddresses).Return([]*entities.E2TInstance{e2tInstance1, e2tInstance2}, nil)
-	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances(context.Background())
 	rnibWriterMock.AssertNotCalled(t, "SaveE2TInstance")
 }
 
@@ -425,7 +426,7 @@ func TestResetKeepAliveTimestampsForAllE2TInstancesOneActiveInstance(t *testing.
 	e2tInstance2.State = entities.ToBeDeleted
 	rnibReaderMock.On("GetE2TInstances", e2tAddresses).Return([]*entities.E2TInstance{e2tInstance1, e2tInstance2}, nil)
 	rnibWriterMock.On("SaveE2TInstance", mock.Anything).Return(nil)
-	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances(context.Background())
 	rnibWriterMock.AssertNumberOfCalls(t, "SaveE2TInstance",1)
 }
 
@@ -439,7 +440,7 @@ func TestResetKeepAliveTimestampsForAllE2TInstancesSaveE2TInstanceFailure(t *tes
 	e2tInstance2.State = entities.ToBeDeleted
 	rnibReaderMock.On("GetE2TInstances", e2tAddresses).Return([]*entities.E2TInstance{e2tInstance1, e2tInstance2}, nil)
 	rnibWriterMock.On("SaveE2TInstance", mock.Anything).Return(common.NewInternalError(errors.New("Error")))
-	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances(context.Background())
 	rnibWriterMock.AssertNumberOfCalls(t, "SaveE2TInstance",1)
 }
 
@@ -451,7 +452,7 @@ func TestRemoveE2TInstanceSuccess(t *testing.T) {
 	e2tAddressesNew := []string{E2TAddress2}
 	rnibWriterMock.On("SaveE2TAddresses", e2tAddressesNew).Return(nil)
 
-	err := e2tInstancesManager.RemoveE2TInstance(E2TAddress)
+	err := e2tInstancesManager.RemoveE2TInstance(context.Background(), E2TAddress)
 	assert.Nil(t, err)
 	rnibReaderMock.AssertExpectations(t)
 	rnibWriterMock.AssertExpectations(t)
@@ -461,7 +462,7 @@ func TestRemoveE2TInstanceRnibErrorInRemoveInstance(t *testing.T) {
 	rnibReaderMock, rnibWriterMock, e2tInstancesManager := initE2TInstancesManagerTest(t)
 	rnibWriterMock.On("RemoveE2TInstance", E2TAddress).Return(e2managererrors.NewRnibDbError())
 
-	err := e2tInstancesManager.RemoveE2TInstance(E2TAddress)
+	err := e2tInstancesManager.RemoveE2TInstance(context.Background(), E2TAddress)
 	assert.NotNil(t, err)
 	assert.IsType(t, e2managererrors.NewRnibDbError(), err)
 	rnibReaderMock.AssertExpectations(t)
@@ -474,7 +475,7 @@ func TestRemoveE2TInstanceRnibErrorInGetAddresses(t *testing.T) {
 	var e2tAddresses []string
 	rnibReaderMock.On("GetE2TAddresses").Return(e2tAddresses, e2managererrors.NewRnibDbError())
 
-	err := e2tInstancesManager.RemoveE2TInstance(E2TAddress)
+	err := e2tInstancesManager.RemoveE2TInstance(context.Background(), E2TAddress)
 	assert.NotNil(t, err)
 	assert.IsType(t, e2managererrors.NewRnibDbError(), err)
 	rnibReaderMock.AssertExpectations(t)
@@ -489,7 +490,7 @@ func TestRemoveE2TInstanceRnibErrorInSaveAddresses(t *testing.T) {
 	e2tAddressesNew := []string{E2TAddress2}
 	rnibWriterMock.On("SaveE2TAddresses", e2tAddressesNew).Return(e2managererrors.NewRnibDbError())
 
-	err := e2tInstancesManager.RemoveE2TInstance(E2TAddress)
+	err := e2tInstancesManager.RemoveE2TInstance(context.Background(), E2TAddress)
 	assert.NotNil(t, err)
 	assert.IsType(t, e2managererrors.NewRnibDbError(), err)
 	rnibReaderMock.AssertExpectations(t)
@@ -504,7 +505,7 @@ func TestSetE2tInstanceStateCurrentStateHasChanged(t *testing.T) {
 
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance, nil)
 
-	err := e2tInstancesManager.SetE2tInstanceState(E2TAddress, entities.ToBeDeleted, entities.Active)
+	err := e2tInstancesManager.SetE2tInstanceState(context.Background(), E2TAddress, entities.ToBeDeleted, entities.Active)
 	assert.NotNil(t, err)
 	assert.IsType(t, e2managererrors.NewInternalError(), err)
 	rnibReaderMock.AssertExpectations(t)
@@ -518,7 +519,7 @@ func TestSetE2tInstanceStateErrorInSaveE2TInstance(t *testing.T) {
 	rnibReaderMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance, nil)
 	rnibWriterMock.On("SaveE2TInstance", mock.Anything).Return(common.NewInternalError(fmt.Errorf("for testing")))
 
-	err := e2tInstancesManager.SetE2tInstanceState(E2TAddress, entities.ToBeDeleted, entities.Active)
+	err := e2tInstancesManager.SetE2tInstanceState(context.Background(), E2TAddress, entities.ToBeDeleted, entities.Active)
 	assert.NotNil(t, err)
 	assert.IsType(t, &common.InternalError{}, err)
 	rnibReaderMock.AssertExpectations(t)
@@ -528,7 +529,7 @@ func TestClearRansOfAllE2TInstancesEmptyList(t *testing.T) {
 	rnibReaderMock, rnibWriterMock, e2tInstancesManager := initE2TInstancesManagerTest(t)
 	E2TAddresses := []string{}
 	rnibReaderMock.On("GetE2TAddresses").Return(E2TAddresses, nil)
-	err := e2tInstancesManager.ClearRansOfAllE2TInstances()
+	err := e2tInstancesManager.ClearRansOfAllE2TInstances(context.Background())
 	assert.Nil(t, err)
 	rnibReaderMock.AssertExpectations(t)
 	rnibWriterMock.AssertExpectations(t)
@@ -546,7 +547,7 @@ func TestClearRansOfAllE2TInstancesErrorInSaveE2TInstance(t *testing.T) {
 	rnibReaderMock.On("GetE2TInstances", addresses).Return([]*entities.E2TInstance{e2tInstance1, e2tInstance2}, nil)
 	rnibWriterMock.On("SaveE2TInstance", mock.MatchedBy(func(e2tInstance *entities.E2TInstance) bool { return e2tInstance.Address == E2TAddress})).Return(common.NewInternalError(fmt.Errorf("for testing")))
 	rnibWriterMock.On("SaveE2TInstance", mock.MatchedBy(func(e2tInstance *entities.E2TInstance) bool { return e2tInstance.Address == E2TAddress2})).Return(nil)
-	err := e2tInstancesManager.ClearRansOfAllE2TInstances()
+	err := e2tInstancesManager.ClearRansOfAllE2TInstances(context.Background())
 	assert.Nil(t, err)
 	rnibReaderMock.AssertExpectations(t)
 	rnibWriterMock.AssertExpectations(t)
@@ -21,11 +21,15 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services/rmrsender"
+	"e2mgr/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"sync/atomic"
 	"time"
 )
 
@@ -35,6 +39,9 @@ type E2TKeepAliveWorker struct {
 	e2TInstancesManager IE2TInstancesManager
 	rmrSender           *rmrsender.RmrSender
 	config              *configuration.Configuration
+	lastExecution       *int64
+	delayMs             *int64
+	responseTimeoutMs   *int64
 }
 
 func NewE2TKeepAliveWorker(logger *logger.Logger, rmrSender *rmrsender.RmrSender, e2TInstancesManager IE2TInstancesManager, e2tShutdownManager IE2TShutdownManager, config *configuration.Configuration) E2TKeepAliveWorker {
@@ -44,22 +51,68 @@ func NewE2TKeepAliveWorker(logger *logger.Logger, rmrSender *rmrsender.RmrSender
 		e2TInstancesManager: e2TInstancesManager,
 		rmrSender:           rmrSender,
 		config:              config,
+		lastExecution:       new(int64),
+		delayMs:             newInt64(config.KeepAliveDelayMs),
+		responseTimeoutMs:   newInt64(config.KeepAliveResponseTimeoutMs),
 	}
 }
 
+func newInt64(value int) *int64 {
+	v := int64(value)
+	return &v
+}
+
+// SetTimings changes the keep alive delay and response timeout, used when the configuration is reloaded.
+// A new delay takes effect after the current tick
+func (h E2TKeepAliveWorker) SetTimings(delayMs int, responseTimeoutMs int) {
+	atomic.StoreInt64(h.delayMs, int64(delayMs))
+	atomic.StoreInt64(h.responseTimeoutMs, int64(responseTimeoutMs))
+}
+
+// Delay returns the current keep alive delay, which changes when the configuration is reloaded
+func (h E2TKeepAliveWorker) Delay() time.Duration {
+	return time.Duration(atomic.LoadInt64(h.delayMs)) * time.Millisecond
+}
+
 func (h E2TKeepAliveWorker) Execute() {
 
 	h.logger.Infof("#E2TKeepAliveWorker.Execute - keep alive started")
 
-	ticker := time.NewTicker(time.Duration(h.config.KeepAliveDelayMs) * time.Millisecond)
+	delay := h.Delay()
+	ticker := time.NewTicker(delay)
+	h.markExecution()
 
-	for _ = range ticker.C {
+	for {
+		<-ticker.C
 
 		h.SendKeepAliveRequest()
 		h.E2TKeepAliveExpired()
+		h.markExecution()
+
+		if newDelay := h.Delay(); newDelay != delay {
+			h.logger.Infof("#E2TKeepAliveWorker.Execute - keep alive delay changed from %s to %s", delay, newDelay)
+			ticker.Stop()
+			delay = newDelay
+			ticker = time.NewTicker(delay)
+		}
 	}
 }
 
+func (h E2TKeepAliveWorker) markExecution() {
+	atomic.StoreInt64(h.lastExecution, time.Now().UnixNano())
+}
+
+// LastExecution returns the time the keep alive loop last completed an iteration (zero if it never started)
+func (h E2TKeepAliveWorker) LastExecution() time.Time {
+	lastExecution := atomic.LoadInt64(h.lastExecution)
+
+	if lastExecution == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, lastExecution)
+}
+
 func (h E2TKeepAliveWorker) E2TKeepAliveExpired() {
 
 	e2tInstances, err := h.e2TInstancesManager.GetE2TInstancesNoLogs()
@@ -71,19 +124,22 @@ func (h E2TKeepAliveWorker) E2TKeepAliveExpired() {
 	for _, e2tInstance := range e2tInstances {
 
 		delta := int64(time.Now().UnixNano()) - e2tInstance.KeepAliveTimestamp
-		timestampNanosec := int64(time.Duration(h.config.KeepAliveResponseTimeoutMs) * time.Millisecond)
+		timestampNanosec := int64(time.Duration(atomic.LoadInt64(h.responseTimeoutMs)) * time.Millisecond)
 
 		if delta > timestampNanosec {
 
 			h.logger.Warnf("#E2TKeepAliveWorker.E2TKeepAliveExpired - e2t address: %s time expired, shutdown e2 instance", e2tInstance.Address)
 
-			h.e2tShutdownManager.Shutdown(e2tInstance)
+			ctx, span := tracing.StartSpan(context.Background(), "E2TKeepAliveWorker.E2TKeepAliveExpired", trace.SpanKindInternal,
+				tracing.E2TAddressKey.String(e2tInstance.Address))
+			err := h.e2tShutdownManager.Shutdown(ctx, e2tInstance)
+			tracing.EndSpan(span, err)
 		}
 	}
 }
 
 func (h E2TKeepAliveWorker) SendKeepAliveRequest() {
 
-	rmrMessage := models.RmrMessage{MsgType: rmrCgo.E2_TERM_KEEP_ALIVE_REQ}
+	rmrMessage := models.RmrMessage{MsgType: rmrtypes.E2_TERM_KEEP_ALIVE_REQ}
 	h.rmrSender.SendWithoutLogs(&rmrMessage)
 }
@@ -24,11 +24,12 @@ import (
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
@@ -60,13 +61,13 @@ func initE2TKeepAliveTest(t *testing.T) (*mocks.RmrMessengerMock, *mocks.RnibRea
 func TestSendKeepAliveRequest(t *testing.T) {
 	rmrMessengerMock, _, _, _, e2tKeepAliveWorker := initE2TKeepAliveTest(t)
 
-	rmrMessengerMock.On("SendMsg", mock.Anything, false).Return(&rmrCgo.MBuf{}, nil)
+	rmrMessengerMock.On("SendMsg", mock.Anything, false).Return(&rmrtypes.MBuf{}, nil)
 
 	e2tKeepAliveWorker.SendKeepAliveRequest()
 
 	var payload, xAction []byte
 	var msgSrc unsafe.Pointer
-	req := rmrCgo.NewMBuf(rmrCgo.E2_TERM_KEEP_ALIVE_REQ, 0, "", &payload, &xAction, msgSrc)
+	req := rmrtypes.NewMBuf(rmrtypes.E2_TERM_KEEP_ALIVE_REQ, 0, "", &payload, &xAction, msgSrc)
 
 	rmrMessengerMock.AssertCalled(t, "SendMsg", req, false)
 }
@@ -190,7 +191,7 @@ func TestExecute_Two_E2TExpired(t *testing.T) {
 	readerMock.On("GetE2TAddresses").Return(addresses, nil)
 	readerMock.On("GetE2TInstances",addresses).Return([]*entities.E2TInstance{e2tInstance1}, nil)
 	e2tShutdownManagerMock.On("Shutdown", e2tInstance1).Return(nil)
-	rmrMessengerMock.On("SendMsg", mock.Anything, false).Return(&rmrCgo.MBuf{}, nil)
+	rmrMessengerMock.On("SendMsg", mock.Anything, false).Return(&rmrtypes.MBuf{}, nil)
 
 	go e2tKeepAliveWorker.Execute()
 
@@ -198,8 +199,35 @@ func TestExecute_Two_E2TExpired(t *testing.T) {
 
 	var payload, xAction []byte
 	var msgSrc unsafe.Pointer
-	req := rmrCgo.NewMBuf(rmrCgo.E2_TERM_KEEP_ALIVE_REQ, 0, "", &payload, &xAction, msgSrc)
+	req := rmrtypes.NewMBuf(rmrtypes.E2_TERM_KEEP_ALIVE_REQ, 0, "", &payload, &xAction, msgSrc)
 
 	rmrMessengerMock.AssertCalled(t, "SendMsg", req, false)
 	e2tShutdownManagerMock.AssertCalled(t, "Shutdown", e2tInstance1)
+	assert.WithinDuration(t, time.Now(), e2tKeepAliveWorker.LastExecution(), time.Second)
+}
+
+func TestSetTimingsChangesResponseTimeout(t *testing.T) {
+	_, readerMock, _, e2tShutdownManagerMock, e2tKeepAliveWorker := initE2TKeepAliveTest(t)
+
+	addresses := []string{E2TAddress}
+	e2tInstance1 := entities.NewE2TInstance(E2TAddress, PodName)
+	e2tInstance1.KeepAliveTimestamp = time.Now().Add(-time.Second).UnixNano()
+
+	readerMock.On("GetE2TAddresses").Return(addresses, nil)
+	readerMock.On("GetE2TInstances", addresses).Return([]*entities.E2TInstance{e2tInstance1}, nil)
+	e2tShutdownManagerMock.On("Shutdown", e2tInstance1).Return(nil)
+
+	e2tKeepAliveWorker.SetTimings(100, 2000)
+	e2tKeepAliveWorker.E2TKeepAliveExpired()
+	e2tShutdownManagerMock.AssertNotCalled(t, "Shutdown", e2tInstance1)
+
+	e2tKeepAliveWorker.SetTimings(100, 500)
+	e2tKeepAliveWorker.E2TKeepAliveExpired()
+	e2tShutdownManagerMock.AssertCalled(t, "Shutdown", e2tInstance1)
+}
+
+func TestLastExecutionNotStarted(t *testing.T) {
+	_, _, _, _, e2tKeepAliveWorker := initE2TKeepAliveTest(t)
+
+	assert.True(t, e2tKeepAliveWorker.LastExecution().IsZero())
 }
\ No newline at end of file
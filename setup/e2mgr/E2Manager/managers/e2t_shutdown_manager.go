@@ -21,8 +21,10 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
+	"e2mgr/models"
 	"e2mgr/services"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -30,7 +32,7 @@ import (
 )
 
 type IE2TShutdownManager interface {
-	Shutdown(e2tInstance *entities.E2TInstance) error
+	Shutdown(ctx context.Context, e2tInstance *entities.E2TInstance) error
 }
 
 type E2TShutdownManager struct {
@@ -53,7 +55,7 @@ func NewE2TShutdownManager(logger *logger.Logger, config *configuration.Configur
 	}
 }
 
-func (m E2TShutdownManager) Shutdown(e2tInstance *entities.E2TInstance) error {
+func (m E2TShutdownManager) Shutdown(ctx context.Context, e2tInstance *entities.E2TInstance) error {
 	m.logger.Infof("#E2TShutdownManager.Shutdown - E2T %s is Dead, RIP", e2tInstance.Address)
 
 	isE2tInstanceBeingDeleted := m.isE2tInstanceAlreadyBeingDeleted(e2tInstance)
@@ -62,21 +64,23 @@ func (m E2TShutdownManager) Shutdown(e2tInstance *entities.E2TInstance) error {
 		return nil
 	}
 
-	//go m.kubernetesManager.DeletePod(e2tInstance.PodName)
+	if m.config.Kubernetes.Enabled {
+		go m.kubernetesManager.DeletePod(e2tInstance.PodName)
+	}
 
-	err := m.markE2tInstanceToBeDeleted(e2tInstance)
+	err := m.markE2tInstanceToBeDeleted(ctx, e2tInstance)
 	if err != nil {
 		m.logger.Errorf("#E2TShutdownManager.Shutdown - Failed to mark E2T %s as 'ToBeDeleted'.", e2tInstance.Address)
 		return err
 	}
 
-	err = m.clearNodebsAssociation(e2tInstance.AssociatedRanList)
+	err = m.clearNodebsAssociation(ctx, e2tInstance.Address, e2tInstance.AssociatedRanList)
 	if err != nil {
 		m.logger.Errorf("#E2TShutdownManager.Shutdown - Failed to clear nodebs association to E2T %s.", e2tInstance.Address)
 		return err
 	}
 
-	err = m.e2tAssociationManager.RemoveE2tInstance(e2tInstance)
+	err = m.e2tAssociationManager.RemoveE2tInstance(ctx, e2tInstance)
 	if err != nil {
 		m.logger.Errorf("#E2TShutdownManager.Shutdown - Failed to remove E2T %s.", e2tInstance.Address)
 		return err
@@ -86,9 +90,9 @@ func (m E2TShutdownManager) Shutdown(e2tInstance *entities.E2TInstance) error {
 	return nil
 }
 
-func (m E2TShutdownManager) clearNodebsAssociation(ranNamesToBeDissociated []string) error {
+func (m E2TShutdownManager) clearNodebsAssociation(ctx context.Context, e2tAddress string, ranNamesToBeDissociated []string) error {
 	for _, ranName := range ranNamesToBeDissociated {
-		nodeb, err := m.rnibDataService.GetNodeb(ranName)
+		nodeb, err := m.rnibDataService.GetNodeb(ctx, ranName)
 		if err != nil {
 			m.logger.Warnf("#E2TShutdownManager.associateAndSetupNodebs - Failed to get nodeb %s from db.", ranName)
 			_, ok := err.(*common.ResourceNotFoundError)
@@ -97,23 +101,30 @@ func (m E2TShutdownManager) clearNodebsAssociation(ranNamesToBeDissociated []str
 			}
 			return err
 		}
+		previousStatus := nodeb.ConnectionStatus
 		nodeb.AssociatedE2TInstanceAddress = ""
 		nodeb.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 
-		err = m.rnibDataService.UpdateNodebInfo(nodeb)
+		err = m.rnibDataService.UpdateNodebInfo(ctx, nodeb)
 		if err != nil {
 			m.logger.Errorf("#E2TShutdownManager.associateAndSetupNodebs - Failed to save nodeb %s from db.", ranName)
 			return err
 		}
+
+		ranStatusChange := models.NewRanStatusChange(previousStatus, nodeb.ConnectionStatus, "E2T instance "+e2tAddress+" shut down", "E2TShutdownManager")
+		err = m.rnibDataService.AddRanStatusChange(ctx, ranName, ranStatusChange)
+		if err != nil {
+			m.logger.Warnf("#E2TShutdownManager.clearNodebsAssociation - Failed to add status change to nodeb %s status history.", ranName)
+		}
 	}
 	return nil
 }
 
-func (m E2TShutdownManager) markE2tInstanceToBeDeleted(e2tInstance *entities.E2TInstance) error {
+func (m E2TShutdownManager) markE2tInstanceToBeDeleted(ctx context.Context, e2tInstance *entities.E2TInstance) error {
 	e2tInstance.State = entities.ToBeDeleted
 	e2tInstance.DeletionTimestamp = time.Now().UnixNano()
 
-	return m.rnibDataService.SaveE2TInstance(e2tInstance)
+	return m.rnibDataService.SaveE2TInstance(ctx, e2tInstance)
 }
 
 func (m E2TShutdownManager) isE2tInstanceAlreadyBeingDeleted(e2tInstance *entities.E2TInstance) bool {
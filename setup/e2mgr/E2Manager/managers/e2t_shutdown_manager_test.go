@@ -22,6 +22,7 @@ package managers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
@@ -35,8 +36,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io/ioutil"
-	//"k8s.io/apimachinery/pkg/runtime"
-	//"k8s.io/client-go/kubernetes/fake"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 	"net/http"
 	"testing"
 	"time"
@@ -47,6 +49,7 @@ const E2TAddress3 = "10.10.2.17:9800"
 func initE2TShutdownManagerTest(t *testing.T) (*E2TShutdownManager, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.HttpClientMock, *KubernetesManager) {
 	log := initLog(t)
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, E2TInstanceDeletionTimeoutMs: 15000}
+	config.Kubernetes.Enabled = true
 
 	readerMock := &mocks.RnibReaderMock{}
 	writerMock := &mocks.RnibWriterMock{}
@@ -56,14 +59,12 @@ func initE2TShutdownManagerTest(t *testing.T) (*E2TShutdownManager, *mocks.RnibR
 	httpClientMock := &mocks.HttpClientMock{}
 	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock)
 	associationManager := NewE2TAssociationManager(log, rnibDataService, e2tInstancesManager, rmClient)
-	//kubernetesManager := initKubernetesManagerTest(t)
+	kubernetesManager := initKubernetesManagerTest(t)
+	kubernetesManager.ClientSet = fake.NewSimpleClientset()
 
-	/*shutdownManager := NewE2TShutdownManager(log, config, rnibDataService, e2tInstancesManager, associationManager, kubernetesManager)
+	shutdownManager := NewE2TShutdownManager(log, config, rnibDataService, e2tInstancesManager, associationManager, kubernetesManager)
 
-	return shutdownManager, readerMock, writerMock, httpClientMock, kubernetesManager*/
-	shutdownManager := NewE2TShutdownManager(log, config, rnibDataService, e2tInstancesManager, associationManager, nil)
-
-	return shutdownManager, readerMock, writerMock, httpClientMock, nil
+	return shutdownManager, readerMock, writerMock, httpClientMock, kubernetesManager
 }
 
 func TestShutdownSuccess1OutOf3Instances(t *testing.T) {
@@ -112,7 +113,7 @@ func TestShutdownSuccess1OutOf3Instances(t *testing.T) {
 	nodeb5connected.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &nodeb5connected).Return(nil)
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -138,7 +139,7 @@ func TestShutdownSuccess1InstanceWithoutRans(t *testing.T) {
 	readerMock.On("GetE2TAddresses").Return([]string{E2TAddress}, nil)
 	writerMock.On("SaveE2TAddresses", []string{}).Return(nil)
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -178,7 +179,7 @@ func TestShutdownSuccess1Instance2Rans(t *testing.T) {
 	nodeb2new.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &nodeb2new).Return(nil)
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -195,7 +196,7 @@ func TestShutdownE2tInstanceAlreadyBeingDeleted(t *testing.T) {
 	e2tInstance1.AssociatedRanList = []string{"test1"}
 	e2tInstance1.DeletionTimestamp = time.Now().UnixNano()
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -212,7 +213,7 @@ func TestShutdownFailureMarkInstanceAsToBeDeleted(t *testing.T) {
 	e2tInstance1.AssociatedRanList = []string{"test1", "test2", "test5"}
 	writerMock.On("SaveE2TInstance", mock.MatchedBy(func(e2tInstance *entities.E2TInstance) bool { return e2tInstance.Address == E2TAddress && e2tInstance.State == entities.ToBeDeleted })).Return(e2managererrors.NewRnibDbError())
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.NotNil(t, err)
 	readerMock.AssertExpectations(t)
@@ -267,7 +268,7 @@ func TestShutdownFailureRoutingManagerError(t *testing.T) {
 	nodeb5connected.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &nodeb5connected).Return(nil)
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -292,7 +293,7 @@ func TestShutdownFailureInClearNodebsAssociation(t *testing.T) {
 	nodeb1new.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &nodeb1new).Return(common.NewInternalError(fmt.Errorf("for tests")))
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.NotNil(t, err)
 	readerMock.AssertExpectations(t)
@@ -318,7 +319,7 @@ func TestShutdownResourceNotFoundErrorInGetNodeb(t *testing.T) {
 	nodeb1new.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &nodeb1new).Return(nil)
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.NotNil(t, err)
 	readerMock.AssertExpectations(t)
@@ -354,7 +355,7 @@ func TestShutdownResourceGeneralErrorInGetNodeb(t *testing.T) {
 	nodeb2new.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &nodeb2new).Return(nil)
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -405,14 +406,14 @@ func TestShutdownFailureInRemoveE2TInstance(t *testing.T) {
 	nodeb5connected.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &nodeb5connected).Return(nil)
 
-	err := shutdownManager.Shutdown(e2tInstance1)
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
 	httpClientMock.AssertExpectations(t)
 }
-/*
+
 func TestShutdownSuccess2Instance2Rans(t *testing.T) {
 	shutdownManager, readerMock, writerMock, httpClientMock,kubernetesManager  := initE2TShutdownManagerTest(t)
 
@@ -452,9 +453,10 @@ func TestShutdownSuccess2Instance2Rans(t *testing.T) {
 	t.Run(test.description, func(t *testing.T) {
 		kubernetesManager.ClientSet = fake.NewSimpleClientset(test.objs...)
 
-		err := shutdownManager.Shutdown(e2tInstance1)
+		err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 		assert.Nil(t, err)
+		waitForPodDeletion(t, kubernetesManager, "oran", PodName)
 		readerMock.AssertExpectations(t)
 		writerMock.AssertExpectations(t)
 		httpClientMock.AssertExpectations(t)
@@ -500,11 +502,50 @@ func TestShutdownSuccess2Instance2RansNoPod(t *testing.T) {
 	t.Run(test.description, func(t *testing.T) {
 		kubernetesManager.ClientSet = fake.NewSimpleClientset(test.objs...)
 
-		err := shutdownManager.Shutdown(e2tInstance1)
+		err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
 
 		assert.Nil(t, err)
 		readerMock.AssertExpectations(t)
 		writerMock.AssertExpectations(t)
 		httpClientMock.AssertExpectations(t)
 	})
-}*/
\ No newline at end of file
+}
+
+func TestShutdownKubernetesDisabledKeepsPod(t *testing.T) {
+	shutdownManager, readerMock, writerMock, httpClientMock, kubernetesManager := initE2TShutdownManagerTest(t)
+	shutdownManager.config.Kubernetes.Enabled = false
+	kubernetesManager.ClientSet = fake.NewSimpleClientset(pod("oran", PodName))
+
+	e2tInstance1 := entities.NewE2TInstance(E2TAddress, PodName)
+	e2tInstance1.State = entities.Active
+	writerMock.On("SaveE2TInstance", mock.MatchedBy(func(e2tInstance *entities.E2TInstance) bool { return e2tInstance.Address == E2TAddress && e2tInstance.State == entities.ToBeDeleted })).Return(nil)
+
+	data := models.NewRoutingManagerDeleteRequestModel(E2TAddress, nil, nil)
+	marshaled, _ := json.Marshal(data)
+	body := bytes.NewBuffer(marshaled)
+	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
+	httpClientMock.On("Delete", "e2t", "application/json", body).Return(&http.Response{StatusCode: http.StatusCreated, Body: respBody}, nil)
+
+	writerMock.On("RemoveE2TInstance", E2TAddress).Return(nil)
+	readerMock.On("GetE2TAddresses").Return([]string{E2TAddress}, nil)
+	writerMock.On("SaveE2TAddresses", []string{}).Return(nil)
+
+	err := shutdownManager.Shutdown(context.Background(), e2tInstance1)
+
+	assert.Nil(t, err)
+	time.Sleep(100 * time.Millisecond)
+	_, err = kubernetesManager.ClientSet.CoreV1().Pods("oran").Get(PodName, metaV1.GetOptions{})
+	assert.Nil(t, err)
+}
+
+// waitForPodDeletion waits for the pod deletion the shutdown manager runs in the background
+func waitForPodDeletion(t *testing.T, kubernetesManager *KubernetesManager, namespace string, podName string) {
+	for i := 0; i < 50; i++ {
+		_, err := kubernetesManager.ClientSet.CoreV1().Pods(namespace).Get(podName, metaV1.GetOptions{})
+		if err != nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("#waitForPodDeletion - pod %s of namespace %s was not deleted", podName, namespace)
+}
\ No newline at end of file
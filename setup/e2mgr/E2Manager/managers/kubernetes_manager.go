@@ -22,8 +22,13 @@ package managers
 
 import (
 	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
 	"e2mgr/logger"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"path/filepath"
 )
 
 type KubernetesManager struct {
@@ -33,36 +38,49 @@ type KubernetesManager struct {
 }
 
 func NewKubernetesManager(logger *logger.Logger, config *configuration.Configuration) *KubernetesManager {
-	return &KubernetesManager{
-		Logger:    logger,
-		//ClientSet: createClientSet(logger, config),
-		Config:    config,
+	kubernetesManager := &KubernetesManager{
+		Logger: logger,
+		Config: config,
 	}
+
+	if config.Kubernetes.Enabled {
+		kubernetesManager.ClientSet = createClientSet(logger, config)
+	}
+
+	return kubernetesManager
 }
 
-/*func createClientSet(logger *logger.Logger, config *configuration.Configuration) kubernetes.Interface {
+func createClientSet(logger *logger.Logger, config *configuration.Configuration) kubernetes.Interface {
 
-	absConfigPath,err := filepath.Abs(config.Kubernetes.ConfigPath)
+	kubernetesConfig, err := buildKubernetesConfig(config.Kubernetes.ConfigPath)
 	if err != nil {
 		logger.Errorf("#KubernetesManager.init - error: %s", err)
 		return nil
 	}
 
-	kubernetesConfig, err := clientcmd.BuildConfigFromFlags("", absConfigPath)
+	clientSet, err := kubernetes.NewForConfig(kubernetesConfig)
 	if err != nil {
 		logger.Errorf("#KubernetesManager.init - error: %s", err)
 		return nil
 	}
+	return clientSet
+}
 
-	clientSet, err := kubernetes.NewForConfig(kubernetesConfig)
+// buildKubernetesConfig reads the kubeconfig file at configPath, or uses the service account of the pod when empty
+func buildKubernetesConfig(configPath string) (*rest.Config, error) {
+	if len(configPath) == 0 {
+		return rest.InClusterConfig()
+	}
+
+	absConfigPath, err := filepath.Abs(configPath)
 	if err != nil {
-		logger.Errorf("#KubernetesManager.init - error: %s", err)
-		return nil
+		return nil, err
 	}
-	return clientSet
-}*/
 
-/*func (km KubernetesManager) DeletePod(podName string) error {
+	return clientcmd.BuildConfigFromFlags("", absConfigPath)
+}
+
+func (km KubernetesManager) DeletePod(podName string) error {
 	km.Logger.Infof("#KubernetesManager.DeletePod - POD name: %s ", podName)
 
 	if km.ClientSet == nil {
@@ -84,4 +102,4 @@ func NewKubernetesManager(logger *logger.Logger, config *configuration.Configura
 
 	km.Logger.Infof("#KubernetesManager.DeletePod - POD %s was deleted", podName)
 	return nil
-}*/
\ No newline at end of file
+}
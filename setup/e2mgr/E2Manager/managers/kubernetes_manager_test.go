@@ -19,16 +19,21 @@
 //  platform project (RICP).
 
 package managers
-/*
 
 import (
 	"e2mgr/configuration"
 	"e2mgr/logger"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -149,4 +154,65 @@ func pod(namespace, image string) *v1.Pod {
 		},
 	}
 }
-*/
\ No newline at end of file
+
+func TestDelete_PodIsGone(t *testing.T) {
+	kubernetesManager := initKubernetesManagerTest(t)
+	kubernetesManager.ClientSet = fake.NewSimpleClientset(pod("oran", "POD_Test_1"), pod("oran", "POD_Test_2"))
+
+	err := kubernetesManager.DeletePod("POD_Test_1")
+
+	assert.Nil(t, err)
+	pods, _ := kubernetesManager.ClientSet.CoreV1().Pods("oran").List(metaV1.ListOptions{})
+	assert.Len(t, pods.Items, 1)
+	assert.Equal(t, "POD_Test_2", pods.Items[0].Name)
+}
+
+func TestNewKubernetesManager_Disabled(t *testing.T) {
+	kubernetesManager := initKubernetesManagerTest(t)
+
+	assert.Nil(t, kubernetesManager.ClientSet)
+	assert.NotNil(t, kubernetesManager.DeletePod("POD_Test_1"))
+}
+
+func TestNewKubernetesManager_ConfigNotFound(t *testing.T) {
+	config := &configuration.Configuration{}
+	config.Kubernetes.Enabled = true
+	config.Kubernetes.ConfigPath = "no/such/kubeconfig"
+
+	kubernetesManager := NewKubernetesManager(initLog(t), config)
+
+	assert.Nil(t, kubernetesManager.ClientSet)
+}
+
+func TestDelete_ThroughKubeconfig(t *testing.T) {
+	requests := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		requests <- r.Method + " " + r.URL.Path
+		writer.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(writer, `{"kind":"Status","apiVersion":"v1","status":"Success"}`)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	if err != nil {
+		t.Fatalf("#TestDelete_ThroughKubeconfig - failed to create temp dir, error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	kubeconfig := filepath.Join(dir, "config")
+	content := fmt.Sprintf("apiVersion: v1\nkind: Config\nclusters:\n- name: sim\n  cluster:\n    server: %s\n"+
+		"contexts:\n- name: sim\n  context:\n    cluster: sim\ncurrent-context: sim\n", server.URL)
+	if err := ioutil.WriteFile(kubeconfig, []byte(content), 0644); err != nil {
+		t.Fatalf("#TestDelete_ThroughKubeconfig - failed to write kubeconfig, error: %s", err)
+	}
+
+	config := &configuration.Configuration{}
+	config.Kubernetes.Enabled = true
+	config.Kubernetes.ConfigPath = kubeconfig
+	config.Kubernetes.KubeNamespace = "ricplt"
+	kubernetesManager := NewKubernetesManager(initLog(t), config)
+
+	err = kubernetesManager.DeletePod("deployment-ricplt-e2term-alpha")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE /api/v1/namespaces/ricplt/pods/deployment-ricplt-e2term-alpha", <-requests)
+}
@@ -21,26 +21,36 @@
 package notificationmanager
 
 import (
+	"context"
+	"e2mgr/handlers/rmrmsghandlers"
 	"e2mgr/logger"
 	"e2mgr/models"
 	"e2mgr/providers/rmrmsghandlerprovider"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
+	"e2mgr/tracing"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
 	"time"
 )
 
 type NotificationManager struct {
 	logger                      *logger.Logger
 	notificationHandlerProvider *rmrmsghandlerprovider.NotificationHandlerProvider
+	inFlight                    *int64
+	decodeErrors                *rmrmsghandlers.DecodeErrors
 }
 
 func NewNotificationManager(logger *logger.Logger, notificationHandlerProvider *rmrmsghandlerprovider.NotificationHandlerProvider) *NotificationManager {
 	return &NotificationManager{
 		logger:                      logger,
 		notificationHandlerProvider: notificationHandlerProvider,
+		inFlight:                    new(int64),
+		decodeErrors:                notificationHandlerProvider.DecodeErrors(),
 	}
 }
 
-func (m NotificationManager) HandleMessage(mbuf *rmrCgo.MBuf) error {
+func (m NotificationManager) HandleMessage(mbuf *rmrtypes.MBuf) error {
 
 	notificationHandler, err := m.notificationHandlerProvider.GetNotificationHandler(mbuf.MType)
 
@@ -50,6 +60,47 @@ func (m NotificationManager) HandleMessage(mbuf *rmrCgo.MBuf) error {
 	}
 
 	notificationRequest := models.NewNotificationRequest(mbuf.Meid, *mbuf.Payload, time.Now(), *mbuf.XAction, mbuf.GetMsgSrc())
-	go notificationHandler.Handle(notificationRequest)
+
+	atomic.AddInt64(m.inFlight, 1)
+
+	// Keep alive responses arrive every few seconds from every E2T and are not traced
+	if mbuf.MType == rmrtypes.E2_TERM_KEEP_ALIVE_RESP {
+		go func() {
+			defer atomic.AddInt64(m.inFlight, -1)
+			m.handle(context.Background(), notificationHandler, notificationRequest, mbuf.MType)
+		}()
+		return nil
+	}
+
+	go func() {
+		defer atomic.AddInt64(m.inFlight, -1)
+		ctx, span := tracing.StartRmrReceiveSpan(fmt.Sprintf("%T", notificationHandler), mbuf.MType, mbuf.Meid, *mbuf.XAction)
+		defer span.End()
+		m.handle(ctx, notificationHandler, notificationRequest, mbuf.MType)
+	}()
 	return nil
 }
+
+// InFlight returns the number of notifications currently being handled
+func (m NotificationManager) InFlight() int {
+	return int(atomic.LoadInt64(m.inFlight))
+}
+
+// handle recovers from a panic of the handler, typically on a malformed payload, and counts it as a decode error of
+// the message type so that a single message does not bring E2 Manager down
+func (m NotificationManager) handle(ctx context.Context, notificationHandler rmrmsghandlers.NotificationHandler, notificationRequest *models.NotificationRequest, mType int) {
+	defer func() {
+		if r := recover(); r != nil {
+			count := m.decodeErrors.Add(mType)
+			m.logger.Errorf("#NotificationManager.handle - RAN name: %s - %T panicked handling message type %d (decode error #%d), payload: %x, error: %v\n%s",
+				notificationRequest.RanName, notificationHandler, mType, count, notificationRequest.Payload, r, debug.Stack())
+		}
+	}()
+
+	notificationHandler.Handle(ctx, notificationRequest)
+}
+
+// DecodeErrors returns the number of notifications that could not be decoded, by message type
+func (m NotificationManager) DecodeErrors() map[int]int {
+	return m.decodeErrors.Counts()
+}
@@ -20,20 +20,27 @@
 package notificationmanager
 
 import (
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/mocks"
+	"e2mgr/models"
 	"e2mgr/providers/rmrmsghandlerprovider"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
 	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"testing"
+	"time"
 )
 
 func initNotificationManagerTest(t *testing.T) (*logger.Logger, *mocks.RnibReaderMock, *NotificationManager) {
@@ -59,25 +66,100 @@ func initNotificationManagerTest(t *testing.T) (*logger.Logger, *mocks.RnibReade
 func TestHandleMessageUnexistingMessageType(t *testing.T) {
 	_, _, nm := initNotificationManagerTest(t)
 
-	mbuf := &rmrCgo.MBuf{MType: 1234}
+	mbuf := &rmrtypes.MBuf{MType: 1234}
 
 	err := nm.HandleMessage(mbuf)
 	assert.NotNil(t, err)
+	assert.Equal(t, 0, nm.InFlight())
 }
 
 func TestHandleMessageExistingMessageType(t *testing.T) {
 	_, readerMock, nm := initNotificationManagerTest(t)
 	payload := []byte("123")
 	xaction := []byte("test")
-	mbuf := &rmrCgo.MBuf{MType: rmrCgo.RIC_X2_SETUP_RESP, Meid: "test", Payload: &payload, XAction: &xaction}
+	mbuf := &rmrtypes.MBuf{MType: rmrtypes.RIC_X2_SETUP_RESP, Meid: "test", Payload: &payload, XAction: &xaction}
 	readerMock.On("GetNodeb", "test").Return(&entities.NodebInfo{}, fmt.Errorf("Some error"))
 	err := nm.HandleMessage(mbuf)
 	assert.Nil(t, err)
 }
 
+type panickingHandler struct{}
+
+func (panickingHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
+	_ = request.Payload[len(request.Payload)]
+}
+
+func TestHandleMessageRecoversFromHandlerPanic(t *testing.T) {
+	logger := initLog(t)
+	provider := rmrmsghandlerprovider.NewNotificationHandlerProvider()
+	provider.Register(rmrtypes.RIC_X2_SETUP_RESP, panickingHandler{})
+	nm := NewNotificationManager(logger, provider)
+	payload := []byte{}
+	xaction := []byte{}
+	mbuf := &rmrtypes.MBuf{MType: rmrtypes.RIC_X2_SETUP_RESP, Meid: "test", Payload: &payload, XAction: &xaction}
+
+	for i := 0; i < 2; i++ {
+		err := nm.HandleMessage(mbuf)
+		assert.Nil(t, err)
+	}
+
+	for start := time.Now(); nm.InFlight() > 0 && time.Since(start) < time.Second; {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, 0, nm.InFlight())
+	assert.Equal(t, map[int]int{rmrtypes.RIC_X2_SETUP_RESP: 2}, nm.DecodeErrors())
+}
+
+func TestHandleMessageCountsReturnedDecodeErrors(t *testing.T) {
+	_, _, nm := initNotificationManagerTest(t)
+	payload := []byte("asd")
+	xaction := []byte{}
+	mbuf := &rmrtypes.MBuf{MType: rmrtypes.E2_TERM_KEEP_ALIVE_RESP, Payload: &payload, XAction: &xaction}
+
+	err := nm.HandleMessage(mbuf)
+	assert.Nil(t, err)
+
+	for start := time.Now(); nm.InFlight() > 0 && time.Since(start) < time.Second; {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, map[int]int{rmrtypes.E2_TERM_KEEP_ALIVE_RESP: 1}, nm.DecodeErrors())
+}
+
+type contextRecordingHandler struct {
+	contexts chan context.Context
+}
+
+func (h contextRecordingHandler) Handle(ctx context.Context, request *models.NotificationRequest) {
+	h.contexts <- ctx
+}
+
+func TestHandleMessagePassesReceiveSpanContextToHandler(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	logger := initLog(t)
+	handler := contextRecordingHandler{contexts: make(chan context.Context, 1)}
+	provider := rmrmsghandlerprovider.NewNotificationHandlerProvider()
+	provider.Register(rmrtypes.RIC_X2_SETUP_RESP, handler)
+	nm := NewNotificationManager(logger, provider)
+	payload := []byte{}
+	xaction := []byte("x2setup-1")
+	mbuf := &rmrtypes.MBuf{MType: rmrtypes.RIC_X2_SETUP_RESP, Meid: "test", Payload: &payload, XAction: &xaction}
+
+	err := nm.HandleMessage(mbuf)
+	assert.Nil(t, err)
+
+	select {
+	case ctx := <-handler.contexts:
+		assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+	case <-time.After(time.Second):
+		t.Fatal("handler was not called")
+	}
+}
+
 // TODO: extract to test_utils
 func initRmrSender(rmrMessengerMock *mocks.RmrMessengerMock, log *logger.Logger) *rmrsender.RmrSender {
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrMessengerMock.On("Init", tests.GetPort(), tests.MaxMsgSize, tests.Flags, log).Return(&rmrMessenger)
 	return rmrsender.NewRmrSender(log, rmrMessenger)
 }
@@ -20,14 +20,16 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
+	"e2mgr/models"
 	"e2mgr/services"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 )
 
 type IRanDisconnectionManager interface {
-	DisconnectRan(inventoryName string) error
+	DisconnectRan(ctx context.Context, inventoryName string) error
 }
 
 type RanDisconnectionManager struct {
@@ -47,8 +49,8 @@ func NewRanDisconnectionManager(logger *logger.Logger, config *configuration.Con
 	}
 }
 
-func (m *RanDisconnectionManager) DisconnectRan(inventoryName string) error {
-	nodebInfo, err := m.rnibDataService.GetNodeb(inventoryName)
+func (m *RanDisconnectionManager) DisconnectRan(ctx context.Context, inventoryName string) error {
+	nodebInfo, err := m.rnibDataService.GetNodeb(ctx, inventoryName)
 
 	if err != nil {
 		m.logger.Errorf("#RanDisconnectionManager.DisconnectRan - RAN name: %s - Failed fetching RAN from rNib. Error: %v", inventoryName, err)
@@ -65,23 +67,24 @@ func (m *RanDisconnectionManager) DisconnectRan(inventoryName string) error {
 	}
 
 	if connectionStatus == entities.ConnectionStatus_SHUTTING_DOWN {
-		return m.updateNodebInfo(nodebInfo, entities.ConnectionStatus_SHUT_DOWN)
+		return m.updateNodebInfo(ctx, nodebInfo, entities.ConnectionStatus_SHUT_DOWN)
 	}
 
-	err = m.updateNodebInfo(nodebInfo, entities.ConnectionStatus_DISCONNECTED)
+	err = m.updateNodebInfo(ctx, nodebInfo, entities.ConnectionStatus_DISCONNECTED)
 
 	if err != nil {
 		return err
 	}
 
 	e2tAddress := nodebInfo.AssociatedE2TInstanceAddress
-	return m.e2tAssociationManager.DissociateRan(e2tAddress, nodebInfo.RanName)
+	return m.e2tAssociationManager.DissociateRan(ctx, e2tAddress, nodebInfo.RanName)
 }
 
-func (m *RanDisconnectionManager) updateNodebInfo(nodebInfo *entities.NodebInfo, connectionStatus entities.ConnectionStatus) error {
+func (m *RanDisconnectionManager) updateNodebInfo(ctx context.Context, nodebInfo *entities.NodebInfo, connectionStatus entities.ConnectionStatus) error {
 
+	previousStatus := nodebInfo.ConnectionStatus
 	nodebInfo.ConnectionStatus = connectionStatus;
-	err := m.rnibDataService.UpdateNodebInfo(nodebInfo)
+	err := m.rnibDataService.UpdateNodebInfo(ctx, nodebInfo)
 
 	if err != nil {
 		m.logger.Errorf("#RanDisconnectionManager.updateNodebInfo - RAN name: %s - Failed updating RAN's connection status to %s in rNib. Error: %v", nodebInfo.RanName, connectionStatus, err)
@@ -89,5 +92,13 @@ func (m *RanDisconnectionManager) updateNodebInfo(nodebInfo *entities.NodebInfo,
 	}
 
 	m.logger.Infof("#RanDisconnectionManager.updateNodebInfo - RAN name: %s - Successfully updated rNib. RAN's current connection status: %s", nodebInfo.RanName, nodebInfo.ConnectionStatus)
+
+	ranStatusChange := models.NewRanStatusChange(previousStatus, connectionStatus, "RAN connection lost", "RanDisconnectionManager")
+	err = m.rnibDataService.AddRanStatusChange(ctx, nodebInfo.RanName, ranStatusChange)
+
+	if err != nil {
+		m.logger.Warnf("#RanDisconnectionManager.updateNodebInfo - RAN name: %s - Failed adding status change to RAN's status history. Error: %v", nodebInfo.RanName, err)
+	}
+
 	return nil
 }
@@ -20,11 +20,12 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
@@ -62,7 +63,7 @@ func TestRanDisconnectionGetNodebFailure(t *testing.T) {
 
 	var nodebInfo *entities.NodebInfo
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, common.NewInternalError(errors.New("Error")))
-	err := ranDisconnectionManager.DisconnectRan(ranName)
+	err := ranDisconnectionManager.DisconnectRan(context.Background(), ranName)
 	assert.NotNil(t, err)
 	readerMock.AssertCalled(t, "GetNodeb", ranName)
 	writerMock.AssertNotCalled(t, "UpdateNodebInfo")
@@ -74,7 +75,7 @@ func TestShutdownRan(t *testing.T) {
 	origNodebInfo := &entities.NodebInfo{RanName: ranName, GlobalNbId: &entities.GlobalNbId{PlmnId: "xxx", NbId: "yyy"}, ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN}
 	var rnibErr error
 	readerMock.On("GetNodeb", ranName).Return(origNodebInfo, rnibErr)
-	err := ranDisconnectionManager.DisconnectRan(ranName)
+	err := ranDisconnectionManager.DisconnectRan(context.Background(), ranName)
 	assert.Nil(t, err)
 	readerMock.AssertCalled(t, "GetNodeb", ranName)
 	writerMock.AssertNotCalled(t, "UpdateNodebInfo")
@@ -89,7 +90,7 @@ func TestShuttingdownRan(t *testing.T) {
 	updatedNodebInfo := *origNodebInfo
 	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", &updatedNodebInfo).Return(rnibErr)
-	err := ranDisconnectionManager.DisconnectRan(ranName)
+	err := ranDisconnectionManager.DisconnectRan(context.Background(), ranName)
 	assert.Nil(t, err)
 	readerMock.AssertCalled(t, "GetNodeb", ranName)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 1)
@@ -104,7 +105,7 @@ func TestShuttingDownRanUpdateNodebInfoFailure(t *testing.T) {
 	updatedNodebInfo := *origNodebInfo
 	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", &updatedNodebInfo).Return(common.NewInternalError(errors.New("Error")))
-	err := ranDisconnectionManager.DisconnectRan(ranName)
+	err := ranDisconnectionManager.DisconnectRan(context.Background(), ranName)
 	assert.NotNil(t, err)
 	readerMock.AssertCalled(t, "GetNodeb", ranName)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 1)
@@ -119,7 +120,7 @@ func TestConnectingRanUpdateNodebInfoFailure(t *testing.T) {
 	updatedNodebInfo := *origNodebInfo
 	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
 	writerMock.On("UpdateNodebInfo", &updatedNodebInfo).Return(common.NewInternalError(errors.New("Error")))
-	err := ranDisconnectionManager.DisconnectRan(ranName)
+	err := ranDisconnectionManager.DisconnectRan(context.Background(), ranName)
 	assert.NotNil(t, err)
 	readerMock.AssertCalled(t, "GetNodeb", ranName)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 1)
@@ -144,7 +145,7 @@ func TestConnectingRanDisconnectSucceeds(t *testing.T) {
 	e2tInstanceToSave.AssociatedRanList = []string{}
 	writerMock.On("SaveE2TInstance", &e2tInstanceToSave).Return(nil)
 	mockHttpClient(httpClient, clients.DissociateRanE2TInstanceApiSuffix, true)
-	err := ranDisconnectionManager.DisconnectRan(ranName)
+	err := ranDisconnectionManager.DisconnectRan(context.Background(), ranName)
 	assert.Nil(t, err)
 	readerMock.AssertCalled(t, "GetNodeb", ranName)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 2)
@@ -169,7 +170,7 @@ func TestConnectingRanDissociateFailsRmError(t *testing.T) {
 	e2tInstanceToSave.AssociatedRanList = []string{}
 	writerMock.On("SaveE2TInstance", &e2tInstanceToSave).Return(nil)
 	mockHttpClient(httpClient, clients.DissociateRanE2TInstanceApiSuffix, false)
-	err := ranDisconnectionManager.DisconnectRan(ranName)
+	err := ranDisconnectionManager.DisconnectRan(context.Background(), ranName)
 	assert.Nil(t, err)
 	readerMock.AssertCalled(t, "GetNodeb", ranName)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 2)
@@ -190,7 +191,7 @@ func TestConnectingRanDissociateFailsDbError(t *testing.T) {
 	writerMock.On("UpdateNodebInfo", &updatedNodebInfo2).Return(rnibErr)
 	e2tInstance := &entities.E2TInstance{Address: e2tAddress, AssociatedRanList: []string{ranName}}
 	readerMock.On("GetE2TInstance", e2tAddress).Return(e2tInstance, common.NewInternalError(errors.New("Error")))
-	err := ranDisconnectionManager.DisconnectRan(ranName)
+	err := ranDisconnectionManager.DisconnectRan(context.Background(), ranName)
 	assert.NotNil(t, err)
 	readerMock.AssertCalled(t, "GetNodeb", ranName)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 2)
@@ -198,7 +199,7 @@ func TestConnectingRanDissociateFailsDbError(t *testing.T) {
 }
 
 func initRmrSender(rmrMessengerMock *mocks.RmrMessengerMock, log *logger.Logger) *rmrsender.RmrSender {
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrMessengerMock.On("Init", tests.GetPort(), tests.MaxMsgSize, tests.Flags, log).Return(&rmrMessenger)
 	return rmrsender.NewRmrSender(log, rmrMessenger)
 }
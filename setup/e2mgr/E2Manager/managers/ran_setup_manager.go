@@ -21,11 +21,12 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/e2pdus"
 	"e2mgr/logger"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -39,7 +40,7 @@ type RanSetupManager struct {
 }
 
 type IRanSetupManager interface {
-	ExecuteSetup(nodebInfo *entities.NodebInfo, status entities.ConnectionStatus) error
+	ExecuteSetup(ctx context.Context, nodebInfo *entities.NodebInfo, status entities.ConnectionStatus) error
 }
 
 func NewRanSetupManager(logger *logger.Logger, rmrSender *rmrsender.RmrSender, rnibDataService services.RNibDataService) *RanSetupManager {
@@ -51,40 +52,52 @@ func NewRanSetupManager(logger *logger.Logger, rmrSender *rmrsender.RmrSender, r
 }
 
 // Update retries and connection status 
-func (m *RanSetupManager) updateConnectionStatus(nodebInfo *entities.NodebInfo, status entities.ConnectionStatus) error {
+func (m *RanSetupManager) updateConnectionStatus(ctx context.Context, nodebInfo *entities.NodebInfo, status entities.ConnectionStatus) error {
 	// Update retries and connection status
+	previousStatus := nodebInfo.ConnectionStatus
 	nodebInfo.ConnectionStatus = status
-	err := m.rnibDataService.UpdateNodebInfo(nodebInfo)
+	err := m.rnibDataService.UpdateNodebInfo(ctx, nodebInfo)
 	if err != nil {
 		m.logger.Errorf("#RanSetupManager.updateConnectionStatus - Ran name: %s - Failed updating RAN's connection status to %v : %s", nodebInfo.RanName, status, err)
 	} else {
 		m.logger.Infof("#RanSetupManager.updateConnectionStatus - Ran name: %s - Successfully updated rNib. RAN's current connection status: %v", nodebInfo.RanName, status)
+		m.addRanStatusChange(ctx, nodebInfo, previousStatus, "setup request")
 	}
 	return err
 }
 
 // Decrement retries and connection status (disconnected)
-func (m *RanSetupManager) updateConnectionStatusDisconnected(nodebInfo *entities.NodebInfo) error {
+func (m *RanSetupManager) updateConnectionStatusDisconnected(ctx context.Context, nodebInfo *entities.NodebInfo) error {
 	// Update retries and connection status
+	previousStatus := nodebInfo.ConnectionStatus
 	nodebInfo.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
-	err := m.rnibDataService.UpdateNodebInfo(nodebInfo)
+	err := m.rnibDataService.UpdateNodebInfo(ctx, nodebInfo)
 	if err != nil {
 		m.logger.Errorf("#RanSetupManager.updateConnectionStatusDisconnected - Ran name: %s - Failed updating RAN's connection status to DISCONNECTED : %s", nodebInfo.RanName, err)
 	} else {
 		m.logger.Infof("#RanSetupManager.updateConnectionStatusDisconnected - Ran name: %s - Successfully updated rNib. RAN's current connection status: DISCONNECTED", nodebInfo.RanName)
+		m.addRanStatusChange(ctx, nodebInfo, previousStatus, "failed sending setup request to RMR")
 	}
 	return err
 }
 
+func (m *RanSetupManager) addRanStatusChange(ctx context.Context, nodebInfo *entities.NodebInfo, previousStatus entities.ConnectionStatus, cause string) {
+	ranStatusChange := models.NewRanStatusChange(previousStatus, nodebInfo.ConnectionStatus, cause, "RanSetupManager")
+	err := m.rnibDataService.AddRanStatusChange(ctx, nodebInfo.RanName, ranStatusChange)
+	if err != nil {
+		m.logger.Warnf("#RanSetupManager.addRanStatusChange - Ran name: %s - Failed adding status change to RAN's status history: %s", nodebInfo.RanName, err)
+	}
+}
+
 func (m *RanSetupManager) prepareSetupRequest(nodebInfo *entities.NodebInfo) (int, *models.E2RequestMessage, error) {
 	// Build the endc/x2 setup request
 	switch nodebInfo.E2ApplicationProtocol {
 	case entities.E2ApplicationProtocol_X2_SETUP_REQUEST:
-		rmrMsgType := rmrCgo.RIC_X2_SETUP_REQ
+		rmrMsgType := rmrtypes.RIC_X2_SETUP_REQ
 		request := models.NewE2RequestMessage(nodebInfo.RanName /*tid*/, nodebInfo.Ip, uint16(nodebInfo.Port), nodebInfo.RanName, e2pdus.PackedX2setupRequest)
 		return rmrMsgType, request, nil
 	case entities.E2ApplicationProtocol_ENDC_X2_SETUP_REQUEST:
-		rmrMsgType := rmrCgo.RIC_ENDC_X2_SETUP_REQ
+		rmrMsgType := rmrtypes.RIC_ENDC_X2_SETUP_REQ
 		request := models.NewE2RequestMessage(nodebInfo.RanName /*tid*/, nodebInfo.Ip, uint16(nodebInfo.Port), nodebInfo.RanName, e2pdus.PackedEndcX2setupRequest)
 		return rmrMsgType, request, nil
 	}
@@ -94,10 +107,10 @@ func (m *RanSetupManager) prepareSetupRequest(nodebInfo *entities.NodebInfo) (in
 }
 
 // ExecuteSetup updates the connection status and number of attempts in the nodebInfo and send an endc/x2 setup request to establish a connection with the RAN
-func (m *RanSetupManager) ExecuteSetup(nodebInfo *entities.NodebInfo, status entities.ConnectionStatus) error {
+func (m *RanSetupManager) ExecuteSetup(ctx context.Context, nodebInfo *entities.NodebInfo, status entities.ConnectionStatus) error {
 
 	// Update retries and connection status
-	if err := m.updateConnectionStatus(nodebInfo, status); err != nil {
+	if err := m.updateConnectionStatus(ctx, nodebInfo, status); err != nil {
 		return e2managererrors.NewRnibDbError()
 	}
 
@@ -112,12 +125,12 @@ func (m *RanSetupManager) ExecuteSetup(nodebInfo *entities.NodebInfo, status ent
 	var msgSrc unsafe.Pointer
 	msg := models.NewRmrMessage(rmrMsgType, nodebInfo.RanName, request.GetMessageAsBytes(m.logger), xAction, msgSrc)
 
-	err = m.rmrSender.Send(msg)
+	err = m.rmrSender.Send(ctx, msg)
 
 	if err != nil {
 		m.logger.Errorf("#RanSetupManager.ExecuteSetup - failed sending setup request to RMR: %s", err)
 
-		err := m.updateConnectionStatusDisconnected(nodebInfo)
+		err := m.updateConnectionStatusDisconnected(ctx, nodebInfo)
 
 		// Decrement retries and connection status (disconnected)
 		if err != nil {
@@ -21,12 +21,14 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/e2pdus"
 	"e2mgr/logger"
 	"e2mgr/mocks"
-	"e2mgr/rmrCgo"
+	"e2mgr/models"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
@@ -69,10 +71,10 @@ func TestExecuteSetupConnectingX2Setup(t *testing.T) {
 	payload := e2pdus.PackedX2setupRequest
 	xAction := []byte(ranName)
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, nil)
 
-	if err := mgr.ExecuteSetup(initialNodeb, entities.ConnectionStatus_CONNECTING); err != nil {
+	if err := mgr.ExecuteSetup(context.Background(), initialNodeb, entities.ConnectionStatus_CONNECTING); err != nil {
 		t.Errorf("want: success, got: error: %s", err)
 	}
 
@@ -93,10 +95,10 @@ func TestExecuteSetupConnectingEndcX2Setup(t *testing.T) {
 	payload := e2pdus.PackedEndcX2setupRequest
 	xAction := []byte(ranName)
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_ENDC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_ENDC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, nil)
 
-	if err := mgr.ExecuteSetup(initialNodeb, entities.ConnectionStatus_CONNECTING); err != nil {
+	if err := mgr.ExecuteSetup(context.Background(), initialNodeb, entities.ConnectionStatus_CONNECTING); err != nil {
 		t.Errorf("want: success, got: error: %s", err)
 	}
 
@@ -119,10 +121,10 @@ func TestExecuteSetupDisconnected(t *testing.T) {
 	payload := []byte{0}
 	xAction := []byte(ranName)
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, fmt.Errorf("send failure"))
 
-	if err := mgr.ExecuteSetup(initialNodeb, entities.ConnectionStatus_CONNECTING); err == nil {
+	if err := mgr.ExecuteSetup(context.Background(), initialNodeb, entities.ConnectionStatus_CONNECTING); err == nil {
 		t.Errorf("want: failure, got: success")
 	}
 
@@ -145,10 +147,10 @@ func TestExecuteSetupConnectingRnibError(t *testing.T) {
 	payload := []byte{0}
 	xAction := []byte(ranName)
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, fmt.Errorf("send failure"))
 
-	if err := mgr.ExecuteSetup(initialNodeb, entities.ConnectionStatus_CONNECTING); err == nil {
+	if err := mgr.ExecuteSetup(context.Background(), initialNodeb, entities.ConnectionStatus_CONNECTING); err == nil {
 		t.Errorf("want: failure, got: success")
 	} else {
 		assert.IsType(t, e2managererrors.NewRnibDbError(), err)
@@ -173,10 +175,10 @@ func TestExecuteSetupDisconnectedRnibError(t *testing.T) {
 	payload := []byte{0}
 	xAction := []byte(ranName)
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, fmt.Errorf("send failure"))
 
-	if err := mgr.ExecuteSetup(initialNodeb, entities.ConnectionStatus_CONNECTING); err == nil {
+	if err := mgr.ExecuteSetup(context.Background(), initialNodeb, entities.ConnectionStatus_CONNECTING); err == nil {
 		t.Errorf("want: failure, got: success")
 	} else {
 		assert.IsType(t, e2managererrors.NewRnibDbError(), err)
@@ -199,10 +201,10 @@ func TestExecuteSetupUnsupportedProtocol(t *testing.T) {
 	payload := e2pdus.PackedX2setupRequest
 	xAction := []byte(ranName)
 	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, nil)
 
-	if err := mgr.ExecuteSetup(initialNodeb, entities.ConnectionStatus_CONNECTING); err == nil {
+	if err := mgr.ExecuteSetup(context.Background(), initialNodeb, entities.ConnectionStatus_CONNECTING); err == nil {
 		t.Errorf("want: error, got: success")
 	}
 
@@ -210,6 +212,62 @@ func TestExecuteSetupUnsupportedProtocol(t *testing.T) {
 	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 0)
 }
 
+func initRanSetupManagerWithHistoryTest(t *testing.T) (*mocks.RmrMessengerMock, *mocks.RnibWriterMock, *RanSetupManager) {
+	logger := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, RanStatusHistorySize: 10}
+
+	rmrMessengerMock := &mocks.RmrMessengerMock{}
+	rmrSender := initRmrSender(rmrMessengerMock, logger)
+	writerMock := &mocks.RnibWriterMock{}
+
+	rnibDataService := services.NewRnibDataService(logger, config, &mocks.RnibReaderMock{}, writerMock)
+	return rmrMessengerMock, writerMock, NewRanSetupManager(logger, rmrSender, rnibDataService)
+}
+
+func TestExecuteSetupRecordsRanStatusChange(t *testing.T) {
+	rmrMessengerMock, writerMock, mgr := initRanSetupManagerWithHistoryTest(t)
+
+	ranName := "test1"
+
+	var initialNodeb = &entities.NodebInfo{RanName: ranName, ConnectionStatus: entities.ConnectionStatus_CONNECTED, E2ApplicationProtocol: entities.E2ApplicationProtocol_X2_SETUP_REQUEST}
+	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
+	writerMock.On("AddRanStatusChange", ranName, mock.MatchedBy(func(change *models.RanStatusChange) bool {
+		return change.PreviousStatus == "CONNECTED" && change.NewStatus == "CONNECTING" && change.Component == "RanSetupManager"
+	}), 10).Return(nil)
+
+	payload := e2pdus.PackedX2setupRequest
+	xAction := []byte(ranName)
+	var msgSrc unsafe.Pointer
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, nil)
+
+	err := mgr.ExecuteSetup(context.Background(), initialNodeb, entities.ConnectionStatus_CONNECTING)
+
+	assert.Nil(t, err)
+	writerMock.AssertNumberOfCalls(t, "AddRanStatusChange", 1)
+}
+
+func TestExecuteSetupIgnoresRanStatusHistoryFailure(t *testing.T) {
+	rmrMessengerMock, writerMock, mgr := initRanSetupManagerWithHistoryTest(t)
+
+	ranName := "test1"
+
+	var initialNodeb = &entities.NodebInfo{RanName: ranName, ConnectionStatus: entities.ConnectionStatus_CONNECTED, E2ApplicationProtocol: entities.E2ApplicationProtocol_X2_SETUP_REQUEST}
+	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
+	writerMock.On("AddRanStatusChange", ranName, mock.Anything, 10).Return(common.NewInternalError(fmt.Errorf("internal error")))
+
+	payload := e2pdus.PackedX2setupRequest
+	xAction := []byte(ranName)
+	var msgSrc unsafe.Pointer
+	msg := rmrtypes.NewMBuf(rmrtypes.RIC_X2_SETUP_REQ, len(payload), ranName, &payload, &xAction, msgSrc)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(msg, nil)
+
+	err := mgr.ExecuteSetup(context.Background(), initialNodeb, entities.ConnectionStatus_CONNECTING)
+
+	assert.Nil(t, err)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
 func initLog(t *testing.T) *logger.Logger {
 	log, err := logger.InitLogger(logger.InfoLevel)
 	if err != nil {
@@ -20,6 +20,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/enums"
 	"e2mgr/logger"
 	"e2mgr/models"
@@ -42,10 +43,10 @@ func NewRanStatusChangeManager(logger *logger.Logger, rmrSender *rmrsender.RmrSe
 }
 
 type IRanStatusChangeManager interface {
-	Execute(msgType int, msgDirection enums.MessageDirection, nodebInfo *entities.NodebInfo) error
+	Execute(ctx context.Context, msgType int, msgDirection enums.MessageDirection, nodebInfo *entities.NodebInfo) error
 }
 
-func (m *RanStatusChangeManager) Execute(msgType int, msgDirection enums.MessageDirection, nodebInfo *entities.NodebInfo) error {
+func (m *RanStatusChangeManager) Execute(ctx context.Context, msgType int, msgDirection enums.MessageDirection, nodebInfo *entities.NodebInfo) error {
 
 	resourceStatusPayload := models.NewResourceStatusPayload(nodebInfo.NodeType, msgDirection)
 	resourceStatusJson, err := json.Marshal(resourceStatusPayload)
@@ -58,5 +59,5 @@ func (m *RanStatusChangeManager) Execute(msgType int, msgDirection enums.Message
 	var xAction []byte
 	var msgSrc unsafe.Pointer
 	rmrMessage := models.NewRmrMessage(msgType, nodebInfo.RanName, resourceStatusJson, xAction, msgSrc)
-	return m.rmrSender.Send(rmrMessage)
+	return m.rmrSender.Send(ctx, rmrMessage)
 }
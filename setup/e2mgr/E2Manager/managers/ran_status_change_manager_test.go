@@ -20,10 +20,11 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/enums"
 	"e2mgr/logger"
 	"e2mgr/mocks"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services/rmrsender"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/assert"
@@ -48,7 +49,7 @@ func TestMarshalFailure(t *testing.T) {
 	m := NewRanStatusChangeManager(logger, rmrSender)
 
 	nodebInfo := entities.NodebInfo{}
-	err := m.Execute(123, 4, &nodebInfo)
+	err := m.Execute(context.Background(), 123, 4, &nodebInfo)
 
 	assert.NotNil(t, err)
 }
@@ -59,8 +60,8 @@ func TestMarshalSuccess(t *testing.T) {
 
 	nodebInfo := entities.NodebInfo{NodeType: entities.Node_ENB}
 	var err error
-	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, err)
-	err  = m.Execute(rmrCgo.RAN_CONNECTED, enums.RIC_TO_RAN, &nodebInfo)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrtypes.MBuf{}, err)
+	err  = m.Execute(context.Background(), rmrtypes.RAN_CONNECTED, enums.RIC_TO_RAN, &nodebInfo)
 
 	assert.Nil(t, err)
 }
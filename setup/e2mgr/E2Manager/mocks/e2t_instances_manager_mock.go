@@ -21,6 +21,7 @@
 package mocks
 
 import (
+	"context"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/mock"
 )
@@ -29,40 +30,40 @@ type E2TInstancesManagerMock struct {
 	mock.Mock
 }
 
-func (m *E2TInstancesManagerMock) GetE2TInstance(e2tAddress string) (*entities.E2TInstance, error) {
+func (m *E2TInstancesManagerMock) GetE2TInstance(ctx context.Context, e2tAddress string) (*entities.E2TInstance, error) {
 	args := m.Called(e2tAddress)
 
 	return args.Get(0).(*entities.E2TInstance), args.Error(1)
 }
 
-func (m *E2TInstancesManagerMock) AddE2TInstance(e2tInstanceAddress string, podName string) error {
+func (m *E2TInstancesManagerMock) AddE2TInstance(ctx context.Context, e2tInstanceAddress string, podName string) error {
 	args := m.Called(e2tInstanceAddress, podName)
 	return args.Error(0)
 }
 
-func (m *E2TInstancesManagerMock) RemoveE2TInstance(e2tAddress string) error {
+func (m *E2TInstancesManagerMock) RemoveE2TInstance(ctx context.Context, e2tAddress string) error {
 	args := m.Called(e2tAddress)
 	return args.Error(0)
 }
 
-func (m *E2TInstancesManagerMock) SelectE2TInstance() (string, error) {
+func (m *E2TInstancesManagerMock) SelectE2TInstance(ctx context.Context) (string, error) {
 	args := m.Called()
 	return args.String(0), args.Error(1)
 }
 
-func (m *E2TInstancesManagerMock) AddRansToInstance(e2tAddress string, ranNames []string) error {
+func (m *E2TInstancesManagerMock) AddRansToInstance(ctx context.Context, e2tAddress string, ranNames []string) error {
 	args := m.Called(e2tAddress, ranNames)
 	return args.Error(0)
 
 }
 
-func (m *E2TInstancesManagerMock) RemoveRanFromInstance(ranName string, e2tAddress string) error {
+func (m *E2TInstancesManagerMock) RemoveRanFromInstance(ctx context.Context, ranName string, e2tAddress string) error {
 	args := m.Called(ranName, e2tAddress)
 	return args.Error(0)
 
 }
 
-func (m *E2TInstancesManagerMock) GetE2TInstances() ([]*entities.E2TInstance, error) {
+func (m *E2TInstancesManagerMock) GetE2TInstances(ctx context.Context) ([]*entities.E2TInstance, error) {
 	args := m.Called()
 
 	return args.Get(0).([]*entities.E2TInstance), args.Error(1)
@@ -80,17 +81,17 @@ func (m *E2TInstancesManagerMock) ResetKeepAliveTimestamp(e2tAddress string) err
 
 }
 
-func (m *E2TInstancesManagerMock) SetE2tInstanceState(e2tAddress string, currentState entities.E2TInstanceState, newState entities.E2TInstanceState) error {
+func (m *E2TInstancesManagerMock) SetE2tInstanceState(ctx context.Context, e2tAddress string, currentState entities.E2TInstanceState, newState entities.E2TInstanceState) error {
 	args := m.Called(e2tAddress, currentState, newState)
 	return args.Error(0)
 }
 
-func (m *E2TInstancesManagerMock) GetE2TAddresses() ([]string, error) {
+func (m *E2TInstancesManagerMock) GetE2TAddresses(ctx context.Context) ([]string, error) {
 	args := m.Called()
 	return args.Get(0).([]string), args.Error(1)
 }
 
-func (m *E2TInstancesManagerMock) ClearRansOfAllE2TInstances() error {
+func (m *E2TInstancesManagerMock) ClearRansOfAllE2TInstances(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
@@ -21,6 +21,7 @@
 package mocks
 
 import (
+	"context"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/mock"
 )
@@ -29,7 +30,7 @@ type E2TShutdownManagerMock struct {
 	mock.Mock
 }
 
-func (m *E2TShutdownManagerMock) Shutdown(e2tInstance *entities.E2TInstance) error {
+func (m *E2TShutdownManagerMock) Shutdown(ctx context.Context, e2tInstance *entities.E2TInstance) error {
 	args := m.Called(e2tInstance)
 	return args.Error(0)
 }
\ No newline at end of file
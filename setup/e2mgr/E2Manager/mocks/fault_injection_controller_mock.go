@@ -0,0 +1,41 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"net/http"
+)
+
+type FaultInjectionControllerMock struct {
+	mock.Mock
+}
+
+func (m *FaultInjectionControllerMock) GetFaults(writer http.ResponseWriter, r *http.Request) {
+	m.Called()
+}
+
+func (m *FaultInjectionControllerMock) SetFaults(writer http.ResponseWriter, r *http.Request) {
+	m.Called()
+}
+
+func (m *FaultInjectionControllerMock) ClearFaults(writer http.ResponseWriter, r *http.Request) {
+	m.Called()
+}
@@ -20,6 +20,7 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 	"io"
 	"net/http"
@@ -29,12 +30,12 @@ type HttpClientMock struct {
 	mock.Mock
 }
 
-func (c *HttpClientMock) Post(url, contentType string, body io.Reader) (resp *http.Response, err error) {
+func (c *HttpClientMock) Post(ctx context.Context, url, contentType string, body io.Reader) (resp *http.Response, err error) {
 	args := c.Called(url, contentType, body)
 	return args.Get(0).(*http.Response), args.Error(1)
 }
 
-func (c *HttpClientMock) Delete(url, contentType string, body io.Reader) (resp *http.Response, err error) {
+func (c *HttpClientMock) Delete(ctx context.Context, url, contentType string, body io.Reader) (resp *http.Response, err error) {
 	args := c.Called(url, contentType, body)
 	return args.Get(0).(*http.Response), args.Error(1)
 }
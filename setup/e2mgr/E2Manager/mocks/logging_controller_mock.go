@@ -0,0 +1,60 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package mocks
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+)
+
+type LoggingControllerMock struct {
+	mock.Mock
+}
+
+func (m *LoggingControllerMock) GetLoggingConfig(writer http.ResponseWriter, r *http.Request) {
+	m.Called()
+}
+
+func (m *LoggingControllerMock) SetLogLevel(writer http.ResponseWriter, r *http.Request) {
+	m.Called()
+}
+
+func (m *LoggingControllerMock) SetComponentLogLevel(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write([]byte(mux.Vars(r)["component"]))
+	m.Called()
+}
+
+func (m *LoggingControllerMock) ResetComponentLogLevel(writer http.ResponseWriter, r *http.Request) {
+	m.Called()
+}
+
+func (m *LoggingControllerMock) TraceRan(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write([]byte(mux.Vars(r)["ranName"]))
+	m.Called()
+}
+
+func (m *LoggingControllerMock) StopRanTrace(writer http.ResponseWriter, r *http.Request) {
+	m.Called()
+}
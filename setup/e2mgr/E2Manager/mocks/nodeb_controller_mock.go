@@ -41,6 +41,28 @@ func (c *NodebControllerMock) GetNodeb(writer http.ResponseWriter, r *http.Reque
 	c.Called()
 }
 
+func (c *NodebControllerMock) GetRanStatusHistory(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+
+	vars := mux.Vars(r)
+	ranName := vars["ranName"]
+
+	writer.Write([]byte(ranName))
+	c.Called()
+}
+
+func (c *NodebControllerMock) GetRanLoadInformation(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+
+	vars := mux.Vars(r)
+	ranName := vars["ranName"]
+
+	writer.Write([]byte(ranName))
+	c.Called()
+}
+
 func (c *NodebControllerMock) GetNodebIdList(writer http.ResponseWriter, r *http.Request) {
 	c.Called()
 }
@@ -81,3 +103,25 @@ func (c *NodebControllerMock) UpdateGnb(writer http.ResponseWriter, r *http.Requ
 
 	c.Called()
 }
+
+func (c *NodebControllerMock) UpdateGnbCells(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+
+	vars := mux.Vars(r)
+	ranName := vars["ranName"]
+
+	writer.Write([]byte(ranName))
+	c.Called()
+}
+
+func (c *NodebControllerMock) UpdateEnb(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+
+	vars := mux.Vars(r)
+	ranName := vars["ranName"]
+
+	writer.Write([]byte(ranName))
+	c.Called()
+}
@@ -19,6 +19,7 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -26,7 +27,7 @@ type RanDisconnectionManagerMock struct {
 	mock.Mock
 }
 
-func (m *RanDisconnectionManagerMock) DisconnectRan(inventoryName string) error {
+func (m *RanDisconnectionManagerMock) DisconnectRan(ctx context.Context, inventoryName string) error {
 	args := m.Called(inventoryName)
 
 	return args.Error(0)
@@ -20,6 +20,7 @@
 package mocks
 
 import (
+	"context"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/mock"
 )
@@ -28,7 +29,7 @@ type RanSetupManagerMock struct {
 	mock.Mock
 }
 
-func (m *RanSetupManagerMock) ExecuteSetup(nodebInfo *entities.NodebInfo, status entities.ConnectionStatus) error {
+func (m *RanSetupManagerMock) ExecuteSetup(ctx context.Context, nodebInfo *entities.NodebInfo, status entities.ConnectionStatus) error {
 	args := m.Called(nodebInfo, status)
 
 	return args.Error(0)
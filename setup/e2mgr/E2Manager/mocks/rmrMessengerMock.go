@@ -22,7 +22,7 @@ package mocks
 
 import (
 	"e2mgr/logger"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -30,27 +30,27 @@ type RmrMessengerMock struct {
 	mock.Mock
 }
 
-func (m *RmrMessengerMock) Init(port string, maxMsgSize int, flags int, logger *logger.Logger) rmrCgo.RmrMessenger{
+func (m *RmrMessengerMock) Init(port string, maxMsgSize int, flags int, logger *logger.Logger) rmrtypes.RmrMessenger{
 	args := m.Called(port, maxMsgSize, flags, logger)
-	return args.Get(0).(rmrCgo.RmrMessenger)
+	return args.Get(0).(rmrtypes.RmrMessenger)
 }
 
-func (m *RmrMessengerMock) SendMsg(msg *rmrCgo.MBuf, printLogs bool) (*rmrCgo.MBuf, error){
+func (m *RmrMessengerMock) SendMsg(msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error){
 	args := m.Called(msg, printLogs)
-	return args.Get(0).(*rmrCgo.MBuf), args.Error(1)
+	return args.Get(0).(*rmrtypes.MBuf), args.Error(1)
 }
 
-func (m *RmrMessengerMock) WhSendMsg(msg *rmrCgo.MBuf, printLogs bool) (*rmrCgo.MBuf, error){
+func (m *RmrMessengerMock) WhSendMsg(msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error){
 	args := m.Called(msg, printLogs)
-	return args.Get(0).(*rmrCgo.MBuf), args.Error(1)
+	return args.Get(0).(*rmrtypes.MBuf), args.Error(1)
 }
 
-func (m *RmrMessengerMock) RecvMsg() (*rmrCgo.MBuf, error){
+func (m *RmrMessengerMock) RecvMsg() (*rmrtypes.MBuf, error){
 	args := m.Called()
-	return args.Get(0).(*rmrCgo.MBuf), args.Error(1)
+	return args.Get(0).(*rmrtypes.MBuf), args.Error(1)
 }
 
-func (m *RmrMessengerMock) RtsMsg(msg *rmrCgo.MBuf){
+func (m *RmrMessengerMock) RtsMsg(msg *rmrtypes.MBuf){
 	m.Called( )
 }
 
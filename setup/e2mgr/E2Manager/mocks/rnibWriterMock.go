@@ -20,6 +20,7 @@
 package mocks
 
 import (
+	"e2mgr/models"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/mock"
 )
@@ -87,8 +88,66 @@ func (rnibWriterMock *RnibWriterMock) UpdateGnbCells(nodebInfo *entities.NodebIn
 	return args.Error(0)
 }
 
+func (rnibWriterMock *RnibWriterMock) PatchGnbCells(nodebInfo *entities.NodebInfo, servedNrCellsToSet []*entities.ServedNRCell, servedNrCellsToRemove []*entities.ServedNRCell) error {
+	args := rnibWriterMock.Called(nodebInfo, servedNrCellsToSet, servedNrCellsToRemove)
+	return args.Error(0)
+}
+
+func (rnibWriterMock *RnibWriterMock) PatchEnbCells(nodebInfo *entities.NodebInfo, servedCellsToSet []*entities.ServedCellInfo, servedCellsToRemove []*entities.ServedCellInfo) error {
+	args := rnibWriterMock.Called(nodebInfo, servedCellsToSet, servedCellsToRemove)
+	return args.Error(0)
+}
+
 func (rnibWriterMock *RnibWriterMock) RemoveServedNrCells(inventoryName string, servedNrCells []*entities.ServedNRCell) error {
 	args := rnibWriterMock.Called(inventoryName, servedNrCells)
 	return args.Error(0)
 }
 
+func (rnibWriterMock *RnibWriterMock) UpdateEnbCells(nodebInfo *entities.NodebInfo, servedCells []*entities.ServedCellInfo) error {
+	args := rnibWriterMock.Called(nodebInfo, servedCells)
+	return args.Error(0)
+}
+
+func (rnibWriterMock *RnibWriterMock) RemoveServedCells(inventoryName string, servedCells []*entities.ServedCellInfo) error {
+	args := rnibWriterMock.Called(inventoryName, servedCells)
+	return args.Error(0)
+}
+
+func (rnibWriterMock *RnibWriterMock) AddRanStatusChange(inventoryName string, ranStatusChange *models.RanStatusChange, maxHistorySize int) error {
+	args := rnibWriterMock.Called(inventoryName, ranStatusChange, maxHistorySize)
+	return args.Error(0)
+}
+
+func (rnibWriterMock *RnibWriterMock) GetRanStatusHistory(inventoryName string) ([]*models.RanStatusChange, error) {
+	args := rnibWriterMock.Called(inventoryName)
+
+	errArg := args.Error(1)
+
+	if errArg != nil {
+		return nil, errArg
+	}
+
+	return args.Get(0).([]*models.RanStatusChange), nil
+}
+
+func (rnibWriterMock *RnibWriterMock) SaveCellLoadInformation(inventoryName string, ranLoadInformation *entities.RanLoadInformation) error {
+	args := rnibWriterMock.Called(inventoryName, ranLoadInformation)
+	return args.Error(0)
+}
+
+func (rnibWriterMock *RnibWriterMock) GetCellLoadInformation(inventoryName string) ([]*models.CellLoadInformation, error) {
+	args := rnibWriterMock.Called(inventoryName)
+
+	errArg := args.Error(1)
+
+	if errArg != nil {
+		return nil, errArg
+	}
+
+	return args.Get(0).([]*models.CellLoadInformation), nil
+}
+
+func (rnibWriterMock *RnibWriterMock) RemoveCellLoadInformation(inventoryName string, servedCells []*entities.ServedCellInfo, previousServedCells []*entities.ServedCellInfo) error {
+	args := rnibWriterMock.Called(inventoryName, servedCells, previousServedCells)
+	return args.Error(0)
+}
@@ -20,6 +20,7 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -27,31 +28,31 @@ type RoutingManagerClientMock struct {
 	mock.Mock
 }
 
-func (m *RoutingManagerClientMock) AddE2TInstance(e2tAddress string) error {
+func (m *RoutingManagerClientMock) AddE2TInstance(ctx context.Context, e2tAddress string) error {
 
 	args := m.Called(e2tAddress)
 	return args.Error(0)
 }
 
-func (m *RoutingManagerClientMock) AssociateRanToE2TInstance(e2tAddress string, ranName string) error {
+func (m *RoutingManagerClientMock) AssociateRanToE2TInstance(ctx context.Context, e2tAddress string, ranName string) error {
 
 	args := m.Called(e2tAddress, ranName)
 	return args.Error(0)
 }
 
-func (m *RoutingManagerClientMock) DissociateRanE2TInstance(e2tAddress string, ranName string) error {
+func (m *RoutingManagerClientMock) DissociateRanE2TInstance(ctx context.Context, e2tAddress string, ranName string) error {
 
 	args := m.Called(e2tAddress, ranName)
 	return args.Error(0)
 }
 
-func (m *RoutingManagerClientMock) DissociateAllRans(e2tAddresses []string) error {
+func (m *RoutingManagerClientMock) DissociateAllRans(ctx context.Context, e2tAddresses []string) error {
 
 args := m.Called(e2tAddresses)
 return args.Error(0)
 }
 
-func (m *RoutingManagerClientMock) DeleteE2TInstance(e2tAddress string, ransToBeDissociated []string) error {
+func (m *RoutingManagerClientMock) DeleteE2TInstance(ctx context.Context, e2tAddress string, ransToBeDissociated []string) error {
 
 	args := m.Called(e2tAddress, ransToBeDissociated)
 	return args.Error(0)
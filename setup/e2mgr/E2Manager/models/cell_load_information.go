@@ -0,0 +1,40 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"time"
+)
+
+// CellLoadInformation is the latest load information an eNB reported for one of its cells
+type CellLoadInformation struct {
+	Timestamp       time.Time
+	LoadInformation *entities.CellLoadInformation
+}
+
+// NewCellLoadInformation stamps the cell's load information with the load timestamp, in nanoseconds since the epoch,
+// of the message which carried it
+func NewCellLoadInformation(loadTimestamp uint64, loadInformation *entities.CellLoadInformation) *CellLoadInformation {
+	return &CellLoadInformation{
+		Timestamp:       time.Unix(0, int64(loadTimestamp)).UTC(),
+		LoadInformation: loadInformation,
+	}
+}
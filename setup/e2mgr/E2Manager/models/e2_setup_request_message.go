@@ -183,8 +183,15 @@ func (m *E2SetupRequestMessage) ExtractRanFunctionsList() ([]*entities.RanFuncti
 	return funcs, nil
 }
 
+// getGlobalE2NodeId returns an empty id when the request has no IEs, the ids derived from it are then empty
 func (m *E2SetupRequestMessage) getGlobalE2NodeId() GlobalE2NodeId {
-	return m.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs[0].Value.GlobalE2nodeID
+	setupRequestIes := m.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs
+
+	if len(setupRequestIes) == 0 {
+		return GlobalE2NodeId{}
+	}
+
+	return setupRequestIes[0].Value.GlobalE2nodeID
 }
 
 //func (m *E2SetupRequestMessage) GetNodeType() entities.Node_Type {
@@ -0,0 +1,36 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import "gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+
+// EnbConfigurationUpdate holds the served cell changes an eNB reports in an X2 ENB CONFIGURATION UPDATE
+type EnbConfigurationUpdate struct {
+	ServedCellsToAdd    []*entities.ServedCellInfo
+	ServedCellsToModify []*ServedCellToModify
+	// ECGIs of the cells the eNB no longer serves
+	ServedCellsToDelete []string
+}
+
+// ServedCellToModify replaces the served cell identified by OldCellId, whose own ECGI may change along the way
+type ServedCellToModify struct {
+	OldCellId      string
+	ServedCellInfo *entities.ServedCellInfo
+}
@@ -0,0 +1,40 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import "gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+
+// EndcConfigurationUpdate holds the served NR cell changes an en-gNB reports in an X2 EN-DC CONFIGURATION UPDATE. Each
+// added cell carries its complete NR neighbour information
+type EndcConfigurationUpdate struct {
+	ServedNrCellsToAdd    []*entities.ServedNRCell
+	ServedNrCellsToModify []*ServedNrCellToModify
+	// NR CGIs of the cells the en-gNB no longer serves
+	ServedNrCellsToDelete []string
+	// Set when the update comes from an eNB, which reports its E-UTRA cells rather than served NR cells
+	InitiatedByEnb bool
+}
+
+// ServedNrCellToModify replaces the served NR cell identified by OldCellId, whose own NR CGI may change along the way.
+// The NR neighbour information is optional: a ServedNrCell without any keeps the neighbours of the cell it replaces
+type ServedNrCellToModify struct {
+	OldCellId    string
+	ServedNrCell *entities.ServedNRCell
+}
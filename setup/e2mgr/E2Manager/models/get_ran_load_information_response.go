@@ -0,0 +1,81 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+	"github.com/golang/protobuf/jsonpb"
+	"time"
+)
+
+type GetRanLoadInformationResponse struct {
+	ranName string
+	cells   []*CellLoadInformation
+}
+
+type cellLoadInformationResponse struct {
+	CellId          string          `json:"cellId"`
+	Timestamp       time.Time       `json:"timestamp"`
+	LoadInformation json.RawMessage `json:"loadInformation"`
+}
+
+type ranLoadInformationResponse struct {
+	RanName string                         `json:"ranName"`
+	Cells   []*cellLoadInformationResponse `json:"cells"`
+}
+
+func NewGetRanLoadInformationResponse(ranName string, cells []*CellLoadInformation) *GetRanLoadInformationResponse {
+	return &GetRanLoadInformationResponse{
+		ranName: ranName,
+		cells:   cells,
+	}
+}
+
+// Marshal renders the load information of each cell the way the nodeb entity is rendered, with enum names
+func (response *GetRanLoadInformationResponse) Marshal() ([]byte, error) {
+	m := jsonpb.Marshaler{}
+	result := ranLoadInformationResponse{
+		RanName: response.ranName,
+		Cells:   make([]*cellLoadInformationResponse, 0, len(response.cells)),
+	}
+
+	for _, cell := range response.cells {
+		loadInformation, err := m.MarshalToString(cell.LoadInformation)
+
+		if err != nil {
+			return nil, e2managererrors.NewInternalError()
+		}
+
+		result.Cells = append(result.Cells, &cellLoadInformationResponse{
+			CellId:          cell.LoadInformation.GetCellId(),
+			Timestamp:       cell.Timestamp,
+			LoadInformation: json.RawMessage(loadInformation),
+		})
+	}
+
+	data, err := json.Marshal(result)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
@@ -0,0 +1,61 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import "gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+
+// GnbCellsDiff is the change an UpdateGnbCellsRequest makes to the served NR cells of a gNB
+type GnbCellsDiff struct {
+	Added    []*entities.ServedNRCell
+	Modified []*ServedNrCellChange
+	Deleted  []*entities.ServedNRCell
+}
+
+// ServedNrCellChange holds both versions of a modified served NR cell, whose NR CGI stays the same
+type ServedNrCellChange struct {
+	Old *entities.ServedNRCell
+	New *entities.ServedNRCell
+}
+
+func (diff *GnbCellsDiff) IsEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Modified) == 0 && len(diff.Deleted) == 0
+}
+
+// ServedNrCellsToSet returns the cells whose keys the diff writes: the added cells and the new version of the modified ones
+func (diff *GnbCellsDiff) ServedNrCellsToSet() []*entities.ServedNRCell {
+	servedNrCells := append([]*entities.ServedNRCell{}, diff.Added...)
+
+	for _, change := range diff.Modified {
+		servedNrCells = append(servedNrCells, change.New)
+	}
+
+	return servedNrCells
+}
+
+// ServedNrCellsToRemove returns the cells whose keys the diff may make stale: the deleted cells and the old version of the modified ones
+func (diff *GnbCellsDiff) ServedNrCellsToRemove() []*entities.ServedNRCell {
+	servedNrCells := append([]*entities.ServedNRCell{}, diff.Deleted...)
+
+	for _, change := range diff.Modified {
+		servedNrCells = append(servedNrCells, change.Old)
+	}
+
+	return servedNrCells
+}
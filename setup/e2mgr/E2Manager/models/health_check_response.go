@@ -0,0 +1,83 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+)
+
+const (
+	HealthStatusUp       = "UP"
+	HealthStatusDegraded = "DEGRADED"
+	HealthStatusDown     = "DOWN"
+)
+
+type HealthCheckResult struct {
+	Name       string                 `json:"name"`
+	Status     string                 `json:"status"`
+	Critical   bool                   `json:"critical"`
+	Error      string                 `json:"error,omitempty"`
+	DurationMs int64                  `json:"durationMs"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+}
+
+type HealthCheckResponse struct {
+	Status string               `json:"status"`
+	Checks []*HealthCheckResult `json:"checks"`
+}
+
+// NewHealthCheckResponse derives the overall status from the results:
+// DOWN if a critical check failed, DEGRADED if only non critical checks failed, UP otherwise
+func NewHealthCheckResponse(checks []*HealthCheckResult) *HealthCheckResponse {
+	status := HealthStatusUp
+
+	for _, check := range checks {
+		if check.Status != HealthStatusDown {
+			continue
+		}
+
+		if check.Critical {
+			status = HealthStatusDown
+			break
+		}
+
+		status = HealthStatusDegraded
+	}
+
+	if checks == nil {
+		checks = []*HealthCheckResult{}
+	}
+
+	return &HealthCheckResponse{
+		Status: status,
+		Checks: checks,
+	}
+}
+
+func (response *HealthCheckResponse) Marshal() ([]byte, error) {
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
@@ -0,0 +1,44 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"time"
+)
+
+// RanStatusChange is a single entry of a RAN's connection status history
+type RanStatusChange struct {
+	Timestamp      time.Time `json:"timestamp"`
+	PreviousStatus string    `json:"previousStatus"`
+	NewStatus      string    `json:"newStatus"`
+	Cause          string    `json:"cause"`
+	Component      string    `json:"component"`
+}
+
+func NewRanStatusChange(previousStatus entities.ConnectionStatus, newStatus entities.ConnectionStatus, cause string, component string) *RanStatusChange {
+	return &RanStatusChange{
+		Timestamp:      time.Now().UTC(),
+		PreviousStatus: previousStatus.String(),
+		NewStatus:      newStatus.String(),
+		Cause:          cause,
+		Component:      component,
+	}
+}
@@ -0,0 +1,83 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"encoding/json"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// UpdateGnbCellsRequest adds, modifies and deletes individual served NR cells of a gNB, each identified by its NR CGI
+type UpdateGnbCellsRequest struct {
+	RanName               string
+	DryRun                bool
+	ServedNrCellsToAdd    []*entities.ServedNRCell
+	ServedNrCellsToModify []*entities.ServedNRCell
+	ServedNrCellsToDelete []string
+}
+
+type updateGnbCellsRequestBody struct {
+	ServedNrCellsToAdd    []json.RawMessage `json:"servedNrCellsToAdd"`
+	ServedNrCellsToModify []json.RawMessage `json:"servedNrCellsToModify"`
+	ServedNrCellsToDelete []string          `json:"servedNrCellsToDelete"`
+}
+
+// UnmarshalJSON parses the cells the way the nodeb entity is parsed, with camel case field names and enum names
+func (request *UpdateGnbCellsRequest) UnmarshalJSON(data []byte) error {
+	body := updateGnbCellsRequestBody{}
+	err := json.Unmarshal(data, &body)
+
+	if err != nil {
+		return err
+	}
+
+	request.ServedNrCellsToAdd, err = unmarshalServedNrCells(body.ServedNrCellsToAdd)
+
+	if err != nil {
+		return err
+	}
+
+	request.ServedNrCellsToModify, err = unmarshalServedNrCells(body.ServedNrCellsToModify)
+
+	if err != nil {
+		return err
+	}
+
+	request.ServedNrCellsToDelete = body.ServedNrCellsToDelete
+	return nil
+}
+
+func unmarshalServedNrCells(rawServedNrCells []json.RawMessage) ([]*entities.ServedNRCell, error) {
+	servedNrCells := make([]*entities.ServedNRCell, 0, len(rawServedNrCells))
+
+	for _, rawServedNrCell := range rawServedNrCells {
+		servedNrCell := &entities.ServedNRCell{}
+		err := jsonpb.UnmarshalString(string(rawServedNrCell), servedNrCell)
+
+		if err != nil {
+			return nil, err
+		}
+
+		servedNrCells = append(servedNrCells, servedNrCell)
+	}
+
+	return servedNrCells, nil
+}
@@ -0,0 +1,116 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+type UpdateGnbCellsResponse struct {
+	ranName string
+	dryRun  bool
+	diff    *GnbCellsDiff
+}
+
+type servedNrCellChangeResponse struct {
+	CellId string          `json:"cellId"`
+	Old    json.RawMessage `json:"old"`
+	New    json.RawMessage `json:"new"`
+}
+
+type updateGnbCellsResponse struct {
+	RanName  string                        `json:"ranName"`
+	DryRun   bool                          `json:"dryRun"`
+	Added    []json.RawMessage             `json:"added"`
+	Modified []*servedNrCellChangeResponse `json:"modified"`
+	Deleted  []json.RawMessage             `json:"deleted"`
+}
+
+func NewUpdateGnbCellsResponse(ranName string, dryRun bool, diff *GnbCellsDiff) *UpdateGnbCellsResponse {
+	return &UpdateGnbCellsResponse{
+		ranName: ranName,
+		dryRun:  dryRun,
+		diff:    diff,
+	}
+}
+
+// Marshal renders the cells of the diff the way the nodeb entity is rendered, with enum names
+func (response *UpdateGnbCellsResponse) Marshal() ([]byte, error) {
+	m := jsonpb.Marshaler{}
+	result := updateGnbCellsResponse{
+		RanName:  response.ranName,
+		DryRun:   response.dryRun,
+		Modified: make([]*servedNrCellChangeResponse, 0, len(response.diff.Modified)),
+	}
+
+	var err error
+	result.Added, err = marshalServedNrCells(m, response.diff.Added)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	for _, change := range response.diff.Modified {
+		cells, err := marshalServedNrCells(m, []*entities.ServedNRCell{change.Old, change.New})
+
+		if err != nil {
+			return nil, e2managererrors.NewInternalError()
+		}
+
+		result.Modified = append(result.Modified, &servedNrCellChangeResponse{
+			CellId: change.New.GetServedNrCellInformation().GetCellId(),
+			Old:    cells[0],
+			New:    cells[1],
+		})
+	}
+
+	result.Deleted, err = marshalServedNrCells(m, response.diff.Deleted)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	data, err := json.Marshal(result)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
+
+func marshalServedNrCells(m jsonpb.Marshaler, servedNrCells []*entities.ServedNRCell) ([]json.RawMessage, error) {
+	result := make([]json.RawMessage, 0, len(servedNrCells))
+
+	for _, servedNrCell := range servedNrCells {
+		data, err := m.MarshalToString(servedNrCell)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, json.RawMessage(data))
+	}
+
+	return result, nil
+}
@@ -34,14 +34,18 @@ import (
 type IncomingRequest string
 
 const (
-	ShutdownRequest        IncomingRequest = "Shutdown"
-	ResetRequest           IncomingRequest = "Reset"
-	X2SetupRequest         IncomingRequest = "X2SetupRequest"
-	EndcSetupRequest       IncomingRequest = "EndcSetupRequest"
-	GetNodebRequest        IncomingRequest = "GetNodebRequest"
-	GetNodebIdListRequest  IncomingRequest = "GetNodebIdListRequest"
-	GetE2TInstancesRequest IncomingRequest = "GetE2TInstancesRequest"
-	UpdateGnbRequest       IncomingRequest = "UpdateGnbRequest"
+	ShutdownRequest              IncomingRequest = "Shutdown"
+	ResetRequest                 IncomingRequest = "Reset"
+	X2SetupRequest               IncomingRequest = "X2SetupRequest"
+	EndcSetupRequest             IncomingRequest = "EndcSetupRequest"
+	GetNodebRequest              IncomingRequest = "GetNodebRequest"
+	GetNodebIdListRequest        IncomingRequest = "GetNodebIdListRequest"
+	GetE2TInstancesRequest       IncomingRequest = "GetE2TInstancesRequest"
+	UpdateGnbRequest             IncomingRequest = "UpdateGnbRequest"
+	UpdateEnbRequest             IncomingRequest = "UpdateEnbRequest"
+	UpdateGnbCellsRequest        IncomingRequest = "UpdateGnbCellsRequest"
+	GetRanStatusHistoryRequest   IncomingRequest = "GetRanStatusHistoryRequest"
+	GetRanLoadInformationRequest IncomingRequest = "GetRanLoadInformationRequest"
 )
 
 type IncomingRequestHandlerProvider struct {
@@ -60,14 +64,18 @@ func NewIncomingRequestHandlerProvider(logger *logger.Logger, rmrSender *rmrsend
 func initRequestHandlerMap(logger *logger.Logger, rmrSender *rmrsender.RmrSender, config *configuration.Configuration, rNibDataService services.RNibDataService, ranSetupManager *managers.RanSetupManager, e2tInstancesManager managers.IE2TInstancesManager, e2tAssociationManager *managers.E2TAssociationManager, rmClient clients.IRoutingManagerClient) map[IncomingRequest]httpmsghandlers.RequestHandler {
 
 	return map[IncomingRequest]httpmsghandlers.RequestHandler{
-		ShutdownRequest:        httpmsghandlers.NewDeleteAllRequestHandler(logger, rmrSender, config, rNibDataService, e2tInstancesManager, rmClient),
-		ResetRequest:           httpmsghandlers.NewX2ResetRequestHandler(logger, rmrSender, rNibDataService),
-		X2SetupRequest:         httpmsghandlers.NewSetupRequestHandler(logger, rNibDataService, ranSetupManager, entities.E2ApplicationProtocol_X2_SETUP_REQUEST, e2tInstancesManager, e2tAssociationManager),
-		EndcSetupRequest:       httpmsghandlers.NewSetupRequestHandler(logger, rNibDataService, ranSetupManager, entities.E2ApplicationProtocol_ENDC_X2_SETUP_REQUEST, e2tInstancesManager, e2tAssociationManager),
-		GetNodebRequest:        httpmsghandlers.NewGetNodebRequestHandler(logger, rNibDataService),
-		GetNodebIdListRequest:  httpmsghandlers.NewGetNodebIdListRequestHandler(logger, rNibDataService),
-		GetE2TInstancesRequest: httpmsghandlers.NewGetE2TInstancesRequestHandler(logger, e2tInstancesManager),
-		UpdateGnbRequest:       httpmsghandlers.NewUpdateGnbRequestHandler(logger, rNibDataService),
+		ShutdownRequest:              httpmsghandlers.NewDeleteAllRequestHandler(logger, rmrSender, config, rNibDataService, e2tInstancesManager, rmClient),
+		ResetRequest:                 httpmsghandlers.NewX2ResetRequestHandler(logger, rmrSender, rNibDataService),
+		X2SetupRequest:               httpmsghandlers.NewSetupRequestHandler(logger, rNibDataService, ranSetupManager, entities.E2ApplicationProtocol_X2_SETUP_REQUEST, e2tInstancesManager, e2tAssociationManager),
+		EndcSetupRequest:             httpmsghandlers.NewSetupRequestHandler(logger, rNibDataService, ranSetupManager, entities.E2ApplicationProtocol_ENDC_X2_SETUP_REQUEST, e2tInstancesManager, e2tAssociationManager),
+		GetNodebRequest:              httpmsghandlers.NewGetNodebRequestHandler(logger, rNibDataService),
+		GetNodebIdListRequest:        httpmsghandlers.NewGetNodebIdListRequestHandler(logger, rNibDataService),
+		GetE2TInstancesRequest:       httpmsghandlers.NewGetE2TInstancesRequestHandler(logger, e2tInstancesManager),
+		UpdateGnbRequest:             httpmsghandlers.NewUpdateGnbRequestHandler(logger, rNibDataService),
+		UpdateEnbRequest:             httpmsghandlers.NewUpdateEnbRequestHandler(logger, rNibDataService),
+		UpdateGnbCellsRequest:        httpmsghandlers.NewUpdateGnbCellsRequestHandler(logger, rNibDataService),
+		GetRanStatusHistoryRequest:   httpmsghandlers.NewGetRanStatusHistoryRequestHandler(logger, rNibDataService),
+		GetRanLoadInformationRequest: httpmsghandlers.NewGetRanLoadInformationRequestHandler(logger, rNibDataService),
 	}
 }
 
@@ -28,7 +28,7 @@ import (
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/mocks"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
@@ -38,7 +38,7 @@ import (
 )
 
 func getRmrSender(rmrMessengerMock *mocks.RmrMessengerMock, log *logger.Logger) *rmrsender.RmrSender {
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrMessengerMock.On("Init", tests.GetPort(), tests.MaxMsgSize, tests.Flags, log).Return(&rmrMessenger)
 	return rmrsender.NewRmrSender(log, rmrMessenger)
 }
@@ -102,6 +102,54 @@ func TestEndcSetupRequestHandler(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestGetRanStatusHistoryRequestHandler(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(GetRanStatusHistoryRequest)
+
+	assert.NotNil(t, provider)
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.GetRanStatusHistoryRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestGetRanLoadInformationRequestHandler(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(GetRanLoadInformationRequest)
+
+	assert.NotNil(t, provider)
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.GetRanLoadInformationRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestUpdateEnbRequestHandler(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(UpdateEnbRequest)
+
+	assert.NotNil(t, provider)
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.UpdateEnbRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestUpdateGnbCellsRequestHandler(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(UpdateGnbCellsRequest)
+
+	assert.NotNil(t, provider)
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.UpdateGnbCellsRequestHandler)
+
+	assert.True(t, ok)
+}
+
 func TestGetShutdownHandlerFailure(t *testing.T) {
 	provider := setupTest(t)
 	_, actual := provider.GetHandler("test")
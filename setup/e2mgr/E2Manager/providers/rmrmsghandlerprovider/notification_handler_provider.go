@@ -26,7 +26,7 @@ import (
 	"e2mgr/handlers/rmrmsghandlers"
 	"e2mgr/logger"
 	"e2mgr/managers"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"fmt"
@@ -34,14 +34,21 @@ import (
 
 type NotificationHandlerProvider struct {
 	notificationHandlers map[int]rmrmsghandlers.NotificationHandler
+	decodeErrors         *rmrmsghandlers.DecodeErrors
 }
 
 func NewNotificationHandlerProvider() *NotificationHandlerProvider {
 	return &NotificationHandlerProvider{
 		notificationHandlers: map[int]rmrmsghandlers.NotificationHandler{},
+		decodeErrors:         rmrmsghandlers.NewDecodeErrors(),
 	}
 }
 
+// DecodeErrors returns the decode error counters shared by the handlers the provider creates
+func (provider NotificationHandlerProvider) DecodeErrors() *rmrmsghandlers.DecodeErrors {
+	return provider.decodeErrors
+}
+
 // TODO: check whether it has been initialized
 func (provider NotificationHandlerProvider) GetNotificationHandler(messageType int) (rmrmsghandlers.NotificationHandler, error) {
 	handler, ok := provider.notificationHandlers[messageType]
@@ -64,8 +71,10 @@ func (provider *NotificationHandlerProvider) Init(logger *logger.Logger, config
 	x2SetupFailureResponseConverter := converters.NewX2SetupFailureResponseConverter(logger)
 	endcSetupResponseConverter := converters.NewEndcSetupResponseConverter(logger)
 	endcSetupFailureResponseConverter := converters.NewEndcSetupFailureResponseConverter(logger)
-	//enbLoadInformationExtractor := converters.NewEnbLoadInformationExtractor(logger)
+	enbLoadInformationExtractor := converters.NewEnbLoadInformationExtractor(logger)
 	x2ResetResponseExtractor := converters.NewX2ResetResponseExtractor(logger)
+	enbConfigurationUpdateExtractor := converters.NewEnbConfigurationUpdateExtractor(logger)
+	endcConfigurationUpdateExtractor := converters.NewEndcConfigurationUpdateExtractor(logger)
 
 	// Init managers
 	ranReconnectionManager := managers.NewRanDisconnectionManager(logger, config, rnibDataService, e2tAssociationManager)
@@ -76,31 +85,34 @@ func (provider *NotificationHandlerProvider) Init(logger *logger.Logger, config
 	endcSetupFailureResponseManager := managers.NewEndcSetupFailureResponseManager(endcSetupFailureResponseConverter)
 
 	// Init handlers
-	x2SetupResponseHandler := rmrmsghandlers.NewSetupResponseNotificationHandler(logger, rnibDataService, x2SetupResponseManager, ranStatusChangeManager, rmrCgo.RIC_X2_SETUP_RESP)
-	x2SetupFailureResponseHandler := rmrmsghandlers.NewSetupResponseNotificationHandler(logger, rnibDataService, x2SetupFailureResponseManager, nil, rmrCgo.RIC_X2_SETUP_FAILURE)
-	endcSetupResponseHandler := rmrmsghandlers.NewSetupResponseNotificationHandler(logger, rnibDataService, endcSetupResponseManager, ranStatusChangeManager, rmrCgo.RIC_ENDC_X2_SETUP_RESP)
-	endcSetupFailureResponseHandler := rmrmsghandlers.NewSetupResponseNotificationHandler(logger, rnibDataService, endcSetupFailureResponseManager, nil, rmrCgo.RIC_ENDC_X2_SETUP_FAILURE)
+	x2SetupResponseHandler := rmrmsghandlers.NewSetupResponseNotificationHandler(logger, rnibDataService, x2SetupResponseManager, ranStatusChangeManager, rmrtypes.RIC_X2_SETUP_RESP, provider.decodeErrors)
+	x2SetupFailureResponseHandler := rmrmsghandlers.NewSetupResponseNotificationHandler(logger, rnibDataService, x2SetupFailureResponseManager, nil, rmrtypes.RIC_X2_SETUP_FAILURE, provider.decodeErrors)
+	endcSetupResponseHandler := rmrmsghandlers.NewSetupResponseNotificationHandler(logger, rnibDataService, endcSetupResponseManager, ranStatusChangeManager, rmrtypes.RIC_ENDC_X2_SETUP_RESP, provider.decodeErrors)
+	endcSetupFailureResponseHandler := rmrmsghandlers.NewSetupResponseNotificationHandler(logger, rnibDataService, endcSetupFailureResponseManager, nil, rmrtypes.RIC_ENDC_X2_SETUP_FAILURE, provider.decodeErrors)
 	ranLostConnectionHandler := rmrmsghandlers.NewRanLostConnectionHandler(logger, ranReconnectionManager)
-	//enbLoadInformationNotificationHandler := rmrmsghandlers.NewEnbLoadInformationNotificationHandler(logger, rnibDataService, enbLoadInformationExtractor)
-	x2EnbConfigurationUpdateHandler := rmrmsghandlers.NewX2EnbConfigurationUpdateHandler(logger, rmrSender)
-	endcConfigurationUpdateHandler := rmrmsghandlers.NewEndcConfigurationUpdateHandler(logger, rmrSender)
-	x2ResetResponseHandler := rmrmsghandlers.NewX2ResetResponseHandler(logger, rnibDataService, ranStatusChangeManager, x2ResetResponseExtractor)
+	enbLoadInformationNotificationHandler := rmrmsghandlers.NewEnbLoadInformationNotificationHandler(logger, rnibDataService, enbLoadInformationExtractor, provider.decodeErrors)
+	x2EnbConfigurationUpdateHandler := rmrmsghandlers.NewX2EnbConfigurationUpdateHandler(logger, rmrSender, rnibDataService, enbConfigurationUpdateExtractor, provider.decodeErrors)
+	endcConfigurationUpdateHandler := rmrmsghandlers.NewEndcConfigurationUpdateHandler(logger, rmrSender, rnibDataService, ranStatusChangeManager, endcConfigurationUpdateExtractor, provider.decodeErrors)
+	x2ResetResponseHandler := rmrmsghandlers.NewX2ResetResponseHandler(logger, rnibDataService, ranStatusChangeManager, x2ResetResponseExtractor, provider.decodeErrors)
 	x2ResetRequestNotificationHandler := rmrmsghandlers.NewX2ResetRequestNotificationHandler(logger, rnibDataService, ranStatusChangeManager, rmrSender)
-	e2TermInitNotificationHandler := rmrmsghandlers.NewE2TermInitNotificationHandler(logger, ranReconnectionManager, e2tInstancesManager, routingManagerClient)
-	e2TKeepAliveResponseHandler := rmrmsghandlers.NewE2TKeepAliveResponseHandler(logger, rnibDataService, e2tInstancesManager)
-	e2SetupRequestNotificationHandler := rmrmsghandlers.NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManager, rmrSender, rnibDataService, e2tAssociationManager)
-
-	provider.Register(rmrCgo.RIC_X2_SETUP_RESP, x2SetupResponseHandler)
-	provider.Register(rmrCgo.RIC_X2_SETUP_FAILURE, x2SetupFailureResponseHandler)
-	provider.Register(rmrCgo.RIC_ENDC_X2_SETUP_RESP, endcSetupResponseHandler)
-	provider.Register(rmrCgo.RIC_ENDC_X2_SETUP_FAILURE, endcSetupFailureResponseHandler)
-	provider.Register(rmrCgo.RIC_SCTP_CONNECTION_FAILURE, ranLostConnectionHandler)
-	//provider.Register(rmrCgo.RIC_ENB_LOAD_INFORMATION, enbLoadInformationNotificationHandler)
-	provider.Register(rmrCgo.RIC_ENB_CONF_UPDATE, x2EnbConfigurationUpdateHandler)
-	provider.Register(rmrCgo.RIC_ENDC_CONF_UPDATE, endcConfigurationUpdateHandler)
-	provider.Register(rmrCgo.RIC_X2_RESET_RESP, x2ResetResponseHandler)
-	provider.Register(rmrCgo.RIC_X2_RESET, x2ResetRequestNotificationHandler)
-	provider.Register(rmrCgo.RIC_E2_TERM_INIT, e2TermInitNotificationHandler)
-	provider.Register(rmrCgo.E2_TERM_KEEP_ALIVE_RESP, e2TKeepAliveResponseHandler)
-	provider.Register(rmrCgo.RIC_E2_SETUP_REQ, e2SetupRequestNotificationHandler)
+	e2TermInitNotificationHandler := rmrmsghandlers.NewE2TermInitNotificationHandler(logger, ranReconnectionManager, e2tInstancesManager, routingManagerClient, provider.decodeErrors)
+	e2TKeepAliveResponseHandler := rmrmsghandlers.NewE2TKeepAliveResponseHandler(logger, rnibDataService, e2tInstancesManager, provider.decodeErrors)
+	e2SetupRequestNotificationHandler := rmrmsghandlers.NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManager, rmrSender, rnibDataService, e2tAssociationManager, provider.decodeErrors)
+
+	provider.Register(rmrtypes.RIC_X2_SETUP_RESP, x2SetupResponseHandler)
+	provider.Register(rmrtypes.RIC_X2_SETUP_FAILURE, x2SetupFailureResponseHandler)
+	provider.Register(rmrtypes.RIC_ENDC_X2_SETUP_RESP, endcSetupResponseHandler)
+	provider.Register(rmrtypes.RIC_ENDC_X2_SETUP_FAILURE, endcSetupFailureResponseHandler)
+	provider.Register(rmrtypes.RIC_SCTP_CONNECTION_FAILURE, ranLostConnectionHandler)
+	provider.Register(rmrtypes.RIC_ENB_CONF_UPDATE, x2EnbConfigurationUpdateHandler)
+	provider.Register(rmrtypes.RIC_ENDC_CONF_UPDATE, endcConfigurationUpdateHandler)
+	provider.Register(rmrtypes.RIC_X2_RESET_RESP, x2ResetResponseHandler)
+	provider.Register(rmrtypes.RIC_X2_RESET, x2ResetRequestNotificationHandler)
+	provider.Register(rmrtypes.RIC_E2_TERM_INIT, e2TermInitNotificationHandler)
+	provider.Register(rmrtypes.E2_TERM_KEEP_ALIVE_RESP, e2TKeepAliveResponseHandler)
+	provider.Register(rmrtypes.RIC_E2_SETUP_REQ, e2SetupRequestNotificationHandler)
+
+	if config.LoadInformation.Enabled {
+		provider.Register(rmrtypes.RIC_ENB_LOAD_INFORMATION, enbLoadInformationNotificationHandler)
+	}
 }
@@ -20,15 +20,29 @@
 package rNibWriter
 
 import (
+	"e2mgr/models"
 	"encoding/json"
 	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/golang/protobuf/proto"
+	"sort"
 )
 
 const E2TAddressesKey = "E2TAddresses"
 
+const (
+	RanStatusHistoryKeyPrefix        = "RAN_STATUS_HISTORY"
+	maxRanStatusHistoryUpdateAttempts = 5
+)
+
+const (
+	CellLoadInformationKeyPrefix      = "CELL_LOAD_INFORMATION"
+	CellLoadInformationCellsKeyPrefix = "CELL_LOAD_INFORMATION_CELLS"
+)
+
+const StaleCellKeysKeyPrefix = "STALE_CELL_KEYS"
+
 type rNibWriterInstance struct {
 	sdl common.ISdlInstance
 }
@@ -44,7 +58,16 @@ type RNibWriter interface {
 	SaveE2TAddresses(addresses []string) error
 	RemoveE2TInstance(e2tAddress string) error
 	UpdateGnbCells(nodebInfo *entities.NodebInfo, servedNrCells []*entities.ServedNRCell) error
+	UpdateEnbCells(nodebInfo *entities.NodebInfo, servedCells []*entities.ServedCellInfo) error
+	PatchGnbCells(nodebInfo *entities.NodebInfo, servedNrCellsToSet []*entities.ServedNRCell, servedNrCellsToRemove []*entities.ServedNRCell) error
+	PatchEnbCells(nodebInfo *entities.NodebInfo, servedCellsToSet []*entities.ServedCellInfo, servedCellsToRemove []*entities.ServedCellInfo) error
 	RemoveServedNrCells(inventoryName string, servedNrCells []*entities.ServedNRCell) error
+	RemoveServedCells(inventoryName string, servedCells []*entities.ServedCellInfo) error
+	AddRanStatusChange(inventoryName string, ranStatusChange *models.RanStatusChange, maxHistorySize int) error
+	GetRanStatusHistory(inventoryName string) ([]*models.RanStatusChange, error)
+	SaveCellLoadInformation(inventoryName string, ranLoadInformation *entities.RanLoadInformation) error
+	GetCellLoadInformation(inventoryName string) ([]*models.CellLoadInformation, error)
+	RemoveCellLoadInformation(inventoryName string, servedCells []*entities.ServedCellInfo, previousServedCells []*entities.ServedCellInfo) error
 }
 
 /*
@@ -67,6 +90,10 @@ func (w *rNibWriterInstance) RemoveServedNrCells(inventoryName string, servedNrC
 	return nil
 }
 
+func (w *rNibWriterInstance) RemoveServedCells(inventoryName string, servedCells []*entities.ServedCellInfo) error {
+	return w.removeCellKeys(buildServedCellInfoKeysToRemove(inventoryName, servedCells))
+}
+
 /*
 SaveNodeb saves nodeB entity data in the redis DB according to the specified data model
 */
@@ -161,6 +188,203 @@ func (w *rNibWriterInstance) UpdateGnbCells(nodebInfo *entities.NodebInfo, serve
 	return nil
 }
 
+func (w *rNibWriterInstance) UpdateEnbCells(nodebInfo *entities.NodebInfo, servedCells []*entities.ServedCellInfo) error {
+
+	pairs, err := buildUpdateNodebInfoPairs(nodebInfo)
+
+	if err != nil {
+		return err
+	}
+
+	pairs, err = appendEnbCells(nodebInfo.RanName, servedCells, pairs)
+
+	if err != nil {
+		return err
+	}
+
+	err = w.sdl.Set(pairs)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	return nil
+}
+
+/*
+PatchGnbCells writes the nodeb entity together with the keys of servedNrCellsToSet only, instead of rewriting the keys of all its cells.
+The keys of servedNrCellsToRemove (deleted cells and the previous version of modified ones) which the nodeb no longer uses, e.g. the PCI
+key of a modified cell whose PCI changed, are recorded under the STALE_CELL_KEYS key of the RAN in the same Set, then removed together
+with that record. A record left by a failed removal is read back and its keys removed by the next PatchGnbCells of the RAN.
+*/
+func (w *rNibWriterInstance) PatchGnbCells(nodebInfo *entities.NodebInfo, servedNrCellsToSet []*entities.ServedNRCell, servedNrCellsToRemove []*entities.ServedNRCell) error {
+
+	pairs, err := buildUpdateNodebInfoPairs(nodebInfo)
+
+	if err != nil {
+		return err
+	}
+
+	pairs, err = appendGnbCells(nodebInfo.RanName, servedNrCellsToSet, pairs)
+
+	if err != nil {
+		return err
+	}
+
+	staleCellKeysKey, err := buildStaleCellKeysKey(nodebInfo.RanName)
+
+	if err != nil {
+		return err
+	}
+
+	pendingStaleCellKeys, err := w.getStaleCellKeys(staleCellKeysKey)
+
+	if err != nil {
+		return err
+	}
+
+	keysToKeep := buildCellKeysToRemove(nodebInfo.RanName, nodebInfo.GetGnb().GetServedNrCells())
+
+	for i := 0; i < len(pairs); i += 2 {
+		keysToKeep = append(keysToKeep, pairs[i].(string))
+	}
+
+	staleCellKeys := buildStaleCellKeys(append(pendingStaleCellKeys, buildCellKeysToRemove(nodebInfo.RanName, servedNrCellsToRemove)...), keysToKeep)
+
+	if len(staleCellKeys) != 0 {
+		data, err := json.Marshal(staleCellKeys)
+
+		if err != nil {
+			return common.NewInternalError(err)
+		}
+
+		pairs = append(pairs, staleCellKeysKey, data)
+	}
+
+	err = w.sdl.Set(pairs)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	if len(staleCellKeys) == 0 && len(pendingStaleCellKeys) == 0 {
+		return nil
+	}
+
+	return w.removeCellKeys(append(staleCellKeys, staleCellKeysKey))
+}
+
+func (w *rNibWriterInstance) getStaleCellKeys(staleCellKeysKey string) ([]string, error) {
+
+	values, err := w.sdl.Get([]string{staleCellKeysKey})
+
+	if err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	data, ok := values[staleCellKeysKey]
+
+	if !ok || data == nil {
+		return nil, nil
+	}
+
+	var raw []byte
+
+	switch v := data.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return nil, common.NewInternalError(fmt.Errorf("#rNibWriter.getStaleCellKeys - unexpected value type %T for key %s", data, staleCellKeysKey))
+	}
+
+	staleCellKeys := []string{}
+	err = json.Unmarshal(raw, &staleCellKeys)
+
+	if err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	return staleCellKeys, nil
+}
+
+/*
+PatchEnbCells is the eNB counterpart of PatchGnbCells: it writes the nodeb entity together with servedCellsToSet through
+UpdateEnbCells, then removes the keys of servedCellsToRemove which were not rewritten, as RemoveServedCells does. The stale
+keys are not recorded: a failure between the two leaves them behind, never a served cell without its keys.
+*/
+func (w *rNibWriterInstance) PatchEnbCells(nodebInfo *entities.NodebInfo, servedCellsToSet []*entities.ServedCellInfo, servedCellsToRemove []*entities.ServedCellInfo) error {
+
+	err := w.UpdateEnbCells(nodebInfo, servedCellsToSet)
+
+	if err != nil {
+		return err
+	}
+
+	cellKeysToSet := buildServedCellInfoKeysToRemove(nodebInfo.RanName, servedCellsToSet)
+	staleCellKeys := buildStaleCellKeys(buildServedCellInfoKeysToRemove(nodebInfo.RanName, servedCellsToRemove), cellKeysToSet)
+
+	if len(staleCellKeys) == 0 {
+		return nil
+	}
+
+	return w.removeCellKeys(staleCellKeys)
+}
+
+func (w *rNibWriterInstance) removeCellKeys(cellKeys []string) error {
+
+	err := w.sdl.Remove(cellKeys)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	return nil
+}
+
+func buildStaleCellKeys(cellKeys []string, keysToKeep []string) []string {
+
+	isKept := make(map[string]bool, len(keysToKeep))
+
+	for _, key := range keysToKeep {
+		isKept[key] = true
+	}
+
+	staleCellKeys := []string{}
+
+	for _, key := range cellKeys {
+		if !isKept[key] {
+			staleCellKeys = append(staleCellKeys, key)
+			isKept[key] = true
+		}
+	}
+
+	return staleCellKeys
+}
+
+func buildServedCellInfoKeysToRemove(inventoryName string, servedCellsToRemove []*entities.ServedCellInfo) []string {
+
+	cellKeysToRemove := []string{}
+
+	for _, cell := range servedCellsToRemove {
+
+		key, _ := common.ValidateAndBuildCellIdKey(cell.GetCellId())
+
+		if len(key) != 0 {
+			cellKeysToRemove = append(cellKeysToRemove, key)
+		}
+
+		key, _ = common.ValidateAndBuildCellNamePciKey(inventoryName, cell.GetPci())
+
+		if len(key) != 0 {
+			cellKeysToRemove = append(cellKeysToRemove, key)
+		}
+	}
+
+	return cellKeysToRemove
+}
+
 func buildCellKeysToRemove(inventoryName string, servedNrCellsToRemove []*entities.ServedNRCell) []string {
 
 	cellKeysToRemove := []string{}
@@ -315,6 +539,113 @@ func (w *rNibWriterInstance) RemoveE2TInstance(address string) error {
 	return nil
 }
 
+/*
+AddRanStatusChange appends a connection status change to the RAN's status history, keeping at most maxHistorySize (latest) entries.
+The history is kept as a single JSON array which is updated with check-and-set, so concurrent writers don't overwrite each other.
+*/
+func (w *rNibWriterInstance) AddRanStatusChange(inventoryName string, ranStatusChange *models.RanStatusChange, maxHistorySize int) error {
+	key, rNibErr := buildRanStatusHistoryKey(inventoryName)
+
+	if rNibErr != nil {
+		return rNibErr
+	}
+
+	for i := 0; i < maxRanStatusHistoryUpdateAttempts; i++ {
+		oldData, history, err := w.getRanStatusHistory(key)
+
+		if err != nil {
+			return err
+		}
+
+		history = append(history, ranStatusChange)
+
+		if len(history) > maxHistorySize {
+			history = history[len(history)-maxHistorySize:]
+		}
+
+		newData, err := json.Marshal(history)
+
+		if err != nil {
+			return common.NewInternalError(err)
+		}
+
+		var ok bool
+
+		if oldData == nil {
+			ok, err = w.sdl.SetIfNotExists(key, newData)
+		} else {
+			ok, err = w.sdl.SetIf(key, oldData, newData)
+		}
+
+		if err != nil {
+			return common.NewInternalError(err)
+		}
+
+		if ok {
+			return nil
+		}
+	}
+
+	return common.NewInternalError(fmt.Errorf("#rNibWriter.AddRanStatusChange - RAN name: %s - status history was concurrently modified, giving up after %d attempts", inventoryName, maxRanStatusHistoryUpdateAttempts))
+}
+
+/*
+GetRanStatusHistory returns the RAN's status history, oldest entry first.
+The history is owned by the E2 Manager and is not part of the rNib reader API, hence it is read here.
+*/
+func (w *rNibWriterInstance) GetRanStatusHistory(inventoryName string) ([]*models.RanStatusChange, error) {
+	key, rNibErr := buildRanStatusHistoryKey(inventoryName)
+
+	if rNibErr != nil {
+		return nil, rNibErr
+	}
+
+	_, history, err := w.getRanStatusHistory(key)
+	return history, err
+}
+
+func (w *rNibWriterInstance) getRanStatusHistory(key string) (interface{}, []*models.RanStatusChange, error) {
+	values, err := w.sdl.Get([]string{key})
+
+	if err != nil {
+		return nil, nil, common.NewInternalError(err)
+	}
+
+	data, ok := values[key]
+
+	if !ok || data == nil {
+		return nil, []*models.RanStatusChange{}, nil
+	}
+
+	var raw []byte
+
+	switch v := data.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return nil, nil, common.NewInternalError(fmt.Errorf("#rNibWriter.getRanStatusHistory - unexpected value type %T for key %s", data, key))
+	}
+
+	history := []*models.RanStatusChange{}
+	err = json.Unmarshal(raw, &history)
+
+	if err != nil {
+		return nil, nil, common.NewInternalError(err)
+	}
+
+	return data, history, nil
+}
+
+func buildRanStatusHistoryKey(inventoryName string) (string, error) {
+	if inventoryName == "" {
+		return "", common.NewValidationError("#rNibWriter.buildRanStatusHistoryKey - an empty inventory name received")
+	}
+
+	return fmt.Sprintf("%s:%s", RanStatusHistoryKeyPrefix, inventoryName), nil
+}
+
 /*
 Close the writer
 */
@@ -367,3 +698,214 @@ func appendGnbCells(inventoryName string, cells []*entities.ServedNRCell, pairs
 func isNotEmpty(nbIdentity *entities.NbIdentity) bool {
 	return nbIdentity.GlobalNbId != nil && nbIdentity.GlobalNbId.PlmnId != "" && nbIdentity.GlobalNbId.NbId != ""
 }
+
+/*
+SaveCellLoadInformation stores the load information of each cell of ranLoadInformation under a key of its own, along
+with the load timestamp, so a message which reports some of the RAN's cells keeps the latest load of the others.
+*/
+func (w *rNibWriterInstance) SaveCellLoadInformation(inventoryName string, ranLoadInformation *entities.RanLoadInformation) error {
+	cellsKey, rNibErr := buildCellLoadInformationCellsKey(inventoryName)
+
+	if rNibErr != nil {
+		return rNibErr
+	}
+
+	if len(ranLoadInformation.GetCellLoadInfos()) == 0 {
+		return nil
+	}
+
+	var pairs []interface{}
+	var cellIds []interface{}
+
+	for _, cellLoadInformation := range ranLoadInformation.GetCellLoadInfos() {
+		key, rNibErr := buildCellLoadInformationKey(inventoryName, cellLoadInformation.GetCellId())
+
+		if rNibErr != nil {
+			return rNibErr
+		}
+
+		data, err := proto.Marshal(&entities.RanLoadInformation{
+			LoadTimestamp: ranLoadInformation.GetLoadTimestamp(),
+			CellLoadInfos: []*entities.CellLoadInformation{cellLoadInformation},
+		})
+
+		if err != nil {
+			return common.NewInternalError(err)
+		}
+
+		pairs = append(pairs, key, data)
+		cellIds = append(cellIds, cellLoadInformation.GetCellId())
+	}
+
+	err := w.sdl.Set(pairs)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	err = w.sdl.AddMember(cellsKey, cellIds...)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	return nil
+}
+
+/*
+GetCellLoadInformation returns the latest load information of each of the RAN's cells, ordered by cell id.
+Like the status history, it is owned by the E2 Manager and is not part of the rNib reader API, hence it is read here.
+*/
+func (w *rNibWriterInstance) GetCellLoadInformation(inventoryName string) ([]*models.CellLoadInformation, error) {
+	cellsKey, rNibErr := buildCellLoadInformationCellsKey(inventoryName)
+
+	if rNibErr != nil {
+		return nil, rNibErr
+	}
+
+	cellIds, err := w.sdl.GetMembers(cellsKey)
+
+	if err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	cells := []*models.CellLoadInformation{}
+
+	if len(cellIds) == 0 {
+		return cells, nil
+	}
+
+	sort.Strings(cellIds)
+	keys := make([]string, len(cellIds))
+
+	for i, cellId := range cellIds {
+		keys[i], rNibErr = buildCellLoadInformationKey(inventoryName, cellId)
+
+		if rNibErr != nil {
+			return nil, rNibErr
+		}
+	}
+
+	values, err := w.sdl.Get(keys)
+
+	if err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	for _, key := range keys {
+		data, ok := values[key]
+
+		if !ok || data == nil {
+			continue
+		}
+
+		var raw []byte
+
+		switch v := data.(type) {
+		case string:
+			raw = []byte(v)
+		case []byte:
+			raw = v
+		default:
+			return nil, common.NewInternalError(fmt.Errorf("#rNibWriter.GetCellLoadInformation - unexpected value type %T for key %s", data, key))
+		}
+
+		ranLoadInformation := &entities.RanLoadInformation{}
+		err = proto.Unmarshal(raw, ranLoadInformation)
+
+		if err != nil {
+			return nil, common.NewInternalError(err)
+		}
+
+		for _, cellLoadInformation := range ranLoadInformation.GetCellLoadInfos() {
+			cells = append(cells, models.NewCellLoadInformation(ranLoadInformation.GetLoadTimestamp(), cellLoadInformation))
+		}
+	}
+
+	return cells, nil
+}
+
+/*
+RemoveCellLoadInformation removes the load information of the cells of previousServedCells which are not in servedCells,
+the cells the eNB no longer serves, so GetCellLoadInformation no longer reports them. The load information keys are
+removed before the cells are, so a failure in between leaves cells GetCellLoadInformation skips rather than leaked keys.
+*/
+func (w *rNibWriterInstance) RemoveCellLoadInformation(inventoryName string, servedCells []*entities.ServedCellInfo, previousServedCells []*entities.ServedCellInfo) error {
+	cellsKey, rNibErr := buildCellLoadInformationCellsKey(inventoryName)
+
+	if rNibErr != nil {
+		return rNibErr
+	}
+
+	removedCellIds := buildRemovedCellIds(servedCells, previousServedCells)
+
+	if len(removedCellIds) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(removedCellIds))
+	members := make([]interface{}, len(removedCellIds))
+
+	for i, cellId := range removedCellIds {
+		keys[i], _ = buildCellLoadInformationKey(inventoryName, cellId)
+		members[i] = cellId
+	}
+
+	err := w.sdl.Remove(keys)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	err = w.sdl.RemoveMember(cellsKey, members...)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// buildRemovedCellIds returns the ids of the cells of previousServedCells which are not in servedCells
+func buildRemovedCellIds(servedCells []*entities.ServedCellInfo, previousServedCells []*entities.ServedCellInfo) []string {
+
+	servedCellIds := make(map[string]bool, len(servedCells))
+
+	for _, cell := range servedCells {
+		servedCellIds[cell.GetCellId()] = true
+	}
+
+	removedCellIds := []string{}
+
+	for _, cell := range previousServedCells {
+		if cell.GetCellId() != "" && !servedCellIds[cell.GetCellId()] {
+			removedCellIds = append(removedCellIds, cell.GetCellId())
+		}
+	}
+
+	return removedCellIds
+}
+
+func buildStaleCellKeysKey(inventoryName string) (string, error) {
+	if inventoryName == "" {
+		return "", common.NewValidationError("#rNibWriter.buildStaleCellKeysKey - an empty inventory name received")
+	}
+
+	return fmt.Sprintf("%s:%s", StaleCellKeysKeyPrefix, inventoryName), nil
+}
+
+func buildCellLoadInformationKey(inventoryName string, cellId string) (string, error) {
+	if inventoryName == "" || cellId == "" {
+		return "", common.NewValidationError("#rNibWriter.buildCellLoadInformationKey - an empty inventory name or cell id received")
+	}
+
+	return fmt.Sprintf("%s:%s:%s", CellLoadInformationKeyPrefix, inventoryName, cellId), nil
+}
+
+func buildCellLoadInformationCellsKey(inventoryName string) (string, error) {
+	if inventoryName == "" {
+		return "", common.NewValidationError("#rNibWriter.buildCellLoadInformationCellsKey - an empty inventory name received")
+	}
+
+	return fmt.Sprintf("%s:%s", CellLoadInformationCellsKeyPrefix, inventoryName), nil
+}
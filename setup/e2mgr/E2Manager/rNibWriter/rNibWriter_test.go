@@ -21,6 +21,7 @@ package rNibWriter
 
 import (
 	"e2mgr/mocks"
+	"e2mgr/models"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,6 +29,7 @@ import (
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 )
@@ -180,6 +182,347 @@ func TestUpdateGnbCellsSuccess(t *testing.T) {
 	assert.Nil(t, rNibErr)
 }
 
+func TestPatchGnbCellsSuccess(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	oldServedNrCells := generateServedNrCells("test1", "test2", "test3")
+	modifiedServedNrCell := proto.Clone(oldServedNrCells[0]).(*entities.ServedNRCell)
+	modifiedServedNrCell.ServedNrCellInformation.NrPci = 5
+	addedServedNrCells := generateServedNrCells("test4")
+	addedServedNrCells[0].ServedNrCellInformation.NrPci = 4
+	servedNrCellsToSet := append([]*entities.ServedNRCell{modifiedServedNrCell}, addedServedNrCells...)
+	servedNrCellsToRemove := []*entities.ServedNRCell{oldServedNrCells[0], oldServedNrCells[2]}
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_GNB, plmnId, nbId)
+	nodebInfo.GetGnb().ServedNrCells = []*entities.ServedNRCell{modifiedServedNrCell, oldServedNrCells[1], addedServedNrCells[0]}
+	staleCellKeysKey := fmt.Sprintf("STALE_CELL_KEYS:%s", inventoryName)
+	staleCellKeys := []string{fmt.Sprintf("PCI:%s:01", inventoryName), "NRCELL:test3", fmt.Sprintf("PCI:%s:03", inventoryName)}
+	sdlInstanceMock.On("Get", []string{staleCellKeysKey}).Return(map[string]interface{}{}, nil)
+	setExpected := append(getUpdateGnbCellsSetExpected(t, nodebInfo, servedNrCellsToSet), staleCellKeysKey, getStaleCellKeysData(t, staleCellKeys))
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	sdlInstanceMock.On("Remove", append(staleCellKeys, staleCellKeysKey)).Return(nil)
+	rNibErr := w.PatchGnbCells(nodebInfo, servedNrCellsToSet, servedNrCellsToRemove)
+	assert.Nil(t, rNibErr)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestPatchGnbCellsNoStaleKeysSuccess(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	oldServedNrCells := generateServedNrCells("test1")
+	modifiedServedNrCell := proto.Clone(oldServedNrCells[0]).(*entities.ServedNRCell)
+	modifiedServedNrCell.ServedNrCellInformation.ServedPlmns = []string{"whatever", "whatever else"}
+	servedNrCellsToSet := []*entities.ServedNRCell{modifiedServedNrCell}
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_GNB, plmnId, nbId)
+	nodebInfo.GetGnb().ServedNrCells = servedNrCellsToSet
+	sdlInstanceMock.On("Get", []string{fmt.Sprintf("STALE_CELL_KEYS:%s", inventoryName)}).Return(map[string]interface{}{}, nil)
+	setExpected := getUpdateGnbCellsSetExpected(t, nodebInfo, servedNrCellsToSet)
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	rNibErr := w.PatchGnbCells(nodebInfo, servedNrCellsToSet, oldServedNrCells)
+	assert.Nil(t, rNibErr)
+	sdlInstanceMock.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+func TestPatchGnbCellsPendingStaleKeysSuccess(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedNrCells := generateServedNrCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_GNB, plmnId, nbId)
+	nodebInfo.GetGnb().ServedNrCells = servedNrCells[:1]
+	staleCellKeysKey := fmt.Sprintf("STALE_CELL_KEYS:%s", inventoryName)
+	pendingStaleCellKeys := []string{"NRCELL:test0", fmt.Sprintf("PCI:%s:01", inventoryName), fmt.Sprintf("PCI:%s:07", inventoryName)}
+	sdlInstanceMock.On("Get", []string{staleCellKeysKey}).Return(map[string]interface{}{staleCellKeysKey: string(getStaleCellKeysData(t, pendingStaleCellKeys))}, nil)
+	staleCellKeys := []string{"NRCELL:test0", fmt.Sprintf("PCI:%s:07", inventoryName), "NRCELL:test2", fmt.Sprintf("PCI:%s:02", inventoryName)}
+	setExpected := append(getUpdateGnbCellsSetExpected(t, nodebInfo, nil), staleCellKeysKey, getStaleCellKeysData(t, staleCellKeys))
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	sdlInstanceMock.On("Remove", append(staleCellKeys, staleCellKeysKey)).Return(nil)
+	rNibErr := w.PatchGnbCells(nodebInfo, nil, servedNrCells[1:])
+	assert.Nil(t, rNibErr)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestPatchGnbCellsPendingStaleKeysInUseSuccess(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedNrCells := generateServedNrCells("test1")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_GNB, plmnId, nbId)
+	nodebInfo.GetGnb().ServedNrCells = servedNrCells
+	staleCellKeysKey := fmt.Sprintf("STALE_CELL_KEYS:%s", inventoryName)
+	pendingStaleCellKeys := []string{"NRCELL:test1", fmt.Sprintf("PCI:%s:01", inventoryName)}
+	sdlInstanceMock.On("Get", []string{staleCellKeysKey}).Return(map[string]interface{}{staleCellKeysKey: string(getStaleCellKeysData(t, pendingStaleCellKeys))}, nil)
+	setExpected := getUpdateGnbCellsSetExpected(t, nodebInfo, nil)
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	sdlInstanceMock.On("Remove", []string{staleCellKeysKey}).Return(nil)
+	rNibErr := w.PatchGnbCells(nodebInfo, nil, nil)
+	assert.Nil(t, rNibErr)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestPatchGnbCellsInvalidNodebInfoFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedNrCells := generateServedNrCells("test1", "test2")
+	nodebInfo := &entities.NodebInfo{}
+	rNibErr := w.PatchGnbCells(nodebInfo, servedNrCells, nil)
+	assert.IsType(t, &common.ValidationError{}, rNibErr)
+	sdlInstanceMock.AssertNotCalled(t, "Set", mock.Anything)
+}
+
+func TestPatchGnbCellsGetStaleCellKeysFailure(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedNrCells := generateServedNrCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_GNB, plmnId, nbId)
+	nodebInfo.GetGnb().ServedNrCells = servedNrCells[:1]
+	sdlInstanceMock.On("Get", []string{fmt.Sprintf("STALE_CELL_KEYS:%s", inventoryName)}).Return(map[string]interface{}{}, errors.New("expected error"))
+	rNibErr := w.PatchGnbCells(nodebInfo, nil, servedNrCells[1:])
+	assert.IsType(t, &common.InternalError{}, rNibErr)
+	sdlInstanceMock.AssertNotCalled(t, "Set", mock.Anything)
+}
+
+func TestPatchGnbCellsSetFailure(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedNrCells := generateServedNrCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_GNB, plmnId, nbId)
+	nodebInfo.GetGnb().ServedNrCells = servedNrCells[:1]
+	staleCellKeysKey := fmt.Sprintf("STALE_CELL_KEYS:%s", inventoryName)
+	sdlInstanceMock.On("Get", []string{staleCellKeysKey}).Return(map[string]interface{}{}, nil)
+	setExpected := append(getUpdateGnbCellsSetExpected(t, nodebInfo, nil), staleCellKeysKey, getStaleCellKeysData(t, buildCellKeysToRemove(inventoryName, servedNrCells[1:])))
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(errors.New("expected error"))
+	rNibErr := w.PatchGnbCells(nodebInfo, nil, servedNrCells[1:])
+	assert.IsType(t, &common.InternalError{}, rNibErr)
+	sdlInstanceMock.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+func TestPatchGnbCellsRemoveFailure(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedNrCells := generateServedNrCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_GNB, plmnId, nbId)
+	nodebInfo.GetGnb().ServedNrCells = servedNrCells[:1]
+	staleCellKeysKey := fmt.Sprintf("STALE_CELL_KEYS:%s", inventoryName)
+	staleCellKeys := buildCellKeysToRemove(inventoryName, servedNrCells[1:])
+	sdlInstanceMock.On("Get", []string{staleCellKeysKey}).Return(map[string]interface{}{}, nil)
+	setExpected := append(getUpdateGnbCellsSetExpected(t, nodebInfo, nil), staleCellKeysKey, getStaleCellKeysData(t, staleCellKeys))
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	sdlInstanceMock.On("Remove", append(staleCellKeys, staleCellKeysKey)).Return(errors.New("expected error"))
+	rNibErr := w.PatchGnbCells(nodebInfo, nil, servedNrCells[1:])
+	assert.IsType(t, &common.InternalError{}, rNibErr)
+}
+
+func getStaleCellKeysData(t *testing.T, staleCellKeys []string) []byte {
+
+	data, err := json.Marshal(staleCellKeys)
+	if err != nil {
+		t.Fatalf("#rNibWriter_test.getStaleCellKeysData - Failed to marshal stale cell keys. Error: %s", err)
+	}
+
+	return data
+}
+
+func generateServedCells(cellIds ...string) []*entities.ServedCellInfo {
+
+	servedCells := []*entities.ServedCellInfo{}
+
+	for i, v := range cellIds {
+		servedCells = append(servedCells, &entities.ServedCellInfo{
+			CellId: v,
+			ChoiceEutraMode: &entities.ChoiceEUTRAMode{
+				Fdd: &entities.FddInfo{},
+			},
+			Pci:            uint32(i + 1),
+			BroadcastPlmns: []string{"whatever"},
+		})
+	}
+
+	return servedCells
+}
+
+func TestRemoveServedCellsSuccess(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCellsToRemove := generateServedCells("whatever1", "whatever2")
+	expectedKeys := []string{"CELL:whatever1", fmt.Sprintf("PCI:%s:01", RanName), "CELL:whatever2", fmt.Sprintf("PCI:%s:02", RanName)}
+	sdlInstanceMock.On("Remove", expectedKeys).Return(nil)
+	err := w.RemoveServedCells(RanName, servedCellsToRemove)
+	assert.Nil(t, err)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestRemoveServedCellsFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCellsToRemove := generateServedCells("whatever1", "whatever2")
+	sdlInstanceMock.On("Remove", buildServedCellInfoKeysToRemove(RanName, servedCellsToRemove)).Return(errors.New("expected error"))
+	err := w.RemoveServedCells(RanName, servedCellsToRemove)
+	assert.IsType(t, &common.InternalError{}, err)
+}
+
+func TestUpdateEnbCellsInvalidNodebInfoFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := generateServedCells("test1", "test2")
+	nodebInfo := &entities.NodebInfo{}
+	sdlInstanceMock.AssertNotCalled(t, "Set")
+	rNibErr := w.UpdateEnbCells(nodebInfo, servedCells)
+	assert.IsType(t, &common.ValidationError{}, rNibErr)
+}
+
+func TestPatchEnbCellsInvalidNodebInfoFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := generateServedCells("test1", "test2")
+	nodebInfo := &entities.NodebInfo{}
+	sdlInstanceMock.AssertNotCalled(t, "Set")
+	rNibErr := w.PatchEnbCells(nodebInfo, servedCells, nil)
+	assert.IsType(t, &common.ValidationError{}, rNibErr)
+}
+
+func TestPatchEnbCellsInvalidCellFailure(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := []*entities.ServedCellInfo{{CellId: ""}}
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_ENB, plmnId, nbId)
+	nodebInfo.GetEnb().ServedCells = servedCells
+	sdlInstanceMock.AssertNotCalled(t, "Set")
+	rNibErr := w.PatchEnbCells(nodebInfo, servedCells, nil)
+	assert.IsType(t, &common.ValidationError{}, rNibErr)
+}
+
+func getUpdateEnbCellsSetExpected(t *testing.T, nodebInfo *entities.NodebInfo, servedCells []*entities.ServedCellInfo) []interface{} {
+
+	nodebInfoData, err := proto.Marshal(nodebInfo)
+	if err != nil {
+		t.Fatalf("#rNibWriter_test.getUpdateEnbCellsSetExpected - Failed to marshal NodeB entity. Error: %s", err)
+	}
+
+	nodebNameKey, _ := common.ValidateAndBuildNodeBNameKey(nodebInfo.RanName)
+	nodebIdKey, _ := common.ValidateAndBuildNodeBIdKey(nodebInfo.NodeType.String(), nodebInfo.GlobalNbId.PlmnId, nodebInfo.GlobalNbId.NbId)
+	setExpected := []interface{}{nodebNameKey, nodebInfoData, nodebIdKey, nodebInfoData}
+
+	for _, v := range servedCells {
+
+		cellEntity := entities.Cell{Type: entities.Cell_LTE_CELL, Cell: &entities.Cell_ServedCellInfo{ServedCellInfo: v}}
+		cellData, err := proto.Marshal(&cellEntity)
+
+		if err != nil {
+			t.Fatalf("#rNibWriter_test.getUpdateEnbCellsSetExpected - Failed to marshal cell entity. Error: %s", err)
+		}
+
+		cellIdKey, _ := common.ValidateAndBuildCellIdKey(v.GetCellId())
+		cellNamePciKey, _ := common.ValidateAndBuildCellNamePciKey(nodebInfo.RanName, v.GetPci())
+		setExpected = append(setExpected, cellIdKey, cellData, cellNamePciKey, cellData)
+	}
+
+	return setExpected
+}
+
+func TestUpdateEnbCellsSdlFailure(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := generateServedCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_ENB, plmnId, nbId)
+	nodebInfo.GetEnb().ServedCells = servedCells
+	setExpected := getUpdateEnbCellsSetExpected(t, nodebInfo, servedCells)
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(errors.New("expected error"))
+	rNibErr := w.UpdateEnbCells(nodebInfo, servedCells)
+	assert.IsType(t, &common.InternalError{}, rNibErr)
+}
+
+func TestUpdateEnbCellsSuccess(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := generateServedCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_ENB, plmnId, nbId)
+	nodebInfo.GetEnb().ServedCells = servedCells
+	setExpected := getUpdateEnbCellsSetExpected(t, nodebInfo, servedCells)
+	var e error
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(e)
+	rNibErr := w.UpdateEnbCells(nodebInfo, servedCells)
+	assert.Nil(t, rNibErr)
+}
+
+func TestPatchEnbCellsSuccess(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	oldServedCells := generateServedCells("test1", "test2", "test3")
+	modifiedServedCell := proto.Clone(oldServedCells[0]).(*entities.ServedCellInfo)
+	modifiedServedCell.Pci = 5
+	servedCellsToSet := []*entities.ServedCellInfo{modifiedServedCell, oldServedCells[1]}
+	servedCellsToRemove := []*entities.ServedCellInfo{oldServedCells[0], oldServedCells[2]}
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_ENB, plmnId, nbId)
+	nodebInfo.GetEnb().ServedCells = servedCellsToSet
+	setExpected := getUpdateEnbCellsSetExpected(t, nodebInfo, servedCellsToSet)
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	removeExpected := []string{fmt.Sprintf("PCI:%s:01", inventoryName), "CELL:test3", fmt.Sprintf("PCI:%s:03", inventoryName)}
+	sdlInstanceMock.On("Remove", removeExpected).Return(nil)
+	rNibErr := w.PatchEnbCells(nodebInfo, servedCellsToSet, servedCellsToRemove)
+	assert.Nil(t, rNibErr)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestPatchEnbCellsNoStaleKeysSuccess(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := generateServedCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_ENB, plmnId, nbId)
+	nodebInfo.GetEnb().ServedCells = servedCells
+	setExpected := getUpdateEnbCellsSetExpected(t, nodebInfo, servedCells)
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	rNibErr := w.PatchEnbCells(nodebInfo, servedCells, servedCells)
+	assert.Nil(t, rNibErr)
+	sdlInstanceMock.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+func TestPatchEnbCellsSetFailure(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := generateServedCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_ENB, plmnId, nbId)
+	nodebInfo.GetEnb().ServedCells = servedCells[:1]
+	setExpected := getUpdateEnbCellsSetExpected(t, nodebInfo, servedCells[:1])
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(errors.New("expected error"))
+	rNibErr := w.PatchEnbCells(nodebInfo, servedCells[:1], servedCells[1:])
+	assert.IsType(t, &common.InternalError{}, rNibErr)
+	sdlInstanceMock.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+func TestPatchEnbCellsRemoveFailure(t *testing.T) {
+	inventoryName := "name"
+	plmnId := "02f829"
+	nbId := "4a952a0a"
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := generateServedCells("test1", "test2")
+	nodebInfo := generateNodebInfo(inventoryName, entities.Node_ENB, plmnId, nbId)
+	nodebInfo.GetEnb().ServedCells = servedCells[:1]
+	setExpected := getUpdateEnbCellsSetExpected(t, nodebInfo, servedCells[:1])
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	sdlInstanceMock.On("Remove", buildServedCellInfoKeysToRemove(inventoryName, servedCells[1:])).Return(errors.New("expected error"))
+	rNibErr := w.PatchEnbCells(nodebInfo, servedCells[:1], servedCells[1:])
+	assert.IsType(t, &common.InternalError{}, rNibErr)
+}
+
 func TestUpdateNodebInfoSuccess(t *testing.T) {
 	inventoryName := "name"
 	plmnId := "02f829"
@@ -743,6 +1086,256 @@ func TestRemoveE2TInstanceEmptyAddressFailure(t *testing.T) {
 	sdlInstanceMock.AssertExpectations(t)
 }
 
+func generateRanStatusChange(previousStatus entities.ConnectionStatus, newStatus entities.ConnectionStatus, second int) *models.RanStatusChange {
+	return &models.RanStatusChange{
+		Timestamp:      time.Date(2020, 5, 1, 10, 0, second, 0, time.UTC),
+		PreviousStatus: previousStatus.String(),
+		NewStatus:      newStatus.String(),
+		Cause:          "cause",
+		Component:      "component",
+	}
+}
+
+func marshalRanStatusHistory(t *testing.T, history ...*models.RanStatusChange) string {
+	data, err := json.Marshal(history)
+	if err != nil {
+		t.Fatalf("#rNibWriter_test.marshalRanStatusHistory - Failed to marshal history. Error: %v", err)
+	}
+	return string(data)
+}
+
+func TestAddRanStatusChangeFirstEntrySuccess(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := RanStatusHistoryKeyPrefix + ":" + RanName
+	change := generateRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, 0)
+
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{}, nil)
+	sdlInstanceMock.On("SetIfNotExists", key, []byte(marshalRanStatusHistory(t, change))).Return(true, nil)
+
+	err := w.AddRanStatusChange(RanName, change, 3)
+	assert.Nil(t, err)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestAddRanStatusChangeTrimsHistory(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := RanStatusHistoryKeyPrefix + ":" + RanName
+	change1 := generateRanStatusChange(entities.ConnectionStatus_CONNECTING, entities.ConnectionStatus_CONNECTED, 0)
+	change2 := generateRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, 1)
+	change3 := generateRanStatusChange(entities.ConnectionStatus_DISCONNECTED, entities.ConnectionStatus_CONNECTED, 2)
+	oldData := marshalRanStatusHistory(t, change1, change2)
+
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{key: oldData}, nil)
+	sdlInstanceMock.On("SetIf", key, oldData, []byte(marshalRanStatusHistory(t, change2, change3))).Return(true, nil)
+
+	err := w.AddRanStatusChange(RanName, change3, 2)
+	assert.Nil(t, err)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestAddRanStatusChangeRetriesOnConcurrentUpdate(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := RanStatusHistoryKeyPrefix + ":" + RanName
+	change1 := generateRanStatusChange(entities.ConnectionStatus_CONNECTING, entities.ConnectionStatus_CONNECTED, 0)
+	change2 := generateRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, 1)
+	concurrentData := marshalRanStatusHistory(t, change1)
+
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{}, nil).Once()
+	sdlInstanceMock.On("SetIfNotExists", key, []byte(marshalRanStatusHistory(t, change2))).Return(false, nil)
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{key: concurrentData}, nil).Once()
+	sdlInstanceMock.On("SetIf", key, concurrentData, []byte(marshalRanStatusHistory(t, change1, change2))).Return(true, nil)
+
+	err := w.AddRanStatusChange(RanName, change2, 10)
+	assert.Nil(t, err)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestAddRanStatusChangeGivesUpOnConcurrentUpdates(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := RanStatusHistoryKeyPrefix + ":" + RanName
+	change := generateRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, 0)
+
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{}, nil)
+	sdlInstanceMock.On("SetIfNotExists", key, []byte(marshalRanStatusHistory(t, change))).Return(false, nil)
+
+	err := w.AddRanStatusChange(RanName, change, 10)
+	assert.IsType(t, &common.InternalError{}, err)
+	sdlInstanceMock.AssertNumberOfCalls(t, "SetIfNotExists", maxRanStatusHistoryUpdateAttempts)
+}
+
+func TestAddRanStatusChangeSdlFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := RanStatusHistoryKeyPrefix + ":" + RanName
+	change := generateRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, 0)
+
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{}, errors.New("expected error"))
+
+	err := w.AddRanStatusChange(RanName, change, 10)
+	assert.IsType(t, &common.InternalError{}, err)
+	sdlInstanceMock.AssertNotCalled(t, "SetIfNotExists", key, mock.Anything)
+}
+
+func TestAddRanStatusChangeEmptyInventoryNameFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	change := generateRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, 0)
+
+	err := w.AddRanStatusChange("", change, 10)
+	assert.IsType(t, &common.ValidationError{}, err)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestGetRanStatusHistorySuccess(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := RanStatusHistoryKeyPrefix + ":" + RanName
+	change1 := generateRanStatusChange(entities.ConnectionStatus_CONNECTING, entities.ConnectionStatus_CONNECTED, 0)
+	change2 := generateRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, 1)
+
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{key: marshalRanStatusHistory(t, change1, change2)}, nil)
+
+	history, err := w.GetRanStatusHistory(RanName)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.RanStatusChange{change1, change2}, history)
+}
+
+func TestGetRanStatusHistoryNoHistory(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := RanStatusHistoryKeyPrefix + ":" + RanName
+
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{key: nil}, nil)
+
+	history, err := w.GetRanStatusHistory(RanName)
+	assert.Nil(t, err)
+	assert.Empty(t, history)
+}
+
+func TestGetRanStatusHistoryUnmarshalFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := RanStatusHistoryKeyPrefix + ":" + RanName
+
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{key: "{"}, nil)
+
+	history, err := w.GetRanStatusHistory(RanName)
+	assert.Nil(t, history)
+	assert.IsType(t, &common.InternalError{}, err)
+}
+
+func marshalCellLoadInformation(t *testing.T, loadTimestamp uint64, cellLoadInformation *entities.CellLoadInformation) []byte {
+	data, err := proto.Marshal(&entities.RanLoadInformation{LoadTimestamp: loadTimestamp, CellLoadInfos: []*entities.CellLoadInformation{cellLoadInformation}})
+	if err != nil {
+		t.Fatalf("#rNibWriter_test.marshalCellLoadInformation - Failed to marshal RanLoadInformation entity. Error: %v", err)
+	}
+	return data
+}
+
+func TestSaveCellLoadInformationSuccess(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	cell1 := &entities.CellLoadInformation{CellId: "02f829:0007ab50", InvokeIndication: entities.InvokeIndication_ABS_INFORMATION}
+	cell2 := &entities.CellLoadInformation{CellId: "02f829:0007ab60"}
+	ranLoadInformation := &entities.RanLoadInformation{LoadTimestamp: 1257894000000000000, CellLoadInfos: []*entities.CellLoadInformation{cell1, cell2}}
+
+	setExpected := []interface{}{
+		CellLoadInformationKeyPrefix + ":" + RanName + ":02f829:0007ab50", marshalCellLoadInformation(t, 1257894000000000000, cell1),
+		CellLoadInformationKeyPrefix + ":" + RanName + ":02f829:0007ab60", marshalCellLoadInformation(t, 1257894000000000000, cell2),
+	}
+	sdlInstanceMock.On("Set", []interface{}{setExpected}).Return(nil)
+	sdlInstanceMock.On("AddMember", CellLoadInformationCellsKeyPrefix+":"+RanName, []interface{}{"02f829:0007ab50", "02f829:0007ab60"}).Return(nil)
+
+	err := w.SaveCellLoadInformation(RanName, ranLoadInformation)
+	assert.Nil(t, err)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestSaveCellLoadInformationNoCells(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+
+	err := w.SaveCellLoadInformation(RanName, &entities.RanLoadInformation{LoadTimestamp: 1257894000000000000})
+	assert.Nil(t, err)
+	sdlInstanceMock.AssertNotCalled(t, "Set", mock.Anything)
+}
+
+func TestSaveCellLoadInformationEmptyCellIdFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	ranLoadInformation := &entities.RanLoadInformation{CellLoadInfos: []*entities.CellLoadInformation{{}}}
+
+	err := w.SaveCellLoadInformation(RanName, ranLoadInformation)
+	assert.IsType(t, &common.ValidationError{}, err)
+	sdlInstanceMock.AssertNotCalled(t, "Set", mock.Anything)
+}
+
+func TestSaveCellLoadInformationEmptyInventoryNameFailure(t *testing.T) {
+	w, _ := initSdlInstanceMock(namespace)
+
+	err := w.SaveCellLoadInformation("", &entities.RanLoadInformation{})
+	assert.IsType(t, &common.ValidationError{}, err)
+}
+
+func TestSaveCellLoadInformationSdlFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	ranLoadInformation := &entities.RanLoadInformation{CellLoadInfos: []*entities.CellLoadInformation{{CellId: "02f829:0007ab50"}}}
+
+	sdlInstanceMock.On("Set", mock.Anything).Return(errors.New("expected error"))
+
+	err := w.SaveCellLoadInformation(RanName, ranLoadInformation)
+	assert.IsType(t, &common.InternalError{}, err)
+	sdlInstanceMock.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+func TestGetCellLoadInformationSuccess(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	cell1 := &entities.CellLoadInformation{CellId: "02f829:0007ab50"}
+	cell2 := &entities.CellLoadInformation{CellId: "02f829:0007ab60", InvokeIndication: entities.InvokeIndication_ABS_INFORMATION}
+	key1 := CellLoadInformationKeyPrefix + ":" + RanName + ":02f829:0007ab50"
+	key2 := CellLoadInformationKeyPrefix + ":" + RanName + ":02f829:0007ab60"
+
+	sdlInstanceMock.On("GetMembers", CellLoadInformationCellsKeyPrefix+":"+RanName).Return([]string{"02f829:0007ab60", "02f829:0007ab50"}, nil)
+	sdlInstanceMock.On("Get", []string{key1, key2}).Return(map[string]interface{}{
+		key1: string(marshalCellLoadInformation(t, 1257894000000000000, cell1)),
+		key2: string(marshalCellLoadInformation(t, 1257894001000000000, cell2)),
+	}, nil)
+
+	cells, err := w.GetCellLoadInformation(RanName)
+	assert.Nil(t, err)
+	assert.Len(t, cells, 2)
+	assert.Equal(t, "02f829:0007ab50", cells[0].LoadInformation.CellId)
+	assert.Equal(t, time.Unix(0, 1257894000000000000).UTC(), cells[0].Timestamp)
+	assert.Equal(t, "02f829:0007ab60", cells[1].LoadInformation.CellId)
+	assert.Equal(t, entities.InvokeIndication_ABS_INFORMATION, cells[1].LoadInformation.InvokeIndication)
+	assert.Equal(t, time.Unix(1257894001, 0).UTC(), cells[1].Timestamp)
+}
+
+func TestGetCellLoadInformationNoCells(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+
+	sdlInstanceMock.On("GetMembers", CellLoadInformationCellsKeyPrefix+":"+RanName).Return([]string{}, nil)
+
+	cells, err := w.GetCellLoadInformation(RanName)
+	assert.Nil(t, err)
+	assert.Empty(t, cells)
+	sdlInstanceMock.AssertNotCalled(t, "Get", mock.Anything)
+}
+
+func TestGetCellLoadInformationSdlFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+
+	sdlInstanceMock.On("GetMembers", CellLoadInformationCellsKeyPrefix+":"+RanName).Return([]string{}, errors.New("expected error"))
+
+	cells, err := w.GetCellLoadInformation(RanName)
+	assert.Nil(t, cells)
+	assert.IsType(t, &common.InternalError{}, err)
+}
+
+func TestGetCellLoadInformationUnmarshalFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	key := CellLoadInformationKeyPrefix + ":" + RanName + ":02f829:0007ab50"
+
+	sdlInstanceMock.On("GetMembers", CellLoadInformationCellsKeyPrefix+":"+RanName).Return([]string{"02f829:0007ab50"}, nil)
+	sdlInstanceMock.On("Get", []string{key}).Return(map[string]interface{}{key: "\xff"}, nil)
+
+	cells, err := w.GetCellLoadInformation(RanName)
+	assert.Nil(t, cells)
+	assert.IsType(t, &common.InternalError{}, err)
+}
+
 //Integration tests
 //
 //func TestSaveEnbGnbInteg(t *testing.T){
@@ -812,3 +1405,49 @@ func TestRemoveE2TInstanceEmptyAddressFailure(t *testing.T) {
 //			t.Errorf("#rNibWriter_test.TestSaveRanLoadInformationInteg - Failed to save RanLoadInformation entity. Error: %v", err)
 //		}
 //}
+
+func TestRemoveCellLoadInformationSuccess(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	previousServedCells := generateServedCells("test1", "test2", "test3")
+	modifiedServedCell := proto.Clone(previousServedCells[0]).(*entities.ServedCellInfo)
+	modifiedServedCell.Pci = 5
+	servedCells := []*entities.ServedCellInfo{modifiedServedCell, previousServedCells[1]}
+	sdlInstanceMock.On("Remove", []string{CellLoadInformationKeyPrefix + ":" + RanName + ":test3"}).Return(nil)
+	sdlInstanceMock.On("RemoveMember", CellLoadInformationCellsKeyPrefix+":"+RanName, []interface{}{"test3"}).Return(nil)
+	err := w.RemoveCellLoadInformation(RanName, servedCells, previousServedCells)
+	assert.Nil(t, err)
+	sdlInstanceMock.AssertExpectations(t)
+}
+
+func TestRemoveCellLoadInformationNoRemovedCells(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	servedCells := generateServedCells("test1", "test2")
+	err := w.RemoveCellLoadInformation(RanName, servedCells, servedCells)
+	assert.Nil(t, err)
+	sdlInstanceMock.AssertNotCalled(t, "Remove", mock.Anything)
+	sdlInstanceMock.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything)
+}
+
+func TestRemoveCellLoadInformationRemoveFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	previousServedCells := generateServedCells("test1", "test2")
+	sdlInstanceMock.On("Remove", []string{CellLoadInformationKeyPrefix + ":" + RanName + ":test2"}).Return(errors.New("expected error"))
+	err := w.RemoveCellLoadInformation(RanName, previousServedCells[:1], previousServedCells)
+	assert.IsType(t, &common.InternalError{}, err)
+	sdlInstanceMock.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything)
+}
+
+func TestRemoveCellLoadInformationRemoveMemberFailure(t *testing.T) {
+	w, sdlInstanceMock := initSdlInstanceMock(namespace)
+	previousServedCells := generateServedCells("test1", "test2")
+	sdlInstanceMock.On("Remove", mock.Anything).Return(nil)
+	sdlInstanceMock.On("RemoveMember", CellLoadInformationCellsKeyPrefix+":"+RanName, []interface{}{"test2"}).Return(errors.New("expected error"))
+	err := w.RemoveCellLoadInformation(RanName, previousServedCells[:1], previousServedCells)
+	assert.IsType(t, &common.InternalError{}, err)
+}
+
+func TestRemoveCellLoadInformationEmptyInventoryNameFailure(t *testing.T) {
+	w, _ := initSdlInstanceMock(namespace)
+	err := w.RemoveCellLoadInformation("", nil, generateServedCells("test1"))
+	assert.IsType(t, &common.ValidationError{}, err)
+}
@@ -32,9 +32,10 @@ import (
 	"unsafe"
 
 	"e2mgr/logger"
+	"e2mgr/rmrtypes"
 )
 
-func (*Context) Init(port string, maxMsgSize int, flags int, logger *logger.Logger) RmrMessenger {
+func (*Context) Init(port string, maxMsgSize int, flags int, logger *logger.Logger) rmrtypes.RmrMessenger {
 	pp := C.CString(port)
 	defer C.free(unsafe.Pointer(pp))
 	logger.Debugf("#rmrCgoApi.Init - Going to initiate RMR router")
@@ -53,16 +54,16 @@ func (*Context) Init(port string, maxMsgSize int, flags int, logger *logger.Logg
 	// Configure the rmr to make rounds of attempts to send a message before notifying the application that it should retry.
 	// Each round is about 1000 attempts with a short sleep between each round.
 	C.rmr_set_stimeout(ctx.RmrCtx, C.int(1000))
-	r := RmrMessenger(ctx)
+	r := rmrtypes.RmrMessenger(ctx)
 	return r
 }
 
-func (ctx *Context) SendMsg(msg *MBuf, printLogs bool) (*MBuf, error) {
+func (ctx *Context) SendMsg(msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error) {
 	ctx.checkContextInitialized()
 	ctx.Logger.Debugf("#rmrCgoApi.SendMsg - Going to send message. MBuf: %v", *msg)
 	allocatedCMBuf := ctx.getAllocatedCRmrMBuf(ctx.Logger, msg, ctx.MaxMsgSize)
 	state := allocatedCMBuf.state
-	if state != RMR_OK {
+	if state != rmrtypes.RMR_OK {
 		errorMessage := fmt.Sprintf("#rmrCgoApi.SendMsg - Failed to get allocated message. state: %v - %s", state, states[int(state)])
 		return nil, errors.New(errorMessage)
 	}
@@ -79,7 +80,7 @@ func (ctx *Context) SendMsg(msg *MBuf, printLogs bool) (*MBuf, error) {
 
 	state = currCMBuf.state
 
-	if state != RMR_OK {
+	if state != rmrtypes.RMR_OK {
 		errorMessage := fmt.Sprintf("#rmrCgoApi.SendMsg - Failed to send message. state: %v - %s", state, states[int(state)])
 		return nil, errors.New(errorMessage)
 	}
@@ -87,7 +88,7 @@ func (ctx *Context) SendMsg(msg *MBuf, printLogs bool) (*MBuf, error) {
 	return convertToMBuf(ctx.Logger, currCMBuf), nil
 }
 
-func (ctx *Context) WhSendMsg(msg *MBuf, printLogs bool) (*MBuf, error) {
+func (ctx *Context) WhSendMsg(msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error) {
 	ctx.checkContextInitialized()
 	ctx.Logger.Debugf("#rmrCgoApi.WhSendMsg - Going to wormhole send message. MBuf: %v", *msg)
 
@@ -97,7 +98,7 @@ func (ctx *Context) WhSendMsg(msg *MBuf, printLogs bool) (*MBuf, error) {
 
 	allocatedCMBuf := ctx.getAllocatedCRmrMBuf(ctx.Logger, msg, ctx.MaxMsgSize)
 	state := allocatedCMBuf.state
-	if state != RMR_OK {
+	if state != rmrtypes.RMR_OK {
 		errorMessage := fmt.Sprintf("#rmrCgoApi.WhSendMsg - Failed to get allocated message. state: %v - %s", state, states[int(state)])
 		return nil, errors.New(errorMessage)
 	}
@@ -113,7 +114,7 @@ func (ctx *Context) WhSendMsg(msg *MBuf, printLogs bool) (*MBuf, error) {
 
 	state = currCMBuf.state
 
-	if state != RMR_OK {
+	if state != rmrtypes.RMR_OK {
 		errorMessage := fmt.Sprintf("#rmrCgoApi.WhSendMsg - Failed to send message. state: %v - %s", state, states[int(state)])
 		return nil, errors.New(errorMessage)
 	}
@@ -122,7 +123,7 @@ func (ctx *Context) WhSendMsg(msg *MBuf, printLogs bool) (*MBuf, error) {
 }
 
 
-func (ctx *Context) RecvMsg() (*MBuf, error) {
+func (ctx *Context) RecvMsg() (*rmrtypes.MBuf, error) {
 	ctx.checkContextInitialized()
 	ctx.Logger.Debugf("#rmrCgoApi.RecvMsg - Going to receive message")
 	allocatedCMBuf := C.rmr_alloc_msg(ctx.RmrCtx, C.int(ctx.MaxMsgSize))
@@ -132,7 +133,7 @@ func (ctx *Context) RecvMsg() (*MBuf, error) {
 
 	state := currCMBuf.state
 
-	if state != RMR_OK {
+	if state != rmrtypes.RMR_OK {
 		errorMessage := fmt.Sprintf("#rmrCgoApi.RecvMsg - Failed to receive message. state: %v - %s", state, states[int(state)])
 		ctx.Logger.Errorf(errorMessage)
 		return nil, errors.New(errorMessage)
@@ -140,7 +141,7 @@ func (ctx *Context) RecvMsg() (*MBuf, error) {
 
 	mbuf := convertToMBuf(ctx.Logger, currCMBuf)
 
-	if mbuf.MType != E2_TERM_KEEP_ALIVE_RESP {
+	if mbuf.MType != rmrtypes.E2_TERM_KEEP_ALIVE_RESP {
 
 		transactionId := string(*mbuf.XAction)
 		tmpTid := strings.TrimSpace(transactionId)
@@ -23,18 +23,16 @@ package rmrCgo_test
 import (
 	"bytes"
 	"e2mgr/logger"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/tests"
 	"encoding/json"
-	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"testing"
-	"unsafe"
 )
 
 var (
 	log  *logger.Logger
-	msgr rmrCgo.RmrMessenger
+	msgr rmrtypes.RmrMessenger
 )
 
 func TestLogger(t *testing.T) {
@@ -47,17 +45,6 @@ func TestLogger(t *testing.T) {
 	log.Debugf("#rmr_c_go_api_test.TestLogger - request header: %v\n; request body: %s\n", req.Header, string(boo))
 }
 
-func TestNewMBufSuccess(t *testing.T) {
-	var msgSrc unsafe.Pointer
-	msg := rmrCgo.NewMBuf(tests.MessageType, len(tests.DummyPayload), "RanName", &tests.DummyPayload, &tests.DummyXAction, msgSrc)
-	assert.NotNil(t, msg)
-	assert.NotEmpty(t, msg.Payload)
-	assert.NotEmpty(t, msg.XAction)
-	assert.Equal(t, msg.MType, tests.MessageType)
-	assert.Equal(t, msg.Meid, "RanName")
-	assert.Equal(t, msg.Len, len(tests.DummyPayload))
-}
-
 /*func TestIsReadySuccess(t *testing.T) {
 	log := initLog(t)
 
@@ -74,7 +61,7 @@ func TestSendRecvMsgSuccess(t *testing.T) {
 	if msgr == nil || !msgr.IsReady() {
 		t.Errorf("#rmr_c_go_api_test.TestSendRecvMsgSuccess - The rmr router is not ready")
 	}
-	msg := rmrCgo.NewMBuf(1, tests.MaxMsgSize, "test 1", &tests.DummyPayload, &tests.DummyXAction)
+	msg := rmrtypes.NewMBuf(1, tests.MaxMsgSize, "test 1", &tests.DummyPayload, &tests.DummyXAction)
 	log.Debugf("#rmr_c_go_api_test.TestSendRecvMsgSuccess - Going to send the message: %#v\n", msg)
 	result, err := msgr.SendMsg(msg, true)
 
@@ -96,7 +83,7 @@ func TestSendMsgRmrInvalidMsgNumError(t *testing.T) {
 		t.Errorf("#rmr_c_go_api_test.TestSendMsgRmrInvalidMsgNumError - The rmr router is not ready")
 	}
 
-	msg := rmrCgo.NewMBuf(10, tests.MaxMsgSize, "test 1", &tests.DummyPayload, &tests.DummyXAction)
+	msg := rmrtypes.NewMBuf(10, tests.MaxMsgSize, "test 1", &tests.DummyPayload, &tests.DummyXAction)
 	log.Debugf("#rmr_c_go_api_test.TestSendMsgRmrInvalidMsgNumError - Going to send the message: %#v\n", msg)
 	result, err := msgr.SendMsg(msg, true)
 
@@ -114,7 +101,7 @@ func TestSendMsgRmrInvalidPortError(t *testing.T) {
 		t.Errorf("#rmr_c_go_api_test.TestSendMsgRmrInvalidPortError - The rmr router is not ready")
 	}
 
-	msg := rmrCgo.NewMBuf(1, tests.MaxMsgSize, "test 1", &tests.DummyPayload, &tests.DummyXAction)
+	msg := rmrtypes.NewMBuf(1, tests.MaxMsgSize, "test 1", &tests.DummyPayload, &tests.DummyXAction)
 	log.Debugf("#rmr_c_go_api_test.TestSendMsgRmrInvalidPortError - Going to send the message: %#v\n", msg)
 	result, err := msgr.SendMsg(msg, true)
 
@@ -27,21 +27,10 @@ package rmrCgo
 import "C"
 import (
 	"e2mgr/logger"
-	"fmt"
+	"e2mgr/rmrtypes"
 	"unsafe"
 )
 
-func NewMBuf(mType int, len int, meid string, payload *[]byte, xAction *[]byte, msgSrc unsafe.Pointer) *MBuf {
-	return &MBuf{
-		mType,
-		len,
-		meid,
-		payload,
-		xAction,
-		msgSrc,
-	}
-}
-
 func NewContext(maxMsgSize int, flags int, ctx unsafe.Pointer, logger *logger.Logger) *Context {
 	return &Context{
 		MaxMsgSize: maxMsgSize,
@@ -51,95 +40,80 @@ func NewContext(maxMsgSize int, flags int, ctx unsafe.Pointer, logger *logger.Lo
 	}
 }
 
-//TODO: consider declaring using its own type
-const (
-	// messages
-	RIC_X2_SETUP_REQ                     = C.RIC_X2_SETUP_REQ
-	RIC_X2_SETUP_RESP                    = C.RIC_X2_SETUP_RESP
-	RIC_X2_SETUP_FAILURE                 = C.RIC_X2_SETUP_FAILURE
-	RIC_ENDC_X2_SETUP_REQ                = C.RIC_ENDC_X2_SETUP_REQ
-	RIC_ENDC_X2_SETUP_RESP               = C.RIC_ENDC_X2_SETUP_RESP
-	RIC_ENDC_X2_SETUP_FAILURE            = C.RIC_ENDC_X2_SETUP_FAILURE
-	RIC_SCTP_CONNECTION_FAILURE          = C.RIC_SCTP_CONNECTION_FAILURE
-	RIC_ENB_LOAD_INFORMATION             = C.RIC_ENB_LOAD_INFORMATION
-	RIC_ENB_CONF_UPDATE                  = C.RIC_ENB_CONF_UPDATE
-	RIC_ENB_CONFIGURATION_UPDATE_ACK     = C.RIC_ENB_CONF_UPDATE_ACK
-	RIC_ENB_CONFIGURATION_UPDATE_FAILURE = C.RIC_ENB_CONF_UPDATE_FAILURE
-	RIC_ENDC_CONF_UPDATE                 = C.RIC_ENDC_CONF_UPDATE
-	RIC_ENDC_CONF_UPDATE_ACK             = C.RIC_ENDC_CONF_UPDATE_ACK
-	RIC_ENDC_CONF_UPDATE_FAILURE         = C.RIC_ENDC_CONF_UPDATE_FAILURE
-	RIC_SCTP_CLEAR_ALL                   = C.RIC_SCTP_CLEAR_ALL
-	RIC_X2_RESET_RESP                    = C.RIC_X2_RESET_RESP
-	RIC_X2_RESET                         = C.RIC_X2_RESET
-	RIC_E2_TERM_INIT 					 = C.E2_TERM_INIT
-	RAN_CONNECTED						 = C.RAN_CONNECTED
-	RAN_RESTARTED						 = C.RAN_RESTARTED
-	RAN_RECONFIGURED					 = C.RAN_RECONFIGURED
-	E2_TERM_KEEP_ALIVE_REQ				 = C.E2_TERM_KEEP_ALIVE_REQ
-	E2_TERM_KEEP_ALIVE_RESP				 = C.E2_TERM_KEEP_ALIVE_RESP
-	RIC_E2_SETUP_REQ					 = C.RIC_E2_SETUP_REQ
-	RIC_E2_SETUP_RESP                    = C.RIC_E2_SETUP_RESP
-	RIC_E2_SETUP_FAILURE                 = C.RIC_E2_SETUP_FAILURE
-)
-
 const (
 	RMR_MAX_XACTION_LEN = int(C.RMR_MAX_XID)
 	RMR_MAX_MEID_LEN    = int(C.RMR_MAX_MEID)
-	RMR_MAX_SRC_LEN			= int(C.RMR_MAX_SRC)
-
-	//states
-	RMR_OK             = C.RMR_OK
-	RMR_ERR_BADARG     = C.RMR_ERR_BADARG
-	RMR_ERR_NOENDPT    = C.RMR_ERR_NOENDPT
-	RMR_ERR_EMPTY      = C.RMR_ERR_EMPTY
-	RMR_ERR_NOHDR      = C.RMR_ERR_NOHDR
-	RMR_ERR_SENDFAILED = C.RMR_ERR_SENDFAILED
-	RMR_ERR_CALLFAILED = C.RMR_ERR_CALLFAILED
-	RMR_ERR_NOWHOPEN   = C.RMR_ERR_NOWHOPEN
-	RMR_ERR_WHID       = C.RMR_ERR_WHID
-	RMR_ERR_OVERFLOW   = C.RMR_ERR_OVERFLOW
-	RMR_ERR_RETRY      = C.RMR_ERR_RETRY
-	RMR_ERR_RCVFAILED  = C.RMR_ERR_RCVFAILED
-	RMR_ERR_TIMEOUT    = C.RMR_ERR_TIMEOUT
-	RMR_ERR_UNSET      = C.RMR_ERR_UNSET
-	RMR_ERR_TRUNC      = C.RMR_ERR_TRUNC
-	RMR_ERR_INITFAILED = C.RMR_ERR_INITFAILED
 )
 
-var states = map[int]string{
-	RMR_OK:             "state is good",
-	RMR_ERR_BADARG:     "argument passd to function was unusable",
-	RMR_ERR_NOENDPT:    "send/call could not find an endpoint based on msg type",
-	RMR_ERR_EMPTY:      "msg received had no payload; attempt to send an empty message",
-	RMR_ERR_NOHDR:      "message didn't contain a valid header",
-	RMR_ERR_SENDFAILED: "send failed; errno has nano reason",
-	RMR_ERR_CALLFAILED: "unable to send call() message",
-	RMR_ERR_NOWHOPEN:   "no wormholes are open",
-	RMR_ERR_WHID:       "wormhole id was invalid",
-	RMR_ERR_OVERFLOW:   "operation would have busted through a buffer/field size",
-	RMR_ERR_RETRY:      "request (send/call/rts) failed, but caller should retry (EAGAIN for wrappers)",
-	RMR_ERR_RCVFAILED:  "receive failed (hard error)",
-	RMR_ERR_TIMEOUT:    "message processing call timed out",
-	RMR_ERR_UNSET:      "the message hasn't been populated with a transport buffer",
-	RMR_ERR_TRUNC:      "received message likely truncated",
-	RMR_ERR_INITFAILED: "initialisation of something (probably message) failed",
+// headerValues maps the values rmrtypes declares to their definitions in the RMR headers, which must be the same
+var headerValues = map[int]int{
+	rmrtypes.RIC_X2_SETUP_REQ:                     C.RIC_X2_SETUP_REQ,
+	rmrtypes.RIC_X2_SETUP_RESP:                    C.RIC_X2_SETUP_RESP,
+	rmrtypes.RIC_X2_SETUP_FAILURE:                 C.RIC_X2_SETUP_FAILURE,
+	rmrtypes.RIC_ENDC_X2_SETUP_REQ:                C.RIC_ENDC_X2_SETUP_REQ,
+	rmrtypes.RIC_ENDC_X2_SETUP_RESP:               C.RIC_ENDC_X2_SETUP_RESP,
+	rmrtypes.RIC_ENDC_X2_SETUP_FAILURE:            C.RIC_ENDC_X2_SETUP_FAILURE,
+	rmrtypes.RIC_SCTP_CONNECTION_FAILURE:          C.RIC_SCTP_CONNECTION_FAILURE,
+	rmrtypes.RIC_ENB_LOAD_INFORMATION:             C.RIC_ENB_LOAD_INFORMATION,
+	rmrtypes.RIC_ENB_CONF_UPDATE:                  C.RIC_ENB_CONF_UPDATE,
+	rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_ACK:     C.RIC_ENB_CONF_UPDATE_ACK,
+	rmrtypes.RIC_ENB_CONFIGURATION_UPDATE_FAILURE: C.RIC_ENB_CONF_UPDATE_FAILURE,
+	rmrtypes.RIC_ENDC_CONF_UPDATE:                 C.RIC_ENDC_CONF_UPDATE,
+	rmrtypes.RIC_ENDC_CONF_UPDATE_ACK:             C.RIC_ENDC_CONF_UPDATE_ACK,
+	rmrtypes.RIC_ENDC_CONF_UPDATE_FAILURE:         C.RIC_ENDC_CONF_UPDATE_FAILURE,
+	rmrtypes.RIC_SCTP_CLEAR_ALL:                   C.RIC_SCTP_CLEAR_ALL,
+	rmrtypes.RIC_X2_RESET_RESP:                    C.RIC_X2_RESET_RESP,
+	rmrtypes.RIC_X2_RESET:                         C.RIC_X2_RESET,
+	rmrtypes.RIC_E2_TERM_INIT:                     C.E2_TERM_INIT,
+	rmrtypes.RAN_CONNECTED:                        C.RAN_CONNECTED,
+	rmrtypes.RAN_RESTARTED:                        C.RAN_RESTARTED,
+	rmrtypes.RAN_RECONFIGURED:                     C.RAN_RECONFIGURED,
+	rmrtypes.E2_TERM_KEEP_ALIVE_REQ:               C.E2_TERM_KEEP_ALIVE_REQ,
+	rmrtypes.E2_TERM_KEEP_ALIVE_RESP:              C.E2_TERM_KEEP_ALIVE_RESP,
+	rmrtypes.RIC_E2_SETUP_REQ:                     C.RIC_E2_SETUP_REQ,
+	rmrtypes.RIC_E2_SETUP_RESP:                    C.RIC_E2_SETUP_RESP,
+	rmrtypes.RIC_E2_SETUP_FAILURE:                 C.RIC_E2_SETUP_FAILURE,
 }
 
-type MBuf struct {
-	MType   int
-	Len     int
-	Meid    string //Managed entity id (RAN name)
-	Payload *[]byte
-	XAction *[]byte
-	msgSrc  unsafe.Pointer
+// headerStates maps the states rmrtypes declares to their definitions in rmr.h
+var headerStates = map[int]int{
+	rmrtypes.RMR_OK:             C.RMR_OK,
+	rmrtypes.RMR_ERR_BADARG:     C.RMR_ERR_BADARG,
+	rmrtypes.RMR_ERR_NOENDPT:    C.RMR_ERR_NOENDPT,
+	rmrtypes.RMR_ERR_EMPTY:      C.RMR_ERR_EMPTY,
+	rmrtypes.RMR_ERR_NOHDR:      C.RMR_ERR_NOHDR,
+	rmrtypes.RMR_ERR_SENDFAILED: C.RMR_ERR_SENDFAILED,
+	rmrtypes.RMR_ERR_CALLFAILED: C.RMR_ERR_CALLFAILED,
+	rmrtypes.RMR_ERR_NOWHOPEN:   C.RMR_ERR_NOWHOPEN,
+	rmrtypes.RMR_ERR_WHID:       C.RMR_ERR_WHID,
+	rmrtypes.RMR_ERR_OVERFLOW:   C.RMR_ERR_OVERFLOW,
+	rmrtypes.RMR_ERR_RETRY:      C.RMR_ERR_RETRY,
+	rmrtypes.RMR_ERR_RCVFAILED:  C.RMR_ERR_RCVFAILED,
+	rmrtypes.RMR_ERR_TIMEOUT:    C.RMR_ERR_TIMEOUT,
+	rmrtypes.RMR_ERR_UNSET:      C.RMR_ERR_UNSET,
+	rmrtypes.RMR_ERR_TRUNC:      C.RMR_ERR_TRUNC,
+	rmrtypes.RMR_ERR_INITFAILED: C.RMR_ERR_INITFAILED,
 }
 
-func (m MBuf) String() string {
-	return fmt.Sprintf("{ MType: %d, Len: %d, Meid: %q, Xaction: %q, Payload: [%x] }", m.MType, m.Len, m.Meid, m.XAction, m.Payload)
-}
+const headerMaxSrcLen = int(C.RMR_MAX_SRC)
 
-func (m MBuf) GetMsgSrc() unsafe.Pointer {
-	return m.msgSrc
+var states = map[int]string{
+	rmrtypes.RMR_OK:             "state is good",
+	rmrtypes.RMR_ERR_BADARG:     "argument passd to function was unusable",
+	rmrtypes.RMR_ERR_NOENDPT:    "send/call could not find an endpoint based on msg type",
+	rmrtypes.RMR_ERR_EMPTY:      "msg received had no payload; attempt to send an empty message",
+	rmrtypes.RMR_ERR_NOHDR:      "message didn't contain a valid header",
+	rmrtypes.RMR_ERR_SENDFAILED: "send failed; errno has nano reason",
+	rmrtypes.RMR_ERR_CALLFAILED: "unable to send call() message",
+	rmrtypes.RMR_ERR_NOWHOPEN:   "no wormholes are open",
+	rmrtypes.RMR_ERR_WHID:       "wormhole id was invalid",
+	rmrtypes.RMR_ERR_OVERFLOW:   "operation would have busted through a buffer/field size",
+	rmrtypes.RMR_ERR_RETRY:      "request (send/call/rts) failed, but caller should retry (EAGAIN for wrappers)",
+	rmrtypes.RMR_ERR_RCVFAILED:  "receive failed (hard error)",
+	rmrtypes.RMR_ERR_TIMEOUT:    "message processing call timed out",
+	rmrtypes.RMR_ERR_UNSET:      "the message hasn't been populated with a transport buffer",
+	rmrtypes.RMR_ERR_TRUNC:      "received message likely truncated",
+	rmrtypes.RMR_ERR_INITFAILED: "initialisation of something (probably message) failed",
 }
 
 type Context struct {
@@ -148,12 +122,3 @@ type Context struct {
 	RmrCtx     unsafe.Pointer
 	Logger     *logger.Logger
 }
-
-type RmrMessenger interface {
-	Init(port string, maxMsgSize int, flags int, logger *logger.Logger) RmrMessenger
-	SendMsg(msg *MBuf, printLogs bool) (*MBuf, error)
-	WhSendMsg(msg *MBuf, printLogs bool) (*MBuf, error)
-	RecvMsg() (*MBuf, error)
-	IsReady() bool
-	Close()
-}
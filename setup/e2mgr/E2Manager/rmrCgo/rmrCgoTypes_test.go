@@ -0,0 +1,39 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package rmrCgo
+
+import (
+	"e2mgr/rmrtypes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRmrTypesMatchHeaders(t *testing.T) {
+	assert.Len(t, headerValues, 26)
+	for value, headerValue := range headerValues {
+		assert.Equal(t, headerValue, value)
+	}
+	assert.Len(t, headerStates, 16)
+	for state, headerState := range headerStates {
+		assert.Equal(t, headerState, state)
+	}
+	assert.Equal(t, headerMaxSrcLen, rmrtypes.RMR_MAX_SRC_LEN)
+}
@@ -26,13 +26,14 @@ package rmrCgo
 import "C"
 import (
 	"e2mgr/logger"
+	"e2mgr/rmrtypes"
 	"bytes"
 	"encoding/binary"
 	"strings"
 	"unsafe"
 )
 
-func convertToMBuf(logger *logger.Logger, m *C.rmr_mbuf_t) *MBuf {
+func convertToMBuf(logger *logger.Logger, m *C.rmr_mbuf_t) *rmrtypes.MBuf {
 	payloadArr := C.GoBytes(unsafe.Pointer(m.payload),C.int(m.len))
 	xActionArr := C.GoBytes(unsafe.Pointer(m.xaction),C.int(RMR_MAX_XACTION_LEN))
 
@@ -40,15 +41,10 @@ func convertToMBuf(logger *logger.Logger, m *C.rmr_mbuf_t) *MBuf {
 	xActionStr :=  strings.TrimRight(string(xActionArr),"\040\000")
 	xActionArr = []byte(xActionStr)
 
-	mbuf := &MBuf{
-		MType: int(m.mtype),
-		Len:   int(m.len),
-		Payload: &payloadArr,
-		XAction: &xActionArr,
-		msgSrc: C.CBytes(make([]byte, RMR_MAX_SRC_LEN)),
-	}
+	msgSrc := C.CBytes(make([]byte, rmrtypes.RMR_MAX_SRC_LEN))
+	C.rmr_get_src(m, (*C.uchar)(msgSrc)) // Capture message source
 
-	C.rmr_get_src(m, (*C.uchar)(mbuf.msgSrc)) // Capture message source
+	mbuf := rmrtypes.NewMBuf(int(m.mtype), int(m.len), "", &payloadArr, &xActionArr, msgSrc)
 
 	meidBuf := make([]byte, RMR_MAX_MEID_LEN)
 	if meidCstr := C.rmr_get_meid(m, (*C.uchar)(unsafe.Pointer(&meidBuf[0]))); meidCstr != nil {
@@ -58,7 +54,7 @@ func convertToMBuf(logger *logger.Logger, m *C.rmr_mbuf_t) *MBuf {
 	return mbuf
 }
 
-func (ctx *Context) getAllocatedCRmrMBuf(logger *logger.Logger, mBuf *MBuf, maxMsgSize int) (cMBuf *C.rmr_mbuf_t) {
+func (ctx *Context) getAllocatedCRmrMBuf(logger *logger.Logger, mBuf *rmrtypes.MBuf, maxMsgSize int) (cMBuf *C.rmr_mbuf_t) {
 	var xActionBuf [RMR_MAX_XACTION_LEN]byte
 	var meidBuf[RMR_MAX_MEID_LEN]byte
 
@@ -0,0 +1,197 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+// Package rmrcapture records the RMR messages E2 Manager exchanges to a capture file and feeds them back later.
+// A capture file holds one JSON object per line: a header naming the format and its version, then a record per
+// message. A file appended to by several runs holds a header per run.
+package rmrcapture
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	Format  = "e2mgr-rmr-capture"
+	Version = 1
+
+	maxLineSize = 4 * 1024 * 1024
+)
+
+type Direction string
+
+const (
+	Received Direction = "received"
+	Sent     Direction = "sent"
+)
+
+type Header struct {
+	Format  string    `json:"format,omitempty"`
+	Version int       `json:"version,omitempty"`
+	Created time.Time `json:"created,omitempty"`
+}
+
+// Record is a message as it was received or sent. The payload is base64 encoded in the file
+type Record struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Direction     Direction `json:"direction"`
+	MType         int       `json:"mType"`
+	Meid          string    `json:"meid"`
+	TransactionId string    `json:"transactionId"`
+	Source        string    `json:"source,omitempty"`
+	Payload       []byte    `json:"payload"`
+}
+
+// Writer appends records to a capture, one write per record so that a crash loses at most the record being written
+type Writer struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriter starts a capture on w by writing its header
+func NewWriter(w io.Writer) (*Writer, error) {
+	writer := &Writer{w: w}
+	err := writer.writeLine(Header{Format: Format, Version: Version, Created: time.Now().UTC()})
+	if err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// CreateFile starts a capture at the end of the file at path, which is created if missing
+func CreateFile(path string) (*Writer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("#rmrcapture.CreateFile - failed to open capture file %s, error: %s", path, err)
+	}
+
+	writer, err := NewWriter(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("#rmrcapture.CreateFile - failed to write capture file %s, error: %s", path, err)
+	}
+	writer.closer = file
+	return writer, nil
+}
+
+func (w *Writer) Write(record Record) error {
+	return w.writeLine(record)
+}
+
+func (w *Writer) Close() error {
+	if w.closer == nil {
+		return nil
+	}
+	return w.closer.Close()
+}
+
+func (w *Writer) writeLine(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err = w.w.Write(append(line, '\n'))
+	return err
+}
+
+// entry is any line of a capture, a header when Format is set
+type entry struct {
+	Header
+	Record
+}
+
+// Reader reads the records of a capture in order
+type Reader struct {
+	scanner *bufio.Scanner
+	line    int
+	started bool
+}
+
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &Reader{scanner: scanner}
+}
+
+// Next returns the next record, or io.EOF at the end of the capture. A capture of another format or of a later
+// version is refused
+func (r *Reader) Next() (Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+		if len(r.scanner.Bytes()) == 0 {
+			continue
+		}
+
+		e := entry{}
+		err := json.Unmarshal(r.scanner.Bytes(), &e)
+		if err != nil {
+			return Record{}, fmt.Errorf("#Reader.Next - line %d: %s", r.line, err)
+		}
+
+		if e.Format != "" {
+			if e.Format != Format || e.Version < 1 || e.Version > Version {
+				return Record{}, fmt.Errorf("#Reader.Next - line %d: unsupported capture %s version %d", r.line, e.Format, e.Version)
+			}
+			r.started = true
+			continue
+		}
+
+		if !r.started {
+			return Record{}, fmt.Errorf("#Reader.Next - line %d: missing capture header", r.line)
+		}
+		return e.Record, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return Record{}, fmt.Errorf("#Reader.Next - line %d: %s", r.line+1, err)
+	}
+	return Record{}, io.EOF
+}
+
+// ReadFile returns every record of the capture file at path
+func ReadFile(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("#rmrcapture.ReadFile - failed to open capture file %s, error: %s", path, err)
+	}
+	defer file.Close()
+
+	reader := NewReader(file)
+	records := []Record{}
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,125 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrcapture
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRecord(offset time.Duration, direction Direction, mType int, meid string, payload string) Record {
+	return Record{
+		Timestamp:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Add(offset),
+		Direction:     direction,
+		MType:         mType,
+		Meid:          meid,
+		TransactionId: "xid-" + meid,
+		Source:        "e2term",
+		Payload:       []byte(payload),
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer)
+	assert.Nil(t, err)
+	records := []Record{
+		newRecord(0, Received, 12001, "ran1", "setup\x00\x01"),
+		newRecord(time.Second, Sent, 12002, "ran1", ""),
+	}
+	for _, record := range records {
+		assert.Nil(t, writer.Write(record))
+	}
+	assert.Nil(t, writer.Close())
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"format":"e2mgr-rmr-capture","version":1`)
+
+	reader := NewReader(buffer)
+	for _, expected := range records {
+		record, err := reader.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, record)
+	}
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestCreateFileAppends(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rmr.capture")
+
+	for _, meid := range []string{"ran1", "ran2"} {
+		writer, err := CreateFile(path)
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Write(newRecord(0, Received, 12001, meid, "setup")))
+		assert.Nil(t, writer.Close())
+	}
+
+	records, err := ReadFile(path)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "ran1", records[0].Meid)
+	assert.Equal(t, "ran2", records[1].Meid)
+}
+
+func TestCreateFileFailure(t *testing.T) {
+	_, err := CreateFile(filepath.Join("no", "such", "dir", "rmr.capture"))
+	assert.NotNil(t, err)
+}
+
+func TestReadFileNotFound(t *testing.T) {
+	_, err := ReadFile(filepath.Join("no", "such", "rmr.capture"))
+	assert.NotNil(t, err)
+}
+
+func TestNextUnsupportedVersion(t *testing.T) {
+	reader := NewReader(strings.NewReader(`{"format":"e2mgr-rmr-capture","version":2}` + "\n"))
+	_, err := reader.Next()
+	assert.Contains(t, err.Error(), "unsupported capture")
+}
+
+func TestNextUnknownFormat(t *testing.T) {
+	reader := NewReader(strings.NewReader(`{"format":"pcap","version":1}` + "\n"))
+	_, err := reader.Next()
+	assert.Contains(t, err.Error(), "unsupported capture")
+}
+
+func TestNextMissingHeader(t *testing.T) {
+	reader := NewReader(strings.NewReader(`{"direction":"received","mType":12001,"meid":"ran1"}` + "\n"))
+	_, err := reader.Next()
+	assert.Contains(t, err.Error(), "missing capture header")
+}
+
+func TestNextMalformedLine(t *testing.T) {
+	reader := NewReader(strings.NewReader(`{"format":"e2mgr-rmr-capture","version":1}` + "\n\n{\n"))
+	_, err := reader.Next()
+	assert.Contains(t, err.Error(), "line 3")
+}
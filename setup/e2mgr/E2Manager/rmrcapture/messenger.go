@@ -0,0 +1,110 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrcapture
+
+import (
+	"e2mgr/logger"
+	"e2mgr/rmrtypes"
+	"time"
+)
+
+// Messenger taps an RmrMessenger: every message received through it and every message it sends successfully is
+// written to the capture. A capture which cannot be written is logged and never fails the message
+type Messenger struct {
+	logger    *logger.Logger
+	messenger rmrtypes.RmrMessenger
+	writer    *Writer
+}
+
+func NewMessenger(logger *logger.Logger, messenger rmrtypes.RmrMessenger, writer *Writer) *Messenger {
+	return &Messenger{
+		logger:    logger,
+		messenger: messenger,
+		writer:    writer,
+	}
+}
+
+func (m *Messenger) Init(port string, maxMsgSize int, flags int, logger *logger.Logger) rmrtypes.RmrMessenger {
+	return NewMessenger(logger, m.messenger.Init(port, maxMsgSize, flags, logger), m.writer)
+}
+
+func (m *Messenger) SendMsg(msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error) {
+	response, err := m.messenger.SendMsg(msg, printLogs)
+	if err == nil {
+		m.capture(Sent, msg)
+	}
+	return response, err
+}
+
+func (m *Messenger) WhSendMsg(msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error) {
+	response, err := m.messenger.WhSendMsg(msg, printLogs)
+	if err == nil {
+		m.capture(Sent, msg)
+	}
+	return response, err
+}
+
+func (m *Messenger) RecvMsg() (*rmrtypes.MBuf, error) {
+	msg, err := m.messenger.RecvMsg()
+	if err == nil && msg != nil {
+		m.capture(Received, msg)
+	}
+	return msg, err
+}
+
+func (m *Messenger) IsReady() bool {
+	return m.messenger.IsReady()
+}
+
+func (m *Messenger) Close() {
+	m.messenger.Close()
+}
+
+func (m *Messenger) capture(direction Direction, msg *rmrtypes.MBuf) {
+	record := Record{
+		Timestamp: time.Now().UTC(),
+		Direction: direction,
+		MType:     msg.MType,
+		Meid:      msg.Meid,
+		Source:    msg.GetMsgSrcString(),
+		Payload:   []byte{},
+	}
+	if msg.XAction != nil {
+		record.TransactionId = string((*msg.XAction)[:trimNul(*msg.XAction)])
+	}
+	if msg.Payload != nil {
+		record.Payload = *msg.Payload
+	}
+
+	err := m.writer.Write(record)
+	if err != nil {
+		m.logger.Errorf("#Messenger.capture - RAN name: %s - failed to capture message type %d, error: %s", msg.Meid, msg.MType, err)
+	}
+}
+
+// trimNul returns the length of a transaction id, which RMR pads with NUL bytes
+func trimNul(b []byte) int {
+	for i, c := range b {
+		if c == 0 {
+			return i
+		}
+	}
+	return len(b)
+}
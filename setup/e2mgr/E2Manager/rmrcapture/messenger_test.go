@@ -0,0 +1,128 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrcapture
+
+import (
+	"bytes"
+	"e2mgr/logger"
+	"e2mgr/rmrmemory"
+	"e2mgr/rmrtypes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func initLog(t *testing.T) *logger.Logger {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#initLog test - failed to initialize logger, error: %s", err)
+	}
+	return log
+}
+
+func initPair(t *testing.T) (*rmrmemory.Messenger, *rmrmemory.Messenger) {
+	log := initLog(t)
+	bus := rmrmemory.NewBus()
+	e2mgr, err := rmrmemory.NewMessenger(bus, "e2mgr", 65536, log)
+	assert.Nil(t, err)
+	e2term, err := rmrmemory.NewMessenger(bus, "e2term", 65536, log)
+	assert.Nil(t, err)
+	bus.Connect(e2mgr, e2term)
+	return e2mgr, e2term
+}
+
+func newMBuf(mType int, meid string, payload string, xAction string) *rmrtypes.MBuf {
+	payloadBytes := []byte(payload)
+	xActionBytes := []byte(xAction)
+	return rmrtypes.NewMBuf(mType, len(payloadBytes), meid, &payloadBytes, &xActionBytes, nil)
+}
+
+func TestMessengerCapturesReceivedAndSent(t *testing.T) {
+	e2mgr, e2term := initPair(t)
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer)
+	assert.Nil(t, err)
+	messenger := NewMessenger(initLog(t), e2mgr, writer)
+
+	_, err = e2term.SendMsg(newMBuf(rmrtypes.RIC_E2_SETUP_REQ, "ran1", "setup", "xid1"), true)
+	assert.Nil(t, err)
+	received, err := messenger.RecvMsg()
+	assert.Nil(t, err)
+	assert.Equal(t, "ran1", received.Meid)
+
+	_, err = messenger.SendMsg(newMBuf(rmrtypes.RIC_E2_SETUP_RESP, "ran1", "response", "xid1\x00\x00"), true)
+	assert.Nil(t, err)
+	_, err = e2term.Receive(time.Second)
+	assert.Nil(t, err)
+
+	records := readAll(t, buffer)
+	assert.Len(t, records, 2)
+	assert.Equal(t, Received, records[0].Direction)
+	assert.Equal(t, rmrtypes.RIC_E2_SETUP_REQ, records[0].MType)
+	assert.Equal(t, "ran1", records[0].Meid)
+	assert.Equal(t, "xid1", records[0].TransactionId)
+	assert.Equal(t, "e2term", records[0].Source)
+	assert.Equal(t, []byte("setup"), records[0].Payload)
+	assert.Equal(t, Sent, records[1].Direction)
+	assert.Equal(t, rmrtypes.RIC_E2_SETUP_RESP, records[1].MType)
+	assert.Equal(t, "xid1", records[1].TransactionId)
+	assert.Equal(t, "", records[1].Source)
+	assert.Equal(t, []byte("response"), records[1].Payload)
+}
+
+func TestMessengerSkipsFailedSend(t *testing.T) {
+	e2mgr, _ := initPair(t)
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer)
+	assert.Nil(t, err)
+	messenger := NewMessenger(initLog(t), e2mgr, writer)
+	e2mgr.Close()
+
+	_, err = messenger.WhSendMsg(newMBuf(rmrtypes.RIC_E2_SETUP_RESP, "ran1", "response", ""), true)
+	assert.NotNil(t, err)
+	assert.Empty(t, readAll(t, buffer))
+}
+
+func TestMessengerInitKeepsCapturing(t *testing.T) {
+	e2mgr, e2term := initPair(t)
+	buffer := &bytes.Buffer{}
+	writer, err := NewWriter(buffer)
+	assert.Nil(t, err)
+	messenger := NewMessenger(initLog(t), e2mgr, writer).Init("", 65536, 0, initLog(t))
+	assert.True(t, messenger.IsReady())
+
+	_, err = messenger.SendMsg(newMBuf(rmrtypes.RIC_SCTP_CLEAR_ALL, "", "", ""), true)
+	assert.Nil(t, err)
+	_, err = e2term.Receive(time.Second)
+	assert.Nil(t, err)
+	assert.Len(t, readAll(t, buffer), 1)
+}
+
+func readAll(t *testing.T, buffer *bytes.Buffer) []Record {
+	reader := NewReader(bytes.NewReader(buffer.Bytes()))
+	records := []Record{}
+	for {
+		record, err := reader.Next()
+		if err != nil {
+			return records
+		}
+		records = append(records, record)
+	}
+}
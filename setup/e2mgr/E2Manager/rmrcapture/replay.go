@@ -0,0 +1,138 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrcapture
+
+import (
+	"context"
+	"e2mgr/logger"
+	"e2mgr/rmrtypes"
+	"time"
+)
+
+// Filter selects the records of a capture to replay. Direction defaults to Received, the messages E2 Manager was
+// sent; an empty list of RAN names or message types selects them all
+type Filter struct {
+	Direction Direction
+	RanNames  []string
+	MTypes    []int
+}
+
+func (f Filter) Match(record Record) bool {
+	direction := f.Direction
+	if direction == "" {
+		direction = Received
+	}
+	if record.Direction != direction {
+		return false
+	}
+	if len(f.RanNames) > 0 && !containsString(f.RanNames, record.Meid) {
+		return false
+	}
+	if len(f.MTypes) > 0 && !containsInt(f.MTypes, record.MType) {
+		return false
+	}
+	return true
+}
+
+type ReplayStats struct {
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// Replayer sends the records of a capture through a messenger, to E2 Manager when the messenger is an E2T endpoint
+type Replayer struct {
+	logger    *logger.Logger
+	messenger rmrtypes.RmrMessenger
+}
+
+func NewReplayer(logger *logger.Logger, messenger rmrtypes.RmrMessenger) *Replayer {
+	return &Replayer{
+		logger:    logger,
+		messenger: messenger,
+	}
+}
+
+// Replay sends the records filter selects, keeping the intervals of the capture divided by speed: 1 replays at the
+// original speed, 2 twice as fast, and 0 sends everything without waiting. It stops when ctx is done
+func (r *Replayer) Replay(ctx context.Context, records []Record, filter Filter, speed float64) (ReplayStats, error) {
+	stats := ReplayStats{}
+	var first time.Time
+	start := time.Now()
+
+	for _, record := range records {
+		if !filter.Match(record) {
+			stats.Skipped++
+			continue
+		}
+
+		if first.IsZero() {
+			first = record.Timestamp
+		}
+
+		if speed > 0 {
+			offset := time.Duration(float64(record.Timestamp.Sub(first)) / speed)
+			select {
+			case <-time.After(time.Until(start.Add(offset))):
+			case <-ctx.Done():
+				return stats, ctx.Err()
+			}
+		} else if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		err := r.send(record)
+		if err != nil {
+			stats.Failed++
+			r.logger.Errorf("#Replayer.Replay - RAN name: %s - failed to send message type %d, error: %s", record.Meid, record.MType, err)
+			continue
+		}
+		stats.Sent++
+	}
+
+	r.logger.Infof("#Replayer.Replay - sent: %d, failed: %d, skipped: %d", stats.Sent, stats.Failed, stats.Skipped)
+	return stats, nil
+}
+
+func (r *Replayer) send(record Record) error {
+	payload := append([]byte{}, record.Payload...)
+	xAction := []byte(record.TransactionId)
+	msg := rmrtypes.NewMBuf(record.MType, len(payload), record.Meid, &payload, &xAction, nil)
+	_, err := r.messenger.SendMsg(msg, false)
+	return err
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrcapture
+
+import (
+	"context"
+	"e2mgr/rmrtypes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func captured() []Record {
+	return []Record{
+		newRecord(0, Received, rmrtypes.RIC_E2_TERM_INIT, "", "init"),
+		newRecord(100*time.Millisecond, Received, rmrtypes.RIC_E2_SETUP_REQ, "ran1", "setup1"),
+		newRecord(200*time.Millisecond, Sent, rmrtypes.RIC_E2_SETUP_RESP, "ran1", "response1"),
+		newRecord(300*time.Millisecond, Received, rmrtypes.RIC_E2_SETUP_REQ, "ran2", "setup2"),
+		newRecord(400*time.Millisecond, Received, rmrtypes.RIC_SCTP_CONNECTION_FAILURE, "ran1", ""),
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	records := captured()
+
+	assert.True(t, Filter{}.Match(records[0]))
+	assert.False(t, Filter{}.Match(records[2]))
+	assert.True(t, Filter{Direction: Sent}.Match(records[2]))
+	assert.True(t, Filter{RanNames: []string{"ran1"}}.Match(records[1]))
+	assert.False(t, Filter{RanNames: []string{"ran1"}}.Match(records[3]))
+	assert.True(t, Filter{MTypes: []int{rmrtypes.RIC_E2_SETUP_REQ}}.Match(records[3]))
+	assert.False(t, Filter{MTypes: []int{rmrtypes.RIC_E2_SETUP_REQ}}.Match(records[4]))
+}
+
+func receiveAll(t *testing.T, receive func(time.Duration) (*rmrtypes.MBuf, error), count int) []*rmrtypes.MBuf {
+	var received []*rmrtypes.MBuf
+	for i := 0; i < count; i++ {
+		mbuf, err := receive(time.Second)
+		if err != nil {
+			t.Fatalf("expected %d messages, got %d, error: %s", count, len(received), err)
+		}
+		received = append(received, mbuf)
+	}
+	return received
+}
+
+func TestReplayFiltered(t *testing.T) {
+	e2mgr, e2term := initPair(t)
+	replayer := NewReplayer(initLog(t), e2term)
+
+	stats, err := replayer.Replay(context.Background(), captured(), Filter{RanNames: []string{"ran1"}}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, ReplayStats{Sent: 2, Skipped: 3}, stats)
+
+	received := receiveAll(t, e2mgr.Receive, 2)
+	assert.Equal(t, rmrtypes.RIC_E2_SETUP_REQ, received[0].MType)
+	assert.Equal(t, "ran1", received[0].Meid)
+	assert.Equal(t, []byte("setup1"), *received[0].Payload)
+	assert.Equal(t, []byte("xid-ran1"), *received[0].XAction)
+	assert.Equal(t, rmrtypes.RIC_SCTP_CONNECTION_FAILURE, received[1].MType)
+}
+
+func TestReplayScaledSpeed(t *testing.T) {
+	e2mgr, e2term := initPair(t)
+	replayer := NewReplayer(initLog(t), e2term)
+
+	start := time.Now()
+	stats, err := replayer.Replay(context.Background(), captured(), Filter{}, 4)
+	elapsed := time.Since(start)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, stats.Sent)
+	assert.True(t, elapsed >= 100*time.Millisecond, "replay took %s", elapsed)
+	assert.True(t, elapsed < 400*time.Millisecond, "replay took %s", elapsed)
+	receiveAll(t, e2mgr.Receive, 4)
+}
+
+func TestReplayCountsFailures(t *testing.T) {
+	_, e2term := initPair(t)
+	e2term.Close()
+	replayer := NewReplayer(initLog(t), e2term)
+
+	stats, err := replayer.Replay(context.Background(), captured(), Filter{}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, ReplayStats{Failed: 4, Skipped: 1}, stats)
+}
+
+func TestReplayCancelled(t *testing.T) {
+	_, e2term := initPair(t)
+	replayer := NewReplayer(initLog(t), e2term)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	stats, err := replayer.Replay(ctx, captured(), Filter{}, 1)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, stats.Sent)
+}
@@ -0,0 +1,146 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+// Package rmrmemory provides an in-memory implementation of rmrtypes.RmrMessenger. Messengers attached to the same
+// Bus exchange messages without an RMR router or routing table, so components can be wired as in main.go and
+// driven from Go tests or simulators.
+package rmrmemory
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+type routeKey struct {
+	mType int
+	meid  string
+}
+
+// Bus delivers the messages of its messengers. A message goes to the endpoint routed for its type and meid, else
+// for its type, else to the peer its sender was connected to
+type Bus struct {
+	mu        sync.RWMutex
+	endpoints map[string]*Messenger
+	sources   map[unsafe.Pointer]*Messenger
+	routes    map[routeKey]string
+	peers     map[string]string
+	randMu    sync.Mutex
+	rand      *rand.Rand
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		endpoints: make(map[string]*Messenger),
+		sources:   make(map[unsafe.Pointer]*Messenger),
+		routes:    make(map[routeKey]string),
+		peers:     make(map[string]string),
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Seed makes the drop and jitter decisions of the bus reproducible
+func (b *Bus) Seed(seed int64) {
+	b.randMu.Lock()
+	defer b.randMu.Unlock()
+	b.rand = rand.New(rand.NewSource(seed))
+}
+
+func (b *Bus) attach(m *Messenger) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.endpoints[m.name]; ok {
+		return fmt.Errorf("endpoint %s already attached", m.name)
+	}
+
+	b.endpoints[m.name] = m
+	b.sources[m.source] = m
+	return nil
+}
+
+// Route sends every message of mType to endpoint, whatever its sender
+func (b *Bus) Route(mType int, endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.routes[routeKey{mType: mType}] = endpoint
+}
+
+// RouteMeid sends the messages of mType for the managed entity meid to endpoint, like an RMR meid route
+func (b *Bus) RouteMeid(mType int, meid string, endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.routes[routeKey{mType: mType, meid: meid}] = endpoint
+}
+
+// Connect makes a and b each other's default destination
+func (b *Bus) Connect(a *Messenger, c *Messenger) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.peers[a.name] = c.name
+	b.peers[c.name] = a.name
+}
+
+func (b *Bus) Endpoint(name string) (*Messenger, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	m, ok := b.endpoints[name]
+	return m, ok
+}
+
+func (b *Bus) destination(from *Messenger, mType int, meid string) (*Messenger, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	name, ok := b.routes[routeKey{mType: mType, meid: meid}]
+	if !ok {
+		name, ok = b.routes[routeKey{mType: mType}]
+	}
+	if !ok {
+		name, ok = b.peers[from.name]
+	}
+	if !ok {
+		return nil, false
+	}
+
+	m, ok := b.endpoints[name]
+	return m, ok
+}
+
+func (b *Bus) source(msgSrc unsafe.Pointer) (*Messenger, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	m, ok := b.sources[msgSrc]
+	return m, ok
+}
+
+func (b *Bus) float64() float64 {
+	b.randMu.Lock()
+	defer b.randMu.Unlock()
+	return b.rand.Float64()
+}
+
+func (b *Bus) int63n(n int64) int64 {
+	b.randMu.Lock()
+	defer b.randMu.Unlock()
+	return b.rand.Int63n(n)
+}
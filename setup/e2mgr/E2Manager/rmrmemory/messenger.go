@@ -0,0 +1,305 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package rmrmemory
+
+import (
+	"e2mgr/logger"
+	"e2mgr/rmrtypes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+const DefaultQueueSize = 4096
+
+// Faults are applied to the messages a messenger sends. MessageTypes restricts them to some message types, all
+// types are affected when it is empty
+type Faults struct {
+	Latency      time.Duration
+	Jitter       time.Duration
+	DropRate     float64
+	MessageTypes []int
+}
+
+func (f Faults) applyTo(mType int) bool {
+	if len(f.MessageTypes) == 0 {
+		return true
+	}
+	for _, t := range f.MessageTypes {
+		if t == mType {
+			return true
+		}
+	}
+	return false
+}
+
+type Stats struct {
+	Sent      int64
+	Dropped   int64
+	Received  int64
+	Overflows int64
+}
+
+type delayedMessage struct {
+	mbuf      *rmrtypes.MBuf
+	deliverAt time.Time
+}
+
+// Messenger is an endpoint of a Bus implementing rmrtypes.RmrMessenger. Received messages carry the sender as their
+// message source, so WhSendMsg replies reach it as a wormhole would
+type Messenger struct {
+	name       string
+	bus        *Bus
+	logger     *logger.Logger
+	maxMsgSize int
+	source     unsafe.Pointer
+	inbox      chan *rmrtypes.MBuf
+	delayed    chan delayedMessage
+	closed     chan struct{}
+	closeOnce  sync.Once
+	faultsMu   sync.RWMutex
+	faults     Faults
+	sent       int64
+	dropped    int64
+	received   int64
+	overflows  int64
+	delaying   int64
+}
+
+// NewMessenger attaches a messenger named name to bus. maxMsgSize of 0 means no size limit
+func NewMessenger(bus *Bus, name string, maxMsgSize int, logger *logger.Logger) (*Messenger, error) {
+	source := append([]byte(name), 0)
+	m := &Messenger{
+		name:       name,
+		bus:        bus,
+		logger:     logger,
+		maxMsgSize: maxMsgSize,
+		source:     unsafe.Pointer(&source[0]),
+		inbox:      make(chan *rmrtypes.MBuf, DefaultQueueSize),
+		delayed:    make(chan delayedMessage, DefaultQueueSize),
+		closed:     make(chan struct{}),
+	}
+
+	err := bus.attach(m)
+	if err != nil {
+		return nil, err
+	}
+
+	go m.deliverDelayed()
+	return m, nil
+}
+
+func (m *Messenger) Name() string {
+	return m.name
+}
+
+// Init returns the messenger itself, it is ready as soon as it is attached to its bus
+func (m *Messenger) Init(port string, maxMsgSize int, flags int, logger *logger.Logger) rmrtypes.RmrMessenger {
+	m.maxMsgSize = maxMsgSize
+	m.logger = logger
+	return m
+}
+
+func (m *Messenger) SetFaults(faults Faults) {
+	m.faultsMu.Lock()
+	defer m.faultsMu.Unlock()
+	m.faults = faults
+}
+
+func (m *Messenger) SendMsg(msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error) {
+	destination, ok := m.bus.destination(m, msg.MType, msg.Meid)
+
+	if !ok {
+		return nil, fmt.Errorf("#rmrmemory.Messenger.SendMsg - Failed to send message. state: %v - %s, endpoint: %s, message type: %d",
+			rmrtypes.RMR_ERR_NOENDPT, "send/call could not find an endpoint based on msg type", m.name, msg.MType)
+	}
+
+	return m.send(destination, msg, printLogs)
+}
+
+// WhSendMsg sends msg to the endpoint identified by its message source, which is the sender of a received message
+func (m *Messenger) WhSendMsg(msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error) {
+	destination, ok := m.bus.source(msg.GetMsgSrc())
+
+	if !ok {
+		return nil, fmt.Errorf("#rmrmemory.Messenger.WhSendMsg - Failed to send message. state: %v - %s, endpoint: %s, message type: %d",
+			rmrtypes.RMR_ERR_WHID, "wormhole id was invalid", m.name, msg.MType)
+	}
+
+	return m.send(destination, msg, printLogs)
+}
+
+func (m *Messenger) send(destination *Messenger, msg *rmrtypes.MBuf, printLogs bool) (*rmrtypes.MBuf, error) {
+	if m.isClosed() {
+		return nil, fmt.Errorf("#rmrmemory.Messenger.SendMsg - endpoint %s is closed", m.name)
+	}
+
+	if m.maxMsgSize > 0 && msg.Payload != nil && len(*msg.Payload) > m.maxMsgSize {
+		return nil, fmt.Errorf("#rmrmemory.Messenger.SendMsg - Failed to send message. state: %v - %s",
+			rmrtypes.RMR_ERR_OVERFLOW, "operation would have busted through a buffer/field size")
+	}
+
+	if printLogs && m.logger != nil {
+		m.logger.Infof("[%s -> %s] #rmrmemory.Messenger.SendMsg - Going to send message %v", m.name, destination.name, *msg)
+	}
+
+	atomic.AddInt64(&m.sent, 1)
+	delivered := m.copyOf(msg)
+
+	m.faultsMu.RLock()
+	faults := m.faults
+	m.faultsMu.RUnlock()
+
+	if !faults.applyTo(msg.MType) {
+		faults = Faults{}
+	}
+
+	if faults.DropRate > 0 && m.bus.float64() < faults.DropRate {
+		// Like a message lost in transit, the send itself succeeds
+		atomic.AddInt64(&m.dropped, 1)
+		if m.logger != nil {
+			m.logger.Debugf("#rmrmemory.Messenger.SendMsg - %s dropped message type %d to %s", m.name, msg.MType, destination.name)
+		}
+		return m.copyOf(msg), nil
+	}
+
+	delay := faults.Latency
+	if faults.Jitter > 0 {
+		delay += time.Duration(m.bus.int63n(int64(faults.Jitter) + 1))
+	}
+
+	if delay > 0 {
+		atomic.AddInt64(&destination.delaying, 1)
+		destination.delayed <- delayedMessage{mbuf: delivered, deliverAt: time.Now().Add(delay)}
+		return m.copyOf(msg), nil
+	}
+
+	if !destination.enqueue(delivered) {
+		return nil, fmt.Errorf("#rmrmemory.Messenger.SendMsg - Failed to send message. state: %v - %s, receive queue of %s is full",
+			rmrtypes.RMR_ERR_RETRY, "request (send/call/rts) failed, but caller should retry (EAGAIN for wrappers)", destination.name)
+	}
+
+	return m.copyOf(msg), nil
+}
+
+// copyOf returns a deep copy of msg with the messenger as its source
+func (m *Messenger) copyOf(msg *rmrtypes.MBuf) *rmrtypes.MBuf {
+	var payload, xAction []byte
+
+	if msg.Payload != nil {
+		payload = append([]byte{}, *msg.Payload...)
+	}
+	if msg.XAction != nil {
+		xAction = append([]byte{}, *msg.XAction...)
+	}
+
+	return rmrtypes.NewMBuf(msg.MType, len(payload), msg.Meid, &payload, &xAction, m.source)
+}
+
+func (m *Messenger) enqueue(mbuf *rmrtypes.MBuf) bool {
+	select {
+	case m.inbox <- mbuf:
+		return true
+	default:
+		atomic.AddInt64(&m.overflows, 1)
+		return false
+	}
+}
+
+// deliverDelayed delivers delayed messages in the order they were sent
+func (m *Messenger) deliverDelayed() {
+	for {
+		select {
+		case delayed := <-m.delayed:
+			if wait := time.Until(delayed.deliverAt); wait > 0 {
+				time.Sleep(wait)
+			}
+			m.enqueue(delayed.mbuf)
+			atomic.AddInt64(&m.delaying, -1)
+		case <-m.closed:
+			return
+		}
+	}
+}
+
+// RecvMsg blocks until a message arrives. Once the messenger is closed it blocks forever, so that a receive loop
+// such as RmrReceiver.ListenAndHandle does not spin on errors
+func (m *Messenger) RecvMsg() (*rmrtypes.MBuf, error) {
+	select {
+	case mbuf := <-m.inbox:
+		return m.countReceived(mbuf), nil
+	case <-m.closed:
+		select {}
+	}
+}
+
+// Receive waits up to timeout for a message, for tests and simulators
+func (m *Messenger) Receive(timeout time.Duration) (*rmrtypes.MBuf, error) {
+	select {
+	case mbuf := <-m.inbox:
+		return m.countReceived(mbuf), nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("#rmrmemory.Messenger.Receive - Failed to receive message. state: %v - %s",
+			rmrtypes.RMR_ERR_TIMEOUT, "message processing call timed out")
+	case <-m.closed:
+		return nil, fmt.Errorf("#rmrmemory.Messenger.Receive - endpoint %s is closed", m.name)
+	}
+}
+
+func (m *Messenger) countReceived(mbuf *rmrtypes.MBuf) *rmrtypes.MBuf {
+	atomic.AddInt64(&m.received, 1)
+	return mbuf
+}
+
+// Pending returns the number of messages sent to the messenger and not received yet, including delayed ones
+func (m *Messenger) Pending() int {
+	return len(m.inbox) + int(atomic.LoadInt64(&m.delaying))
+}
+
+func (m *Messenger) IsReady() bool {
+	return !m.isClosed()
+}
+
+func (m *Messenger) Close() {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+	})
+}
+
+func (m *Messenger) isClosed() bool {
+	select {
+	case <-m.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *Messenger) Stats() Stats {
+	return Stats{
+		Sent:      atomic.LoadInt64(&m.sent),
+		Dropped:   atomic.LoadInt64(&m.dropped),
+		Received:  atomic.LoadInt64(&m.received),
+		Overflows: atomic.LoadInt64(&m.overflows),
+	}
+}
@@ -0,0 +1,259 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package rmrmemory
+
+import (
+	"e2mgr/clients"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/managers"
+	"e2mgr/managers/notificationmanager"
+	"e2mgr/mocks"
+	"e2mgr/providers/rmrmsghandlerprovider"
+	"e2mgr/rmrtypes"
+	"e2mgr/services"
+	"e2mgr/services/rmrreceiver"
+	"e2mgr/services/rmrsender"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func initLog(t *testing.T) *logger.Logger {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#initLog test - failed to initialize logger, error: %s", err)
+	}
+	return log
+}
+
+func initPair(t *testing.T) (*Bus, *Messenger, *Messenger) {
+	log := initLog(t)
+	bus := NewBus()
+	e2mgr, err := NewMessenger(bus, "e2mgr", 65536, log)
+	assert.Nil(t, err)
+	e2term, err := NewMessenger(bus, "e2term", 65536, log)
+	assert.Nil(t, err)
+	bus.Connect(e2mgr, e2term)
+	return bus, e2mgr, e2term
+}
+
+func newMBuf(mType int, meid string, payload string) *rmrtypes.MBuf {
+	payloadBytes := []byte(payload)
+	xAction := []byte("xaction")
+	var msgSrc unsafe.Pointer
+	return rmrtypes.NewMBuf(mType, len(payloadBytes), meid, &payloadBytes, &xAction, msgSrc)
+}
+
+func TestSendMsgDeliversCopyToPeer(t *testing.T) {
+	_, e2mgr, e2term := initPair(t)
+	msg := newMBuf(rmrtypes.RIC_X2_SETUP_REQ, "ran1", "setup")
+
+	_, err := e2mgr.SendMsg(msg, true)
+	assert.Nil(t, err)
+	(*msg.Payload)[0] = 'X'
+
+	received, err := e2term.Receive(time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, rmrtypes.RIC_X2_SETUP_REQ, received.MType)
+	assert.Equal(t, "ran1", received.Meid)
+	assert.Equal(t, "setup", string(*received.Payload))
+	assert.Equal(t, "xaction", string(*received.XAction))
+	assert.Equal(t, Stats{Sent: 1}, e2mgr.Stats())
+	assert.Equal(t, Stats{Received: 1}, e2term.Stats())
+}
+
+func TestWhSendMsgRepliesToSource(t *testing.T) {
+	bus, e2mgr, e2term := initPair(t)
+	otherE2term, _ := NewMessenger(bus, "e2term2", 0, initLog(t))
+	bus.Route(rmrtypes.RIC_E2_SETUP_RESP, "e2term")
+
+	bus.Connect(otherE2term, e2mgr)
+	_, _ = otherE2term.SendMsg(newMBuf(rmrtypes.RIC_E2_SETUP_REQ, "gnb1", "setup"), false)
+	request, err := e2mgr.RecvMsg()
+	assert.Nil(t, err)
+
+	response := rmrtypes.NewMBuf(rmrtypes.RIC_E2_SETUP_RESP, 2, "gnb1", &[]byte{'o', 'k'}, &[]byte{}, request.GetMsgSrc())
+	_, err = e2mgr.WhSendMsg(response, true)
+	assert.Nil(t, err)
+
+	reply, err := otherE2term.Receive(time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, rmrtypes.RIC_E2_SETUP_RESP, reply.MType)
+	_, err = e2term.Receive(10 * time.Millisecond)
+	assert.NotNil(t, err, "the wormhole bypasses the message type route")
+}
+
+func TestWhSendMsgUnknownSource(t *testing.T) {
+	_, e2mgr, _ := initPair(t)
+	_, err := e2mgr.WhSendMsg(newMBuf(rmrtypes.RIC_E2_SETUP_RESP, "gnb1", ""), false)
+	assert.NotNil(t, err)
+}
+
+func TestRouting(t *testing.T) {
+	log := initLog(t)
+	bus := NewBus()
+	e2mgr, _ := NewMessenger(bus, "e2mgr", 0, log)
+	e2term1, _ := NewMessenger(bus, "e2term1", 0, log)
+	e2term2, _ := NewMessenger(bus, "e2term2", 0, log)
+	bus.Route(rmrtypes.RIC_X2_SETUP_REQ, "e2term1")
+	bus.RouteMeid(rmrtypes.RIC_X2_SETUP_REQ, "ran2", "e2term2")
+
+	_, err := e2mgr.SendMsg(newMBuf(rmrtypes.RIC_X2_SETUP_REQ, "ran1", ""), false)
+	assert.Nil(t, err)
+	_, err = e2mgr.SendMsg(newMBuf(rmrtypes.RIC_X2_SETUP_REQ, "ran2", ""), false)
+	assert.Nil(t, err)
+
+	received, err := e2term1.Receive(time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "ran1", received.Meid)
+	received, err = e2term2.Receive(time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "ran2", received.Meid)
+
+	_, err = e2mgr.SendMsg(newMBuf(rmrtypes.RIC_X2_RESET, "ran1", ""), false)
+	assert.Contains(t, err.Error(), "could not find an endpoint")
+}
+
+func TestNewMessengerDuplicateName(t *testing.T) {
+	bus, _, _ := initPair(t)
+	_, err := NewMessenger(bus, "e2mgr", 0, initLog(t))
+	assert.NotNil(t, err)
+}
+
+func TestDropRate(t *testing.T) {
+	bus, e2mgr, e2term := initPair(t)
+	bus.Seed(1)
+	e2mgr.SetFaults(Faults{DropRate: 1, MessageTypes: []int{rmrtypes.E2_TERM_KEEP_ALIVE_REQ}})
+
+	_, err := e2mgr.SendMsg(newMBuf(rmrtypes.E2_TERM_KEEP_ALIVE_REQ, "", ""), false)
+	assert.Nil(t, err)
+	_, err = e2mgr.SendMsg(newMBuf(rmrtypes.RIC_X2_SETUP_REQ, "ran1", ""), false)
+	assert.Nil(t, err)
+
+	received, err := e2term.Receive(time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, rmrtypes.RIC_X2_SETUP_REQ, received.MType)
+	assert.Equal(t, Stats{Sent: 2, Dropped: 1}, e2mgr.Stats())
+
+	e2mgr.SetFaults(Faults{DropRate: 0.5})
+	for i := 0; i < 1000; i++ {
+		_, _ = e2mgr.SendMsg(newMBuf(rmrtypes.RIC_X2_SETUP_REQ, "ran1", ""), false)
+	}
+	dropped := e2mgr.Stats().Dropped - 1
+	assert.True(t, dropped > 400 && dropped < 600, "dropped %d of 1000", dropped)
+}
+
+func TestLatencyPreservesOrder(t *testing.T) {
+	_, e2mgr, e2term := initPair(t)
+	e2mgr.SetFaults(Faults{Latency: 50 * time.Millisecond, Jitter: 10 * time.Millisecond})
+
+	start := time.Now()
+	for _, meid := range []string{"ran1", "ran2", "ran3"} {
+		_, err := e2mgr.SendMsg(newMBuf(rmrtypes.RIC_X2_SETUP_REQ, meid, ""), false)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 3, e2term.Pending())
+
+	for _, meid := range []string{"ran1", "ran2", "ran3"} {
+		received, err := e2term.Receive(time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, meid, received.Meid)
+	}
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.Equal(t, 0, e2term.Pending())
+}
+
+func TestMaxMsgSize(t *testing.T) {
+	_, e2mgr, _ := initPair(t)
+	e2mgr.Init("tcp:3801", 4, 0, initLog(t))
+
+	_, err := e2mgr.SendMsg(newMBuf(rmrtypes.RIC_X2_SETUP_REQ, "ran1", "too long"), false)
+	assert.Contains(t, err.Error(), "busted through a buffer")
+}
+
+func TestClose(t *testing.T) {
+	_, e2mgr, e2term := initPair(t)
+	assert.True(t, e2term.IsReady())
+
+	e2term.Close()
+	e2term.Close()
+	assert.False(t, e2term.IsReady())
+	_, err := e2term.SendMsg(newMBuf(rmrtypes.RIC_X2_SETUP_REQ, "ran1", ""), false)
+	assert.NotNil(t, err)
+	_, err = e2term.Receive(time.Second)
+	assert.Contains(t, err.Error(), "closed")
+	assert.True(t, e2mgr.IsReady())
+}
+
+// TestE2ManagerKeepAlive wires the RMR side of E2Manager as main.go does and drives the keep alive exchange
+// from an in-memory E2 Termination
+func TestE2ManagerKeepAlive(t *testing.T) {
+	log := initLog(t)
+	bus := NewBus()
+	e2term, _ := NewMessenger(bus, "e2term", 0, log)
+	var messenger *Messenger
+	messenger, _ = NewMessenger(bus, "e2mgr", 0, log)
+	rmrMessenger := messenger.Init("tcp:3801", 65536, 0, log)
+	bus.Connect(messenger, e2term)
+
+	config := &configuration.Configuration{MaxRnibConnectionAttempts: 3, KeepAliveDelayMs: 100, KeepAliveResponseTimeoutMs: 300}
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	rmrSender := rmrsender.NewRmrSender(log, rmrMessenger)
+	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, log)
+	routingManagerClient := clients.NewRoutingManagerClient(log, config, &mocks.HttpClientMock{})
+	e2tAssociationManager := managers.NewE2TAssociationManager(log, rnibDataService, e2tInstancesManager, routingManagerClient)
+	ranSetupManager := managers.NewRanSetupManager(log, rmrSender, rnibDataService)
+	e2tKeepAliveWorker := managers.NewE2TKeepAliveWorker(log, rmrSender, e2tInstancesManager, &mocks.E2TShutdownManagerMock{}, config)
+	provider := rmrmsghandlerprovider.NewNotificationHandlerProvider()
+	provider.Init(log, config, rnibDataService, rmrSender, ranSetupManager, e2tInstancesManager, routingManagerClient, e2tAssociationManager)
+	notificationManager := notificationmanager.NewNotificationManager(log, provider)
+	go rmrreceiver.NewRmrReceiver(log, rmrMessenger, notificationManager).ListenAndHandle()
+
+	e2tInstance := entities.NewE2TInstance("10.0.2.15:38000", "e2term-pod")
+	readerMock.On("GetE2TInstance", "10.0.2.15:38000").Return(e2tInstance, nil)
+	saved := make(chan *entities.E2TInstance, 1)
+	writerMock.On("SaveE2TInstance", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		saved <- args.Get(0).(*entities.E2TInstance)
+	})
+
+	e2tKeepAliveWorker.SendKeepAliveRequest()
+	request, err := e2term.Receive(time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, rmrtypes.E2_TERM_KEEP_ALIVE_REQ, request.MType)
+
+	payload := []byte(`{"address":"10.0.2.15:38000"}`)
+	_, err = e2term.SendMsg(rmrtypes.NewMBuf(rmrtypes.E2_TERM_KEEP_ALIVE_RESP, len(payload), "", &payload, &[]byte{}, nil), false)
+	assert.Nil(t, err)
+
+	select {
+	case instance := <-saved:
+		assert.Equal(t, "10.0.2.15:38000", instance.Address)
+		assert.NotZero(t, instance.KeepAliveTimestamp)
+	case <-time.After(time.Second):
+		t.Fatal("the keep alive response was not handled")
+	}
+}
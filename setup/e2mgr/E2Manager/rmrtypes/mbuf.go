@@ -0,0 +1,87 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+// Package rmrtypes holds the RMR message buffer, message types, states and messenger interface. It does not use cgo,
+// so packages that only exchange messages, such as rmrmemory, build without the RMR library. The values match the RMR
+// headers, rmrCgo verifies them against the definitions of the library it links
+package rmrtypes
+
+import (
+	"e2mgr/logger"
+	"fmt"
+	"unsafe"
+)
+
+// RMR_MAX_SRC_LEN is the size of a message source, RMR_MAX_SRC in rmr.h
+const RMR_MAX_SRC_LEN = 64
+
+type MBuf struct {
+	MType   int
+	Len     int
+	Meid    string //Managed entity id (RAN name)
+	Payload *[]byte
+	XAction *[]byte
+	msgSrc  unsafe.Pointer
+}
+
+func NewMBuf(mType int, len int, meid string, payload *[]byte, xAction *[]byte, msgSrc unsafe.Pointer) *MBuf {
+	return &MBuf{
+		mType,
+		len,
+		meid,
+		payload,
+		xAction,
+		msgSrc,
+	}
+}
+
+func (m MBuf) String() string {
+	return fmt.Sprintf("{ MType: %d, Len: %d, Meid: %q, Xaction: %q, Payload: [%x] }", m.MType, m.Len, m.Meid, m.XAction, m.Payload)
+}
+
+func (m MBuf) GetMsgSrc() unsafe.Pointer {
+	return m.msgSrc
+}
+
+// GetMsgSrcString returns the message source as text, the host:port of the sender for a message received through RMR
+func (m MBuf) GetMsgSrcString() string {
+	if m.msgSrc == nil {
+		return ""
+	}
+
+	src := make([]byte, 0, RMR_MAX_SRC_LEN)
+	for i := 0; i < RMR_MAX_SRC_LEN; i++ {
+		b := *(*byte)(unsafe.Pointer(uintptr(m.msgSrc) + uintptr(i)))
+		if b == 0 {
+			break
+		}
+		src = append(src, b)
+	}
+	return string(src)
+}
+
+type RmrMessenger interface {
+	Init(port string, maxMsgSize int, flags int, logger *logger.Logger) RmrMessenger
+	SendMsg(msg *MBuf, printLogs bool) (*MBuf, error)
+	WhSendMsg(msg *MBuf, printLogs bool) (*MBuf, error)
+	RecvMsg() (*MBuf, error)
+	IsReady() bool
+	Close()
+}
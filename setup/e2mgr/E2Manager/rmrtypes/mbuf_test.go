@@ -0,0 +1,49 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package rmrtypes_test
+
+import (
+	"e2mgr/rmrtypes"
+	"e2mgr/tests"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"unsafe"
+)
+
+func TestNewMBufSuccess(t *testing.T) {
+	var msgSrc unsafe.Pointer
+	msg := rmrtypes.NewMBuf(tests.MessageType, len(tests.DummyPayload), "RanName", &tests.DummyPayload, &tests.DummyXAction, msgSrc)
+	assert.NotNil(t, msg)
+	assert.NotEmpty(t, msg.Payload)
+	assert.NotEmpty(t, msg.XAction)
+	assert.Equal(t, msg.MType, tests.MessageType)
+	assert.Equal(t, msg.Meid, "RanName")
+	assert.Equal(t, msg.Len, len(tests.DummyPayload))
+}
+
+func TestGetMsgSrcString(t *testing.T) {
+	src := append([]byte("10.0.2.15:38000"), 0)
+	msg := rmrtypes.NewMBuf(tests.MessageType, len(tests.DummyPayload), "RanName", &tests.DummyPayload, &tests.DummyXAction, unsafe.Pointer(&src[0]))
+	assert.Equal(t, "10.0.2.15:38000", msg.GetMsgSrcString())
+
+	msg = rmrtypes.NewMBuf(tests.MessageType, len(tests.DummyPayload), "RanName", &tests.DummyPayload, &tests.DummyXAction, nil)
+	assert.Equal(t, "", msg.GetMsgSrcString())
+}
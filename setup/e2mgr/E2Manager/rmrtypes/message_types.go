@@ -0,0 +1,73 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package rmrtypes
+
+// Message types of RIC_message_types.h
+//TODO: consider declaring using its own type
+const (
+	// messages
+	RIC_X2_SETUP_REQ                     = 10060
+	RIC_X2_SETUP_RESP                    = 10061
+	RIC_X2_SETUP_FAILURE                 = 10062
+	RIC_ENDC_X2_SETUP_REQ                = 10360
+	RIC_ENDC_X2_SETUP_RESP               = 10361
+	RIC_ENDC_X2_SETUP_FAILURE            = 10362
+	RIC_SCTP_CONNECTION_FAILURE          = 1080
+	RIC_ENB_LOAD_INFORMATION             = 10020
+	RIC_ENB_CONF_UPDATE                  = 10080
+	RIC_ENB_CONFIGURATION_UPDATE_ACK     = 10081
+	RIC_ENB_CONFIGURATION_UPDATE_FAILURE = 10082
+	RIC_ENDC_CONF_UPDATE                 = 10370
+	RIC_ENDC_CONF_UPDATE_ACK             = 10371
+	RIC_ENDC_CONF_UPDATE_FAILURE         = 10372
+	RIC_SCTP_CLEAR_ALL                   = 1090
+	RIC_X2_RESET_RESP                    = 10071
+	RIC_X2_RESET                         = 10070
+	RIC_E2_TERM_INIT                     = 1100
+	RAN_CONNECTED                        = 1200
+	RAN_RESTARTED                        = 1210
+	RAN_RECONFIGURED                     = 1220
+	E2_TERM_KEEP_ALIVE_REQ               = 1101
+	E2_TERM_KEEP_ALIVE_RESP              = 1102
+	RIC_E2_SETUP_REQ                     = 12001
+	RIC_E2_SETUP_RESP                    = 12002
+	RIC_E2_SETUP_FAILURE                 = 12003
+)
+
+// States of rmr.h
+const (
+	RMR_OK             = 0
+	RMR_ERR_BADARG     = 1
+	RMR_ERR_NOENDPT    = 2
+	RMR_ERR_EMPTY      = 3
+	RMR_ERR_NOHDR      = 4
+	RMR_ERR_SENDFAILED = 5
+	RMR_ERR_CALLFAILED = 6
+	RMR_ERR_NOWHOPEN   = 7
+	RMR_ERR_WHID       = 8
+	RMR_ERR_OVERFLOW   = 9
+	RMR_ERR_RETRY      = 10
+	RMR_ERR_RCVFAILED  = 11
+	RMR_ERR_TIMEOUT    = 12
+	RMR_ERR_UNSET      = 13
+	RMR_ERR_TRUNC      = 14
+	RMR_ERR_INITFAILED = 15
+)
@@ -0,0 +1,252 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rnibfaults
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/models"
+	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+var _ services.RNibDataService = (*DataService)(nil)
+
+// DataService injects the faults of the rnib rules into a data service. Faults fail the operation as a whole, a
+// connection error is not retried. Operations are named after the methods of services.RNibDataService and keyed by
+// the RAN name or E2T address they apply to
+type DataService struct {
+	injector    *Injector
+	dataService services.RNibDataService
+}
+
+func NewDataService(injector *Injector, dataService services.RNibDataService) *DataService {
+	return &DataService{
+		injector:    injector,
+		dataService: dataService,
+	}
+}
+
+func (d *DataService) inject(operation string, keys ...string) error {
+	if len(keys) == 0 {
+		keys = []string{""}
+	}
+
+	for _, key := range keys {
+		switch d.injector.inject(configuration.FaultLayerRnib, operation, key) {
+		case configuration.FaultConnectionError:
+			return common.NewInternalError(connectionError(operation))
+		case configuration.FaultNotFound:
+			return common.NewResourceNotFoundErrorf("#DataService.%s - entity not found (fault injected). Key: %s", operation, key)
+		}
+	}
+
+	return nil
+}
+
+func (d *DataService) SaveNodeb(ctx context.Context, nbIdentity *entities.NbIdentity, nb *entities.NodebInfo) error {
+	if err := d.inject("SaveNodeb", nb.GetRanName()); err != nil {
+		return err
+	}
+	return d.dataService.SaveNodeb(ctx, nbIdentity, nb)
+}
+
+func (d *DataService) UpdateNodebInfo(ctx context.Context, nodebInfo *entities.NodebInfo) error {
+	if err := d.inject("UpdateNodebInfo", nodebInfo.GetRanName()); err != nil {
+		return err
+	}
+	return d.dataService.UpdateNodebInfo(ctx, nodebInfo)
+}
+
+func (d *DataService) SaveRanLoadInformation(ctx context.Context, inventoryName string, ranLoadInformation *entities.RanLoadInformation) error {
+	if err := d.inject("SaveRanLoadInformation", inventoryName); err != nil {
+		return err
+	}
+	return d.dataService.SaveRanLoadInformation(ctx, inventoryName, ranLoadInformation)
+}
+
+func (d *DataService) SaveCellLoadInformation(ctx context.Context, inventoryName string, ranLoadInformation *entities.RanLoadInformation) error {
+	if err := d.inject("SaveCellLoadInformation", inventoryName); err != nil {
+		return err
+	}
+	return d.dataService.SaveCellLoadInformation(ctx, inventoryName, ranLoadInformation)
+}
+
+func (d *DataService) GetCellLoadInformation(ctx context.Context, ranName string) ([]*models.CellLoadInformation, error) {
+	if err := d.inject("GetCellLoadInformation", ranName); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetCellLoadInformation(ctx, ranName)
+}
+
+func (d *DataService) RemoveCellLoadInformation(ctx context.Context, inventoryName string, servedCells []*entities.ServedCellInfo, previousServedCells []*entities.ServedCellInfo) error {
+	if err := d.inject("RemoveCellLoadInformation", inventoryName); err != nil {
+		return err
+	}
+	return d.dataService.RemoveCellLoadInformation(ctx, inventoryName, servedCells, previousServedCells)
+}
+
+func (d *DataService) GetNodeb(ctx context.Context, ranName string) (*entities.NodebInfo, error) {
+	if err := d.inject("GetNodeb", ranName); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetNodeb(ctx, ranName)
+}
+
+func (d *DataService) GetListNodebIds(ctx context.Context) ([]*entities.NbIdentity, error) {
+	if err := d.inject("GetListNodebIds"); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetListNodebIds(ctx)
+}
+
+func (d *DataService) PingRnib() bool {
+	if err := d.inject("PingRnib"); err != nil {
+		return false
+	}
+	return d.dataService.PingRnib()
+}
+
+func (d *DataService) GetE2TInstance(ctx context.Context, address string) (*entities.E2TInstance, error) {
+	if err := d.inject("GetE2TInstance", address); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetE2TInstance(ctx, address)
+}
+
+func (d *DataService) GetE2TInstances(ctx context.Context, addresses []string) ([]*entities.E2TInstance, error) {
+	if err := d.inject("GetE2TInstances", addresses...); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetE2TInstances(ctx, addresses)
+}
+
+func (d *DataService) GetE2TAddresses(ctx context.Context) ([]string, error) {
+	if err := d.inject("GetE2TAddresses"); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetE2TAddresses(ctx)
+}
+
+func (d *DataService) SaveE2TInstance(ctx context.Context, e2tInstance *entities.E2TInstance) error {
+	if err := d.inject("SaveE2TInstance", e2tInstance.Address); err != nil {
+		return err
+	}
+	return d.dataService.SaveE2TInstance(ctx, e2tInstance)
+}
+
+func (d *DataService) SaveE2TAddresses(ctx context.Context, addresses []string) error {
+	if err := d.inject("SaveE2TAddresses"); err != nil {
+		return err
+	}
+	return d.dataService.SaveE2TAddresses(ctx, addresses)
+}
+
+func (d *DataService) GetE2TInstanceNoLogs(address string) (*entities.E2TInstance, error) {
+	if err := d.inject("GetE2TInstanceNoLogs", address); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetE2TInstanceNoLogs(address)
+}
+
+func (d *DataService) GetE2TInstancesNoLogs(addresses []string) ([]*entities.E2TInstance, error) {
+	if err := d.inject("GetE2TInstancesNoLogs", addresses...); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetE2TInstancesNoLogs(addresses)
+}
+
+func (d *DataService) SaveE2TInstanceNoLogs(e2tInstance *entities.E2TInstance) error {
+	if err := d.inject("SaveE2TInstanceNoLogs", e2tInstance.Address); err != nil {
+		return err
+	}
+	return d.dataService.SaveE2TInstanceNoLogs(e2tInstance)
+}
+
+func (d *DataService) GetE2TAddressesNoLogs() ([]string, error) {
+	if err := d.inject("GetE2TAddressesNoLogs"); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetE2TAddressesNoLogs()
+}
+
+func (d *DataService) RemoveE2TInstance(ctx context.Context, e2tAddress string) error {
+	if err := d.inject("RemoveE2TInstance", e2tAddress); err != nil {
+		return err
+	}
+	return d.dataService.RemoveE2TInstance(ctx, e2tAddress)
+}
+
+func (d *DataService) UpdateGnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedNrCells []*entities.ServedNRCell) error {
+	if err := d.inject("UpdateGnbCells", nodebInfo.GetRanName()); err != nil {
+		return err
+	}
+	return d.dataService.UpdateGnbCells(ctx, nodebInfo, servedNrCells)
+}
+
+func (d *DataService) RemoveServedNrCells(ctx context.Context, inventoryName string, servedNrCells []*entities.ServedNRCell) error {
+	if err := d.inject("RemoveServedNrCells", inventoryName); err != nil {
+		return err
+	}
+	return d.dataService.RemoveServedNrCells(ctx, inventoryName, servedNrCells)
+}
+
+func (d *DataService) PatchGnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedNrCellsToSet []*entities.ServedNRCell, servedNrCellsToRemove []*entities.ServedNRCell) error {
+	if err := d.inject("PatchGnbCells", nodebInfo.GetRanName()); err != nil {
+		return err
+	}
+	return d.dataService.PatchGnbCells(ctx, nodebInfo, servedNrCellsToSet, servedNrCellsToRemove)
+}
+
+func (d *DataService) PatchEnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedCellsToSet []*entities.ServedCellInfo, servedCellsToRemove []*entities.ServedCellInfo) error {
+	if err := d.inject("PatchEnbCells", nodebInfo.GetRanName()); err != nil {
+		return err
+	}
+	return d.dataService.PatchEnbCells(ctx, nodebInfo, servedCellsToSet, servedCellsToRemove)
+}
+
+func (d *DataService) UpdateEnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedCells []*entities.ServedCellInfo) error {
+	if err := d.inject("UpdateEnbCells", nodebInfo.GetRanName()); err != nil {
+		return err
+	}
+	return d.dataService.UpdateEnbCells(ctx, nodebInfo, servedCells)
+}
+
+func (d *DataService) RemoveServedCells(ctx context.Context, inventoryName string, servedCells []*entities.ServedCellInfo) error {
+	if err := d.inject("RemoveServedCells", inventoryName); err != nil {
+		return err
+	}
+	return d.dataService.RemoveServedCells(ctx, inventoryName, servedCells)
+}
+
+func (d *DataService) AddRanStatusChange(ctx context.Context, ranName string, ranStatusChange *models.RanStatusChange) error {
+	if err := d.inject("AddRanStatusChange", ranName); err != nil {
+		return err
+	}
+	return d.dataService.AddRanStatusChange(ctx, ranName, ranStatusChange)
+}
+
+func (d *DataService) GetRanStatusHistory(ctx context.Context, ranName string) ([]*models.RanStatusChange, error) {
+	if err := d.inject("GetRanStatusHistory", ranName); err != nil {
+		return nil, err
+	}
+	return d.dataService.GetRanStatusHistory(ctx, ranName)
+}
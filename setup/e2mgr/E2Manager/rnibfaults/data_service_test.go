@@ -0,0 +1,72 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rnibfaults
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func setupDataServiceTest(t *testing.T, rules ...configuration.FaultRule) (*DataService, *Injector, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
+	log, _ := logger.InitLogger(logger.InfoLevel)
+	config := &configuration.Configuration{MaxRnibConnectionAttempts: 3, RnibRetryIntervalMs: 1}
+	injector, _ := setupInjectorTest(t, rules...)
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	dataService := NewDataService(injector, services.NewRnibDataService(log, config, readerMock, writerMock))
+	return dataService, injector, readerMock, writerMock
+}
+
+func TestDataServiceNotFound(t *testing.T) {
+	dataService, _, readerMock, _ := setupDataServiceTest(t, configuration.FaultRule{Layer: "rnib", Operation: "GetNodeb", Key: "ran1", Fault: "notFound"})
+	readerMock.On("GetNodeb", "ran2").Return(&entities.NodebInfo{RanName: "ran2"}, nil)
+
+	_, err := dataService.GetNodeb(context.Background(), "ran1")
+	assert.IsType(t, &common.ResourceNotFoundError{}, err)
+
+	nodebInfo, err := dataService.GetNodeb(context.Background(), "ran2")
+	assert.Nil(t, err)
+	assert.Equal(t, "ran2", nodebInfo.RanName)
+	readerMock.AssertNumberOfCalls(t, "GetNodeb", 1)
+}
+
+// Connection errors injected into the data service are not retried
+func TestDataServiceConnectionError(t *testing.T) {
+	dataService, injector, _, writerMock := setupDataServiceTest(t, configuration.FaultRule{Layer: "rnib", Operation: "UpdateNodebInfo", Fault: "connectionError"})
+
+	err := dataService.UpdateNodebInfo(context.Background(), &entities.NodebInfo{RanName: "ran1"})
+
+	assert.IsType(t, &common.InternalError{}, err)
+	assert.Equal(t, 1, injector.Status().Rules[0].Injected)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfo")
+}
+
+func TestDataServicePingRnib(t *testing.T) {
+	dataService, _, _, _ := setupDataServiceTest(t, configuration.FaultRule{Layer: "rnib", Operation: "PingRnib", Fault: "connectionError"})
+
+	assert.False(t, dataService.PingRnib())
+}
@@ -0,0 +1,175 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+// Package rnibfaults injects latency, connection errors and missing entities into the rNib, to chaos test the E2
+// setup and shutdown flows. Faults are injected by decorators of the SDL instance, below the retries of the data
+// service, and of the data service itself, as directed by the rules of an Injector
+package rnibfaults
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"math/rand"
+	"path"
+	"sync"
+	"time"
+)
+
+// RuleStatus is a rule and the number of faults it injected so far. A rule with a count stops matching once it
+// injected count faults
+type RuleStatus struct {
+	configuration.FaultRule
+	Injected int `json:"injected"`
+}
+
+// Status is the state of an Injector, as set and returned by the debug endpoint
+type Status struct {
+	Enabled bool         `json:"enabled"`
+	Rules   []RuleStatus `json:"rules"`
+}
+
+type Injector struct {
+	logger  *logger.Logger
+	mu      sync.Mutex
+	enabled bool
+	rules   []*RuleStatus
+	random  func() float64
+	sleep   func(time.Duration)
+}
+
+// NewInjector returns an injector with the rules of the faultInjection configuration, injecting only if enabled
+func NewInjector(logger *logger.Logger, config *configuration.Configuration) (*Injector, error) {
+	i := &Injector{
+		logger: logger,
+		random: rand.Float64,
+		sleep:  time.Sleep,
+	}
+
+	if err := i.SetRules(config.FaultInjection.Rules); err != nil {
+		return nil, err
+	}
+
+	i.SetEnabled(config.FaultInjection.Enabled)
+	return i, nil
+}
+
+func (i *Injector) SetEnabled(enabled bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.enabled = enabled
+}
+
+// SetRules replaces the rules and resets their counters, or leaves them untouched if any of the rules is invalid
+func (i *Injector) SetRules(rules []configuration.FaultRule) error {
+	statuses := make([]*RuleStatus, 0, len(rules))
+
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		statuses = append(statuses, &RuleStatus{FaultRule: rule})
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = statuses
+	return nil
+}
+
+// Clear disables the injector and removes its rules
+func (i *Injector) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.enabled = false
+	i.rules = nil
+}
+
+func (i *Injector) Status() Status {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	status := Status{Enabled: i.enabled, Rules: make([]RuleStatus, 0, len(i.rules))}
+	for _, rule := range i.rules {
+		status.Rules = append(status.Rules, *rule)
+	}
+	return status
+}
+
+// inject waits for the latency of the matching latency rules and returns the fault of the first matching error rule,
+// or an empty string
+func (i *Injector) inject(layer string, operation string, key string) string {
+	i.mu.Lock()
+
+	if !i.enabled {
+		i.mu.Unlock()
+		return ""
+	}
+
+	var latency time.Duration
+	fault := ""
+
+	for _, rule := range i.rules {
+		if !rule.matches(layer, operation, key) || (fault != "" && rule.Fault != configuration.FaultLatency) {
+			continue
+		}
+
+		if rule.Probability > 0 && i.random() >= rule.Probability {
+			continue
+		}
+
+		rule.Injected++
+
+		if rule.Fault == configuration.FaultLatency {
+			latency += time.Duration(rule.LatencyMs) * time.Millisecond
+		} else {
+			fault = rule.Fault
+		}
+	}
+
+	i.mu.Unlock()
+
+	if latency > 0 {
+		i.logger.Infof("#Injector.inject - layer: %s, operation: %s, key: %s - injecting latency of %s", layer, operation, key, latency)
+		i.sleep(latency)
+	}
+
+	if fault != "" {
+		i.logger.Infof("#Injector.inject - layer: %s, operation: %s, key: %s - injecting %s", layer, operation, key, fault)
+	}
+
+	return fault
+}
+
+func (r *RuleStatus) matches(layer string, operation string, key string) bool {
+	if r.Layer != layer || (r.Count > 0 && r.Injected >= r.Count) {
+		return false
+	}
+
+	return matchPattern(r.Operation, operation) && matchPattern(r.Key, key)
+}
+
+// An empty pattern matches everything, patterns are validated when the rules are set
+func matchPattern(pattern string, name string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
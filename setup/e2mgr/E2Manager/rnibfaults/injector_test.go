@@ -0,0 +1,143 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rnibfaults
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func setupInjectorTest(t *testing.T, rules ...configuration.FaultRule) (*Injector, *[]time.Duration) {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#... - failed to initialize logger, error: %s", err)
+	}
+
+	config := &configuration.Configuration{}
+	config.FaultInjection.Enabled = true
+	config.FaultInjection.Rules = rules
+
+	injector, err := NewInjector(log, config)
+	assert.Nil(t, err)
+
+	var sleeps []time.Duration
+	injector.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	return injector, &sleeps
+}
+
+func TestInjectMatchesLayerOperationAndKey(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Operation: "Get*", Key: "RAN:ran?", Fault: "connectionError"})
+
+	assert.Equal(t, "connectionError", injector.inject("sdl", "Get", "RAN:ran1"))
+	assert.Equal(t, "connectionError", injector.inject("sdl", "GetMembers", "RAN:ran2"))
+	assert.Equal(t, "", injector.inject("rnib", "Get", "RAN:ran1"))
+	assert.Equal(t, "", injector.inject("sdl", "Set", "RAN:ran1"))
+	assert.Equal(t, "", injector.inject("sdl", "Get", "RAN:ran10"))
+	assert.Equal(t, 2, injector.Status().Rules[0].Injected)
+}
+
+func TestInjectEmptyPatternsMatchEverything(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "rnib", Fault: "notFound"})
+
+	assert.Equal(t, "notFound", injector.inject("rnib", "GetNodeb", "ran1"))
+	assert.Equal(t, "notFound", injector.inject("rnib", "GetE2TAddresses", ""))
+}
+
+func TestInjectCountExpiresRule(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "rnib", Fault: "connectionError", Count: 2})
+
+	assert.Equal(t, "connectionError", injector.inject("rnib", "GetNodeb", "ran1"))
+	assert.Equal(t, "connectionError", injector.inject("rnib", "GetNodeb", "ran1"))
+	assert.Equal(t, "", injector.inject("rnib", "GetNodeb", "ran1"))
+	assert.Equal(t, 2, injector.Status().Rules[0].Injected)
+}
+
+func TestInjectProbability(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "rnib", Fault: "connectionError", Probability: 0.3})
+	random := []float64{0.1, 0.5, 0.29}
+	injector.random = func() float64 {
+		r := random[0]
+		random = random[1:]
+		return r
+	}
+
+	assert.Equal(t, "connectionError", injector.inject("rnib", "GetNodeb", "ran1"))
+	assert.Equal(t, "", injector.inject("rnib", "GetNodeb", "ran1"))
+	assert.Equal(t, "connectionError", injector.inject("rnib", "GetNodeb", "ran1"))
+}
+
+func TestInjectLatencyAddsUpAndFirstErrorWins(t *testing.T) {
+	injector, sleeps := setupInjectorTest(t,
+		configuration.FaultRule{Layer: "sdl", Fault: "latency", LatencyMs: 100},
+		configuration.FaultRule{Layer: "sdl", Operation: "Get", Fault: "notFound"},
+		configuration.FaultRule{Layer: "sdl", Operation: "Get", Fault: "connectionError"},
+		configuration.FaultRule{Layer: "sdl", Operation: "Get", Fault: "latency", LatencyMs: 50},
+	)
+
+	assert.Equal(t, "notFound", injector.inject("sdl", "Get", "RAN:ran1"))
+	assert.Equal(t, []time.Duration{150 * time.Millisecond}, *sleeps)
+
+	status := injector.Status()
+	assert.Equal(t, 1, status.Rules[1].Injected)
+	assert.Equal(t, 0, status.Rules[2].Injected)
+	assert.Equal(t, 1, status.Rules[3].Injected)
+}
+
+func TestInjectDisabled(t *testing.T) {
+	injector, sleeps := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Fault: "latency", LatencyMs: 100})
+	injector.SetEnabled(false)
+
+	assert.Equal(t, "", injector.inject("sdl", "Get", "RAN:ran1"))
+	assert.Empty(t, *sleeps)
+	assert.Equal(t, 0, injector.Status().Rules[0].Injected)
+}
+
+func TestSetRulesInvalidKeepsRules(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Fault: "notFound"})
+
+	err := injector.SetRules([]configuration.FaultRule{{Layer: "sdl", Fault: "timeout"}})
+
+	assert.IsType(t, &configuration.ValidationError{}, err)
+	assert.Len(t, injector.Status().Rules, 1)
+	assert.Equal(t, "notFound", injector.Status().Rules[0].Fault)
+}
+
+func TestNewInjectorInvalidRulesFailure(t *testing.T) {
+	log, _ := logger.InitLogger(logger.InfoLevel)
+	config := &configuration.Configuration{}
+	config.FaultInjection.Rules = []configuration.FaultRule{{Layer: "sdl", Fault: "latency"}}
+
+	injector, err := NewInjector(log, config)
+
+	assert.Nil(t, injector)
+	assert.NotNil(t, err)
+}
+
+func TestClear(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Fault: "notFound"})
+
+	injector.Clear()
+
+	assert.Equal(t, Status{Enabled: false, Rules: []RuleStatus{}}, injector.Status())
+	assert.Equal(t, "", injector.inject("sdl", "Get", "RAN:ran1"))
+}
@@ -0,0 +1,284 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rnibfaults
+
+import (
+	"e2mgr/configuration"
+	"errors"
+	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"net"
+)
+
+var _ common.ISdlInstance = (*Sdl)(nil)
+
+// Sdl injects the faults of the sdl rules into an SDL instance. A connection error is returned as the *net.OpError
+// of a failed connection to Redis, which the data service retries. A notFound fault hides existing keys and group
+// members from reads and is ignored by writes. Operations are named after the methods of common.ISdlInstance and
+// every key of a multi key operation is injected separately
+type Sdl struct {
+	injector *Injector
+	sdl      common.ISdlInstance
+}
+
+func NewSdl(injector *Injector, sdl common.ISdlInstance) *Sdl {
+	return &Sdl{
+		injector: injector,
+		sdl:      sdl,
+	}
+}
+
+func connectionError(operation string) error {
+	return &net.OpError{Op: "dial", Net: "tcp", Err: errors.New(fmt.Sprintf("fault injected into %s", operation))}
+}
+
+// inject returns the connection error of the first key a connectionError fault is injected into, and the keys a
+// notFound fault is injected into
+func (s *Sdl) inject(operation string, keys ...string) (map[string]bool, error) {
+	if len(keys) == 0 {
+		keys = []string{""}
+	}
+
+	notFound := map[string]bool{}
+
+	for _, key := range keys {
+		switch s.injector.inject(configuration.FaultLayerSdl, operation, key) {
+		case configuration.FaultConnectionError:
+			return nil, connectionError(operation)
+		case configuration.FaultNotFound:
+			notFound[key] = true
+		}
+	}
+
+	return notFound, nil
+}
+
+// pairKeys returns the keys of pairs, which may be passed flat or as slices and maps
+func pairKeys(pairs []interface{}) []string {
+	var flat []interface{}
+	var keys []string
+
+	for _, pair := range pairs {
+		switch v := pair.(type) {
+		case []interface{}:
+			keys = append(keys, pairKeys(v)...)
+		case []string:
+			for i := 0; i+1 < len(v); i += 2 {
+				keys = append(keys, v[i])
+			}
+		case map[string]interface{}:
+			for key := range v {
+				keys = append(keys, key)
+			}
+		case map[string]string:
+			for key := range v {
+				keys = append(keys, key)
+			}
+		default:
+			flat = append(flat, v)
+		}
+	}
+
+	for i := 0; i < len(flat); i += 2 {
+		keys = append(keys, fmt.Sprint(flat[i]))
+	}
+
+	return keys
+}
+
+func (s *Sdl) SubscribeChannel(cb func(string, ...string), channels ...string) error {
+	return s.sdl.SubscribeChannel(cb, channels...)
+}
+
+func (s *Sdl) UnsubscribeChannel(channels ...string) error {
+	return s.sdl.UnsubscribeChannel(channels...)
+}
+
+func (s *Sdl) Close() error {
+	return s.sdl.Close()
+}
+
+func (s *Sdl) SetAndPublish(channelsAndEvents []string, pairs ...interface{}) error {
+	if _, err := s.inject("SetAndPublish", pairKeys(pairs)...); err != nil {
+		return err
+	}
+	return s.sdl.SetAndPublish(channelsAndEvents, pairs...)
+}
+
+func (s *Sdl) Set(pairs ...interface{}) error {
+	if _, err := s.inject("Set", pairKeys(pairs)...); err != nil {
+		return err
+	}
+	return s.sdl.Set(pairs...)
+}
+
+func (s *Sdl) Get(keys []string) (map[string]interface{}, error) {
+	notFound, err := s.inject("Get", keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.sdl.Get(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range notFound {
+		if _, ok := data[key]; ok {
+			data[key] = nil
+		}
+	}
+	return data, nil
+}
+
+func (s *Sdl) SetIfAndPublish(channelsAndEvents []string, key string, oldData, newData interface{}) (bool, error) {
+	if _, err := s.inject("SetIfAndPublish", key); err != nil {
+		return false, err
+	}
+	return s.sdl.SetIfAndPublish(channelsAndEvents, key, oldData, newData)
+}
+
+func (s *Sdl) SetIf(key string, oldData, newData interface{}) (bool, error) {
+	if _, err := s.inject("SetIf", key); err != nil {
+		return false, err
+	}
+	return s.sdl.SetIf(key, oldData, newData)
+}
+
+func (s *Sdl) SetIfNotExistsAndPublish(channelsAndEvents []string, key string, data interface{}) (bool, error) {
+	if _, err := s.inject("SetIfNotExistsAndPublish", key); err != nil {
+		return false, err
+	}
+	return s.sdl.SetIfNotExistsAndPublish(channelsAndEvents, key, data)
+}
+
+func (s *Sdl) SetIfNotExists(key string, data interface{}) (bool, error) {
+	if _, err := s.inject("SetIfNotExists", key); err != nil {
+		return false, err
+	}
+	return s.sdl.SetIfNotExists(key, data)
+}
+
+func (s *Sdl) RemoveAndPublish(channelsAndEvents []string, keys []string) error {
+	if _, err := s.inject("RemoveAndPublish", keys...); err != nil {
+		return err
+	}
+	return s.sdl.RemoveAndPublish(channelsAndEvents, keys)
+}
+
+func (s *Sdl) Remove(keys []string) error {
+	if _, err := s.inject("Remove", keys...); err != nil {
+		return err
+	}
+	return s.sdl.Remove(keys)
+}
+
+func (s *Sdl) RemoveIfAndPublish(channelsAndEvents []string, key string, data interface{}) (bool, error) {
+	if _, err := s.inject("RemoveIfAndPublish", key); err != nil {
+		return false, err
+	}
+	return s.sdl.RemoveIfAndPublish(channelsAndEvents, key, data)
+}
+
+func (s *Sdl) RemoveIf(key string, data interface{}) (bool, error) {
+	if _, err := s.inject("RemoveIf", key); err != nil {
+		return false, err
+	}
+	return s.sdl.RemoveIf(key, data)
+}
+
+func (s *Sdl) GetAll() ([]string, error) {
+	notFound, err := s.inject("GetAll")
+	if err != nil {
+		return nil, err
+	}
+	if len(notFound) > 0 {
+		return []string{}, nil
+	}
+	return s.sdl.GetAll()
+}
+
+func (s *Sdl) RemoveAll() error {
+	if _, err := s.inject("RemoveAll"); err != nil {
+		return err
+	}
+	return s.sdl.RemoveAll()
+}
+
+func (s *Sdl) RemoveAllAndPublish(channelsAndEvents []string) error {
+	if _, err := s.inject("RemoveAllAndPublish"); err != nil {
+		return err
+	}
+	return s.sdl.RemoveAllAndPublish(channelsAndEvents)
+}
+
+func (s *Sdl) AddMember(group string, member ...interface{}) error {
+	if _, err := s.inject("AddMember", group); err != nil {
+		return err
+	}
+	return s.sdl.AddMember(group, member...)
+}
+
+func (s *Sdl) RemoveMember(group string, member ...interface{}) error {
+	if _, err := s.inject("RemoveMember", group); err != nil {
+		return err
+	}
+	return s.sdl.RemoveMember(group, member...)
+}
+
+func (s *Sdl) RemoveGroup(group string) error {
+	if _, err := s.inject("RemoveGroup", group); err != nil {
+		return err
+	}
+	return s.sdl.RemoveGroup(group)
+}
+
+func (s *Sdl) GetMembers(group string) ([]string, error) {
+	notFound, err := s.inject("GetMembers", group)
+	if err != nil {
+		return nil, err
+	}
+	if notFound[group] {
+		return []string{}, nil
+	}
+	return s.sdl.GetMembers(group)
+}
+
+func (s *Sdl) IsMember(group string, member interface{}) (bool, error) {
+	notFound, err := s.inject("IsMember", group)
+	if err != nil {
+		return false, err
+	}
+	if notFound[group] {
+		return false, nil
+	}
+	return s.sdl.IsMember(group, member)
+}
+
+func (s *Sdl) GroupSize(group string) (int64, error) {
+	notFound, err := s.inject("GroupSize", group)
+	if err != nil {
+		return 0, err
+	}
+	if notFound[group] {
+		return 0, nil
+	}
+	return s.sdl.GroupSize(group)
+}
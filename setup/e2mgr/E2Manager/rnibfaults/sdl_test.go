@@ -0,0 +1,110 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rnibfaults
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/reader"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+)
+
+const e2tInstanceJson = `{"address":"10.0.2.15:38000","associatedRanList":[],"state":"ACTIVE"}`
+
+func TestSdlConnectionErrorSkipsSdl(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Operation: "Set*", Key: "RAN:*", Fault: "connectionError"})
+	sdlMock := &mocks.MockSdlInstance{}
+	sdl := NewSdl(injector, sdlMock)
+
+	err := sdl.SetAndPublish([]string{"RAN_MANIPULATION", "ran1_UPDATED"}, "RAN:ran1", "data", "GNB:02f829:4a952a0a", "data")
+
+	assert.IsType(t, &net.OpError{}, err)
+	sdlMock.AssertNotCalled(t, "SetAndPublish")
+}
+
+func TestSdlNotFoundHidesKeys(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Operation: "Get", Key: "RAN:ran1", Fault: "notFound"})
+	sdlMock := &mocks.MockSdlInstance{}
+	keys := []string{"RAN:ran1", "RAN:ran2"}
+	sdlMock.On("Get", keys).Return(map[string]interface{}{"RAN:ran1": "data1", "RAN:ran2": "data2"}, nil)
+	sdl := NewSdl(injector, sdlMock)
+
+	data, err := sdl.Get(keys)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"RAN:ran1": nil, "RAN:ran2": "data2"}, data)
+}
+
+func TestSdlNotFoundEmptiesGroup(t *testing.T) {
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Key: "GNB", Fault: "notFound"})
+	sdlMock := &mocks.MockSdlInstance{}
+	sdl := NewSdl(injector, sdlMock)
+
+	members, err := sdl.GetMembers("GNB")
+
+	assert.Nil(t, err)
+	assert.Empty(t, members)
+	sdlMock.AssertNotCalled(t, "GetMembers")
+}
+
+func TestPairKeys(t *testing.T) {
+	assert.Equal(t, []string{"a", "c"}, pairKeys([]interface{}{"a", "b", "c", "d"}))
+	assert.Equal(t, []string{"a", "c"}, pairKeys([]interface{}{[]interface{}{"a", []byte("b")}, []string{"c", "d"}}))
+	assert.Equal(t, []string{"a"}, pairKeys([]interface{}{map[string]interface{}{"a": "b"}}))
+}
+
+// Connection errors injected into the SDL are retried by the data service, which recovers once the rule expires
+func TestSdlConnectionErrorRetriedByDataService(t *testing.T) {
+	log, _ := logger.InitLogger(logger.InfoLevel)
+	config := &configuration.Configuration{MaxRnibConnectionAttempts: 3, RnibRetryIntervalMs: 1}
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Operation: "Get", Key: "E2TInstance:*", Fault: "connectionError", Count: 2})
+	sdlMock := &mocks.MockSdlInstance{}
+	key := "E2TInstance:10.0.2.15:38000"
+	sdlMock.On("Get", []string{key}).Return(map[string]interface{}{key: e2tInstanceJson}, nil)
+	sdl := NewSdl(injector, sdlMock)
+	dataService := services.NewRnibDataService(log, config, reader.GetRNibReader(sdl), nil)
+
+	e2tInstance, err := dataService.GetE2TInstance(context.Background(), "10.0.2.15:38000")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.2.15:38000", e2tInstance.Address)
+	assert.Equal(t, 2, injector.Status().Rules[0].Injected)
+	sdlMock.AssertNumberOfCalls(t, "Get", 1)
+}
+
+func TestSdlConnectionErrorExhaustsRetries(t *testing.T) {
+	log, _ := logger.InitLogger(logger.InfoLevel)
+	config := &configuration.Configuration{MaxRnibConnectionAttempts: 3, RnibRetryIntervalMs: 1}
+	injector, _ := setupInjectorTest(t, configuration.FaultRule{Layer: "sdl", Operation: "Get", Fault: "connectionError"})
+	sdlMock := &mocks.MockSdlInstance{}
+	dataService := services.NewRnibDataService(log, config, reader.GetRNibReader(NewSdl(injector, sdlMock)), nil)
+
+	_, err := dataService.GetE2TInstance(context.Background(), "10.0.2.15:38000")
+
+	assert.IsType(t, &common.InternalError{}, err)
+	assert.Equal(t, 3, injector.Status().Rules[0].Injected)
+	sdlMock.AssertNotCalled(t, "Get")
+}
@@ -23,16 +23,16 @@ package rmrreceiver
 import (
 	"e2mgr/logger"
 	"e2mgr/managers/notificationmanager"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 )
 
 type RmrReceiver struct {
 	logger    *logger.Logger
 	nManager  *notificationmanager.NotificationManager
-	messenger rmrCgo.RmrMessenger
+	messenger rmrtypes.RmrMessenger
 }
 
-func NewRmrReceiver(logger *logger.Logger, messenger rmrCgo.RmrMessenger, nManager *notificationmanager.NotificationManager) *RmrReceiver {
+func NewRmrReceiver(logger *logger.Logger, messenger rmrtypes.RmrMessenger, nManager *notificationmanager.NotificationManager) *RmrReceiver {
 	return &RmrReceiver{
 		logger:    logger,
 		nManager:  nManager,
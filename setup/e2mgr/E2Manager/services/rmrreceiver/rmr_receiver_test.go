@@ -28,7 +28,7 @@ import (
 	"e2mgr/managers/notificationmanager"
 	"e2mgr/mocks"
 	"e2mgr/providers/rmrmsghandlerprovider"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
@@ -47,13 +47,13 @@ func TestListenAndHandle(t *testing.T) {
 	time.Sleep(time.Microsecond * 10)
 }
 
-func initRmrMessenger(log *logger.Logger) rmrCgo.RmrMessenger {
+func initRmrMessenger(log *logger.Logger) rmrtypes.RmrMessenger {
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrMessengerMock.On("Init", tests.GetPort(), tests.MaxMsgSize, tests.Flags, log).Return(&rmrMessenger)
 
 	// TODO: that's not good since we don't actually test anything. if the error is populated then the loop will just continue and it's sort of a "workaround" for that method to be called
-	var buf *rmrCgo.MBuf
+	var buf *rmrtypes.MBuf
 	e := fmt.Errorf("test error")
 	rmrMessengerMock.On("RecvMsg").Return(buf, e)
 	return rmrMessenger
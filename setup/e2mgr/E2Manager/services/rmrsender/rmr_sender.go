@@ -21,27 +21,31 @@
 package rmrsender
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
+	"e2mgr/tracing"
 )
 
 type RmrSender struct {
 	logger    *logger.Logger
-	messenger rmrCgo.RmrMessenger
+	messenger rmrtypes.RmrMessenger
 }
 
-func NewRmrSender(logger *logger.Logger, messenger rmrCgo.RmrMessenger) *RmrSender {
+func NewRmrSender(logger *logger.Logger, messenger rmrtypes.RmrMessenger) *RmrSender {
 	return &RmrSender{
 		logger:    logger,
 		messenger: messenger,
 	}
 }
 
-func (r *RmrSender) WhSend(rmrMessage *models.RmrMessage) error {
-	msg := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
+func (r *RmrSender) WhSend(ctx context.Context, rmrMessage *models.RmrMessage) error {
+	msg := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 
+	_, span := tracing.StartRmrSendSpan(ctx, rmrMessage.MsgType, rmrMessage.RanName, rmrMessage.XAction)
 	_, err := r.messenger.WhSendMsg(msg, true)
+	tracing.EndSpan(span, err)
 
 	if err != nil {
 		r.logger.Errorf("#RmrSender.WhSend - RAN name: %s , Message type: %d - Failed sending message. Error: %v", rmrMessage.RanName, rmrMessage.MsgType, err)
@@ -52,10 +56,12 @@ func (r *RmrSender) WhSend(rmrMessage *models.RmrMessage) error {
 	return nil
 }
 
-func (r *RmrSender) Send(rmrMessage *models.RmrMessage) error {
-	msg := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
+func (r *RmrSender) Send(ctx context.Context, rmrMessage *models.RmrMessage) error {
+	msg := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 
+	_, span := tracing.StartRmrSendSpan(ctx, rmrMessage.MsgType, rmrMessage.RanName, rmrMessage.XAction)
 	_, err := r.messenger.SendMsg(msg, true)
+	tracing.EndSpan(span, err)
 
 	if err != nil {
 		r.logger.Errorf("#RmrSender.Send - RAN name: %s , Message type: %d - Failed sending message. Error: %v", rmrMessage.RanName, rmrMessage.MsgType, err)
@@ -67,7 +73,7 @@ func (r *RmrSender) Send(rmrMessage *models.RmrMessage) error {
 }
 
 func (r *RmrSender) SendWithoutLogs(rmrMessage *models.RmrMessage) error {
-	msg := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
+	msg := rmrtypes.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 
 	_, err := r.messenger.SendMsg(msg, false)
 
@@ -21,10 +21,11 @@
 package rmrsender
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/mocks"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
+	"e2mgr/rmrtypes"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -42,7 +43,7 @@ func initRmrSenderTest(t *testing.T) (*logger.Logger, *mocks.RmrMessengerMock) {
 //func TestRmrSender_CloseContext(t *testing.T) {
 //	logger, rmrMessengerMock := initRmrSenderTest(t)
 //
-//	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+//	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 //	rmrSender := NewRmrSender(logger, &rmrMessenger)
 //
 //	rmrSender.CloseContext()
@@ -56,12 +57,12 @@ func TestRmrSenderSendSuccess(t *testing.T) {
 	payload := []byte("some payload")
 	var xAction []byte
 	var msgSrc unsafe.Pointer
-	mbuf := rmrCgo.NewMBuf(123, len(payload), ranName, &payload, &xAction, msgSrc)
-	rmrMessengerMock.On("SendMsg", mbuf, true).Return(&rmrCgo.MBuf{}, nil)
+	mbuf := rmrtypes.NewMBuf(123, len(payload), ranName, &payload, &xAction, msgSrc)
+	rmrMessengerMock.On("SendMsg", mbuf, true).Return(&rmrtypes.MBuf{}, nil)
 	rmrMsg := models.NewRmrMessage(123, ranName, payload, xAction, nil)
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrSender := NewRmrSender(logger, rmrMessenger)
-	err := rmrSender.Send(rmrMsg)
+	err := rmrSender.Send(context.Background(), rmrMsg)
 	assert.Nil(t, err)
 	rmrMessengerMock.AssertCalled(t, "SendMsg",mbuf, true)
 
@@ -74,12 +75,12 @@ func TestRmrSenderSendFailure(t *testing.T) {
 	payload := []byte("some payload")
 	var xAction []byte
 	var msgSrc unsafe.Pointer
-	mbuf := rmrCgo.NewMBuf(123, len(payload), ranName, &payload, &xAction, msgSrc)
+	mbuf := rmrtypes.NewMBuf(123, len(payload), ranName, &payload, &xAction, msgSrc)
 	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, fmt.Errorf("rmr send failure"))
 	rmrMsg := models.NewRmrMessage(123, ranName, payload, xAction, nil)
-	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrMessenger := rmrtypes.RmrMessenger(rmrMessengerMock)
 	rmrSender := NewRmrSender(logger, rmrMessenger)
-	err := rmrSender.Send(rmrMsg)
+	err := rmrSender.Send(context.Background(), rmrMsg)
 	rmrMessengerMock.AssertCalled(t, "SendMsg",mbuf, true)
 	assert.NotNil(t, err)
 }
@@ -20,116 +20,227 @@
 package services
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
+	"e2mgr/models"
 	"e2mgr/rNibWriter"
+	"e2mgr/tracing"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/reader"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"net"
+	"sync"
 	"time"
 )
 
 type RNibDataService interface {
-	SaveNodeb(nbIdentity *entities.NbIdentity, nb *entities.NodebInfo) error
-	UpdateNodebInfo(nodebInfo *entities.NodebInfo) error
-	SaveRanLoadInformation(inventoryName string, ranLoadInformation *entities.RanLoadInformation) error
-	GetNodeb(ranName string) (*entities.NodebInfo, error)
-	GetListNodebIds() ([]*entities.NbIdentity, error)
+	SaveNodeb(ctx context.Context, nbIdentity *entities.NbIdentity, nb *entities.NodebInfo) error
+	UpdateNodebInfo(ctx context.Context, nodebInfo *entities.NodebInfo) error
+	SaveRanLoadInformation(ctx context.Context, inventoryName string, ranLoadInformation *entities.RanLoadInformation) error
+	GetNodeb(ctx context.Context, ranName string) (*entities.NodebInfo, error)
+	GetListNodebIds(ctx context.Context) ([]*entities.NbIdentity, error)
 	PingRnib() bool
-	GetE2TInstance(address string) (*entities.E2TInstance, error)
-	GetE2TInstances(addresses []string) ([]*entities.E2TInstance, error)
-	GetE2TAddresses() ([]string, error)
-	SaveE2TInstance(e2tInstance *entities.E2TInstance) error
-	SaveE2TAddresses(addresses []string) error
+	GetE2TInstance(ctx context.Context, address string) (*entities.E2TInstance, error)
+	GetE2TInstances(ctx context.Context, addresses []string) ([]*entities.E2TInstance, error)
+	GetE2TAddresses(ctx context.Context) ([]string, error)
+	SaveE2TInstance(ctx context.Context, e2tInstance *entities.E2TInstance) error
+	SaveE2TAddresses(ctx context.Context, addresses []string) error
 	GetE2TInstanceNoLogs(address string) (*entities.E2TInstance, error)
 	GetE2TInstancesNoLogs(addresses []string) ([]*entities.E2TInstance, error)
 	SaveE2TInstanceNoLogs(e2tInstance *entities.E2TInstance) error
 	GetE2TAddressesNoLogs() ([]string, error)
-	RemoveE2TInstance(e2tAddress string) error
-	UpdateGnbCells(nodebInfo *entities.NodebInfo, servedNrCells []*entities.ServedNRCell) error
-	RemoveServedNrCells(inventoryName string, servedNrCells []*entities.ServedNRCell) error
+	RemoveE2TInstance(ctx context.Context, e2tAddress string) error
+	UpdateGnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedNrCells []*entities.ServedNRCell) error
+	RemoveServedNrCells(ctx context.Context, inventoryName string, servedNrCells []*entities.ServedNRCell) error
+	PatchGnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedNrCellsToSet []*entities.ServedNRCell, servedNrCellsToRemove []*entities.ServedNRCell) error
+	PatchEnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedCellsToSet []*entities.ServedCellInfo, servedCellsToRemove []*entities.ServedCellInfo) error
+	UpdateEnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedCells []*entities.ServedCellInfo) error
+	RemoveServedCells(ctx context.Context, inventoryName string, servedCells []*entities.ServedCellInfo) error
+	AddRanStatusChange(ctx context.Context, ranName string, ranStatusChange *models.RanStatusChange) error
+	GetRanStatusHistory(ctx context.Context, ranName string) ([]*models.RanStatusChange, error)
+	SaveCellLoadInformation(ctx context.Context, inventoryName string, ranLoadInformation *entities.RanLoadInformation) error
+	GetCellLoadInformation(ctx context.Context, ranName string) ([]*models.CellLoadInformation, error)
+	RemoveCellLoadInformation(ctx context.Context, inventoryName string, servedCells []*entities.ServedCellInfo, previousServedCells []*entities.ServedCellInfo) error
 }
 
 type rNibDataService struct {
-	logger        *logger.Logger
-	rnibReader    reader.RNibReader
-	rnibWriter    rNibWriter.RNibWriter
-	maxAttempts   int
-	retryInterval time.Duration
+	logger               *logger.Logger
+	rnibReader           reader.RNibReader
+	rnibWriter           rNibWriter.RNibWriter
+	retryPolicyLock      sync.RWMutex
+	maxAttempts          int
+	retryInterval        time.Duration
+	ranStatusHistorySize int
 }
 
 func NewRnibDataService(logger *logger.Logger, config *configuration.Configuration, rnibReader reader.RNibReader, rnibWriter rNibWriter.RNibWriter) *rNibDataService {
 	return &rNibDataService{
-		logger:        logger,
-		rnibReader:    rnibReader,
-		rnibWriter:    rnibWriter,
-		maxAttempts:   config.MaxRnibConnectionAttempts,
-		retryInterval: time.Duration(config.RnibRetryIntervalMs) * time.Millisecond,
+		logger:               logger,
+		rnibReader:           rnibReader,
+		rnibWriter:           rnibWriter,
+		maxAttempts:          config.MaxRnibConnectionAttempts,
+		retryInterval:        time.Duration(config.RnibRetryIntervalMs) * time.Millisecond,
+		ranStatusHistorySize: config.RanStatusHistorySize,
 	}
 }
 
-func (w *rNibDataService) RemoveServedNrCells(inventoryName string, servedNrCells []*entities.ServedNRCell) error {
-	err := w.retry("RemoveServedNrCells", func() (err error) {
+// SetRetryPolicy changes the retries of rNib connection errors, used when the configuration is reloaded
+func (w *rNibDataService) SetRetryPolicy(maxAttempts int, retryInterval time.Duration) {
+	w.retryPolicyLock.Lock()
+	defer w.retryPolicyLock.Unlock()
+	w.maxAttempts = maxAttempts
+	w.retryInterval = retryInterval
+}
+
+func (w *rNibDataService) retryPolicy() (int, time.Duration) {
+	w.retryPolicyLock.RLock()
+	defer w.retryPolicyLock.RUnlock()
+	return w.maxAttempts, w.retryInterval
+}
+
+func (w *rNibDataService) RemoveServedNrCells(ctx context.Context, inventoryName string, servedNrCells []*entities.ServedNRCell) error {
+	err := w.tracedRetry(ctx, "RemoveServedNrCells", func() (err error) {
 		err = w.rnibWriter.RemoveServedNrCells(inventoryName, servedNrCells)
 		return
-	})
+	}, tracing.RanNameKey.String(inventoryName))
 
 	return err
 }
 
-func (w *rNibDataService) UpdateGnbCells(nodebInfo *entities.NodebInfo, servedNrCells []*entities.ServedNRCell) error {
+func (w *rNibDataService) UpdateGnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedNrCells []*entities.ServedNRCell) error {
 	w.logger.Infof("#RnibDataService.UpdateGnbCells - nodebInfo: %s, servedNrCells: %s", nodebInfo, servedNrCells)
 
-	err := w.retry("UpdateGnbCells", func() (err error) {
+	err := w.tracedRetry(ctx, "UpdateGnbCells", func() (err error) {
 		err = w.rnibWriter.UpdateGnbCells(nodebInfo, servedNrCells)
 		return
-	})
+	}, tracing.RanNameKey.String(nodebInfo.GetRanName()))
+
+	return err
+}
+
+func (w *rNibDataService) PatchGnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedNrCellsToSet []*entities.ServedNRCell, servedNrCellsToRemove []*entities.ServedNRCell) error {
+	w.logger.Infof("#RnibDataService.PatchGnbCells - nodebInfo: %s, servedNrCellsToSet: %s, servedNrCellsToRemove: %s", nodebInfo, servedNrCellsToSet, servedNrCellsToRemove)
+
+	err := w.tracedRetry(ctx, "PatchGnbCells", func() (err error) {
+		err = w.rnibWriter.PatchGnbCells(nodebInfo, servedNrCellsToSet, servedNrCellsToRemove)
+		return
+	}, tracing.RanNameKey.String(nodebInfo.GetRanName()))
+
+	return err
+}
+
+func (w *rNibDataService) PatchEnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedCellsToSet []*entities.ServedCellInfo, servedCellsToRemove []*entities.ServedCellInfo) error {
+	w.logger.Infof("#RnibDataService.PatchEnbCells - nodebInfo: %s, servedCellsToSet: %s, servedCellsToRemove: %s", nodebInfo, servedCellsToSet, servedCellsToRemove)
+
+	err := w.tracedRetry(ctx, "PatchEnbCells", func() (err error) {
+		err = w.rnibWriter.PatchEnbCells(nodebInfo, servedCellsToSet, servedCellsToRemove)
+		return
+	}, tracing.RanNameKey.String(nodebInfo.GetRanName()))
+
+	return err
+}
+
+func (w *rNibDataService) RemoveServedCells(ctx context.Context, inventoryName string, servedCells []*entities.ServedCellInfo) error {
+	err := w.tracedRetry(ctx, "RemoveServedCells", func() (err error) {
+		err = w.rnibWriter.RemoveServedCells(inventoryName, servedCells)
+		return
+	}, tracing.RanNameKey.String(inventoryName))
+
+	return err
+}
+
+func (w *rNibDataService) UpdateEnbCells(ctx context.Context, nodebInfo *entities.NodebInfo, servedCells []*entities.ServedCellInfo) error {
+	w.logger.Infof("#RnibDataService.UpdateEnbCells - nodebInfo: %s, servedCells: %s", nodebInfo, servedCells)
+
+	err := w.tracedRetry(ctx, "UpdateEnbCells", func() (err error) {
+		err = w.rnibWriter.UpdateEnbCells(nodebInfo, servedCells)
+		return
+	}, tracing.RanNameKey.String(nodebInfo.GetRanName()))
 
 	return err
 }
 
-func (w *rNibDataService) UpdateNodebInfo(nodebInfo *entities.NodebInfo) error {
+func (w *rNibDataService) UpdateNodebInfo(ctx context.Context, nodebInfo *entities.NodebInfo) error {
 	w.logger.Infof("#RnibDataService.UpdateNodebInfo - nodebInfo: %s", nodebInfo)
 
-	err := w.retry("UpdateNodebInfo", func() (err error) {
+	err := w.tracedRetry(ctx, "UpdateNodebInfo", func() (err error) {
 		err = w.rnibWriter.UpdateNodebInfo(nodebInfo)
 		return
-	})
+	}, tracing.RanNameKey.String(nodebInfo.GetRanName()))
 
 	return err
 }
 
-func (w *rNibDataService) SaveNodeb(nbIdentity *entities.NbIdentity, nb *entities.NodebInfo) error {
+func (w *rNibDataService) SaveNodeb(ctx context.Context, nbIdentity *entities.NbIdentity, nb *entities.NodebInfo) error {
 	w.logger.Infof("#RnibDataService.SaveNodeb - nbIdentity: %s, nodebInfo: %s", nbIdentity, nb)
 
-	err := w.retry("SaveNodeb", func() (err error) {
+	err := w.tracedRetry(ctx, "SaveNodeb", func() (err error) {
 		err = w.rnibWriter.SaveNodeb(nbIdentity, nb)
 		return
-	})
+	}, tracing.RanNameKey.String(nb.GetRanName()))
 
 	return err
 }
 
-func (w *rNibDataService) SaveRanLoadInformation(inventoryName string, ranLoadInformation *entities.RanLoadInformation) error {
+func (w *rNibDataService) SaveRanLoadInformation(ctx context.Context, inventoryName string, ranLoadInformation *entities.RanLoadInformation) error {
 	w.logger.Infof("#RnibDataService.SaveRanLoadInformation - inventoryName: %s, ranLoadInformation: %s", inventoryName, ranLoadInformation)
 
-	err := w.retry("SaveRanLoadInformation", func() (err error) {
+	err := w.tracedRetry(ctx, "SaveRanLoadInformation", func() (err error) {
 		err = w.rnibWriter.SaveRanLoadInformation(inventoryName, ranLoadInformation)
 		return
-	})
+	}, tracing.RanNameKey.String(inventoryName))
+
+	return err
+}
+
+func (w *rNibDataService) SaveCellLoadInformation(ctx context.Context, inventoryName string, ranLoadInformation *entities.RanLoadInformation) error {
+	w.logger.Infof("#RnibDataService.SaveCellLoadInformation - inventoryName: %s, cells count: %d", inventoryName, len(ranLoadInformation.GetCellLoadInfos()))
+
+	err := w.tracedRetry(ctx, "SaveCellLoadInformation", func() (err error) {
+		err = w.rnibWriter.SaveCellLoadInformation(inventoryName, ranLoadInformation)
+		return
+	}, tracing.RanNameKey.String(inventoryName))
 
 	return err
 }
 
-func (w *rNibDataService) GetNodeb(ranName string) (*entities.NodebInfo, error) {
+func (w *rNibDataService) GetCellLoadInformation(ctx context.Context, ranName string) ([]*models.CellLoadInformation, error) {
+	var cells []*models.CellLoadInformation = nil
+
+	err := w.tracedRetry(ctx, "GetCellLoadInformation", func() (err error) {
+		cells, err = w.rnibWriter.GetCellLoadInformation(ranName)
+		return
+	}, tracing.RanNameKey.String(ranName))
+
+	if err == nil {
+		w.logger.Infof("#RnibDataService.GetCellLoadInformation - RAN name: %s, cells count: %d", ranName, len(cells))
+	}
+
+	return cells, err
+}
+
+func (w *rNibDataService) RemoveCellLoadInformation(ctx context.Context, inventoryName string, servedCells []*entities.ServedCellInfo, previousServedCells []*entities.ServedCellInfo) error {
+	w.logger.Infof("#RnibDataService.RemoveCellLoadInformation - inventoryName: %s, servedCells: %s, previousServedCells: %s", inventoryName, servedCells, previousServedCells)
+
+	err := w.tracedRetry(ctx, "RemoveCellLoadInformation", func() (err error) {
+		err = w.rnibWriter.RemoveCellLoadInformation(inventoryName, servedCells, previousServedCells)
+		return
+	}, tracing.RanNameKey.String(inventoryName))
+
+	return err
+}
+
+func (w *rNibDataService) GetNodeb(ctx context.Context, ranName string) (*entities.NodebInfo, error) {
 
 	var nodeb *entities.NodebInfo = nil
 
-	err := w.retry("GetNodeb", func() (err error) {
+	err := w.tracedRetry(ctx, "GetNodeb", func() (err error) {
 		nodeb, err = w.rnibReader.GetNodeb(ranName)
 		return
-	})
+	}, tracing.RanNameKey.String(ranName))
 
 	if err == nil {
 		w.logger.Infof("#RnibDataService.GetNodeb - RAN name: %s, connection status: %s, associated E2T: %s", nodeb.RanName, nodeb.ConnectionStatus, nodeb.AssociatedE2TInstanceAddress)
@@ -138,10 +249,10 @@ func (w *rNibDataService) GetNodeb(ranName string) (*entities.NodebInfo, error)
 	return nodeb, err
 }
 
-func (w *rNibDataService) GetListNodebIds() ([]*entities.NbIdentity, error) {
+func (w *rNibDataService) GetListNodebIds(ctx context.Context) ([]*entities.NbIdentity, error) {
 	var nodeIds []*entities.NbIdentity = nil
 
-	err := w.retry("GetListNodebIds", func() (err error) {
+	err := w.tracedRetry(ctx, "GetListNodebIds", func() (err error) {
 		nodeIds, err = w.rnibReader.GetListNodebIds()
 		return
 	})
@@ -153,13 +264,13 @@ func (w *rNibDataService) GetListNodebIds() ([]*entities.NbIdentity, error) {
 	return nodeIds, err
 }
 
-func (w *rNibDataService) GetE2TInstance(address string) (*entities.E2TInstance, error) {
+func (w *rNibDataService) GetE2TInstance(ctx context.Context, address string) (*entities.E2TInstance, error) {
 	var e2tInstance *entities.E2TInstance = nil
 
-	err := w.retry("GetE2TInstance", func() (err error) {
+	err := w.tracedRetry(ctx, "GetE2TInstance", func() (err error) {
 		e2tInstance, err = w.rnibReader.GetE2TInstance(address)
 		return
-	})
+	}, tracing.E2TAddressKey.String(address))
 
 	if err == nil {
 		w.logger.Infof("#RnibDataService.GetE2TInstance - E2T instance address: %s, state: %s, associated RANs count: %d, keep Alive ts: %d", e2tInstance.Address, e2tInstance.State, len(e2tInstance.AssociatedRanList), e2tInstance.KeepAliveTimestamp)
@@ -179,11 +290,11 @@ func (w *rNibDataService) GetE2TInstanceNoLogs(address string) (*entities.E2TIns
 	return e2tInstance, err
 }
 
-func (w *rNibDataService) GetE2TInstances(addresses []string) ([]*entities.E2TInstance, error) {
+func (w *rNibDataService) GetE2TInstances(ctx context.Context, addresses []string) ([]*entities.E2TInstance, error) {
 	w.logger.Infof("#RnibDataService.GetE2TInstances - addresses: %s", addresses)
 	var e2tInstances []*entities.E2TInstance = nil
 
-	err := w.retry("GetE2TInstance", func() (err error) {
+	err := w.tracedRetry(ctx, "GetE2TInstance", func() (err error) {
 		e2tInstances, err = w.rnibReader.GetE2TInstances(addresses)
 		return
 	})
@@ -203,11 +314,11 @@ func (w *rNibDataService) GetE2TInstancesNoLogs(addresses []string) ([]*entities
 	return e2tInstances, err
 }
 
-func (w *rNibDataService) GetE2TAddresses() ([]string, error) {
+func (w *rNibDataService) GetE2TAddresses(ctx context.Context) ([]string, error) {
 
 	var e2tAddresses []string = nil
 
-	err := w.retry("GetE2TAddresses", func() (err error) {
+	err := w.tracedRetry(ctx, "GetE2TAddresses", func() (err error) {
 		e2tAddresses, err = w.rnibReader.GetE2TAddresses()
 		return
 	})
@@ -231,10 +342,14 @@ func (w *rNibDataService) GetE2TAddressesNoLogs() ([]string, error) {
 	return e2tAddresses, err
 }
 
-func (w *rNibDataService) SaveE2TInstance(e2tInstance *entities.E2TInstance) error {
+func (w *rNibDataService) SaveE2TInstance(ctx context.Context, e2tInstance *entities.E2TInstance) error {
 	w.logger.Infof("#RnibDataService.SaveE2TInstance - E2T instance address: %s, podName: %s, state: %s, associated RANs count: %d, keep Alive ts: %d", e2tInstance.Address, e2tInstance.PodName, e2tInstance.State, len(e2tInstance.AssociatedRanList), e2tInstance.KeepAliveTimestamp)
 
-	return w.SaveE2TInstanceNoLogs(e2tInstance)
+	_, span := tracing.StartSpan(ctx, "RnibDataService.SaveE2TInstance", trace.SpanKindClient,
+		tracing.RnibOperationKey.String("SaveE2TInstance"), tracing.E2TAddressKey.String(e2tInstance.Address))
+	err := w.SaveE2TInstanceNoLogs(e2tInstance)
+	tracing.EndSpan(span, err)
+	return err
 }
 
 func (w *rNibDataService) SaveE2TInstanceNoLogs(e2tInstance *entities.E2TInstance) error {
@@ -247,10 +362,10 @@ func (w *rNibDataService) SaveE2TInstanceNoLogs(e2tInstance *entities.E2TInstanc
 	return err
 }
 
-func (w *rNibDataService) SaveE2TAddresses(addresses []string) error {
+func (w *rNibDataService) SaveE2TAddresses(ctx context.Context, addresses []string) error {
 	w.logger.Infof("#RnibDataService.SaveE2TAddresses - addresses: %s", addresses)
 
-	err := w.retry("SaveE2TAddresses", func() (err error) {
+	err := w.tracedRetry(ctx, "SaveE2TAddresses", func() (err error) {
 		err = w.rnibWriter.SaveE2TAddresses(addresses)
 		return
 	})
@@ -258,17 +373,49 @@ func (w *rNibDataService) SaveE2TAddresses(addresses []string) error {
 	return err
 }
 
-func (w *rNibDataService) RemoveE2TInstance(e2tAddress string) error {
+func (w *rNibDataService) RemoveE2TInstance(ctx context.Context, e2tAddress string) error {
 	w.logger.Infof("#RnibDataService.RemoveE2TInstance - e2tAddress: %s", e2tAddress)
 
-	err := w.retry("RemoveE2TInstance", func() (err error) {
+	err := w.tracedRetry(ctx, "RemoveE2TInstance", func() (err error) {
 		err = w.rnibWriter.RemoveE2TInstance(e2tAddress)
 		return
-	})
+	}, tracing.E2TAddressKey.String(e2tAddress))
 
 	return err
 }
 
+// AddRanStatusChange appends the change to the RAN's status history.
+// Changes that leave the status as is are ignored, as are all changes when the history size is not configured.
+func (w *rNibDataService) AddRanStatusChange(ctx context.Context, ranName string, ranStatusChange *models.RanStatusChange) error {
+	if w.ranStatusHistorySize <= 0 || ranStatusChange.PreviousStatus == ranStatusChange.NewStatus {
+		return nil
+	}
+
+	w.logger.Infof("#RnibDataService.AddRanStatusChange - RAN name: %s, status change: %s -> %s, cause: %s, component: %s", ranName, ranStatusChange.PreviousStatus, ranStatusChange.NewStatus, ranStatusChange.Cause, ranStatusChange.Component)
+
+	err := w.tracedRetry(ctx, "AddRanStatusChange", func() (err error) {
+		err = w.rnibWriter.AddRanStatusChange(ranName, ranStatusChange, w.ranStatusHistorySize)
+		return
+	}, tracing.RanNameKey.String(ranName))
+
+	return err
+}
+
+func (w *rNibDataService) GetRanStatusHistory(ctx context.Context, ranName string) ([]*models.RanStatusChange, error) {
+	var history []*models.RanStatusChange = nil
+
+	err := w.tracedRetry(ctx, "GetRanStatusHistory", func() (err error) {
+		history, err = w.rnibWriter.GetRanStatusHistory(ranName)
+		return
+	}, tracing.RanNameKey.String(ranName))
+
+	if err == nil {
+		w.logger.Infof("#RnibDataService.GetRanStatusHistory - RAN name: %s, history entries count: %d", ranName, len(history))
+	}
+
+	return history, err
+}
+
 func (w *rNibDataService) PingRnib() bool {
 	err := w.retry("GetListNodebIds", func() (err error) {
 		_, err = w.rnibReader.GetListNodebIds()
@@ -278,8 +425,16 @@ func (w *rNibDataService) PingRnib() bool {
 	return !isRnibConnectionError(err)
 }
 
+func (w *rNibDataService) tracedRetry(ctx context.Context, rnibFunc string, f func() error, attributes ...attribute.KeyValue) error {
+	attributes = append(attributes, tracing.RnibOperationKey.String(rnibFunc))
+	_, span := tracing.StartSpan(ctx, "RnibDataService."+rnibFunc, trace.SpanKindClient, attributes...)
+	err := w.retry(rnibFunc, f)
+	tracing.EndSpan(span, err)
+	return err
+}
+
 func (w *rNibDataService) retry(rnibFunc string, f func() error) (err error) {
-	attempts := w.maxAttempts
+	attempts, retryInterval := w.retryPolicy()
 
 	for i := 1; ; i++ {
 		err = f()
@@ -293,7 +448,7 @@ func (w *rNibDataService) retry(rnibFunc string, f func() error) (err error) {
 			w.logger.Errorf("#RnibDataService.retry - after %d attempts of %s, last error: %s", attempts, rnibFunc, err)
 			return err
 		}
-		time.Sleep(w.retryInterval)
+		time.Sleep(retryInterval)
 
 		w.logger.Infof("#RnibDataService.retry - retrying %d %s after error: %s", i, rnibFunc, err)
 	}
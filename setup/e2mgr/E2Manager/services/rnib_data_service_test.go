@@ -21,9 +21,11 @@
 package services
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
+	"e2mgr/models"
 	"fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -31,6 +33,7 @@ import (
 	"net"
 	"strings"
 	"testing"
+	"time"
 )
 
 func setupRnibDataServiceTest(t *testing.T) (*rNibDataService, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
@@ -64,7 +67,7 @@ func TestSuccessfulSaveNodeb(t *testing.T) {
 	nbIdentity := &entities.NbIdentity{}
 	writerMock.On("SaveNodeb", nbIdentity, nodebInfo).Return(nil)
 
-	rnibDataService.SaveNodeb(nbIdentity, nodebInfo)
+	rnibDataService.SaveNodeb(context.Background(), nbIdentity, nodebInfo)
 	writerMock.AssertNumberOfCalls(t, "SaveNodeb", 1)
 }
 
@@ -76,7 +79,7 @@ func TestConnFailureSaveNodeb(t *testing.T) {
 	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
 	writerMock.On("SaveNodeb", nbIdentity, nodebInfo).Return(mockErr)
 
-	rnibDataService.SaveNodeb(nbIdentity, nodebInfo)
+	rnibDataService.SaveNodeb(context.Background(), nbIdentity, nodebInfo)
 	writerMock.AssertNumberOfCalls(t, "SaveNodeb", 3)
 }
 
@@ -88,7 +91,7 @@ func TestNonConnFailureSaveNodeb(t *testing.T) {
 	mockErr := &common.InternalError{Err: fmt.Errorf("non connection failure")}
 	writerMock.On("SaveNodeb", nbIdentity, nodebInfo).Return(mockErr)
 
-	rnibDataService.SaveNodeb(nbIdentity, nodebInfo)
+	rnibDataService.SaveNodeb(context.Background(), nbIdentity, nodebInfo)
 	writerMock.AssertNumberOfCalls(t, "SaveNodeb", 1)
 }
 
@@ -98,7 +101,7 @@ func TestSuccessfulUpdateNodebInfo(t *testing.T) {
 	nodebInfo := &entities.NodebInfo{}
 	writerMock.On("UpdateNodebInfo", nodebInfo).Return(nil)
 
-	rnibDataService.UpdateNodebInfo(nodebInfo)
+	rnibDataService.UpdateNodebInfo(context.Background(), nodebInfo)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 1)
 }
 
@@ -109,7 +112,7 @@ func TestConnFailureUpdateNodebInfo(t *testing.T) {
 	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
 	writerMock.On("UpdateNodebInfo", nodebInfo).Return(mockErr)
 
-	rnibDataService.UpdateNodebInfo(nodebInfo)
+	rnibDataService.UpdateNodebInfo(context.Background(), nodebInfo)
 	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfo", 3)
 }
 
@@ -120,7 +123,7 @@ func TestSuccessfulSaveRanLoadInformation(t *testing.T) {
 	ranLoadInformation := &entities.RanLoadInformation{}
 	writerMock.On("SaveRanLoadInformation", ranName, ranLoadInformation).Return(nil)
 
-	rnibDataService.SaveRanLoadInformation(ranName, ranLoadInformation)
+	rnibDataService.SaveRanLoadInformation(context.Background(), ranName, ranLoadInformation)
 	writerMock.AssertNumberOfCalls(t, "SaveRanLoadInformation", 1)
 }
 
@@ -132,7 +135,7 @@ func TestConnFailureSaveRanLoadInformation(t *testing.T) {
 	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
 	writerMock.On("SaveRanLoadInformation", ranName, ranLoadInformation).Return(mockErr)
 
-	rnibDataService.SaveRanLoadInformation(ranName, ranLoadInformation)
+	rnibDataService.SaveRanLoadInformation(context.Background(), ranName, ranLoadInformation)
 	writerMock.AssertNumberOfCalls(t, "SaveRanLoadInformation", 3)
 }
 
@@ -143,7 +146,7 @@ func TestSuccessfulGetNodeb(t *testing.T) {
 	nodebInfo := &entities.NodebInfo{}
 	readerMock.On("GetNodeb", invName).Return(nodebInfo, nil)
 
-	res, err := rnibDataService.GetNodeb(invName)
+	res, err := rnibDataService.GetNodeb(context.Background(), invName)
 	readerMock.AssertNumberOfCalls(t, "GetNodeb", 1)
 	assert.Equal(t, nodebInfo, res)
 	assert.Nil(t, err)
@@ -157,7 +160,7 @@ func TestConnFailureGetNodeb(t *testing.T) {
 	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
 	readerMock.On("GetNodeb", invName).Return(nodeb, mockErr)
 
-	res, err := rnibDataService.GetNodeb(invName)
+	res, err := rnibDataService.GetNodeb(context.Background(), invName)
 	readerMock.AssertNumberOfCalls(t, "GetNodeb", 3)
 	assert.True(t, strings.Contains(err.Error(), "connection error", ))
 	assert.Equal(t, nodeb, res)
@@ -169,7 +172,7 @@ func TestSuccessfulGetNodebIdList(t *testing.T) {
 	nodeIds := []*entities.NbIdentity{}
 	readerMock.On("GetListNodebIds").Return(nodeIds, nil)
 
-	res, err := rnibDataService.GetListNodebIds()
+	res, err := rnibDataService.GetListNodebIds(context.Background())
 	readerMock.AssertNumberOfCalls(t, "GetListNodebIds", 1)
 	assert.Equal(t, nodeIds, res)
 	assert.Nil(t, err)
@@ -182,7 +185,7 @@ func TestConnFailureGetNodebIdList(t *testing.T) {
 	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
 	readerMock.On("GetListNodebIds").Return(nodeIds, mockErr)
 
-	res, err := rnibDataService.GetListNodebIds()
+	res, err := rnibDataService.GetListNodebIds(context.Background())
 	readerMock.AssertNumberOfCalls(t, "GetListNodebIds", 3)
 	assert.True(t, strings.Contains(err.Error(), "connection error", ))
 	assert.Equal(t, nodeIds, res)
@@ -198,12 +201,12 @@ func TestConnFailureTwiceGetNodebIdList(t *testing.T) {
 	readerMock.On("GetNodeb", invName).Return(nodeb, mockErr)
 	readerMock.On("GetListNodebIds").Return(nodeIds, mockErr)
 
-	res, err := rnibDataService.GetListNodebIds()
+	res, err := rnibDataService.GetListNodebIds(context.Background())
 	readerMock.AssertNumberOfCalls(t, "GetListNodebIds", 3)
 	assert.True(t, strings.Contains(err.Error(), "connection error", ))
 	assert.Equal(t, nodeIds, res)
 
-	res2, err := rnibDataService.GetNodeb(invName)
+	res2, err := rnibDataService.GetNodeb(context.Background(), invName)
 	readerMock.AssertNumberOfCalls(t, "GetNodeb", 3)
 	assert.True(t, strings.Contains(err.Error(), "connection error", ))
 	assert.Equal(t, nodeb, res2)
@@ -216,7 +219,7 @@ func TestConnFailureWithAnotherConfig(t *testing.T) {
 	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
 	readerMock.On("GetListNodebIds").Return(nodeIds, mockErr)
 
-	res, err := rnibDataService.GetListNodebIds()
+	res, err := rnibDataService.GetListNodebIds(context.Background())
 	readerMock.AssertNumberOfCalls(t, "GetListNodebIds", 5)
 	assert.True(t, strings.Contains(err.Error(), "connection error", ))
 	assert.Equal(t, nodeIds, res)
@@ -234,6 +237,19 @@ func TestPingRnibConnFailure(t *testing.T) {
 	assert.False(t, res)
 }
 
+func TestSetRetryPolicy(t *testing.T) {
+	rnibDataService, readerMock, _ := setupRnibDataServiceTest(t)
+	rnibDataService.SetRetryPolicy(5, time.Millisecond)
+
+	var nodeIds []*entities.NbIdentity = nil
+	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
+	readerMock.On("GetListNodebIds").Return(nodeIds, mockErr)
+
+	res := rnibDataService.PingRnib()
+	readerMock.AssertNumberOfCalls(t, "GetListNodebIds", 5)
+	assert.False(t, res)
+}
+
 func TestPingRnibOkNoError(t *testing.T) {
 	rnibDataService, readerMock, _ := setupRnibDataServiceTest(t)
 
@@ -280,7 +296,7 @@ func TestGetE2TInstanceConnFailure(t *testing.T) {
 	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
 	readerMock.On("GetE2TInstance", address).Return(e2tInstance, mockErr)
 
-	res, err := rnibDataService.GetE2TInstance(address)
+	res, err := rnibDataService.GetE2TInstance(context.Background(), address)
 	readerMock.AssertNumberOfCalls(t, "GetE2TInstance", 3)
 	assert.Nil(t, res)
 	assert.NotNil(t, err)
@@ -293,7 +309,7 @@ func TestGetE2TInstanceOkNoError(t *testing.T) {
 	e2tInstance := &entities.E2TInstance{}
 	readerMock.On("GetE2TInstance", address).Return(e2tInstance, nil)
 
-	res, err := rnibDataService.GetE2TInstance(address)
+	res, err := rnibDataService.GetE2TInstance(context.Background(), address)
 	readerMock.AssertNumberOfCalls(t, "GetE2TInstance", 1)
 	assert.Nil(t, err)
 	assert.Equal(t, e2tInstance, res)
@@ -307,8 +323,148 @@ func TestGetE2TInstanceOkOtherError(t *testing.T) {
 	mockErr := &common.InternalError{Err: fmt.Errorf("non connection error")}
 	readerMock.On("GetE2TInstance", address).Return(e2tInstance, mockErr)
 
-	res, err := rnibDataService.GetE2TInstance(address)
+	res, err := rnibDataService.GetE2TInstance(context.Background(), address)
 	readerMock.AssertNumberOfCalls(t, "GetE2TInstance", 1)
 	assert.Nil(t, res)
 	assert.NotNil(t, err)
 }
+
+func TestAddRanStatusChangeSuccess(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+	rnibDataService.ranStatusHistorySize = 10
+
+	change := models.NewRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, "cause", "component")
+	writerMock.On("AddRanStatusChange", "test", change, 10).Return(nil)
+
+	err := rnibDataService.AddRanStatusChange(context.Background(), "test", change)
+	assert.Nil(t, err)
+	writerMock.AssertNumberOfCalls(t, "AddRanStatusChange", 1)
+}
+
+func TestAddRanStatusChangeConnFailure(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+	rnibDataService.ranStatusHistorySize = 10
+
+	change := models.NewRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, "cause", "component")
+	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
+	writerMock.On("AddRanStatusChange", "test", change, 10).Return(mockErr)
+
+	err := rnibDataService.AddRanStatusChange(context.Background(), "test", change)
+	assert.NotNil(t, err)
+	writerMock.AssertNumberOfCalls(t, "AddRanStatusChange", 3)
+}
+
+func TestAddRanStatusChangeUnchangedStatusIgnored(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+	rnibDataService.ranStatusHistorySize = 10
+
+	change := models.NewRanStatusChange(entities.ConnectionStatus_CONNECTING, entities.ConnectionStatus_CONNECTING, "cause", "component")
+
+	err := rnibDataService.AddRanStatusChange(context.Background(), "test", change)
+	assert.Nil(t, err)
+	writerMock.AssertNotCalled(t, "AddRanStatusChange", "test", change, 10)
+}
+
+func TestAddRanStatusChangeHistoryDisabled(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	change := models.NewRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, "cause", "component")
+
+	err := rnibDataService.AddRanStatusChange(context.Background(), "test", change)
+	assert.Nil(t, err)
+	writerMock.AssertNotCalled(t, "AddRanStatusChange", "test", change, 0)
+}
+
+func TestGetRanStatusHistoryOkNoError(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	history := []*models.RanStatusChange{models.NewRanStatusChange(entities.ConnectionStatus_CONNECTED, entities.ConnectionStatus_DISCONNECTED, "cause", "component")}
+	writerMock.On("GetRanStatusHistory", "test").Return(history, nil)
+
+	res, err := rnibDataService.GetRanStatusHistory(context.Background(), "test")
+	writerMock.AssertNumberOfCalls(t, "GetRanStatusHistory", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, history, res)
+}
+
+func TestSuccessfulSaveCellLoadInformation(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	ranLoadInformation := &entities.RanLoadInformation{CellLoadInfos: []*entities.CellLoadInformation{{CellId: "02f829:0007ab50"}}}
+	writerMock.On("SaveCellLoadInformation", "test", ranLoadInformation).Return(nil)
+
+	err := rnibDataService.SaveCellLoadInformation(context.Background(), "test", ranLoadInformation)
+	assert.Nil(t, err)
+	writerMock.AssertNumberOfCalls(t, "SaveCellLoadInformation", 1)
+}
+
+func TestConnFailureSaveCellLoadInformation(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	ranLoadInformation := &entities.RanLoadInformation{}
+	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
+	writerMock.On("SaveCellLoadInformation", "test", ranLoadInformation).Return(mockErr)
+
+	err := rnibDataService.SaveCellLoadInformation(context.Background(), "test", ranLoadInformation)
+	assert.Equal(t, mockErr, err)
+	writerMock.AssertNumberOfCalls(t, "SaveCellLoadInformation", 3)
+}
+
+func TestGetCellLoadInformationOkNoError(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	cells := []*models.CellLoadInformation{models.NewCellLoadInformation(1257894000000000000, &entities.CellLoadInformation{CellId: "02f829:0007ab50"})}
+	writerMock.On("GetCellLoadInformation", "test").Return(cells, nil)
+
+	res, err := rnibDataService.GetCellLoadInformation(context.Background(), "test")
+	writerMock.AssertNumberOfCalls(t, "GetCellLoadInformation", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, cells, res)
+}
+
+func TestSuccessfulRemoveCellLoadInformation(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	previousServedCells := []*entities.ServedCellInfo{{CellId: "02f829:0007ab50"}, {CellId: "02f829:0007ab60"}}
+	writerMock.On("RemoveCellLoadInformation", "test", previousServedCells[:1], previousServedCells).Return(nil)
+
+	err := rnibDataService.RemoveCellLoadInformation(context.Background(), "test", previousServedCells[:1], previousServedCells)
+	assert.Nil(t, err)
+	writerMock.AssertNumberOfCalls(t, "RemoveCellLoadInformation", 1)
+}
+
+func TestSuccessfulUpdateEnbCells(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	servedCells := []*entities.ServedCellInfo{{CellId: "02f829:0007ab50", Pci: 1}}
+	nodebInfo := &entities.NodebInfo{RanName: "test", Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: servedCells}}}
+	writerMock.On("UpdateEnbCells", nodebInfo, servedCells).Return(nil)
+
+	err := rnibDataService.UpdateEnbCells(context.Background(), nodebInfo, servedCells)
+	assert.Nil(t, err)
+	writerMock.AssertNumberOfCalls(t, "UpdateEnbCells", 1)
+}
+
+func TestConnFailureUpdateEnbCells(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	servedCells := []*entities.ServedCellInfo{{CellId: "02f829:0007ab50", Pci: 1}}
+	nodebInfo := &entities.NodebInfo{RanName: "test", Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: servedCells}}}
+	mockErr := &common.InternalError{Err: &net.OpError{Err: fmt.Errorf("connection error")}}
+	writerMock.On("UpdateEnbCells", nodebInfo, servedCells).Return(mockErr)
+
+	err := rnibDataService.UpdateEnbCells(context.Background(), nodebInfo, servedCells)
+	assert.NotNil(t, err)
+	writerMock.AssertNumberOfCalls(t, "UpdateEnbCells", 3)
+}
+
+func TestSuccessfulRemoveServedCells(t *testing.T) {
+	rnibDataService, _, writerMock := setupRnibDataServiceTest(t)
+
+	servedCells := []*entities.ServedCellInfo{{CellId: "02f829:0007ab50", Pci: 1}}
+	writerMock.On("RemoveServedCells", "test", servedCells).Return(nil)
+
+	err := rnibDataService.RemoveServedCells(context.Background(), "test", servedCells)
+	assert.Nil(t, err)
+	writerMock.AssertNumberOfCalls(t, "RemoveServedCells", 1)
+}
@@ -0,0 +1,88 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package integration
+
+import (
+	"context"
+	"e2mgr/rmrcapture"
+	"e2mgr/rmrtypes"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCaptureReplayConnectsRan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := DefaultConfiguration()
+	config.Rmr.Capture.Enabled = true
+	config.Rmr.Capture.File = filepath.Join(dir, "rmr.capture")
+
+	recorded, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initE2T(t, recorded)
+	setupRan(t, recorded)
+	recorded.Close()
+
+	records, err := rmrcapture.ReadFile(config.Rmr.Capture.File)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mTypes []int
+	for _, record := range records {
+		mTypes = append(mTypes, record.MType)
+	}
+	if len(records) != 3 || records[1].MType != rmrtypes.RIC_E2_SETUP_REQ || records[2].MType != rmrtypes.RIC_E2_SETUP_RESP {
+		t.Fatalf("expected RIC_E2_TERM_INIT, RIC_E2_SETUP_REQ and RIC_E2_SETUP_RESP captured, got %v", mTypes)
+	}
+	if records[1].Direction != rmrcapture.Received || records[1].Meid != ranName || records[1].Source != E2TEndpoint {
+		t.Errorf("expected the setup request received from %s for %s, got %+v", E2TEndpoint, ranName, records[1])
+	}
+	if records[2].Direction != rmrcapture.Sent || records[2].Meid != ranName {
+		t.Errorf("expected the setup response sent to %s, got %+v", ranName, records[2])
+	}
+
+	h := start(t)
+	defer h.Close()
+
+	// E2 Manager handles messages concurrently, the setup request has to keep its distance from RIC_E2_TERM_INIT
+	stats, err := rmrcapture.NewReplayer(h.Logger, h.E2T).Replay(context.Background(), records, rmrcapture.Filter{}, 1)
+	if err != nil || stats.Sent != 2 || stats.Skipped != 1 {
+		t.Fatalf("expected 2 messages replayed and 1 skipped, got %+v, error: %v", stats, err)
+	}
+	settle(t, h)
+
+	response, err := h.E2T.Receive(timeout)
+	if err != nil || response.MType != rmrtypes.RIC_E2_SETUP_RESP {
+		t.Fatalf("expected RIC_E2_SETUP_RESP to the replayed setup request, got %v, error: %v", response, err)
+	}
+	nodeb := getNodeb(t, h)
+	if nodeb.ConnectionStatus != entities.ConnectionStatus_CONNECTED || nodeb.AssociatedE2TInstanceAddress != e2tAddress {
+		t.Errorf("expected nodeb connected to %s, got %s to %s", e2tAddress, nodeb.ConnectionStatus, nodeb.AssociatedE2TInstanceAddress)
+	}
+}
@@ -0,0 +1,108 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package integration
+
+import (
+	"bytes"
+	"e2mgr/configuration"
+	"e2mgr/rmrtypes"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"net/http"
+	"testing"
+)
+
+func startWithFaults(t *testing.T, rules ...configuration.FaultRule) *Harness {
+	config := DefaultConfiguration()
+	config.FaultInjection.Enabled = len(rules) > 0
+	config.FaultInjection.DebugEndpoint = true
+	config.FaultInjection.Rules = rules
+	h, err := New(config)
+	if err != nil {
+		t.Fatalf("#faults_test.startWithFaults - failed to start the harness, error: %s", err)
+	}
+	return h
+}
+
+func faultsRequest(t *testing.T, h *Harness, method string, body string) int {
+	req, err := http.NewRequest(method, h.Api.URL+"/v1/debug/faults", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+// Connection errors of the SDL are retried by the data service, fewer of them than the attempts do not fail the setup
+func TestE2SetupRecoversFromSdlConnectionErrors(t *testing.T) {
+	h := startWithFaults(t, configuration.FaultRule{Layer: "sdl", Operation: "Set*", Key: "RAN:*", Fault: "connectionError", Count: 2})
+	defer h.Close()
+
+	initE2T(t, h)
+	response := setupRan(t, h)
+
+	if response.MType != rmrtypes.RIC_E2_SETUP_RESP {
+		t.Errorf("expected RIC_E2_SETUP_RESP, got type %d", response.MType)
+	}
+	if status := getNodeb(t, h).ConnectionStatus; status != entities.ConnectionStatus_CONNECTED {
+		t.Errorf("expected nodeb connected, got %s", status)
+	}
+	if injected := h.Faults.Status().Rules[0].Injected; injected != 2 {
+		t.Errorf("expected 2 injected connection errors, got %d", injected)
+	}
+}
+
+func TestDebugEndpointInjectsMissingNodeb(t *testing.T) {
+	h := startWithFaults(t)
+	defer h.Close()
+
+	initE2T(t, h)
+	setupRan(t, h)
+
+	status := faultsRequest(t, h, http.MethodPut, `{"enabled":true,"rules":[{"layer":"rnib","operation":"GetNodeb","key":"`+ranName+`","fault":"notFound"}]}`)
+	if status != http.StatusOK {
+		t.Fatalf("expected PUT faults to succeed, got %d", status)
+	}
+
+	resp, err := h.Get("/v1/nodeb/" + ranName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected GET nodeb to fail with the injected fault, got %d", resp.StatusCode)
+	}
+
+	if status = faultsRequest(t, h, http.MethodDelete, ""); status != http.StatusNoContent {
+		t.Fatalf("expected DELETE faults to succeed, got %d", status)
+	}
+
+	resp, err = h.Get("/v1/nodeb/" + ranName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected GET nodeb to succeed once the faults are cleared, got %d", resp.StatusCode)
+	}
+}
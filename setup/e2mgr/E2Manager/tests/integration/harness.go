@@ -0,0 +1,264 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+// Package integration boots the E2 Manager component graph through bootstrap.New, as app/main.go does, on an
+// in-memory SDL, an in-memory RMR bus and an in-process Routing Manager, so that end-to-end flows can be driven by an
+// E2 Termination endpoint and asserted on the rNib contents.
+package integration
+
+import (
+	"e2mgr/auth"
+	"e2mgr/bootstrap"
+	"e2mgr/configuration"
+	"e2mgr/httpserver"
+	"e2mgr/logger"
+	"e2mgr/managers"
+	"e2mgr/managers/notificationmanager"
+	"e2mgr/models"
+	"e2mgr/rmrcapture"
+	"e2mgr/rmrmemory"
+	"e2mgr/rmrtypes"
+	"e2mgr/rnibfaults"
+	"e2mgr/services"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+const (
+	E2ManagerEndpoint = "e2mgr"
+	E2TEndpoint       = "e2t"
+)
+
+// settleInterval is how long the E2 Manager has to stay idle to be considered settled
+const settleInterval = 5 * time.Millisecond
+
+// Harness is a running E2 Manager. The keep alive loop is not started, KeepAliveTick runs one iteration of it so
+// that E2T expiry is deterministic
+type Harness struct {
+	Config              *configuration.Configuration
+	Logger              *logger.Logger
+	Sdl                 *MemorySdl
+	RnibDataService     services.RNibDataService
+	RoutingManager      *RoutingManagerFake
+	Bus                 *rmrmemory.Bus
+	E2Manager           *rmrmemory.Messenger
+	E2T                 *rmrmemory.Messenger
+	Api                 *httptest.Server
+	KeepAliveWorker     managers.E2TKeepAliveWorker
+	NotificationManager *notificationmanager.NotificationManager
+	Capture             *rmrcapture.Writer
+	Faults              *rnibfaults.Injector
+	components          *bootstrap.E2Manager
+}
+
+// DefaultConfiguration returns a configuration suited to tests: short keep alive timings and a Routing Manager client
+// that neither retries nor opens its circuit. The Routing Manager base url is set by New
+func DefaultConfiguration() *configuration.Configuration {
+	config := &configuration.Configuration{}
+	config.Logging.LogLevel = "info"
+	config.Logging.RanTraceDefaultDurationSec = 600
+	config.Logging.RanTraceMaxDurationSec = 3600
+	config.Rmr.MaxMsgSize = 65536
+	config.RoutingManager.TimeoutMs = 1000
+	config.NotificationResponseBuffer = 100
+	config.BigRedButtonTimeoutSec = 1
+	config.MaxRnibConnectionAttempts = 3
+	config.RnibRetryIntervalMs = 10
+	config.KeepAliveResponseTimeoutMs = 300
+	config.KeepAliveDelayMs = 100
+	config.E2TInstanceDeletionTimeoutMs = 15000
+	config.RanStatusHistorySize = 50
+	config.GlobalRicId.PlmnId = "131014"
+	config.GlobalRicId.RicNearRtId = "556670"
+	config.Health.CheckTimeoutMs = 1000
+	config.Health.KeepAliveMaxAgeMs = 4500
+	config.Health.NotificationQueueMaxInFlight = 1000
+	return config
+}
+
+// New starts an E2 Manager with config, DefaultConfiguration if nil, connected to a single E2 Termination endpoint
+func New(config *configuration.Configuration) (*Harness, error) {
+	if config == nil {
+		config = DefaultConfiguration()
+	}
+
+	logLevel, ok := logger.LogLevelTokenToLevel(config.Logging.LogLevel)
+	if !ok {
+		return nil, fmt.Errorf("#integration.New - invalid log level %s", config.Logging.LogLevel)
+	}
+
+	log, err := logger.InitLogger(logLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Harness{
+		Config:         config,
+		Logger:         log,
+		Sdl:            NewMemorySdl(),
+		RoutingManager: NewRoutingManagerFake(),
+		Bus:            rmrmemory.NewBus(),
+	}
+
+	config.RoutingManager.BaseUrl = h.RoutingManager.BaseUrl()
+
+	h.E2Manager, err = rmrmemory.NewMessenger(h.Bus, E2ManagerEndpoint, config.Rmr.MaxMsgSize, log)
+	if err != nil {
+		h.RoutingManager.Close()
+		return nil, err
+	}
+
+	h.E2T, err = rmrmemory.NewMessenger(h.Bus, E2TEndpoint, config.Rmr.MaxMsgSize, log)
+	if err != nil {
+		h.E2Manager.Close()
+		h.RoutingManager.Close()
+		return nil, err
+	}
+
+	h.Bus.Connect(h.E2Manager, h.E2T)
+
+	h.components, err = bootstrap.New(log, config, h.Sdl, h.E2Manager.Init("", config.Rmr.MaxMsgSize, 0, log))
+	if err != nil {
+		h.E2T.Close()
+		h.E2Manager.Close()
+		h.RoutingManager.Close()
+		return nil, err
+	}
+	h.RnibDataService = h.components.RnibDataService
+	h.KeepAliveWorker = h.components.KeepAliveWorker
+	h.NotificationManager = h.components.NotificationManager
+	h.Capture = h.components.Capture
+	h.Faults = h.components.Faults
+
+	go h.components.RmrReceiver.ListenAndHandle()
+
+	h.Api = httptest.NewServer(httpserver.NewRouter(h.components.RootController, h.components.NodebController, h.components.E2TController,
+		h.components.LoggingController, h.components.FaultInjectionController, auth.AllowAll{}))
+
+	return h, nil
+}
+
+func (h *Harness) Close() {
+	h.Api.Close()
+	h.components.Close()
+	h.E2T.Close()
+	h.RoutingManager.Close()
+}
+
+// SendFromE2T sends a message from the E2 Termination endpoint to the E2 Manager
+func (h *Harness) SendFromE2T(mType int, ranName string, payload []byte) error {
+	xAction := []byte{}
+	msg := rmrtypes.NewMBuf(mType, len(payload), ranName, &payload, &xAction, nil)
+	_, err := h.E2T.SendMsg(msg, true)
+	return err
+}
+
+// E2TermInit announces the E2 Termination at address, as an E2T does when it starts
+func (h *Harness) E2TermInit(address string, podName string) error {
+	payload, err := json.Marshal(models.E2TermInitPayload{Address: address, PodName: podName})
+	if err != nil {
+		return err
+	}
+	return h.SendFromE2T(rmrtypes.RIC_E2_TERM_INIT, "", payload)
+}
+
+// E2Setup forwards setupRequest, an E2 Setup Request E2AP-PDU in XML, from ranName through the E2T at e2tAddress
+func (h *Harness) E2Setup(e2tAddress string, ranName string, setupRequest []byte) error {
+	payload := append([]byte(e2tAddress+"|"), setupRequest...)
+	return h.SendFromE2T(rmrtypes.RIC_E2_SETUP_REQ, ranName, payload)
+}
+
+// KeepAliveResponse answers a keep alive request on behalf of the E2T at address
+func (h *Harness) KeepAliveResponse(address string) error {
+	payload, err := json.Marshal(models.E2TKeepAlivePayload{Address: address})
+	if err != nil {
+		return err
+	}
+	return h.SendFromE2T(rmrtypes.E2_TERM_KEEP_ALIVE_RESP, "", payload)
+}
+
+// KeepAliveTick runs one iteration of the keep alive loop: a request is sent to the E2T and the instances whose
+// last response is older than keepAliveResponseTimeoutMs are shut down
+func (h *Harness) KeepAliveTick() {
+	h.KeepAliveWorker.SendKeepAliveRequest()
+	h.KeepAliveWorker.E2TKeepAliveExpired()
+}
+
+// ReceiveAtE2T waits for the next message sent to the E2T, skipping keep alive requests unless mType is one
+func (h *Harness) ReceiveAtE2T(mType int, timeout time.Duration) (*rmrtypes.MBuf, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		msg, err := h.E2T.Receive(time.Until(deadline))
+		if err != nil {
+			return nil, err
+		}
+		if msg.MType == mType {
+			return msg, nil
+		}
+		if msg.MType != rmrtypes.E2_TERM_KEEP_ALIVE_REQ {
+			return nil, fmt.Errorf("#Harness.ReceiveAtE2T - expected message type %d, received %d", mType, msg.MType)
+		}
+	}
+}
+
+// Settle waits until every message sent to the E2 Manager has been received and handled
+func (h *Harness) Settle(timeout time.Duration) error {
+	idle := func() bool {
+		return h.E2Manager.Pending() == 0 && h.NotificationManager.InFlight() == 0
+	}
+
+	// A message just taken off the queue is not in flight yet, so idleness has to be seen twice
+	settled := h.WaitFor(timeout, func() bool {
+		if !idle() {
+			return false
+		}
+		time.Sleep(settleInterval)
+		return idle()
+	})
+
+	if !settled {
+		return fmt.Errorf("#Harness.Settle - E2 Manager did not settle within %s", timeout)
+	}
+	return nil
+}
+
+// WaitFor polls condition until it holds or timeout expires, and reports whether it held
+func (h *Harness) WaitFor(timeout time.Duration, condition func() bool) bool {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if condition() {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(settleInterval)
+	}
+}
+
+// Get calls the northbound API
+func (h *Harness) Get(path string) (*http.Response, error) {
+	return http.Get(h.Api.URL + path)
+}
@@ -0,0 +1,268 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package integration
+
+import (
+	"context"
+	"e2mgr/rmrtypes"
+	"encoding/json"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	e2tAddress          = "10.0.2.15:38000"
+	e2tPodName          = "e2term-pod"
+	ranName             = "gnb:310-410-b5c67788"
+	gnbSetupRequestPath = "../resources/setupRequest_gnb.xml"
+	timeout             = 2 * time.Second
+)
+
+func start(t *testing.T) *Harness {
+	h, err := New(nil)
+	if err != nil {
+		t.Fatalf("#lifecycle_test.start - failed to start the harness, error: %s", err)
+	}
+	return h
+}
+
+func settle(t *testing.T, h *Harness) {
+	if err := h.Settle(timeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func initE2T(t *testing.T, h *Harness) {
+	if err := h.E2TermInit(e2tAddress, e2tPodName); err != nil {
+		t.Fatal(err)
+	}
+	settle(t, h)
+}
+
+func setupRan(t *testing.T, h *Harness) *rmrtypes.MBuf {
+	setupRequest, err := ioutil.ReadFile(gnbSetupRequestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = h.E2Setup(e2tAddress, ranName, setupRequest); err != nil {
+		t.Fatal(err)
+	}
+	settle(t, h)
+
+	response, err := h.E2T.Receive(timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
+
+func getNodeb(t *testing.T, h *Harness) *entities.NodebInfo {
+	nodeb, err := h.RnibDataService.GetNodeb(context.Background(), ranName)
+	if err != nil {
+		t.Fatalf("#lifecycle_test.getNodeb - failed to get nodeb %s, error: %s", ranName, err)
+	}
+	return nodeb
+}
+
+func getE2TInstance(t *testing.T, h *Harness) *entities.E2TInstance {
+	e2tInstance, err := h.RnibDataService.GetE2TInstance(context.Background(), e2tAddress)
+	if err != nil {
+		t.Fatalf("#lifecycle_test.getE2TInstance - failed to get E2T instance %s, error: %s", e2tAddress, err)
+	}
+	return e2tInstance
+}
+
+func assertStrings(t *testing.T, what string, expected []string, actual []string) {
+	if len(expected) != len(actual) {
+		t.Fatalf("%s: expected %v, got %v", what, expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("%s: expected %v, got %v", what, expected, actual)
+		}
+	}
+}
+
+func TestE2TermInitAddsE2TInstance(t *testing.T) {
+	h := start(t)
+	defer h.Close()
+
+	initE2T(t, h)
+
+	e2tInstance := getE2TInstance(t, h)
+	if e2tInstance.State != entities.Active || e2tInstance.PodName != e2tPodName {
+		t.Errorf("unexpected E2T instance %+v", e2tInstance)
+	}
+	addresses, err := h.RnibDataService.GetE2TAddresses(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStrings(t, "E2T addresses", []string{e2tAddress}, addresses)
+	assertStrings(t, "routing manager E2T instances", []string{e2tAddress}, h.RoutingManager.E2TInstances())
+}
+
+func TestE2SetupConnectsRan(t *testing.T) {
+	h := start(t)
+	defer h.Close()
+
+	initE2T(t, h)
+	response := setupRan(t, h)
+
+	if response.MType != rmrtypes.RIC_E2_SETUP_RESP || response.Meid != ranName {
+		t.Errorf("expected RIC_E2_SETUP_RESP to %s, got type %d to %s", ranName, response.MType, response.Meid)
+	}
+
+	nodeb := getNodeb(t, h)
+	if nodeb.ConnectionStatus != entities.ConnectionStatus_CONNECTED || nodeb.AssociatedE2TInstanceAddress != e2tAddress {
+		t.Errorf("expected nodeb connected to %s, got %s to %s", e2tAddress, nodeb.ConnectionStatus, nodeb.AssociatedE2TInstanceAddress)
+	}
+	if len(nodeb.GetGnb().GetRanFunctions()) == 0 {
+		t.Errorf("expected the RAN functions of the setup request to be saved")
+	}
+	assertStrings(t, "E2T instance RANs", []string{ranName}, getE2TInstance(t, h).AssociatedRanList)
+	assertStrings(t, "routing manager RANs", []string{ranName}, h.RoutingManager.AssociatedRans(e2tAddress))
+
+	history, err := h.RnibDataService.GetRanStatusHistory(context.Background(), ranName)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("expected one status change, got %v, error: %v", history, err)
+	}
+
+	resp, err := h.Get("/v1/nodeb/" + ranName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body := map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != http.StatusOK || body["associatedE2TInstanceAddress"] != e2tAddress {
+		t.Errorf("expected GET nodeb to return the associated RAN, got %d %v", resp.StatusCode, body)
+	}
+}
+
+func TestE2SetupRoutingManagerFailure(t *testing.T) {
+	h := start(t)
+	defer h.Close()
+
+	initE2T(t, h)
+	h.RoutingManager.Fail(http.MethodPost, "associate-ran-to-e2t", http.StatusServiceUnavailable)
+	response := setupRan(t, h)
+
+	if response.MType != rmrtypes.RIC_E2_SETUP_FAILURE {
+		t.Errorf("expected RIC_E2_SETUP_FAILURE, got type %d", response.MType)
+	}
+	if status := getNodeb(t, h).ConnectionStatus; status != entities.ConnectionStatus_DISCONNECTED {
+		t.Errorf("expected nodeb disconnected, got %s", status)
+	}
+	assertStrings(t, "E2T instance RANs", []string{}, getE2TInstance(t, h).AssociatedRanList)
+	assertStrings(t, "routing manager RANs", []string{}, h.RoutingManager.AssociatedRans(e2tAddress))
+}
+
+func TestKeepAliveResponsesKeepE2TInstance(t *testing.T) {
+	h := start(t)
+	defer h.Close()
+
+	initE2T(t, h)
+
+	responseTimeout := time.Duration(h.Config.KeepAliveResponseTimeoutMs) * time.Millisecond
+	for i := 0; i < 3; i++ {
+		time.Sleep(responseTimeout / 2)
+		h.KeepAliveTick()
+
+		if _, err := h.ReceiveAtE2T(rmrtypes.E2_TERM_KEEP_ALIVE_REQ, timeout); err != nil {
+			t.Fatal(err)
+		}
+		if err := h.KeepAliveResponse(e2tAddress); err != nil {
+			t.Fatal(err)
+		}
+		settle(t, h)
+	}
+
+	if getE2TInstance(t, h).State != entities.Active {
+		t.Errorf("expected the E2T instance to stay active")
+	}
+}
+
+func TestKeepAliveLossShutsDownE2T(t *testing.T) {
+	h := start(t)
+	defer h.Close()
+
+	initE2T(t, h)
+	setupRan(t, h)
+
+	time.Sleep(time.Duration(h.Config.KeepAliveResponseTimeoutMs) * time.Millisecond)
+	h.KeepAliveTick()
+	settle(t, h)
+
+	_, err := h.RnibDataService.GetE2TInstance(context.Background(), e2tAddress)
+	if _, ok := err.(*common.ResourceNotFoundError); !ok {
+		t.Errorf("expected the E2T instance to be removed, got error %v", err)
+	}
+	addresses, _ := h.RnibDataService.GetE2TAddresses(context.Background())
+	assertStrings(t, "E2T addresses", []string{}, addresses)
+
+	nodeb := getNodeb(t, h)
+	if nodeb.ConnectionStatus != entities.ConnectionStatus_DISCONNECTED || nodeb.AssociatedE2TInstanceAddress != "" {
+		t.Errorf("expected nodeb disconnected and not associated, got %s to %s", nodeb.ConnectionStatus, nodeb.AssociatedE2TInstanceAddress)
+	}
+	assertStrings(t, "routing manager E2T instances", []string{}, h.RoutingManager.E2TInstances())
+}
+
+func TestE2TRestartDisconnectsRans(t *testing.T) {
+	h := start(t)
+	defer h.Close()
+
+	initE2T(t, h)
+	setupRan(t, h)
+	initE2T(t, h)
+
+	nodeb := getNodeb(t, h)
+	if nodeb.ConnectionStatus != entities.ConnectionStatus_DISCONNECTED || nodeb.AssociatedE2TInstanceAddress != "" {
+		t.Errorf("expected nodeb disconnected and not associated, got %s to %s", nodeb.ConnectionStatus, nodeb.AssociatedE2TInstanceAddress)
+	}
+	assertStrings(t, "E2T instance RANs", []string{}, getE2TInstance(t, h).AssociatedRanList)
+	assertStrings(t, "routing manager RANs", []string{}, h.RoutingManager.AssociatedRans(e2tAddress))
+}
+
+func TestSctpConnectionFailureDisconnectsRan(t *testing.T) {
+	h := start(t)
+	defer h.Close()
+
+	initE2T(t, h)
+	setupRan(t, h)
+
+	if err := h.SendFromE2T(rmrtypes.RIC_SCTP_CONNECTION_FAILURE, ranName, []byte{}); err != nil {
+		t.Fatal(err)
+	}
+	settle(t, h)
+
+	if getNodeb(t, h).ConnectionStatus != entities.ConnectionStatus_DISCONNECTED {
+		t.Errorf("expected nodeb disconnected")
+	}
+	assertStrings(t, "routing manager RANs", []string{}, h.RoutingManager.AssociatedRans(e2tAddress))
+	history, _ := h.RnibDataService.GetRanStatusHistory(context.Background(), ranName)
+	if len(history) != 2 {
+		t.Errorf("expected two status changes, got %v", history)
+	}
+}
@@ -0,0 +1,230 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package integration
+
+import (
+	"e2mgr/clients"
+	"e2mgr/models"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const routingManagerBasePath = "/ric/v1/handles/"
+
+type RoutingManagerRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// RoutingManagerFake is an in-process Routing Manager serving the calls of clients.RoutingManagerClient. It keeps the
+// E2T instances and their associated RANs, so tests can assert on what E2 Manager asked it to route
+type RoutingManagerFake struct {
+	server       *httptest.Server
+	mu           sync.Mutex
+	e2tInstances map[string]map[string]bool
+	requests     []RoutingManagerRequest
+	failures     map[string]int
+}
+
+func NewRoutingManagerFake() *RoutingManagerFake {
+	f := &RoutingManagerFake{
+		e2tInstances: map[string]map[string]bool{},
+		failures:     map[string]int{},
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+// BaseUrl is the routingManager.baseUrl to configure E2 Manager with
+func (f *RoutingManagerFake) BaseUrl() string {
+	return f.server.URL + routingManagerBasePath
+}
+
+func (f *RoutingManagerFake) Close() {
+	f.server.Close()
+}
+
+// Fail makes requests of method to the api suffix (e.g. clients.AssociateRanToE2TInstanceApiSuffix) answer status
+// without changing the state, until Fail is called again with status 0
+func (f *RoutingManagerFake) Fail(method string, suffix string, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if status == 0 {
+		delete(f.failures, method+" "+suffix)
+		return
+	}
+	f.failures[method+" "+suffix] = status
+}
+
+// E2TInstances returns the addresses of the E2T instances, sorted
+func (f *RoutingManagerFake) E2TInstances() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	addresses := []string{}
+	for address := range f.e2tInstances {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// AssociatedRans returns the RANs associated to the E2T instance at e2tAddress, sorted
+func (f *RoutingManagerFake) AssociatedRans(e2tAddress string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ranNames := []string{}
+	for ranName := range f.e2tInstances[e2tAddress] {
+		ranNames = append(ranNames, ranName)
+	}
+	sort.Strings(ranNames)
+	return ranNames
+}
+
+// Requests returns the requests received so far, failed ones included
+func (f *RoutingManagerFake) Requests() []RoutingManagerRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]RoutingManagerRequest{}, f.requests...)
+}
+
+func (f *RoutingManagerFake) handle(writer http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	suffix := strings.TrimPrefix(r.URL.Path, routingManagerBasePath)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, RoutingManagerRequest{Method: r.Method, Path: r.URL.Path, Body: string(body)})
+
+	if status, ok := f.failures[r.Method+" "+suffix]; ok {
+		http.Error(writer, "injected failure", status)
+		return
+	}
+
+	var err error
+
+	switch {
+	case r.Method == http.MethodPost && suffix == clients.AddE2TInstanceApiSuffix:
+		err = f.addE2TInstance(body)
+	case r.Method == http.MethodDelete && suffix == clients.DeleteE2TInstanceApiSuffix:
+		err = f.deleteE2TInstance(body)
+	case r.Method == http.MethodPost && suffix == clients.AssociateRanToE2TInstanceApiSuffix:
+		err = f.associate(body)
+	case r.Method == http.MethodPost && suffix == clients.DissociateRanE2TInstanceApiSuffix:
+		err = f.dissociate(body)
+	default:
+		http.NotFound(writer, r)
+		return
+	}
+
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+}
+
+func (f *RoutingManagerFake) addE2TInstance(body []byte) error {
+	data := models.RoutingManagerE2TData{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := f.e2tInstances[data.E2TAddress]; !ok {
+		f.e2tInstances[data.E2TAddress] = map[string]bool{}
+	}
+	return nil
+}
+
+func (f *RoutingManagerFake) deleteE2TInstance(body []byte) error {
+	data := models.RoutingManagerDeleteRequestModel{}
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return err
+	}
+
+	delete(f.e2tInstances, data.E2TAddress)
+
+	for _, association := range data.RanAssocList {
+		f.associateRans(association)
+	}
+	return nil
+}
+
+func (f *RoutingManagerFake) associate(body []byte) error {
+	dataList := models.RoutingManagerE2TDataList{}
+	err := json.Unmarshal(body, &dataList)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range dataList {
+		f.associateRans(data)
+	}
+	return nil
+}
+
+func (f *RoutingManagerFake) associateRans(data *models.RoutingManagerE2TData) {
+	ranNames, ok := f.e2tInstances[data.E2TAddress]
+	if !ok {
+		ranNames = map[string]bool{}
+		f.e2tInstances[data.E2TAddress] = ranNames
+	}
+	for _, ranName := range data.RanNamelist {
+		ranNames[ranName] = true
+	}
+}
+
+// dissociate removes the listed RANs, or all RANs of an E2T instance listed without any
+func (f *RoutingManagerFake) dissociate(body []byte) error {
+	dataList := models.RoutingManagerE2TDataList{}
+	err := json.Unmarshal(body, &dataList)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range dataList {
+		ranNames, ok := f.e2tInstances[data.E2TAddress]
+		if !ok {
+			continue
+		}
+		if len(data.RanNamelist) == 0 {
+			f.e2tInstances[data.E2TAddress] = map[string]bool{}
+			continue
+		}
+		for _, ranName := range data.RanNamelist {
+			delete(ranNames, ranName)
+		}
+	}
+	return nil
+}
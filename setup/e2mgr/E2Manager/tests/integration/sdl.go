@@ -0,0 +1,363 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package integration
+
+import (
+	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"sort"
+	"sync"
+)
+
+var _ common.ISdlInstance = (*MemorySdl)(nil)
+
+type subscription struct {
+	callback func(string, ...string)
+	channels map[string]bool
+}
+
+// MemorySdl is an in-memory common.ISdlInstance with the semantics the rNib reader and writer rely on: values are
+// stored and returned as strings, as they are by Redis, and pairs may be passed flat or as a single slice or map
+type MemorySdl struct {
+	mu            sync.Mutex
+	values        map[string]string
+	groups        map[string]map[string]bool
+	subscriptions []*subscription
+}
+
+func NewMemorySdl() *MemorySdl {
+	return &MemorySdl{
+		values: map[string]string{},
+		groups: map[string]map[string]bool{},
+	}
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func flattenPairs(pairs []interface{}) ([]string, error) {
+	var flat []string
+
+	for _, pair := range pairs {
+		switch v := pair.(type) {
+		case []interface{}:
+			nested, err := flattenPairs(v)
+			if err != nil {
+				return nil, err
+			}
+			flat = append(flat, nested...)
+		case []string:
+			flat = append(flat, v...)
+		case map[string]interface{}:
+			for key, value := range v {
+				flat = append(flat, key, toString(value))
+			}
+		case map[string]string:
+			for key, value := range v {
+				flat = append(flat, key, value)
+			}
+		default:
+			flat = append(flat, toString(v))
+		}
+	}
+
+	if len(flat)%2 != 0 {
+		return nil, fmt.Errorf("#MemorySdl - key without a value in %v", pairs)
+	}
+
+	return flat, nil
+}
+
+func (s *MemorySdl) set(pairs []interface{}) error {
+	flat, err := flattenPairs(pairs)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < len(flat); i += 2 {
+		s.values[flat[i]] = flat[i+1]
+	}
+	return nil
+}
+
+func (s *MemorySdl) Set(pairs ...interface{}) error {
+	return s.set(pairs)
+}
+
+func (s *MemorySdl) SetAndPublish(channelsAndEvents []string, pairs ...interface{}) error {
+	err := s.set(pairs)
+	if err == nil {
+		s.publish(channelsAndEvents)
+	}
+	return err
+}
+
+// Get returns a nil value for every key that does not exist
+func (s *MemorySdl) Get(keys []string) (map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		value, ok := s.values[key]
+		if ok {
+			result[key] = value
+		} else {
+			result[key] = nil
+		}
+	}
+	return result, nil
+}
+
+func (s *MemorySdl) SetIf(key string, oldData, newData interface{}) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	if !ok || value != toString(oldData) {
+		return false, nil
+	}
+	s.values[key] = toString(newData)
+	return true, nil
+}
+
+func (s *MemorySdl) SetIfAndPublish(channelsAndEvents []string, key string, oldData, newData interface{}) (bool, error) {
+	ok, err := s.SetIf(key, oldData, newData)
+	if ok {
+		s.publish(channelsAndEvents)
+	}
+	return ok, err
+}
+
+func (s *MemorySdl) SetIfNotExists(key string, data interface{}) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+	s.values[key] = toString(data)
+	return true, nil
+}
+
+func (s *MemorySdl) SetIfNotExistsAndPublish(channelsAndEvents []string, key string, data interface{}) (bool, error) {
+	ok, err := s.SetIfNotExists(key, data)
+	if ok {
+		s.publish(channelsAndEvents)
+	}
+	return ok, err
+}
+
+func (s *MemorySdl) Remove(keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		delete(s.values, key)
+	}
+	return nil
+}
+
+func (s *MemorySdl) RemoveAndPublish(channelsAndEvents []string, keys []string) error {
+	err := s.Remove(keys)
+	if err == nil {
+		s.publish(channelsAndEvents)
+	}
+	return err
+}
+
+func (s *MemorySdl) RemoveIf(key string, data interface{}) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	if !ok || value != toString(data) {
+		return false, nil
+	}
+	delete(s.values, key)
+	return true, nil
+}
+
+func (s *MemorySdl) RemoveIfAndPublish(channelsAndEvents []string, key string, data interface{}) (bool, error) {
+	ok, err := s.RemoveIf(key, data)
+	if ok {
+		s.publish(channelsAndEvents)
+	}
+	return ok, err
+}
+
+// GetAll returns the keys of all values, sorted
+func (s *MemorySdl) GetAll() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *MemorySdl) RemoveAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values = map[string]string{}
+	s.groups = map[string]map[string]bool{}
+	return nil
+}
+
+func (s *MemorySdl) RemoveAllAndPublish(channelsAndEvents []string) error {
+	err := s.RemoveAll()
+	if err == nil {
+		s.publish(channelsAndEvents)
+	}
+	return err
+}
+
+func (s *MemorySdl) AddMember(group string, member ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members, ok := s.groups[group]
+	if !ok {
+		members = map[string]bool{}
+		s.groups[group] = members
+	}
+	for _, m := range member {
+		members[toString(m)] = true
+	}
+	return nil
+}
+
+func (s *MemorySdl) RemoveMember(group string, member ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members := s.groups[group]
+	for _, m := range member {
+		delete(members, toString(m))
+	}
+	if len(members) == 0 {
+		delete(s.groups, group)
+	}
+	return nil
+}
+
+func (s *MemorySdl) RemoveGroup(group string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.groups, group)
+	return nil
+}
+
+// GetMembers returns the members of group, sorted
+func (s *MemorySdl) GetMembers(group string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members := make([]string, 0, len(s.groups[group]))
+	for m := range s.groups[group] {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+func (s *MemorySdl) IsMember(group string, member interface{}) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.groups[group][toString(member)], nil
+}
+
+func (s *MemorySdl) GroupSize(group string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.groups[group])), nil
+}
+
+func (s *MemorySdl) SubscribeChannel(cb func(string, ...string), channels ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub := &subscription{callback: cb, channels: map[string]bool{}}
+	for _, channel := range channels {
+		sub.channels[channel] = true
+	}
+	s.subscriptions = append(s.subscriptions, sub)
+	return nil
+}
+
+func (s *MemorySdl) UnsubscribeChannel(channels ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.subscriptions {
+		for _, channel := range channels {
+			delete(sub.channels, channel)
+		}
+	}
+	return nil
+}
+
+// publish calls the subscribers of every channel in channelsAndEvents, a list of channel and event pairs, outside
+// of the lock so that a callback may use the instance
+func (s *MemorySdl) publish(channelsAndEvents []string) {
+	type notification struct {
+		callback func(string, ...string)
+		channel  string
+		event    string
+	}
+	var notifications []notification
+
+	s.mu.Lock()
+	for i := 0; i+1 < len(channelsAndEvents); i += 2 {
+		for _, sub := range s.subscriptions {
+			if sub.channels[channelsAndEvents[i]] {
+				notifications = append(notifications, notification{sub.callback, channelsAndEvents[i], channelsAndEvents[i+1]})
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, n := range notifications {
+		n.callback(n.channel, n.event)
+	}
+}
+
+func (s *MemorySdl) Close() error {
+	return nil
+}
@@ -0,0 +1,130 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package main
+
+import (
+	"context"
+	"e2mgr/logger"
+	"e2mgr/rmrCgo"
+	"e2mgr/tests/integration"
+	"e2mgr/tools/e2nodesim"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+)
+
+const (
+	modeRmr   = "rmr"
+	modeLocal = "local"
+)
+
+func main() {
+	scenarioPath := flag.String("scenario", "resources/scenario.yaml", "scenario file")
+	mode := flag.String("mode", modeRmr, "rmr: talk to E2 Manager through RMR, local: run against an in-process E2 Manager")
+	rmrPort := flag.Int("rmr-port", 38000, "RMR port to listen on in rmr mode")
+	maxMsgSize := flag.Int("rmr-max-msg-size", 65536, "RMR maximum message size")
+	logLevel := flag.String("log-level", "info", "log level")
+	jsonReport := flag.Bool("json", false, "print the report as JSON")
+	minSuccessRate := flag.Float64("min-success-rate", 0, "exit with status 2 when the setup success rate is lower")
+	timeout := flag.Duration("timeout", 0, "stop the scenario after this duration, 0 for no limit")
+	flag.Parse()
+
+	level, ok := logger.LogLevelTokenToLevel(*logLevel)
+	if !ok {
+		fmt.Printf("#e2nodesim.main - invalid log level %s\n", *logLevel)
+		os.Exit(1)
+	}
+	logger, err := logger.InitLogger(level)
+	if err != nil {
+		fmt.Printf("#e2nodesim.main - failed to initialize logger, error: %s\n", err)
+		os.Exit(1)
+	}
+
+	scenario, err := e2nodesim.LoadScenario(*scenarioPath)
+	if err != nil {
+		logger.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	var transport e2nodesim.Transport
+
+	switch *mode {
+	case modeRmr:
+		var msgImpl *rmrCgo.Context
+		transport = e2nodesim.NewMessengerTransport(logger, msgImpl.Init("tcp:"+strconv.Itoa(*rmrPort), *maxMsgSize, 0, logger))
+	case modeLocal:
+		config := integration.DefaultConfiguration()
+		config.Logging.LogLevel = *logLevel
+		harness, err := integration.New(config)
+		if err != nil {
+			logger.Errorf("#e2nodesim.main - failed to start the in-process E2 Manager, error: %s", err)
+			os.Exit(1)
+		}
+		defer harness.Close()
+		transport = e2nodesim.NewMessengerTransport(logger, harness.E2T)
+		// The in-process E2 Manager only knows E2T instances that announced themselves
+		scenario.AnnounceE2T = true
+	default:
+		logger.Errorf("#e2nodesim.main - unknown mode %s", *mode)
+		os.Exit(1)
+	}
+	defer transport.Close()
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if *timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), *timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		logger.Infof("#e2nodesim.main - interrupted, stopping")
+		cancel()
+	}()
+
+	simulator := e2nodesim.New(logger, scenario, transport)
+	logger.Infof("#e2nodesim.main - simulating %d E2 nodes", len(simulator.Nodes()))
+	report, err := simulator.Run(ctx)
+	if err != nil && report == nil {
+		logger.Errorf("#e2nodesim.main - %s", err)
+		os.Exit(1)
+	}
+
+	if *jsonReport {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(report)
+	}
+
+	if report.Setups.SuccessRate() < *minSuccessRate {
+		logger.Errorf("#e2nodesim.main - setup success rate %.3f is lower than %.3f", report.Setups.SuccessRate(), *minSuccessRate)
+		os.Exit(2)
+	}
+}
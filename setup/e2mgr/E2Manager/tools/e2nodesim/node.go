@@ -0,0 +1,170 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package e2nodesim
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// Node is a simulated E2 node
+type Node struct {
+	RanName      string
+	Group        string
+	Type         string
+	PlmnId       string
+	NbId         string
+	RanFunctions []RanFunction
+}
+
+// NewNodes returns the nodes of the scenario, group by group
+func NewNodes(scenario *Scenario) []*Node {
+	var nodes []*Node
+
+	for _, group := range scenario.Nodes {
+		width := len(fmt.Sprint(group.Count))
+
+		for i := 0; i < group.Count; i++ {
+			nodes = append(nodes, &Node{
+				RanName:      fmt.Sprintf("%s_%0*d", group.Name, width, i+1),
+				Group:        group.Name,
+				Type:         group.Type,
+				PlmnId:       group.PlmnId,
+				NbId:         fmt.Sprintf("%0*b", group.NbIdLength, group.NbId+uint64(i)),
+				RanFunctions: group.RanFunctions,
+			})
+		}
+	}
+	return nodes
+}
+
+// NewNode returns a node of nodeType whose node id is nbId, with the default node id length of the type
+func NewNode(ranName string, nodeType string, plmnId string, nbId uint64) (*Node, error) {
+	length, ok := nbIdLengths[nodeType]
+	if !ok {
+		return nil, fmt.Errorf("#e2nodesim.NewNode - unknown node type '%s'", nodeType)
+	}
+	if nbId >= 1<<uint(length) {
+		return nil, fmt.Errorf("#e2nodesim.NewNode - node id %d does not fit in %d bits", nbId, length)
+	}
+	return &Node{
+		RanName: ranName,
+		Group:   ranName,
+		Type:    nodeType,
+		PlmnId:  plmnId,
+		NbId:    fmt.Sprintf("%0*b", length, nbId),
+	}, nil
+}
+
+// The E2 Setup Request E2AP-PDU in the XER form E2SetupRequestNotificationHandler parses, see tests/resources
+var setupRequestTemplate = template.Must(template.New("setupRequest").Parse(`<E2AP-PDU>
+    <initiatingMessage>
+        <procedureCode>1</procedureCode>
+        <criticality><reject/></criticality>
+        <value>
+            <E2setupRequest>
+                <protocolIEs>
+                    <E2setupRequestIEs>
+                        <id>3</id>
+                        <criticality><reject/></criticality>
+                        <value>
+                            <GlobalE2node-ID>
+{{- if eq .Type "gnb"}}
+                                <gNB>
+                                    <global-gNB-ID>
+                                        <plmn-id>{{html .PlmnId}}</plmn-id>
+                                        <gnb-id><gnb-ID>{{.NbId}}</gnb-ID></gnb-id>
+                                    </global-gNB-ID>
+                                </gNB>
+{{- else if eq .Type "en-gnb"}}
+                                <en-gNB>
+                                    <global-gNB-ID>
+                                        <pLMN-Identity>{{html .PlmnId}}</pLMN-Identity>
+                                        <gNB-ID><gNB-ID>{{.NbId}}</gNB-ID></gNB-ID>
+                                    </global-gNB-ID>
+                                </en-gNB>
+{{- else if eq .Type "ng-enb"}}
+                                <ng-eNB>
+                                    <global-ng-eNB-ID>
+                                        <plmn-id>{{html .PlmnId}}</plmn-id>
+                                        <enb-id><enb-ID-macro>{{.NbId}}</enb-ID-macro></enb-id>
+                                    </global-ng-eNB-ID>
+                                </ng-eNB>
+{{- else}}
+                                <eNB>
+                                    <global-eNB-ID>
+                                        <pLMN-Identity>{{html .PlmnId}}</pLMN-Identity>
+                                        <eNB-ID><macro-eNB-ID>{{.NbId}}</macro-eNB-ID></eNB-ID>
+                                    </global-eNB-ID>
+                                </eNB>
+{{- end}}
+                            </GlobalE2node-ID>
+                        </value>
+                    </E2setupRequestIEs>
+{{- if .RanFunctions}}
+                    <E2setupRequestIEs>
+                        <id>10</id>
+                        <criticality><reject/></criticality>
+                        <value>
+                            <RANfunctions-List>
+{{- range .RanFunctions}}
+                                <ProtocolIE-SingleContainer>
+                                    <id>8</id>
+                                    <criticality><reject/></criticality>
+                                    <value>
+                                        <RANfunction-Item>
+                                            <ranFunctionID>{{.Id}}</ranFunctionID>
+                                            <ranFunctionDefinition>{{html .Definition}}</ranFunctionDefinition>
+                                            <ranFunctionRevision>{{.Revision}}</ranFunctionRevision>
+                                        </RANfunction-Item>
+                                    </value>
+                                </ProtocolIE-SingleContainer>
+{{- end}}
+                            </RANfunctions-List>
+                        </value>
+                    </E2setupRequestIEs>
+{{- end}}
+                </protocolIEs>
+            </E2setupRequest>
+        </value>
+    </initiatingMessage>
+</E2AP-PDU>
+`))
+
+// SetupRequest returns the E2 Setup Request of the node
+func (n *Node) SetupRequest() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := setupRequestTemplate.Execute(&buffer, n)
+	if err != nil {
+		return nil, fmt.Errorf("#e2nodesim.Node.SetupRequest - RAN name: %s - failed to build E2 Setup Request, error: %s", n.RanName, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// SetupRequestPayload returns the RIC_E2_SETUP_REQ payload an E2T at e2tAddress forwards for the node
+func (n *Node) SetupRequestPayload(e2tAddress string) ([]byte, error) {
+	setupRequest, err := n.SetupRequest()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(e2tAddress+"|"), setupRequest...), nil
+}
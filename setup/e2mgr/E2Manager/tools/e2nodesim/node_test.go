@@ -0,0 +1,90 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package e2nodesim
+
+import (
+	"bytes"
+	"e2mgr/models"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func parseSetupRequestPayload(t *testing.T, payload []byte) (string, *models.E2SetupRequestMessage) {
+	pipe := bytes.IndexByte(payload, '|')
+	assert.True(t, pipe > 0)
+
+	setupRequest := &models.E2SetupRequestMessage{}
+	err := xml.Unmarshal(payload[pipe+1:], &setupRequest.E2APPDU)
+	assert.Nil(t, err)
+	return string(payload[:pipe]), setupRequest
+}
+
+func TestSetupRequestPayload(t *testing.T) {
+	for _, nodeType := range []string{NodeTypeGnb, NodeTypeEnGnb, NodeTypeNgEnb, NodeTypeEnb} {
+		node := &Node{
+			RanName:      "node_1",
+			Type:         nodeType,
+			PlmnId:       "13 10 14",
+			NbId:         "10011001101010101011",
+			RanFunctions: []RanFunction{{Id: 1, Definition: "334455", Revision: 0}, {Id: 7, Definition: "<def>", Revision: 2}},
+		}
+
+		payload, err := node.SetupRequestPayload("10.0.2.15:38000")
+		assert.Nil(t, err)
+
+		e2tAddress, setupRequest := parseSetupRequestPayload(t, payload)
+		assert.Equal(t, "10.0.2.15:38000", e2tAddress)
+		assert.Equal(t, "131014", setupRequest.GetPlmnId(), nodeType)
+		assert.Equal(t, "10011001101010101011", setupRequest.GetNbId(), nodeType)
+
+		ranFunctions, err := setupRequest.ExtractRanFunctionsList()
+		assert.Nil(t, err)
+		if assert.Len(t, ranFunctions, 2) {
+			assert.Equal(t, uint32(7), ranFunctions[1].RanFunctionId)
+			assert.Equal(t, "<def>", ranFunctions[1].RanFunctionDefinition)
+			assert.Equal(t, uint32(2), ranFunctions[1].RanFunctionRevision)
+		}
+	}
+}
+
+func TestSetupRequestWithoutRanFunctions(t *testing.T) {
+	node := &Node{RanName: "gnb_1", Type: NodeTypeGnb, PlmnId: "131014", NbId: "1"}
+
+	payload, err := node.SetupRequestPayload("10.0.2.15:38000")
+	assert.Nil(t, err)
+
+	_, setupRequest := parseSetupRequestPayload(t, payload)
+	ranFunctions, err := setupRequest.ExtractRanFunctionsList()
+	assert.Nil(t, err)
+	assert.Nil(t, ranFunctions)
+}
+
+func TestNewNode(t *testing.T) {
+	node, err := NewNode("enb_7", NodeTypeEnb, "6359AB", 7)
+	assert.Nil(t, err)
+	assert.Equal(t, "00000000000000000111", node.NbId)
+
+	_, err = NewNode("x", "nr", "6359AB", 7)
+	assert.NotNil(t, err)
+	_, err = NewNode("x", NodeTypeEnb, "6359AB", 1<<20)
+	assert.NotNil(t, err)
+}
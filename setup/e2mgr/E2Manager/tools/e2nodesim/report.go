@@ -0,0 +1,163 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package e2nodesim
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LatencySummary summarizes the setup latencies of a step, in milliseconds
+type LatencySummary struct {
+	MinMs  float64 `json:"minMs"`
+	MeanMs float64 `json:"meanMs"`
+	P50Ms  float64 `json:"p50Ms"`
+	P95Ms  float64 `json:"p95Ms"`
+	P99Ms  float64 `json:"p99Ms"`
+	MaxMs  float64 `json:"maxMs"`
+}
+
+// StepReport counts the outcome of the messages of a step. A setup succeeds when E2 Manager answers with
+// RIC_E2_SETUP_RESP, and is rejected when it answers with RIC_E2_SETUP_FAILURE
+type StepReport struct {
+	Step      int             `json:"step"`
+	Action    string          `json:"action"`
+	Attempted int             `json:"attempted"`
+	Succeeded int             `json:"succeeded"`
+	Rejected  int             `json:"rejected"`
+	TimedOut  int             `json:"timedOut"`
+	Errors    int             `json:"errors"`
+	Latency   *LatencySummary `json:"latency,omitempty"`
+	latencies []time.Duration
+}
+
+type Report struct {
+	Steps      []*StepReport `json:"steps"`
+	Setups     *StepReport   `json:"setups"`
+	DurationMs float64       `json:"durationMs"`
+}
+
+func isSetup(action string) bool {
+	return action == ActionSetup || action == ActionReconnect
+}
+
+// SuccessRate is the rate of succeeded attempts, 1 when there were none
+func (r *StepReport) SuccessRate() float64 {
+	if r.Attempted == 0 {
+		return 1
+	}
+	return float64(r.Succeeded) / float64(r.Attempted)
+}
+
+func (r *StepReport) add(other *StepReport) {
+	r.Attempted += other.Attempted
+	r.Succeeded += other.Succeeded
+	r.Rejected += other.Rejected
+	r.TimedOut += other.TimedOut
+	r.Errors += other.Errors
+	r.latencies = append(r.latencies, other.latencies...)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+func (r *StepReport) summarize() {
+	if len(r.latencies) == 0 {
+		r.Latency = nil
+		return
+	}
+
+	sorted := append([]time.Duration{}, r.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	r.Latency = &LatencySummary{
+		MinMs:  milliseconds(sorted[0]),
+		MeanMs: milliseconds(total / time.Duration(len(sorted))),
+		P50Ms:  milliseconds(percentile(sorted, 50)),
+		P95Ms:  milliseconds(percentile(sorted, 95)),
+		P99Ms:  milliseconds(percentile(sorted, 99)),
+		MaxMs:  milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// finish summarizes the latencies and totals the setup and reconnect steps
+func (r *Report) finish(duration time.Duration) {
+	r.Setups = &StepReport{Action: "setups"}
+
+	for _, step := range r.Steps {
+		step.summarize()
+		if isSetup(step.Action) {
+			r.Setups.add(step)
+		}
+	}
+
+	r.Setups.summarize()
+	r.DurationMs = milliseconds(duration)
+}
+
+func (r *StepReport) String() string {
+	line := fmt.Sprintf("%-10s attempted %d, succeeded %d, rejected %d, timed out %d, errors %d",
+		r.Action, r.Attempted, r.Succeeded, r.Rejected, r.TimedOut, r.Errors)
+
+	if isSetup(r.Action) || r.Action == "setups" {
+		line += fmt.Sprintf(", success rate %.1f%%", r.SuccessRate()*100)
+	}
+	if r.Latency != nil {
+		line += fmt.Sprintf(", latency ms min %.1f mean %.1f p50 %.1f p95 %.1f p99 %.1f max %.1f",
+			r.Latency.MinMs, r.Latency.MeanMs, r.Latency.P50Ms, r.Latency.P95Ms, r.Latency.P99Ms, r.Latency.MaxMs)
+	}
+	return line
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+
+	for _, step := range r.Steps {
+		if step.Action == ActionWait {
+			continue
+		}
+		fmt.Fprintf(&b, "step %d: %s\n", step.Step, step)
+	}
+	fmt.Fprintf(&b, "total:  %s\n", r.Setups)
+	fmt.Fprintf(&b, "duration %.0f ms\n", r.DurationMs)
+	return b.String()
+}
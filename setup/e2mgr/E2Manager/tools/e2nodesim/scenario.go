@@ -0,0 +1,188 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package e2nodesim
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+)
+
+const (
+	NodeTypeGnb   = "gnb"
+	NodeTypeEnGnb = "en-gnb"
+	NodeTypeNgEnb = "ng-enb"
+	NodeTypeEnb   = "enb"
+)
+
+const (
+	ActionSetup      = "setup"
+	ActionDisconnect = "disconnect"
+	ActionReconnect  = "reconnect"
+	ActionWait       = "wait"
+)
+
+const (
+	defaultSetupTimeoutMs = 5000
+	defaultConcurrency    = 10
+	defaultE2TInitWaitMs  = 500
+)
+
+// nbIdLengths are the default lengths, in bits, of the node ids of every node type
+var nbIdLengths = map[string]int{
+	NodeTypeGnb:   22,
+	NodeTypeEnGnb: 32,
+	NodeTypeNgEnb: 20,
+	NodeTypeEnb:   20,
+}
+
+type RanFunction struct {
+	Id         int    `yaml:"id"`
+	Definition string `yaml:"definition"`
+	Revision   int    `yaml:"revision"`
+}
+
+// NodeGroup describes Count E2 nodes named <Name>_<n>, whose node ids are consecutive from NbId
+type NodeGroup struct {
+	Name         string        `yaml:"name"`
+	Count        int           `yaml:"count"`
+	Type         string        `yaml:"type"`
+	PlmnId       string        `yaml:"plmnId"`
+	NbId         uint64        `yaml:"nbId"`
+	NbIdLength   int           `yaml:"nbIdLength"`
+	RanFunctions []RanFunction `yaml:"ranFunctions"`
+}
+
+// Step is an action applied to the nodes of the listed groups, or to all nodes when none is listed
+type Step struct {
+	Action     string   `yaml:"action"`
+	Groups     []string `yaml:"groups"`
+	DurationMs int      `yaml:"durationMs"`
+}
+
+type Scenario struct {
+	E2TAddress     string      `yaml:"e2tAddress"`
+	AnnounceE2T    bool        `yaml:"announceE2T"`
+	E2TPodName     string      `yaml:"e2tPodName"`
+	E2TInitWaitMs  int         `yaml:"e2tInitWaitMs"`
+	SetupTimeoutMs int         `yaml:"setupTimeoutMs"`
+	Concurrency    int         `yaml:"concurrency"`
+	Nodes          []NodeGroup `yaml:"nodes"`
+	Steps          []Step      `yaml:"steps"`
+}
+
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("#e2nodesim.LoadScenario - failed to read scenario file %s, error: %s", path, err)
+	}
+	return ParseScenario(data)
+}
+
+// ParseScenario parses a yaml scenario, applies the defaults and validates it
+func ParseScenario(data []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+	err := yaml.UnmarshalStrict(data, scenario)
+	if err != nil {
+		return nil, fmt.Errorf("#e2nodesim.ParseScenario - failed to parse scenario, error: %s", err)
+	}
+
+	scenario.applyDefaults()
+
+	err = scenario.validate()
+	if err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+func (s *Scenario) applyDefaults() {
+	if s.E2TInitWaitMs == 0 {
+		s.E2TInitWaitMs = defaultE2TInitWaitMs
+	}
+	if s.SetupTimeoutMs == 0 {
+		s.SetupTimeoutMs = defaultSetupTimeoutMs
+	}
+	if s.Concurrency == 0 {
+		s.Concurrency = defaultConcurrency
+	}
+	for i := range s.Nodes {
+		if s.Nodes[i].NbIdLength == 0 {
+			s.Nodes[i].NbIdLength = nbIdLengths[s.Nodes[i].Type]
+		}
+	}
+	if len(s.Steps) == 0 {
+		s.Steps = []Step{{Action: ActionSetup}}
+	}
+}
+
+func (s *Scenario) validate() error {
+	if s.E2TAddress == "" {
+		return fmt.Errorf("#e2nodesim.ParseScenario - e2tAddress is missing")
+	}
+	if s.SetupTimeoutMs < 0 || s.Concurrency < 0 || s.E2TInitWaitMs < 0 {
+		return fmt.Errorf("#e2nodesim.ParseScenario - setupTimeoutMs, concurrency and e2tInitWaitMs must be positive")
+	}
+	if len(s.Nodes) == 0 {
+		return fmt.Errorf("#e2nodesim.ParseScenario - no nodes")
+	}
+
+	groups := map[string]bool{}
+
+	for _, group := range s.Nodes {
+		if group.Name == "" || groups[group.Name] {
+			return fmt.Errorf("#e2nodesim.ParseScenario - node group names must be set and unique, got '%s'", group.Name)
+		}
+		groups[group.Name] = true
+
+		if _, ok := nbIdLengths[group.Type]; !ok {
+			return fmt.Errorf("#e2nodesim.ParseScenario - group %s: unknown node type '%s'", group.Name, group.Type)
+		}
+		if group.Count <= 0 {
+			return fmt.Errorf("#e2nodesim.ParseScenario - group %s: count must be positive", group.Name)
+		}
+		if group.PlmnId == "" {
+			return fmt.Errorf("#e2nodesim.ParseScenario - group %s: plmnId is missing", group.Name)
+		}
+		if group.NbIdLength <= 0 || group.NbIdLength > 64 || (group.NbIdLength < 64 && group.NbId+uint64(group.Count)-1 >= 1<<uint(group.NbIdLength)) {
+			return fmt.Errorf("#e2nodesim.ParseScenario - group %s: %d node ids from %d do not fit in %d bits", group.Name, group.Count, group.NbId, group.NbIdLength)
+		}
+	}
+
+	for i, step := range s.Steps {
+		switch step.Action {
+		case ActionSetup, ActionDisconnect, ActionReconnect:
+		case ActionWait:
+			if step.DurationMs <= 0 {
+				return fmt.Errorf("#e2nodesim.ParseScenario - step %d: wait requires a positive durationMs", i+1)
+			}
+		default:
+			return fmt.Errorf("#e2nodesim.ParseScenario - step %d: unknown action '%s'", i+1, step.Action)
+		}
+		for _, group := range step.Groups {
+			if !groups[group] {
+				return fmt.Errorf("#e2nodesim.ParseScenario - step %d: unknown node group '%s'", i+1, group)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2nodesim
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoadScenarioExample(t *testing.T) {
+	scenario, err := LoadScenario("resources/scenario.yaml")
+	assert.Nil(t, err)
+	assert.True(t, scenario.AnnounceE2T)
+	assert.Equal(t, 22, scenario.Nodes[0].NbIdLength)
+	assert.Equal(t, 20, scenario.Nodes[1].NbIdLength)
+	assert.Len(t, scenario.Steps, 5)
+	assert.Len(t, NewNodes(scenario), 15)
+}
+
+func TestParseScenarioDefaults(t *testing.T) {
+	scenario, err := ParseScenario([]byte(`
+e2tAddress: 10.0.2.15:38000
+nodes:
+  - name: engnb
+    count: 2
+    type: en-gnb
+    plmnId: "131014"
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, defaultSetupTimeoutMs, scenario.SetupTimeoutMs)
+	assert.Equal(t, defaultConcurrency, scenario.Concurrency)
+	assert.Equal(t, 32, scenario.Nodes[0].NbIdLength)
+	assert.Equal(t, []Step{{Action: ActionSetup}}, scenario.Steps)
+}
+
+func TestParseScenarioErrors(t *testing.T) {
+	node := `
+  - name: gnb
+    count: 2
+    type: gnb
+    plmnId: "131014"
+`
+	tests := map[string]string{
+		"e2tAddress is missing":    "nodes:" + node,
+		"no nodes":                 "e2tAddress: a",
+		"unknown node type 'nr'":   "e2tAddress: a\nnodes:\n  - {name: x, count: 1, type: nr, plmnId: p}",
+		"count must be positive":   "e2tAddress: a\nnodes:\n  - {name: x, count: 0, type: gnb, plmnId: p}",
+		"plmnId is missing":        "e2tAddress: a\nnodes:\n  - {name: x, count: 1, type: gnb}",
+		"do not fit in 2 bits":     "e2tAddress: a\nnodes:\n  - {name: x, count: 2, type: gnb, plmnId: p, nbId: 3, nbIdLength: 2}",
+		"must be set and unique":   "e2tAddress: a\nnodes:" + node + node,
+		"unknown action 'restart'": "e2tAddress: a\nnodes:" + node + "steps:\n  - action: restart",
+		"requires a positive":      "e2tAddress: a\nnodes:" + node + "steps:\n  - action: wait",
+		"unknown node group 'enb'": "e2tAddress: a\nnodes:" + node + "steps:\n  - {action: setup, groups: [enb]}",
+		"failed to parse scenario": "e2tAddress: a\nunknownKey: 1",
+	}
+
+	for expected, data := range tests {
+		_, err := ParseScenario([]byte(data))
+		if assert.NotNil(t, err, expected) {
+			assert.Contains(t, err.Error(), expected)
+		}
+	}
+}
+
+func TestNewNodes(t *testing.T) {
+	scenario := &Scenario{Nodes: []NodeGroup{
+		{Name: "gnb", Count: 10, Type: NodeTypeGnb, PlmnId: "131014", NbId: 5, NbIdLength: 22},
+		{Name: "enb", Count: 1, Type: NodeTypeEnb, PlmnId: "6359AB", NbIdLength: 20},
+	}}
+
+	nodes := NewNodes(scenario)
+
+	assert.Len(t, nodes, 11)
+	assert.Equal(t, "gnb_01", nodes[0].RanName)
+	assert.Equal(t, "0000000000000000000101", nodes[0].NbId)
+	assert.Equal(t, "gnb_10", nodes[9].RanName)
+	assert.Equal(t, "0000000000000000001110", nodes[9].NbId)
+	assert.Equal(t, "enb_1", nodes[10].RanName)
+	assert.Equal(t, "enb", nodes[10].Group)
+}
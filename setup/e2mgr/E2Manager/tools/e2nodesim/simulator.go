@@ -0,0 +1,265 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+// Package e2nodesim simulates E2 nodes going through E2 Setup. The simulator stands in for the E2 Termination: it
+// forwards the E2 Setup Requests of the nodes of a scenario to E2 Manager, reports RIC_SCTP_CONNECTION_FAILURE when
+// they disconnect and answers the keep alive requests of E2 Manager.
+package e2nodesim
+
+import (
+	"context"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/rmrtypes"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const receivePollInterval = 100 * time.Millisecond
+
+type Simulator struct {
+	logger    *logger.Logger
+	scenario  *Scenario
+	transport Transport
+	nodes     []*Node
+	mu        sync.Mutex
+	waiting   map[string]chan int
+}
+
+func New(logger *logger.Logger, scenario *Scenario, transport Transport) *Simulator {
+	return &Simulator{
+		logger:    logger,
+		scenario:  scenario,
+		transport: transport,
+		nodes:     NewNodes(scenario),
+		waiting:   map[string]chan int{},
+	}
+}
+
+func (s *Simulator) Nodes() []*Node {
+	return s.nodes
+}
+
+// Run plays the steps of the scenario and reports their outcome. It returns early, with the steps played so far,
+// when ctx is done
+func (s *Simulator) Run(ctx context.Context) (*Report, error) {
+	start := time.Now()
+	report := &Report{}
+
+	dispatchCtx, stopDispatch := context.WithCancel(ctx)
+	defer stopDispatch()
+	go s.dispatch(dispatchCtx)
+
+	if s.scenario.AnnounceE2T {
+		err := s.announceE2T()
+		if err != nil {
+			return nil, err
+		}
+		if !sleep(ctx, time.Duration(s.scenario.E2TInitWaitMs)*time.Millisecond) {
+			report.finish(time.Since(start))
+			return report, ctx.Err()
+		}
+	}
+
+	for i, step := range s.scenario.Steps {
+		if ctx.Err() != nil {
+			break
+		}
+
+		s.logger.Infof("#Simulator.Run - step %d: %s %v", i+1, step.Action, step.Groups)
+		stepReport := &StepReport{Step: i + 1, Action: step.Action}
+
+		switch step.Action {
+		case ActionSetup, ActionReconnect:
+			s.forEachNode(ctx, step, func(node *Node) { s.setup(ctx, node, stepReport) })
+		case ActionDisconnect:
+			s.forEachNode(ctx, step, func(node *Node) { s.disconnect(node, stepReport) })
+		case ActionWait:
+			sleep(ctx, time.Duration(step.DurationMs)*time.Millisecond)
+		}
+
+		report.Steps = append(report.Steps, stepReport)
+	}
+
+	report.finish(time.Since(start))
+	return report, ctx.Err()
+}
+
+func sleep(ctx context.Context, duration time.Duration) bool {
+	select {
+	case <-time.After(duration):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *Simulator) announceE2T() error {
+	payload, err := json.Marshal(models.E2TermInitPayload{Address: s.scenario.E2TAddress, PodName: s.scenario.E2TPodName})
+	if err != nil {
+		return err
+	}
+	s.logger.Infof("#Simulator.announceE2T - sending E2_TERM_INIT, E2T address: %s", s.scenario.E2TAddress)
+	return s.transport.Send(rmrtypes.RIC_E2_TERM_INIT, "", payload)
+}
+
+func (s *Simulator) selectNodes(step Step) []*Node {
+	if len(step.Groups) == 0 {
+		return s.nodes
+	}
+
+	groups := map[string]bool{}
+	for _, group := range step.Groups {
+		groups[group] = true
+	}
+
+	var nodes []*Node
+	for _, node := range s.nodes {
+		if groups[node.Group] {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// forEachNode applies action to the nodes of the step, at most scenario.concurrency at a time
+func (s *Simulator) forEachNode(ctx context.Context, step Step, action func(node *Node)) {
+	semaphore := make(chan struct{}, s.scenario.Concurrency)
+	var wg sync.WaitGroup
+
+	for _, node := range s.selectNodes(step) {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+
+		wg.Add(1)
+		go func(node *Node) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			action(node)
+		}(node)
+	}
+
+	wg.Wait()
+}
+
+func (s *Simulator) setup(ctx context.Context, node *Node, stepReport *StepReport) {
+	payload, err := node.SetupRequestPayload(s.scenario.E2TAddress)
+	if err != nil {
+		s.logger.Errorf("%s", err)
+		s.count(stepReport, func() { stepReport.Attempted++; stepReport.Errors++ })
+		return
+	}
+
+	response := make(chan int, 1)
+	s.mu.Lock()
+	s.waiting[node.RanName] = response
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.waiting, node.RanName)
+		s.mu.Unlock()
+	}()
+
+	start := time.Now()
+	err = s.transport.Send(rmrtypes.RIC_E2_SETUP_REQ, node.RanName, payload)
+	if err != nil {
+		s.logger.Errorf("#Simulator.setup - RAN name: %s - failed to send E2 Setup Request, error: %s", node.RanName, err)
+		s.count(stepReport, func() { stepReport.Attempted++; stepReport.Errors++ })
+		return
+	}
+
+	select {
+	case mType := <-response:
+		latency := time.Since(start)
+		s.count(stepReport, func() {
+			stepReport.Attempted++
+			if mType == rmrtypes.RIC_E2_SETUP_RESP {
+				stepReport.Succeeded++
+				stepReport.latencies = append(stepReport.latencies, latency)
+			} else {
+				stepReport.Rejected++
+			}
+		})
+	case <-time.After(time.Duration(s.scenario.SetupTimeoutMs) * time.Millisecond):
+		s.logger.Warnf("#Simulator.setup - RAN name: %s - no response to E2 Setup Request", node.RanName)
+		s.count(stepReport, func() { stepReport.Attempted++; stepReport.TimedOut++ })
+	case <-ctx.Done():
+		s.count(stepReport, func() { stepReport.Attempted++; stepReport.TimedOut++ })
+	}
+}
+
+func (s *Simulator) disconnect(node *Node, stepReport *StepReport) {
+	err := s.transport.Send(rmrtypes.RIC_SCTP_CONNECTION_FAILURE, node.RanName, []byte{})
+	if err != nil {
+		s.logger.Errorf("#Simulator.disconnect - RAN name: %s - failed to send RIC_SCTP_CONNECTION_FAILURE, error: %s", node.RanName, err)
+		s.count(stepReport, func() { stepReport.Attempted++; stepReport.Errors++ })
+		return
+	}
+	s.count(stepReport, func() { stepReport.Attempted++; stepReport.Succeeded++ })
+}
+
+func (s *Simulator) count(stepReport *StepReport, update func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update()
+}
+
+// dispatch answers keep alive requests and hands E2 Setup responses to the node waiting for them
+func (s *Simulator) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		mbuf, err := s.transport.Receive(receivePollInterval)
+		if err != nil {
+			continue
+		}
+
+		switch mbuf.MType {
+		case rmrtypes.E2_TERM_KEEP_ALIVE_REQ:
+			s.answerKeepAlive()
+		case rmrtypes.RIC_E2_SETUP_RESP, rmrtypes.RIC_E2_SETUP_FAILURE:
+			s.mu.Lock()
+			response, ok := s.waiting[mbuf.Meid]
+			s.mu.Unlock()
+			if !ok {
+				s.logger.Warnf("#Simulator.dispatch - RAN name: %s - unexpected E2 Setup response, message type %d", mbuf.Meid, mbuf.MType)
+				continue
+			}
+			select {
+			case response <- mbuf.MType:
+			default:
+			}
+		default:
+			s.logger.Debugf("#Simulator.dispatch - RAN name: %s - ignoring message type %d", mbuf.Meid, mbuf.MType)
+		}
+	}
+}
+
+func (s *Simulator) answerKeepAlive() {
+	payload, _ := json.Marshal(models.E2TKeepAlivePayload{Address: s.scenario.E2TAddress})
+	err := s.transport.Send(rmrtypes.E2_TERM_KEEP_ALIVE_RESP, "", payload)
+	if err != nil {
+		s.logger.Warnf("#Simulator.answerKeepAlive - failed to send keep alive response, error: %s", err)
+	}
+}
@@ -0,0 +1,175 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package e2nodesim
+
+import (
+	"context"
+	"e2mgr/rmrtypes"
+	"e2mgr/tests/integration"
+	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const e2tAddress = "10.0.2.15:38000"
+
+func startHarness(t *testing.T) *integration.Harness {
+	h, err := integration.New(nil)
+	if err != nil {
+		t.Fatalf("#simulator_test.startHarness - failed to start the harness, error: %s", err)
+	}
+	return h
+}
+
+func newScenario(steps ...Step) *Scenario {
+	scenario := &Scenario{
+		E2TAddress:     e2tAddress,
+		AnnounceE2T:    true,
+		E2TInitWaitMs:  100,
+		SetupTimeoutMs: 2000,
+		Concurrency:    2,
+		Nodes: []NodeGroup{
+			{Name: "gnb", Count: 3, Type: NodeTypeGnb, PlmnId: "131014", NbId: 1, NbIdLength: 22, RanFunctions: []RanFunction{{Id: 1, Definition: "334455"}}},
+			{Name: "enb", Count: 2, Type: NodeTypeEnb, PlmnId: "6359AB", NbId: 1, NbIdLength: 20},
+		},
+		Steps: steps,
+	}
+	return scenario
+}
+
+func TestRunSetupDisconnectReconnect(t *testing.T) {
+	h := startHarness(t)
+	defer h.Close()
+
+	scenario := newScenario(
+		Step{Action: ActionSetup},
+		Step{Action: ActionDisconnect, Groups: []string{"gnb"}},
+		Step{Action: ActionWait, DurationMs: 50},
+		Step{Action: ActionReconnect, Groups: []string{"gnb"}},
+	)
+	transport := NewMessengerTransport(h.Logger, h.E2T)
+	defer transport.Close()
+
+	report, err := New(h.Logger, scenario, transport).Run(context.Background())
+
+	assert.Nil(t, err)
+	assert.Len(t, report.Steps, 4)
+	assert.Equal(t, 5, report.Steps[0].Succeeded)
+	assert.Equal(t, 3, report.Steps[1].Succeeded)
+	assert.Equal(t, 3, report.Steps[3].Succeeded)
+	assert.Equal(t, 8, report.Setups.Attempted)
+	assert.Equal(t, 1.0, report.Setups.SuccessRate())
+	assert.NotNil(t, report.Setups.Latency)
+
+	for _, ranName := range []string{"gnb_1", "gnb_2", "gnb_3", "enb_1", "enb_2"} {
+		nodeb, err := h.RnibDataService.GetNodeb(context.Background(), ranName)
+		if assert.Nil(t, err, ranName) {
+			assert.Equal(t, entities.ConnectionStatus_CONNECTED, nodeb.ConnectionStatus, ranName)
+			assert.Equal(t, e2tAddress, nodeb.AssociatedE2TInstanceAddress, ranName)
+		}
+	}
+	history, _ := h.RnibDataService.GetRanStatusHistory(context.Background(), "gnb_1")
+	assert.Len(t, history, 3)
+}
+
+func TestRunRoutingManagerFailureIsRejected(t *testing.T) {
+	h := startHarness(t)
+	defer h.Close()
+	h.RoutingManager.Fail(http.MethodPost, "associate-ran-to-e2t", http.StatusServiceUnavailable)
+
+	transport := NewMessengerTransport(h.Logger, h.E2T)
+	defer transport.Close()
+
+	report, err := New(h.Logger, newScenario(Step{Action: ActionSetup}), transport).Run(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 5, report.Setups.Rejected)
+	assert.Equal(t, 0.0, report.Setups.SuccessRate())
+	assert.Nil(t, report.Setups.Latency)
+}
+
+// silentTransport accepts every message and never answers
+type silentTransport struct {
+	sent chan int
+}
+
+func (t *silentTransport) Send(mType int, ranName string, payload []byte) error {
+	t.sent <- mType
+	return nil
+}
+
+func (t *silentTransport) Receive(timeout time.Duration) (*rmrtypes.MBuf, error) {
+	time.Sleep(timeout)
+	return nil, fmt.Errorf("timeout")
+}
+
+func (t *silentTransport) Close() {
+}
+
+func TestRunSetupTimeout(t *testing.T) {
+	h := startHarness(t)
+	defer h.Close()
+
+	scenario := newScenario(Step{Action: ActionSetup, Groups: []string{"enb"}})
+	scenario.AnnounceE2T = false
+	scenario.SetupTimeoutMs = 50
+	transport := &silentTransport{sent: make(chan int, 10)}
+
+	report, err := New(h.Logger, scenario, transport).Run(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.Setups.TimedOut)
+	assert.Equal(t, rmrtypes.RIC_E2_SETUP_REQ, <-transport.sent)
+}
+
+func TestRunCancelled(t *testing.T) {
+	h := startHarness(t)
+	defer h.Close()
+
+	scenario := newScenario(Step{Action: ActionWait, DurationMs: 10000}, Step{Action: ActionSetup})
+	scenario.AnnounceE2T = false
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	report, err := New(h.Logger, scenario, &silentTransport{sent: make(chan int, 10)}).Run(ctx)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Len(t, report.Steps, 1)
+	assert.Equal(t, 0, report.Setups.Attempted)
+}
+
+func TestReportLatencySummary(t *testing.T) {
+	step := &StepReport{Step: 1, Action: ActionSetup, Attempted: 100, Succeeded: 100}
+	for i := 100; i > 0; i-- {
+		step.latencies = append(step.latencies, time.Duration(i)*time.Millisecond)
+	}
+	report := &Report{Steps: []*StepReport{step, {Step: 2, Action: ActionDisconnect, Attempted: 3, Succeeded: 3}}}
+
+	report.finish(time.Second)
+
+	assert.Equal(t, LatencySummary{MinMs: 1, MeanMs: 50.5, P50Ms: 50, P95Ms: 95, P99Ms: 99, MaxMs: 100}, *report.Setups.Latency)
+	assert.Equal(t, 100, report.Setups.Attempted)
+	assert.Contains(t, report.String(), "success rate 100.0%")
+	assert.Contains(t, report.String(), "step 2: disconnect")
+}
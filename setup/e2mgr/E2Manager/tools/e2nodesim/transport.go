@@ -0,0 +1,106 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package e2nodesim
+
+import (
+	"e2mgr/logger"
+	"e2mgr/rmrtypes"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const receiveQueueSize = 1024
+
+// Transport carries the messages the simulator exchanges with E2 Manager, standing in for the E2 Termination
+type Transport interface {
+	Send(mType int, ranName string, payload []byte) error
+	Receive(timeout time.Duration) (*rmrtypes.MBuf, error)
+	Close()
+}
+
+// MessengerTransport is a Transport over an rmrtypes.RmrMessenger: an RMR context, or an rmrmemory.Messenger in local mode
+type MessengerTransport struct {
+	logger    *logger.Logger
+	messenger rmrtypes.RmrMessenger
+	received  chan *rmrtypes.MBuf
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewMessengerTransport(logger *logger.Logger, messenger rmrtypes.RmrMessenger) *MessengerTransport {
+	t := &MessengerTransport{
+		logger:    logger,
+		messenger: messenger,
+		received:  make(chan *rmrtypes.MBuf, receiveQueueSize),
+		closed:    make(chan struct{}),
+	}
+	go t.receiveLoop()
+	return t
+}
+
+func (t *MessengerTransport) receiveLoop() {
+	for {
+		mbuf, err := t.messenger.RecvMsg()
+
+		select {
+		case <-t.closed:
+			return
+		default:
+		}
+
+		if err != nil {
+			t.logger.Debugf("#MessengerTransport.receiveLoop - error: %s", err)
+			continue
+		}
+
+		select {
+		case t.received <- mbuf:
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+func (t *MessengerTransport) Send(mType int, ranName string, payload []byte) error {
+	xAction := []byte{}
+	msg := rmrtypes.NewMBuf(mType, len(payload), ranName, &payload, &xAction, nil)
+	_, err := t.messenger.SendMsg(msg, false)
+	return err
+}
+
+func (t *MessengerTransport) Receive(timeout time.Duration) (*rmrtypes.MBuf, error) {
+	select {
+	case mbuf := <-t.received:
+		return mbuf, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("#MessengerTransport.Receive - no message within %s", timeout)
+	case <-t.closed:
+		return nil, fmt.Errorf("#MessengerTransport.Receive - transport is closed")
+	}
+}
+
+func (t *MessengerTransport) Close() {
+	t.closeOnce.Do(func() {
+		close(t.closed)
+		t.messenger.Close()
+	})
+}
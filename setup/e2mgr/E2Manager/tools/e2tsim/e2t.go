@@ -0,0 +1,341 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+// Package e2tsim simulates an E2 Termination speaking the E2T <-> E2 Manager RMR contract: it announces itself with
+// RIC_E2_TERM_INIT, answers E2_TERM_KEEP_ALIVE_REQ (promptly, late or not at all), forwards E2 Setup Requests and
+// RIC_SCTP_CONNECTION_FAILURE on behalf of its RANs and drops them all on RIC_SCTP_CLEAR_ALL. Scripts drive it
+// through E2T death and restart.
+package e2tsim
+
+import (
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/rmrtypes"
+	"e2mgr/tools/e2nodesim"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	receivePollInterval = 100 * time.Millisecond
+	observedQueueSize   = 1024
+)
+
+// Stats counts what the E2T went through
+type Stats struct {
+	Inits              int `json:"inits"`
+	KeepAliveRequests  int `json:"keepAliveRequests"`
+	KeepAliveResponses int `json:"keepAliveResponses"`
+	SetupRequests      int `json:"setupRequests"`
+	ConnectionFailures int `json:"connectionFailures"`
+	ClearAlls          int `json:"clearAlls"`
+	Dropped            int `json:"dropped"`
+}
+
+type E2T struct {
+	logger         *logger.Logger
+	transport      e2nodesim.Transport
+	address        string
+	podName        string
+	mu             sync.Mutex
+	alive          bool
+	keepAliveDelay time.Duration
+	silent         bool
+	rans           map[string]bool
+	stats          Stats
+	observed       chan *rmrtypes.MBuf
+	stop           chan struct{}
+	stopOnce       sync.Once
+	done           chan struct{}
+}
+
+// New returns an E2T at address which is not started yet: it neither announces itself nor listens until Init
+func New(logger *logger.Logger, transport e2nodesim.Transport, address string, podName string) *E2T {
+	e := &E2T{
+		logger:    logger,
+		transport: transport,
+		address:   address,
+		podName:   podName,
+		rans:      map[string]bool{},
+		observed:  make(chan *rmrtypes.MBuf, observedQueueSize),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go e.receiveLoop()
+	return e
+}
+
+func (e *E2T) Address() string {
+	return e.address
+}
+
+// Close stops listening. The transport is left to its owner
+func (e *E2T) Close() {
+	e.stopOnce.Do(func() {
+		close(e.stop)
+		<-e.done
+	})
+}
+
+// Init starts the E2T: it answers the messages of E2 Manager from now on and announces itself with RIC_E2_TERM_INIT
+func (e *E2T) Init() error {
+	e.mu.Lock()
+	e.alive = true
+	e.stats.Inits++
+	e.mu.Unlock()
+
+	payload, err := json.Marshal(models.E2TermInitPayload{Address: e.address, PodName: e.podName})
+	if err != nil {
+		return err
+	}
+
+	err = e.transport.Send(rmrtypes.RIC_E2_TERM_INIT, "", payload)
+	if err != nil {
+		return fmt.Errorf("#E2T.Init - failed to send RIC_E2_TERM_INIT, error: %s", err)
+	}
+	e.logger.Infof("#E2T.Init - E2T address: %s - sent RIC_E2_TERM_INIT", e.address)
+	return nil
+}
+
+// Die stops the E2T the way a crashed pod stops: nothing is answered, what it received is forgotten and its SCTP
+// associations are gone
+func (e *E2T) Die() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.alive = false
+	e.rans = map[string]bool{}
+	e.forgetObserved()
+	e.logger.Infof("#E2T.Die - E2T address: %s - died", e.address)
+}
+
+// Restart brings a dead E2T back at the same address, which announces itself again
+func (e *E2T) Restart() error {
+	e.Die()
+	return e.Init()
+}
+
+// SetKeepAlive makes the E2T answer keep alive requests after delay, or not at all when silent
+func (e *E2T) SetKeepAlive(delay time.Duration, silent bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.keepAliveDelay = delay
+	e.silent = silent
+}
+
+// Setup forwards the E2 Setup Request of node. The RAN counts as connected once E2 Manager accepts it
+func (e *E2T) Setup(node *e2nodesim.Node) error {
+	payload, err := node.SetupRequestPayload(e.address)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.stats.SetupRequests++
+	e.mu.Unlock()
+
+	err = e.transport.Send(rmrtypes.RIC_E2_SETUP_REQ, node.RanName, payload)
+	if err != nil {
+		return fmt.Errorf("#E2T.Setup - RAN name: %s - failed to send RIC_E2_SETUP_REQ, error: %s", node.RanName, err)
+	}
+	return nil
+}
+
+// ConnectionFailure reports that the SCTP association of ranName was lost
+func (e *E2T) ConnectionFailure(ranName string) error {
+	e.mu.Lock()
+	delete(e.rans, ranName)
+	e.stats.ConnectionFailures++
+	e.mu.Unlock()
+
+	err := e.transport.Send(rmrtypes.RIC_SCTP_CONNECTION_FAILURE, ranName, []byte{})
+	if err != nil {
+		return fmt.Errorf("#E2T.ConnectionFailure - RAN name: %s - failed to send RIC_SCTP_CONNECTION_FAILURE, error: %s", ranName, err)
+	}
+	return nil
+}
+
+// Rans returns the connected RANs, sorted
+func (e *E2T) Rans() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rans := make([]string, 0, len(e.rans))
+	for ranName := range e.rans {
+		rans = append(rans, ranName)
+	}
+	sort.Strings(rans)
+	return rans
+}
+
+func (e *E2T) Stats() Stats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.stats
+}
+
+// Expect waits for the next message of type mType E2 Manager sends while the E2T is alive, discarding the others
+func (e *E2T) Expect(mType int, timeout time.Duration) (*rmrtypes.MBuf, error) {
+	deadline := time.After(timeout)
+
+	for {
+		select {
+		case mbuf := <-e.observed:
+			if mbuf.MType == mType {
+				return mbuf, nil
+			}
+		case <-deadline:
+			return nil, fmt.Errorf("#E2T.Expect - no message of type %d within %s", mType, timeout)
+		}
+	}
+}
+
+func (e *E2T) receiveLoop() {
+	defer close(e.done)
+
+	for {
+		select {
+		case <-e.stop:
+			return
+		default:
+		}
+
+		mbuf, err := e.transport.Receive(receivePollInterval)
+		if err != nil {
+			continue
+		}
+		e.handle(mbuf)
+	}
+}
+
+func (e *E2T) handle(mbuf *rmrtypes.MBuf) {
+	e.mu.Lock()
+	if !e.alive {
+		e.stats.Dropped++
+		e.mu.Unlock()
+		e.logger.Debugf("#E2T.handle - E2T address: %s - dead, dropping message type %d", e.address, mbuf.MType)
+		return
+	}
+	e.mu.Unlock()
+
+	switch mbuf.MType {
+	case rmrtypes.E2_TERM_KEEP_ALIVE_REQ:
+		e.handleKeepAlive()
+	case rmrtypes.RIC_E2_SETUP_RESP:
+		e.mu.Lock()
+		e.rans[mbuf.Meid] = true
+		e.mu.Unlock()
+	case rmrtypes.RIC_E2_SETUP_FAILURE:
+		e.mu.Lock()
+		delete(e.rans, mbuf.Meid)
+		e.mu.Unlock()
+	case rmrtypes.RIC_SCTP_CLEAR_ALL:
+		e.clearAll()
+	default:
+		e.logger.Debugf("#E2T.handle - RAN name: %s - ignoring message type %d", mbuf.Meid, mbuf.MType)
+	}
+
+	e.observe(mbuf)
+}
+
+// observe keeps mbuf for Expect, making room by forgetting the oldest message when nobody has been expecting any
+func (e *E2T) observe(mbuf *rmrtypes.MBuf) {
+	for {
+		select {
+		case e.observed <- mbuf:
+			return
+		default:
+		}
+		select {
+		case <-e.observed:
+		default:
+		}
+	}
+}
+
+func (e *E2T) forgetObserved() {
+	for {
+		select {
+		case <-e.observed:
+		default:
+			return
+		}
+	}
+}
+
+func (e *E2T) handleKeepAlive() {
+	e.mu.Lock()
+	e.stats.KeepAliveRequests++
+	delay, silent := e.keepAliveDelay, e.silent
+	e.mu.Unlock()
+
+	if silent {
+		return
+	}
+	if delay == 0 {
+		e.answerKeepAlive()
+		return
+	}
+	time.AfterFunc(delay, e.answerKeepAlive)
+}
+
+func (e *E2T) answerKeepAlive() {
+	e.mu.Lock()
+	alive := e.alive
+	e.mu.Unlock()
+
+	// A late answer of an E2T that died meanwhile is never sent
+	if !alive {
+		return
+	}
+
+	payload, _ := json.Marshal(models.E2TKeepAlivePayload{Address: e.address})
+	err := e.transport.Send(rmrtypes.E2_TERM_KEEP_ALIVE_RESP, "", payload)
+	if err != nil {
+		e.logger.Warnf("#E2T.answerKeepAlive - failed to send keep alive response, error: %s", err)
+		return
+	}
+
+	e.mu.Lock()
+	e.stats.KeepAliveResponses++
+	e.mu.Unlock()
+}
+
+// clearAll closes every SCTP association, as the E2T does on the shutdown of E2 Manager, and reports each of them lost
+func (e *E2T) clearAll() {
+	e.mu.Lock()
+	e.stats.ClearAlls++
+	e.mu.Unlock()
+
+	rans := e.Rans()
+	e.logger.Infof("#E2T.clearAll - E2T address: %s - RIC_SCTP_CLEAR_ALL received, closing %d associations", e.address, len(rans))
+
+	for _, ranName := range rans {
+		err := e.ConnectionFailure(ranName)
+		if err != nil {
+			e.logger.Warnf("%s", err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package e2tsim
+
+import (
+	"context"
+	"e2mgr/tests/integration"
+	"e2mgr/tools/e2nodesim"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	e2tAddress = "10.0.2.15:38000"
+	ranName    = "gnb:310-410-b5c67788"
+	timeout    = 2 * time.Second
+)
+
+func startE2T(t *testing.T) (*integration.Harness, *E2T) {
+	h, err := integration.New(nil)
+	if err != nil {
+		t.Fatalf("#e2t_test.startE2T - failed to start the harness, error: %s", err)
+	}
+
+	transport := e2nodesim.NewMessengerTransport(h.Logger, h.E2T)
+	e2t := New(h.Logger, transport, e2tAddress, "e2term-0")
+
+	if err := e2t.Init(); err != nil {
+		t.Fatalf("#e2t_test.startE2T - %s", err)
+	}
+	if err := h.Settle(timeout); err != nil {
+		t.Fatalf("#e2t_test.startE2T - %s", err)
+	}
+	return h, e2t
+}
+
+func stop(h *integration.Harness, e2t *E2T) {
+	e2t.Close()
+	h.Close()
+}
+
+func setup(t *testing.T, h *integration.Harness, e2t *E2T, ranName string) {
+	node, _ := e2nodesim.NewNode(ranName, e2nodesim.NodeTypeGnb, "131014", 1)
+	assert.Nil(t, e2t.Setup(node))
+
+	if !h.WaitFor(timeout, func() bool { return len(e2t.Rans()) > 0 }) {
+		t.Fatalf("#e2t_test.setup - RAN name: %s - not connected", ranName)
+	}
+}
+
+func connectionStatus(h *integration.Harness, ranName string) entities.ConnectionStatus {
+	nodeb, err := h.RnibDataService.GetNodeb(context.Background(), ranName)
+	if err != nil {
+		return entities.ConnectionStatus_UNKNOWN_CONNECTION_STATUS
+	}
+	return nodeb.ConnectionStatus
+}
+
+func keepAliveTimestamp(h *integration.Harness) int64 {
+	instance, err := h.RnibDataService.GetE2TInstance(context.Background(), e2tAddress)
+	if err != nil {
+		return 0
+	}
+	return instance.KeepAliveTimestamp
+}
+
+func TestInitAnswersKeepAlive(t *testing.T) {
+	h, e2t := startE2T(t)
+	defer stop(h, e2t)
+
+	before := keepAliveTimestamp(h)
+	assert.NotZero(t, before)
+	time.Sleep(10 * time.Millisecond)
+
+	h.KeepAliveTick()
+
+	assert.True(t, h.WaitFor(timeout, func() bool { return e2t.Stats().KeepAliveResponses == 1 }))
+	assert.Nil(t, h.Settle(timeout))
+	assert.True(t, keepAliveTimestamp(h) > before)
+	assert.Equal(t, Stats{Inits: 1, KeepAliveRequests: 1, KeepAliveResponses: 1}, e2t.Stats())
+}
+
+func TestKeepAliveDelay(t *testing.T) {
+	h, e2t := startE2T(t)
+	defer stop(h, e2t)
+	e2t.SetKeepAlive(200*time.Millisecond, false)
+
+	h.KeepAliveTick()
+
+	assert.True(t, h.WaitFor(timeout, func() bool { return e2t.Stats().KeepAliveRequests == 1 }))
+	assert.Equal(t, 0, e2t.Stats().KeepAliveResponses)
+	assert.True(t, h.WaitFor(timeout, func() bool { return e2t.Stats().KeepAliveResponses == 1 }))
+}
+
+func TestSilentE2TIsShutDown(t *testing.T) {
+	h, e2t := startE2T(t)
+	defer stop(h, e2t)
+	setup(t, h, e2t, ranName)
+	e2t.SetKeepAlive(0, true)
+
+	time.Sleep(time.Duration(h.Config.KeepAliveResponseTimeoutMs+50) * time.Millisecond)
+	h.KeepAliveTick()
+	assert.Nil(t, h.Settle(timeout))
+
+	_, err := h.RnibDataService.GetE2TInstance(context.Background(), e2tAddress)
+	assert.NotNil(t, err)
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, connectionStatus(h, ranName))
+	assert.Equal(t, 1, e2t.Stats().KeepAliveRequests)
+	assert.Equal(t, 0, e2t.Stats().KeepAliveResponses)
+}
+
+func TestDieAndRestart(t *testing.T) {
+	h, e2t := startE2T(t)
+	defer stop(h, e2t)
+	setup(t, h, e2t, ranName)
+
+	e2t.Die()
+	h.KeepAliveTick()
+	assert.True(t, h.WaitFor(timeout, func() bool { return e2t.Stats().Dropped > 0 }))
+	assert.Empty(t, e2t.Rans())
+
+	assert.Nil(t, e2t.Restart())
+	assert.Nil(t, h.Settle(timeout))
+
+	// E2 Manager disconnects the RANs of an E2T which comes back
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, connectionStatus(h, ranName))
+
+	setup(t, h, e2t, ranName)
+	assert.Nil(t, h.Settle(timeout))
+	assert.Equal(t, entities.ConnectionStatus_CONNECTED, connectionStatus(h, ranName))
+	assert.Equal(t, 2, e2t.Stats().Inits)
+}
+
+func TestConnectionFailure(t *testing.T) {
+	h, e2t := startE2T(t)
+	defer stop(h, e2t)
+	setup(t, h, e2t, ranName)
+
+	assert.Nil(t, e2t.ConnectionFailure(ranName))
+	assert.Nil(t, h.Settle(timeout))
+
+	assert.Empty(t, e2t.Rans())
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, connectionStatus(h, ranName))
+}
+
+func TestClearAllOnShutdown(t *testing.T) {
+	h, e2t := startE2T(t)
+	defer stop(h, e2t)
+	setup(t, h, e2t, ranName)
+
+	req, _ := http.NewRequest(http.MethodPut, h.Api.URL+"/v1/nodeb/shutdown", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if assert.Nil(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+
+	assert.True(t, h.WaitFor(timeout, func() bool { return e2t.Stats().ClearAlls == 1 }))
+	assert.Empty(t, e2t.Rans())
+	assert.Equal(t, 1, e2t.Stats().ConnectionFailures)
+	assert.True(t, h.WaitFor(timeout, func() bool {
+		return connectionStatus(h, ranName) == entities.ConnectionStatus_SHUT_DOWN
+	}))
+}
@@ -0,0 +1,137 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package main
+
+import (
+	"context"
+	"e2mgr/logger"
+	"e2mgr/rmrCgo"
+	"e2mgr/tests/integration"
+	"e2mgr/tools/e2nodesim"
+	"e2mgr/tools/e2tsim"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+)
+
+const (
+	modeRmr   = "rmr"
+	modeLocal = "local"
+)
+
+func main() {
+	scriptPath := flag.String("script", "", "script to play, none to announce the E2T and answer keep alives until interrupted")
+	mode := flag.String("mode", modeRmr, "rmr: talk to E2 Manager through RMR, local: run against an in-process E2 Manager")
+	address := flag.String("address", "", "E2T address announced to E2 Manager, defaults to localhost:<rmr-port>")
+	podName := flag.String("pod-name", "e2term-sim", "E2T pod name announced to E2 Manager")
+	rmrPort := flag.Int("rmr-port", 38000, "RMR port to listen on in rmr mode")
+	maxMsgSize := flag.Int("rmr-max-msg-size", 65536, "RMR maximum message size")
+	keepAliveDelay := flag.Duration("keepalive-delay", 0, "delay of the keep alive responses")
+	logLevel := flag.String("log-level", "info", "log level")
+	flag.Parse()
+
+	level, ok := logger.LogLevelTokenToLevel(*logLevel)
+	if !ok {
+		fmt.Printf("#e2tsim.main - invalid log level %s\n", *logLevel)
+		os.Exit(1)
+	}
+	logger, err := logger.InitLogger(level)
+	if err != nil {
+		fmt.Printf("#e2tsim.main - failed to initialize logger, error: %s\n", err)
+		os.Exit(1)
+	}
+
+	var script *e2tsim.Script
+	if *scriptPath != "" {
+		script, err = e2tsim.LoadScript(*scriptPath)
+		if err != nil {
+			logger.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	if *address == "" {
+		*address = "localhost:" + strconv.Itoa(*rmrPort)
+	}
+
+	var transport e2nodesim.Transport
+	var harness *integration.Harness
+
+	switch *mode {
+	case modeRmr:
+		var msgImpl *rmrCgo.Context
+		transport = e2nodesim.NewMessengerTransport(logger, msgImpl.Init("tcp:"+strconv.Itoa(*rmrPort), *maxMsgSize, 0, logger))
+	case modeLocal:
+		config := integration.DefaultConfiguration()
+		config.Logging.LogLevel = *logLevel
+		harness, err = integration.New(config)
+		if err != nil {
+			logger.Errorf("#e2tsim.main - failed to start the in-process E2 Manager, error: %s", err)
+			os.Exit(1)
+		}
+		defer harness.Close()
+		transport = e2nodesim.NewMessengerTransport(logger, harness.E2T)
+		go harness.KeepAliveWorker.Execute()
+	default:
+		logger.Errorf("#e2tsim.main - unknown mode %s", *mode)
+		os.Exit(1)
+	}
+	defer transport.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		logger.Infof("#e2tsim.main - interrupted, stopping")
+		cancel()
+	}()
+
+	e2t := e2tsim.New(logger, transport, *address, *podName)
+	defer e2t.Close()
+	e2t.SetKeepAlive(*keepAliveDelay, false)
+
+	if script == nil {
+		err = e2t.Init()
+		if err == nil {
+			<-ctx.Done()
+		}
+	} else {
+		err = e2t.Run(ctx, script)
+	}
+
+	stats, _ := json.Marshal(e2t.Stats())
+	logger.Infof("#e2tsim.main - stats: %s, connected RANs: %s", stats, e2t.Rans())
+	if harness != nil {
+		addresses, _ := harness.RnibDataService.GetE2TAddresses(context.Background())
+		logger.Infof("#e2tsim.main - E2T instances known to E2 Manager: %s", addresses)
+	}
+
+	if err != nil && err != context.Canceled {
+		logger.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
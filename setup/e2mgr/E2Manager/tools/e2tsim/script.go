@@ -0,0 +1,249 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package e2tsim
+
+import (
+	"bufio"
+	"context"
+	"e2mgr/rmrtypes"
+	"e2mgr/tools/e2nodesim"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	CommandInit              = "init"
+	CommandDie               = "die"
+	CommandRestart           = "restart"
+	CommandKeepAlive         = "keepalive"
+	CommandSetup             = "setup"
+	CommandConnectionFailure = "connection-failure"
+	CommandSleep             = "sleep"
+	CommandExpect            = "expect"
+
+	defaultPlmnId = "131014"
+)
+
+// The messages of E2 Manager an expect command can wait for
+var expectableMessages = map[string]int{
+	"E2_TERM_KEEP_ALIVE_REQ": rmrtypes.E2_TERM_KEEP_ALIVE_REQ,
+	"RIC_E2_SETUP_RESP":      rmrtypes.RIC_E2_SETUP_RESP,
+	"RIC_E2_SETUP_FAILURE":   rmrtypes.RIC_E2_SETUP_FAILURE,
+	"RIC_SCTP_CLEAR_ALL":     rmrtypes.RIC_SCTP_CLEAR_ALL,
+}
+
+// Command is a line of a script
+type Command struct {
+	Line int
+	Name string
+	Args []string
+}
+
+func (c Command) String() string {
+	return strings.TrimSpace(c.Name + " " + strings.Join(c.Args, " "))
+}
+
+// Script is a list of commands, one per line, run in order. Blank lines and lines starting with # are ignored:
+//
+//	init                                   announce the E2T with RIC_E2_TERM_INIT
+//	keepalive answer [<delay>]             answer keep alive requests, after delay
+//	keepalive silent                       stop answering keep alive requests
+//	setup <ranName> [<type> [<plmnId>]]    forward an E2 Setup Request of a gnb, en-gnb, ng-enb or enb node
+//	connection-failure <ranName>...|all    report the SCTP associations of RANs lost
+//	die                                    stop answering anything and lose every association
+//	restart                                come back after die and announce the E2T again
+//	sleep <duration>                       wait
+//	expect <message> <timeout>             fail unless E2 Manager sends the message within timeout
+type Script struct {
+	Commands []Command
+}
+
+func LoadScript(path string) (*Script, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("#e2tsim.LoadScript - failed to open script %s, error: %s", path, err)
+	}
+	defer file.Close()
+
+	return ParseScript(file)
+}
+
+func ParseScript(reader io.Reader) (*Script, error) {
+	script := &Script{}
+	scanner := bufio.NewScanner(reader)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		command := Command{Line: line, Name: fields[0], Args: fields[1:]}
+		err := validate(command)
+		if err != nil {
+			return nil, fmt.Errorf("#e2tsim.ParseScript - line %d: %s", line, err)
+		}
+		script.Commands = append(script.Commands, command)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("#e2tsim.ParseScript - failed to read script, error: %s", err)
+	}
+	return script, nil
+}
+
+func validate(command Command) error {
+	args := command.Args
+
+	switch command.Name {
+	case CommandInit, CommandDie, CommandRestart:
+		return argCount(command, 0, 0)
+	case CommandKeepAlive:
+		if err := argCount(command, 1, 2); err != nil {
+			return err
+		}
+		switch args[0] {
+		case "answer":
+			if len(args) == 2 {
+				_, err := time.ParseDuration(args[1])
+				return err
+			}
+			return nil
+		case "silent":
+			return argCount(command, 1, 1)
+		}
+		return fmt.Errorf("keepalive mode must be answer or silent, got '%s'", args[0])
+	case CommandSetup:
+		if err := argCount(command, 1, 3); err != nil {
+			return err
+		}
+		if len(args) > 1 {
+			_, err := e2nodesim.NewNode(args[0], args[1], defaultPlmnId, 0)
+			return err
+		}
+		return nil
+	case CommandConnectionFailure:
+		return argCount(command, 1, -1)
+	case CommandSleep:
+		if err := argCount(command, 1, 1); err != nil {
+			return err
+		}
+		_, err := time.ParseDuration(args[0])
+		return err
+	case CommandExpect:
+		if err := argCount(command, 2, 2); err != nil {
+			return err
+		}
+		if _, ok := expectableMessages[args[0]]; !ok {
+			return fmt.Errorf("unknown message '%s'", args[0])
+		}
+		_, err := time.ParseDuration(args[1])
+		return err
+	}
+	return fmt.Errorf("unknown command '%s'", command.Name)
+}
+
+// argCount checks command has between min and max arguments, max < 0 meaning no upper bound
+func argCount(command Command, min int, max int) error {
+	count := len(command.Args)
+	if count < min || (max >= 0 && count > max) {
+		return fmt.Errorf("wrong number of arguments for %s: %d", command.Name, count)
+	}
+	return nil
+}
+
+// Run plays script on e. It stops at the first command that fails, or when ctx is done
+func (e *E2T) Run(ctx context.Context, script *Script) error {
+	var nbId uint64
+
+	for _, command := range script.Commands {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		e.logger.Infof("#E2T.Run - E2T address: %s - line %d: %s", e.address, command.Line, command)
+
+		err := e.runCommand(ctx, command, &nbId)
+		if err != nil {
+			return fmt.Errorf("#E2T.Run - line %d: %s: %s", command.Line, command, err)
+		}
+	}
+	return nil
+}
+
+func (e *E2T) runCommand(ctx context.Context, command Command, nbId *uint64) error {
+	args := command.Args
+
+	switch command.Name {
+	case CommandInit:
+		return e.Init()
+	case CommandDie:
+		e.Die()
+	case CommandRestart:
+		return e.Restart()
+	case CommandKeepAlive:
+		var delay time.Duration
+		if len(args) == 2 {
+			delay, _ = time.ParseDuration(args[1])
+		}
+		e.SetKeepAlive(delay, args[0] == "silent")
+	case CommandSetup:
+		nodeType, plmnId := e2nodesim.NodeTypeGnb, defaultPlmnId
+		if len(args) > 1 {
+			nodeType = args[1]
+		}
+		if len(args) > 2 {
+			plmnId = args[2]
+		}
+		*nbId++
+		node, err := e2nodesim.NewNode(args[0], nodeType, plmnId, *nbId)
+		if err != nil {
+			return err
+		}
+		return e.Setup(node)
+	case CommandConnectionFailure:
+		rans := args
+		if len(args) == 1 && args[0] == "all" {
+			rans = e.Rans()
+		}
+		for _, ranName := range rans {
+			if err := e.ConnectionFailure(ranName); err != nil {
+				return err
+			}
+		}
+	case CommandSleep:
+		duration, _ := time.ParseDuration(args[0])
+		select {
+		case <-time.After(duration):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case CommandExpect:
+		timeout, _ := time.ParseDuration(args[1])
+		_, err := e.Expect(expectableMessages[args[0]], timeout)
+		return err
+	}
+	return nil
+}
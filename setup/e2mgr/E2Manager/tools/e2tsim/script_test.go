@@ -0,0 +1,97 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2tsim
+
+import (
+	"context"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLoadExampleScript(t *testing.T) {
+	script, err := LoadScript("resources/death_and_restart.script")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Command{Line: 5, Name: CommandInit, Args: []string{}}, script.Commands[0])
+	assert.Equal(t, "setup enb_734_733_00000001 enb 6359AB", script.Commands[3].String())
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	scripts := map[string]string{
+		"unknown command":        "reboot",
+		"init with arguments":    "init now",
+		"keepalive mode":         "keepalive sometimes",
+		"keepalive delay":        "keepalive answer soon",
+		"silent with delay":      "keepalive silent 1s",
+		"setup without ran":      "setup",
+		"setup unknown type":     "setup ran_1 nr",
+		"failure without ran":    "connection-failure",
+		"sleep duration":         "sleep 5",
+		"expect unknown message": "expect RIC_X2_SETUP_REQ 1s",
+		"expect without timeout": "expect RIC_SCTP_CLEAR_ALL",
+	}
+
+	for name, text := range scripts {
+		_, err := ParseScript(strings.NewReader("# comment\n\ninit\n" + text + "\n"))
+		if assert.NotNil(t, err, name) {
+			assert.Contains(t, err.Error(), "line 4", name)
+		}
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	h, e2t := startE2T(t)
+	defer stop(h, e2t)
+
+	script, err := ParseScript(strings.NewReader(`
+setup gnb_1
+setup enb_1 enb 6359AB
+expect RIC_E2_SETUP_RESP 2s
+expect RIC_E2_SETUP_RESP 2s
+connection-failure all
+sleep 10ms
+`))
+	assert.Nil(t, err)
+
+	assert.Nil(t, e2t.Run(context.Background(), script))
+	assert.Nil(t, h.Settle(timeout))
+
+	assert.Empty(t, e2t.Rans())
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, connectionStatus(h, "gnb_1"))
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, connectionStatus(h, "enb_1"))
+	assert.Equal(t, 2, e2t.Stats().ConnectionFailures)
+}
+
+func TestRunScriptFailedExpectation(t *testing.T) {
+	h, e2t := startE2T(t)
+	defer stop(h, e2t)
+
+	script, _ := ParseScript(strings.NewReader("keepalive answer 1ms\nexpect RIC_SCTP_CLEAR_ALL 50ms\ndie\n"))
+
+	err := e2t.Run(context.Background(), script)
+
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "line 2: expect RIC_SCTP_CLEAR_ALL 50ms")
+	}
+	assert.Equal(t, 1, e2t.Stats().Inits)
+	assert.Equal(t, 0, e2t.Stats().Dropped)
+}
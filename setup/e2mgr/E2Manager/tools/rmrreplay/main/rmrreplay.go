@@ -0,0 +1,185 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+// rmrreplay feeds the messages of an RMR capture written by E2 Manager back into an E2 Manager, through RMR or into an
+// in-process one
+package main
+
+import (
+	"context"
+	"e2mgr/logger"
+	"e2mgr/rmrCgo"
+	"e2mgr/rmrcapture"
+	"e2mgr/rmrtypes"
+	"e2mgr/tests/integration"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	modeRmr   = "rmr"
+	modeLocal = "local"
+
+	settleTimeout = 10 * time.Second
+)
+
+func main() {
+	capturePath := flag.String("capture", "", "capture file to replay")
+	speed := flag.Float64("speed", 1, "replay speed, 1 keeps the original intervals, 2 halves them, 0 sends without waiting")
+	ranNames := flag.String("ran", "", "comma separated RAN names to replay, all if empty")
+	mTypes := flag.String("mtype", "", "comma separated message types to replay, all if empty")
+	direction := flag.String("direction", string(rmrcapture.Received), "received: the messages E2 Manager was sent, sent: the messages it sent")
+	list := flag.Bool("list", false, "print the messages selected instead of replaying them")
+	mode := flag.String("mode", modeRmr, "rmr: send to E2 Manager through RMR, local: replay into an in-process E2 Manager")
+	rmrPort := flag.Int("rmr-port", 38000, "RMR port to listen on in rmr mode")
+	maxMsgSize := flag.Int("rmr-max-msg-size", 65536, "RMR maximum message size")
+	logLevel := flag.String("log-level", "info", "log level")
+	flag.Parse()
+
+	level, ok := logger.LogLevelTokenToLevel(*logLevel)
+	if !ok {
+		fmt.Printf("#rmrreplay.main - invalid log level %s\n", *logLevel)
+		os.Exit(1)
+	}
+	logger, err := logger.InitLogger(level)
+	if err != nil {
+		fmt.Printf("#rmrreplay.main - failed to initialize logger, error: %s\n", err)
+		os.Exit(1)
+	}
+
+	filter, err := parseFilter(*ranNames, *mTypes, *direction)
+	if err != nil {
+		logger.Errorf("#rmrreplay.main - %s", err)
+		os.Exit(1)
+	}
+	if *speed < 0 {
+		logger.Errorf("#rmrreplay.main - speed must not be negative, got %g", *speed)
+		os.Exit(1)
+	}
+
+	records, err := rmrcapture.ReadFile(*capturePath)
+	if err != nil {
+		logger.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if *list {
+		printRecords(records, filter)
+		return
+	}
+
+	var messenger rmrtypes.RmrMessenger
+	var harness *integration.Harness
+
+	switch *mode {
+	case modeRmr:
+		var msgImpl *rmrCgo.Context
+		messenger = msgImpl.Init("tcp:"+strconv.Itoa(*rmrPort), *maxMsgSize, 0, logger)
+	case modeLocal:
+		config := integration.DefaultConfiguration()
+		config.Logging.LogLevel = *logLevel
+		harness, err = integration.New(config)
+		if err != nil {
+			logger.Errorf("#rmrreplay.main - failed to start the in-process E2 Manager, error: %s", err)
+			os.Exit(1)
+		}
+		defer harness.Close()
+		messenger = harness.E2T
+	default:
+		logger.Errorf("#rmrreplay.main - unknown mode %s", *mode)
+		os.Exit(1)
+	}
+	defer messenger.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		logger.Infof("#rmrreplay.main - interrupted, stopping")
+		cancel()
+	}()
+
+	stats, err := rmrcapture.NewReplayer(logger, messenger).Replay(ctx, records, filter, *speed)
+
+	output, _ := json.Marshal(stats)
+	logger.Infof("#rmrreplay.main - stats: %s", output)
+	if harness != nil {
+		_ = harness.Settle(settleTimeout)
+		nodebIds, _ := harness.RnibDataService.GetListNodebIds(context.Background())
+		for _, nodebId := range nodebIds {
+			nodeb, err := harness.RnibDataService.GetNodeb(context.Background(), nodebId.InventoryName)
+			if err == nil {
+				logger.Infof("#rmrreplay.main - RAN name: %s, connection status: %s", nodeb.RanName, nodeb.ConnectionStatus)
+			}
+		}
+	}
+
+	if err != nil && err != context.Canceled {
+		logger.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if stats.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func parseFilter(ranNames string, mTypes string, direction string) (rmrcapture.Filter, error) {
+	filter := rmrcapture.Filter{Direction: rmrcapture.Direction(direction)}
+
+	if filter.Direction != rmrcapture.Received && filter.Direction != rmrcapture.Sent {
+		return filter, fmt.Errorf("direction must be received or sent, got %s", direction)
+	}
+	filter.RanNames = splitList(ranNames)
+	for _, mType := range splitList(mTypes) {
+		value, err := strconv.Atoi(mType)
+		if err != nil {
+			return filter, fmt.Errorf("invalid message type %s", mType)
+		}
+		filter.MTypes = append(filter.MTypes, value)
+	}
+	return filter, nil
+}
+
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func printRecords(records []rmrcapture.Record, filter rmrcapture.Filter) {
+	for _, record := range records {
+		if filter.Match(record) {
+			fmt.Printf("%s %-8s %5d meid=%q xid=%q source=%q len=%d\n", record.Timestamp.Format(time.RFC3339Nano), record.Direction,
+				record.MType, record.Meid, record.TransactionId, record.Source, len(record.Payload))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package tracing
+
+import (
+	"context"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	incomingTraceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingSpanId  = "00f067aa0ba902b7"
+)
+
+func TestHttpMiddlewareContinuesIncomingTrace(t *testing.T) {
+	buf := initTestTracing(t)
+	router := mux.NewRouter()
+	router.Use(HttpMiddleware)
+	router.HandleFunc("/v1/nodeb/{ranName}", func(writer http.ResponseWriter, request *http.Request) {
+		assert.True(t, trace.SpanContextFromContext(request.Context()).IsValid())
+		writer.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/nodeb/test", nil)
+	req.Header.Set("traceparent", "00-"+incomingTraceId+"-"+incomingSpanId+"-01")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	spans := readSpans(t, buf)
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "HTTP GET /v1/nodeb/{ranName}", spans[0].Name)
+	assert.Equal(t, incomingTraceId, spans[0].SpanContext.TraceID)
+	assert.Equal(t, incomingSpanId, spans[0].Parent.SpanID)
+	assert.Equal(t, "test", spans[0].attribute(string(RanNameKey)))
+	assert.Equal(t, float64(http.StatusNotFound), spans[0].attribute("http.response.status_code"))
+}
+
+func TestHttpMiddlewareServerErrorSetsErrorStatus(t *testing.T) {
+	buf := initTestTracing(t)
+	router := mux.NewRouter()
+	router.Use(HttpMiddleware)
+	router.HandleFunc("/v1/health", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/health", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := readSpans(t, buf)
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "Error", spans[0].Status.Code)
+	assert.Equal(t, "0000000000000000", spans[0].Parent.SpanID)
+}
+
+func TestInjectHeaders(t *testing.T) {
+	initTestTracing(t)
+	ctx, span := StartSpan(context.Background(), "RoutingManagerClient POST", trace.SpanKindClient)
+	defer span.End()
+
+	header := http.Header{}
+	InjectHeaders(ctx, header)
+
+	expected := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	assert.Equal(t, expected, header.Get("traceparent"))
+}
+
+func TestInjectHeadersWithoutSpan(t *testing.T) {
+	initTestTracing(t)
+	header := http.Header{}
+	InjectHeaders(context.Background(), header)
+	assert.Empty(t, header.Get("traceparent"))
+}
@@ -0,0 +1,122 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RMR carries no trace headers. A message sent while handling a request is parented through the context passed to
+// StartRmrSendSpan; across RMR the only correlation is the transaction id: the span that sent a request is remembered
+// for a while and the span receiving the response on the same transaction links to it.
+const transactionTtl = 2 * time.Minute
+
+type transactionEntry struct {
+	spanContext trace.SpanContext
+	expiresAt   time.Time
+}
+
+type transactionRegistry struct {
+	mutex     sync.Mutex
+	entries   map[string]transactionEntry
+	lastSweep time.Time
+}
+
+var transactions = &transactionRegistry{entries: map[string]transactionEntry{}}
+
+func (r *transactionRegistry) put(transactionId string, spanContext trace.SpanContext) {
+	if transactionId == "" || !spanContext.IsValid() {
+		return
+	}
+
+	now := time.Now()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if now.Sub(r.lastSweep) > transactionTtl {
+		for id, entry := range r.entries {
+			if now.After(entry.expiresAt) {
+				delete(r.entries, id)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	r.entries[transactionId] = transactionEntry{spanContext: spanContext, expiresAt: now.Add(transactionTtl)}
+}
+
+func (r *transactionRegistry) get(transactionId string) (trace.SpanContext, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, ok := r.entries[transactionId]
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return trace.SpanContext{}, false
+	}
+
+	return entry.spanContext, true
+}
+
+// NormalizeTransactionId strips the padding RMR leaves around the fixed-size transaction id buffer.
+func NormalizeTransactionId(transactionId []byte) string {
+	return strings.TrimSpace(strings.TrimRight(string(transactionId), "\x00"))
+}
+
+func rmrAttributes(msgType int, ranName string, transactionId string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		RmrMessageTypeKey.Int(msgType),
+		RanNameKey.String(ranName),
+		RmrTransactionIdKey.String(transactionId),
+	}
+}
+
+// StartRmrReceiveSpan opens a consumer span for an incoming RMR message, linked to the span that sent the request
+// on the same transaction (if known). The returned context is to be passed down to whatever handles the message.
+func StartRmrReceiveSpan(handler string, msgType int, ranName string, transactionId []byte) (context.Context, trace.Span) {
+	xAction := NormalizeTransactionId(transactionId)
+	options := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(rmrAttributes(msgType, ranName, xAction)...),
+		trace.WithAttributes(RmrHandlerKey.String(handler)),
+	}
+
+	if requestSpanContext, ok := transactions.get(xAction); ok {
+		options = append(options, trace.WithLinks(trace.Link{SpanContext: requestSpanContext}))
+	}
+
+	return Tracer().Start(context.Background(), fmt.Sprintf("RMR receive %d", msgType), options...)
+}
+
+// StartRmrSendSpan opens a producer span for an outgoing RMR message as a child of the span in ctx (e.g. the span
+// receiving the request a setup response answers).
+func StartRmrSendSpan(ctx context.Context, msgType int, ranName string, transactionId []byte) (context.Context, trace.Span) {
+	xAction := NormalizeTransactionId(transactionId)
+	ctx, span := StartSpan(ctx, fmt.Sprintf("RMR send %d", msgType), trace.SpanKindProducer, rmrAttributes(msgType, ranName, xAction)...)
+	transactions.put(xAction, span.SpanContext())
+	return ctx, span
+}
@@ -0,0 +1,123 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package tracing
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const (
+	E2SetupRequest  = 12001
+	E2SetupResponse = 12002
+	X2SetupRequest  = 10060
+	X2SetupResponse = 10061
+)
+
+func paddedTransactionId(xAction string) []byte {
+	buf := make([]byte, 32)
+	copy(buf, xAction)
+	return buf
+}
+
+func TestNormalizeTransactionId(t *testing.T) {
+	assert.Equal(t, "abc", NormalizeTransactionId(paddedTransactionId("abc")))
+	assert.Equal(t, "abc", NormalizeTransactionId([]byte(" abc ")))
+	assert.Equal(t, "", NormalizeTransactionId(nil))
+}
+
+func TestRmrResponseSpanIsChildOfReceiveSpan(t *testing.T) {
+	buf := initTestTracing(t)
+
+	ctx, receiveSpan := StartRmrReceiveSpan("E2SetupRequestNotificationHandler", E2SetupRequest, "gnb:208-092-303030", paddedTransactionId("e2setup-1"))
+	_, sendSpan := StartRmrSendSpan(ctx, E2SetupResponse, "gnb:208-092-303030", []byte("e2setup-1"))
+	sendSpan.End()
+	receiveSpan.End()
+
+	spans := readSpans(t, buf)
+	assert.Len(t, spans, 2)
+	send, receive := spans[0], spans[1]
+	assert.Equal(t, "RMR send 12002", send.Name)
+	assert.Equal(t, "RMR receive 12001", receive.Name)
+	assert.Equal(t, receive.SpanContext.TraceID, send.SpanContext.TraceID)
+	assert.Equal(t, receive.SpanContext.SpanID, send.Parent.SpanID)
+	assert.Equal(t, "e2setup-1", send.attribute(string(RmrTransactionIdKey)))
+	assert.Equal(t, "E2SetupRequestNotificationHandler", receive.attribute(string(RmrHandlerKey)))
+}
+
+func TestRmrReceiveSpanLinksToRequestSpan(t *testing.T) {
+	buf := initTestTracing(t)
+
+	_, sendSpan := StartRmrSendSpan(context.Background(), X2SetupRequest, "test", paddedTransactionId("x2setup-1"))
+	sendSpan.End()
+	_, receiveSpan := StartRmrReceiveSpan("SetupResponseNotificationHandler", X2SetupResponse, "test", paddedTransactionId("x2setup-1"))
+	receiveSpan.End()
+
+	spans := readSpans(t, buf)
+	assert.Len(t, spans, 2)
+	send, receive := spans[0], spans[1]
+	assert.Len(t, receive.Links, 1)
+	assert.Equal(t, send.SpanContext.SpanID, receive.Links[0].SpanContext.SpanID)
+	assert.Equal(t, send.SpanContext.TraceID, receive.Links[0].SpanContext.TraceID)
+}
+
+func TestRmrReceiveSpanDoesNotReplaceRequestSpan(t *testing.T) {
+	buf := initTestTracing(t)
+
+	_, sendSpan := StartRmrSendSpan(context.Background(), X2SetupRequest, "test", paddedTransactionId("x2setup-4"))
+	sendSpan.End()
+	_, firstReceiveSpan := StartRmrReceiveSpan("SetupResponseNotificationHandler", X2SetupResponse, "test", paddedTransactionId("x2setup-4"))
+	firstReceiveSpan.End()
+	_, secondReceiveSpan := StartRmrReceiveSpan("SetupResponseNotificationHandler", X2SetupResponse, "test", paddedTransactionId("x2setup-4"))
+	secondReceiveSpan.End()
+
+	spans := readSpans(t, buf)
+	assert.Len(t, spans, 3)
+	assert.Len(t, spans[2].Links, 1)
+	assert.Equal(t, spans[0].SpanContext.SpanID, spans[2].Links[0].SpanContext.SpanID)
+}
+
+func TestRmrSendSpanWithoutParentStartsNewTrace(t *testing.T) {
+	buf := initTestTracing(t)
+
+	_, receiveSpan := StartRmrReceiveSpan("E2SetupRequestNotificationHandler", E2SetupRequest, "test", paddedTransactionId("e2setup-2"))
+	_, sendSpan := StartRmrSendSpan(context.Background(), E2SetupResponse, "test", paddedTransactionId("e2setup-2"))
+	sendSpan.End()
+	receiveSpan.End()
+
+	spans := readSpans(t, buf)
+	assert.Len(t, spans, 2)
+	assert.NotEqual(t, spans[1].SpanContext.TraceID, spans[0].SpanContext.TraceID)
+}
+
+func TestRmrSpansOfUnrelatedTransactionsAreNotCorrelated(t *testing.T) {
+	buf := initTestTracing(t)
+
+	_, sendSpan := StartRmrSendSpan(context.Background(), X2SetupRequest, "test", paddedTransactionId("x2setup-2"))
+	sendSpan.End()
+	_, receiveSpan := StartRmrReceiveSpan("SetupResponseNotificationHandler", X2SetupResponse, "test", paddedTransactionId("x2setup-3"))
+	receiveSpan.End()
+
+	spans := readSpans(t, buf)
+	assert.Len(t, spans, 2)
+	assert.Empty(t, spans[1].Links)
+	assert.NotEqual(t, spans[0].SpanContext.TraceID, spans[1].SpanContext.TraceID)
+}
@@ -0,0 +1,140 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package tracing
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"os"
+)
+
+const (
+	TracerName         = "e2mgr"
+	DefaultServiceName = "e2mgr"
+
+	OtlpExporter   = "otlp"
+	StdoutExporter = "stdout"
+)
+
+const (
+	RanNameKey          = attribute.Key("ran.name")
+	E2TAddressKey       = attribute.Key("e2t.address")
+	RmrMessageTypeKey   = attribute.Key("rmr.message_type")
+	RmrTransactionIdKey = attribute.Key("rmr.transaction_id")
+	RmrHandlerKey       = attribute.Key("rmr.handler")
+	RnibOperationKey    = attribute.Key("rnib.operation")
+)
+
+type ShutdownFunc func(ctx context.Context) error
+
+// InitTracerProvider installs the global tracer provider and W3C propagators according to the tracing configuration.
+// When tracing is disabled the no-op provider is kept, so instrumented code pays (almost) nothing.
+func InitTracerProvider(logger *logger.Logger, config *configuration.Configuration) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !config.Tracing.Enabled {
+		logger.Infof("#tracing.InitTracerProvider - tracing is disabled")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(config, os.Stdout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tp := newTracerProvider(config, sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+	logger.Infof("#tracing.InitTracerProvider - tracing is enabled. exporter: %s", config.Tracing.Exporter)
+	return tp.Shutdown, nil
+}
+
+// NewStdoutTracerProvider returns a provider that synchronously writes every span as JSON to w. Intended for tests.
+func NewStdoutTracerProvider(w io.Writer) (*sdktrace.TracerProvider, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+
+	if err != nil {
+		return nil, err
+	}
+
+	config := &configuration.Configuration{}
+	config.Tracing.SampleRatio = 1
+	return newTracerProvider(config, sdktrace.WithSyncer(exporter)), nil
+}
+
+func newExporter(config *configuration.Configuration, stdout io.Writer) (sdktrace.SpanExporter, error) {
+	switch config.Tracing.Exporter {
+	case OtlpExporter:
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Tracing.OtlpEndpoint)}
+		if config.Tracing.OtlpInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(context.Background(), options...)
+	case StdoutExporter:
+		return stdouttrace.New(stdouttrace.WithWriter(stdout))
+	}
+
+	return nil, fmt.Errorf("#tracing.newExporter - unknown exporter: %q", config.Tracing.Exporter)
+}
+
+func newTracerProvider(config *configuration.Configuration, exporterOption sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	serviceName := config.Tracing.ServiceName
+
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+
+	return sdktrace.NewTracerProvider(
+		exporterOption,
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Tracing.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan starts a root-or-child span of the given kind on the E2 Manager tracer.
+func StartSpan(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// EndSpan records err (if any) on the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,157 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"io"
+	"testing"
+)
+
+type exportedSpanContext struct {
+	TraceID string
+	SpanID  string
+}
+
+type exportedSpan struct {
+	Name        string
+	SpanContext exportedSpanContext
+	Parent      exportedSpanContext
+	Links       []struct {
+		SpanContext exportedSpanContext
+	}
+	Attributes []struct {
+		Key   string
+		Value struct {
+			Type  string
+			Value interface{}
+		}
+	}
+	Status struct {
+		Code string
+	}
+}
+
+func (s exportedSpan) attribute(key string) interface{} {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value.Value
+		}
+	}
+	return nil
+}
+
+func initTestTracing(t *testing.T) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	tp, err := NewStdoutTracerProvider(buf)
+	if err != nil {
+		t.Fatalf("#initTestTracing - failed creating tracer provider: %s", err)
+	}
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+	return buf
+}
+
+func readSpans(t *testing.T, buf *bytes.Buffer) []exportedSpan {
+	var spans []exportedSpan
+	decoder := json.NewDecoder(buf)
+	for {
+		var span exportedSpan
+		err := decoder.Decode(&span)
+		if err == io.EOF {
+			return spans
+		}
+		if err != nil {
+			t.Fatalf("#readSpans - failed decoding exported span: %s", err)
+		}
+		spans = append(spans, span)
+	}
+}
+
+func initLog(t *testing.T) *logger.Logger {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#tests.initLog - failed to initialize logger, error: %s", err)
+	}
+	return log
+}
+
+func TestInitTracerProviderDisabled(t *testing.T) {
+	config := &configuration.Configuration{}
+	shutdown, err := InitTracerProvider(initLog(t), config)
+	assert.Nil(t, err)
+	assert.Nil(t, shutdown(context.Background()))
+}
+
+func TestInitTracerProviderStdoutExporter(t *testing.T) {
+	config := &configuration.Configuration{}
+	config.Tracing.Enabled = true
+	config.Tracing.Exporter = StdoutExporter
+	config.Tracing.SampleRatio = 1
+	shutdown, err := InitTracerProvider(initLog(t), config)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	assert.Nil(t, err)
+	assert.Nil(t, shutdown(context.Background()))
+}
+
+func TestInitTracerProviderOtlpExporter(t *testing.T) {
+	config := &configuration.Configuration{}
+	config.Tracing.Enabled = true
+	config.Tracing.Exporter = OtlpExporter
+	config.Tracing.OtlpEndpoint = "localhost:4318"
+	config.Tracing.OtlpInsecure = true
+	_, err := InitTracerProvider(initLog(t), config)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	assert.Nil(t, err)
+}
+
+func TestInitTracerProviderUnknownExporter(t *testing.T) {
+	config := &configuration.Configuration{}
+	config.Tracing.Enabled = true
+	config.Tracing.Exporter = "jaeger"
+	_, err := InitTracerProvider(initLog(t), config)
+	assert.NotNil(t, err)
+}
+
+func TestEndSpanRecordsError(t *testing.T) {
+	buf := initTestTracing(t)
+	_, span := StartSpan(context.Background(), "RnibDataService.GetNodeb", trace.SpanKindClient, RanNameKey.String("test"))
+	EndSpan(span, errors.New("error"))
+
+	spans := readSpans(t, buf)
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "RnibDataService.GetNodeb", spans[0].Name)
+	assert.Equal(t, "Error", spans[0].Status.Code)
+	assert.Equal(t, "test", spans[0].attribute(string(RanNameKey)))
+}
@@ -29,7 +29,8 @@ type Configuration struct {
 	Http struct {
 		Port int
 	}
-
+	// Pods are the names of the pods running at start, keyed by namespace
+	Pods map[string][]string
 }
 
 func ParseConfiguration() *Configuration{
@@ -46,6 +47,7 @@ func ParseConfiguration() *Configuration{
 
 	config := Configuration{}
 	config.fillHttpConfig(viper.Sub("http"))
+	config.fillPodsConfig(viper.Sub("pods"))
 	return &config
 }
 
@@ -55,3 +57,15 @@ func (c *Configuration)fillHttpConfig(httpConfig *viper.Viper) {
 	}
 	c.Http.Port = httpConfig.GetInt("port")
 }
+
+// fillPodsConfig reads the optional pods entry
+func (c *Configuration) fillPodsConfig(podsConfig *viper.Viper) {
+	c.Pods = map[string][]string{}
+	if podsConfig == nil {
+		return
+	}
+
+	for _, namespace := range podsConfig.AllKeys() {
+		c.Pods[namespace] = podsConfig.GetStringSlice(namespace)
+	}
+}
@@ -31,6 +31,7 @@ import (
 func TestParseConfigurationSuccess(t *testing.T) {
 	config := ParseConfiguration()
 	assert.Equal(t, 59009, config.Http.Port)
+	assert.Equal(t, []string{"deployment-ricplt-e2term-alpha"}, config.Pods["ricplt"])
 }
 
 func TestParseConfigurationFileNotFoundFailure(t *testing.T) {
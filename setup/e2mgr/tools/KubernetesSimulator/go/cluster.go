@@ -0,0 +1,125 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cluster keeps the pods of every namespace and the pods deleted so far
+type Cluster struct {
+	mu          sync.Mutex
+	initialPods map[string][]string
+	namespaces  map[string]map[string]Pod
+	deletions   []Deletion
+}
+
+// NewCluster returns a cluster running initialPods, keyed by namespace
+func NewCluster(initialPods map[string][]string) *Cluster {
+	c := &Cluster{initialPods: initialPods}
+	c.Reset()
+	return c
+}
+
+// CreatePod adds a running pod, failing if the namespace already has one with the same name
+func (c *Cluster) CreatePod(namespace string, name string) (Pod, error) {
+	if name == "" {
+		return Pod{}, fmt.Errorf("pod name is missing")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.namespaces[namespace][name]; ok {
+		return Pod{}, fmt.Errorf("pods \"%s\" already exists", name)
+	}
+	return c.create(namespace, name), nil
+}
+
+// ListPods returns the pods of namespace, sorted by name
+func (c *Cluster) ListPods(namespace string) []Pod {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pods := []Pod{}
+	for _, pod := range c.namespaces[namespace] {
+		pods = append(pods, pod)
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Metadata.Name < pods[j].Metadata.Name })
+	return pods
+}
+
+func (c *Cluster) GetPod(namespace string, name string) (Pod, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pod, ok := c.namespaces[namespace][name]
+	return pod, ok
+}
+
+// DeletePod removes a pod and records the deletion. It returns false if the namespace has no such pod
+func (c *Cluster) DeletePod(namespace string, name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.namespaces[namespace][name]; !ok {
+		return false
+	}
+	delete(c.namespaces[namespace], name)
+	c.deletions = append(c.deletions, Deletion{Namespace: namespace, Pod: name, Time: time.Now().UTC()})
+	return true
+}
+
+// Deletions returns the pods deleted since the start or the last reset, in order
+func (c *Cluster) Deletions() []Deletion {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deletions := make([]Deletion, len(c.deletions))
+	copy(deletions, c.deletions)
+	return deletions
+}
+
+// Reset brings back the initial pods and forgets the deletions
+func (c *Cluster) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.namespaces = map[string]map[string]Pod{}
+	c.deletions = []Deletion{}
+	for namespace, names := range c.initialPods {
+		for _, name := range names {
+			c.create(namespace, name)
+		}
+	}
+}
+
+func (c *Cluster) create(namespace string, name string) Pod {
+	if _, ok := c.namespaces[namespace]; !ok {
+		c.namespaces[namespace] = map[string]Pod{}
+	}
+	pod := NewPod(namespace, name)
+	c.namespaces[namespace][name] = pod
+	return pod
+}
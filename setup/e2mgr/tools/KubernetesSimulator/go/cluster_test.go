@@ -0,0 +1,84 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package kubernetes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func podNames(pods []Pod) []string {
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Metadata.Name)
+	}
+	return names
+}
+
+func TestNewClusterRunsInitialPods(t *testing.T) {
+	cluster := NewCluster(map[string][]string{"ricplt": {"e2term-b", "e2term-a"}, "ricxapp": {"xapp"}})
+
+	assert.Equal(t, []string{"e2term-a", "e2term-b"}, podNames(cluster.ListPods("ricplt")))
+	assert.Equal(t, []string{"xapp"}, podNames(cluster.ListPods("ricxapp")))
+	assert.Empty(t, cluster.ListPods("default"))
+}
+
+func TestCreatePod(t *testing.T) {
+	cluster := NewCluster(nil)
+
+	pod, err := cluster.CreatePod("ricplt", "e2term-a")
+	assert.Nil(t, err)
+	assert.Equal(t, "ricplt", pod.Metadata.Namespace)
+	assert.Equal(t, "Running", pod.Status.Phase)
+
+	_, err = cluster.CreatePod("ricplt", "e2term-a")
+	assert.NotNil(t, err)
+	_, err = cluster.CreatePod("ricplt", "")
+	assert.NotNil(t, err)
+
+	_, ok := cluster.GetPod("ricplt", "e2term-a")
+	assert.True(t, ok)
+}
+
+func TestDeletePodRecordsDeletion(t *testing.T) {
+	cluster := NewCluster(map[string][]string{"ricplt": {"e2term-a", "e2term-b"}})
+
+	assert.True(t, cluster.DeletePod("ricplt", "e2term-a"))
+	assert.False(t, cluster.DeletePod("ricplt", "e2term-a"))
+	assert.False(t, cluster.DeletePod("ricxapp", "e2term-b"))
+
+	assert.Equal(t, []string{"e2term-b"}, podNames(cluster.ListPods("ricplt")))
+	deletions := cluster.Deletions()
+	assert.Len(t, deletions, 1)
+	assert.Equal(t, "ricplt", deletions[0].Namespace)
+	assert.Equal(t, "e2term-a", deletions[0].Pod)
+}
+
+func TestReset(t *testing.T) {
+	cluster := NewCluster(map[string][]string{"ricplt": {"e2term-a"}})
+	cluster.DeletePod("ricplt", "e2term-a")
+	_, _ = cluster.CreatePod("ricplt", "e2term-b")
+
+	cluster.Reset()
+
+	assert.Equal(t, []string{"e2term-a"}, podNames(cluster.ListPods("ricplt")))
+	assert.Empty(t, cluster.Deletions())
+}
@@ -21,10 +21,91 @@
 package kubernetes
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+
+	"github.com/gorilla/mux"
 )
 
-func DeletePod(writer http.ResponseWriter, r *http.Request) {
+// Simulator serves the part of the Kubernetes core v1 API E2 Manager uses to delete E2T pods
+type Simulator struct {
+	Cluster *Cluster
+}
+
+func NewSimulator(cluster *Cluster) *Simulator {
+	return &Simulator{
+		Cluster: cluster,
+	}
+}
+
+func (s *Simulator) ListPods(writer http.ResponseWriter, r *http.Request) {
+	podList := PodList{Kind: "PodList", ApiVersion: "v1", Items: s.Cluster.ListPods(mux.Vars(r)["namespace"])}
+	writeJson(writer, http.StatusOK, podList)
+}
+
+func (s *Simulator) GetPod(writer http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pod, ok := s.Cluster.GetPod(vars["namespace"], vars["pod"])
+	if !ok {
+		writeNotFound(writer, vars["pod"])
+		return
+	}
+	writeJson(writer, http.StatusOK, pod)
+}
+
+// CreatePod starts the pod named by the metadata of the body, e.g. {"metadata": {"name": "e2term-1"}}
+func (s *Simulator) CreatePod(writer http.ResponseWriter, r *http.Request) {
+	request := Pod{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		writeStatus(writer, http.StatusBadRequest, "BadRequest", err.Error(), nil)
+		return
+	}
+
+	pod, err := s.Cluster.CreatePod(mux.Vars(r)["namespace"], request.Metadata.Name)
+	if err != nil {
+		writeStatus(writer, http.StatusConflict, "AlreadyExists", err.Error(), &StatusDetails{Name: request.Metadata.Name, Kind: "pods"})
+		return
+	}
+	writeJson(writer, http.StatusCreated, pod)
+}
+
+func (s *Simulator) DeletePod(writer http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if !s.Cluster.DeletePod(vars["namespace"], vars["pod"]) {
+		writeNotFound(writer, vars["pod"])
+		return
+	}
+	writeJson(writer, http.StatusOK, Status{Kind: "Status", ApiVersion: "v1", Status: "Success", Details: &StatusDetails{Name: vars["pod"], Kind: "pods"}, Code: http.StatusOK})
+}
+
+// GetDeletions returns the pods deleted since the start or the last reset, in order
+func (s *Simulator) GetDeletions(writer http.ResponseWriter, r *http.Request) {
+	writeJson(writer, http.StatusOK, s.Cluster.Deletions())
+}
+
+// Reset brings back the pods of the configuration and forgets the deletions
+func (s *Simulator) Reset(writer http.ResponseWriter, r *http.Request) {
+	s.Cluster.Reset()
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func writeNotFound(writer http.ResponseWriter, podName string) {
+	writeStatus(writer, http.StatusNotFound, "NotFound", fmt.Sprintf("pods \"%s\" not found", podName), &StatusDetails{Name: podName, Kind: "pods"})
+}
+
+func writeStatus(writer http.ResponseWriter, code int, reason string, message string, details *StatusDetails) {
+	writeJson(writer, code, Status{Kind: "Status", ApiVersion: "v1", Status: "Failure", Message: message, Reason: reason, Details: details, Code: code})
+}
+
+func writeJson(writer http.ResponseWriter, code int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	writer.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	writer.WriteHeader(http.StatusOK)
-}
\ No newline at end of file
+	writer.WriteHeader(code)
+	_, _ = writer.Write(body)
+}
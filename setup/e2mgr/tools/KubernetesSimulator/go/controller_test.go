@@ -0,0 +1,107 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serve(router http.Handler, method string, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func initControllerTest() (*Simulator, http.Handler) {
+	simulator := NewSimulator(NewCluster(map[string][]string{"ricplt": {"e2term-a", "e2term-b"}}))
+	return simulator, NewRouter(simulator)
+}
+
+func TestListPods(t *testing.T) {
+	_, router := initControllerTest()
+
+	recorder := serve(router, http.MethodGet, "/api/v1/namespaces/ricplt/pods", "")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	podList := PodList{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &podList))
+	assert.Equal(t, "PodList", podList.Kind)
+	assert.Equal(t, []string{"e2term-a", "e2term-b"}, podNames(podList.Items))
+}
+
+func TestDeletePodHandler(t *testing.T) {
+	simulator, router := initControllerTest()
+
+	recorder := serve(router, http.MethodDelete, "/api/v1/namespaces/ricplt/pods/e2term-a", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = serve(router, http.MethodGet, "/api/v1/namespaces/ricplt/pods/e2term-a", "")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = serve(router, http.MethodGet, "/simulator/deletions", "")
+	deletions := []Deletion{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &deletions))
+	assert.Len(t, deletions, 1)
+	assert.Equal(t, "e2term-a", deletions[0].Pod)
+	assert.Equal(t, []string{"e2term-b"}, podNames(simulator.Cluster.ListPods("ricplt")))
+}
+
+func TestDeletePodNotFound(t *testing.T) {
+	_, router := initControllerTest()
+
+	recorder := serve(router, http.MethodDelete, "/api/v1/namespaces/ricxapp/pods/e2term-a", "")
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	status := Status{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, "NotFound", status.Reason)
+	assert.Equal(t, http.StatusNotFound, status.Code)
+}
+
+func TestCreatePodHandler(t *testing.T) {
+	_, router := initControllerTest()
+
+	recorder := serve(router, http.MethodPost, "/api/v1/namespaces/ricplt/pods", `{"metadata": {"name": "e2term-c"}}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+
+	recorder = serve(router, http.MethodPost, "/api/v1/namespaces/ricplt/pods", `{"metadata": {"name": "e2term-c"}}`)
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+
+	recorder = serve(router, http.MethodPost, "/api/v1/namespaces/ricplt/pods", `{`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestResetHandler(t *testing.T) {
+	simulator, router := initControllerTest()
+	serve(router, http.MethodDelete, "/api/v1/namespaces/ricplt/pods/e2term-a", "")
+
+	recorder := serve(router, http.MethodPost, "/simulator/reset", "")
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Len(t, simulator.Cluster.ListPods("ricplt"), 2)
+	assert.Empty(t, simulator.Cluster.Deletions())
+}
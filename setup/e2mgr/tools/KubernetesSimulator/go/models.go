@@ -0,0 +1,82 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package kubernetes
+
+import "time"
+
+// The models carry the few fields of the Kubernetes core v1 objects a client such as client-go reads back
+
+type ObjectMeta struct {
+	Name              string    `json:"name"`
+	Namespace         string    `json:"namespace"`
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+}
+
+type PodStatus struct {
+	Phase string `json:"phase"`
+}
+
+type Pod struct {
+	Kind       string     `json:"kind"`
+	ApiVersion string     `json:"apiVersion"`
+	Metadata   ObjectMeta `json:"metadata"`
+	Status     PodStatus  `json:"status"`
+}
+
+type PodList struct {
+	Kind       string   `json:"kind"`
+	ApiVersion string   `json:"apiVersion"`
+	Metadata   struct{} `json:"metadata"`
+	Items      []Pod    `json:"items"`
+}
+
+type StatusDetails struct {
+	Name string `json:"name,omitempty"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// Status is what the API server answers a delete or a failed request with
+type Status struct {
+	Kind       string         `json:"kind"`
+	ApiVersion string         `json:"apiVersion"`
+	Metadata   struct{}       `json:"metadata"`
+	Status     string         `json:"status"`
+	Message    string         `json:"message,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+	Details    *StatusDetails `json:"details,omitempty"`
+	Code       int            `json:"code"`
+}
+
+// Deletion records a pod deleted through the API, for tests to assert on
+type Deletion struct {
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	Time      time.Time `json:"time"`
+}
+
+func NewPod(namespace string, name string) Pod {
+	return Pod{
+		Kind:       "Pod",
+		ApiVersion: "v1",
+		Metadata:   ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: time.Now().UTC()},
+		Status:     PodStatus{Phase: "Running"},
+	}
+}
@@ -21,13 +21,13 @@
 package kubernetes
 
 import (
-	//"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/gorilla/mux"
 )
 
+const simulatorBasePath = "/simulator"
+
 type Route struct {
 	Name        string
 	Method      string
@@ -37,9 +37,9 @@ type Route struct {
 
 type Routes []Route
 
-func NewRouter() *mux.Router {
+func NewRouter(simulator *Simulator) *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
-	for _, route := range routes {
+	for _, route := range routes(simulator) {
 		var handler http.Handler
 		handler = route.HandlerFunc
 		handler = Logger(handler, route.Name)
@@ -54,11 +54,43 @@ func NewRouter() *mux.Router {
 	return router
 }
 
-var routes = Routes{
-	Route{
-		"DeletePod",
-		strings.ToUpper("Delete"),
-		"/api/v1/namespaces/{namespace}/pods/{pod}",
-		DeletePod,
-	},
+func routes(simulator *Simulator) Routes {
+	return Routes{
+		Route{
+			"ListPods",
+			http.MethodGet,
+			"/api/v1/namespaces/{namespace}/pods",
+			simulator.ListPods,
+		},
+		Route{
+			"CreatePod",
+			http.MethodPost,
+			"/api/v1/namespaces/{namespace}/pods",
+			simulator.CreatePod,
+		},
+		Route{
+			"GetPod",
+			http.MethodGet,
+			"/api/v1/namespaces/{namespace}/pods/{pod}",
+			simulator.GetPod,
+		},
+		Route{
+			"DeletePod",
+			http.MethodDelete,
+			"/api/v1/namespaces/{namespace}/pods/{pod}",
+			simulator.DeletePod,
+		},
+		Route{
+			"GetDeletions",
+			http.MethodGet,
+			simulatorBasePath + "/deletions",
+			simulator.GetDeletions,
+		},
+		Route{
+			"Reset",
+			http.MethodPost,
+			simulatorBasePath + "/reset",
+			simulator.Reset,
+		},
+	}
 }
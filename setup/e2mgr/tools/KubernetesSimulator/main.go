@@ -34,7 +34,8 @@ func main() {
 
 	log.Printf("Server started on port %d", port)
 
-	router := kubernetes.NewRouter()
+	simulator := kubernetes.NewSimulator(kubernetes.NewCluster(config.Pods))
+	router := kubernetes.NewRouter(simulator)
 
 	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", port), router))
 }
@@ -23,13 +23,25 @@ package configuration
 import (
 	"fmt"
 	"github.com/spf13/viper"
+	"strings"
 )
 
+// Fault makes the requests of an endpoint slow or failing
+type Fault struct {
+	// Status answers the requests with this status instead of handling them, 0 to only delay them
+	Status int `json:"status"`
+	// LatencyMs delays every request
+	LatencyMs int `json:"latencyMs"`
+	// FailAfter is the number of requests handled normally before failing
+	FailAfter int `json:"failAfter"`
+}
+
 type Configuration struct {
 	Http struct {
 		Port int
 	}
-
+	// Faults are keyed by route name, e.g. AssociateRanToE2tHandle
+	Faults map[string]Fault
 }
 
 func ParseConfiguration() *Configuration{
@@ -46,6 +58,7 @@ func ParseConfiguration() *Configuration{
 
 	config := Configuration{}
 	config.fillHttpConfig(viper.Sub("http"))
+	config.fillFaultsConfig(viper.Sub("faults"))
 	return &config
 }
 
@@ -55,3 +68,24 @@ func (c *Configuration)fillHttpConfig(httpConfig *viper.Viper) {
 	}
 	c.Http.Port = httpConfig.GetInt("port")
 }
+
+// fillFaultsConfig reads the optional faults entry. Viper keys are case insensitive, so route names come lower cased
+func (c *Configuration) fillFaultsConfig(faultsConfig *viper.Viper) {
+	c.Faults = map[string]Fault{}
+	if faultsConfig == nil {
+		return
+	}
+
+	for _, route := range faultsConfig.AllKeys() {
+		name := strings.SplitN(route, ".", 2)[0]
+		if _, ok := c.Faults[name]; ok {
+			continue
+		}
+		fault := faultsConfig.Sub(name)
+		c.Faults[name] = Fault{
+			Status:    fault.GetInt("status"),
+			LatencyMs: fault.GetInt("latencyMs"),
+			FailAfter: fault.GetInt("failAfter"),
+		}
+	}
+}
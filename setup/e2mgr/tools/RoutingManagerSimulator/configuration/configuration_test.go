@@ -31,6 +31,7 @@ import (
 func TestParseConfigurationSuccess(t *testing.T) {
 	config := ParseConfiguration()
 	assert.Equal(t, 12020, config.Http.Port)
+	assert.Empty(t, config.Faults)
 }
 
 func TestParseConfigurationFileNotFoundFailure(t *testing.T) {
@@ -74,3 +75,40 @@ func TestHttpConfigNotFoundFailure(t *testing.T) {
 	assert.PanicsWithValue(t, "#configuration.fillHttpConfig - failed to fill HTTP configuration: The entry 'http' not found\n",
 		func() { ParseConfiguration() })
 }
+
+func TestParseConfigurationFaults(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestParseConfigurationFaults - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestParseConfigurationFaults - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"http": map[string]interface{}{"port": 12020},
+		"faults": map[string]interface{}{
+			"AssociateRanToE2tHandle": map[string]interface{}{"status": 503, "latencyMs": 200, "failAfter": 3},
+			"DissociateRan":           map[string]interface{}{"latencyMs": 100},
+		},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestParseConfigurationFaults - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestParseConfigurationFaults - failed to write configuration file: %s\n", configPath)
+	}
+
+	config := ParseConfiguration()
+
+	assert.Equal(t, map[string]Fault{
+		"associaterantoe2thandle": {Status: 503, LatencyMs: 200, FailAfter: 3},
+		"dissociateran":           {LatencyMs: 100},
+	}, config.Faults)
+}
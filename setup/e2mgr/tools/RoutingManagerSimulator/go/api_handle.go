@@ -30,22 +30,49 @@
 package swagger
 
 import (
+	"encoding/json"
 	"net/http"
 )
 
-func AssociateRanToE2tHandle(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
+// Simulator serves the Routing Manager API, keeping the E2T instances and RAN associations E2 Manager asks for
+type Simulator struct {
+	State  *State
+	Faults *Faults
 }
 
-func CreateNewE2tHandle(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
+func NewSimulator(state *State, faults *Faults) *Simulator {
+	return &Simulator{
+		State:  state,
+		Faults: faults,
+	}
 }
 
-func DeleteE2tHandle(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
+func (s *Simulator) AssociateRanToE2tHandle(w http.ResponseWriter, r *http.Request) {
+	ranE2tMap := RanE2tMap{}
+	if !decode(w, r, &ranE2tMap) {
+		return
+	}
+	respond(w, s.State.Associate(ranE2tMap))
+}
+
+func (s *Simulator) CreateNewE2tHandle(w http.ResponseWriter, r *http.Request) {
+	e2tData := E2tData{}
+	if !decode(w, r, &e2tData) {
+		return
+	}
+	respond(w, s.State.AddE2t(e2tData))
+}
+
+func (s *Simulator) DeleteE2tHandle(w http.ResponseWriter, r *http.Request) {
+	e2tDeleteData := E2tDeleteData{}
+	if !decode(w, r, &e2tDeleteData) {
+		return
+	}
+	if e2tDeleteData.E2TAddress == "" {
+		http.Error(w, "E2TAddress is missing", http.StatusBadRequest)
+		return
+	}
+	respond(w, s.State.DeleteE2t(e2tDeleteData))
 }
 
 func DeleteXappSubscriptionHandle(w http.ResponseWriter, r *http.Request) {
@@ -53,9 +80,12 @@ func DeleteXappSubscriptionHandle(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-func DissociateRan(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
+func (s *Simulator) DissociateRan(w http.ResponseWriter, r *http.Request) {
+	ranE2tMap := RanE2tMap{}
+	if !decode(w, r, &ranE2tMap) {
+		return
+	}
+	respond(w, s.State.Dissociate(ranE2tMap))
 }
 
 func GetHandles(w http.ResponseWriter, r *http.Request) {
@@ -77,3 +107,23 @@ func UpdateXappSubscriptionHandle(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 }
+
+// decode reads the JSON body of r into v, answering 400 when it is invalid
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		http.Error(w, "invalid data: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// respond answers 201 when the state was updated and 400 when the request was rejected
+func respond(w http.ResponseWriter, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+}
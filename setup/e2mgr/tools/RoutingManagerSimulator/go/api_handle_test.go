@@ -0,0 +1,149 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package swagger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"rmsimulator/configuration"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSimulator(faults map[string]configuration.Fault) (*Simulator, *httptest.Server) {
+	simulator := NewSimulator(NewState(), NewFaults(faults))
+	return simulator, httptest.NewServer(NewRouter(simulator))
+}
+
+func call(t *testing.T, server *httptest.Server, method string, path string, body string) (int, string) {
+	req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("#api_handle_test.call - %s %s failed, error: %s", method, path, err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, string(respBody)
+}
+
+func TestE2ManagerCalls(t *testing.T) {
+	_, server := setupSimulator(nil)
+	defer server.Close()
+
+	status, _ := call(t, server, http.MethodPost, "/ric/v1/handles/e2t", `{"E2TAddress":"10.0.2.15:38000"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	status, _ = call(t, server, http.MethodPost, "/ric/v1/handles/associate-ran-to-e2t", `[{"E2TAddress":"10.0.2.15:38000","ranNamelist":["gnb_1","gnb_2"]}]`)
+	assert.Equal(t, http.StatusCreated, status)
+	status, _ = call(t, server, http.MethodPost, "/ric/v1/handles/dissociate-ran", `[{"E2TAddress":"10.0.2.15:38000","ranNamelist":["gnb_2"]}]`)
+	assert.Equal(t, http.StatusCreated, status)
+
+	status, body := call(t, server, http.MethodGet, "/ric/v1/simulator/e2t/10.0.2.15:38000", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"E2TAddress":"10.0.2.15:38000","ranNamelist":["gnb_1"]}`, body)
+
+	status, _ = call(t, server, http.MethodDelete, "/ric/v1/handles/e2t", `{"E2TAddress":"10.0.2.15:38000","ranNamelistTobeDissociated":["gnb_1"]}`)
+	assert.Equal(t, http.StatusCreated, status)
+
+	status, body = call(t, server, http.MethodGet, "/ric/v1/simulator/e2t", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `[]`, body)
+	status, _ = call(t, server, http.MethodGet, "/ric/v1/simulator/e2t/10.0.2.15:38000", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestInvalidCallsAreRejected(t *testing.T) {
+	simulator, server := setupSimulator(nil)
+	defer server.Close()
+
+	status, _ := call(t, server, http.MethodPost, "/ric/v1/handles/associate-ran-to-e2t", `{"E2TAddress":"10.0.2.15:38000"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, body := call(t, server, http.MethodPost, "/ric/v1/handles/associate-ran-to-e2t", `[{"E2TAddress":"10.0.2.15:38000","ranNamelist":["gnb_1"]}]`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "E2T instance 10.0.2.15:38000 not found")
+	status, _ = call(t, server, http.MethodDelete, "/ric/v1/handles/e2t", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	assert.Empty(t, simulator.State.E2tInstances())
+}
+
+func TestFailAfter(t *testing.T) {
+	simulator, server := setupSimulator(map[string]configuration.Fault{
+		"createnewe2thandle": {Status: http.StatusServiceUnavailable, FailAfter: 1},
+	})
+	defer server.Close()
+
+	status, _ := call(t, server, http.MethodPost, "/ric/v1/handles/e2t", `{"E2TAddress":"10.0.2.15:38000"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	status, _ = call(t, server, http.MethodPost, "/ric/v1/handles/e2t", `{"E2TAddress":"10.0.2.16:38000"}`)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	// A failed request leaves the state alone
+	assert.Len(t, simulator.State.E2tInstances(), 1)
+
+	status, body := call(t, server, http.MethodGet, "/ric/v1/simulator/stats", "")
+	assert.Equal(t, http.StatusOK, status)
+	stats := map[string]RouteStats{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &stats))
+	assert.Equal(t, RouteStats{Requests: 2, Failed: 1}, stats["createnewe2thandle"])
+}
+
+func TestSetAndClearFault(t *testing.T) {
+	_, server := setupSimulator(nil)
+	defer server.Close()
+	call(t, server, http.MethodPost, "/ric/v1/handles/e2t", `{"E2TAddress":"10.0.2.15:38000"}`)
+
+	status, _ := call(t, server, http.MethodPut, "/ric/v1/simulator/faults/DissociateRan", `{"status":500,"latencyMs":50}`)
+	assert.Equal(t, http.StatusNoContent, status)
+	_, body := call(t, server, http.MethodGet, "/ric/v1/simulator/faults", "")
+	assert.JSONEq(t, `{"dissociateran":{"status":500,"latencyMs":50,"failAfter":0}}`, body)
+
+	start := time.Now()
+	status, _ = call(t, server, http.MethodPost, "/ric/v1/handles/dissociate-ran", `[{"E2TAddress":"10.0.2.15:38000"}]`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	status, _ = call(t, server, http.MethodDelete, "/ric/v1/simulator/faults/DissociateRan", "")
+	assert.Equal(t, http.StatusNoContent, status)
+	status, _ = call(t, server, http.MethodPost, "/ric/v1/handles/dissociate-ran", `[{"E2TAddress":"10.0.2.15:38000"}]`)
+	assert.Equal(t, http.StatusCreated, status)
+}
+
+func TestReset(t *testing.T) {
+	simulator, server := setupSimulator(map[string]configuration.Fault{"GetHealth": {Status: http.StatusServiceUnavailable}})
+	defer server.Close()
+	call(t, server, http.MethodPost, "/ric/v1/handles/e2t", `{"E2TAddress":"10.0.2.15:38000","ranNamelist":["gnb_1"]}`)
+	status, _ := call(t, server, http.MethodGet, "/ric/v1/health", "")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	status, _ = call(t, server, http.MethodPost, "/ric/v1/simulator/reset", "")
+
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Empty(t, simulator.State.Rans())
+	assert.Empty(t, simulator.Faults.All())
+	assert.Empty(t, simulator.Faults.Stats())
+	status, _ = call(t, server, http.MethodGet, "/ric/v1/health", "")
+	assert.Equal(t, http.StatusOK, status)
+}
@@ -0,0 +1,107 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package swagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"rmsimulator/configuration"
+
+	"github.com/gorilla/mux"
+)
+
+const simulatorBasePath = "/ric/v1/simulator"
+
+// simulatorRoutes inspect the state of the simulator and control its faults. They are not part of the Routing
+// Manager API, so faults are not injected into them
+func simulatorRoutes(simulator *Simulator) Routes {
+	return Routes{
+		Route{"GetE2tInstances", http.MethodGet, simulatorBasePath + "/e2t", simulator.GetE2tInstances},
+		Route{"GetE2tInstance", http.MethodGet, simulatorBasePath + "/e2t/{address}", simulator.GetE2tInstance},
+		Route{"GetRans", http.MethodGet, simulatorBasePath + "/rans", simulator.GetRans},
+		Route{"GetFaults", http.MethodGet, simulatorBasePath + "/faults", simulator.GetFaults},
+		Route{"SetFault", http.MethodPut, simulatorBasePath + "/faults/{route}", simulator.SetFault},
+		Route{"ClearFault", http.MethodDelete, simulatorBasePath + "/faults/{route}", simulator.ClearFault},
+		Route{"GetStats", http.MethodGet, simulatorBasePath + "/stats", simulator.GetStats},
+		Route{"Reset", http.MethodPost, simulatorBasePath + "/reset", simulator.Reset},
+	}
+}
+
+func (s *Simulator) GetE2tInstances(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, s.State.E2tInstances())
+}
+
+func (s *Simulator) GetE2tInstance(w http.ResponseWriter, r *http.Request) {
+	e2tData, ok := s.State.E2tInstance(mux.Vars(r)["address"])
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJson(w, e2tData)
+}
+
+// GetRans returns the E2T instance address of every associated RAN
+func (s *Simulator) GetRans(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, s.State.Rans())
+}
+
+func (s *Simulator) GetFaults(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, s.Faults.All())
+}
+
+// SetFault injects the fault of the body into a route, e.g. PUT /ric/v1/simulator/faults/DissociateRan
+// {"status": 503, "failAfter": 2}
+func (s *Simulator) SetFault(w http.ResponseWriter, r *http.Request) {
+	fault := configuration.Fault{}
+	if !decode(w, r, &fault) {
+		return
+	}
+	s.Faults.Set(mux.Vars(r)["route"], fault)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Simulator) ClearFault(w http.ResponseWriter, r *http.Request) {
+	s.Faults.Clear(mux.Vars(r)["route"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetStats returns the number of requests and injected failures per route
+func (s *Simulator) GetStats(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, s.Faults.Stats())
+}
+
+// Reset forgets the E2T instances, the faults and the stats
+func (s *Simulator) Reset(w http.ResponseWriter, r *http.Request) {
+	s.State.Reset()
+	s.Faults.Reset()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJson(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,152 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package swagger
+
+import (
+	"net/http"
+	"rmsimulator/configuration"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RouteStats counts the requests of a route
+type RouteStats struct {
+	Requests int `json:"requests"`
+	Failed   int `json:"failed"`
+}
+
+// Faults holds the faults injected per route. Route names are case insensitive
+type Faults struct {
+	mu     sync.Mutex
+	faults map[string]configuration.Fault
+	// calls counts the requests of a route since its fault was set
+	calls map[string]int
+	stats map[string]*RouteStats
+}
+
+func NewFaults(faults map[string]configuration.Fault) *Faults {
+	f := &Faults{
+		faults: map[string]configuration.Fault{},
+		calls:  map[string]int{},
+		stats:  map[string]*RouteStats{},
+	}
+	for route, fault := range faults {
+		f.Set(route, fault)
+	}
+	return f
+}
+
+func (f *Faults) Set(route string, fault configuration.Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	route = strings.ToLower(route)
+	f.faults[route] = fault
+	f.calls[route] = 0
+}
+
+func (f *Faults) Clear(route string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	route = strings.ToLower(route)
+	delete(f.faults, route)
+	delete(f.calls, route)
+}
+
+// Reset clears the faults and the stats
+func (f *Faults) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.faults = map[string]configuration.Fault{}
+	f.calls = map[string]int{}
+	f.stats = map[string]*RouteStats{}
+}
+
+func (f *Faults) All() map[string]configuration.Fault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	faults := map[string]configuration.Fault{}
+	for route, fault := range f.faults {
+		faults[route] = fault
+	}
+	return faults
+}
+
+func (f *Faults) Stats() map[string]RouteStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := map[string]RouteStats{}
+	for route, routeStats := range f.stats {
+		stats[route] = *routeStats
+	}
+	return stats
+}
+
+// next counts a request of route and returns its latency and the status to fail it with, 0 when it is handled
+func (f *Faults) next(route string) (time.Duration, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	route = strings.ToLower(route)
+	stats, ok := f.stats[route]
+	if !ok {
+		stats = &RouteStats{}
+		f.stats[route] = stats
+	}
+	stats.Requests++
+
+	fault, ok := f.faults[route]
+	if !ok {
+		return 0, 0
+	}
+
+	f.calls[route]++
+	latency := time.Duration(fault.LatencyMs) * time.Millisecond
+
+	if fault.Status == 0 || f.calls[route] <= fault.FailAfter {
+		return latency, 0
+	}
+	stats.Failed++
+	return latency, fault.Status
+}
+
+// Inject wraps the handler of route with its faults
+func (f *Faults) Inject(inner http.Handler, route string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		latency, status := f.next(route)
+
+		if latency > 0 {
+			time.Sleep(latency)
+		}
+
+		if status != 0 {
+			http.Error(w, "injected failure", status)
+			return
+		}
+
+		inner.ServeHTTP(w, r)
+	})
+}
@@ -41,14 +41,16 @@ func Logger(inner http.Handler, name string) http.Handler {
     return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
         start := time.Now()
 
-        inner.ServeHTTP(w, r)
-
         body, err := ioutil.ReadAll(r.Body)
         if err != nil {
             log.Printf("Error reading body: %v", err)
             http.Error(w, "can't read body", http.StatusBadRequest)
             return
         }
+        // The handlers read the body too
+        r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+        inner.ServeHTTP(w, r)
 
         buffer := new(bytes.Buffer)
         _ =json.Compact(buffer, body)
@@ -32,5 +32,5 @@ type E2tData struct {
 
 	E2TAddress string `json:"E2TAddress"`
 
-	RanNamelist *RanNamelist `json:"ranNamelist,omitempty"`
+	RanNamelist RanNamelist `json:"ranNamelist,omitempty"`
 }
@@ -32,7 +32,7 @@ type E2tDeleteData struct {
 
 	E2TAddress string `json:"E2TAddress"`
 
-	RanNamelistTobeDissociated *RanNamelist `json:"ranNamelistTobeDissociated,omitempty"`
+	RanNamelistTobeDissociated RanNamelist `json:"ranNamelistTobeDissociated,omitempty"`
 
-	RanAssocList *RanE2tMap `json:"ranAssocList,omitempty"`
+	RanAssocList RanE2tMap `json:"ranAssocList,omitempty"`
 }
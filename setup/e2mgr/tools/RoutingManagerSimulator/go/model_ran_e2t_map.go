@@ -28,5 +28,4 @@
 
 package swagger
 
-type RanE2tMap struct {
-}
+type RanE2tMap []RanE2tElement
@@ -28,5 +28,4 @@
 
 package swagger
 
-type RanNamelist struct {
-}
+type RanNamelist []string
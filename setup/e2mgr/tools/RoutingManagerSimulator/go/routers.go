@@ -45,102 +45,110 @@ type Route struct {
 
 type Routes []Route
 
-func NewRouter() *mux.Router {
+// NewRouter serves the Routing Manager API, with the faults of the simulator injected, and the simulator API
+func NewRouter(simulator *Simulator) *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
-	for _, route := range routes {
-		var handler http.Handler
-		handler = route.HandlerFunc
-		handler = Logger(handler, route.Name)
-
-		router.
-			Methods(route.Method).
-			Path(route.Pattern).
-			Name(route.Name).
-			Handler(handler)
+	for _, route := range routes(simulator) {
+		addRoute(router, route, simulator.Faults.Inject(route.HandlerFunc, route.Name))
+	}
+	for _, route := range simulatorRoutes(simulator) {
+		addRoute(router, route, route.HandlerFunc)
 	}
 
 	return router
 }
 
+func addRoute(router *mux.Router, route Route, handler http.Handler) {
+	handler = Logger(handler, route.Name)
+
+	router.
+		Methods(route.Method).
+		Path(route.Pattern).
+		Name(route.Name).
+		Handler(handler)
+}
+
 func Index(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "Hello World!")
 }
 
-var routes = Routes{
-	Route{
-		"Index",
-		"GET",
-		"/ric/v1/",
-		Index,
-	},
-
-	Route{
-		"AssociateRanToE2tHandle",
-		strings.ToUpper("Post"),
-		"/ric/v1/handles/associate-ran-to-e2t",
-		AssociateRanToE2tHandle,
-	},
-
-	Route{
-		"CreateNewE2tHandle",
-		strings.ToUpper("Post"),
-		"/ric/v1/handles/e2t",
-		CreateNewE2tHandle,
-	},
-
-	Route{
-		"DeleteE2tHandle",
-		strings.ToUpper("Delete"),
-		"/ric/v1/handles/e2t",
-		DeleteE2tHandle,
-	},
-
-	Route{
-		"DeleteXappSubscriptionHandle",
-		strings.ToUpper("Delete"),
-		"/ric/v1/handles/xapp-subscription-handle",
-		DeleteXappSubscriptionHandle,
-	},
-
-	Route{
-		"DissociateRan",
-		strings.ToUpper("Post"),
-		"/ric/v1/handles/dissociate-ran",
-		DissociateRan,
-	},
-
-	Route{
-		"GetHandles",
-		strings.ToUpper("Get"),
-		"/ric/v1/handles",
-		GetHandles,
-	},
-
-	Route{
-		"ProvideXappHandle",
-		strings.ToUpper("Post"),
-		"/ric/v1/handles/xapp-handle",
-		ProvideXappHandle,
-	},
-
-	Route{
-		"ProvideXappSubscriptionHandle",
-		strings.ToUpper("Post"),
-		"/ric/v1/handles/xapp-subscription-handle",
-		ProvideXappSubscriptionHandle,
-	},
-
-	Route{
-		"UpdateXappSubscriptionHandle",
-		strings.ToUpper("Put"),
-		"/ric/v1/handles/xapp-subscription-handle/{subscription_id}",
-		UpdateXappSubscriptionHandle,
-	},
-
-	Route{
-		"GetHealth",
-		strings.ToUpper("Get"),
-		"/ric/v1/health",
-		GetHealth,
-	},
+func routes(simulator *Simulator) Routes {
+	return Routes{
+		Route{
+			"Index",
+			"GET",
+			"/ric/v1/",
+			Index,
+		},
+
+		Route{
+			"AssociateRanToE2tHandle",
+			strings.ToUpper("Post"),
+			"/ric/v1/handles/associate-ran-to-e2t",
+			simulator.AssociateRanToE2tHandle,
+		},
+
+		Route{
+			"CreateNewE2tHandle",
+			strings.ToUpper("Post"),
+			"/ric/v1/handles/e2t",
+			simulator.CreateNewE2tHandle,
+		},
+
+		Route{
+			"DeleteE2tHandle",
+			strings.ToUpper("Delete"),
+			"/ric/v1/handles/e2t",
+			simulator.DeleteE2tHandle,
+		},
+
+		Route{
+			"DeleteXappSubscriptionHandle",
+			strings.ToUpper("Delete"),
+			"/ric/v1/handles/xapp-subscription-handle",
+			DeleteXappSubscriptionHandle,
+		},
+
+		Route{
+			"DissociateRan",
+			strings.ToUpper("Post"),
+			"/ric/v1/handles/dissociate-ran",
+			simulator.DissociateRan,
+		},
+
+		Route{
+			"GetHandles",
+			strings.ToUpper("Get"),
+			"/ric/v1/handles",
+			GetHandles,
+		},
+
+		Route{
+			"ProvideXappHandle",
+			strings.ToUpper("Post"),
+			"/ric/v1/handles/xapp-handle",
+			ProvideXappHandle,
+		},
+
+		Route{
+			"ProvideXappSubscriptionHandle",
+			strings.ToUpper("Post"),
+			"/ric/v1/handles/xapp-subscription-handle",
+			ProvideXappSubscriptionHandle,
+		},
+
+		Route{
+			"UpdateXappSubscriptionHandle",
+			strings.ToUpper("Put"),
+			"/ric/v1/handles/xapp-subscription-handle/{subscription_id}",
+			UpdateXappSubscriptionHandle,
+		},
+
+		Route{
+			"GetHealth",
+			strings.ToUpper("Get"),
+			"/ric/v1/health",
+			GetHealth,
+		},
+	}
 }
@@ -0,0 +1,194 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package swagger
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// State is the routing state the simulator builds from the calls of E2 Manager: the E2T instances and the RANs
+// associated to each of them. A RAN is associated to one E2T instance at most
+type State struct {
+	mu           sync.Mutex
+	e2tInstances map[string]map[string]bool
+}
+
+func NewState() *State {
+	return &State{
+		e2tInstances: map[string]map[string]bool{},
+	}
+}
+
+// AddE2t adds the E2T instance at e2tAddress, which an E2T announces on every start
+func (s *State) AddE2t(data E2tData) error {
+	if data.E2TAddress == "" {
+		return fmt.Errorf("E2TAddress is missing")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.e2tInstances[data.E2TAddress]; !ok {
+		s.e2tInstances[data.E2TAddress] = map[string]bool{}
+	}
+	return s.associate(data.E2TAddress, data.RanNamelist)
+}
+
+// DeleteE2t removes an E2T instance, dissociates the RANs to be dissociated and moves the others to the E2T
+// instances of the association list
+func (s *State) DeleteE2t(data E2tDeleteData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.e2tInstances[data.E2TAddress]; !ok {
+		return fmt.Errorf("E2T instance %s not found", data.E2TAddress)
+	}
+	if err := s.validate(data.RanAssocList); err != nil {
+		return err
+	}
+
+	delete(s.e2tInstances, data.E2TAddress)
+
+	for _, element := range data.RanAssocList {
+		_ = s.associate(element.E2TAddress, element.RanNamelist)
+	}
+	return nil
+}
+
+// Associate associates each RAN of the list to its E2T instance, moving it from the one it was associated to
+func (s *State) Associate(ranE2tMap RanE2tMap) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.validate(ranE2tMap); err != nil {
+		return err
+	}
+
+	for _, element := range ranE2tMap {
+		_ = s.associate(element.E2TAddress, element.RanNamelist)
+	}
+	return nil
+}
+
+// Dissociate removes the listed RANs from their E2T instance, or all RANs of an E2T instance listed without any
+func (s *State) Dissociate(ranE2tMap RanE2tMap) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.validate(ranE2tMap); err != nil {
+		return err
+	}
+
+	for _, element := range ranE2tMap {
+		if len(element.RanNamelist) == 0 {
+			s.e2tInstances[element.E2TAddress] = map[string]bool{}
+			continue
+		}
+		for _, ranName := range element.RanNamelist {
+			delete(s.e2tInstances[element.E2TAddress], ranName)
+		}
+	}
+	return nil
+}
+
+// E2tInstances returns the E2T instances and their RANs, sorted by address and RAN name
+func (s *State) E2tInstances() []E2tData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instances := []E2tData{}
+	for address, ranNames := range s.e2tInstances {
+		instances = append(instances, E2tData{E2TAddress: address, RanNamelist: sortedNames(ranNames)})
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].E2TAddress < instances[j].E2TAddress })
+	return instances
+}
+
+// E2tInstance returns the E2T instance at e2tAddress and its RANs
+func (s *State) E2tInstance(e2tAddress string) (E2tData, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ranNames, ok := s.e2tInstances[e2tAddress]
+	if !ok {
+		return E2tData{}, false
+	}
+	return E2tData{E2TAddress: e2tAddress, RanNamelist: sortedNames(ranNames)}, true
+}
+
+// Rans returns the E2T instance address of every associated RAN
+func (s *State) Rans() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rans := map[string]string{}
+	for address, ranNames := range s.e2tInstances {
+		for ranName := range ranNames {
+			rans[ranName] = address
+		}
+	}
+	return rans
+}
+
+func (s *State) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.e2tInstances = map[string]map[string]bool{}
+}
+
+// validate rejects a list referring to an E2T instance which was not added, so a request is applied entirely or not
+func (s *State) validate(ranE2tMap RanE2tMap) error {
+	for _, element := range ranE2tMap {
+		if element.E2TAddress == "" {
+			return fmt.Errorf("E2TAddress is missing")
+		}
+		if _, ok := s.e2tInstances[element.E2TAddress]; !ok {
+			return fmt.Errorf("E2T instance %s not found", element.E2TAddress)
+		}
+	}
+	return nil
+}
+
+func (s *State) associate(e2tAddress string, ranNamelist RanNamelist) error {
+	ranNames, ok := s.e2tInstances[e2tAddress]
+	if !ok {
+		return fmt.Errorf("E2T instance %s not found", e2tAddress)
+	}
+
+	for _, ranName := range ranNamelist {
+		for _, other := range s.e2tInstances {
+			delete(other, ranName)
+		}
+		ranNames[ranName] = true
+	}
+	return nil
+}
+
+func sortedNames(names map[string]bool) RanNamelist {
+	sorted := RanNamelist{}
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
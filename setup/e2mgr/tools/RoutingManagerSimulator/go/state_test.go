@@ -0,0 +1,94 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+
+package swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	e2tAddress      = "10.0.2.15:38000"
+	otherE2tAddress = "10.0.2.16:38000"
+)
+
+func TestAssociateMovesRans(t *testing.T) {
+	state := NewState()
+	assert.Nil(t, state.AddE2t(E2tData{E2TAddress: e2tAddress}))
+	assert.Nil(t, state.AddE2t(E2tData{E2TAddress: otherE2tAddress}))
+
+	assert.Nil(t, state.Associate(RanE2tMap{{E2TAddress: e2tAddress, RanNamelist: RanNamelist{"ran_2", "ran_1"}}}))
+	assert.Nil(t, state.Associate(RanE2tMap{{E2TAddress: otherE2tAddress, RanNamelist: RanNamelist{"ran_2"}}}))
+
+	assert.Equal(t, []E2tData{
+		{E2TAddress: e2tAddress, RanNamelist: RanNamelist{"ran_1"}},
+		{E2TAddress: otherE2tAddress, RanNamelist: RanNamelist{"ran_2"}},
+	}, state.E2tInstances())
+	assert.Equal(t, map[string]string{"ran_1": e2tAddress, "ran_2": otherE2tAddress}, state.Rans())
+}
+
+func TestAssociateUnknownE2tIsRejected(t *testing.T) {
+	state := NewState()
+	assert.Nil(t, state.AddE2t(E2tData{E2TAddress: e2tAddress}))
+
+	err := state.Associate(RanE2tMap{
+		{E2TAddress: e2tAddress, RanNamelist: RanNamelist{"ran_1"}},
+		{E2TAddress: otherE2tAddress, RanNamelist: RanNamelist{"ran_2"}},
+	})
+
+	assert.EqualError(t, err, "E2T instance 10.0.2.16:38000 not found")
+	assert.Empty(t, state.Rans())
+	assert.NotNil(t, state.Associate(RanE2tMap{{RanNamelist: RanNamelist{"ran_1"}}}))
+	assert.NotNil(t, state.AddE2t(E2tData{}))
+}
+
+func TestDissociate(t *testing.T) {
+	state := NewState()
+	assert.Nil(t, state.AddE2t(E2tData{E2TAddress: e2tAddress, RanNamelist: RanNamelist{"ran_1", "ran_2", "ran_3"}}))
+
+	assert.Nil(t, state.Dissociate(RanE2tMap{{E2TAddress: e2tAddress, RanNamelist: RanNamelist{"ran_2"}}}))
+	e2tData, _ := state.E2tInstance(e2tAddress)
+	assert.Equal(t, RanNamelist{"ran_1", "ran_3"}, e2tData.RanNamelist)
+
+	// An E2T instance listed without RANs loses all of them
+	assert.Nil(t, state.Dissociate(RanE2tMap{{E2TAddress: e2tAddress}}))
+	e2tData, _ = state.E2tInstance(e2tAddress)
+	assert.Empty(t, e2tData.RanNamelist)
+}
+
+func TestDeleteE2tRedistributesRans(t *testing.T) {
+	state := NewState()
+	assert.Nil(t, state.AddE2t(E2tData{E2TAddress: e2tAddress, RanNamelist: RanNamelist{"ran_1", "ran_2", "ran_3"}}))
+	assert.Nil(t, state.AddE2t(E2tData{E2TAddress: otherE2tAddress}))
+
+	err := state.DeleteE2t(E2tDeleteData{
+		E2TAddress:                 e2tAddress,
+		RanNamelistTobeDissociated: RanNamelist{"ran_3"},
+		RanAssocList:               RanE2tMap{{E2TAddress: otherE2tAddress, RanNamelist: RanNamelist{"ran_1", "ran_2"}}},
+	})
+
+	assert.Nil(t, err)
+	_, ok := state.E2tInstance(e2tAddress)
+	assert.False(t, ok)
+	assert.Equal(t, map[string]string{"ran_1": otherE2tAddress, "ran_2": otherE2tAddress}, state.Rans())
+	assert.NotNil(t, state.DeleteE2t(E2tDeleteData{E2TAddress: e2tAddress}))
+}
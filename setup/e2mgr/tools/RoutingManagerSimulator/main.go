@@ -43,7 +43,8 @@ func main() {
 
 	log.Printf("Server started on port %d", port)
 
-	router := swagger.NewRouter()
+	simulator := swagger.NewSimulator(swagger.NewState(), swagger.NewFaults(config.Faults))
+	router := swagger.NewRouter(simulator)
 
 	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", port), router))
 }
@@ -0,0 +1,374 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+// Package daemon keeps xappmock running behind a REST API, so that one instance is driven across many test steps:
+// templates are loaded and removed, sends are triggered, received messages are delivered to subscriptions and the
+// traffic is counted.
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"xappmock/dispatcher"
+	"xappmock/logger"
+	"xappmock/models"
+	"xappmock/rmr"
+	"xappmock/sender"
+)
+
+const (
+	subscriptionQueueSize = 1000
+	maxWait               = 60 * time.Second
+)
+
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// Message is a message received by xappmock. The payload is hex encoded, as the packed payloads of the templates
+type Message struct {
+	Seq            int64     `json:"seq"`
+	Timestamp      time.Time `json:"timestamp"`
+	RmrMessageType int       `json:"rmrMessageType"`
+	Meid           string    `json:"meid"`
+	TransactionId  string    `json:"transactionId"`
+	PackedPayload  string    `json:"packedPayload"`
+}
+
+// Filter selects the messages of a subscription. RmrMessageType is a type or a comma separated list of types; empty
+// fields select every message
+type Filter struct {
+	RmrMessageType string `json:"rmrMessageType,omitempty"`
+	Meid           string `json:"meid,omitempty"`
+	TransactionId  string `json:"transactionId,omitempty"`
+}
+
+type Subscription struct {
+	Id      string `json:"id"`
+	Filter  Filter `json:"filter"`
+	Pending int    `json:"pending"`
+	// Dropped counts the messages lost because the subscription was not read from for too long
+	Dropped int `json:"dropped"`
+}
+
+type Stats struct {
+	UptimeMs       int64          `json:"uptimeMs"`
+	Sent           int            `json:"sent"`
+	SendErrors     int            `json:"sendErrors"`
+	Received       int            `json:"received"`
+	ReceiveErrors  int            `json:"receiveErrors"`
+	ReceivedByType map[string]int `json:"receivedByType"`
+	SentByType     map[string]int `json:"sentByType"`
+	Subscriptions  int            `json:"subscriptions"`
+}
+
+type SendResult struct {
+	Sent           int      `json:"sent"`
+	Failed         int      `json:"failed"`
+	TransactionIds []string `json:"transactionIds"`
+	Error          string   `json:"error,omitempty"`
+}
+
+type subscription struct {
+	id      string
+	filter  Filter
+	types   map[int]bool
+	queue   []Message
+	dropped int
+	notify  chan struct{}
+}
+
+func (s *subscription) matches(message Message) bool {
+	if s.types != nil && !s.types[message.RmrMessageType] {
+		return false
+	}
+	if len(s.filter.Meid) > 0 && s.filter.Meid != message.Meid {
+		return false
+	}
+	return len(s.filter.TransactionId) == 0 || s.filter.TransactionId == message.TransactionId
+}
+
+type Daemon struct {
+	logger        *logger.Logger
+	rmrService    *rmr.Service
+	jsonSender    *sender.JsonSender
+	mu            sync.Mutex
+	start         time.Time
+	seq           int64
+	lastId        int
+	subscriptions map[string]*subscription
+	stats         Stats
+}
+
+func New(logger *logger.Logger, rmrService *rmr.Service, jsonSender *sender.JsonSender) *Daemon {
+	d := &Daemon{
+		logger:        logger,
+		rmrService:    rmrService,
+		jsonSender:    jsonSender,
+		subscriptions: make(map[string]*subscription),
+	}
+	d.ResetStats()
+	return d
+}
+
+// Receive delivers the messages received to the subscriptions until ctx is done
+func (d *Daemon) Receive(ctx context.Context) {
+	for {
+		mbuf, err := d.rmrService.RecvMessage()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			d.logger.Errorf("#Daemon.Receive - error receiving message: %s", err)
+			d.mu.Lock()
+			d.stats.ReceiveErrors++
+			d.mu.Unlock()
+			continue
+		}
+
+		d.deliver(mbuf)
+	}
+}
+
+func (d *Daemon) deliver(mbuf *rmr.MBuf) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.seq++
+	message := Message{
+		Seq:            d.seq,
+		Timestamp:      time.Now().UTC(),
+		RmrMessageType: mbuf.MType,
+		Meid:           mbuf.Meid,
+		TransactionId:  strings.TrimRight(string(mbuf.XAction), "\x00"),
+		PackedPayload:  fmt.Sprintf("%x", mbuf.Payload),
+	}
+
+	d.stats.Received++
+	d.stats.ReceivedByType[strconv.Itoa(mbuf.MType)]++
+	d.logger.Infof("#Daemon.deliver - received message type: %d, meid: %s, transaction id: %s", message.RmrMessageType, message.Meid, message.TransactionId)
+
+	for _, s := range d.subscriptions {
+		if !s.matches(message) {
+			continue
+		}
+		if len(s.queue) == subscriptionQueueSize {
+			s.queue = s.queue[1:]
+			s.dropped++
+		}
+		s.queue = append(s.queue, message)
+		select {
+		case s.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Send sends cmd, merged with the template of its id, RepeatCount times or once, RepeatDelayInMs apart. Each message
+// gets its own transaction id when the template one ends with $
+func (d *Daemon) Send(ctx context.Context, cmd *models.JsonCommand) (SendResult, error) {
+	command, err := dispatcher.GetMergedCommand(cmd)
+	if err != nil {
+		return SendResult{}, err
+	}
+	if _, err := rmr.MessageIdToUint(command.RmrMessageType); err != nil || len(command.RmrMessageType) == 0 {
+		return SendResult{}, errors.New(fmt.Sprintf("invalid rmr message type %s for command id %s", command.RmrMessageType, command.Id))
+	}
+
+	count := command.RepeatCount
+	if count < 1 {
+		count = 1
+	}
+
+	result := SendResult{TransactionIds: []string{}}
+	xAction := []byte{}
+	for i := 0; i < count; i++ {
+		if i > 0 && command.RepeatDelayInMs > 0 {
+			select {
+			case <-time.After(time.Duration(command.RepeatDelayInMs) * time.Millisecond):
+			case <-ctx.Done():
+				return result, nil
+			}
+		}
+
+		message := command
+		message.TransactionId = sender.ExpandTransactionId(command.TransactionId)
+		err := d.jsonSender.SendJsonRmrMessage(message, &xAction, d.rmrService)
+
+		d.mu.Lock()
+		if err != nil {
+			d.stats.SendErrors++
+		} else {
+			d.stats.Sent++
+			d.stats.SentByType[command.RmrMessageType]++
+		}
+		d.mu.Unlock()
+
+		if err != nil {
+			d.logger.Errorf("#Daemon.Send - error sending rmr message: %s", err)
+			result.Failed++
+			result.Error = err.Error()
+			continue
+		}
+		result.Sent++
+		result.TransactionIds = append(result.TransactionIds, message.TransactionId)
+	}
+	return result, nil
+}
+
+// Subscribe starts keeping the messages received that filter selects, until Unsubscribe
+func (d *Daemon) Subscribe(filter Filter) (Subscription, error) {
+	s := &subscription{filter: filter, notify: make(chan struct{}, 1)}
+
+	if len(filter.RmrMessageType) > 0 {
+		s.types = make(map[int]bool)
+		for _, messageType := range strings.Split(filter.RmrMessageType, ",") {
+			messageType = strings.TrimSpace(messageType)
+			id, err := rmr.MessageIdToUint(messageType)
+			if err != nil || len(messageType) == 0 {
+				return Subscription{}, errors.New(fmt.Sprintf("invalid rmr message type %s", messageType))
+			}
+			s.types[int(id)] = true
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.lastId++
+	s.id = strconv.Itoa(d.lastId)
+	d.subscriptions[s.id] = s
+	return s.info(), nil
+}
+
+func (d *Daemon) Unsubscribe(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.subscriptions[id]
+	delete(d.subscriptions, id)
+	return ok
+}
+
+// Subscription returns the subscription with id
+func (d *Daemon) Subscription(id string) (Subscription, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	s, ok := d.subscriptions[id]
+	if !ok {
+		return Subscription{}, false
+	}
+	return s.info(), true
+}
+
+// Subscriptions returns the subscriptions sorted by id
+func (d *Daemon) Subscriptions() []Subscription {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	subscriptions := make([]Subscription, 0, len(d.subscriptions))
+	for _, s := range d.subscriptions {
+		subscriptions = append(subscriptions, s.info())
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		a, _ := strconv.Atoi(subscriptions[i].Id)
+		b, _ := strconv.Atoi(subscriptions[j].Id)
+		return a < b
+	})
+	return subscriptions
+}
+
+// Messages takes up to max messages, all when max is 0, of the subscription with id. When there are none it waits
+// up to wait for one
+func (d *Daemon) Messages(ctx context.Context, id string, wait time.Duration, max int) ([]Message, error) {
+	if wait > maxWait {
+		wait = maxWait
+	}
+	deadline := time.After(wait)
+
+	for {
+		d.mu.Lock()
+		s, ok := d.subscriptions[id]
+		if !ok {
+			d.mu.Unlock()
+			return nil, ErrSubscriptionNotFound
+		}
+
+		if len(s.queue) > 0 || wait <= 0 {
+			count := len(s.queue)
+			if max > 0 && max < count {
+				count = max
+			}
+			messages := append([]Message{}, s.queue[:count]...)
+			s.queue = s.queue[count:]
+			d.mu.Unlock()
+			return messages, nil
+		}
+		notify := s.notify
+		d.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-deadline:
+			wait = 0
+		case <-ctx.Done():
+			return []Message{}, nil
+		}
+	}
+}
+
+func (d *Daemon) Stats() Stats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats := d.stats
+	stats.UptimeMs = int64(time.Since(d.start) / time.Millisecond)
+	stats.Subscriptions = len(d.subscriptions)
+	stats.ReceivedByType = copyCounts(d.stats.ReceivedByType)
+	stats.SentByType = copyCounts(d.stats.SentByType)
+	return stats
+}
+
+// ResetStats zeroes the counters, the uptime included
+func (d *Daemon) ResetStats() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.start = time.Now()
+	d.stats = Stats{ReceivedByType: map[string]int{}, SentByType: map[string]int{}}
+}
+
+func (s *subscription) info() Subscription {
+	return Subscription{Id: s.id, Filter: s.filter, Pending: len(s.queue), Dropped: s.dropped}
+}
+
+func copyCounts(counts map[string]int) map[string]int {
+	copied := make(map[string]int, len(counts))
+	for k, v := range counts {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,226 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package daemon
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"xappmock/dispatcher"
+	"xappmock/logger"
+	"xappmock/models"
+	"xappmock/rmr"
+	"xappmock/sender"
+)
+
+// messengerMock keeps the messages sent and returns the messages pushed to received
+type messengerMock struct {
+	sent     chan *rmr.MBuf
+	received chan *rmr.MBuf
+}
+
+func (m *messengerMock) Init(port string, maxMsgSize int, maxRetries int, flags int) *rmr.Messenger {
+	messenger := rmr.Messenger(m)
+	return &messenger
+}
+
+func (m *messengerMock) SendMsg(msg *rmr.MBuf) (*rmr.MBuf, error) {
+	select {
+	case m.sent <- msg:
+	default:
+	}
+	return msg, nil
+}
+
+func (m *messengerMock) RecvMsg() (*rmr.MBuf, error) {
+	return <-m.received, nil
+}
+
+func (m *messengerMock) IsReady() bool {
+	return true
+}
+
+func (m *messengerMock) Close() {
+}
+
+func initDaemonTest(t *testing.T) (*Daemon, *messengerMock) {
+	logLevel, _ := logger.LogLevelTokenToLevel("error")
+	log, err := logger.InitLogger(logLevel)
+	if err != nil {
+		t.Fatalf("#initDaemonTest - failed to initialize logger, error: %s", err)
+	}
+
+	_ = dispatcher.AddTemplate(models.JsonCommand{Id: "X2_SETUP_REQUEST", RmrMessageType: "10060", TransactionId: "e2e$", PackedPayload: "0006"})
+	_ = dispatcher.AddTemplate(models.JsonCommand{Id: "RIC_INDICATION_ACK", RmrMessageType: "12051", TransactionId: "ack"})
+
+	messenger := &messengerMock{sent: make(chan *rmr.MBuf, 100), received: make(chan *rmr.MBuf, 100)}
+	rmrService := rmr.NewService(rmr.Config{}, messenger)
+	return New(log, rmrService, sender.NewJsonSender(log)), messenger
+}
+
+func receive(d *Daemon, mType int, meid string, xAction string, payload []byte) {
+	d.deliver(&rmr.MBuf{MType: mType, Meid: meid, XAction: []byte(xAction), Payload: payload})
+}
+
+func TestSendRepeats(t *testing.T) {
+	d, messenger := initDaemonTest(t)
+
+	result, err := d.Send(context.Background(), &models.JsonCommand{Id: "X2_SETUP_REQUEST", RanName: "ran1", RepeatCount: 3})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Sent)
+	assert.Len(t, result.TransactionIds, 3)
+	assert.NotEqual(t, result.TransactionIds[0], result.TransactionIds[1])
+	for _, id := range result.TransactionIds {
+		msg := <-messenger.sent
+		assert.Equal(t, 10060, msg.MType)
+		assert.Equal(t, "ran1", msg.Meid)
+		assert.Equal(t, id, string(msg.XAction))
+	}
+
+	stats := d.Stats()
+	assert.Equal(t, 3, stats.Sent)
+	assert.Equal(t, map[string]int{"10060": 3}, stats.SentByType)
+}
+
+func TestSendFixedTransactionId(t *testing.T) {
+	d, messenger := initDaemonTest(t)
+
+	result, err := d.Send(context.Background(), &models.JsonCommand{Id: "RIC_INDICATION_ACK"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ack"}, result.TransactionIds)
+	assert.Equal(t, "ack", string((<-messenger.sent).XAction))
+}
+
+func TestSendUnknownTemplate(t *testing.T) {
+	d, _ := initDaemonTest(t)
+
+	_, err := d.Send(context.Background(), &models.JsonCommand{Id: "UNKNOWN"})
+
+	assert.Contains(t, err.Error(), "invalid rmr message type")
+}
+
+func TestSubscriptionFilters(t *testing.T) {
+	d, _ := initDaemonTest(t)
+	all, err := d.Subscribe(Filter{})
+	assert.Nil(t, err)
+	setup, err := d.Subscribe(Filter{RmrMessageType: "10061, 10062", Meid: "ran1"})
+	assert.Nil(t, err)
+
+	receive(d, 10061, "ran1", "1", []byte{0x20, 0x06})
+	receive(d, 10061, "ran2", "2", nil)
+	receive(d, 12050, "ran1", "3", nil)
+
+	messages, err := d.Messages(context.Background(), setup.Id, 0, 0)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, 10061, messages[0].RmrMessageType)
+	assert.Equal(t, "2006", messages[0].PackedPayload)
+	assert.Equal(t, "1", messages[0].TransactionId)
+
+	messages, err = d.Messages(context.Background(), all.Id, 0, 2)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, int64(1), messages[0].Seq)
+	info, _ := d.Subscription(all.Id)
+	assert.Equal(t, 1, info.Pending)
+
+	stats := d.Stats()
+	assert.Equal(t, 3, stats.Received)
+	assert.Equal(t, map[string]int{"10061": 2, "12050": 1}, stats.ReceivedByType)
+	assert.Equal(t, 2, stats.Subscriptions)
+}
+
+func TestSubscribeInvalidType(t *testing.T) {
+	d, _ := initDaemonTest(t)
+
+	_, err := d.Subscribe(Filter{RmrMessageType: "10061,x"})
+
+	assert.NotNil(t, err)
+}
+
+func TestMessagesWaitsForMessage(t *testing.T) {
+	d, messenger := initDaemonTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Receive(ctx)
+	subscription, _ := d.Subscribe(Filter{TransactionId: "42"})
+
+	time.AfterFunc(50*time.Millisecond, func() {
+		messenger.received <- &rmr.MBuf{MType: 10061, Meid: "ran1", XAction: []byte("41")}
+		messenger.received <- &rmr.MBuf{MType: 10061, Meid: "ran1", XAction: []byte("42\x00\x00")}
+	})
+
+	messages, err := d.Messages(context.Background(), subscription.Id, time.Second, 0)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "42", messages[0].TransactionId)
+}
+
+func TestMessagesWaitTimeout(t *testing.T) {
+	d, _ := initDaemonTest(t)
+	subscription, _ := d.Subscribe(Filter{})
+
+	start := time.Now()
+	messages, err := d.Messages(context.Background(), subscription.Id, 50*time.Millisecond, 0)
+
+	assert.Nil(t, err)
+	assert.Empty(t, messages)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+}
+
+func TestMessagesDropsOldest(t *testing.T) {
+	d, _ := initDaemonTest(t)
+	subscription, _ := d.Subscribe(Filter{})
+
+	for i := 0; i < subscriptionQueueSize+5; i++ {
+		receive(d, 10061, "ran1", "", nil)
+	}
+
+	info, _ := d.Subscription(subscription.Id)
+	assert.Equal(t, subscriptionQueueSize, info.Pending)
+	assert.Equal(t, 5, info.Dropped)
+	messages, _ := d.Messages(context.Background(), subscription.Id, 0, 1)
+	assert.Equal(t, int64(6), messages[0].Seq)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	d, _ := initDaemonTest(t)
+	subscription, _ := d.Subscribe(Filter{})
+
+	assert.True(t, d.Unsubscribe(subscription.Id))
+	assert.False(t, d.Unsubscribe(subscription.Id))
+	_, err := d.Messages(context.Background(), subscription.Id, 0, 0)
+	assert.Equal(t, ErrSubscriptionNotFound, err)
+}
+
+func TestResetStats(t *testing.T) {
+	d, _ := initDaemonTest(t)
+	receive(d, 10061, "ran1", "", nil)
+
+	d.ResetStats()
+
+	stats := d.Stats()
+	assert.Equal(t, 0, stats.Received)
+	assert.Empty(t, stats.ReceivedByType)
+}
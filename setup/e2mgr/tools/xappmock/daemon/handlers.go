@@ -0,0 +1,261 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"xappmock/dispatcher"
+	"xappmock/models"
+)
+
+type errorResponse struct {
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// NewHandler returns the REST API of d:
+//
+//	GET    /v1/health                           200 while the daemon runs
+//	GET    /v1/templates                        the templates, sorted by id
+//	POST   /v1/templates                        add or replace a template, or an array of them
+//	GET    /v1/templates/{id}                   a template
+//	DELETE /v1/templates/{id}                   remove a template
+//	POST   /v1/send                             send a command, merged with the template of its id
+//	GET    /v1/subscriptions                    the subscriptions
+//	POST   /v1/subscriptions                    subscribe to the messages a filter selects
+//	GET    /v1/subscriptions/{id}               a subscription
+//	DELETE /v1/subscriptions/{id}               unsubscribe
+//	GET    /v1/subscriptions/{id}/messages      take the messages of a subscription, ?waitMs= for one to arrive, ?max=
+//	GET    /v1/stats                            the traffic counters
+//	DELETE /v1/stats                            reset the traffic counters
+func NewHandler(d *Daemon) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/health", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		writeJson(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("/v1/templates", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJson(w, http.StatusOK, dispatcher.Templates())
+		case http.MethodPost:
+			addTemplates(w, r)
+		default:
+			methodNotAllowed(w)
+		}
+	})
+	mux.HandleFunc("/v1/templates/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1/templates/")
+		switch r.Method {
+		case http.MethodGet:
+			template, ok := dispatcher.Template(id)
+			if !ok {
+				writeError(w, http.StatusNotFound, "template not found")
+				return
+			}
+			writeJson(w, http.StatusOK, template)
+		case http.MethodDelete:
+			if !dispatcher.RemoveTemplate(id) {
+				writeError(w, http.StatusNotFound, "template not found")
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+	})
+	mux.HandleFunc("/v1/send", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+		command := &models.JsonCommand{}
+		if !readJson(w, r, command) {
+			return
+		}
+		result, err := d.Send(r.Context(), command)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		status := http.StatusOK
+		if result.Sent == 0 && result.Failed > 0 {
+			status = http.StatusBadGateway
+		}
+		writeJson(w, status, result)
+	})
+	mux.HandleFunc("/v1/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJson(w, http.StatusOK, d.Subscriptions())
+		case http.MethodPost:
+			filter := Filter{}
+			if !readJson(w, r, &filter) {
+				return
+			}
+			subscription, err := d.Subscribe(filter)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			writeJson(w, http.StatusCreated, subscription)
+		default:
+			methodNotAllowed(w)
+		}
+	})
+	mux.HandleFunc("/v1/subscriptions/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1/subscriptions/")
+		if strings.HasSuffix(path, "/messages") {
+			messages(d, w, r, strings.TrimSuffix(path, "/messages"))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			subscription, ok := d.Subscription(path)
+			if !ok {
+				writeError(w, http.StatusNotFound, ErrSubscriptionNotFound.Error())
+				return
+			}
+			writeJson(w, http.StatusOK, subscription)
+		case http.MethodDelete:
+			if !d.Unsubscribe(path) {
+				writeError(w, http.StatusNotFound, ErrSubscriptionNotFound.Error())
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+	})
+	mux.HandleFunc("/v1/stats", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJson(w, http.StatusOK, d.Stats())
+		case http.MethodDelete:
+			d.ResetStats()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+	})
+	return mux
+}
+
+// addTemplates adds the template of the body, or each template of an array
+func addTemplates(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	templates := []models.JsonCommand{}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(body, &templates)
+	} else {
+		template := models.JsonCommand{}
+		err = json.Unmarshal(body, &template)
+		templates = append(templates, template)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, template := range templates {
+		if len(template.Id) == 0 {
+			writeError(w, http.StatusBadRequest, "invalid template, no id")
+			return
+		}
+	}
+	for _, template := range templates {
+		_ = dispatcher.AddTemplate(template)
+	}
+	writeJson(w, http.StatusCreated, templates)
+}
+
+func messages(d *Daemon, w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	waitMs, err := queryInt(r, "waitMs")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "waitMs must be a non negative integer")
+		return
+	}
+	max, err := queryInt(r, "max")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "max must be a non negative integer")
+		return
+	}
+
+	messages, err := d.Messages(r.Context(), id, time.Duration(waitMs)*time.Millisecond, max)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJson(w, http.StatusOK, messages)
+}
+
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if len(value) == 0 {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err == nil && i < 0 {
+		err = strconv.ErrRange
+	}
+	return i, err
+}
+
+func readJson(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJson(w, status, errorResponse{ErrorMessage: message})
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+}
@@ -0,0 +1,177 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package daemon
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"xappmock/dispatcher"
+	"xappmock/models"
+)
+
+func request(t *testing.T, server *httptest.Server, method string, path string, body string) (int, string) {
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	assert.Nil(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	data, _ := ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func initHandlerTest(t *testing.T) (*httptest.Server, *Daemon, *messengerMock) {
+	d, messenger := initDaemonTest(t)
+	return httptest.NewServer(NewHandler(d)), d, messenger
+}
+
+func TestHealth(t *testing.T) {
+	server, _, _ := initHandlerTest(t)
+	defer server.Close()
+
+	status, body := request(t, server, http.MethodGet, "/v1/health", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status": "ok"}`, body)
+
+	status, _ = request(t, server, http.MethodPost, "/v1/health", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestTemplates(t *testing.T) {
+	server, _, _ := initHandlerTest(t)
+	defer server.Close()
+
+	status, _ := request(t, server, http.MethodPost, "/v1/templates", `[{"id": "RESET_REQUEST", "rmrMessageType": "10070"}, {"id": "RESET_RESPONSE", "rmrMessageType": "10071"}]`)
+	assert.Equal(t, http.StatusCreated, status)
+	status, _ = request(t, server, http.MethodPost, "/v1/templates", `{"id": "RESET_REQUEST", "rmrMessageType": "10070", "packedPayload": "0007"}`)
+	assert.Equal(t, http.StatusCreated, status)
+
+	status, body := request(t, server, http.MethodGet, "/v1/templates/RESET_REQUEST", "")
+	assert.Equal(t, http.StatusOK, status)
+	template := models.JsonCommand{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &template))
+	assert.Equal(t, "0007", template.PackedPayload)
+
+	status, body = request(t, server, http.MethodGet, "/v1/templates", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"Id":"RESET_RESPONSE"`)
+
+	status, _ = request(t, server, http.MethodDelete, "/v1/templates/RESET_RESPONSE", "")
+	assert.Equal(t, http.StatusNoContent, status)
+	_, ok := dispatcher.Template("RESET_RESPONSE")
+	assert.False(t, ok)
+	status, _ = request(t, server, http.MethodDelete, "/v1/templates/RESET_RESPONSE", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestTemplatesInvalid(t *testing.T) {
+	server, _, _ := initHandlerTest(t)
+	defer server.Close()
+
+	status, _ := request(t, server, http.MethodPost, "/v1/templates", `[{"id": "VALID"}, {"rmrMessageType": "10070"}]`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	_, ok := dispatcher.Template("VALID")
+	assert.False(t, ok)
+
+	status, _ = request(t, server, http.MethodPost, "/v1/templates", `{`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestSend(t *testing.T) {
+	server, _, messenger := initHandlerTest(t)
+	defer server.Close()
+
+	status, body := request(t, server, http.MethodPost, "/v1/send", `{"id": "X2_SETUP_REQUEST", "ranName": "ran7"}`)
+	assert.Equal(t, http.StatusOK, status)
+	result := SendResult{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &result))
+	assert.Equal(t, 1, result.Sent)
+	assert.Equal(t, "ran7", (<-messenger.sent).Meid)
+
+	status, _ = request(t, server, http.MethodPost, "/v1/send", `{"id": "UNKNOWN"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestSubscriptionMessages(t *testing.T) {
+	server, d, _ := initHandlerTest(t)
+	defer server.Close()
+
+	status, body := request(t, server, http.MethodPost, "/v1/subscriptions", `{"rmrMessageType": "10061"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	subscription := Subscription{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &subscription))
+
+	receive(d, 10061, "ran1", "7", []byte{0x20})
+	receive(d, 10062, "ran1", "8", nil)
+
+	status, body = request(t, server, http.MethodGet, "/v1/subscriptions/"+subscription.Id+"/messages?waitMs=100", "")
+	assert.Equal(t, http.StatusOK, status)
+	messages := []Message{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &messages))
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "20", messages[0].PackedPayload)
+
+	status, body = request(t, server, http.MethodGet, "/v1/subscriptions/"+subscription.Id+"/messages", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "[]\n", body)
+
+	status, _ = request(t, server, http.MethodGet, "/v1/subscriptions/"+subscription.Id+"/messages?waitMs=-1", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, body = request(t, server, http.MethodGet, "/v1/subscriptions", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"id":"`+subscription.Id+`"`)
+
+	status, _ = request(t, server, http.MethodDelete, "/v1/subscriptions/"+subscription.Id, "")
+	assert.Equal(t, http.StatusNoContent, status)
+	status, _ = request(t, server, http.MethodGet, "/v1/subscriptions/"+subscription.Id+"/messages", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = request(t, server, http.MethodGet, "/v1/subscriptions/"+subscription.Id, "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestSubscribeInvalid(t *testing.T) {
+	server, _, _ := initHandlerTest(t)
+	defer server.Close()
+
+	status, _ := request(t, server, http.MethodPost, "/v1/subscriptions", `{"rmrMessageType": "abc"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestStats(t *testing.T) {
+	server, d, _ := initHandlerTest(t)
+	defer server.Close()
+	receive(d, 10061, "ran1", "", nil)
+
+	status, body := request(t, server, http.MethodGet, "/v1/stats", "")
+	assert.Equal(t, http.StatusOK, status)
+	stats := Stats{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &stats))
+	assert.Equal(t, 1, stats.Received)
+
+	status, _ = request(t, server, http.MethodDelete, "/v1/stats", "")
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, 0, d.Stats().Received)
+}
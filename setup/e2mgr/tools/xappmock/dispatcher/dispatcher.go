@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 	"xappmock/enums"
@@ -38,6 +39,9 @@ import (
 // Id -> Command
 var configuration = make(map[string]*models.JsonCommand)
 
+// configurationMutex guards configuration, which the daemon changes while commands run
+var configurationMutex sync.RWMutex
+
 // Rmr Message Id -> Command
 var waitForRmrMessageType = make(map[int]*models.JsonCommand)
 
@@ -52,6 +56,53 @@ func addRmrMessageToWaitFor(rmrMessageToWaitFor string, command models.JsonComma
 	return nil
 }
 
+// AddTemplate adds cmd to the configuration, replacing the command of the same id
+func AddTemplate(cmd models.JsonCommand) error {
+	if len(cmd.Id) == 0 {
+		return errors.New(fmt.Sprintf("invalid cmd, no id"))
+	}
+
+	configurationMutex.Lock()
+	defer configurationMutex.Unlock()
+	configuration[cmd.Id] = &cmd
+	return nil
+}
+
+// Template returns the command of the configuration with id
+func Template(id string) (models.JsonCommand, bool) {
+	configurationMutex.RLock()
+	defer configurationMutex.RUnlock()
+
+	command, ok := configuration[id]
+	if !ok {
+		return models.JsonCommand{}, false
+	}
+	return *command, true
+}
+
+// Templates returns the commands of the configuration, sorted by id
+func Templates() []models.JsonCommand {
+	configurationMutex.RLock()
+	defer configurationMutex.RUnlock()
+
+	commands := make([]models.JsonCommand, 0, len(configuration))
+	for _, command := range configuration {
+		commands = append(commands, *command)
+	}
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Id < commands[j].Id })
+	return commands
+}
+
+// RemoveTemplate removes the command with id from the configuration and tells whether there was one
+func RemoveTemplate(id string) bool {
+	configurationMutex.Lock()
+	defer configurationMutex.Unlock()
+
+	_, ok := configuration[id]
+	delete(configuration, id)
+	return ok
+}
+
 type Dispatcher struct {
 	rmrService    *rmr.Service
 	processResult models.ProcessResult
@@ -72,11 +123,7 @@ func New(logger *logger.Logger, rmrService *rmr.Service, jsonSender *sender.Json
 }
 
 func (d *Dispatcher) JsonCommandsDecoderCB(cmd models.JsonCommand) error {
-	if len(cmd.Id) == 0 {
-		return errors.New(fmt.Sprintf("invalid cmd, no id"))
-	}
-	configuration[cmd.Id] = &cmd
-	return nil
+	return AddTemplate(cmd)
 
 	//	if len(cmd.ReceiveCommandId) == 0 {
 	//		return nil
@@ -134,7 +181,7 @@ func (d *Dispatcher) sendWithRepeat(ctx context.Context, command models.JsonComm
 }
 
 func getReceiveRmrMessageType(receiveCommandId string) (string, error) {
-	command, ok := configuration[receiveCommandId]
+	command, ok := Template(receiveCommandId)
 
 	if !ok {
 		return "", errors.New(fmt.Sprintf("invalid receive command id: %s", receiveCommandId))
@@ -203,7 +250,9 @@ func (d *Dispatcher) receiveHandler(ctx context.Context, sendAndReceiveWg *sync.
 	d.listenAndHandle(ctx, &listenAndHandleWg, command)
 }
 
-func getMergedCommand(cmd *models.JsonCommand) (models.JsonCommand, error) {
+// GetMergedCommand returns the command of the configuration with the id of cmd, overridden by the non empty fields
+// of cmd
+func GetMergedCommand(cmd *models.JsonCommand) (models.JsonCommand, error) {
 	var command models.JsonCommand
 	if len(cmd.Id) == 0 {
 		return command, errors.New(fmt.Sprintf("invalid command, no id"))
@@ -211,10 +260,10 @@ func getMergedCommand(cmd *models.JsonCommand) (models.JsonCommand, error) {
 
 	command = *cmd
 
-	conf, ok := configuration[cmd.Id]
+	conf, ok := Template(cmd.Id)
 
 	if ok {
-		command = *conf
+		command = conf
 		mergeConfigurationAndCommand(&command, cmd)
 	}
 
@@ -223,7 +272,7 @@ func getMergedCommand(cmd *models.JsonCommand) (models.JsonCommand, error) {
 
 func (d *Dispatcher) ProcessJsonCommand(ctx context.Context, cmd *models.JsonCommand) {
 
-	command, err := getMergedCommand(cmd)
+	command, err := GetMergedCommand(cmd)
 
 	if err != nil {
 		d.processResult.Err = err
@@ -251,13 +300,13 @@ func (d *Dispatcher) ProcessJsonCommand(ctx context.Context, cmd *models.JsonCom
 }
 
 func getResponseCommand(command models.JsonCommand) (*models.JsonCommand, error) {
-	responseCommand, ok := configuration[command.SendCommandId]
+	responseCommand, ok := Template(command.SendCommandId)
 
 	if !ok {
 		return nil, errors.New(fmt.Sprintf("invalid SendCommandId %s", command.SendCommandId))
 	}
 
-	return responseCommand, nil
+	return &responseCommand, nil
 }
 
 func (d *Dispatcher) listenAndHandleNoRepeat(ctx context.Context, command models.JsonCommand) {
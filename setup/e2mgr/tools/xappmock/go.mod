@@ -4,6 +4,7 @@ go 1.12
 
 require (
 	github.com/pkg/errors v0.8.1
+	github.com/stretchr/testify v1.4.0
 	go.uber.org/atomic v1.5.0
 	go.uber.org/zap v1.13.0
 )
@@ -0,0 +1,293 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package load
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+	"xappmock/dispatcher"
+	"xappmock/logger"
+	"xappmock/models"
+	"xappmock/rmr"
+	"xappmock/sender"
+)
+
+const (
+	defaultTransactionIdPrefix = "load-"
+	minExpiryInterval          = 10 * time.Millisecond
+)
+
+// Generator runs load profiles on an RMR service. It receives from the service for as long as the context of the
+// first run lasts, so a generator is used for one run at a time
+type Generator struct {
+	logger     *logger.Logger
+	rmrService *rmr.Service
+	jsonSender *sender.JsonSender
+	startOnce  sync.Once
+	mu         sync.Mutex
+	run        *run
+}
+
+func NewGenerator(logger *logger.Logger, rmrService *rmr.Service, jsonSender *sender.JsonSender) *Generator {
+	return &Generator{
+		logger:     logger,
+		rmrService: rmrService,
+		jsonSender: jsonSender,
+	}
+}
+
+// run is the state of a profile being run, guarded by the mutex of the generator
+type run struct {
+	expect    map[int]bool
+	timeout   time.Duration
+	pending   map[string]time.Time
+	latencies []time.Duration
+	report    Report
+}
+
+// Run sends the messages of profile and waits for the responses of the last ones, unless ctx is done first
+func (g *Generator) Run(ctx context.Context, profile *Profile) (*Report, error) {
+	err := profile.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := dispatcher.GetMergedCommand(profile.Send)
+	if err != nil {
+		return nil, err
+	}
+
+	ranNames := profile.ranNames()
+	state := &run{
+		timeout: time.Duration(profile.TimeoutMs) * time.Millisecond,
+		pending: make(map[string]time.Time),
+		report: Report{
+			Name:       profile.Name,
+			TargetRate: profile.Rate,
+			Senders:    profile.Senders,
+			Rans:       len(ranNames),
+		},
+	}
+	if state.report.Rans == 0 {
+		state.report.Rans = 1
+	}
+	if len(profile.Expect) > 0 {
+		state.expect, _ = parseMessageTypes(profile.Expect)
+	}
+
+	g.mu.Lock()
+	g.run = state
+	g.mu.Unlock()
+	g.startOnce.Do(func() { go g.receiveLoop(ctx) })
+
+	g.logger.Infof("#Generator.Run - load %s: %g msg/s for %d ms, ramp up %d ms, %d senders, %d RANs", profile.Name,
+		profile.Rate, profile.DurationMs, profile.RampUpMs, profile.Senders, state.report.Rans)
+
+	start := time.Now()
+	tickets := make(chan int, profile.Senders)
+	var senders sync.WaitGroup
+	for i := 0; i < profile.Senders; i++ {
+		senders.Add(1)
+		go func() {
+			defer senders.Done()
+			for n := range tickets {
+				g.send(command, ranNames, n)
+			}
+		}()
+	}
+
+	stopExpiry := make(chan struct{})
+	expiryDone := make(chan struct{})
+	go g.expireLoop(stopExpiry, expiryDone)
+
+	g.schedule(ctx, profile, start, tickets)
+	close(tickets)
+	senders.Wait()
+	sendDuration := time.Since(start)
+
+	g.waitForResponses(ctx, state.timeout)
+	close(stopExpiry)
+	<-expiryDone
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.run = nil
+
+	report := state.report
+	report.Timeouts += len(state.pending)
+	report.DurationMs = milliseconds(time.Since(start))
+	if sendDuration > 0 {
+		report.AchievedRate = float64(report.Sent) / sendDuration.Seconds()
+	}
+	report.Latency = newLatency(state.latencies)
+	return &report, nil
+}
+
+// schedule hands the index of each message to the senders when it is due, until the end of the run. Senders which
+// cannot keep up hold the schedule back, which shows as an achieved rate below the target
+func (g *Generator) schedule(ctx context.Context, profile *Profile, start time.Time, tickets chan<- int) {
+	duration := time.Duration(profile.DurationMs) * time.Millisecond
+
+	for n := 0; ; n++ {
+		offset := profile.offset(n)
+		if offset >= duration {
+			return
+		}
+
+		if wait := time.Until(start.Add(offset)); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case tickets <- n:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (g *Generator) send(command models.JsonCommand, ranNames []string, n int) {
+	if len(ranNames) > 0 {
+		command.RanName = ranNames[n%len(ranNames)]
+	}
+	command.TransactionId = transactionId(command.TransactionId)
+
+	// The response may be received before the send returns
+	g.mu.Lock()
+	g.run.pending[command.TransactionId] = time.Now()
+	g.mu.Unlock()
+
+	err := g.jsonSender.SendJsonRmrMessage(command, nil, g.rmrService)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err != nil {
+		g.logger.Errorf("#Generator.send - error sending rmr message: %s", err)
+		delete(g.run.pending, command.TransactionId)
+		g.run.report.SendErrors++
+		return
+	}
+	g.run.report.Sent++
+}
+
+// transactionId returns a transaction id unique per message, keeping the fixed part of template
+func transactionId(template string) string {
+	prefix := strings.TrimSuffix(template, "$")
+	if len(prefix) == 0 {
+		prefix = defaultTransactionIdPrefix
+	}
+	return sender.ExpandTransactionId(prefix + "$")
+}
+
+// waitForResponses returns once every message sent got its response or timed out
+func (g *Generator) waitForResponses(ctx context.Context, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		g.mu.Lock()
+		pending := len(g.run.pending)
+		g.mu.Unlock()
+
+		if pending == 0 {
+			return
+		}
+		time.Sleep(minExpiryInterval)
+	}
+}
+
+// expireLoop counts the messages waiting longer than the timeout for their response as timeouts
+func (g *Generator) expireLoop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	g.mu.Lock()
+	interval := g.run.timeout / 10
+	g.mu.Unlock()
+	if interval < minExpiryInterval {
+		interval = minExpiryInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			g.mu.Lock()
+			for id, sentAt := range g.run.pending {
+				if now.Sub(sentAt) > g.run.timeout {
+					delete(g.run.pending, id)
+					g.run.report.Timeouts++
+				}
+			}
+			g.mu.Unlock()
+		}
+	}
+}
+
+func (g *Generator) receiveLoop(ctx context.Context) {
+	for {
+		mbuf, err := g.rmrService.RecvMessage()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			g.logger.Errorf("#Generator.receiveLoop - error receiving message: %s", err)
+			continue
+		}
+
+		g.received(mbuf, time.Now())
+	}
+}
+
+func (g *Generator) received(mbuf *rmr.MBuf, at time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.run == nil {
+		return
+	}
+
+	if g.run.expect != nil && !g.run.expect[mbuf.MType] {
+		g.run.report.Unexpected++
+		return
+	}
+
+	id := strings.TrimRight(string(mbuf.XAction), "\x00")
+	sentAt, ok := g.run.pending[id]
+	if !ok {
+		g.run.report.Unmatched++
+		return
+	}
+
+	delete(g.run.pending, id)
+	g.run.report.Responses++
+	g.run.latencies = append(g.run.latencies, at.Sub(sentAt))
+}
@@ -0,0 +1,228 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package load
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+	"xappmock/dispatcher"
+	"xappmock/logger"
+	"xappmock/models"
+	"xappmock/rmr"
+	"xappmock/sender"
+)
+
+// messengerMock answers each message sent with the message returned by reply, if any, and keeps the Meids sent
+type messengerMock struct {
+	mu       sync.Mutex
+	reply    func(msg *rmr.MBuf) *rmr.MBuf
+	meids    []string
+	received chan *rmr.MBuf
+}
+
+func (m *messengerMock) Init(port string, maxMsgSize int, maxRetries int, flags int) *rmr.Messenger {
+	messenger := rmr.Messenger(m)
+	return &messenger
+}
+
+func (m *messengerMock) SendMsg(msg *rmr.MBuf) (*rmr.MBuf, error) {
+	m.mu.Lock()
+	m.meids = append(m.meids, msg.Meid)
+	m.mu.Unlock()
+
+	if reply := m.reply(msg); reply != nil {
+		m.received <- reply
+	}
+	return msg, nil
+}
+
+func (m *messengerMock) RecvMsg() (*rmr.MBuf, error) {
+	return <-m.received, nil
+}
+
+func (m *messengerMock) IsReady() bool {
+	return true
+}
+
+func (m *messengerMock) Close() {
+}
+
+func (m *messengerMock) sentMeids() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string{}, m.meids...)
+}
+
+func initGeneratorTest(t *testing.T, reply func(msg *rmr.MBuf) *rmr.MBuf) (*Generator, *messengerMock) {
+	logLevel, _ := logger.LogLevelTokenToLevel("error")
+	log, err := logger.InitLogger(logLevel)
+	if err != nil {
+		t.Fatalf("#initGeneratorTest - failed to initialize logger, error: %s", err)
+	}
+
+	d := dispatcher.New(log, nil, nil)
+	_ = d.JsonCommandsDecoderCB(models.JsonCommand{Id: "X2_SETUP_REQUEST", RmrMessageType: "10060", TransactionId: "e2e$", PackedPayload: "0006"})
+
+	messenger := &messengerMock{reply: reply, received: make(chan *rmr.MBuf, 10000)}
+	rmrService := rmr.NewService(rmr.Config{}, messenger)
+	return NewGenerator(log, rmrService, sender.NewJsonSender(log)), messenger
+}
+
+func answer(mType int) func(msg *rmr.MBuf) *rmr.MBuf {
+	return func(msg *rmr.MBuf) *rmr.MBuf {
+		return &rmr.MBuf{MType: mType, Meid: msg.Meid, XAction: msg.XAction}
+	}
+}
+
+func newProfile() *Profile {
+	return &Profile{
+		Name:       "x2 setup",
+		Send:       &models.JsonCommand{Id: "X2_SETUP_REQUEST"},
+		Expect:     "10061",
+		Rate:       200,
+		DurationMs: 250,
+		Senders:    4,
+		RanNames:   []string{"ran1", "ran2", "ran3"},
+		TimeoutMs:  200,
+	}
+}
+
+func TestRunMatchesResponses(t *testing.T) {
+	generator, messenger := initGeneratorTest(t, answer(10061))
+
+	start := time.Now()
+	report, err := generator.Run(context.Background(), newProfile())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 50, report.Sent)
+	assert.Equal(t, 50, report.Responses)
+	assert.Equal(t, 0, report.Timeouts)
+	assert.Equal(t, 0, report.Unmatched)
+	assert.Equal(t, 4, report.Senders)
+	assert.Equal(t, 3, report.Rans)
+	assert.InDelta(t, 200, report.AchievedRate, 40)
+	assert.True(t, time.Since(start) >= 240*time.Millisecond)
+	assert.True(t, report.Latency.MaxMs < 100)
+
+	counts := map[string]int{}
+	for _, meid := range messenger.sentMeids() {
+		counts[meid]++
+	}
+	assert.Equal(t, map[string]int{"ran1": 17, "ran2": 17, "ran3": 16}, counts)
+}
+
+func TestRunCountsTimeouts(t *testing.T) {
+	var mu sync.Mutex
+	count := 0
+	generator, _ := initGeneratorTest(t, func(msg *rmr.MBuf) *rmr.MBuf {
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+		if count%5 == 0 {
+			return nil
+		}
+		return answer(10061)(msg)
+	})
+
+	report, err := generator.Run(context.Background(), newProfile())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 50, report.Sent)
+	assert.Equal(t, 40, report.Responses)
+	assert.Equal(t, 10, report.Timeouts)
+	assert.Len(t, report.Latency.Histogram, len(histogramBoundsMs)+1)
+}
+
+func TestRunCountsUnexpectedAndUnmatched(t *testing.T) {
+	var mu sync.Mutex
+	count := 0
+	generator, _ := initGeneratorTest(t, func(msg *rmr.MBuf) *rmr.MBuf {
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+		switch count % 3 {
+		case 0:
+			return answer(10062)(msg)
+		case 1:
+			return &rmr.MBuf{MType: 10061, Meid: msg.Meid, XAction: []byte("other")}
+		}
+		return answer(10061)(msg)
+	})
+	profile := newProfile()
+	profile.Rate = 120
+
+	report, err := generator.Run(context.Background(), profile)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 30, report.Sent)
+	assert.Equal(t, 10, report.Responses)
+	assert.Equal(t, 10, report.Unexpected)
+	assert.Equal(t, 10, report.Unmatched)
+	assert.Equal(t, 20, report.Timeouts)
+}
+
+func TestRunRampUp(t *testing.T) {
+	generator, _ := initGeneratorTest(t, answer(10061))
+	profile := newProfile()
+	profile.RampUpMs = 250
+
+	report, err := generator.Run(context.Background(), profile)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 25, report.Sent)
+	assert.Equal(t, 25, report.Responses)
+}
+
+func TestRunCancelled(t *testing.T) {
+	generator, _ := initGeneratorTest(t, answer(10061))
+	profile := newProfile()
+	profile.DurationMs = 10000
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	report, err := generator.Run(ctx, profile)
+
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.True(t, report.Sent > 0 && report.Sent < 50)
+}
+
+func TestRunInvalidProfile(t *testing.T) {
+	generator, _ := initGeneratorTest(t, answer(10061))
+
+	_, err := generator.Run(context.Background(), &Profile{Send: &models.JsonCommand{Id: "X2_SETUP_REQUEST"}})
+
+	assert.NotNil(t, err)
+}
+
+func TestTransactionId(t *testing.T) {
+	first := transactionId("e2e$")
+	second := transactionId("e2e$")
+
+	assert.NotEqual(t, first, second)
+	assert.Regexp(t, "^e2e[0-9]+$", first)
+	assert.Regexp(t, "^fixed[0-9]+$", transactionId("fixed"))
+	assert.Regexp(t, "^load-[0-9]+$", transactionId(""))
+}
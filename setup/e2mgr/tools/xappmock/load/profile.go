@@ -0,0 +1,161 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+// Package load sends a command of the configuration at a controlled rate, ramping up to it, from concurrent senders
+// and on behalf of many RANs. Responses are matched to the messages sent by transaction id, and the latencies of
+// the matched ones are reported as percentiles and a histogram, with the messages left unanswered as timeouts.
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"xappmock/models"
+	"xappmock/rmr"
+)
+
+const defaultTimeoutMs = 1000
+
+// Profile describes a load run
+type Profile struct {
+	Name string
+	// Send is a command of the configuration, by Id, whose fields are overridden by the non empty ones here. Its
+	// transaction id is made unique per message, keeping a fixed prefix if any
+	Send *models.JsonCommand
+	// Expect is the type of the responses, or a comma separated list of types. Any type is a response when empty
+	Expect string
+	// Rate is the target number of messages per second, reached at the end of the ramp up
+	Rate       float64
+	DurationMs int
+	// RampUpMs raises the rate linearly from 0 to Rate at the start of the run
+	RampUpMs int
+	// Senders is the number of concurrent senders, 1 when not set
+	Senders int
+	// RanNames are the Meids of the messages, sent to in turn. RanNamePrefix with RanCount names RANs prefix1 to
+	// prefixN instead. The RAN name of the command is used when neither is set
+	RanNames      []string
+	RanNamePrefix string
+	RanCount      int
+	// TimeoutMs is how long a message waits for its response before it counts as a timeout, 1000 when not set
+	TimeoutMs int
+}
+
+// LoadProfile reads a profile from a JSON file. A profile without a name is named after its file
+func LoadProfile(path string) (*Profile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	profile := &Profile{}
+	err = json.Unmarshal(data, profile)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("load profile %s: %s", path, err))
+	}
+
+	if len(profile.Name) == 0 {
+		profile.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	err = profile.Validate()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("load profile %s: %s", path, err))
+	}
+	return profile, nil
+}
+
+// Validate checks the profile can run and sets the defaults of the fields not set
+func (p *Profile) Validate() error {
+	if p.Send == nil || len(p.Send.Id) == 0 {
+		return errors.New("send is missing a command id")
+	}
+	if len(p.Expect) > 0 {
+		if _, err := parseMessageTypes(p.Expect); err != nil {
+			return err
+		}
+	}
+	if p.Rate <= 0 {
+		return errors.New(fmt.Sprintf("rate must be positive, got %g", p.Rate))
+	}
+	if p.DurationMs <= 0 {
+		return errors.New(fmt.Sprintf("durationMs must be positive, got %d", p.DurationMs))
+	}
+	if p.RampUpMs < 0 || p.RampUpMs > p.DurationMs {
+		return errors.New(fmt.Sprintf("rampUpMs must be between 0 and durationMs, got %d", p.RampUpMs))
+	}
+	if p.Senders < 0 || p.RanCount < 0 || p.TimeoutMs < 0 {
+		return errors.New("senders, ranCount and timeoutMs must not be negative")
+	}
+	if p.RanCount > 0 && len(p.RanNamePrefix) == 0 {
+		return errors.New("ranCount requires ranNamePrefix")
+	}
+
+	if p.Senders == 0 {
+		p.Senders = 1
+	}
+	if p.TimeoutMs == 0 {
+		p.TimeoutMs = defaultTimeoutMs
+	}
+	return nil
+}
+
+// ranNames returns the Meids to send to in turn, none meaning the RAN name of the command
+func (p *Profile) ranNames() []string {
+	if len(p.RanNames) > 0 {
+		return p.RanNames
+	}
+
+	ranNames := make([]string, 0, p.RanCount)
+	for i := 1; i <= p.RanCount; i++ {
+		ranNames = append(ranNames, p.RanNamePrefix+strconv.Itoa(i))
+	}
+	return ranNames
+}
+
+// offset returns when the message of index n is due since the start of the run. The rate grows linearly during the
+// ramp up, so that n messages are due after sqrt(2 * rampUp * n / rate), and is constant afterwards
+func (p *Profile) offset(n int) time.Duration {
+	rampUp := time.Duration(p.RampUpMs) * time.Millisecond
+	rampUpMessages := p.Rate * rampUp.Seconds() / 2
+
+	if float64(n) < rampUpMessages {
+		return time.Duration(math.Sqrt(2*rampUp.Seconds()*float64(n)/p.Rate) * float64(time.Second))
+	}
+	return rampUp + time.Duration((float64(n)-rampUpMessages)/p.Rate*float64(time.Second))
+}
+
+func parseMessageTypes(messageTypes string) (map[int]bool, error) {
+	types := make(map[int]bool)
+	for _, messageType := range strings.Split(messageTypes, ",") {
+		messageType = strings.TrimSpace(messageType)
+		id, err := rmr.MessageIdToUint(messageType)
+		if err != nil || len(messageType) == 0 {
+			return nil, errors.New(fmt.Sprintf("invalid rmr message type %s", messageType))
+		}
+		types[int(id)] = true
+	}
+	return types, nil
+}
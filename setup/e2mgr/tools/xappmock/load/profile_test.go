@@ -0,0 +1,114 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package load
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"xappmock/models"
+)
+
+func TestValidateDefaults(t *testing.T) {
+	profile := &Profile{Send: &models.JsonCommand{Id: "X2_SETUP_REQUEST"}, Rate: 10, DurationMs: 1000}
+
+	assert.Nil(t, profile.Validate())
+	assert.Equal(t, 1, profile.Senders)
+	assert.Equal(t, defaultTimeoutMs, profile.TimeoutMs)
+}
+
+func TestValidateFailure(t *testing.T) {
+	send := &models.JsonCommand{Id: "X2_SETUP_REQUEST"}
+	profiles := map[string]*Profile{
+		"send":       {Rate: 10, DurationMs: 1000},
+		"expect":     {Send: send, Expect: "10061,x", Rate: 10, DurationMs: 1000},
+		"rate":       {Send: send, DurationMs: 1000},
+		"durationMs": {Send: send, Rate: 10},
+		"rampUpMs":   {Send: send, Rate: 10, DurationMs: 1000, RampUpMs: 2000},
+		"negative":   {Send: send, Rate: 10, DurationMs: 1000, Senders: -1},
+		"ranCount":   {Send: send, Rate: 10, DurationMs: 1000, RanCount: 3},
+	}
+
+	for name, profile := range profiles {
+		assert.NotNil(t, profile.Validate(), name)
+	}
+}
+
+func TestRanNames(t *testing.T) {
+	assert.Equal(t, []string{"ran1", "ran2"}, (&Profile{RanNames: []string{"ran1", "ran2"}, RanNamePrefix: "x", RanCount: 5}).ranNames())
+	assert.Equal(t, []string{"enb1", "enb2", "enb3"}, (&Profile{RanNamePrefix: "enb", RanCount: 3}).ranNames())
+	assert.Empty(t, (&Profile{}).ranNames())
+}
+
+func TestOffsetConstantRate(t *testing.T) {
+	profile := &Profile{Rate: 100}
+
+	assert.Equal(t, time.Duration(0), profile.offset(0))
+	assert.Equal(t, 10*time.Millisecond, profile.offset(1))
+	assert.Equal(t, time.Second, profile.offset(100))
+}
+
+func TestOffsetRampUp(t *testing.T) {
+	// 100 msg/s reached after 2s: 100 messages during the ramp up, the last half of them in its last 0.6s
+	profile := &Profile{Rate: 100, RampUpMs: 2000}
+
+	assert.Equal(t, time.Duration(0), profile.offset(0))
+	assert.InDelta(t, float64(2*time.Second), float64(profile.offset(100)), float64(time.Millisecond))
+	assert.InDelta(t, float64(1414*time.Millisecond), float64(profile.offset(50)), float64(time.Millisecond))
+	assert.InDelta(t, float64(2010*time.Millisecond), float64(profile.offset(101)), float64(time.Millisecond))
+	assert.True(t, profile.offset(1) > 10*time.Millisecond)
+}
+
+func TestLoadProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "load")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "x2_setup_load.json")
+	err = ioutil.WriteFile(path, []byte(`{"send": {"id": "X2_SETUP_REQUEST"}, "expect": "10061", "rate": 50, "durationMs": 5000, "ranNamePrefix": "ran", "ranCount": 10}`), 0644)
+	assert.Nil(t, err)
+
+	profile, err := LoadProfile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "x2_setup_load", profile.Name)
+	assert.Equal(t, "X2_SETUP_REQUEST", profile.Send.Id)
+	assert.Equal(t, 50.0, profile.Rate)
+	assert.Len(t, profile.ranNames(), 10)
+}
+
+func TestLoadProfileInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "load")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "invalid.json")
+	err = ioutil.WriteFile(path, []byte(`{"send": {"id": "X2_SETUP_REQUEST"}}`), 0644)
+	assert.Nil(t, err)
+
+	_, err = LoadProfile(path)
+	assert.Contains(t, err.Error(), "rate must be positive")
+
+	_, err = LoadProfile(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
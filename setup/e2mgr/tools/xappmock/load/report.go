@@ -0,0 +1,145 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package load
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// histogramBoundsMs are the upper bounds of the latency histogram buckets, a last bucket holding the slower ones
+var histogramBoundsMs = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000}
+
+type Report struct {
+	Name         string  `json:"name"`
+	DurationMs   float64 `json:"durationMs"`
+	TargetRate   float64 `json:"targetRate"`
+	AchievedRate float64 `json:"achievedRate"`
+	Senders      int     `json:"senders"`
+	Rans         int     `json:"rans"`
+	Sent         int     `json:"sent"`
+	SendErrors   int     `json:"sendErrors"`
+	Responses    int     `json:"responses"`
+	Timeouts     int     `json:"timeouts"`
+	// Unmatched counts the responses whose transaction id is not one of a message waiting for its response
+	Unmatched int `json:"unmatched"`
+	// Unexpected counts the messages received of another type than the responses
+	Unexpected int     `json:"unexpected"`
+	Latency    Latency `json:"latency"`
+}
+
+// Latency of the responses, in milliseconds
+type Latency struct {
+	MinMs     float64  `json:"minMs"`
+	MeanMs    float64  `json:"meanMs"`
+	P50Ms     float64  `json:"p50Ms"`
+	P90Ms     float64  `json:"p90Ms"`
+	P95Ms     float64  `json:"p95Ms"`
+	P99Ms     float64  `json:"p99Ms"`
+	P999Ms    float64  `json:"p999Ms"`
+	MaxMs     float64  `json:"maxMs"`
+	Histogram []Bucket `json:"histogram"`
+}
+
+// Bucket counts the latencies up to UpperBoundMs and above the bound of the previous bucket. The last bucket has no
+// upper bound
+type Bucket struct {
+	UpperBoundMs float64 `json:"upperBoundMs,omitempty"`
+	Count        int     `json:"count"`
+}
+
+func newLatency(latencies []time.Duration) Latency {
+	latency := Latency{Histogram: make([]Bucket, len(histogramBoundsMs)+1)}
+	for i, bound := range histogramBoundsMs {
+		latency.Histogram[i].UpperBoundMs = bound
+	}
+
+	if len(latencies) == 0 {
+		return latency
+	}
+
+	sorted := make([]float64, len(latencies))
+	sum := 0.0
+	for i, l := range latencies {
+		sorted[i] = milliseconds(l)
+		sum += sorted[i]
+	}
+	sort.Float64s(sorted)
+
+	for _, ms := range sorted {
+		i := sort.SearchFloat64s(histogramBoundsMs, ms)
+		latency.Histogram[i].Count++
+	}
+
+	latency.MinMs = sorted[0]
+	latency.MeanMs = sum / float64(len(sorted))
+	latency.P50Ms = percentile(sorted, 50)
+	latency.P90Ms = percentile(sorted, 90)
+	latency.P95Ms = percentile(sorted, 95)
+	latency.P99Ms = percentile(sorted, 99)
+	latency.P999Ms = percentile(sorted, 99.9)
+	latency.MaxMs = sorted[len(sorted)-1]
+	return latency
+}
+
+// percentile returns the nearest rank percentile p of sorted. The rank is rounded down first when within rounding
+// error of an integer, so that 99.9 of 1000 is the 999th
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p/100*float64(len(sorted)) - 1e-9))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "load %s: %.3fs, %d senders, %d RANs, target %.1f msg/s, achieved %.1f msg/s\n",
+		r.Name, r.DurationMs/1000, r.Senders, r.Rans, r.TargetRate, r.AchievedRate)
+	fmt.Fprintf(&b, "sent: %d | send errors: %d | responses: %d | timeouts: %d | unmatched: %d | unexpected: %d\n",
+		r.Sent, r.SendErrors, r.Responses, r.Timeouts, r.Unmatched, r.Unexpected)
+
+	if r.Responses == 0 {
+		b.WriteString("latency: no responses\n")
+		return b.String()
+	}
+
+	l := r.Latency
+	fmt.Fprintf(&b, "latency ms: min %.3f | mean %.3f | p50 %.3f | p90 %.3f | p95 %.3f | p99 %.3f | p99.9 %.3f | max %.3f\n",
+		l.MinMs, l.MeanMs, l.P50Ms, l.P90Ms, l.P95Ms, l.P99Ms, l.P999Ms, l.MaxMs)
+
+	for _, bucket := range l.Histogram {
+		if bucket.UpperBoundMs == 0 {
+			fmt.Fprintf(&b, "  >  %6g ms: %d\n", histogramBoundsMs[len(histogramBoundsMs)-1], bucket.Count)
+			continue
+		}
+		fmt.Fprintf(&b, "  <= %6g ms: %d\n", bucket.UpperBoundMs, bucket.Count)
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package load
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewLatency(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1000; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	latency := newLatency(latencies)
+
+	assert.Equal(t, 1.0, latency.MinMs)
+	assert.Equal(t, 500.5, latency.MeanMs)
+	assert.Equal(t, 500.0, latency.P50Ms)
+	assert.Equal(t, 900.0, latency.P90Ms)
+	assert.Equal(t, 950.0, latency.P95Ms)
+	assert.Equal(t, 990.0, latency.P99Ms)
+	assert.Equal(t, 999.0, latency.P999Ms)
+	assert.Equal(t, 1000.0, latency.MaxMs)
+
+	counts := []int{}
+	total := 0
+	for _, bucket := range latency.Histogram {
+		counts = append(counts, bucket.Count)
+		total += bucket.Count
+	}
+	assert.Equal(t, []int{1, 1, 3, 5, 10, 30, 50, 100, 300, 500, 0, 0, 0}, counts)
+	assert.Equal(t, 1000, total)
+}
+
+func TestNewLatencySlowest(t *testing.T) {
+	latency := newLatency([]time.Duration{6 * time.Second})
+
+	last := latency.Histogram[len(latency.Histogram)-1]
+	assert.Equal(t, 0.0, last.UpperBoundMs)
+	assert.Equal(t, 1, last.Count)
+}
+
+func TestNewLatencyEmpty(t *testing.T) {
+	latency := newLatency(nil)
+
+	assert.Equal(t, 0.0, latency.MaxMs)
+	assert.Len(t, latency.Histogram, len(histogramBoundsMs)+1)
+}
+
+func TestReportString(t *testing.T) {
+	report := Report{Name: "x2 setup", DurationMs: 2000, TargetRate: 100, AchievedRate: 99.5, Senders: 2, Rans: 10,
+		Sent: 199, Responses: 198, Timeouts: 1, Latency: newLatency([]time.Duration{time.Millisecond, 3 * time.Millisecond})}
+
+	lines := strings.Split(report.String(), "\n")
+	assert.Equal(t, "load x2 setup: 2.000s, 2 senders, 10 RANs, target 100.0 msg/s, achieved 99.5 msg/s", lines[0])
+	assert.Equal(t, "sent: 199 | send errors: 0 | responses: 198 | timeouts: 1 | unmatched: 0 | unexpected: 0", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "latency ms: min 1.000 | mean 2.000 | p50 1.000"))
+	assert.Equal(t, "  <=      1 ms: 1", lines[3])
+	assert.Equal(t, "  >    5000 ms: 0", lines[15])
+}
+
+func TestReportStringNoResponses(t *testing.T) {
+	report := Report{Name: "x2 setup", Sent: 10, Timeouts: 10}
+
+	assert.Contains(t, report.String(), "latency: no responses")
+}
+
+func TestReportJson(t *testing.T) {
+	report := Report{Name: "x2 setup", Sent: 2, Responses: 1, Latency: newLatency([]time.Duration{7 * time.Second})}
+
+	data, err := json.Marshal(report)
+	assert.Nil(t, err)
+
+	decoded := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "x2 setup", decoded["name"])
+	assert.Equal(t, 7000.0, decoded["latency"].(map[string]interface{})["p99Ms"])
+	histogram := decoded["latency"].(map[string]interface{})["histogram"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"count": 1.0}, histogram[len(histogram)-1])
+}
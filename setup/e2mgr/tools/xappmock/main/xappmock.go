@@ -22,16 +22,22 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
 	"time"
+	"xappmock/daemon"
 	"xappmock/dispatcher"
 	"xappmock/frontend"
+	"xappmock/load"
 	"xappmock/logger"
 	"xappmock/rmr"
+	"xappmock/scenario"
 	"xappmock/sender"
 )
 
@@ -77,17 +83,12 @@ func main() {
 
 	logger.Infof("#main - xApp Mock is up and running...")
 
+	scenarioPath := flag.String("scenario", "", "scenario file, or directory of scenario files, to run instead of a command")
+	junitPath := flag.String("junit", "", "file to write the scenario results to as JUnit XML")
+	loadPath := flag.String("load", "", "load profile file to run instead of a command")
+	loadJsonPath := flag.String("load-json", "", "file to write the load report to as JSON")
+	listenAddress := flag.String("listen", "", "address to serve the REST API on, e.g. :8080, keeping xappmock running until interrupted")
 	flag.Parse()
-	cmd := flag.Arg(0) /*first remaining argument after flags have been processed*/
-
-	command, err := frontend.DecodeJsonCommand([]byte(cmd))
-
-	if err != nil {
-		logger.Errorf("#main - command decoding error: %s", err)
-		rmrService.CloseContext()
-		logger.Infof("#main - xApp Mock is down")
-		return
-	}
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
@@ -100,6 +101,50 @@ func main() {
 		rmrService.CloseContext()
 	}()
 
+	if len(*scenarioPath) > 0 {
+		passed := runScenarios(ctx, logger, jsonSender, *scenarioPath, *junitPath)
+		cancel()
+		rmrService.CloseContext()
+		logger.Infof("#main - xApp Mock is down")
+		if !passed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(*listenAddress) > 0 {
+		passed := runDaemon(ctx, logger, jsonSender, *listenAddress)
+		cancel()
+		rmrService.CloseContext()
+		logger.Infof("#main - xApp Mock is down")
+		if !passed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(*loadPath) > 0 {
+		passed := runLoad(ctx, logger, jsonSender, *loadPath, *loadJsonPath)
+		cancel()
+		rmrService.CloseContext()
+		logger.Infof("#main - xApp Mock is down")
+		if !passed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	cmd := flag.Arg(0) /*first remaining argument after flags have been processed*/
+
+	command, err := frontend.DecodeJsonCommand([]byte(cmd))
+
+	if err != nil {
+		logger.Errorf("#main - command decoding error: %s", err)
+		rmrService.CloseContext()
+		logger.Infof("#main - xApp Mock is down")
+		return
+	}
+
 	dispatcherDesc.ProcessJsonCommand(ctx, command)
 	pr := dispatcherDesc.GetProcessResult()
 
@@ -117,3 +162,98 @@ func main() {
 	rmrService.CloseContext() // TODO: called twice
 	logger.Infof("#main - xApp Mock is down")
 }
+
+// runScenarios runs the scenarios of scenarioPath, prints whether each passed and writes them to junitPath if set.
+// It returns whether all of them passed
+func runScenarios(ctx context.Context, logger *logger.Logger, jsonSender *sender.JsonSender, scenarioPath string, junitPath string) bool {
+	scenarios, err := scenario.Load(scenarioPath)
+	if err != nil {
+		logger.Errorf("#main - failed to load scenarios: %s", err)
+		return false
+	}
+
+	start := time.Now()
+	results := scenario.NewRunner(logger, rmrService, jsonSender).RunAll(ctx, scenarios)
+
+	passed := 0
+	for _, result := range results {
+		fmt.Println(result)
+		if result.Passed {
+			passed++
+		}
+	}
+	fmt.Printf("%d of %d scenarios passed\n", passed, len(results))
+
+	if len(junitPath) > 0 {
+		file, err := os.Create(junitPath)
+		if err != nil {
+			logger.Errorf("#main - failed to create %s: %s", junitPath, err)
+			return false
+		}
+		defer file.Close()
+
+		err = scenario.WriteJUnit(file, "xappmock", start, results)
+		if err != nil {
+			logger.Errorf("#main - failed to write %s: %s", junitPath, err)
+			return false
+		}
+	}
+
+	return passed == len(results)
+}
+
+// runLoad runs the load profile of loadPath, prints its report and writes it to jsonPath if set. It returns whether
+// the profile could be run
+func runLoad(ctx context.Context, logger *logger.Logger, jsonSender *sender.JsonSender, loadPath string, jsonPath string) bool {
+	profile, err := load.LoadProfile(loadPath)
+	if err != nil {
+		logger.Errorf("#main - failed to load the load profile: %s", err)
+		return false
+	}
+
+	report, err := load.NewGenerator(logger, rmrService, jsonSender).Run(ctx, profile)
+	if err != nil {
+		logger.Errorf("#main - failed to run the load profile: %s", err)
+		return false
+	}
+
+	fmt.Print(report)
+
+	if len(jsonPath) > 0 {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			logger.Errorf("#main - failed to encode the load report: %s", err)
+			return false
+		}
+
+		err = ioutil.WriteFile(jsonPath, append(data, '\n'), 0644)
+		if err != nil {
+			logger.Errorf("#main - failed to write %s: %s", jsonPath, err)
+			return false
+		}
+	}
+
+	return true
+}
+
+// runDaemon serves the REST API on address until ctx is done. It returns whether the API could be served
+func runDaemon(ctx context.Context, logger *logger.Logger, jsonSender *sender.JsonSender, address string) bool {
+	d := daemon.New(logger, rmrService, jsonSender)
+	go d.Receive(ctx)
+
+	server := &http.Server{Addr: address, Handler: daemon.NewHandler(d)}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Infof("#main - serving the REST API on %s", address)
+	err := server.ListenAndServe()
+	if err != http.ErrServerClosed {
+		logger.Errorf("#main - failed to serve the REST API: %s", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package scenario
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	results := []Result{
+		{
+			Name:     "x2 setup",
+			Passed:   true,
+			Duration: 1500 * time.Millisecond,
+			Steps:    []StepResult{{Name: "request", Passed: true, Details: "sent X2_SETUP_REQUEST"}},
+		},
+		{
+			Name:     "subscription",
+			Passed:   false,
+			Failure:  "answer: no message type 12011 <within 100 ms>",
+			Duration: 500 * time.Millisecond,
+			Steps:    []StepResult{{Name: "answer", Details: "no message type 12011 <within 100 ms>"}},
+		},
+	}
+	buffer := &bytes.Buffer{}
+
+	err := WriteJUnit(buffer, "xappmock", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), results)
+
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(buffer.String(), xml.Header))
+
+	suites := junitTestSuites{}
+	assert.Nil(t, xml.Unmarshal(buffer.Bytes(), &suites))
+	assert.Len(t, suites.Suites, 1)
+	suite := suites.Suites[0]
+	assert.Equal(t, "xappmock", suite.Name)
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, "2.000", suite.Time)
+	assert.Equal(t, "2020-01-02T03:04:05", suite.Timestamp)
+	assert.Nil(t, suite.TestCases[0].Failure)
+	assert.Equal(t, "1.500", suite.TestCases[0].Time)
+	assert.Contains(t, suite.TestCases[0].SystemOut, "request [ok")
+	assert.Equal(t, "answer: no message type 12011 <within 100 ms>", suite.TestCases[1].Failure.Message)
+	assert.Contains(t, suite.TestCases[1].Failure.Text, "answer [failed")
+}
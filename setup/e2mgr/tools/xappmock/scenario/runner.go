@@ -0,0 +1,275 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+	"xappmock/dispatcher"
+	"xappmock/logger"
+	"xappmock/models"
+	"xappmock/rmr"
+	"xappmock/sender"
+)
+
+const (
+	// maxExecutedSteps stops scenarios whose branches loop for ever
+	maxExecutedSteps  = 1000
+	receivedQueueSize = 1024
+)
+
+type StepResult struct {
+	Name     string
+	Passed   bool
+	Details  string
+	Duration time.Duration
+}
+
+type Result struct {
+	Name     string
+	Passed   bool
+	Failure  string
+	Duration time.Duration
+	Steps    []StepResult
+}
+
+func (r Result) String() string {
+	if r.Passed {
+		return fmt.Sprintf("PASS %s (%.3fs)", r.Name, r.Duration.Seconds())
+	}
+	return fmt.Sprintf("FAIL %s (%.3fs): %s", r.Name, r.Duration.Seconds(), r.Failure)
+}
+
+// Runner runs scenarios one after the other on an RMR service, which it receives from for as long as the context
+// of the first run lasts
+type Runner struct {
+	logger     *logger.Logger
+	rmrService *rmr.Service
+	jsonSender *sender.JsonSender
+	received   chan *rmr.MBuf
+	startOnce  sync.Once
+}
+
+func NewRunner(logger *logger.Logger, rmrService *rmr.Service, jsonSender *sender.JsonSender) *Runner {
+	return &Runner{
+		logger:     logger,
+		rmrService: rmrService,
+		jsonSender: jsonSender,
+		received:   make(chan *rmr.MBuf, receivedQueueSize),
+	}
+}
+
+// run keeps what a scenario sent and received last
+type run struct {
+	sentMeid          string
+	sentTransactionId string
+	received          *rmr.MBuf
+}
+
+func (r *Runner) RunAll(ctx context.Context, scenarios []*Scenario) []Result {
+	results := make([]Result, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		results = append(results, r.Run(ctx, scenario))
+	}
+	return results
+}
+
+func (r *Runner) Run(ctx context.Context, scenario *Scenario) Result {
+	r.forgetReceived()
+	r.startOnce.Do(func() { go r.receiveLoop(ctx) })
+
+	start := time.Now()
+	result := Result{Name: scenario.Name, Passed: true}
+	stepIndexes := make(map[string]int)
+	for i, step := range scenario.Steps {
+		stepIndexes[step.Name] = i
+	}
+
+	state := &run{}
+	for i, executed := 0, 0; i < len(scenario.Steps); executed++ {
+		step := scenario.Steps[i]
+
+		if executed == maxExecutedSteps {
+			result.Passed = false
+			result.Failure = fmt.Sprintf("%s: more than %d steps executed", step.Name, maxExecutedSteps)
+			break
+		}
+
+		stepStart := time.Now()
+		next, details, err := r.runStep(ctx, state, step)
+		stepResult := StepResult{Name: step.Name, Passed: err == nil, Details: details, Duration: time.Since(stepStart)}
+
+		if err != nil {
+			stepResult.Details = err.Error()
+			result.Steps = append(result.Steps, stepResult)
+			result.Passed = false
+			result.Failure = fmt.Sprintf("%s: %s", step.Name, err)
+			r.logger.Errorf("#Runner.Run - scenario %s - %s failed: %s", scenario.Name, step.Name, err)
+			break
+		}
+
+		result.Steps = append(result.Steps, stepResult)
+		r.logger.Infof("#Runner.Run - scenario %s - %s: %s", scenario.Name, step.Name, details)
+
+		switch next {
+		case "":
+			i++
+		case End:
+			i = len(scenario.Steps)
+		default:
+			i = stepIndexes[next]
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result
+}
+
+// runStep returns the step to go to, empty for the next one, and what happened
+func (r *Runner) runStep(ctx context.Context, state *run, step Step) (string, string, error) {
+	switch {
+	case step.Send != nil:
+		details, err := r.send(state, step.Send)
+		return "", details, err
+	case step.Expect != nil:
+		return r.expect(ctx, state, step)
+	default:
+		select {
+		case <-time.After(time.Duration(step.SleepMs) * time.Millisecond):
+		case <-ctx.Done():
+			return "", "", ctx.Err()
+		}
+		return "", fmt.Sprintf("slept %d ms", step.SleepMs), nil
+	}
+}
+
+func (r *Runner) send(state *run, cmd *models.JsonCommand) (string, error) {
+	command, err := dispatcher.GetMergedCommand(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	// Without a transaction id of its own, a message answers the last one received
+	xAction := []byte{}
+	if state.received != nil {
+		xAction = state.received.XAction
+	}
+	command.TransactionId = sender.ExpandTransactionId(command.TransactionId)
+	if len(command.TransactionId) == 0 {
+		command.TransactionId = string(xAction)
+	}
+
+	err = r.jsonSender.SendJsonRmrMessage(command, &xAction, r.rmrService)
+	if err != nil {
+		return "", err
+	}
+
+	state.sentMeid = command.RanName
+	state.sentTransactionId = command.TransactionId
+	return fmt.Sprintf("sent %s, message type: %s, meid: %s, transaction id: %s", command.Id, command.RmrMessageType, command.RanName, command.TransactionId), nil
+}
+
+func (r *Runner) expect(ctx context.Context, state *run, step Step) (string, string, error) {
+	expect := step.Expect
+	messageTypes, err := parseMessageTypes(expect.RmrMessageType)
+	if err != nil {
+		return "", "", err
+	}
+	meid := resolve(expect.Meid, state.sentMeid)
+	transactionId := resolve(expect.TransactionId, state.sentTransactionId)
+
+	timeout := time.After(time.Duration(expect.TimeoutMs) * time.Millisecond)
+	for {
+		select {
+		case mbuf := <-r.received:
+			messageInfo := models.NewMessageInfo(mbuf.MType, mbuf.Meid, mbuf.Payload, mbuf.XAction)
+			if !messageTypes[mbuf.MType] || (len(meid) > 0 && mbuf.Meid != meid) ||
+				(len(transactionId) > 0 && string(mbuf.XAction) != transactionId) {
+				r.logger.Infof("#Runner.expect - %s - received unexpected msg: %s", step.Name, messageInfo)
+				continue
+			}
+
+			state.received = mbuf
+			details := fmt.Sprintf("received message type: %d, meid: %s, transaction id: %s", mbuf.MType, mbuf.Meid, mbuf.XAction)
+			next, err := takeBranch(step.Branches, mbuf)
+			return next, details, err
+		case <-timeout:
+			return "", "", errors.New(fmt.Sprintf("no message type %s, meid: %s, transaction id: %s received within %d ms",
+				expect.RmrMessageType, meid, transactionId, expect.TimeoutMs))
+		case <-ctx.Done():
+			return "", "", ctx.Err()
+		}
+	}
+}
+
+func takeBranch(branches []Branch, mbuf *rmr.MBuf) (string, error) {
+	for i, branch := range branches {
+		if !branch.matches(mbuf.MType, mbuf.Payload) {
+			continue
+		}
+		if len(branch.Fail) > 0 {
+			return "", errors.New(fmt.Sprintf("branch %d: %s", i+1, branch.Fail))
+		}
+		return branch.Goto, nil
+	}
+	return "", nil
+}
+
+func resolve(value string, sent string) string {
+	if value == SentValue {
+		return sent
+	}
+	return value
+}
+
+func (r *Runner) receiveLoop(ctx context.Context) {
+	for {
+		mbuf, err := r.rmrService.RecvMessage()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			r.logger.Errorf("#Runner.receiveLoop - error receiving message: %s", err)
+			continue
+		}
+
+		select {
+		case r.received <- mbuf:
+		default:
+			r.logger.Errorf("#Runner.receiveLoop - queue full, dropping message type %d", mbuf.MType)
+		}
+	}
+}
+
+// forgetReceived drops what an earlier scenario left unread
+func (r *Runner) forgetReceived() {
+	for {
+		select {
+		case <-r.received:
+		default:
+			return
+		}
+	}
+}
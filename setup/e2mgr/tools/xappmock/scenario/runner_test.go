@@ -0,0 +1,213 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package scenario
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+	"xappmock/dispatcher"
+	"xappmock/logger"
+	"xappmock/models"
+	"xappmock/rmr"
+	"xappmock/sender"
+)
+
+// messengerMock answers each message sent with the messages returned by reply, and keeps the first ones sent
+type messengerMock struct {
+	reply    func(msg *rmr.MBuf) []*rmr.MBuf
+	sent     chan *rmr.MBuf
+	received chan *rmr.MBuf
+}
+
+func (m *messengerMock) Init(port string, maxMsgSize int, maxRetries int, flags int) *rmr.Messenger {
+	messenger := rmr.Messenger(m)
+	return &messenger
+}
+
+func (m *messengerMock) SendMsg(msg *rmr.MBuf) (*rmr.MBuf, error) {
+	select {
+	case m.sent <- msg:
+	default:
+	}
+	if m.reply != nil {
+		for _, reply := range m.reply(msg) {
+			m.received <- reply
+		}
+	}
+	return msg, nil
+}
+
+func (m *messengerMock) RecvMsg() (*rmr.MBuf, error) {
+	return <-m.received, nil
+}
+
+func (m *messengerMock) IsReady() bool {
+	return true
+}
+
+func (m *messengerMock) Close() {
+}
+
+func initRunnerTest(t *testing.T, reply func(msg *rmr.MBuf) []*rmr.MBuf) (*Runner, *messengerMock) {
+	logLevel, _ := logger.LogLevelTokenToLevel("error")
+	log, err := logger.InitLogger(logLevel)
+	if err != nil {
+		t.Fatalf("#initRunnerTest - failed to initialize logger, error: %s", err)
+	}
+
+	d := dispatcher.New(log, nil, nil)
+	_ = d.JsonCommandsDecoderCB(models.JsonCommand{Id: "X2_SETUP_REQUEST", RmrMessageType: "10060", TransactionId: "e2e$", PackedPayload: "0006"})
+	_ = d.JsonCommandsDecoderCB(models.JsonCommand{Id: "RIC_INDICATION_ACK", RmrMessageType: "12051", PackedPayload: ""})
+
+	messenger := &messengerMock{reply: reply, sent: make(chan *rmr.MBuf, 100), received: make(chan *rmr.MBuf, 100)}
+	rmrService := rmr.NewService(rmr.Config{}, messenger)
+	return NewRunner(log, rmrService, sender.NewJsonSender(log)), messenger
+}
+
+func answer(mType int, payload string) func(msg *rmr.MBuf) []*rmr.MBuf {
+	return func(msg *rmr.MBuf) []*rmr.MBuf {
+		if msg.MType != 10060 {
+			return nil
+		}
+		unrelated := rmr.NewMBuf(10061, 0, nil, []byte("other"))
+		unrelated.Meid = "RanY"
+		response := rmr.NewMBuf(mType, len(payload), []byte(payload), msg.XAction)
+		response.Meid = msg.Meid
+		return []*rmr.MBuf{unrelated, response}
+	}
+}
+
+func nextSent(t *testing.T, messenger *messengerMock) *rmr.MBuf {
+	select {
+	case msg := <-messenger.sent:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatalf("#nextSent - no message sent")
+		return nil
+	}
+}
+
+func setupScenario(branches ...Branch) *Scenario {
+	scenario := &Scenario{
+		Name: "setup",
+		Steps: []Step{
+			{Name: "request", Send: &models.JsonCommand{Id: "X2_SETUP_REQUEST", RanName: "RanX"}},
+			{Name: "answer", Expect: &Expect{RmrMessageType: "10061,10062", Meid: SentValue, TransactionId: SentValue, TimeoutMs: 500}, Branches: branches},
+			{Name: "not reached unless no branch", Expect: &Expect{RmrMessageType: "1", TimeoutMs: 10}},
+		},
+	}
+	return scenario
+}
+
+func TestRunBranchToEnd(t *testing.T) {
+	runner, messenger := initRunnerTest(t, answer(10061, `{"cause": "none"}`))
+
+	result := runner.Run(context.Background(), setupScenario(Branch{RmrMessageType: "10061", Goto: End}))
+
+	assert.True(t, result.Passed, result.Failure)
+	assert.Len(t, result.Steps, 2)
+	sent := nextSent(t, messenger)
+	assert.Equal(t, "RanX", sent.Meid)
+	assert.True(t, strings.HasPrefix(string(sent.XAction), "e2e"))
+	assert.Contains(t, result.Steps[1].Details, string(sent.XAction))
+}
+
+func TestRunBranchFail(t *testing.T) {
+	runner, _ := initRunnerTest(t, answer(10062, `{"cause": "transport"}`))
+
+	result := runner.Run(context.Background(), setupScenario(
+		Branch{RmrMessageType: "10061", Goto: End},
+		Branch{Field: "cause", Equals: "transport", Fail: "setup failed"},
+	))
+
+	assert.False(t, result.Passed)
+	assert.Equal(t, "answer: branch 2: setup failed", result.Failure)
+	assert.Len(t, result.Steps, 2)
+	assert.False(t, result.Steps[1].Passed)
+}
+
+func TestRunNoBranchTakenContinues(t *testing.T) {
+	runner, _ := initRunnerTest(t, answer(10061, ""))
+
+	result := runner.Run(context.Background(), setupScenario(Branch{RmrMessageType: "10062", Goto: End}))
+
+	assert.False(t, result.Passed)
+	assert.Len(t, result.Steps, 3)
+	assert.Contains(t, result.Failure, "not reached unless no branch: no message type 1")
+}
+
+func TestRunTimeout(t *testing.T) {
+	runner, _ := initRunnerTest(t, nil)
+
+	start := time.Now()
+	result := runner.Run(context.Background(), setupScenario())
+
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Failure, "answer: no message type 10061,10062, meid: RanX")
+	assert.True(t, time.Since(start) >= 500*time.Millisecond)
+}
+
+func TestRunAnswerUsesReceivedTransactionId(t *testing.T) {
+	runner, messenger := initRunnerTest(t, nil)
+	indication := rmr.NewMBuf(12050, 0, nil, []byte("indication-1"))
+	messenger.received <- indication
+
+	result := runner.Run(context.Background(), &Scenario{
+		Name: "indication",
+		Steps: []Step{
+			{Expect: &Expect{RmrMessageType: "12050", TimeoutMs: 500}},
+			{Send: &models.JsonCommand{Id: "RIC_INDICATION_ACK"}},
+		},
+	})
+
+	assert.True(t, result.Passed, result.Failure)
+	assert.Equal(t, "indication-1", string(nextSent(t, messenger).XAction))
+}
+
+func TestRunLoopingScenarioStops(t *testing.T) {
+	runner, _ := initRunnerTest(t, answer(10061, ""))
+
+	result := runner.Run(context.Background(), &Scenario{
+		Name: "loop",
+		Steps: []Step{
+			{Name: "request", Send: &models.JsonCommand{Id: "X2_SETUP_REQUEST", RanName: "RanX"}},
+			{Name: "answer", Expect: &Expect{RmrMessageType: "10061", Meid: SentValue, TimeoutMs: 500}, Branches: []Branch{{Goto: "request"}}},
+		},
+	})
+
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Failure, "more than 1000 steps executed")
+	assert.Len(t, result.Steps, maxExecutedSteps)
+}
+
+func TestRunCancelled(t *testing.T) {
+	runner, _ := initRunnerTest(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := runner.Run(ctx, &Scenario{Name: "cancelled", Steps: []Step{{SleepMs: 1000}}})
+
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Failure, "context canceled")
+}
@@ -0,0 +1,279 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+// Package scenario runs multi-step scenarios against the RMR mesh: messages are sent from the command templates of
+// the configuration, expected back within a timeout and matched on Meid and transaction id, and the scenario branches
+// on what the received messages carry. Results are reported per scenario, and as JUnit XML for CI.
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"xappmock/models"
+	"xappmock/rmr"
+)
+
+const (
+	// SentValue, as the Meid or the transaction id of an expect step, stands for the one of the last message sent
+	SentValue = "$sent"
+	// End, as the goto of a branch, ends the scenario successfully
+	End = "$end"
+)
+
+// Scenario is a list of steps run in order, unless a branch jumps to another step
+type Scenario struct {
+	Name  string
+	Steps []Step
+}
+
+// Step does one of send, expect or sleep
+type Step struct {
+	Name string
+	// Send is a command of the configuration, by Id, whose fields are overridden by the non empty ones here
+	Send *models.JsonCommand
+	// Expect waits for a message
+	Expect *Expect
+	// SleepMs waits without receiving
+	SleepMs int
+	// Branches are evaluated in order on the message an expect step received. The first one matching is taken
+	Branches []Branch
+}
+
+type Expect struct {
+	// RmrMessageType is the type of the message to wait for, or a comma separated list of types to accept any of
+	RmrMessageType string
+	Meid           string
+	TransactionId  string
+	TimeoutMs      int
+}
+
+// Branch conditions must all hold for the branch to be taken. A branch without conditions is always taken
+type Branch struct {
+	RmrMessageType string
+	// Field is a dot separated path into the JSON part of the payload, e.g. "nodebInfo.connectionStatus"
+	Field string
+	// Equals is compared with the value of Field
+	Equals string
+	// Contains is looked for in the value of Field, or in the whole payload without Field
+	Contains string
+	// Goto is the name of the step to continue with, or End
+	Goto string
+	// Fail fails the scenario with this message when the branch is taken
+	Fail string
+}
+
+// Load reads the scenario of a JSON file, or of every JSON file of a directory in the order of their names.
+// A scenario without a name is named after its file
+func Load(path string) ([]*Scenario, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, errors.New(err.Error())
+		}
+		sort.Strings(files)
+	}
+
+	scenarios := []*Scenario{}
+	for _, file := range files {
+		scenario, err := loadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return scenarios, nil
+}
+
+func loadFile(file string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	scenario := &Scenario{}
+	err = json.Unmarshal(data, scenario)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("scenario %s: %s", file, err))
+	}
+
+	if len(scenario.Name) == 0 {
+		scenario.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	err = scenario.Validate()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("scenario %s: %s", file, err))
+	}
+	return scenario, nil
+}
+
+// Validate names the unnamed steps after their position and checks every step can run
+func (s *Scenario) Validate() error {
+	if len(s.Steps) == 0 {
+		return errors.New("no steps")
+	}
+
+	names := make(map[string]bool)
+	for i := range s.Steps {
+		step := &s.Steps[i]
+		if len(step.Name) == 0 {
+			step.Name = fmt.Sprintf("step %d", i+1)
+		}
+		if names[step.Name] {
+			return errors.New(fmt.Sprintf("duplicate step name %s", step.Name))
+		}
+		names[step.Name] = true
+	}
+
+	for _, step := range s.Steps {
+		err := step.validate(names)
+		if err != nil {
+			return errors.New(fmt.Sprintf("%s: %s", step.Name, err))
+		}
+	}
+	return nil
+}
+
+func (s Step) validate(names map[string]bool) error {
+	actions := 0
+	if s.Send != nil {
+		actions++
+		if len(s.Send.Id) == 0 {
+			return errors.New("send without id")
+		}
+	}
+	if s.Expect != nil {
+		actions++
+		if _, err := parseMessageTypes(s.Expect.RmrMessageType); err != nil {
+			return err
+		}
+		if s.Expect.TimeoutMs <= 0 {
+			return errors.New("expect without timeoutMs")
+		}
+	}
+	if s.SleepMs > 0 {
+		actions++
+	}
+	if actions != 1 {
+		return errors.New("a step does exactly one of send, expect or sleepMs")
+	}
+
+	if len(s.Branches) > 0 && s.Expect == nil {
+		return errors.New("branches without expect")
+	}
+	for i, branch := range s.Branches {
+		if len(branch.Goto) == 0 && len(branch.Fail) == 0 {
+			return errors.New(fmt.Sprintf("branch %d has neither goto nor fail", i+1))
+		}
+		if len(branch.Goto) > 0 && branch.Goto != End && !names[branch.Goto] {
+			return errors.New(fmt.Sprintf("branch %d goes to unknown step %s", i+1, branch.Goto))
+		}
+		if len(branch.RmrMessageType) > 0 {
+			if _, err := rmr.MessageIdToUint(branch.RmrMessageType); err != nil {
+				return errors.New(fmt.Sprintf("branch %d: invalid rmr message type %s", i+1, branch.RmrMessageType))
+			}
+		}
+	}
+	return nil
+}
+
+func parseMessageTypes(messageTypes string) (map[int]bool, error) {
+	types := make(map[int]bool)
+	for _, messageType := range strings.Split(messageTypes, ",") {
+		messageType = strings.TrimSpace(messageType)
+		id, err := rmr.MessageIdToUint(messageType)
+		if err != nil || len(messageType) == 0 {
+			return nil, errors.New(fmt.Sprintf("invalid rmr message type %s", messageType))
+		}
+		types[int(id)] = true
+	}
+	return types, nil
+}
+
+// matches tells whether the branch is taken for a message of type mType carrying payload
+func (b Branch) matches(mType int, payload []byte) bool {
+	if len(b.RmrMessageType) > 0 {
+		id, _ := rmr.MessageIdToUint(b.RmrMessageType)
+		if int(id) != mType {
+			return false
+		}
+	}
+
+	if len(b.Field) == 0 {
+		return strings.Contains(string(payload), b.Contains)
+	}
+
+	value, ok := payloadField(payload, b.Field)
+	if !ok {
+		return false
+	}
+	if len(b.Equals) > 0 && value != b.Equals {
+		return false
+	}
+	return strings.Contains(value, b.Contains)
+}
+
+// payloadField returns the value at path of the JSON object of payload, which may follow a header such as the
+// one of the setup messages
+func payloadField(payload []byte, path string) (string, bool) {
+	start := strings.IndexByte(string(payload), '{')
+	if start < 0 {
+		return "", false
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(payload[start:])))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		value, ok = object[key]
+		if !ok {
+			return "", false
+		}
+	}
+
+	if text, ok := value.(string); ok {
+		return text, true
+	}
+	text, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	return string(text), true
+}
@@ -0,0 +1,111 @@
+//
+// Copyright 2019 AT&T Intellectual Property
+// Copyright 2019 Nokia
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package scenario
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"xappmock/models"
+)
+
+func TestLoadExample(t *testing.T) {
+	scenarios, err := Load("../resources/scenarios")
+
+	assert.Nil(t, err)
+	assert.Len(t, scenarios, 1)
+	assert.Equal(t, "x2 setup", scenarios[0].Name)
+	assert.Equal(t, "RanX", scenarios[0].Steps[0].Send.RanName)
+	assert.Equal(t, 5577, scenarios[0].Steps[0].Send.RanPort)
+	assert.Equal(t, SentValue, scenarios[0].Steps[1].Expect.Meid)
+	assert.Equal(t, End, scenarios[0].Steps[1].Branches[0].Goto)
+}
+
+func TestLoadNotFound(t *testing.T) {
+	_, err := Load("no/such/scenario.json")
+
+	assert.NotNil(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	send := &models.JsonCommand{Id: "X2_SETUP_REQUEST"}
+	expect := &Expect{RmrMessageType: "10061", TimeoutMs: 100}
+
+	tests := []struct {
+		description string
+		steps       []Step
+		valid       bool
+	}{
+		{"valid", []Step{{Send: send}, {Expect: expect, Branches: []Branch{{Goto: "step 1"}, {Fail: "failed"}}}}, true},
+		{"no steps", nil, false},
+		{"no action", []Step{{Name: "nothing"}}, false},
+		{"two actions", []Step{{Send: send, SleepMs: 10}}, false},
+		{"send without id", []Step{{Send: &models.JsonCommand{}}}, false},
+		{"expect without timeout", []Step{{Expect: &Expect{RmrMessageType: "10061"}}}, false},
+		{"expect invalid type", []Step{{Expect: &Expect{RmrMessageType: "10061,x", TimeoutMs: 100}}}, false},
+		{"branches on send", []Step{{Send: send, Branches: []Branch{{Goto: End}}}}, false},
+		{"branch to unknown step", []Step{{Expect: expect, Branches: []Branch{{Goto: "nowhere"}}}}, false},
+		{"branch without goto or fail", []Step{{Expect: expect, Branches: []Branch{{Contains: "x"}}}}, false},
+		{"duplicate names", []Step{{Name: "a", Send: send}, {Name: "a", Send: send}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			scenario := &Scenario{Name: test.description, Steps: test.steps}
+			err := scenario.Validate()
+			assert.Equal(t, test.valid, err == nil, "error: %v", err)
+		})
+	}
+}
+
+func TestBranchMatches(t *testing.T) {
+	payload := []byte(`10.0.2.15|5577|RanX|{"nodebInfo": {"connectionStatus": "CONNECTED", "port": 5577}}`)
+
+	tests := []struct {
+		description string
+		branch      Branch
+		matches     bool
+	}{
+		{"always", Branch{Goto: End}, true},
+		{"type", Branch{RmrMessageType: "1200"}, true},
+		{"other type", Branch{RmrMessageType: "1210"}, false},
+		{"payload contains", Branch{Contains: "RanX"}, true},
+		{"payload lacks", Branch{Contains: "RanY"}, false},
+		{"field equals", Branch{Field: "nodebInfo.connectionStatus", Equals: "CONNECTED"}, true},
+		{"field differs", Branch{Field: "nodebInfo.connectionStatus", Equals: "DISCONNECTED"}, false},
+		{"number field", Branch{Field: "nodebInfo.port", Equals: "5577"}, true},
+		{"field contains", Branch{Field: "nodebInfo.connectionStatus", Contains: "CONN"}, true},
+		{"field exists", Branch{Field: "nodebInfo"}, true},
+		{"missing field", Branch{Field: "nodebInfo.ranName"}, false},
+		{"type and field", Branch{RmrMessageType: "1210", Field: "nodebInfo"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			assert.Equal(t, test.matches, test.branch.matches(1200, payload))
+		})
+	}
+}
+
+func TestBranchFieldWithoutJson(t *testing.T) {
+	branch := Branch{Field: "cause"}
+
+	assert.False(t, branch.matches(10062, []byte{0x40, 0x06, 0x00}))
+}
@@ -50,12 +50,14 @@ func NewJsonSender(logger *logger.Logger) *JsonSender {
 
 func (s *JsonSender) SendJsonRmrMessage(command models.JsonCommand /*the copy is modified locally*/, xAction *[]byte, r *rmr.Service) error {
 	var payload []byte
-	_, err := fmt.Sscanf(command.PackedPayload, "%x", &payload)
-	if err != nil {
-		return errors.New(fmt.Sprintf("convert inputPayloadAsStr to payloadAsByte. Error: %v\n", err))
+	if len(command.PackedPayload) > 0 {
+		_, err := fmt.Sscanf(command.PackedPayload, "%x", &payload)
+		if err != nil {
+			return errors.New(fmt.Sprintf("convert inputPayloadAsStr to payloadAsByte. Error: %v\n", err))
+		}
 	}
 	command.PackedPayload = string(payload)
-	command.TransactionId = expandTransactionId(command.TransactionId)
+	command.TransactionId = ExpandTransactionId(command.TransactionId)
 	if len(command.TransactionId) == 0 {
 		command.TransactionId = string(*xAction)
 	}
@@ -79,7 +81,7 @@ func (s *JsonSender) SendJsonRmrMessage(command models.JsonCommand /*the copy is
  * $ is replaced by a value generated at runtime (possibly unique per message sent).
  * If the tag does not exist, then the mock shall use the value taken from the incoming message.
  */
-func expandTransactionId(id string) string {
+func ExpandTransactionId(id string) string {
 	if len(id) == 1 && id[0] == '$' {
 		return fmt.Sprintf("%d", incAndGetCounter())
 	}